@@ -304,6 +304,7 @@ func stateServingInfo() params.StateServingInfo {
 		APIPort:        47,
 		SharedSecret:   "shared",
 		SystemIdentity: "identity",
+		SecretsKey:     "secrets key",
 	}
 }
 
@@ -415,6 +416,7 @@ func (*suite) TestStateServingInfo(c *gc.C) {
 		CAPrivateKey:   "new ca key",
 		SharedSecret:   "new shared",
 		SystemIdentity: "new identity",
+		SecretsKey:     "new secrets key",
 	}
 	conf.SetStateServingInfo(newInfo)
 	gotInfo, ok = conf.StateServingInfo()
@@ -92,6 +92,10 @@ func InitializeState(
 	if err := initMongoAdminUser(info.Info, dialOpts, info.Password); err != nil {
 		return nil, nil, errors.Annotate(err, "failed to initialize mongo admin user")
 	}
+	secretsKey, err := agent.SecretsKey(c)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
 
 	cloudCredentials := make(map[names.CloudCredentialTag]cloud.Credential)
 	var cloudCredentialTag names.CloudCredentialTag
@@ -125,6 +129,7 @@ func InitializeState(
 		MongoInfo:                 info,
 		MongoDialOpts:             dialOpts,
 		NewPolicy:                 newPolicy,
+		SecretsKey:                secretsKey,
 	})
 	if err != nil {
 		return nil, nil, errors.Errorf("failed to initialize state: %v", err)
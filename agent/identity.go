@@ -4,6 +4,7 @@
 package agent
 
 import (
+	"encoding/base64"
 	"os"
 
 	"github.com/juju/errors"
@@ -30,3 +31,18 @@ func WriteSystemIdentityFile(c Config) error {
 	}
 	return nil
 }
+
+// SecretsKey returns the key used to encrypt the values of secrets,
+// decoded from the state serving info. It returns nil if the agent
+// is not a controller or no key has been generated.
+func SecretsKey(c Config) ([]byte, error) {
+	info, ok := c.StateServingInfo()
+	if !ok || info.SecretsKey == "" {
+		return nil, nil
+	}
+	key, err := base64.StdEncoding.DecodeString(info.SecretsKey)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot decode secrets key")
+	}
+	return key, nil
+}
@@ -83,13 +83,54 @@ func (c *Client) ListCompleted(arg params.Entities) (params.ActionsByReceivers,
 	return results, err
 }
 
-// Cancel attempts to cancel a queued up Action from running.
-func (c *Client) Cancel(arg params.Actions) (params.ActionResults, error) {
+// Cancel attempts to cancel the Actions with the given tags. Queued
+// Actions are removed from the queue, and running Actions are stopped.
+func (c *Client) Cancel(arg params.Entities) (params.ActionResults, error) {
 	results := params.ActionResults{}
 	err := c.facade.FacadeCall("Cancel", arg, &results)
 	return results, err
 }
 
+// AddSchedules adds schedules on which Actions are enqueued.
+func (c *Client) AddSchedules(arg params.ActionSchedules) (params.ErrorResults, error) {
+	if c.BestAPIVersion() < 3 {
+		return params.ErrorResults{}, errors.NotSupportedf("action schedules by this controller")
+	}
+	results := params.ErrorResults{}
+	err := c.facade.FacadeCall("AddSchedules", arg, &results)
+	return results, err
+}
+
+// ListSchedules returns all of the action schedules in the model.
+func (c *Client) ListSchedules() (params.ActionSchedules, error) {
+	if c.BestAPIVersion() < 3 {
+		return params.ActionSchedules{}, errors.NotSupportedf("action schedules by this controller")
+	}
+	results := params.ActionSchedules{}
+	err := c.facade.FacadeCall("ListSchedules", nil, &results)
+	return results, err
+}
+
+// SetSchedulesEnabled enables or disables action schedules.
+func (c *Client) SetSchedulesEnabled(arg params.ActionSchedulesEnabled) (params.ErrorResults, error) {
+	if c.BestAPIVersion() < 3 {
+		return params.ErrorResults{}, errors.NotSupportedf("action schedules by this controller")
+	}
+	results := params.ErrorResults{}
+	err := c.facade.FacadeCall("SetSchedulesEnabled", arg, &results)
+	return results, err
+}
+
+// RemoveSchedules removes action schedules.
+func (c *Client) RemoveSchedules(arg params.ActionScheduleNames) (params.ErrorResults, error) {
+	if c.BestAPIVersion() < 3 {
+		return params.ErrorResults{}, errors.NotSupportedf("action schedules by this controller")
+	}
+	results := params.ErrorResults{}
+	err := c.facade.FacadeCall("RemoveSchedules", arg, &results)
+	return results, err
+}
+
 // applicationsCharmActions is a batched query for the charm.Actions for a slice
 // of services by Entity.
 func (c *Client) applicationsCharmActions(arg params.Entities) (params.ApplicationsCharmActionsResults, error) {
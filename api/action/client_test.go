@@ -6,11 +6,13 @@ package action_test
 import (
 	"errors"
 
+	jujuerrors "github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/action"
+	basetesting "github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/apiserver/params"
 )
 
@@ -109,6 +111,23 @@ func (s *actionSuite) TestApplicationCharmActions(c *gc.C) {
 	}
 }
 
+func (s *actionSuite) TestSchedulesNotSupported(c *gc.C) {
+	client := action.NewClient(basetesting.APICallerFunc(
+		func(_ string, _ int, _, _ string, _, _ interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+	))
+	_, err := client.AddSchedules(params.ActionSchedules{})
+	c.Check(err, gc.ErrorMatches, "action schedules by this controller not supported")
+	_, err = client.ListSchedules()
+	c.Check(err, jc.Satisfies, jujuerrors.IsNotSupported)
+	_, err = client.SetSchedulesEnabled(params.ActionSchedulesEnabled{})
+	c.Check(err, jc.Satisfies, jujuerrors.IsNotSupported)
+	_, err = client.RemoveSchedules(params.ActionScheduleNames{})
+	c.Check(err, jc.Satisfies, jujuerrors.IsNotSupported)
+}
+
 // replace sCharmActions" facade call with required results and error
 // if desired
 func patchApplicationCharmActions(c *gc.C, apiCli *action.Client, patchResults []params.ApplicationCharmActionsResult, err string) func() {
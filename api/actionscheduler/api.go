@@ -0,0 +1,76 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/watcher"
+)
+
+// NewWatcherFunc exists to let us test Watch properly.
+type NewWatcherFunc func(base.APICaller, params.NotifyWatchResult) watcher.NotifyWatcher
+
+// API makes calls to the ActionScheduler facade.
+type API struct {
+	caller     base.FacadeCaller
+	newWatcher NewWatcherFunc
+}
+
+// NewAPI returns a new API using the supplied caller.
+func NewAPI(caller base.APICaller, newWatcher NewWatcherFunc) *API {
+	return &API{
+		caller:     base.NewFacadeCaller(caller, "ActionScheduler"),
+		newWatcher: newWatcher,
+	}
+}
+
+// Watch returns a NotifyWatcher that notifies when action schedules
+// are added, changed or removed.
+func (api *API) Watch() (watcher.NotifyWatcher, error) {
+	var result params.NotifyWatchResult
+	err := api.caller.FacadeCall("Watch", nil, &result)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	w := api.newWatcher(api.caller.RawAPICaller(), result)
+	return w, nil
+}
+
+// Schedules returns all action schedules in the model.
+func (api *API) Schedules() ([]params.ActionSchedule, error) {
+	var result params.ActionSchedules
+	err := api.caller.FacadeCall("Schedules", nil, &result)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Schedules, nil
+}
+
+// Run requests that the named action schedules be run. It returns an
+// error for each schedule, which is nil if the schedule's actions
+// were enqueued.
+func (api *API) Run(names []string) ([]error, error) {
+	var results params.ErrorResults
+	args := params.ActionScheduleNames{Names: names}
+	err := api.caller.FacadeCall("Run", args, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(names) {
+		return nil, errors.Errorf("expected %d results, got %d", len(names), len(results.Results))
+	}
+	errs := make([]error, len(names))
+	for i, result := range results.Results {
+		if result.Error != nil {
+			errs[i] = result.Error
+		}
+	}
+	return errs, nil
+}
@@ -0,0 +1,121 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/actionscheduler"
+	"github.com/juju/juju/api/base"
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/watcher"
+)
+
+type APISuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&APISuite{})
+
+func (s *APISuite) TestWatch(c *gc.C) {
+	caller := apiCaller(c, func(request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "Watch")
+		c.Check(arg, gc.IsNil)
+		c.Assert(result, gc.FitsTypeOf, &params.NotifyWatchResult{})
+		*(result.(*params.NotifyWatchResult)) = params.NotifyWatchResult{
+			NotifyWatcherId: "123",
+		}
+		return nil
+	})
+	expectWatcher := &stubWatcher{}
+	newWatcher := func(apiCaller base.APICaller, result params.NotifyWatchResult) watcher.NotifyWatcher {
+		c.Check(apiCaller, gc.NotNil)
+		c.Check(result, jc.DeepEquals, params.NotifyWatchResult{NotifyWatcherId: "123"})
+		return expectWatcher
+	}
+	api := actionscheduler.NewAPI(caller, newWatcher)
+
+	w, err := api.Watch()
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(w, gc.Equals, expectWatcher)
+}
+
+func (s *APISuite) TestWatchError(c *gc.C) {
+	caller := apiCaller(c, func(_ string, _, result interface{}) error {
+		*(result.(*params.NotifyWatchResult)) = params.NotifyWatchResult{
+			Error: &params.Error{Message: "blammo"},
+		}
+		return nil
+	})
+	api := actionscheduler.NewAPI(caller, nil)
+
+	w, err := api.Watch()
+	c.Check(err, gc.ErrorMatches, "blammo")
+	c.Check(w, gc.IsNil)
+}
+
+func (s *APISuite) TestSchedules(c *gc.C) {
+	schedules := []params.ActionSchedule{{
+		Name:       "nightly-backup",
+		Receiver:   "application-mysql",
+		ActionName: "backup",
+		Schedule:   "@daily",
+	}}
+	caller := apiCaller(c, func(request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "Schedules")
+		c.Check(arg, gc.IsNil)
+		*(result.(*params.ActionSchedules)) = params.ActionSchedules{Schedules: schedules}
+		return nil
+	})
+	api := actionscheduler.NewAPI(caller, nil)
+
+	result, err := api.Schedules()
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, schedules)
+}
+
+func (s *APISuite) TestRun(c *gc.C) {
+	caller := apiCaller(c, func(request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "Run")
+		c.Check(arg, jc.DeepEquals, params.ActionScheduleNames{Names: []string{"a", "b"}})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{Results: []params.ErrorResult{
+			{}, {Error: &params.Error{Message: "blammo"}},
+		}}
+		return nil
+	})
+	api := actionscheduler.NewAPI(caller, nil)
+
+	errs, err := api.Run([]string{"a", "b"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, gc.HasLen, 2)
+	c.Check(errs[0], jc.ErrorIsNil)
+	c.Check(errs[1], gc.ErrorMatches, "blammo")
+}
+
+func (s *APISuite) TestRunCallError(c *gc.C) {
+	caller := apiCaller(c, func(_ string, _, _ interface{}) error {
+		return errors.New("blammo")
+	})
+	api := actionscheduler.NewAPI(caller, nil)
+
+	_, err := api.Run([]string{"a"})
+	c.Check(err, gc.ErrorMatches, "blammo")
+}
+
+func apiCaller(c *gc.C, check func(request string, arg, result interface{}) error) base.APICaller {
+	return apitesting.APICallerFunc(func(facade string, version int, id, request string, arg, result interface{}) error {
+		c.Check(facade, gc.Equals, "ActionScheduler")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		return check(request, arg, result)
+	})
+}
+
+type stubWatcher struct {
+	watcher.NotifyWatcher
+}
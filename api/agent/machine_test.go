@@ -4,6 +4,7 @@
 package agent_test
 
 import (
+	"encoding/base64"
 	"fmt"
 	stdtesting "testing"
 
@@ -53,6 +54,7 @@ func (s *servingInfoSuite) TestStateServingInfo(c *gc.C) {
 		SharedSecret: ssi.SharedSecret,
 		APIPort:      ssi.APIPort,
 		StatePort:    ssi.StatePort,
+		SecretsKey:   base64.StdEncoding.EncodeToString(coretesting.SecretsKey),
 	}
 	err := s.State.SetStateServingInfo(ssi)
 	c.Assert(err, jc.ErrorIsNil)
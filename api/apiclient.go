@@ -742,7 +742,15 @@ func (s *state) APICall(facade string, version int, id, method string, args, res
 			if !ok {
 				return true
 			}
-			return ec.ErrorCode() != params.CodeRetry
+			switch ec.ErrorCode() {
+			case params.CodeRetry,
+				params.CodeRateLimitExceeded,
+				params.CodeConnectionLimitExceeded:
+				// The server is asking us to back off
+				// and try again later.
+				return false
+			}
+			return true
 		},
 		Delay:       100 * time.Millisecond,
 		MaxDelay:    1500 * time.Millisecond,
@@ -373,6 +373,30 @@ func (s *apiclientSuite) TestAPICallRetries(c *gc.C) {
 	c.Check(clock.waits, jc.DeepEquals, []time.Duration{100 * time.Millisecond})
 }
 
+func (s *apiclientSuite) TestAPICallRetriesWhenLimited(c *gc.C) {
+	for _, code := range []string{
+		params.CodeRateLimitExceeded,
+		params.CodeConnectionLimitExceeded,
+	} {
+		c.Logf("code %q", code)
+		clock := &fakeClock{}
+		conn := api.NewTestingState(api.TestingStateParams{
+			RPCConnection: newRPCConnection(
+				errors.Trace(&rpc.RequestError{Message: "slow down", Code: code}),
+				errors.Trace(&rpc.RequestError{Message: "slow down", Code: code}),
+			),
+			Clock: clock,
+		})
+
+		err := conn.APICall("facade", 1, "id", "method", nil, nil)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(clock.waits, jc.DeepEquals, []time.Duration{
+			100 * time.Millisecond,
+			200 * time.Millisecond,
+		})
+	}
+}
+
 func (s *apiclientSuite) TestAPICallRetriesLimit(c *gc.C) {
 	clock := &fakeClock{}
 	retryError := errors.Trace(&rpc.RequestError{Message: "hmm...", Code: params.CodeRetry})
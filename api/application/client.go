@@ -18,6 +18,7 @@ import (
 	"github.com/juju/juju/charmstore"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/storage"
 )
 
@@ -89,6 +90,11 @@ type DeployArgs struct {
 	// handled.
 	Storage map[string]storage.Constraints
 
+	// AttachStorage contains IDs of existing storage that should be
+	// attached to the application unit that will be deployed. This
+	// may be non-empty only if NumUnits is 1.
+	AttachStorage []string
+
 	// EndpointBindings
 	EndpointBindings map[string]string
 
@@ -102,6 +108,21 @@ type DeployArgs struct {
 // it. Placement directives, if provided, specify the machine on which the charm
 // is deployed.
 func (c *Client) Deploy(args DeployArgs) error {
+	if len(args.AttachStorage) > 0 {
+		if args.NumUnits != 1 {
+			return errors.New("cannot attach existing storage when more than one unit is requested")
+		}
+		if c.BestAPIVersion() < 7 {
+			return errors.NotSupportedf("attaching existing storage by this controller")
+		}
+	}
+	var attachStorage []string
+	for _, id := range args.AttachStorage {
+		if !names.IsValidStorage(id) {
+			return errors.NotValidf("storage ID %q", id)
+		}
+		attachStorage = append(attachStorage, names.NewStorageTag(id).String())
+	}
 	deployArgs := params.ApplicationsDeploy{
 		Applications: []params.ApplicationDeploy{{
 			ApplicationName:  args.ApplicationName,
@@ -113,6 +134,7 @@ func (c *Client) Deploy(args DeployArgs) error {
 			Constraints:      args.Cons,
 			Placement:        args.Placement,
 			Storage:          args.Storage,
+			AttachStorage:    attachStorage,
 			EndpointBindings: args.EndpointBindings,
 			Resources:        args.Resources,
 		}},
@@ -352,6 +374,21 @@ func (c *Client) Expose(application string) error {
 	return c.facade.FacadeCall("Expose", params, nil)
 }
 
+// ExposeEndpoints exposes the named application, limiting access to
+// its open ports to the spaces and CIDRs in the given expose settings.
+// The settings are keyed on endpoint name, and merged with any existing
+// settings; the empty endpoint name applies to all endpoints.
+func (c *Client) ExposeEndpoints(application string, exposed map[string]params.ExposedEndpoint) error {
+	if c.BestAPIVersion() < 5 {
+		return errors.NotSupportedf("exposing endpoints by this controller")
+	}
+	params := params.ApplicationExpose{
+		ApplicationName:  application,
+		ExposedEndpoints: exposed,
+	}
+	return c.facade.FacadeCall("Expose", params, nil)
+}
+
 // Unexpose changes the juju-managed firewall to unexpose any ports that
 // were also explicitly marked by units as open.
 func (c *Client) Unexpose(application string) error {
@@ -359,6 +396,60 @@ func (c *Client) Unexpose(application string) error {
 	return c.facade.FacadeCall("Unexpose", params, nil)
 }
 
+// UnexposeEndpoints removes the expose settings for the named endpoints
+// of the application. The application is unexposed if no expose settings
+// remain.
+func (c *Client) UnexposeEndpoints(application string, endpoints []string) error {
+	if c.BestAPIVersion() < 5 {
+		return errors.NotSupportedf("unexposing endpoints by this controller")
+	}
+	params := params.ApplicationUnexpose{
+		ApplicationName:  application,
+		ExposedEndpoints: endpoints,
+	}
+	return c.facade.FacadeCall("Unexpose", params, nil)
+}
+
+// SetTrust grants or revokes the named application's access to the
+// cloud credential of the model.
+func (c *Client) SetTrust(application string, trust bool) error {
+	if c.BestAPIVersion() < 8 {
+		return errors.NotSupportedf("trusting applications by this controller")
+	}
+	params := params.ApplicationSetTrust{
+		ApplicationName: application,
+		Trust:           trust,
+	}
+	return c.facade.FacadeCall("SetTrust", params, nil)
+}
+
+// SetEgressRules replaces the egress rules set by operators for the
+// named application. Passing no rules removes them.
+func (c *Client) SetEgressRules(application string, rules []network.EgressRule) error {
+	if c.BestAPIVersion() < 6 {
+		return errors.NotSupportedf("setting egress rules by this controller")
+	}
+	params := params.ApplicationSetEgressRules{
+		ApplicationName: application,
+		Rules:           params.FromNetworkEgressRules(rules),
+	}
+	return c.facade.FacadeCall("SetEgressRules", params, nil)
+}
+
+// GetEgressRules returns the egress rules set for the named application
+// by operators and by its charm, in that order.
+func (c *Client) GetEgressRules(application string) ([]network.EgressRule, []network.EgressRule, error) {
+	if c.BestAPIVersion() < 6 {
+		return nil, nil, errors.NotSupportedf("getting egress rules by this controller")
+	}
+	var result params.ApplicationEgressRulesResult
+	args := params.ApplicationGet{ApplicationName: application}
+	if err := c.facade.FacadeCall("GetEgressRules", args, &result); err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return params.NetworkEgressRules(result.Rules), params.NetworkEgressRules(result.CharmRules), nil
+}
+
 // Get returns the configuration for the named application.
 func (c *Client) Get(application string) (*params.ApplicationGetResults, error) {
 	var results params.ApplicationGetResults
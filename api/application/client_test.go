@@ -16,6 +16,7 @@ import (
 	"github.com/juju/juju/charmstore"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/storage"
 )
 
@@ -111,6 +112,79 @@ func (s *applicationSuite) TestSetServiceDeploy(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *applicationSuite) TestDeployAttachStorage(c *gc.C) {
+	var called bool
+	client := application.NewClient(versionedCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			called = true
+			c.Assert(request, gc.Equals, "Deploy")
+			args, ok := a.(params.ApplicationsDeploy)
+			c.Assert(ok, jc.IsTrue)
+			c.Assert(args.Applications, gc.HasLen, 1)
+			c.Assert(args.Applications[0].AttachStorage, jc.DeepEquals, []string{"storage-data-0"})
+
+			result := response.(*params.ErrorResults)
+			result.Results = make([]params.ErrorResult, 1)
+			return nil
+		},
+		version: 7,
+	})
+
+	args := application.DeployArgs{
+		CharmID: charmstore.CharmID{
+			URL: charm.MustParseURL("trusty/a-charm-1"),
+		},
+		ApplicationName: "serviceA",
+		Series:          "series",
+		NumUnits:        1,
+		AttachStorage:   []string{"data/0"},
+	}
+	err := client.Deploy(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestDeployAttachStorageNotSupported(c *gc.C) {
+	client := application.NewClient(versionedCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+		version: 6,
+	})
+	args := application.DeployArgs{
+		CharmID: charmstore.CharmID{
+			URL: charm.MustParseURL("trusty/a-charm-1"),
+		},
+		ApplicationName: "serviceA",
+		Series:          "series",
+		NumUnits:        1,
+		AttachStorage:   []string{"data/0"},
+	}
+	err := client.Deploy(args)
+	c.Assert(err, gc.ErrorMatches, "attaching existing storage by this controller not supported")
+}
+
+func (s *applicationSuite) TestDeployAttachStorageMultipleUnits(c *gc.C) {
+	var called bool
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		called = true
+		return nil
+	})
+	args := application.DeployArgs{
+		CharmID: charmstore.CharmID{
+			URL: charm.MustParseURL("trusty/a-charm-1"),
+		},
+		ApplicationName: "serviceA",
+		Series:          "series",
+		NumUnits:        2,
+		AttachStorage:   []string{"data/0"},
+	}
+	err := client.Deploy(args)
+	c.Assert(err, gc.ErrorMatches, "cannot attach existing storage when more than one unit is requested")
+	c.Assert(called, jc.IsFalse)
+}
+
 func (s *applicationSuite) TestServiceGetCharmURL(c *gc.C) {
 	var called bool
 	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
@@ -200,6 +274,37 @@ func (s *applicationSuite) TestConsume(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *applicationSuite) TestSetTrust(c *gc.C) {
+	var called bool
+	client := application.NewClient(versionedCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			called = true
+			c.Assert(request, gc.Equals, "SetTrust")
+			c.Assert(a, jc.DeepEquals, params.ApplicationSetTrust{
+				ApplicationName: "foo",
+				Trust:           true,
+			})
+			return nil
+		},
+		version: 8,
+	})
+	err := client.SetTrust("foo", true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestSetTrustNotSupported(c *gc.C) {
+	client := application.NewClient(versionedCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+		version: 7,
+	})
+	err := client.SetTrust("foo", true)
+	c.Assert(err, gc.ErrorMatches, "trusting applications by this controller not supported")
+}
+
 func (s *applicationSuite) TestDestroyDeprecated(c *gc.C) {
 	var called bool
 	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
@@ -332,3 +437,141 @@ func (s *applicationSuite) TestDestroyUnitsInvalidIds(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results, jc.DeepEquals, expectedResults)
 }
+
+type versionedCaller struct {
+	basetesting.APICallerFunc
+	version int
+}
+
+func (c versionedCaller) BestFacadeVersion(string) int {
+	return c.version
+}
+
+func (s *applicationSuite) TestExposeEndpoints(c *gc.C) {
+	var called bool
+	client := application.NewClient(versionedCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			called = true
+			c.Check(objType, gc.Equals, "Application")
+			c.Check(request, gc.Equals, "Expose")
+			c.Check(a, jc.DeepEquals, params.ApplicationExpose{
+				ApplicationName: "mysql",
+				ExposedEndpoints: map[string]params.ExposedEndpoint{
+					"server": {ExposeToSpaces: []string{"dmz"}},
+				},
+			})
+			return nil
+		},
+		version: 5,
+	})
+	err := client.ExposeEndpoints("mysql", map[string]params.ExposedEndpoint{
+		"server": {ExposeToSpaces: []string{"dmz"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestExposeEndpointsNotSupported(c *gc.C) {
+	client := application.NewClient(versionedCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+		version: 4,
+	})
+	err := client.ExposeEndpoints("mysql", map[string]params.ExposedEndpoint{"": {}})
+	c.Assert(err, gc.ErrorMatches, "exposing endpoints by this controller not supported")
+	err = client.UnexposeEndpoints("mysql", []string{"server"})
+	c.Assert(err, gc.ErrorMatches, "unexposing endpoints by this controller not supported")
+}
+
+func (s *applicationSuite) TestUnexposeEndpoints(c *gc.C) {
+	var called bool
+	client := application.NewClient(versionedCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			called = true
+			c.Check(request, gc.Equals, "Unexpose")
+			c.Check(a, jc.DeepEquals, params.ApplicationUnexpose{
+				ApplicationName:  "mysql",
+				ExposedEndpoints: []string{"server"},
+			})
+			return nil
+		},
+		version: 5,
+	})
+	err := client.UnexposeEndpoints("mysql", []string{"server"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestSetEgressRules(c *gc.C) {
+	var called bool
+	client := application.NewClient(versionedCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			called = true
+			c.Check(objType, gc.Equals, "Application")
+			c.Check(request, gc.Equals, "SetEgressRules")
+			c.Check(a, jc.DeepEquals, params.ApplicationSetEgressRules{
+				ApplicationName: "mysql",
+				Rules: []params.EgressRule{{
+					PortRange:        params.PortRange{FromPort: 443, ToPort: 443, Protocol: "tcp"},
+					DestinationCIDRs: []string{"10.0.0.0/8"},
+				}},
+			})
+			return nil
+		},
+		version: 6,
+	})
+	err := client.SetEgressRules("mysql", []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestGetEgressRules(c *gc.C) {
+	var called bool
+	client := application.NewClient(versionedCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			called = true
+			c.Check(request, gc.Equals, "GetEgressRules")
+			c.Check(a, jc.DeepEquals, params.ApplicationGet{ApplicationName: "mysql"})
+			c.Assert(response, gc.FitsTypeOf, &params.ApplicationEgressRulesResult{})
+			*(response.(*params.ApplicationEgressRulesResult)) = params.ApplicationEgressRulesResult{
+				Rules: []params.EgressRule{{
+					PortRange:        params.PortRange{FromPort: 443, ToPort: 443, Protocol: "tcp"},
+					DestinationCIDRs: []string{"10.0.0.0/8"},
+				}},
+				CharmRules: []params.EgressRule{{
+					PortRange:        params.PortRange{FromPort: 53, ToPort: 53, Protocol: "udp"},
+					DestinationCIDRs: []string{"0.0.0.0/0"},
+				}},
+			}
+			return nil
+		},
+		version: 6,
+	})
+	rules, charmRules, err := client.GetEgressRules("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(rules, jc.DeepEquals, []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+	})
+	c.Assert(charmRules, jc.DeepEquals, []network.EgressRule{
+		network.MustNewEgressRule("udp", 53, 53, "0.0.0.0/0"),
+	})
+}
+
+func (s *applicationSuite) TestEgressRulesNotSupported(c *gc.C) {
+	client := application.NewClient(versionedCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+		version: 5,
+	})
+	err := client.SetEgressRules("mysql", nil)
+	c.Assert(err, gc.ErrorMatches, "setting egress rules by this controller not supported")
+	_, _, err = client.GetEgressRules("mysql")
+	c.Assert(err, gc.ErrorMatches, "getting egress rules by this controller not supported")
+}
@@ -0,0 +1,61 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/audit"
+)
+
+// Client provides access to the controller's audit log.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new Client based on an existing authenticated
+// API connection.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "AuditLog")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// Query returns the audit entries matching the query, oldest first.
+// The query's OriginName, if set, must be a user tag.
+func (c *Client) Query(query audit.Query) ([]audit.AuditEntry, error) {
+	args := params.AuditLogQuery{
+		UserTag:       query.OriginName,
+		ModelUUID:     query.ModelUUID,
+		Operation:     query.Operation,
+		RemoteAddress: query.RemoteAddress,
+		Limit:         query.Limit,
+	}
+	if !query.From.IsZero() {
+		args.From = &query.From
+	}
+	if !query.To.IsZero() {
+		args.To = &query.To
+	}
+	var result params.AuditLogEntries
+	if err := c.facade.FacadeCall("Query", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	entries := make([]audit.AuditEntry, len(result.Entries))
+	for i, entry := range result.Entries {
+		entries[i] = audit.AuditEntry{
+			JujuServerVersion: entry.JujuServerVersion,
+			ModelUUID:         entry.ModelUUID,
+			Timestamp:         entry.Timestamp.UTC(),
+			RemoteAddress:     entry.RemoteAddress,
+			OriginType:        entry.OriginType,
+			OriginName:        entry.OriginName,
+			Operation:         entry.Operation,
+			Data:              entry.Data,
+		}
+	}
+	return entries, nil
+}
@@ -0,0 +1,76 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"time"
+
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/auditlog"
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/audit"
+)
+
+type clientSuite struct {
+	gitjujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestQuery(c *gc.C) {
+	t0 := time.Date(2017, time.June, 1, 12, 0, 0, 0, time.UTC)
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			_ int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "AuditLog")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "Query")
+			c.Check(a, jc.DeepEquals, params.AuditLogQuery{
+				UserTag:   "user-bob",
+				Operation: "Application:",
+				From:      &t0,
+				Limit:     5,
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.AuditLogEntries{})
+			*(result.(*params.AuditLogEntries)) = params.AuditLogEntries{
+				Entries: []params.AuditLogEntry{{
+					JujuServerVersion: version.MustParse("2.2.0"),
+					ModelUUID:         "uuid",
+					Timestamp:         t0,
+					RemoteAddress:     "10.0.0.1:1234",
+					OriginType:        "API request",
+					OriginName:        "user-bob",
+					Operation:         "Application:v4 - Deploy",
+				}},
+			}
+			return nil
+		},
+	)
+
+	client := auditlog.NewClient(apiCaller)
+	entries, err := client.Query(audit.Query{
+		OriginName: "user-bob",
+		Operation:  "Application:",
+		From:       t0,
+		Limit:      5,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, jc.DeepEquals, []audit.AuditEntry{{
+		JujuServerVersion: version.MustParse("2.2.0"),
+		ModelUUID:         "uuid",
+		Timestamp:         t0,
+		RemoteAddress:     "10.0.0.1:1234",
+		OriginType:        "API request",
+		OriginName:        "user-bob",
+		Operation:         "Application:v4 - Deploy",
+	}})
+}
@@ -0,0 +1,60 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package bundle provides access to the bundle API facade.
+package bundle
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+const bundleFacade = "Bundle"
+
+// Client provides access to the bundle API facade.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new bundle client.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, bundleFacade)
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// ExportBundle returns the current model rendered as bundle YAML.
+func (c *Client) ExportBundle() (string, error) {
+	if c.BestAPIVersion() < 2 {
+		return "", errors.NotSupportedf("exporting bundles by this controller")
+	}
+	var result params.StringResult
+	if err := c.facade.FacadeCall("ExportBundle", nil, &result); err != nil {
+		return "", errors.Trace(err)
+	}
+	if result.Error != nil {
+		return "", errors.Trace(result.Error)
+	}
+	return result.Result, nil
+}
+
+// DiffBundle returns the differences between the given bundle YAML and
+// the current model.
+func (c *Client) DiffBundle(bundleYAML string) (*params.BundleDiff, error) {
+	if c.BestAPIVersion() < 2 {
+		return nil, errors.NotSupportedf("comparing bundles by this controller")
+	}
+	var result params.BundleDiffResults
+	args := params.BundleChangesParams{BundleDataYAML: bundleYAML}
+	if err := c.facade.FacadeCall("DiffBundle", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(result.Errors) > 0 {
+		return nil, errors.New("the provided bundle has the following errors:\n" + strings.Join(result.Errors, "\n"))
+	}
+	return result.Diff, nil
+}
@@ -0,0 +1,118 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bundle_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/bundle"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type bundleSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&bundleSuite{})
+
+// versionedCaller reports the given version of every facade.
+type versionedCaller struct {
+	basetesting.APICallerFunc
+	version int
+}
+
+func (c versionedCaller) BestFacadeVersion(string) int {
+	return c.version
+}
+
+func (s *bundleSuite) TestExportBundle(c *gc.C) {
+	caller := versionedCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "Bundle")
+			c.Check(version, gc.Equals, 2)
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "ExportBundle")
+			c.Check(arg, gc.IsNil)
+			c.Assert(result, gc.FitsTypeOf, &params.StringResult{})
+			*(result.(*params.StringResult)) = params.StringResult{
+				Result: "applications: {}\n",
+			}
+			return nil
+		},
+		version: 2,
+	}
+	data, err := bundle.NewClient(caller).ExportBundle()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(data, gc.Equals, "applications: {}\n")
+}
+
+func (s *bundleSuite) TestExportBundleError(c *gc.C) {
+	caller := versionedCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			*(result.(*params.StringResult)) = params.StringResult{
+				Error: &params.Error{Message: "cannot export bundle: boom"},
+			}
+			return nil
+		},
+		version: 2,
+	}
+	_, err := bundle.NewClient(caller).ExportBundle()
+	c.Assert(err, gc.ErrorMatches, "cannot export bundle: boom")
+}
+
+func (s *bundleSuite) TestExportBundleNotSupported(c *gc.C) {
+	caller := versionedCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+		version: 1,
+	}
+	_, err := bundle.NewClient(caller).ExportBundle()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *bundleSuite) TestDiffBundle(c *gc.C) {
+	caller := versionedCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "Bundle")
+			c.Check(request, gc.Equals, "DiffBundle")
+			c.Check(arg, jc.DeepEquals, params.BundleChangesParams{BundleDataYAML: "applications: {}\n"})
+			*(result.(*params.BundleDiffResults)) = params.BundleDiffResults{
+				Diff: &params.BundleDiff{
+					Applications: map[string]params.BundleApplicationDiff{
+						"mysql": {Missing: "bundle"},
+					},
+				},
+			}
+			return nil
+		},
+		version: 2,
+	}
+	diff, err := bundle.NewClient(caller).DiffBundle("applications: {}\n")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(diff, jc.DeepEquals, &params.BundleDiff{
+		Applications: map[string]params.BundleApplicationDiff{
+			"mysql": {Missing: "bundle"},
+		},
+	})
+}
+
+func (s *bundleSuite) TestDiffBundleVerificationErrors(c *gc.C) {
+	caller := versionedCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			*(result.(*params.BundleDiffResults)) = params.BundleDiffResults{
+				Errors: []string{"error one", "error two"},
+			}
+			return nil
+		},
+		version: 2,
+	}
+	_, err := bundle.NewClient(caller).DiffBundle("applications: {}\n")
+	c.Assert(err, gc.ErrorMatches, "the provided bundle has the following errors:\nerror one\nerror two")
+}
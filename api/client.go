@@ -58,6 +58,7 @@ func (c *Client) StatusHistory(kind status.HistoryKind, tag names.Tag, filter st
 		Filter: params.StatusHistoryFilter{
 			Size:    filter.Size,
 			Date:    filter.FromDate,
+			ToDate:  filter.ToDate,
 			Delta:   filter.Delta,
 			Exclude: filter.Exclude.Values(),
 		},
@@ -11,17 +11,19 @@ package api
 // New facades should start at 1.
 // Facades that existed before versioning start at 0.
 var facadeVersions = map[string]int{
-	"Action":                       2,
+	"Action":                       3,
+	"ActionScheduler":              1,
 	"Agent":                        2,
 	"AgentTools":                   1,
 	"AllModelWatcher":              2,
 	"AllWatcher":                   1,
 	"Annotations":                  2,
-	"Application":                  4,
+	"Application":                  8,
+	"AuditLog":                     1,
 	"ApplicationScaler":            1,
 	"Backups":                      1,
 	"Block":                        2,
-	"Bundle":                       1,
+	"Bundle":                       2,
 	"CharmRevisionUpdater":         2,
 	"Charms":                       2,
 	"Cleaner":                      2,
@@ -34,8 +36,9 @@ var facadeVersions = map[string]int{
 	"DiskManager":                  2,
 	"EntityWatcher":                2,
 	"FilesystemAttachmentsWatcher": 2,
-	"Firewaller":                   3,
+	"Firewaller":                   5,
 	"HighAvailability":             2,
+	"HookHistory":                  1,
 	"HostKeyReporter":              1,
 	"ImageManager":                 2,
 	"ImageMetadata":                2,
@@ -75,17 +78,18 @@ var facadeVersions = map[string]int{
 	"ResourcesHookContext":         1,
 	"Resumer":                      2,
 	"RetryStrategy":                1,
+	"Secrets":                      1,
 	"Singular":                     1,
 	"Spaces":                       2,
 	"SSHClient":                    2,
 	"StatusHistory":                2,
-	"Storage":                      3,
+	"Storage":                      7,
 	"StorageProvisioner":           3,
 	"StringsWatcher":               1,
 	"Subnets":                      2,
 	"Undertaker":                   1,
 	"UnitAssigner":                 1,
-	"Uniter":                       4,
+	"Uniter":                       13,
 	"Upgrader":                     1,
 	"UserManager":                  1,
 	"VolumeAttachmentsWatcher":     2,
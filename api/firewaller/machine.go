@@ -108,9 +108,17 @@ func (m *Machine) ActiveSubnets() ([]names.SubnetTag, error) {
 	return tags, nil
 }
 
-// OpenedPorts returns a map of network.PortRange to unit tag for all opened
+// OpenedPortRange holds a port range opened on a machine and the name
+// of the unit endpoint it is opened for. An empty endpoint name means
+// the range is opened for all of the unit's endpoints.
+type OpenedPortRange struct {
+	PortRange network.PortRange
+	Endpoint  string
+}
+
+// OpenedPorts returns a map of OpenedPortRange to unit tag for all opened
 // port ranges on the machine for the subnet matching given subnetTag.
-func (m *Machine) OpenedPorts(subnetTag names.SubnetTag) (map[network.PortRange]names.UnitTag, error) {
+func (m *Machine) OpenedPorts(subnetTag names.SubnetTag) (map[OpenedPortRange]names.UnitTag, error) {
 	var results params.MachinePortsResults
 	var subnetTagAsString string
 	if subnetTag.Id() != "" {
@@ -133,13 +141,16 @@ func (m *Machine) OpenedPorts(subnetTag names.SubnetTag) (map[network.PortRange]
 		return nil, result.Error
 	}
 	// Convert string tags to names.UnitTag before returning.
-	endResult := make(map[network.PortRange]names.UnitTag)
+	endResult := make(map[OpenedPortRange]names.UnitTag)
 	for _, ports := range result.Ports {
 		unitTag, err := names.ParseUnitTag(ports.UnitTag)
 		if err != nil {
 			return nil, err
 		}
-		endResult[ports.PortRange.NetworkPortRange()] = unitTag
+		endResult[OpenedPortRange{
+			PortRange: ports.PortRange.NetworkPortRange(),
+			Endpoint:  ports.Endpoint,
+		}] = unitTag
 	}
 	return endResult, nil
 }
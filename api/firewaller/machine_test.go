@@ -130,7 +130,17 @@ func (s *machineSuite) TestOpenedPorts(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	ports, err = s.apiMachine.OpenedPorts(names.SubnetTag{})
 	c.Assert(err, jc.ErrorIsNil)
-	c.Assert(ports, jc.DeepEquals, map[network.PortRange]names.UnitTag{
-		network.PortRange{FromPort: 1234, ToPort: 1234, Protocol: "tcp"}: unitTag,
+	c.Assert(ports, jc.DeepEquals, map[firewaller.OpenedPortRange]names.UnitTag{
+		{PortRange: network.PortRange{FromPort: 1234, ToPort: 1234, Protocol: "tcp"}}: unitTag,
+	})
+
+	// Open a port for an endpoint and check again.
+	err = s.units[0].OpenPortsOnEndpoint("db", "tcp", 4321, 4321)
+	c.Assert(err, jc.ErrorIsNil)
+	ports, err = s.apiMachine.OpenedPorts(names.SubnetTag{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports, jc.DeepEquals, map[firewaller.OpenedPortRange]names.UnitTag{
+		{PortRange: network.PortRange{FromPort: 1234, ToPort: 1234, Protocol: "tcp"}}:                 unitTag,
+		{PortRange: network.PortRange{FromPort: 4321, ToPort: 4321, Protocol: "tcp"}, Endpoint: "db"}: unitTag,
 	})
 }
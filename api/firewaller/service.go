@@ -10,6 +10,7 @@ import (
 
 	"github.com/juju/juju/api/common"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/watcher"
 )
 
@@ -75,3 +76,48 @@ func (s *Application) IsExposed() (bool, error) {
 	}
 	return result.Result, nil
 }
+
+// ExposeInfo returns whether this application is exposed, along with
+// its expose settings keyed on endpoint name. The CIDRs of each exposed
+// endpoint include the CIDRs of the subnets in its spaces. An exposed
+// application with no expose settings may be accessed from anywhere.
+func (s *Application) ExposeInfo() (bool, map[string]params.ExposedEndpoint, error) {
+	var results params.ExposeInfoResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: s.tag.String()}},
+	}
+	err := s.st.facade.FacadeCall("GetExposeInfo", args, &results)
+	if err != nil {
+		return false, nil, err
+	}
+	if len(results.Results) != 1 {
+		return false, nil, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return false, nil, result.Error
+	}
+	return result.Exposed, result.ExposedEndpoints, nil
+}
+
+// EgressRules returns the effective egress rules of this application,
+// including those allowing traffic to the controllers. An application
+// without egress rules may send traffic anywhere.
+func (s *Application) EgressRules() ([]network.EgressRule, error) {
+	var results params.EgressRulesResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: s.tag.String()}},
+	}
+	err := s.st.facade.FacadeCall("GetEgressRules", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != 1 {
+		return nil, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return params.NetworkEgressRules(result.Rules), nil
+}
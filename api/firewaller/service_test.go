@@ -10,6 +10,8 @@ import (
 
 	"github.com/juju/juju/api/firewaller"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/watcher/watchertest"
 )
 
@@ -91,3 +93,47 @@ func (s *serviceSuite) TestIsExposed(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(isExposed, jc.IsFalse)
 }
+
+func (s *serviceSuite) TestExposeInfo(c *gc.C) {
+	err := s.application.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"url": {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	isExposed, exposedEndpoints, err := s.apiApplication.ExposeInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(isExposed, jc.IsTrue)
+	c.Assert(exposedEndpoints, jc.DeepEquals, map[string]params.ExposedEndpoint{
+		"url": {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+	})
+
+	err = s.application.ClearExposed()
+	c.Assert(err, jc.ErrorIsNil)
+
+	isExposed, exposedEndpoints, err = s.apiApplication.ExposeInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(isExposed, jc.IsFalse)
+	c.Assert(exposedEndpoints, gc.IsNil)
+}
+
+func (s *serviceSuite) TestEgressRules(c *gc.C) {
+	rules, err := s.apiApplication.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, gc.IsNil)
+
+	err = s.State.SetAPIHostPorts([][]network.HostPort{
+		network.NewHostPorts(17070, "10.0.0.1"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.application.SetEgressRules([]network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "192.168.0.0/16"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	rules, err = s.apiApplication.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "192.168.0.0/16"),
+		network.MustNewEgressRule("tcp", 17070, 17070, "10.0.0.1/32"),
+	})
+}
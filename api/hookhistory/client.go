@@ -0,0 +1,47 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package hookhistory provides access to the HookHistory facade, used
+// to read the hook and action executions recorded by a model's units.
+package hookhistory
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client provides access to the hook history of a model's units.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new Client based on an existing authenticated
+// API connection.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "HookHistory")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// HookExecutions returns the recorded hook and action executions of
+// the given unit, oldest first.
+func (c *Client) HookExecutions(unit names.UnitTag) ([]params.HookExecution, error) {
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: unit.String()}},
+	}
+	var results params.HookExecutionsResults
+	if err := c.facade.FacadeCall("HookExecutions", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Executions, nil
+}
@@ -0,0 +1,74 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hookhistory_test
+
+import (
+	"time"
+
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/hookhistory"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type clientSuite struct {
+	gitjujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) newClient(c *gc.C, result params.HookExecutionsResults) *hookhistory.Client {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			_ int,
+			id, request string,
+			args, response interface{},
+		) error {
+			c.Check(objType, gc.Equals, "HookHistory")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "HookExecutions")
+			c.Check(args, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "unit-mysql-0"}},
+			})
+			*(response.(*params.HookExecutionsResults)) = result
+			return nil
+		},
+	)
+	return hookhistory.NewClient(apiCaller)
+}
+
+func (s *clientSuite) TestHookExecutions(c *gc.C) {
+	t0 := time.Date(2017, time.June, 1, 12, 0, 0, 0, time.UTC)
+	executions := []params.HookExecution{{
+		Kind:     "hook",
+		Name:     "install",
+		Started:  t0,
+		Finished: t0.Add(time.Minute),
+		ToolCalls: []params.HookToolCall{{
+			Name:     "status-set",
+			Started:  t0.Add(time.Second),
+			Duration: time.Millisecond,
+		}},
+	}}
+	client := s.newClient(c, params.HookExecutionsResults{
+		Results: []params.HookExecutionsResult{{Executions: executions}},
+	})
+	result, err := client.HookExecutions(names.NewUnitTag("mysql/0"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, executions)
+}
+
+func (s *clientSuite) TestHookExecutionsError(c *gc.C) {
+	client := s.newClient(c, params.HookExecutionsResults{
+		Results: []params.HookExecutionsResult{{
+			Error: &params.Error{Message: `unit "mysql/0" not found`, Code: params.CodeNotFound},
+		}},
+	})
+	_, err := client.HookExecutions(names.NewUnitTag("mysql/0"))
+	c.Assert(err, gc.ErrorMatches, `unit "mysql/0" not found`)
+}
@@ -0,0 +1,84 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package secrets provides access to the Secrets facade, used to
+// manage the secrets of a model.
+package secrets
+
+import (
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client provides access to the secrets of a model.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new Client based on an existing authenticated
+// API connection.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "Secrets")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// ListSecrets returns the details, but not the values, of all secrets
+// in the model.
+func (c *Client) ListSecrets() ([]params.SecretDetails, error) {
+	var result params.ListSecretsResults
+	if err := c.facade.FacadeCall("ListSecrets", nil, &result); err != nil {
+		return nil, err
+	}
+	return result.Results, nil
+}
+
+// AddSecret adds a new secret to the model.
+func (c *Client) AddSecret(name, description string, values map[string]string) error {
+	args := params.AddSecretArgs{
+		Args: []params.AddSecretArg{{
+			Name:        name,
+			Description: description,
+			Values:      values,
+		}},
+	}
+	return c.call("AddSecrets", args)
+}
+
+// RotateSecret replaces the values of the named secret.
+func (c *Client) RotateSecret(name string, values map[string]string) error {
+	args := params.RotateSecretArgs{
+		Args: []params.RotateSecretArg{{Name: name, Values: values}},
+	}
+	return c.call("RotateSecrets", args)
+}
+
+// GrantSecret gives the named application access to the named secret.
+func (c *Client) GrantSecret(name, application string) error {
+	args := params.GrantSecretArgs{
+		Args: []params.GrantSecretArg{{Name: name, Application: application}},
+	}
+	return c.call("GrantSecrets", args)
+}
+
+// RevokeSecret removes the named application's access to the named
+// secret.
+func (c *Client) RevokeSecret(name, application string) error {
+	args := params.GrantSecretArgs{
+		Args: []params.GrantSecretArg{{Name: name, Application: application}},
+	}
+	return c.call("RevokeSecrets", args)
+}
+
+// RemoveSecret removes the named secret from the model.
+func (c *Client) RemoveSecret(name string) error {
+	return c.call("RemoveSecrets", params.SecretNames{Names: []string{name}})
+}
+
+func (c *Client) call(method string, args interface{}) error {
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall(method, args, &results); err != nil {
+		return err
+	}
+	return results.OneError()
+}
@@ -0,0 +1,111 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"time"
+
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/secrets"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type clientSuite struct {
+	gitjujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) newClient(c *gc.C, request string, args, result interface{}) *secrets.Client {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			_ int,
+			id, actualRequest string,
+			a, response interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Secrets")
+			c.Check(id, gc.Equals, "")
+			c.Check(actualRequest, gc.Equals, request)
+			c.Check(a, jc.DeepEquals, args)
+			switch response := response.(type) {
+			case *params.ErrorResults:
+				*response = result.(params.ErrorResults)
+			case *params.ListSecretsResults:
+				*response = result.(params.ListSecretsResults)
+			default:
+				c.Fatalf("unexpected response type %T", response)
+			}
+			return nil
+		},
+	)
+	return secrets.NewClient(apiCaller)
+}
+
+func (s *clientSuite) TestListSecrets(c *gc.C) {
+	t0 := time.Date(2017, time.June, 1, 12, 0, 0, 0, time.UTC)
+	details := []params.SecretDetails{{
+		Name:         "db-password",
+		Revision:     2,
+		Applications: []string{"mysql"},
+		Created:      t0,
+		Updated:      t0,
+	}}
+	client := s.newClient(c, "ListSecrets", nil, params.ListSecretsResults{Results: details})
+	result, err := client.ListSecrets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, details)
+}
+
+func (s *clientSuite) TestAddSecret(c *gc.C) {
+	client := s.newClient(c, "AddSecrets", params.AddSecretArgs{
+		Args: []params.AddSecretArg{{
+			Name:        "db-password",
+			Description: "database credentials",
+			Values:      map[string]string{"password": "sekrit"},
+		}},
+	}, params.ErrorResults{Results: []params.ErrorResult{{}}})
+	err := client.AddSecret("db-password", "database credentials", map[string]string{"password": "sekrit"})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *clientSuite) TestRotateSecretError(c *gc.C) {
+	client := s.newClient(c, "RotateSecrets", params.RotateSecretArgs{
+		Args: []params.RotateSecretArg{{
+			Name:   "db-password",
+			Values: map[string]string{"password": "new"},
+		}},
+	}, params.ErrorResults{Results: []params.ErrorResult{{
+		Error: &params.Error{Message: "boom"},
+	}}})
+	err := client.RotateSecret("db-password", map[string]string{"password": "new"})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *clientSuite) TestGrantSecret(c *gc.C) {
+	client := s.newClient(c, "GrantSecrets", params.GrantSecretArgs{
+		Args: []params.GrantSecretArg{{Name: "db-password", Application: "wordpress"}},
+	}, params.ErrorResults{Results: []params.ErrorResult{{}}})
+	err := client.GrantSecret("db-password", "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *clientSuite) TestRevokeSecret(c *gc.C) {
+	client := s.newClient(c, "RevokeSecrets", params.GrantSecretArgs{
+		Args: []params.GrantSecretArg{{Name: "db-password", Application: "wordpress"}},
+	}, params.ErrorResults{Results: []params.ErrorResult{{}}})
+	err := client.RevokeSecret("db-password", "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *clientSuite) TestRemoveSecret(c *gc.C) {
+	client := s.newClient(c, "RemoveSecrets", params.SecretNames{
+		Names: []string{"db-password"},
+	}, params.ErrorResults{Results: []params.ErrorResult{{}}})
+	err := client.RemoveSecret("db-password")
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/storage"
 )
 
 // Client allows access to the storage API end point.
@@ -204,6 +205,113 @@ func (c *Client) Destroy(storageIds []string) ([]params.ErrorResult, error) {
 	return results.Results, nil
 }
 
+// CreateStorageSnapshots requests snapshots of the volumes backing the
+// specified storage entities, returning the IDs of the snapshots.
+func (c *Client) CreateStorageSnapshots(storageIds []string) ([]params.StringResult, error) {
+	results := params.StringResults{}
+	entities := make([]params.Entity, len(storageIds))
+	for i, id := range storageIds {
+		if !names.IsValidStorage(id) {
+			return nil, errors.NotValidf("storage ID %q", id)
+		}
+		entities[i] = params.Entity{Tag: names.NewStorageTag(id).String()}
+	}
+	if err := c.facade.FacadeCall(
+		"CreateStorageSnapshots",
+		params.Entities{Entities: entities},
+		&results,
+	); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(storageIds) {
+		return nil, errors.Errorf(
+			"expected %d result(s), got %d",
+			len(storageIds), len(results.Results),
+		)
+	}
+	return results.Results, nil
+}
+
+// DestroyStorageSnapshots requests that the snapshots with the specified
+// IDs be destroyed.
+func (c *Client) DestroyStorageSnapshots(snapshotIds []string) ([]params.ErrorResult, error) {
+	if c.BestAPIVersion() < 7 {
+		return nil, errors.NotSupportedf("destroying storage snapshots by this controller")
+	}
+	results := params.ErrorResults{}
+	if err := c.facade.FacadeCall(
+		"DestroyStorageSnapshots",
+		params.VolumeSnapshotIds{Ids: snapshotIds},
+		&results,
+	); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(snapshotIds) {
+		return nil, errors.Errorf(
+			"expected %d result(s), got %d",
+			len(snapshotIds), len(results.Results),
+		)
+	}
+	return results.Results, nil
+}
+
+// ResizeStorage requests that the storage instance with the specified
+// ID be grown to the specified size, in MiB.
+func (c *Client) ResizeStorage(storageId string, size uint64) error {
+	if !names.IsValidStorage(storageId) {
+		return errors.NotValidf("storage ID %q", storageId)
+	}
+	args := params.StoragesResizeParams{
+		Storage: []params.StorageResizeParams{{
+			StorageTag: names.NewStorageTag(storageId).String(),
+			Size:       size,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("ResizeStorage", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// Import imports existing storage into the model, returning the tag
+// of the storage instance created for it.
+func (c *Client) Import(
+	kind storage.StorageKind,
+	storagePool string,
+	storageProviderId string,
+	storageName string,
+) (names.StorageTag, error) {
+	var paramsKind params.StorageKind
+	switch kind {
+	case storage.StorageKindBlock:
+		paramsKind = params.StorageKindBlock
+	case storage.StorageKindFilesystem:
+		paramsKind = params.StorageKindFilesystem
+	default:
+		return names.StorageTag{}, errors.NotSupportedf("importing storage kind %q", kind)
+	}
+	args := params.BulkImportStorageParams{
+		Storage: []params.ImportStorageParams{{
+			Kind:        paramsKind,
+			Pool:        storagePool,
+			ProviderId:  storageProviderId,
+			StorageName: storageName,
+		}},
+	}
+	var results params.ImportStorageResults
+	if err := c.facade.FacadeCall("Import", args, &results); err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return names.StorageTag{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return names.StorageTag{}, err
+	}
+	return names.ParseStorageTag(results.Results[0].Result.StorageTag)
+}
+
 // Detach detaches the specified storage entities.
 func (c *Client) Detach(storageIds []string) ([]params.ErrorResult, error) {
 	results := params.ErrorResults{}
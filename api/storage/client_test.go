@@ -16,6 +16,7 @@ import (
 	"github.com/juju/juju/api/storage"
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
+	jujustorage "github.com/juju/juju/storage"
 	"github.com/juju/juju/testing"
 )
 
@@ -625,6 +626,188 @@ func (s *storageMockSuite) TestDestroyInvalidStorageId(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, `storage ID "foo/bar" not valid`)
 }
 
+func (s *storageMockSuite) TestCreateStorageSnapshots(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "CreateStorageSnapshots")
+			c.Check(a, jc.DeepEquals, params.Entities{[]params.Entity{
+				{"storage-foo-0"},
+				{"storage-bar-1"},
+			}})
+			c.Assert(result, gc.FitsTypeOf, &params.StringResults{})
+			results := result.(*params.StringResults)
+			results.Results = []params.StringResult{
+				{Result: "0"},
+				{Error: &params.Error{Message: "baz"}},
+			}
+			return nil
+		},
+	)
+	client := storage.NewClient(apiCaller)
+	results, err := client.CreateStorageSnapshots([]string{"foo/0", "bar/1"})
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.StringResult{
+		{Result: "0"},
+		{Error: &params.Error{Message: "baz"}},
+	})
+}
+
+func (s *storageMockSuite) TestCreateStorageSnapshotsInvalidStorageId(c *gc.C) {
+	client := storage.NewClient(basetesting.APICallerFunc(
+		func(_ string, _ int, _, _ string, _, _ interface{}) error {
+			return nil
+		},
+	))
+	_, err := client.CreateStorageSnapshots([]string{"foo/bar"})
+	c.Check(err, gc.ErrorMatches, `storage ID "foo/bar" not valid`)
+}
+
+func (s *storageMockSuite) TestDestroyStorageSnapshots(c *gc.C) {
+	apiCaller := versionedCaller{
+		APICallerFunc: func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "DestroyStorageSnapshots")
+			c.Check(a, jc.DeepEquals, params.VolumeSnapshotIds{Ids: []string{"0", "1"}})
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			results := result.(*params.ErrorResults)
+			results.Results = []params.ErrorResult{
+				{},
+				{Error: &params.Error{Message: "baz"}},
+			}
+			return nil
+		},
+		version: 7,
+	}
+	client := storage.NewClient(apiCaller)
+	results, err := client.DestroyStorageSnapshots([]string{"0", "1"})
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: "baz"}},
+	})
+}
+
+func (s *storageMockSuite) TestDestroyStorageSnapshotsNotSupported(c *gc.C) {
+	client := storage.NewClient(versionedCaller{
+		APICallerFunc: func(_ string, _ int, _, _ string, _, _ interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+		version: 6,
+	})
+	_, err := client.DestroyStorageSnapshots([]string{"0"})
+	c.Check(err, gc.ErrorMatches, "destroying storage snapshots by this controller not supported")
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+type versionedCaller struct {
+	basetesting.APICallerFunc
+	version int
+}
+
+func (c versionedCaller) BestFacadeVersion(string) int {
+	return c.version
+}
+
+func (s *storageMockSuite) TestResizeStorage(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "ResizeStorage")
+			c.Check(a, jc.DeepEquals, params.StoragesResizeParams{
+				Storage: []params.StorageResizeParams{{
+					StorageTag: "storage-foo-0",
+					Size:       2048,
+				}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			results := result.(*params.ErrorResults)
+			results.Results = []params.ErrorResult{
+				{Error: &params.Error{Message: "baz"}},
+			}
+			return nil
+		},
+	)
+	client := storage.NewClient(apiCaller)
+	err := client.ResizeStorage("foo/0", 2048)
+	c.Check(err, gc.ErrorMatches, "baz")
+}
+
+func (s *storageMockSuite) TestResizeStorageInvalidStorageId(c *gc.C) {
+	client := storage.NewClient(basetesting.APICallerFunc(
+		func(_ string, _ int, _, _ string, _, _ interface{}) error {
+			return nil
+		},
+	))
+	err := client.ResizeStorage("foo/bar", 2048)
+	c.Check(err, gc.ErrorMatches, `storage ID "foo/bar" not valid`)
+}
+
+func (s *storageMockSuite) TestImport(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "Import")
+			c.Check(a, jc.DeepEquals, params.BulkImportStorageParams{
+				Storage: []params.ImportStorageParams{{
+					Kind:        params.StorageKindFilesystem,
+					Pool:        "ebs",
+					ProviderId:  "vol-123",
+					StorageName: "data",
+				}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.ImportStorageResults{})
+			results := result.(*params.ImportStorageResults)
+			results.Results = []params.ImportStorageResult{{
+				Result: &params.ImportStorageDetails{
+					StorageTag: "storage-data-0",
+				},
+			}}
+			return nil
+		},
+	)
+	client := storage.NewClient(apiCaller)
+	storageTag, err := client.Import(jujustorage.StorageKindFilesystem, "ebs", "vol-123", "data")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(storageTag, gc.Equals, names.NewStorageTag("data/0"))
+}
+
+func (s *storageMockSuite) TestImportError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(_ string, _ int, _, _ string, _, result interface{}) error {
+			results := result.(*params.ImportStorageResults)
+			results.Results = []params.ImportStorageResult{{
+				Error: &params.Error{Message: "qux"},
+			}}
+			return nil
+		},
+	)
+	client := storage.NewClient(apiCaller)
+	_, err := client.Import(jujustorage.StorageKindFilesystem, "ebs", "vol-123", "data")
+	c.Check(err, gc.ErrorMatches, "qux")
+}
+
 func (s *storageMockSuite) TestDetach(c *gc.C) {
 	apiCaller := basetesting.APICallerFunc(
 		func(objType string,
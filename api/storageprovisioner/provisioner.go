@@ -87,6 +87,24 @@ func (st *State) WatchFilesystems() (watcher.StringsWatcher, error) {
 	return st.watchStorageEntities("WatchFilesystems")
 }
 
+// WatchVolumeSnapshots watches for lifecycle changes to volume snapshots
+// scoped to the entity with the tag passed to NewState.
+func (st *State) WatchVolumeSnapshots() (watcher.StringsWatcher, error) {
+	return st.watchStorageEntities("WatchVolumeSnapshots")
+}
+
+// WatchVolumeResizes watches for changes to the pending resizes of
+// volumes scoped to the entity with the tag passed to NewState.
+func (st *State) WatchVolumeResizes() (watcher.StringsWatcher, error) {
+	return st.watchStorageEntities("WatchVolumeResizes")
+}
+
+// WatchFilesystemResizes watches for changes to the pending resizes of
+// filesystems scoped to the entity with the tag passed to NewState.
+func (st *State) WatchFilesystemResizes() (watcher.StringsWatcher, error) {
+	return st.watchStorageEntities("WatchFilesystemResizes")
+}
+
 func (st *State) watchStorageEntities(method string) (watcher.StringsWatcher, error) {
 	var results params.StringsWatchResults
 	args := params.Entities{
@@ -307,6 +325,133 @@ func (st *State) SetVolumeInfo(volumes []params.Volume) ([]params.ErrorResult, e
 	return results.Results, nil
 }
 
+// VolumeSnapshotParams returns the parameters for taking the volume
+// snapshots with the specified IDs.
+func (st *State) VolumeSnapshotParams(ids []string) ([]params.VolumeSnapshotParamsResult, error) {
+	args := params.VolumeSnapshotIds{Ids: ids}
+	var results params.VolumeSnapshotParamsResults
+	err := st.facade.FacadeCall("VolumeSnapshotParams", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(ids) {
+		panic(errors.Errorf("expected %d result(s), got %d", len(ids), len(results.Results)))
+	}
+	return results.Results, nil
+}
+
+// SetVolumeSnapshotResults records the outcome of taking volume snapshots.
+func (st *State) SetVolumeSnapshotResults(snapshots []params.VolumeSnapshotResult) ([]params.ErrorResult, error) {
+	args := params.VolumeSnapshotResults{Results: snapshots}
+	var results params.ErrorResults
+	err := st.facade.FacadeCall("SetVolumeSnapshotResults", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(snapshots) {
+		panic(errors.Errorf("expected %d result(s), got %d", len(snapshots), len(results.Results)))
+	}
+	return results.Results, nil
+}
+
+// VolumeSnapshotDestroyParams returns the parameters for destroying the
+// volume snapshots with the specified IDs.
+func (st *State) VolumeSnapshotDestroyParams(ids []string) ([]params.VolumeSnapshotDestroyParamsResult, error) {
+	args := params.VolumeSnapshotIds{Ids: ids}
+	var results params.VolumeSnapshotDestroyParamsResults
+	err := st.facade.FacadeCall("VolumeSnapshotDestroyParams", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(ids) {
+		panic(errors.Errorf("expected %d result(s), got %d", len(ids), len(results.Results)))
+	}
+	return results.Results, nil
+}
+
+// RemoveVolumeSnapshots removes the destroyed volume snapshots with the
+// specified IDs from state.
+func (st *State) RemoveVolumeSnapshots(ids []string) ([]params.ErrorResult, error) {
+	args := params.VolumeSnapshotIds{Ids: ids}
+	var results params.ErrorResults
+	err := st.facade.FacadeCall("RemoveVolumeSnapshots", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(ids) {
+		panic(errors.Errorf("expected %d result(s), got %d", len(ids), len(results.Results)))
+	}
+	return results.Results, nil
+}
+
+// VolumeResizeParams returns the parameters for growing the volumes
+// with the specified tags.
+func (st *State) VolumeResizeParams(tags []names.VolumeTag) ([]params.VolumeResizeParamsResult, error) {
+	args := params.Entities{
+		Entities: make([]params.Entity, len(tags)),
+	}
+	for i, tag := range tags {
+		args.Entities[i].Tag = tag.String()
+	}
+	var results params.VolumeResizeParamsResults
+	err := st.facade.FacadeCall("VolumeResizeParams", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(tags) {
+		panic(errors.Errorf("expected %d result(s), got %d", len(tags), len(results.Results)))
+	}
+	return results.Results, nil
+}
+
+// SetVolumeResizeResults records the outcome of growing volumes.
+func (st *State) SetVolumeResizeResults(resizes []params.VolumeResizeResult) ([]params.ErrorResult, error) {
+	args := params.VolumeResizeResults{Results: resizes}
+	var results params.ErrorResults
+	err := st.facade.FacadeCall("SetVolumeResizeResults", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(resizes) {
+		panic(errors.Errorf("expected %d result(s), got %d", len(resizes), len(results.Results)))
+	}
+	return results.Results, nil
+}
+
+// FilesystemResizeParams returns the parameters for growing the
+// filesystems with the specified tags.
+func (st *State) FilesystemResizeParams(tags []names.FilesystemTag) ([]params.FilesystemResizeParamsResult, error) {
+	args := params.Entities{
+		Entities: make([]params.Entity, len(tags)),
+	}
+	for i, tag := range tags {
+		args.Entities[i].Tag = tag.String()
+	}
+	var results params.FilesystemResizeParamsResults
+	err := st.facade.FacadeCall("FilesystemResizeParams", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(tags) {
+		panic(errors.Errorf("expected %d result(s), got %d", len(tags), len(results.Results)))
+	}
+	return results.Results, nil
+}
+
+// SetFilesystemResizeResults records the outcome of growing filesystems.
+func (st *State) SetFilesystemResizeResults(resizes []params.FilesystemResizeResult) ([]params.ErrorResult, error) {
+	args := params.FilesystemResizeResults{Results: resizes}
+	var results params.ErrorResults
+	err := st.facade.FacadeCall("SetFilesystemResizeResults", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(resizes) {
+		panic(errors.Errorf("expected %d result(s), got %d", len(resizes), len(results.Results)))
+	}
+	return results.Results, nil
+}
+
 // SetFilesystemInfo records the details of newly provisioned filesystems.
 func (st *State) SetFilesystemInfo(filesystems []params.Filesystem) ([]params.ErrorResult, error) {
 	args := params.Filesystems{Filesystems: filesystems}
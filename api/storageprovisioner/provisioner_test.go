@@ -552,6 +552,197 @@ func (s *provisionerSuite) TestSetVolumeInfo(c *gc.C) {
 	c.Assert(errorResults[0].Error, gc.IsNil)
 }
 
+func (s *provisionerSuite) TestVolumeSnapshotParams(c *gc.C) {
+	var callCount int
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "StorageProvisioner")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "VolumeSnapshotParams")
+		c.Check(arg, gc.DeepEquals, params.VolumeSnapshotIds{Ids: []string{"123/1"}})
+		c.Assert(result, gc.FitsTypeOf, &params.VolumeSnapshotParamsResults{})
+		*(result.(*params.VolumeSnapshotParamsResults)) = params.VolumeSnapshotParamsResults{
+			Results: []params.VolumeSnapshotParamsResult{{
+				Result: &params.VolumeSnapshotParams{
+					Id:        "123/1",
+					VolumeTag: "volume-123-0",
+					VolumeId:  "vol-0",
+					Provider:  "loop",
+				},
+			}},
+		}
+		callCount++
+		return nil
+	})
+
+	st, err := storageprovisioner.NewState(apiCaller, names.NewMachineTag("123"))
+	c.Assert(err, jc.ErrorIsNil)
+	snapshotParams, err := st.VolumeSnapshotParams([]string{"123/1"})
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(callCount, gc.Equals, 1)
+	c.Assert(snapshotParams, jc.DeepEquals, []params.VolumeSnapshotParamsResult{{
+		Result: &params.VolumeSnapshotParams{
+			Id: "123/1", VolumeTag: "volume-123-0", VolumeId: "vol-0", Provider: "loop",
+		},
+	}})
+}
+
+func (s *provisionerSuite) TestSetVolumeSnapshotResults(c *gc.C) {
+	var callCount int
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "StorageProvisioner")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "SetVolumeSnapshotResults")
+		c.Check(arg, gc.DeepEquals, params.VolumeSnapshotResults{
+			Results: []params.VolumeSnapshotResult{{
+				Id: "123/1", SnapshotId: "snap-1", Size: 1024,
+			}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{Error: nil}},
+		}
+		callCount++
+		return nil
+	})
+
+	st, err := storageprovisioner.NewState(apiCaller, names.NewMachineTag("123"))
+	c.Assert(err, jc.ErrorIsNil)
+	errorResults, err := st.SetVolumeSnapshotResults([]params.VolumeSnapshotResult{{
+		Id: "123/1", SnapshotId: "snap-1", Size: 1024,
+	}})
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(callCount, gc.Equals, 1)
+	c.Assert(errorResults, gc.HasLen, 1)
+	c.Assert(errorResults[0].Error, gc.IsNil)
+}
+
+func (s *provisionerSuite) TestVolumeSnapshotDestroyParams(c *gc.C) {
+	var callCount int
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "StorageProvisioner")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "VolumeSnapshotDestroyParams")
+		c.Check(arg, gc.DeepEquals, params.VolumeSnapshotIds{Ids: []string{"123/1"}})
+		c.Assert(result, gc.FitsTypeOf, &params.VolumeSnapshotDestroyParamsResults{})
+		*(result.(*params.VolumeSnapshotDestroyParamsResults)) = params.VolumeSnapshotDestroyParamsResults{
+			Results: []params.VolumeSnapshotDestroyParamsResult{{
+				Result: &params.VolumeSnapshotDestroyParams{
+					Id:         "123/1",
+					SnapshotId: "volume-123-0-0",
+					Provider:   "loop",
+				},
+			}},
+		}
+		callCount++
+		return nil
+	})
+
+	st, err := storageprovisioner.NewState(apiCaller, names.NewMachineTag("123"))
+	c.Assert(err, jc.ErrorIsNil)
+	destroyParams, err := st.VolumeSnapshotDestroyParams([]string{"123/1"})
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(callCount, gc.Equals, 1)
+	c.Assert(destroyParams, jc.DeepEquals, []params.VolumeSnapshotDestroyParamsResult{{
+		Result: &params.VolumeSnapshotDestroyParams{
+			Id: "123/1", SnapshotId: "volume-123-0-0", Provider: "loop",
+		},
+	}})
+}
+
+func (s *provisionerSuite) TestRemoveVolumeSnapshots(c *gc.C) {
+	var callCount int
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "StorageProvisioner")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "RemoveVolumeSnapshots")
+		c.Check(arg, gc.DeepEquals, params.VolumeSnapshotIds{Ids: []string{"123/1"}})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{Error: nil}},
+		}
+		callCount++
+		return nil
+	})
+
+	st, err := storageprovisioner.NewState(apiCaller, names.NewMachineTag("123"))
+	c.Assert(err, jc.ErrorIsNil)
+	errorResults, err := st.RemoveVolumeSnapshots([]string{"123/1"})
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(callCount, gc.Equals, 1)
+	c.Assert(errorResults, gc.HasLen, 1)
+	c.Assert(errorResults[0].Error, gc.IsNil)
+}
+
+func (s *provisionerSuite) TestVolumeResizeParams(c *gc.C) {
+	var callCount int
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "StorageProvisioner")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "VolumeResizeParams")
+		c.Check(arg, gc.DeepEquals, params.Entities{Entities: []params.Entity{{"volume-123-0"}}})
+		c.Assert(result, gc.FitsTypeOf, &params.VolumeResizeParamsResults{})
+		*(result.(*params.VolumeResizeParamsResults)) = params.VolumeResizeParamsResults{
+			Results: []params.VolumeResizeParamsResult{{
+				Result: &params.VolumeResizeParams{
+					VolumeTag: "volume-123-0",
+					VolumeId:  "vol-0",
+					Size:      2048,
+					Provider:  "loop",
+				},
+			}},
+		}
+		callCount++
+		return nil
+	})
+
+	st, err := storageprovisioner.NewState(apiCaller, names.NewMachineTag("123"))
+	c.Assert(err, jc.ErrorIsNil)
+	resizeParams, err := st.VolumeResizeParams([]names.VolumeTag{names.NewVolumeTag("123/0")})
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(callCount, gc.Equals, 1)
+	c.Assert(resizeParams, jc.DeepEquals, []params.VolumeResizeParamsResult{{
+		Result: &params.VolumeResizeParams{
+			VolumeTag: "volume-123-0", VolumeId: "vol-0", Size: 2048, Provider: "loop",
+		},
+	}})
+}
+
+func (s *provisionerSuite) TestSetFilesystemResizeResults(c *gc.C) {
+	var callCount int
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "StorageProvisioner")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "SetFilesystemResizeResults")
+		c.Check(arg, gc.DeepEquals, params.FilesystemResizeResults{
+			Results: []params.FilesystemResizeResult{{
+				FilesystemTag: "filesystem-123-0", Size: 2048,
+			}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{Error: nil}},
+		}
+		callCount++
+		return nil
+	})
+
+	st, err := storageprovisioner.NewState(apiCaller, names.NewMachineTag("123"))
+	c.Assert(err, jc.ErrorIsNil)
+	errorResults, err := st.SetFilesystemResizeResults([]params.FilesystemResizeResult{{
+		FilesystemTag: "filesystem-123-0", Size: 2048,
+	}})
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(callCount, gc.Equals, 1)
+	c.Assert(errorResults, gc.HasLen, 1)
+	c.Assert(errorResults[0].Error, gc.IsNil)
+}
+
 func (s *provisionerSuite) TestSetFilesystemInfo(c *gc.C) {
 	var callCount int
 	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
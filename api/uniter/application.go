@@ -13,6 +13,7 @@ import (
 	"github.com/juju/juju/api/common"
 	apiwatcher "github.com/juju/juju/api/watcher"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/status"
 	"github.com/juju/juju/watcher"
 )
@@ -162,6 +163,27 @@ func (s *Application) SetStatus(unitName string, serviceStatus status.Status, in
 	return result.OneError()
 }
 
+// SetCharmEgressRules replaces the egress rules set by the application's
+// charm, if the passed unitName, corresponding to the calling unit, is
+// of the leader.
+func (s *Application) SetCharmEgressRules(unitName string, rules []network.EgressRule) error {
+	if s.st.BestAPIVersion() < 13 {
+		return errors.NotImplementedf("SetCharmEgressRules() (need V13+)")
+	}
+	var result params.ErrorResults
+	args := params.EntitiesEgressRules{
+		Entities: []params.EntityEgressRules{{
+			Tag:   names.NewUnitTag(unitName).String(),
+			Rules: params.FromNetworkEgressRules(rules),
+		}},
+	}
+	err := s.st.facade.FacadeCall("SetCharmEgressRules", args, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return result.OneError()
+}
+
 // Status returns the status of the service if the passed unitName,
 // corresponding to the calling unit, is of the leader.
 func (s *Application) Status(unitName string) (params.ApplicationStatusResult, error) {
@@ -6,12 +6,14 @@ package uniter_test
 import (
 	"time"
 
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/uniter"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
 	"github.com/juju/juju/watcher/watchertest"
@@ -143,6 +145,36 @@ func (s *serviceSuite) TestSetServiceStatus(c *gc.C) {
 	c.Check(stat.Message, gc.Equals, message)
 }
 
+func (s *serviceSuite) TestSetCharmEgressRules(c *gc.C) {
+	rules := []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+	}
+	err := s.apiService.SetCharmEgressRules(s.wordpressUnit.Name(), rules)
+	c.Check(err, gc.ErrorMatches, "permission denied")
+
+	s.claimLeadership(c, s.wordpressUnit, s.wordpressService)
+
+	err = s.apiService.SetCharmEgressRules(s.wordpressUnit.Name(), rules)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.wordpressService.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.wordpressService.CharmEgressRules(), jc.DeepEquals, rules)
+
+	err = s.apiService.SetCharmEgressRules(s.wordpressUnit.Name(), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.wordpressService.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.wordpressService.CharmEgressRules(), gc.IsNil)
+}
+
+func (s *serviceSuite) TestSetCharmEgressRulesNotImplemented(c *gc.C) {
+	st := uniter.NewStateForVersion(s.st, s.wordpressUnit.UnitTag(), 12)
+	apiService, err := st.Application(s.wordpressService.Tag().(names.ApplicationTag))
+	c.Assert(err, jc.ErrorIsNil)
+	err = apiService.SetCharmEgressRules(s.wordpressUnit.Name(), nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
+
 func (s *serviceSuite) TestServiceStatus(c *gc.C) {
 	message := "a test message"
 	stat, err := s.wordpressService.Status()
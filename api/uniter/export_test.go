@@ -13,7 +13,8 @@ import (
 )
 
 var (
-	NewSettings = newSettings
+	NewSettings        = newSettings
+	NewStateForVersion = newStateForVersion
 )
 
 // PatchUnitResponse changes the internal FacadeCaller to one that lets you return
@@ -162,6 +162,52 @@ func (ru *RelationUnit) ReadSettings(uname string) (params.Settings, error) {
 	return result.Settings, nil
 }
 
+// ApplicationSettings returns a Settings which allows access to the
+// application-level settings of the unit's application within the
+// relation. Only the leader of the application may access them.
+func (ru *RelationUnit) ApplicationSettings() (*Settings, error) {
+	settings, err := ru.readApplicationSettings(ru.unit.ApplicationTag())
+	if err != nil {
+		return nil, err
+	}
+	return newApplicationSettings(ru.st, ru.relation.tag.String(), ru.unit.tag.String(), settings), nil
+}
+
+// ReadApplicationSettings returns a map holding the application-level
+// settings of the named application within this relation.
+func (ru *RelationUnit) ReadApplicationSettings(appName string) (params.Settings, error) {
+	if !names.IsValidApplication(appName) {
+		return nil, errors.Errorf("%q is not a valid application", appName)
+	}
+	return ru.readApplicationSettings(names.NewApplicationTag(appName))
+}
+
+func (ru *RelationUnit) readApplicationSettings(tag names.ApplicationTag) (params.Settings, error) {
+	if ru.st.BestAPIVersion() < 8 {
+		return nil, errors.NotImplementedf("application settings (need V8+)")
+	}
+	var results params.SettingsResults
+	args := params.RelationApplications{
+		RelationApplications: []params.RelationApplication{{
+			Relation:    ru.relation.tag.String(),
+			Unit:        ru.unit.tag.String(),
+			Application: tag.String(),
+		}},
+	}
+	err := ru.st.facade.FacadeCall("ReadApplicationSettings", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != 1 {
+		return nil, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Settings, nil
+}
+
 // Watch returns a watcher that notifies of changes to counterpart
 // units in the relation.
 func (ru *RelationUnit) Watch() (watcher.RelationUnitsWatcher, error) {
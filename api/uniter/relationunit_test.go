@@ -4,6 +4,9 @@
 package uniter_test
 
 import (
+	"time"
+
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable"
@@ -256,6 +259,51 @@ func (s *relationUnitSuite) TestReadSettingsInvalidUnitTag(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "\"mysql\" is not a valid unit")
 }
 
+func (s *relationUnitSuite) TestApplicationSettings(c *gc.C) {
+	claimer := s.State.LeadershipClaimer()
+	err := claimer.ClaimLeadership("wordpress", "wordpress/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	err = claimer.ClaimLeadership("mysql", "mysql/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	token := s.State.LeadershipChecker().LeadershipCheck("mysql", "mysql/0")
+	err = s.stateRelation.UpdateApplicationSettings("mysql", token, map[string]string{"host": "db"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, apiRelUnit := s.getRelationUnits(c)
+	settings, err := apiRelUnit.ApplicationSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings.Map(), gc.HasLen, 0)
+	settings.Set("venue", "home")
+	err = settings.Write()
+	c.Assert(err, jc.ErrorIsNil)
+
+	stateSettings, err := s.stateRelation.ApplicationSettings("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stateSettings, jc.DeepEquals, map[string]interface{}{"venue": "home"})
+
+	remoteSettings, err := apiRelUnit.ReadApplicationSettings("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(remoteSettings, jc.DeepEquals, params.Settings{"host": "db"})
+
+	_, err = apiRelUnit.ReadApplicationSettings("mysql/0")
+	c.Assert(err, gc.ErrorMatches, `"mysql/0" is not a valid application`)
+}
+
+func (s *relationUnitSuite) TestApplicationSettingsNotImplemented(c *gc.C) {
+	st := uniter.NewStateForVersion(s.st, s.wordpressUnit.UnitTag(), 7)
+	apiRelation, err := st.Relation(s.stateRelation.Tag().(names.RelationTag))
+	c.Assert(err, jc.ErrorIsNil)
+	apiUnit, err := st.Unit(s.wordpressUnit.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	apiRelUnit, err := apiRelation.Unit(apiUnit)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = apiRelUnit.ApplicationSettings()
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+	_, err = apiRelUnit.ReadApplicationSettings("mysql")
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
+
 func (s *relationUnitSuite) TestWatchRelationUnits(c *gc.C) {
 	// Enter scope with mysqlUnit.
 	myRelUnit, err := s.stateRelation.Unit(s.mysqlUnit)
@@ -0,0 +1,65 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"github.com/juju/errors"
+
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/watcher"
+)
+
+// SecretValues returns the values of the named secret. The unit's
+// application must have been granted access to the secret.
+func (st *State) SecretValues(name string) (map[string]string, error) {
+	if st.BestAPIVersion() < 11 {
+		return nil, errors.NotImplementedf("SecretValues() (need V11+)")
+	}
+	var results params.SecretValuesResults
+	args := params.SecretNames{Names: []string{name}}
+	if err := st.facade.FacadeCall("SecretValues", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Values, nil
+}
+
+// SecretRevisions returns the current revisions of the secrets granted
+// to the unit's application, keyed by secret name.
+func (st *State) SecretRevisions() (map[string]int, error) {
+	if st.BestAPIVersion() < 11 {
+		return nil, errors.NotImplementedf("SecretRevisions() (need V11+)")
+	}
+	var result params.SecretRevisionsResult
+	if err := st.facade.FacadeCall("SecretRevisions", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Revisions, nil
+}
+
+// WatchSecrets returns a watcher that notifies of changes to the
+// secrets in the model.
+func (st *State) WatchSecrets() (watcher.NotifyWatcher, error) {
+	if st.BestAPIVersion() < 11 {
+		return nil, errors.NotImplementedf("WatchSecrets() (need V11+)")
+	}
+	var result params.NotifyWatchResult
+	if err := st.facade.FacadeCall("WatchSecrets", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return apiwatcher.NewNotifyWatcher(st.facade.RawAPICaller(), result), nil
+}
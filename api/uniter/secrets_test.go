@@ -0,0 +1,86 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/watcher/watchertest"
+)
+
+type secretsSuite struct {
+	uniterSuite
+}
+
+var _ = gc.Suite(&secretsSuite{})
+
+func (s *secretsSuite) addSecret(c *gc.C, name string) *state.Secret {
+	secret, err := s.State.AddSecret(state.SecretArgs{
+		Name:   name,
+		Values: map[string]string{"password": "sekrit"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return secret
+}
+
+func (s *secretsSuite) TestSecretValues(c *gc.C) {
+	secret := s.addSecret(c, "db-password")
+	_, err := s.uniter.SecretValues("db-password")
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	c.Assert(params.IsCodeUnauthorized(err), jc.IsTrue)
+
+	err = secret.Grant("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	values, err := s.uniter.SecretValues("db-password")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(values, jc.DeepEquals, map[string]string{"password": "sekrit"})
+
+	_, err = s.uniter.SecretValues("missing")
+	c.Assert(err, gc.ErrorMatches, `secret "missing" not found`)
+	c.Assert(params.IsCodeNotFound(err), jc.IsTrue)
+}
+
+func (s *secretsSuite) TestSecretRevisions(c *gc.C) {
+	secret := s.addSecret(c, "db-password")
+	s.addSecret(c, "other")
+	err := secret.Grant("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	err = secret.Rotate(map[string]string{"password": "new"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	revisions, err := s.uniter.SecretRevisions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(revisions, jc.DeepEquals, map[string]int{"db-password": 2})
+}
+
+func (s *secretsSuite) TestWatchSecrets(c *gc.C) {
+	w, err := s.uniter.WatchSecrets()
+	c.Assert(err, jc.ErrorIsNil)
+	wc := watchertest.NewNotifyWatcherC(c, w, s.BackingState.StartSync)
+	defer wc.AssertStops()
+
+	// Initial event.
+	wc.AssertOneChange()
+
+	secret := s.addSecret(c, "db-password")
+	wc.AssertOneChange()
+
+	err = secret.Rotate(map[string]string{"password": "new"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
+func (s *secretsSuite) TestSecretsNotImplemented(c *gc.C) {
+	st := newStateForVersion(c, 10)
+	_, err := st.SecretValues("db-password")
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+	_, err = st.SecretRevisions()
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+	_, err = st.WatchSecrets()
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
@@ -10,12 +10,16 @@ import (
 // This module implements a subset of the interface provided by
 // state.Settings, as needed by the uniter API.
 
-// Settings manages changes to unit settings in a relation.
+// Settings manages changes to unit or application settings in a
+// relation.
 type Settings struct {
 	st          *State
 	relationTag string
 	unitTag     string
 	settings    params.Settings
+
+	// writeMethod is the facade method Write calls.
+	writeMethod string
 }
 
 func newSettings(st *State, relationTag, unitTag string, settings params.Settings) *Settings {
@@ -27,9 +31,18 @@ func newSettings(st *State, relationTag, unitTag string, settings params.Setting
 		relationTag: relationTag,
 		unitTag:     unitTag,
 		settings:    settings,
+		writeMethod: "UpdateSettings",
 	}
 }
 
+// newApplicationSettings returns a Settings that manages changes to
+// the application-level settings of the given unit's application.
+func newApplicationSettings(st *State, relationTag, unitTag string, settings params.Settings) *Settings {
+	s := newSettings(st, relationTag, unitTag, settings)
+	s.writeMethod = "UpdateApplicationSettings"
+	return s
+}
+
 // Map returns all keys and values of the node.
 //
 // TODO(dimitern): This differes from state.Settings.Map() - it does
@@ -88,7 +101,7 @@ func (s *Settings) Write() error {
 			Settings: settingsCopy,
 		}},
 	}
-	err := s.st.facade.FacadeCall("UpdateSettings", args, &result)
+	err := s.st.facade.FacadeCall(s.writeMethod, args, &result)
 	if err != nil {
 		return err
 	}
@@ -4,6 +4,7 @@
 package uniter_test
 
 import (
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
@@ -36,6 +37,23 @@ func (s *stateSuite) TestProviderType(c *gc.C) {
 	c.Assert(providerType, gc.DeepEquals, cfg.Type())
 }
 
+func (s *stateSuite) TestCloudSpec(c *gc.C) {
+	_, err := s.uniter.CloudSpec()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	c.Assert(params.IsCodeUnauthorized(err), jc.IsTrue)
+
+	err = s.wordpressService.SetTrusted()
+	c.Assert(err, jc.ErrorIsNil)
+	cloudSpec, err := s.uniter.CloudSpec()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cloudSpec.Type, gc.Equals, "dummy")
+}
+
+func (s *stateSuite) TestCloudSpecNotImplemented(c *gc.C) {
+	_, err := newStateForVersion(c, 9).CloudSpec()
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
+
 func (s *stateSuite) TestAllMachinePorts(c *gc.C) {
 	// Verify no ports are opened yet on the machine or unit.
 	machinePorts, err := s.wordpressMachine.AllPorts()
@@ -304,6 +304,94 @@ func (u *Unit) AssignedMachine() (names.MachineTag, error) {
 	return names.ParseMachineTag(result.Result)
 }
 
+// GoalState returns the units expected for the unit's application and
+// for every application related to it.
+func (u *Unit) GoalState() (params.GoalState, error) {
+	if u.st.BestAPIVersion() < 7 {
+		return params.GoalState{}, errors.NotImplementedf("unit.GoalState() (need V7+)")
+	}
+	var results params.GoalStateResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("GoalStates", args, &results)
+	if err != nil {
+		return params.GoalState{}, err
+	}
+	if len(results.Results) != 1 {
+		return params.GoalState{}, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.GoalState{}, result.Error
+	}
+	return *result.Result, nil
+}
+
+// CharmState returns the key/value state that the unit's charm has
+// stored in the controller.
+func (u *Unit) CharmState() (map[string]string, error) {
+	if u.st.BestAPIVersion() < 9 {
+		return nil, errors.NotImplementedf("unit.CharmState() (need V9+)")
+	}
+	var results params.CharmStateResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("CharmState", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != 1 {
+		return nil, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.State, nil
+}
+
+// SetCharmState replaces the key/value state that the unit's charm
+// has stored in the controller.
+func (u *Unit) SetCharmState(charmState map[string]string) error {
+	if u.st.BestAPIVersion() < 9 {
+		return errors.NotImplementedf("unit.SetCharmState() (need V9+)")
+	}
+	var results params.ErrorResults
+	args := params.SetCharmStateArgs{
+		Args: []params.SetCharmStateArg{{
+			Tag:   u.tag.String(),
+			State: charmState,
+		}},
+	}
+	err := u.st.facade.FacadeCall("SetCharmState", args, &results)
+	if err != nil {
+		return err
+	}
+	return results.OneError()
+}
+
+// RecordHookExecution adds the hook or action execution to the unit's
+// hook history on the controller.
+func (u *Unit) RecordHookExecution(execution params.HookExecution) error {
+	if u.st.BestAPIVersion() < 12 {
+		return errors.NotImplementedf("unit.RecordHookExecution() (need V12+)")
+	}
+	var results params.ErrorResults
+	args := params.RecordHookExecutionArgs{
+		Args: []params.RecordHookExecutionArg{{
+			Tag:       u.tag.String(),
+			Execution: execution,
+		}},
+	}
+	err := u.st.facade.FacadeCall("RecordHookExecutions", args, &results)
+	if err != nil {
+		return err
+	}
+	return results.OneError()
+}
+
 // IsPrincipal returns whether the unit is deployed in its own container,
 // and can therefore have subordinate services deployed alongside it.
 //
@@ -425,25 +513,33 @@ func (u *Unit) AvailabilityZone() (string, error) {
 // OpenPorts sets the policy of the port range with protocol to be
 // opened.
 func (u *Unit) OpenPorts(protocol string, fromPort, toPort int) error {
-	var result params.ErrorResults
-	args := params.EntitiesPortRanges{
-		Entities: []params.EntityPortRange{{
-			Tag:      u.tag.String(),
-			Protocol: protocol,
-			FromPort: fromPort,
-			ToPort:   toPort,
-		}},
-	}
-	err := u.st.facade.FacadeCall("OpenPorts", args, &result)
-	if err != nil {
-		return err
-	}
-	return result.OneError()
+	return u.OpenPortsOnEndpoint("", protocol, fromPort, toPort)
+}
+
+// OpenPortsOnEndpoint sets the policy of the port range with protocol
+// to be opened for the named endpoint of the unit, or for all of its
+// endpoints if the name is empty.
+func (u *Unit) OpenPortsOnEndpoint(endpoint, protocol string, fromPort, toPort int) error {
+	return u.portsCall("OpenPorts", endpoint, protocol, fromPort, toPort)
 }
 
 // ClosePorts sets the policy of the port range with protocol to be
 // closed.
 func (u *Unit) ClosePorts(protocol string, fromPort, toPort int) error {
+	return u.ClosePortsOnEndpoint("", protocol, fromPort, toPort)
+}
+
+// ClosePortsOnEndpoint sets the policy of the port range with protocol
+// to be closed for the named endpoint of the unit, or for all of its
+// endpoints if the name is empty.
+func (u *Unit) ClosePortsOnEndpoint(endpoint, protocol string, fromPort, toPort int) error {
+	return u.portsCall("ClosePorts", endpoint, protocol, fromPort, toPort)
+}
+
+func (u *Unit) portsCall(method, endpoint, protocol string, fromPort, toPort int) error {
+	if endpoint != "" && u.st.BestAPIVersion() < 13 {
+		return errors.NotImplementedf("%s() for an endpoint (need V13+)", method)
+	}
 	var result params.ErrorResults
 	args := params.EntitiesPortRanges{
 		Entities: []params.EntityPortRange{{
@@ -451,9 +547,10 @@ func (u *Unit) ClosePorts(protocol string, fromPort, toPort int) error {
 			Protocol: protocol,
 			FromPort: fromPort,
 			ToPort:   toPort,
+			Endpoint: endpoint,
 		}},
 	}
-	err := u.st.facade.FacadeCall("ClosePorts", args, &result)
+	err := u.st.facade.FacadeCall(method, args, &result)
 	if err != nil {
 		return err
 	}
@@ -697,7 +794,11 @@ func (u *Unit) AddStorage(constraints map[string][]params.StorageConstraints) er
 	all := make([]params.StorageAddParams, 0, len(constraints))
 	for storage, cons := range constraints {
 		for _, one := range cons {
-			all = append(all, params.StorageAddParams{u.Tag().String(), storage, one})
+			all = append(all, params.StorageAddParams{
+				UnitTag:     u.Tag().String(),
+				StorageName: storage,
+				Constraints: one,
+			})
 		}
 	}
 
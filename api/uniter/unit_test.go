@@ -261,6 +261,33 @@ func (s *unitSuite) TestAssignedMachine(c *gc.C) {
 	c.Assert(machineTag, gc.Equals, s.wordpressMachine.Tag())
 }
 
+func (s *unitSuite) TestGoalState(c *gc.C) {
+	s.addMachineServiceCharmAndUnit(c, "mysql")
+	s.addRelation(c, "wordpress", "mysql")
+	now := time.Now()
+	err := s.wordpressUnit.SetStatus(status.StatusInfo{
+		Status: status.Active,
+		Since:  &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	goalState, err := s.apiUnit.GoalState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(goalState.Units, gc.HasLen, 1)
+	c.Assert(goalState.Units["wordpress/0"].Status, gc.Equals, "active")
+	c.Assert(goalState.Relations, gc.HasLen, 1)
+	related := goalState.Relations["db"]
+	c.Assert(related, gc.HasLen, 2)
+	c.Assert(related["mysql"], jc.DeepEquals, params.GoalStateStatus{Status: "joined"})
+	c.Assert(related["mysql/0"].Status, gc.Not(gc.Equals), "")
+}
+
+func (s *unitSuite) TestGoalStateNotImplemented(c *gc.C) {
+	unit := uniter.CreateUnit(newStateForVersion(c, 6), s.wordpressUnit.UnitTag())
+	_, err := unit.GoalState()
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
+
 func (s *unitSuite) TestIsPrincipal(c *gc.C) {
 	ok, err := s.apiUnit.IsPrincipal()
 	c.Assert(err, jc.ErrorIsNil)
@@ -401,6 +428,25 @@ func (s *unitSuite) TestOpenClosePortRanges(c *gc.C) {
 	c.Assert(ports, gc.HasLen, 0)
 }
 
+func (s *unitSuite) TestOpenClosePortsOnEndpoint(c *gc.C) {
+	err := s.apiUnit.OpenPortsOnEndpoint("db", "tcp", 1234, 1400)
+	c.Assert(err, jc.ErrorIsNil)
+
+	machinePorts, err := s.wordpressMachine.AllPorts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machinePorts, gc.HasLen, 1)
+	c.Assert(machinePorts[0].PortRanges(), jc.DeepEquals, []state.PortRange{
+		{s.wordpressUnit.Name(), 1234, 1400, "tcp", "db"},
+	})
+
+	err = s.apiUnit.ClosePortsOnEndpoint("db", "tcp", 1234, 1400)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ports, err := s.wordpressUnit.OpenedPorts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports, gc.HasLen, 0)
+}
+
 func (s *unitSuite) TestGetSetCharmURL(c *gc.C) {
 	// No charm URL set yet.
 	curl, ok := s.wordpressUnit.CharmURL()
@@ -886,3 +932,63 @@ func (s *unitMetricBatchesSuite) TestSendMetricBatch(c *gc.C) {
 	c.Assert(batches[0].Metrics()[0].Key, gc.Equals, "pings")
 	c.Assert(batches[0].Metrics()[0].Value, gc.Equals, "5")
 }
+
+func (s *unitSuite) TestCharmState(c *gc.C) {
+	charmState, err := s.apiUnit.CharmState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, gc.HasLen, 0)
+
+	err = s.apiUnit.SetCharmState(map[string]string{"foo": "bar"})
+	c.Assert(err, jc.ErrorIsNil)
+	charmState, err = s.apiUnit.CharmState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, jc.DeepEquals, map[string]string{"foo": "bar"})
+
+	charmState, err = s.wordpressUnit.CharmState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, jc.DeepEquals, map[string]string{"foo": "bar"})
+}
+
+func (s *unitSuite) TestCharmStateNotImplemented(c *gc.C) {
+	unit := uniter.CreateUnit(newStateForVersion(c, 8), s.wordpressUnit.UnitTag())
+	_, err := unit.CharmState()
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+	err = unit.SetCharmState(map[string]string{"foo": "bar"})
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
+
+func (s *unitSuite) TestRecordHookExecution(c *gc.C) {
+	started := time.Date(2017, time.June, 1, 10, 0, 0, 0, time.UTC)
+	err := s.apiUnit.RecordHookExecution(params.HookExecution{
+		Kind:     "action",
+		Name:     "backup",
+		Started:  started,
+		Finished: started.Add(time.Minute),
+		ToolCalls: []params.HookToolCall{{
+			Name:     "action-set",
+			Started:  started.Add(time.Second),
+			Duration: time.Millisecond,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	executions, err := s.wordpressUnit.HookExecutions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(executions, jc.DeepEquals, []state.HookExecution{{
+		Kind:     "action",
+		Name:     "backup",
+		Started:  started,
+		Finished: started.Add(time.Minute),
+		ToolCalls: []state.HookToolCall{{
+			Name:     "action-set",
+			Started:  started.Add(time.Second),
+			Duration: time.Millisecond,
+		}},
+	}})
+}
+
+func (s *unitSuite) TestRecordHookExecutionNotImplemented(c *gc.C) {
+	unit := uniter.CreateUnit(newStateForVersion(c, 11), s.wordpressUnit.UnitTag())
+	err := unit.RecordHookExecution(params.HookExecution{Kind: "action", Name: "backup"})
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
@@ -69,12 +69,12 @@ func newStateForVersionFn(version int) func(base.APICaller, names.UnitTag) *Stat
 	}
 }
 
-// newStateV4 creates a new client-side Uniter facade, version 4.
-var newStateV4 = newStateForVersionFn(4)
+// newStateV13 creates a new client-side Uniter facade, version 13.
+var newStateV13 = newStateForVersionFn(13)
 
 // NewState creates a new client-side Uniter facade.
 // Defined like this to allow patching during tests.
-var NewState = newStateV4
+var NewState = newStateV13
 
 // BestAPIVersion returns the API version that we were able to
 // determine is supported by both the client and the API Server.
@@ -185,6 +185,24 @@ func (st *State) ProviderType() (string, error) {
 	return result.Result, nil
 }
 
+// CloudSpec returns the cloud spec, including the cloud credential, of
+// the current juju model. Only units of trusted applications may
+// obtain it.
+func (st *State) CloudSpec() (*params.CloudSpec, error) {
+	if st.BestAPIVersion() < 10 {
+		return nil, errors.NotImplementedf("CloudSpec() (need V10+)")
+	}
+	var result params.CloudSpecResult
+	err := st.facade.FacadeCall("CloudSpec", nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	if err := result.Error; err != nil {
+		return nil, err
+	}
+	return result.Result, nil
+}
+
 // Charm returns the charm with the given URL.
 func (st *State) Charm(curl *charm.URL) (*Charm, error) {
 	if curl == nil {
@@ -217,8 +235,9 @@ func (st *State) Action(tag names.ActionTag) (*Action, error) {
 		return nil, err
 	}
 	return &Action{
-		name:   result.Action.Name,
-		params: result.Action.Parameters,
+		name:    result.Action.Name,
+		params:  result.Action.Parameters,
+		timeout: result.Action.Timeout,
 	}, nil
 }
 
@@ -246,6 +265,60 @@ func (st *State) ActionBegin(tag names.ActionTag) error {
 	return nil
 }
 
+// ActionStatus returns the current status of an action.
+func (st *State) ActionStatus(tag names.ActionTag) (string, error) {
+	if st.BestAPIVersion() < 5 {
+		return "", errors.NotImplementedf("ActionStatus() (need V5+)")
+	}
+	var results params.StringResults
+
+	args := params.Entities{
+		Entities: []params.Entity{
+			{Tag: tag.String()},
+		},
+	}
+
+	err := st.facade.FacadeCall("ActionStatus", args, &results)
+	if err != nil {
+		return "", err
+	}
+	if len(results.Results) != 1 {
+		return "", fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return "", result.Error
+	}
+	return result.Result, nil
+}
+
+// LogActionMessage records a progress message for a running action.
+func (st *State) LogActionMessage(tag names.ActionTag, message string) error {
+	if st.BestAPIVersion() < 6 {
+		return errors.NotImplementedf("LogActionMessage() (need V6+)")
+	}
+	var outcome params.ErrorResults
+
+	args := params.ActionMessageParams{
+		Messages: []params.ActionMessageArg{
+			{ActionTag: tag.String(), Message: message},
+		},
+	}
+
+	err := st.facade.FacadeCall("LogActionsMessages", args, &outcome)
+	if err != nil {
+		return err
+	}
+	if len(outcome.Results) != 1 {
+		return fmt.Errorf("expected 1 result, got %d", len(outcome.Results))
+	}
+	result := outcome.Results[0]
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
 // ActionFinish captures the structured output of an action.
 func (st *State) ActionFinish(tag names.ActionTag, status string, results map[string]interface{}, message string) error {
 	var outcome params.ErrorResults
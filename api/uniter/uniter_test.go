@@ -11,6 +11,7 @@ import (
 
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/base"
+	basetesting "github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/api/uniter"
 	"github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
@@ -132,3 +133,15 @@ func (s *uniterSuite) patchNewState(
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(s.uniter, gc.NotNil)
 }
+
+// newStateForVersion returns a client-side Uniter facade for the
+// specified version, whose API caller fails the test if it is used.
+func newStateForVersion(c *gc.C, version int) *uniter.State {
+	apiCaller := basetesting.APICallerFunc(
+		func(_ string, _ int, _, request string, _, _ interface{}) error {
+			c.Fatalf("unexpected API call %q", request)
+			return nil
+		},
+	)
+	return uniter.NewStateForVersion(apiCaller, names.NewUnitTag("wordpress/0"), version)
+}
@@ -272,6 +272,12 @@ func copyRelationUnitsChanged(src params.RelationUnitsChange) watcher.RelationUn
 			}
 		}
 	}
+	if src.AppChanged != nil {
+		dst.AppChanged = make(map[string]int64)
+		for name, version := range src.AppChanged {
+			dst.AppChanged[name] = version
+		}
+	}
 	return dst
 }
 
@@ -15,9 +15,39 @@ import (
 )
 
 func init() {
-	common.RegisterStandardFacade("Action", 2, NewActionAPI)
+	common.RegisterStandardFacade("Action", 2, NewActionAPIV2)
+	// Version 3 adds AddSchedules, ListSchedules, SetSchedulesEnabled
+	// and RemoveSchedules.
+	common.RegisterStandardFacade("Action", 3, NewActionAPI)
 }
 
+// ActionAPIV2 implements version 2 of the Action API, which does not
+// have the action schedule methods.
+type ActionAPIV2 struct {
+	*ActionAPI
+}
+
+// NewActionAPIV2 returns an initialized ActionAPIV2.
+func NewActionAPIV2(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*ActionAPIV2, error) {
+	api, err := NewActionAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ActionAPIV2{api}, nil
+}
+
+// AddSchedules isn't on the v2 API.
+func (*ActionAPIV2) AddSchedules(_, _ struct{}) {}
+
+// ListSchedules isn't on the v2 API.
+func (*ActionAPIV2) ListSchedules(_, _ struct{}) {}
+
+// SetSchedulesEnabled isn't on the v2 API.
+func (*ActionAPIV2) SetSchedulesEnabled(_, _ struct{}) {}
+
+// RemoveSchedules isn't on the v2 API.
+func (*ActionAPIV2) RemoveSchedules(_, _ struct{}) {}
+
 // ActionAPI implements the client API for interacting with Actions
 type ActionAPI struct {
 	state      *state.State
@@ -177,7 +207,7 @@ func (a *ActionAPI) Enqueue(arg params.Actions) (params.ActionResults, error) {
 			currentResult.Error = common.ServerError(err)
 			continue
 		}
-		enqueued, err := receiver.AddAction(action.Name, action.Parameters)
+		enqueued, err := receiver.AddActionWithTimeout(action.Name, action.Parameters, action.Timeout)
 		if err != nil {
 			currentResult.Error = common.ServerError(err)
 			continue
@@ -232,7 +262,9 @@ func (a *ActionAPI) ListCompleted(arg params.Entities) (params.ActionsByReceiver
 	return a.internalList(arg, completedActions)
 }
 
-// Cancel attempts to cancel enqueued Actions from running.
+// Cancel attempts to cancel Actions. Pending Actions are removed from
+// the queue; running Actions are marked as aborting, and are stopped
+// by their receivers.
 func (a *ActionAPI) Cancel(arg params.Entities) (params.ActionResults, error) {
 	if err := a.checkCanWrite(); err != nil {
 		return params.ActionResults{}, errors.Trace(err)
@@ -260,7 +292,7 @@ func (a *ActionAPI) Cancel(arg params.Entities) (params.ActionResults, error) {
 			currentResult.Error = common.ServerError(err)
 			continue
 		}
-		result, err := action.Finish(state.ActionResults{Status: state.ActionCancelled, Message: "action cancelled via the API"})
+		result, err := action.Cancel()
 		if err != nil {
 			currentResult.Error = common.ServerError(err)
 			continue
@@ -276,6 +308,94 @@ func (a *ActionAPI) Cancel(arg params.Entities) (params.ActionResults, error) {
 	return response, nil
 }
 
+// AddSchedules adds schedules on which Actions are enqueued on units,
+// or on all units of applications.
+func (a *ActionAPI) AddSchedules(arg params.ActionSchedules) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	if err := a.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	response := params.ErrorResults{Results: make([]params.ErrorResult, len(arg.Schedules))}
+	for i, schedule := range arg.Schedules {
+		receiver, err := names.ParseTag(schedule.Receiver)
+		if err != nil {
+			response.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		_, err = a.state.AddActionSchedule(state.ActionScheduleArgs{
+			Name:       schedule.Name,
+			Receiver:   receiver,
+			ActionName: schedule.ActionName,
+			Parameters: schedule.Parameters,
+			Schedule:   schedule.Schedule,
+			Timeout:    schedule.Timeout,
+		})
+		response.Results[i].Error = common.ServerError(err)
+	}
+	return response, nil
+}
+
+// ListSchedules returns all of the action schedules in the model.
+func (a *ActionAPI) ListSchedules() (params.ActionSchedules, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.ActionSchedules{}, errors.Trace(err)
+	}
+
+	schedules, err := a.state.AllActionSchedules()
+	if err != nil {
+		return params.ActionSchedules{}, errors.Trace(err)
+	}
+	response := params.ActionSchedules{Schedules: make([]params.ActionSchedule, len(schedules))}
+	for i, schedule := range schedules {
+		response.Schedules[i] = common.MakeActionSchedule(schedule)
+	}
+	return response, nil
+}
+
+// SetSchedulesEnabled enables or disables action schedules.
+func (a *ActionAPI) SetSchedulesEnabled(arg params.ActionSchedulesEnabled) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	if err := a.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	response := params.ErrorResults{Results: make([]params.ErrorResult, len(arg.Schedules))}
+	for i, arg := range arg.Schedules {
+		schedule, err := a.state.ActionSchedule(arg.Name)
+		if err == nil {
+			err = schedule.SetEnabled(arg.Enabled)
+		}
+		response.Results[i].Error = common.ServerError(err)
+	}
+	return response, nil
+}
+
+// RemoveSchedules removes action schedules. Actions already enqueued
+// by the schedules are unaffected.
+func (a *ActionAPI) RemoveSchedules(arg params.ActionScheduleNames) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	if err := a.check.RemoveAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	response := params.ErrorResults{Results: make([]params.ErrorResult, len(arg.Names))}
+	for i, name := range arg.Names {
+		err := a.state.RemoveActionSchedule(name)
+		response.Results[i].Error = common.ServerError(err)
+	}
+	return response, nil
+}
+
 // ApplicationsCharmsActions returns a slice of charm Actions for a slice of
 // services.
 func (a *ActionAPI) ApplicationsCharmsActions(args params.Entities) (params.ApplicationsCharmActionsResults, error) {
@@ -5,7 +5,9 @@ package action_test
 
 import (
 	"fmt"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -18,6 +20,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/rpc/rpcreflect"
 	"github.com/juju/juju/state"
 	coretesting "github.com/juju/juju/testing"
 	jujuFactory "github.com/juju/juju/testing/factory"
@@ -161,6 +164,30 @@ func (s *actionSuite) TestActions(c *gc.C) {
 	}
 }
 
+func (s *actionSuite) TestEnqueueWithTimeout(c *gc.C) {
+	arg := params.Actions{Actions: []params.Action{{
+		Receiver: s.wordpressUnit.Tag().String(),
+		Name:     "fakeaction",
+		Timeout:  30 * time.Second,
+	}, {
+		Receiver: s.wordpressUnit.Tag().String(),
+		Name:     "fakeaction",
+		Timeout:  -time.Second,
+	}}}
+	r, err := s.action.Enqueue(arg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(r.Results, gc.HasLen, 2)
+	c.Assert(r.Results[0].Error, gc.IsNil)
+	c.Assert(r.Results[0].Action.Timeout, gc.Equals, 30*time.Second)
+	c.Assert(r.Results[1].Error, gc.ErrorMatches, "negative action timeout -1s not valid")
+
+	actionTag, err := names.ParseActionTag(r.Results[0].Action.Tag)
+	c.Assert(err, jc.ErrorIsNil)
+	action, err := s.State.ActionByTag(actionTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(action.Timeout(), gc.Equals, 30*time.Second)
+}
+
 func (s *actionSuite) TestFindActionTagsByPrefix(c *gc.C) {
 	// NOTE: full testing with multiple matches has been moved to state package.
 	arg := params.Actions{Actions: []params.Action{{Receiver: s.wordpressUnit.Tag().String(), Name: "fakeaction", Parameters: map[string]interface{}{}}}}
@@ -630,6 +657,120 @@ func (s *actionSuite) TestCancel(c *gc.C) {
 	c.Assert(myActions[1].Status, gc.Equals, params.ActionCancelled)
 }
 
+func (s *actionSuite) TestCancelRunning(c *gc.C) {
+	results, err := s.action.Enqueue(params.Actions{
+		Actions: []params.Action{{
+			Receiver: s.wordpressUnit.Tag().String(),
+			Name:     "fakeaction",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+
+	actionTag, err := names.ParseActionTag(results.Results[0].Action.Tag)
+	c.Assert(err, jc.ErrorIsNil)
+	action, err := s.State.ActionByTag(actionTag)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = action.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	arg := params.Entities{Entities: []params.Entity{{Tag: actionTag.String()}}}
+	results, err = s.action.Cancel(arg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Status, gc.Equals, params.ActionAborting)
+
+	// Cancelling an action that has finished is an error.
+	_, err = action.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+	results, err = s.action.Cancel(arg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `cannot cancel action ".*": action is completed`)
+}
+
+func (s *actionSuite) TestAPIV2MasksSchedules(c *gc.C) {
+	v2 := rpcreflect.ObjTypeOf(reflect.TypeOf(&action.ActionAPIV2{}))
+	v3 := rpcreflect.ObjTypeOf(reflect.TypeOf(&action.ActionAPI{}))
+	for _, name := range []string{"AddSchedules", "ListSchedules", "SetSchedulesEnabled", "RemoveSchedules"} {
+		_, err := v2.Method(name)
+		c.Check(err, gc.Equals, rpcreflect.ErrMethodNotFound, gc.Commentf("%s", name))
+		_, err = v3.Method(name)
+		c.Check(err, jc.ErrorIsNil, gc.Commentf("%s", name))
+	}
+}
+
+func (s *actionSuite) TestBlockAddSchedules(c *gc.C) {
+	s.BlockAllChanges(c, "AddSchedules")
+	_, err := s.action.AddSchedules(params.ActionSchedules{})
+	s.AssertBlocked(c, err, "AddSchedules")
+}
+
+func (s *actionSuite) TestSchedules(c *gc.C) {
+	r, err := s.action.AddSchedules(params.ActionSchedules{Schedules: []params.ActionSchedule{{
+		Name:       "hourly-fake",
+		Receiver:   s.wordpress.Tag().String(),
+		ActionName: "fakeaction",
+		Schedule:   "@hourly",
+		Timeout:    time.Minute,
+	}, {
+		Name:       "bad-schedule",
+		Receiver:   s.wordpressUnit.Tag().String(),
+		ActionName: "fakeaction",
+		Schedule:   "whenever",
+	}, {
+		Name:       "bad-receiver",
+		Receiver:   "wordpress",
+		ActionName: "fakeaction",
+		Schedule:   "@hourly",
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(r.Results, gc.HasLen, 3)
+	c.Assert(r.Results[0].Error, gc.IsNil)
+	c.Assert(r.Results[1].Error, gc.ErrorMatches, `cannot add action schedule: schedule "whenever" .* not valid`)
+	c.Assert(r.Results[2].Error, gc.ErrorMatches, `"wordpress" is not a valid tag`)
+
+	schedules, err := s.action.ListSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedules.Schedules, gc.HasLen, 1)
+	schedule := schedules.Schedules[0]
+	c.Assert(schedule.Name, gc.Equals, "hourly-fake")
+	c.Assert(schedule.Receiver, gc.Equals, s.wordpress.Tag().String())
+	c.Assert(schedule.ActionName, gc.Equals, "fakeaction")
+	c.Assert(schedule.Schedule, gc.Equals, "@hourly")
+	c.Assert(schedule.Timeout, gc.Equals, time.Minute)
+	c.Assert(schedule.Enabled, jc.IsTrue)
+	c.Assert(schedule.NextRun.Minute(), gc.Equals, 0)
+
+	r, err = s.action.SetSchedulesEnabled(params.ActionSchedulesEnabled{Schedules: []params.ActionScheduleEnabled{{
+		Name: "hourly-fake", Enabled: false,
+	}, {
+		Name: "missing", Enabled: false,
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(r.Results, gc.HasLen, 2)
+	c.Assert(r.Results[0].Error, gc.IsNil)
+	c.Assert(r.Results[1].Error, gc.ErrorMatches, `action schedule "missing" not found`)
+
+	schedules, err = s.action.ListSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedules.Schedules, gc.HasLen, 1)
+	c.Assert(schedules.Schedules[0].Enabled, jc.IsFalse)
+	c.Assert(schedules.Schedules[0].NextRun.IsZero(), jc.IsTrue)
+
+	r, err = s.action.RemoveSchedules(params.ActionScheduleNames{Names: []string{"hourly-fake", "missing"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(r.Results, gc.HasLen, 2)
+	c.Assert(r.Results[0].Error, gc.IsNil)
+	c.Assert(r.Results[1].Error, gc.ErrorMatches, `action schedule "missing" not found`)
+
+	schedules, err = s.action.ListSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedules.Schedules, gc.HasLen, 0)
+}
+
 func (s *actionSuite) TestApplicationsCharmsActions(c *gc.C) {
 	actionSchemas := map[string]map[string]interface{}{
 		"snapshot": {
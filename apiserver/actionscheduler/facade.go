@@ -0,0 +1,79 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler
+
+import (
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// Backend exposes functionality required by Facade.
+type Backend interface {
+
+	// WatchActionSchedules returns a watcher that notifies when
+	// action schedules are added, changed or removed.
+	WatchActionSchedules() state.NotifyWatcher
+
+	// ActionSchedules returns all action schedules in the model.
+	ActionSchedules() ([]params.ActionSchedule, error)
+
+	// RunActionSchedule enqueues the actions for the named
+	// schedule, if it is enabled and due to run.
+	RunActionSchedule(name string) error
+}
+
+// Facade allows model-manager clients to watch and run action
+// schedules.
+type Facade struct {
+	backend   Backend
+	resources facade.Resources
+}
+
+// NewFacade creates a new authorized Facade.
+func NewFacade(backend Backend, res facade.Resources, auth facade.Authorizer) (*Facade, error) {
+	if !auth.AuthController() {
+		return nil, common.ErrPerm
+	}
+	return &Facade{
+		backend:   backend,
+		resources: res,
+	}, nil
+}
+
+// Watch returns a watcher that notifies when action schedules are
+// added, changed or removed.
+func (facade *Facade) Watch() (params.NotifyWatchResult, error) {
+	watch := facade.backend.WatchActionSchedules()
+	if _, ok := <-watch.Changes(); ok {
+		return params.NotifyWatchResult{
+			NotifyWatcherId: facade.resources.Register(watch),
+		}, nil
+	}
+	return params.NotifyWatchResult{}, watcher.EnsureErr(watch)
+}
+
+// Schedules returns all action schedules in the model.
+func (facade *Facade) Schedules() (params.ActionSchedules, error) {
+	schedules, err := facade.backend.ActionSchedules()
+	if err != nil {
+		return params.ActionSchedules{}, err
+	}
+	return params.ActionSchedules{Schedules: schedules}, nil
+}
+
+// Run enqueues the actions for each of the named schedules that is
+// enabled and due to run, and records the outcome.
+func (facade *Facade) Run(args params.ActionScheduleNames) params.ErrorResults {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Names)),
+	}
+	for i, name := range args.Names {
+		err := facade.backend.RunActionSchedule(name)
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result
+}
@@ -0,0 +1,98 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/actionscheduler"
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type FacadeSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&FacadeSuite{})
+
+func (s *FacadeSuite) TestModelManager(c *gc.C) {
+	facade, err := actionscheduler.NewFacade(nil, nil, mockAuth{modelManager: true})
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(facade, gc.NotNil)
+}
+
+func (s *FacadeSuite) TestNotModelManager(c *gc.C) {
+	facade, err := actionscheduler.NewFacade(nil, nil, mockAuth{})
+	c.Check(err, gc.Equals, common.ErrPerm)
+	c.Check(facade, gc.IsNil)
+}
+
+func (s *FacadeSuite) TestWatchError(c *gc.C) {
+	resources := common.NewResources()
+	facade, err := actionscheduler.NewFacade(&mockBackend{}, resources, mockAuth{modelManager: true})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := facade.Watch()
+	c.Check(err, gc.ErrorMatches, "blammo")
+	c.Check(result, gc.DeepEquals, params.NotifyWatchResult{})
+	c.Check(resources.Count(), gc.Equals, 0)
+}
+
+func (s *FacadeSuite) TestWatchSuccess(c *gc.C) {
+	resources := common.NewResources()
+	facade, err := actionscheduler.NewFacade(&mockBackend{working: true}, resources, mockAuth{modelManager: true})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := facade.Watch()
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(resources.Count(), gc.Equals, 1)
+	c.Check(resources.Get(result.NotifyWatcherId), gc.NotNil)
+}
+
+func (s *FacadeSuite) TestSchedules(c *gc.C) {
+	schedules := []params.ActionSchedule{{
+		Name:       "nightly-backup",
+		Receiver:   "application-mysql",
+		ActionName: "backup",
+		Schedule:   "@daily",
+		Enabled:    true,
+	}}
+	backend := &mockBackend{schedules: schedules}
+	facade, err := actionscheduler.NewFacade(backend, nil, mockAuth{modelManager: true})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := facade.Schedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, params.ActionSchedules{Schedules: schedules})
+}
+
+func (s *FacadeSuite) TestSchedulesError(c *gc.C) {
+	backend := &mockBackend{}
+	backend.SetErrors(errors.New("blammo"))
+	facade, err := actionscheduler.NewFacade(backend, nil, mockAuth{modelManager: true})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = facade.Schedules()
+	c.Check(err, gc.ErrorMatches, "blammo")
+}
+
+func (s *FacadeSuite) TestRun(c *gc.C) {
+	backend := &mockBackend{}
+	backend.SetErrors(nil, errors.NotFoundf("action schedule %q", "missing"))
+	facade, err := actionscheduler.NewFacade(backend, nil, mockAuth{modelManager: true})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result := facade.Run(params.ActionScheduleNames{Names: []string{"nightly-backup", "missing"}})
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Check(result.Results[0].Error, gc.IsNil)
+	c.Check(result.Results[1].Error, jc.Satisfies, params.IsCodeNotFound)
+	backend.CheckCalls(c, []testing.StubCall{
+		{"RunActionSchedule", []interface{}{"nightly-backup"}},
+		{"RunActionSchedule", []interface{}{"missing"}},
+	})
+}
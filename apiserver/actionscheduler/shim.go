@@ -0,0 +1,70 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// This file contains untested shims to let us wrap state in a sensible
+// interface and avoid writing tests that depend on mongodb. If you were
+// to change any part of it so that it were no longer *obviously* and
+// *trivially* correct, you would be Doing It Wrong.
+
+func init() {
+	common.RegisterStandardFacade("ActionScheduler", 1, newFacade)
+}
+
+// newFacade wraps the supplied *state.State for the use of the Facade.
+func newFacade(st *state.State, res facade.Resources, auth facade.Authorizer) (*Facade, error) {
+	return NewFacade(backendShim{st}, res, auth)
+}
+
+// backendShim wraps a *State to implement Backend without pulling in
+// direct mongodb dependencies.
+type backendShim struct {
+	st *state.State
+}
+
+// WatchActionSchedules is part of the Backend interface.
+func (shim backendShim) WatchActionSchedules() state.NotifyWatcher {
+	return shim.st.WatchActionSchedules()
+}
+
+// ActionSchedules is part of the Backend interface.
+func (shim backendShim) ActionSchedules() ([]params.ActionSchedule, error) {
+	schedules, err := shim.st.AllActionSchedules()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]params.ActionSchedule, len(schedules))
+	for i, schedule := range schedules {
+		result[i] = common.MakeActionSchedule(schedule)
+	}
+	return result, nil
+}
+
+// RunActionSchedule is part of the Backend interface.
+func (shim backendShim) RunActionSchedule(name string) error {
+	schedule, err := shim.st.ActionSchedule(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !schedule.Enabled() {
+		return errors.Errorf("action schedule %q is disabled", name)
+	}
+	next, err := schedule.Next()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if next.IsZero() || next.After(shim.st.NowToTheSecond()) {
+		return errors.Errorf("action schedule %q is not due", name)
+	}
+	return schedule.Run()
+}
@@ -0,0 +1,69 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+
+	"github.com/juju/juju/apiserver/actionscheduler"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// mockAuth implements facade.Authorizer for the tests' convenience.
+type mockAuth struct {
+	facade.Authorizer
+	modelManager bool
+}
+
+func (mock mockAuth) AuthController() bool {
+	return mock.modelManager
+}
+
+// mockWatcher implements state.NotifyWatcher for the tests' convenience.
+type mockWatcher struct {
+	state.NotifyWatcher
+	working bool
+}
+
+func (mock *mockWatcher) Changes() <-chan struct{} {
+	ch := make(chan struct{}, 1)
+	if mock.working {
+		ch <- struct{}{}
+	} else {
+		close(ch)
+	}
+	return ch
+}
+
+func (mock *mockWatcher) Err() error {
+	return errors.New("blammo")
+}
+
+// mockBackend implements actionscheduler.Backend for the tests'
+// convenience.
+type mockBackend struct {
+	testing.Stub
+	working   bool
+	schedules []params.ActionSchedule
+}
+
+var _ actionscheduler.Backend = (*mockBackend)(nil)
+
+func (mock *mockBackend) WatchActionSchedules() state.NotifyWatcher {
+	mock.MethodCall(mock, "WatchActionSchedules")
+	return &mockWatcher{working: mock.working}
+}
+
+func (mock *mockBackend) ActionSchedules() ([]params.ActionSchedule, error) {
+	mock.MethodCall(mock, "ActionSchedules")
+	return mock.schedules, mock.NextErr()
+}
+
+func (mock *mockBackend) RunActionSchedule(name string) error {
+	mock.MethodCall(mock, "RunActionSchedule", name)
+	return mock.NextErr()
+}
@@ -54,7 +54,7 @@ func (a *admin) login(req params.LoginRequest, loginVersion int) (params.LoginRe
 	}
 
 	// apiRoot is the API root exposed to the client after authentication.
-	var apiRoot rpc.Root = newAPIRoot(a.root.state, a.srv.statePool, a.root.resources, a.root)
+	var apiRoot rpc.Root = newAPIRoot(a.root.state, a.srv.statePool, a.root.resources, a.root, a.srv.auditEntrySink)
 
 	// Use the login validation function, if one was specified.
 	if a.srv.validator != nil {
@@ -149,6 +149,14 @@ func (a *admin) login(req params.LoginRequest, loginVersion int) (params.LoginRe
 	}
 
 	var maybeUserInfo *params.AuthUserInfo
+	// releaseConnection releases the connection acquired from the
+	// user limiter, unless the login succeeds.
+	var releaseConnection func()
+	defer func() {
+		if releaseConnection != nil {
+			releaseConnection()
+		}
+	}()
 	// Send back user info if user
 	if isUser {
 		userTag := entity.Tag().(names.UserTag)
@@ -157,6 +165,28 @@ func (a *admin) login(req params.LoginRequest, loginVersion int) (params.LoginRe
 			return fail, errors.Trace(err)
 		}
 		maybeUserInfo.LastConnection = lastConnection
+
+		if a.srv.userLimiter.enabled() {
+			var modelUUID string
+			if !controllerOnlyLogin {
+				modelUUID = a.root.state.ModelUUID()
+			}
+			releaseConnection, err = a.srv.userLimiter.acquireConnection(userTag, modelUUID)
+			if err != nil {
+				logger.Debugf("limiting connections for user %s: %v", userTag.Id(), err)
+				return fail, err
+			}
+			if a.srv.userLimiter.limitsRequests() {
+				apiRoot = restrictRoot(apiRoot, func(facadeName, _ string) error {
+					if facadeName == "Pinger" {
+						// Never prevent clients from keeping
+						// their connections alive.
+						return nil
+					}
+					return a.srv.userLimiter.checkRequest(userTag, modelUUID)
+				})
+			}
+		}
 	} else {
 		if controllerOnlyLogin {
 			logger.Debugf("controller login: %s", entity.Tag())
@@ -205,6 +235,11 @@ func (a *admin) login(req params.LoginRequest, loginVersion int) (params.LoginRe
 		apiRoot = restrictRoot(apiRoot, modelFacadesOnly)
 	}
 
+	if releaseConnection != nil {
+		// Hold the connection until the client disconnects.
+		a.root.getResources().Register(limitedConnection{releaseConnection})
+		releaseConnection = nil
+	}
 	a.root.rpcConn.ServeRoot(apiRoot, serverError)
 
 	return loginResult, nil
@@ -458,6 +458,84 @@ func (s *loginSuite) TestUsersAreNotRateLimited(c *gc.C) {
 	}
 }
 
+func (s *loginSuite) TestUserConnectionLimit(c *gc.C) {
+	cfg := defaultServerConfig(c, s.State)
+	cfg.RateLimits = apiserver.RateLimitConfig{UserMaxConnections: 1}
+	info, srv := newServerWithConfig(c, s.State, cfg)
+	defer assertStop(c, srv)
+
+	info.Tag = s.AdminUserTag(c)
+	info.Password = "dummy-secret"
+	info.ModelTag = s.State.ModelTag()
+
+	st, err := api.Open(info, fastDialOpts)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The second connection is refused until the first is
+	// closed, and the client retries until it is accepted.
+	errResults, wg := startNLogins(c, 1, info)
+	select {
+	case err := <-errResults:
+		c.Fatalf("second login should not have completed yet: %v", err)
+	case <-time.After(coretesting.ShortWait):
+	}
+	err = st.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case err := <-errResults:
+		c.Check(err, jc.ErrorIsNil)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for second login")
+	}
+	wg.Wait()
+}
+
+func (s *loginSuite) TestAgentsNotConnectionLimited(c *gc.C) {
+	cfg := defaultServerConfig(c, s.State)
+	cfg.RateLimits = apiserver.RateLimitConfig{
+		UserMaxConnections:  1,
+		ModelMaxConnections: 1,
+	}
+	info, srv := newServerWithConfig(c, s.State, cfg)
+	defer assertStop(c, srv)
+
+	machine, password := s.Factory.MakeMachineReturningPassword(
+		c, &factory.MachineParams{Nonce: "fake_nonce"})
+	info.Tag = machine.Tag()
+	info.Password = password
+	info.Nonce = "fake_nonce"
+	info.ModelTag = s.State.ModelTag()
+
+	for i := 0; i < 2; i++ {
+		st, err := api.Open(info, fastDialOpts)
+		c.Assert(err, jc.ErrorIsNil)
+		defer st.Close()
+	}
+}
+
+func (s *loginSuite) TestUserRequestRateLimit(c *gc.C) {
+	cfg := defaultServerConfig(c, s.State)
+	cfg.RateLimits = apiserver.RateLimitConfig{UserRequestRate: 2}
+	info, srv := newServerWithConfig(c, s.State, cfg)
+	defer assertStop(c, srv)
+
+	info.Tag = s.AdminUserTag(c)
+	info.Password = "dummy-secret"
+	info.ModelTag = s.State.ModelTag()
+
+	st, err := api.Open(info, fastDialOpts)
+	c.Assert(err, jc.ErrorIsNil)
+	defer st.Close()
+
+	// Requests beyond the limit are rejected by the server, and the
+	// client backs off and retries them until they succeed.
+	client := st.Client()
+	for i := 0; i < 3; i++ {
+		_, err := client.GetModelConstraints()
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
 func (s *loginSuite) TestNonModelUserLoginFails(c *gc.C) {
 	info, srv := newServer(c, s.State)
 	defer assertStop(c, srv)
@@ -7,6 +7,8 @@
 package agent
 
 import (
+	"encoding/base64"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
@@ -121,6 +123,11 @@ func (api *AgentAPIV2) StateServingInfo() (result params.StateServingInfo, err e
 		SharedSecret:   info.SharedSecret,
 		SystemIdentity: info.SystemIdentity,
 	}
+	// The secrets key is not stored in Mongo, so new controllers
+	// take it from the one serving this request.
+	if key := api.st.SecretsKey(); len(key) > 0 {
+		result.SecretsKey = base64.StdEncoding.EncodeToString(key)
+	}
 
 	return result, nil
 }
@@ -11,13 +11,15 @@ package apiserver
 // place, not scattering it across packages and depending on magic import lists.
 import (
 	_ "github.com/juju/juju/apiserver/action" // ModelUser Write
+	_ "github.com/juju/juju/apiserver/actionscheduler"
 	_ "github.com/juju/juju/apiserver/agent"
 	_ "github.com/juju/juju/apiserver/agenttools"
 	_ "github.com/juju/juju/apiserver/annotations" // ModelUser Write
 	_ "github.com/juju/juju/apiserver/application" // ModelUser Write
 	_ "github.com/juju/juju/apiserver/applicationscaler"
-	_ "github.com/juju/juju/apiserver/backups" // ModelUser Write
-	_ "github.com/juju/juju/apiserver/block"   // ModelUser Write
+	_ "github.com/juju/juju/apiserver/auditlog" // Controller Superuser
+	_ "github.com/juju/juju/apiserver/backups"  // ModelUser Write
+	_ "github.com/juju/juju/apiserver/block"    // ModelUser Write
 	_ "github.com/juju/juju/apiserver/bundle"
 	_ "github.com/juju/juju/apiserver/charmrevisionupdater"
 	_ "github.com/juju/juju/apiserver/charms" // ModelUser Write
@@ -31,6 +33,7 @@ import (
 	_ "github.com/juju/juju/apiserver/diskmanager"
 	_ "github.com/juju/juju/apiserver/firewaller"
 	_ "github.com/juju/juju/apiserver/highavailability" // ModelUser Write
+	_ "github.com/juju/juju/apiserver/hookhistory"      // ModelUser Read
 	_ "github.com/juju/juju/apiserver/hostkeyreporter"
 	_ "github.com/juju/juju/apiserver/imagemanager" // ModelUser Write
 	_ "github.com/juju/juju/apiserver/imagemetadata"
@@ -66,6 +69,7 @@ import (
 	_ "github.com/juju/juju/apiserver/resourceshookcontext"
 	_ "github.com/juju/juju/apiserver/resumer"
 	_ "github.com/juju/juju/apiserver/retrystrategy"
+	_ "github.com/juju/juju/apiserver/secrets" // ModelUser Admin
 	_ "github.com/juju/juju/apiserver/singular"
 	_ "github.com/juju/juju/apiserver/spaces"    // ModelUser Write
 	_ "github.com/juju/juju/apiserver/sshclient" // ModelUser Write
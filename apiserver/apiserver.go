@@ -24,6 +24,8 @@ import (
 	"github.com/juju/pubsub"
 	"github.com/juju/utils"
 	"github.com/juju/utils/clock"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"golang.org/x/crypto/acme"
 	"golang.org/x/crypto/acme/autocert"
 	"gopkg.in/juju/names.v2"
@@ -35,11 +37,13 @@ import (
 	"github.com/juju/juju/apiserver/common/apihttp"
 	"github.com/juju/juju/apiserver/observer"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/audit"
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/resource/resourceadapters"
 	"github.com/juju/juju/rpc"
 	"github.com/juju/juju/rpc/jsoncodec"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/modelmetrics"
 )
 
 var logger = loggo.GetLogger("juju.apiserver")
@@ -63,6 +67,7 @@ type Server struct {
 	dataDir           string
 	logDir            string
 	limiter           utils.Limiter
+	userLimiter       *userLimiter
 	validator         LoginValidator
 	adminAPIFactories map[int]adminAPIFactory
 	modelUUID         string
@@ -70,6 +75,7 @@ type Server struct {
 	lastConnectionID  uint64
 	centralHub        *pubsub.StructuredHub
 	newObserver       observer.ObserverFactory
+	auditEntrySink    audit.AuditEntrySinkFn
 	connCount         int64
 	certChanged       <-chan params.StateServingInfo
 	tlsConfig         *tls.Config
@@ -130,6 +136,11 @@ type ServerConfig struct {
 	// notified of key events during API requests.
 	NewObserver observer.ObserverFactory
 
+	// AuditEntrySink, if non-nil, receives the audit entries recorded
+	// by facades, for events the observers cannot see. It is nil when
+	// auditing is disabled.
+	AuditEntrySink audit.AuditEntrySinkFn
+
 	// StatePool is created by the machine agent and passed in.
 	StatePool *state.StatePool
 
@@ -138,6 +149,10 @@ type ServerConfig struct {
 	// is to support registering the handlers underneath the
 	// "/introspection" prefix.
 	RegisterIntrospectionHandlers func(func(string, http.Handler))
+
+	// RateLimits holds the limits applied to the API requests
+	// and connections of users.
+	RateLimits RateLimitConfig
 }
 
 func (c *ServerConfig) Validate() error {
@@ -205,6 +220,7 @@ func newServer(s *state.State, lis net.Listener, cfg ServerConfig) (_ *Server, e
 		dataDir:     cfg.DataDir,
 		logDir:      cfg.LogDir,
 		limiter:     utils.NewLimiter(loginRateLimit),
+		userLimiter: newUserLimiter(cfg.Clock, cfg.RateLimits),
 		validator:   cfg.Validator,
 		adminAPIFactories: map[int]adminAPIFactory{
 			3: newAdminAPIV3,
@@ -212,6 +228,7 @@ func newServer(s *state.State, lis net.Listener, cfg ServerConfig) (_ *Server, e
 		centralHub:                    cfg.Hub,
 		certChanged:                   cfg.CertChanged,
 		allowModelAccess:              cfg.AllowModelAccess,
+		auditEntrySink:                cfg.AuditEntrySink,
 		registerIntrospectionHandlers: cfg.RegisterIntrospectionHandlers,
 	}
 
@@ -575,6 +592,16 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 		srv.registerIntrospectionHandlers(handle)
 	}
 
+	// Serve the per-model metrics, which are collected from the
+	// controller's state on each scrape, with the same access
+	// requirements as the introspection endpoints.
+	modelMetrics := prometheus.NewRegistry()
+	modelMetrics.MustRegister(modelmetrics.New(modelmetrics.NewState(srv.state)))
+	add("/introspection/model-metrics", introspectionHandler{
+		httpCtxt,
+		promhttp.HandlerFor(modelMetrics, promhttp.HandlerOpts{}),
+	})
+
 	// Add HTTP handlers for local-user macaroon authentication.
 	localLoginHandlers := &localLoginHandlers{srv.authCtxt, srv.state}
 	dischargeMux := http.NewServeMux()
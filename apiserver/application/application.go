@@ -35,16 +35,88 @@ var logger = loggo.GetLogger("juju.apiserver.application")
 func init() {
 	// TODO - version 1 is required for the legacy deployer,
 	// remove when deploy is updated.
-	common.RegisterStandardFacade("Application", 1, newAPI)
-	common.RegisterStandardFacade("Application", 2, newAPI)
+	common.RegisterStandardFacade("Application", 1, newAPIv5)
+	common.RegisterStandardFacade("Application", 2, newAPIv5)
 	// Version 3 adds support for cross model relations.
-	common.RegisterStandardFacade("Application", 3, newAPI)
+	common.RegisterStandardFacade("Application", 3, newAPIv5)
 	// Version 4 adds the DestroyUnit and DestroyApplication
 	// methods, superseding the existing DestroyUnits and
 	// Destroy methods respectively.
-	common.RegisterStandardFacade("Application", 4, newAPI)
+	common.RegisterStandardFacade("Application", 4, newAPIv5)
+	// Version 5 adds expose settings to the Expose and Unexpose
+	// methods.
+	common.RegisterStandardFacade("Application", 5, newAPIv5)
+	// Version 6 adds the SetEgressRules and GetEgressRules methods.
+	common.RegisterStandardFacade("Application", 6, newAPIv6)
+	// Version 7 adds AttachStorage to the Deploy method.
+	common.RegisterStandardFacade("Application", 7, newAPIv7)
+	// Version 8 adds the SetTrust method.
+	common.RegisterStandardFacade("Application", 8, newAPI)
 }
 
+// APIv5 provides the Application API facade for versions 1-5,
+// which do not have the methods added by later versions.
+type APIv5 struct {
+	*APIv6
+}
+
+// APIv6 provides the Application API facade for version 6.
+type APIv6 struct {
+	*APIv7
+}
+
+// APIv7 provides the Application API facade for version 7.
+type APIv7 struct {
+	*API
+}
+
+func newAPIv5(ctx facade.Context) (*APIv5, error) {
+	api, err := newAPIv6(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv5{api}, nil
+}
+
+func newAPIv6(ctx facade.Context) (*APIv6, error) {
+	api, err := newAPIv7(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv6{api}, nil
+}
+
+func newAPIv7(ctx facade.Context) (*APIv7, error) {
+	api, err := newAPI(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv7{api}, nil
+}
+
+// Deploy fetches the charms from the charm store and deploys them
+// using the specified placement directives. Attaching existing storage
+// is not supported by the v6 API.
+func (api *APIv6) Deploy(args params.ApplicationsDeploy) (params.ErrorResults, error) {
+	for _, arg := range args.Applications {
+		if len(arg.AttachStorage) > 0 {
+			return params.ErrorResults{}, errors.NotSupportedf("attaching existing storage in this version of the API")
+		}
+	}
+	return api.APIv7.Deploy(args)
+}
+
+// SetEgressRules isn't on the v5 API. Methods with more than one
+// argument are not exposed by the RPC layer, so this hides the
+// method of the embedded API.
+func (*APIv5) SetEgressRules(_, _ struct{}) {}
+
+// GetEgressRules isn't on the v5 API.
+func (*APIv5) GetEgressRules(_, _ struct{}) {}
+
+// SetTrust isn't on the v7 API.
+func (*APIv7) SetTrust(_, _ struct{}) {}
+
 // API implements the application interface and is the concrete
 // implementation of the api end point.
 type API struct {
@@ -226,6 +298,21 @@ func deployApplication(
 		return errors.Trace(err)
 	}
 
+	var attachStorage []names.StorageTag
+	if len(args.AttachStorage) > 0 {
+		if args.NumUnits != 1 {
+			return errors.New("AttachStorage is non-empty, but NumUnits is not 1")
+		}
+		attachStorage = make([]names.StorageTag, len(args.AttachStorage))
+		for i, tagString := range args.AttachStorage {
+			tag, err := names.ParseStorageTag(tagString)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			attachStorage[i] = tag
+		}
+	}
+
 	return errors.Trace(deployApplicationFunc(backend, jjj.DeployApplicationParams{
 		ApplicationName:  args.ApplicationName,
 		Series:           args.Series,
@@ -236,6 +323,7 @@ func deployApplication(
 		Constraints:      args.Constraints,
 		Placement:        args.Placement,
 		Storage:          args.Storage,
+		AttachStorage:    attachStorage,
 		EndpointBindings: args.EndpointBindings,
 		Resources:        args.Resources,
 	}))
@@ -575,7 +663,9 @@ func (api *API) CharmRelations(p params.ApplicationCharmRelations) (params.Appli
 }
 
 // Expose changes the juju-managed firewall to expose any ports that
-// were also explicitly marked by units as open.
+// were also explicitly marked by units as open. If expose settings
+// are specified, access to the ports is limited to the specified
+// spaces and CIDRs.
 func (api *API) Expose(args params.ApplicationExpose) error {
 	if err := api.checkCanWrite(); err != nil {
 		return err
@@ -587,11 +677,22 @@ func (api *API) Expose(args params.ApplicationExpose) error {
 	if err != nil {
 		return err
 	}
-	return app.SetExposed()
+	if len(args.ExposedEndpoints) == 0 {
+		return app.SetExposed()
+	}
+	exposed := make(map[string]state.ExposedEndpoint, len(args.ExposedEndpoints))
+	for name, settings := range args.ExposedEndpoints {
+		exposed[name] = state.ExposedEndpoint{
+			ExposeToSpaces: settings.ExposeToSpaces,
+			ExposeToCIDRs:  settings.ExposeToCIDRs,
+		}
+	}
+	return app.MergeExposeSettings(exposed)
 }
 
 // Unexpose changes the juju-managed firewall to unexpose any ports that
-// were also explicitly marked by units as open.
+// were also explicitly marked by units as open. If endpoints are
+// specified, only the expose settings for those endpoints are removed.
 func (api *API) Unexpose(args params.ApplicationUnexpose) error {
 	if err := api.checkCanWrite(); err != nil {
 		return err
@@ -603,9 +704,71 @@ func (api *API) Unexpose(args params.ApplicationUnexpose) error {
 	if err != nil {
 		return err
 	}
+	if len(args.ExposedEndpoints) > 0 {
+		return app.UnsetExposeSettings(args.ExposedEndpoints)
+	}
 	return app.ClearExposed()
 }
 
+// SetTrust grants or revokes an application's access to the cloud
+// credential of the model. Only model administrators may change it.
+func (api *API) SetTrust(args params.ApplicationSetTrust) error {
+	isAdmin, err := api.authorizer.HasPermission(permission.AdminAccess, api.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !isAdmin {
+		return common.ErrPerm
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	app, err := api.backend.Application(args.ApplicationName)
+	if err != nil {
+		return err
+	}
+	if args.Trust {
+		return app.SetTrusted()
+	}
+	return app.ClearTrusted()
+}
+
+// SetEgressRules replaces the egress rules set for an application by
+// operators. Only model administrators may change them.
+func (api *API) SetEgressRules(args params.ApplicationSetEgressRules) error {
+	isAdmin, err := api.authorizer.HasPermission(permission.AdminAccess, api.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !isAdmin {
+		return common.ErrPerm
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	app, err := api.backend.Application(args.ApplicationName)
+	if err != nil {
+		return err
+	}
+	return app.SetEgressRules(params.NetworkEgressRules(args.Rules))
+}
+
+// GetEgressRules returns the egress rules set for an application by
+// operators and by its charm.
+func (api *API) GetEgressRules(args params.ApplicationGet) (params.ApplicationEgressRulesResult, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.ApplicationEgressRulesResult{}, err
+	}
+	app, err := api.backend.Application(args.ApplicationName)
+	if err != nil {
+		return params.ApplicationEgressRulesResult{}, err
+	}
+	return params.ApplicationEgressRulesResult{
+		Rules:      params.FromNetworkEgressRules(app.EgressRules()),
+		CharmRules: params.FromNetworkEgressRules(app.CharmEgressRules()),
+	}, nil
+}
+
 // addApplicationUnits adds a given number of units to an application.
 func addApplicationUnits(backend Backend, args params.AddApplicationUnits) ([]*state.Unit, error) {
 	application, err := backend.Application(args.ApplicationName)
@@ -31,6 +31,7 @@ import (
 	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/instance"
 	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	statestorage "github.com/juju/juju/state/storage"
 	"github.com/juju/juju/status"
@@ -313,6 +314,51 @@ func (s *applicationSuite) TestApplicationDeployDefaultFilesystemStorage(c *gc.C
 	})
 }
 
+func (s *applicationSuite) TestApplicationDeployAttachStorage(c *gc.C) {
+	storageTag, err := s.State.AddExistingFilesystem(state.FilesystemInfo{
+		Pool: "modelscoped-block",
+	}, &state.VolumeInfo{
+		VolumeId: "vol-123",
+		Size:     1024,
+	}, "data")
+	c.Assert(err, jc.ErrorIsNil)
+
+	curl, _ := s.UploadCharm(c, "trusty/storage-filesystem-1", "storage-filesystem")
+	err = application.AddCharmWithAuthorization(s.State, params.AddCharmWithAuthorization{
+		URL: curl.String(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	results, err := s.applicationAPI.Deploy(params.ApplicationsDeploy{
+		Applications: []params.ApplicationDeploy{{
+			ApplicationName: "application",
+			CharmURL:        curl.String(),
+			NumUnits:        1,
+			AttachStorage:   []string{storageTag.String()},
+		}, {
+			ApplicationName: "application-two",
+			CharmURL:        curl.String(),
+			NumUnits:        2,
+			AttachStorage:   []string{storageTag.String()},
+		}, {
+			ApplicationName: "application-three",
+			CharmURL:        curl.String(),
+			NumUnits:        1,
+			AttachStorage:   []string{"data/0"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, "AttachStorage is non-empty, but NumUnits is not 1")
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `"data/0" is not a valid tag`)
+
+	storageInstance, err := s.State.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	owner, ok := storageInstance.Owner()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(owner, gc.Equals, names.NewUnitTag("application/0"))
+}
+
 func (s *applicationSuite) TestApplicationDeploy(c *gc.C) {
 	curl, ch := s.UploadCharm(c, "precise/dummy-42", "dummy")
 	err := application.AddCharmWithAuthorization(s.State, params.AddCharmWithAuthorization{
@@ -1828,7 +1874,7 @@ func (s *applicationSuite) TestApplicationExpose(c *gc.C) {
 	c.Assert(apps[1].IsExposed(), jc.IsTrue)
 	for i, t := range applicationExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err = s.applicationAPI.Expose(params.ApplicationExpose{t.application})
+		err = s.applicationAPI.Expose(params.ApplicationExpose{ApplicationName: t.application})
 		if t.err != "" {
 			c.Assert(err, gc.ErrorMatches, t.err)
 		} else {
@@ -1840,6 +1886,52 @@ func (s *applicationSuite) TestApplicationExpose(c *gc.C) {
 	}
 }
 
+func (s *applicationSuite) TestApplicationExposeEndpoints(c *gc.C) {
+	app := s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	err := s.applicationAPI.Expose(params.ApplicationExpose{
+		ApplicationName: "mysql",
+		ExposedEndpoints: map[string]params.ExposedEndpoint{
+			"server": {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.IsExposed(), jc.IsTrue)
+	c.Assert(app.ExposedEndpoints(), jc.DeepEquals, map[string]state.ExposedEndpoint{
+		"server": {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+	})
+
+	err = s.applicationAPI.Expose(params.ApplicationExpose{
+		ApplicationName: "mysql",
+		ExposedEndpoints: map[string]params.ExposedEndpoint{
+			"foo": {},
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot expose application "mysql": endpoint "foo" not found`)
+}
+
+func (s *applicationSuite) TestApplicationUnexposeEndpoints(c *gc.C) {
+	app := s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	err := app.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"":       {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+		"server": {ExposeToCIDRs: []string{"10.0.1.0/24"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.applicationAPI.Unexpose(params.ApplicationUnexpose{
+		ApplicationName:  "mysql",
+		ExposedEndpoints: []string{"server"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.IsExposed(), jc.IsTrue)
+	c.Assert(app.ExposedEndpoints(), jc.DeepEquals, map[string]state.ExposedEndpoint{
+		"": {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+	})
+}
+
 func (s *applicationSuite) setupApplicationExpose(c *gc.C) {
 	charm := s.AddTestingCharm(c, "dummy")
 	applicationNames := []string{"dummy-application", "exposed-application"}
@@ -1880,7 +1972,7 @@ var applicationExposeTests = []struct {
 func (s *applicationSuite) assertApplicationExpose(c *gc.C) {
 	for i, t := range applicationExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err := s.applicationAPI.Expose(params.ApplicationExpose{t.application})
+		err := s.applicationAPI.Expose(params.ApplicationExpose{ApplicationName: t.application})
 		if t.err != "" {
 			c.Assert(err, gc.ErrorMatches, t.err)
 		} else {
@@ -1895,7 +1987,7 @@ func (s *applicationSuite) assertApplicationExpose(c *gc.C) {
 func (s *applicationSuite) assertApplicationExposeBlocked(c *gc.C, msg string) {
 	for i, t := range applicationExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err := s.applicationAPI.Expose(params.ApplicationExpose{t.application})
+		err := s.applicationAPI.Expose(params.ApplicationExpose{ApplicationName: t.application})
 		s.AssertBlocked(c, err, msg)
 	}
 }
@@ -1918,6 +2010,145 @@ func (s *applicationSuite) TestBlockChangesApplicationExpose(c *gc.C) {
 	s.assertApplicationExposeBlocked(c, "TestBlockChangesApplicationExpose")
 }
 
+func (s *applicationSuite) TestApplicationSetTrust(c *gc.C) {
+	err := s.applicationAPI.SetTrust(params.ApplicationSetTrust{
+		ApplicationName: s.application.Name(),
+		Trust:           true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.application.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.application.IsTrusted(), jc.IsTrue)
+
+	err = s.applicationAPI.SetTrust(params.ApplicationSetTrust{
+		ApplicationName: s.application.Name(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.application.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.application.IsTrusted(), jc.IsFalse)
+
+	err = s.applicationAPI.SetTrust(params.ApplicationSetTrust{
+		ApplicationName: "unknown-application",
+		Trust:           true,
+	})
+	c.Assert(err, gc.ErrorMatches, `application "unknown-application" not found`)
+}
+
+func (s *applicationSuite) TestApplicationSetTrustRequiresAdmin(c *gc.C) {
+	writer := names.NewUserTag("writer")
+	authorizer := apiservertesting.FakeAuthorizer{
+		Tag:         writer,
+		HasWriteTag: writer,
+	}
+	api, err := application.NewAPI(
+		application.NewStateBackend(s.State), authorizer, common.NewResources(), s.BackingStatePool,
+		common.NewBlockChecker(s.State), application.CharmToStateCharm,
+		application.DeployApplication,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	err = api.SetTrust(params.ApplicationSetTrust{
+		ApplicationName: s.application.Name(),
+		Trust:           true,
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	err = s.application.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.application.IsTrusted(), jc.IsFalse)
+}
+
+func (s *applicationSuite) TestBlockChangesApplicationSetTrust(c *gc.C) {
+	s.BlockAllChanges(c, "TestBlockChangesApplicationSetTrust")
+	err := s.applicationAPI.SetTrust(params.ApplicationSetTrust{
+		ApplicationName: s.application.Name(),
+		Trust:           true,
+	})
+	s.AssertBlocked(c, err, "TestBlockChangesApplicationSetTrust")
+}
+
+func (s *applicationSuite) TestApplicationSetEgressRules(c *gc.C) {
+	err := s.applicationAPI.SetEgressRules(params.ApplicationSetEgressRules{
+		ApplicationName: s.application.Name(),
+		Rules: []params.EgressRule{{
+			PortRange:        params.PortRange{FromPort: 443, ToPort: 443, Protocol: "tcp"},
+			DestinationCIDRs: []string{"10.0.0.0/8"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.application.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.application.EgressRules(), jc.DeepEquals, []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+	})
+	err = s.application.SetCharmEgressRules([]network.EgressRule{
+		network.MustNewEgressRule("udp", 53, 53),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.applicationAPI.GetEgressRules(params.ApplicationGet{
+		ApplicationName: s.application.Name(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ApplicationEgressRulesResult{
+		Rules: []params.EgressRule{{
+			PortRange:        params.PortRange{FromPort: 443, ToPort: 443, Protocol: "tcp"},
+			DestinationCIDRs: []string{"10.0.0.0/8"},
+		}},
+		CharmRules: []params.EgressRule{{
+			PortRange:        params.PortRange{FromPort: 53, ToPort: 53, Protocol: "udp"},
+			DestinationCIDRs: []string{"0.0.0.0/0"},
+		}},
+	})
+
+	err = s.applicationAPI.SetEgressRules(params.ApplicationSetEgressRules{
+		ApplicationName: s.application.Name(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.application.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.application.EgressRules(), gc.IsNil)
+
+	err = s.applicationAPI.SetEgressRules(params.ApplicationSetEgressRules{
+		ApplicationName: "unknown-application",
+	})
+	c.Assert(err, gc.ErrorMatches, `application "unknown-application" not found`)
+}
+
+func (s *applicationSuite) TestApplicationSetEgressRulesRequiresAdmin(c *gc.C) {
+	writer := names.NewUserTag("writer")
+	authorizer := apiservertesting.FakeAuthorizer{
+		Tag:         writer,
+		HasWriteTag: writer,
+	}
+	api, err := application.NewAPI(
+		application.NewStateBackend(s.State), authorizer, common.NewResources(), s.BackingStatePool,
+		common.NewBlockChecker(s.State), application.CharmToStateCharm,
+		application.DeployApplication,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	err = api.SetEgressRules(params.ApplicationSetEgressRules{
+		ApplicationName: s.application.Name(),
+		Rules: []params.EgressRule{{
+			PortRange: params.PortRange{FromPort: 443, ToPort: 443, Protocol: "tcp"},
+		}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	err = s.application.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.application.EgressRules(), gc.IsNil)
+}
+
+func (s *applicationSuite) TestBlockChangesApplicationSetEgressRules(c *gc.C) {
+	s.BlockAllChanges(c, "TestBlockChangesApplicationSetEgressRules")
+	err := s.applicationAPI.SetEgressRules(params.ApplicationSetEgressRules{
+		ApplicationName: s.application.Name(),
+		Rules: []params.EgressRule{{
+			PortRange: params.PortRange{FromPort: 443, ToPort: 443, Protocol: "tcp"},
+		}},
+	})
+	s.AssertBlocked(c, err, "TestBlockChangesApplicationSetEgressRules")
+}
+
 var applicationUnexposeTests = []struct {
 	about       string
 	application string
@@ -1953,7 +2184,7 @@ func (s *applicationSuite) TestApplicationUnexpose(c *gc.C) {
 			app.SetExposed()
 		}
 		c.Assert(app.IsExposed(), gc.Equals, t.initial)
-		err := s.applicationAPI.Unexpose(params.ApplicationUnexpose{t.application})
+		err := s.applicationAPI.Unexpose(params.ApplicationUnexpose{ApplicationName: t.application})
 		if t.err == "" {
 			c.Assert(err, jc.ErrorIsNil)
 			app.Refresh()
@@ -1975,7 +2206,7 @@ func (s *applicationSuite) setupApplicationUnexpose(c *gc.C) *state.Application
 }
 
 func (s *applicationSuite) assertApplicationUnexpose(c *gc.C, app *state.Application) {
-	err := s.applicationAPI.Unexpose(params.ApplicationUnexpose{"dummy-application"})
+	err := s.applicationAPI.Unexpose(params.ApplicationUnexpose{ApplicationName: "dummy-application"})
 	c.Assert(err, jc.ErrorIsNil)
 	app.Refresh()
 	c.Assert(app.IsExposed(), gc.Equals, false)
@@ -1984,7 +2215,7 @@ func (s *applicationSuite) assertApplicationUnexpose(c *gc.C, app *state.Applica
 }
 
 func (s *applicationSuite) assertApplicationUnexposeBlocked(c *gc.C, app *state.Application, msg string) {
-	err := s.applicationAPI.Unexpose(params.ApplicationUnexpose{"dummy-application"})
+	err := s.applicationAPI.Unexpose(params.ApplicationUnexpose{ApplicationName: "dummy-application"})
 	s.AssertBlocked(c, err, msg)
 	err = app.Destroy()
 	c.Assert(err, jc.ErrorIsNil)
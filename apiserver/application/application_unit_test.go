@@ -4,6 +4,8 @@
 package application_test
 
 import (
+	"reflect"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -16,6 +18,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/juju"
+	"github.com/juju/juju/rpc/rpcreflect"
 	"github.com/juju/juju/state"
 	coretesting "github.com/juju/juju/testing"
 )
@@ -466,3 +469,37 @@ func (a *mockStorage) StorageTag() names.StorageTag {
 func (a *mockStorage) Owner() (names.Tag, bool) {
 	return a.owner, a.owner != nil
 }
+
+func (s *ApplicationSuite) TestAPIv5MasksEgressRules(c *gc.C) {
+	v5 := rpcreflect.ObjTypeOf(reflect.TypeOf(&application.APIv5{}))
+	v6 := rpcreflect.ObjTypeOf(reflect.TypeOf(&application.APIv6{}))
+	for _, name := range []string{"SetEgressRules", "GetEgressRules"} {
+		_, err := v5.Method(name)
+		c.Check(err, gc.Equals, rpcreflect.ErrMethodNotFound, gc.Commentf("%s", name))
+		_, err = v6.Method(name)
+		c.Check(err, jc.ErrorIsNil, gc.Commentf("%s", name))
+	}
+}
+
+func (s *ApplicationSuite) TestAPIv7MasksSetTrust(c *gc.C) {
+	v7 := rpcreflect.ObjTypeOf(reflect.TypeOf(&application.APIv7{}))
+	v8 := rpcreflect.ObjTypeOf(reflect.TypeOf(&application.API{}))
+	_, err := v7.Method("SetTrust")
+	c.Check(err, gc.Equals, rpcreflect.ErrMethodNotFound)
+	_, err = v8.Method("SetTrust")
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *ApplicationSuite) TestAPIv6DeployAttachStorageNotSupported(c *gc.C) {
+	api := &application.APIv6{APIv7: &application.APIv7{API: s.api}}
+	_, err := api.Deploy(params.ApplicationsDeploy{
+		Applications: []params.ApplicationDeploy{{
+			ApplicationName: "foo",
+			CharmURL:        "local:foo-0",
+			NumUnits:        1,
+			AttachStorage:   []string{"storage-data-0"},
+		}},
+	})
+	c.Assert(err, gc.ErrorMatches, "attaching existing storage in this version of the API not supported")
+	s.backend.CheckNoCalls(c)
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/storage"
 )
@@ -53,20 +54,27 @@ type Application interface {
 	AddUnit() (*state.Unit, error)
 	AllUnits() ([]Unit, error)
 	Charm() (Charm, bool, error)
+	CharmEgressRules() []network.EgressRule
 	CharmURL() (*charm.URL, bool)
 	Channel() csparams.Channel
 	ClearExposed() error
+	ClearTrusted() error
 	ConfigSettings() (charm.Settings, error)
 	Constraints() (constraints.Value, error)
 	Destroy() error
+	EgressRules() []network.EgressRule
 	Endpoints() ([]state.Endpoint, error)
 	IsPrincipal() bool
+	MergeExposeSettings(map[string]state.ExposedEndpoint) error
 	Series() string
 	SetCharm(state.SetCharmConfig) error
 	SetConstraints(constraints.Value) error
+	SetEgressRules([]network.EgressRule) error
 	SetExposed() error
+	SetTrusted() error
 	SetMetricCredentials([]byte) error
 	SetMinUnits(int) error
+	UnsetExposeSettings([]string) error
 	UpdateConfigSettings(charm.Settings) error
 }
 
@@ -0,0 +1,104 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package auditlog defines an API end point for querying the audit
+// entries recorded by the controller.
+package auditlog
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+func init() {
+	common.RegisterStandardFacade("AuditLog", 1, newFacade)
+}
+
+// Backend defines the state methods used by the AuditLog facade.
+type Backend interface {
+	ControllerTag() names.ControllerTag
+	AuditEntries(audit.Query) ([]audit.AuditEntry, error)
+}
+
+type stateShim struct {
+	*state.State
+}
+
+func (s stateShim) AuditEntries(query audit.Query) ([]audit.AuditEntry, error) {
+	return s.State.GetAuditEntriesFn()(query)
+}
+
+// API implements the AuditLog facade.
+type API struct {
+	backend Backend
+}
+
+func newFacade(st *state.State, _ facade.Resources, auth facade.Authorizer) (*API, error) {
+	return NewAPI(stateShim{st}, auth)
+}
+
+// NewAPI returns a new AuditLog facade. Only controller superusers
+// may query the audit log, as it records activity across every model.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	isAdmin, err := authorizer.HasPermission(permission.SuperuserAccess, backend.ControllerTag())
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+	if !isAdmin {
+		return nil, common.ErrPerm
+	}
+	return &API{backend: backend}, nil
+}
+
+// Query returns the audit entries matching the supplied query,
+// oldest first.
+func (api *API) Query(args params.AuditLogQuery) (params.AuditLogEntries, error) {
+	var result params.AuditLogEntries
+	query := audit.Query{
+		ModelUUID:     args.ModelUUID,
+		Operation:     args.Operation,
+		RemoteAddress: args.RemoteAddress,
+		Limit:         args.Limit,
+	}
+	if args.UserTag != "" {
+		tag, err := names.ParseUserTag(args.UserTag)
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		query.OriginName = tag.String()
+	}
+	if args.From != nil {
+		query.From = *args.From
+	}
+	if args.To != nil {
+		query.To = *args.To
+	}
+	entries, err := api.backend.AuditEntries(query)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Entries = make([]params.AuditLogEntry, len(entries))
+	for i, entry := range entries {
+		result.Entries[i] = params.AuditLogEntry{
+			JujuServerVersion: entry.JujuServerVersion,
+			ModelUUID:         entry.ModelUUID,
+			Timestamp:         entry.Timestamp,
+			RemoteAddress:     entry.RemoteAddress,
+			OriginType:        entry.OriginType,
+			OriginName:        entry.OriginName,
+			Operation:         entry.Operation,
+			Data:              entry.Data,
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,136 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/auditlog"
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/audit"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type auditLogSuite struct {
+	gitjujutesting.IsolationSuite
+	backend    mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&auditLogSuite{})
+
+func (s *auditLogSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("superuser-bob"),
+	}
+	s.backend = mockBackend{}
+}
+
+func (s *auditLogSuite) TestNewAPIRequiresSuperuser(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("admin-bob")
+	_, err := auditlog.NewAPI(&s.backend, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *auditLogSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := auditlog.NewAPI(&s.backend, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *auditLogSuite) TestQuery(c *gc.C) {
+	t0 := time.Date(2017, time.June, 1, 12, 0, 0, 0, time.UTC)
+	t1 := t0.Add(time.Hour)
+	entry := audit.AuditEntry{
+		JujuServerVersion: version.MustParse("2.2.0"),
+		ModelUUID:         coretesting.ModelTag.Id(),
+		Timestamp:         t0,
+		RemoteAddress:     "10.0.0.1:1234",
+		OriginType:        "API request",
+		OriginName:        "user-mary",
+		Operation:         "Application:v4 - Deploy",
+		Data:              map[string]interface{}{"foo": "bar"},
+	}
+	s.backend.entries = []audit.AuditEntry{entry}
+
+	api, err := auditlog.NewAPI(&s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := api.Query(params.AuditLogQuery{
+		UserTag:       "user-mary",
+		ModelUUID:     coretesting.ModelTag.Id(),
+		Operation:     "Application:",
+		RemoteAddress: "10.0.0.1",
+		From:          &t0,
+		To:            &t1,
+		Limit:         10,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCalls(c, []gitjujutesting.StubCall{
+		{"ControllerTag", nil},
+		{"AuditEntries", []interface{}{audit.Query{
+			OriginName:    "user-mary",
+			ModelUUID:     coretesting.ModelTag.Id(),
+			Operation:     "Application:",
+			RemoteAddress: "10.0.0.1",
+			From:          t0,
+			To:            t1,
+			Limit:         10,
+		}}},
+	})
+	c.Assert(result, jc.DeepEquals, params.AuditLogEntries{
+		Entries: []params.AuditLogEntry{{
+			JujuServerVersion: version.MustParse("2.2.0"),
+			ModelUUID:         coretesting.ModelTag.Id(),
+			Timestamp:         t0,
+			RemoteAddress:     "10.0.0.1:1234",
+			OriginType:        "API request",
+			OriginName:        "user-mary",
+			Operation:         "Application:v4 - Deploy",
+			Data:              map[string]interface{}{"foo": "bar"},
+		}},
+	})
+}
+
+func (s *auditLogSuite) TestQueryInvalidUser(c *gc.C) {
+	api, err := auditlog.NewAPI(&s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = api.Query(params.AuditLogQuery{UserTag: "machine-0"})
+	c.Assert(err, gc.ErrorMatches, `"machine-0" is not a valid user tag`)
+}
+
+func (s *auditLogSuite) TestQueryError(c *gc.C) {
+	s.backend.SetErrors(errors.New("boom"))
+	api, err := auditlog.NewAPI(&s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = api.Query(params.AuditLogQuery{})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type mockBackend struct {
+	gitjujutesting.Stub
+	entries []audit.AuditEntry
+}
+
+func (b *mockBackend) ControllerTag() names.ControllerTag {
+	b.MethodCall(b, "ControllerTag")
+	return coretesting.ControllerTag
+}
+
+func (b *mockBackend) AuditEntries(query audit.Query) ([]audit.AuditEntry, error) {
+	b.MethodCall(b, "AuditEntries", query)
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	return b.entries, nil
+}
@@ -10,30 +10,59 @@ import (
 	"github.com/juju/bundlechanges"
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/storage"
 )
 
 // init registers the Bundle facade.
 func init() {
-	common.RegisterStandardFacade("Bundle", 1, newFacade)
+	common.RegisterStandardFacade("Bundle", 1, newFacadeV1)
+	// Version 2 adds ExportBundle and DiffBundle.
+	common.RegisterStandardFacade("Bundle", 2, newFacade)
 }
 
-func newFacade(_ *state.State, _ facade.Resources, auth facade.Authorizer) (Bundle, error) {
-	return NewFacade(auth)
+// Backend defines the state functionality required by the Bundle
+// facade. *state.State satisfies it.
+type Backend interface {
+	ModelTag() names.ModelTag
+	AllApplications() ([]*state.Application, error)
+	AllMachines() ([]*state.Machine, error)
+	AllRelations() ([]*state.Relation, error)
+}
+
+func newFacade(st *state.State, _ facade.Resources, auth facade.Authorizer) (Bundle, error) {
+	return NewFacade(st, auth)
+}
+
+func newFacadeV1(st *state.State, _ facade.Resources, auth facade.Authorizer) (*BundleAPIV1, error) {
+	return NewFacadeV1(st, auth)
+}
+
+// NewFacadeV1 creates and returns a new Bundle API facade, version 1.
+func NewFacadeV1(backend Backend, auth facade.Authorizer) (*BundleAPIV1, error) {
+	api, err := NewFacade(backend, auth)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &BundleAPIV1{api}, nil
 }
 
 // NewFacade creates and returns a new Bundle API facade.
-func NewFacade(auth facade.Authorizer) (Bundle, error) {
+func NewFacade(backend Backend, auth facade.Authorizer) (Bundle, error) {
 	if !auth.AuthClient() {
 		return nil, common.ErrPerm
 	}
-	return &bundleAPI{}, nil
+	return &bundleAPI{
+		backend:    backend,
+		authorizer: auth,
+	}, nil
 }
 
 // Bundle defines the API endpoint used to retrieve bundle changes.
@@ -41,20 +70,66 @@ type Bundle interface {
 	// GetChanges returns the list of changes required to deploy the given
 	// bundle data.
 	GetChanges(params.BundleChangesParams) (params.BundleChangesResults, error)
+
+	// ExportBundle returns the current model rendered as bundle YAML.
+	ExportBundle() (params.StringResult, error)
+
+	// DiffBundle returns the differences between the given bundle
+	// data and the current model.
+	DiffBundle(params.BundleChangesParams) (params.BundleDiffResults, error)
+}
+
+// BundleAPIV1 implements version 1 of the Bundle API, which only has
+// GetChanges.
+type BundleAPIV1 struct {
+	Bundle
 }
 
+// ExportBundle isn't on the v1 API.
+func (*BundleAPIV1) ExportBundle(_, _ struct{}) {}
+
+// DiffBundle isn't on the v1 API.
+func (*BundleAPIV1) DiffBundle(_, _ struct{}) {}
+
 // bundleAPI implements the Bundle interface and is the concrete implementation
 // of the API end point.
-type bundleAPI struct{}
+type bundleAPI struct {
+	backend    Backend
+	authorizer facade.Authorizer
+}
 
 // GetChanges returns the list of changes required to deploy the given bundle
 // data. The changes are sorted by requirements, so that they can be applied in
 // order.
 func (b *bundleAPI) GetChanges(args params.BundleChangesParams) (params.BundleChangesResults, error) {
 	var results params.BundleChangesResults
-	data, err := charm.ReadBundleData(strings.NewReader(args.BundleDataYAML))
+	data, verifyErrors, err := readBundle(args.BundleDataYAML)
 	if err != nil {
-		return results, errors.Annotate(err, "cannot read bundle YAML")
+		return results, errors.Trace(err)
+	}
+	if len(verifyErrors) > 0 {
+		results.Errors = verifyErrors
+		return results, nil
+	}
+	changes := bundlechanges.FromData(data)
+	results.Changes = make([]*params.BundleChange, len(changes))
+	for i, c := range changes {
+		results.Changes[i] = &params.BundleChange{
+			Id:       c.Id(),
+			Method:   c.Method(),
+			Args:     c.GUIArgs(),
+			Requires: c.Requires(),
+		}
+	}
+	return results, nil
+}
+
+// readBundle reads and verifies the given bundle YAML. Verification
+// errors are returned as strings rather than as an error.
+func readBundle(bundleYAML string) (*charm.BundleData, []string, error) {
+	data, err := charm.ReadBundleData(strings.NewReader(bundleYAML))
+	if err != nil {
+		return nil, nil, errors.Annotate(err, "cannot read bundle YAML")
 	}
 	verifyConstraints := func(s string) error {
 		_, err := constraints.Parse(s)
@@ -66,24 +141,64 @@ func (b *bundleAPI) GetChanges(args params.BundleChangesParams) (params.BundleCh
 	}
 	if err := data.Verify(verifyConstraints, verifyStorage); err != nil {
 		if err, ok := err.(*charm.VerificationError); ok {
-			results.Errors = make([]string, len(err.Errors))
+			verifyErrors := make([]string, len(err.Errors))
 			for i, e := range err.Errors {
-				results.Errors[i] = e.Error()
+				verifyErrors[i] = e.Error()
 			}
-			return results, nil
+			return nil, verifyErrors, nil
 		}
 		// This should never happen as Verify only returns verification errors.
-		return results, errors.Annotate(err, "cannot verify bundle")
+		return nil, nil, errors.Annotate(err, "cannot verify bundle")
 	}
-	changes := bundlechanges.FromData(data)
-	results.Changes = make([]*params.BundleChange, len(changes))
-	for i, c := range changes {
-		results.Changes[i] = &params.BundleChange{
-			Id:       c.Id(),
-			Method:   c.Method(),
-			Args:     c.GUIArgs(),
-			Requires: c.Requires(),
-		}
+	return data, nil, nil
+}
+
+// checkCanRead returns an error if the authenticated user cannot read
+// the model.
+func (b *bundleAPI) checkCanRead() error {
+	canRead, err := b.authorizer.HasPermission(permission.ReadAccess, b.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !canRead {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// ExportBundle returns the applications, machines and relations of the
+// current model rendered as bundle YAML that can be passed to
+// "juju deploy".
+func (b *bundleAPI) ExportBundle() (params.StringResult, error) {
+	if err := b.checkCanRead(); err != nil {
+		return params.StringResult{}, err
+	}
+	data, err := exportBundle(b.backend)
+	if err != nil {
+		return params.StringResult{}, errors.Annotate(err, "cannot export bundle")
+	}
+	return params.StringResult{Result: data}, nil
+}
+
+// DiffBundle returns the differences between the given bundle data and
+// the current model.
+func (b *bundleAPI) DiffBundle(args params.BundleChangesParams) (params.BundleDiffResults, error) {
+	var results params.BundleDiffResults
+	if err := b.checkCanRead(); err != nil {
+		return results, err
+	}
+	data, verifyErrors, err := readBundle(args.BundleDataYAML)
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	if len(verifyErrors) > 0 {
+		results.Errors = verifyErrors
+		return results, nil
+	}
+	model, err := modelBundle(b.backend)
+	if err != nil {
+		return results, errors.Annotate(err, "cannot read model")
 	}
+	results.Diff = diffBundle(data, model)
 	return results, nil
 }
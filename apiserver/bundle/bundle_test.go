@@ -4,6 +4,8 @@
 package bundle_test
 
 import (
+	"reflect"
+
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
@@ -11,6 +13,7 @@ import (
 	"github.com/juju/juju/apiserver/bundle"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/rpc/rpcreflect"
 	coretesting "github.com/juju/juju/testing"
 )
 
@@ -26,7 +29,7 @@ func (s *bundleSuite) SetUpTest(c *gc.C) {
 	auth := apiservertesting.FakeAuthorizer{
 		Tag: names.NewUserTag("who"),
 	}
-	facade, err := bundle.NewFacade(auth)
+	facade, err := bundle.NewFacade(nil, auth)
 	c.Assert(err, jc.ErrorIsNil)
 	s.facade = facade
 }
@@ -198,3 +201,21 @@ func (s *bundleSuite) TestGetChangesBundleEndpointBindingsSuccess(c *gc.C) {
 		}
 	}
 }
+
+func (s *bundleSuite) TestV1MasksExportAndDiff(c *gc.C) {
+	auth := apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("who"),
+	}
+	v1, err := bundle.NewFacadeV1(nil, auth)
+	c.Assert(err, jc.ErrorIsNil)
+	v1Type := rpcreflect.ObjTypeOf(reflect.TypeOf(v1))
+	v2Type := rpcreflect.ObjTypeOf(reflect.TypeOf(s.facade))
+	for _, name := range []string{"ExportBundle", "DiffBundle"} {
+		_, err := v1Type.Method(name)
+		c.Check(err, gc.Equals, rpcreflect.ErrMethodNotFound, gc.Commentf("%s", name))
+		_, err = v2Type.Method(name)
+		c.Check(err, jc.ErrorIsNil, gc.Commentf("%s", name))
+	}
+	_, err = v1Type.Method("GetChanges")
+	c.Check(err, jc.ErrorIsNil)
+}
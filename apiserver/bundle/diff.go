@@ -0,0 +1,205 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bundle
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/constraints"
+)
+
+// diffBundle compares the applications and relations of a verified
+// bundle with those of a model.
+func diffBundle(data *charm.BundleData, model *bundleOutput) *params.BundleDiff {
+	result := &params.BundleDiff{}
+	addApplication := func(name string, diff params.BundleApplicationDiff) {
+		if result.Applications == nil {
+			result.Applications = make(map[string]params.BundleApplicationDiff)
+		}
+		result.Applications[name] = diff
+	}
+	for name, spec := range data.Applications {
+		app, ok := model.Applications[name]
+		if !ok {
+			addApplication(name, params.BundleApplicationDiff{Missing: "model"})
+			continue
+		}
+		if diff, changed := diffApplication(data, spec, app); changed {
+			addApplication(name, diff)
+		}
+	}
+	for name := range model.Applications {
+		if _, ok := data.Applications[name]; !ok {
+			addApplication(name, params.BundleApplicationDiff{Missing: "bundle"})
+		}
+	}
+	result.Relations = diffRelations(data.Relations, model.Relations)
+	return result
+}
+
+// diffApplication compares an application in a bundle with the same
+// application in a model, and reports whether they differ.
+func diffApplication(data *charm.BundleData, spec *charm.ApplicationSpec, app *applicationOutput) (params.BundleApplicationDiff, bool) {
+	var diff params.BundleApplicationDiff
+	changed := false
+
+	bundleURL, err := charm.ParseURL(spec.Charm)
+	if err != nil {
+		// The charm is a local path, which can only be compared
+		// by name.
+		bundleURL = &charm.URL{Name: filepath.Base(spec.Charm), Revision: -1}
+	}
+	if modelURL, err := charm.ParseURL(app.Charm); err != nil || !charmMatches(bundleURL, modelURL) {
+		diff.Charm = &params.BundleStringDiff{Bundle: spec.Charm, Model: app.Charm}
+		changed = true
+	}
+
+	series := spec.Series
+	if series == "" {
+		series = bundleURL.Series
+	}
+	if series == "" {
+		series = data.Series
+	}
+	if series != "" && series != app.Series {
+		diff.Series = &params.BundleStringDiff{Bundle: series, Model: app.Series}
+		changed = true
+	}
+
+	if spec.NumUnits != app.NumUnits {
+		diff.NumUnits = &params.BundleIntDiff{Bundle: spec.NumUnits, Model: app.NumUnits}
+		changed = true
+	}
+
+	if spec.Expose != app.Expose {
+		diff.Expose = &params.BundleBoolDiff{Bundle: spec.Expose, Model: app.Expose}
+		changed = true
+	}
+
+	for key, value := range spec.Options {
+		if modelValue := app.Options[key]; !optionEqual(value, modelValue) {
+			if diff.Options == nil {
+				diff.Options = make(map[string]params.BundleValueDiff)
+			}
+			diff.Options[key] = params.BundleValueDiff{Bundle: value, Model: modelValue}
+		}
+	}
+	for key, modelValue := range app.Options {
+		if _, ok := spec.Options[key]; !ok {
+			if diff.Options == nil {
+				diff.Options = make(map[string]params.BundleValueDiff)
+			}
+			diff.Options[key] = params.BundleValueDiff{Model: modelValue}
+		}
+	}
+	if diff.Options != nil {
+		changed = true
+	}
+
+	if bundleCons := normaliseConstraints(spec.Constraints); bundleCons != app.Constraints {
+		diff.Constraints = &params.BundleStringDiff{Bundle: bundleCons, Model: app.Constraints}
+		changed = true
+	}
+	return diff, changed
+}
+
+// charmMatches reports whether a model charm URL satisfies a bundle
+// charm URL, which may omit the series and revision.
+func charmMatches(bundleURL, modelURL *charm.URL) bool {
+	if bundleURL.Name != modelURL.Name {
+		return false
+	}
+	if bundleURL.Schema != "" && bundleURL.Schema != modelURL.Schema {
+		return false
+	}
+	if bundleURL.User != modelURL.User {
+		return false
+	}
+	if bundleURL.Series != "" && bundleURL.Series != modelURL.Series {
+		return false
+	}
+	return bundleURL.Revision < 0 || bundleURL.Revision == modelURL.Revision
+}
+
+// optionEqual reports whether two option values are equal, ignoring
+// differences in numeric types.
+func optionEqual(a, b interface{}) bool {
+	if fa, ok := toFloat(a); ok {
+		fb, ok := toFloat(b)
+		return ok && fa == fb
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch v := v.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
+
+// normaliseConstraints returns the constraints in the form used by the
+// exported model.
+func normaliseConstraints(s string) string {
+	cons, err := constraints.Parse(s)
+	if err != nil {
+		return s
+	}
+	return cons.String()
+}
+
+// diffRelations returns the relations present in only one of the bundle
+// and the model, or nil if they match. Bundle endpoints may omit the
+// relation name, in which case any endpoint of the application matches.
+func diffRelations(bundleRelations, modelRelations [][]string) *params.BundleRelationsDiff {
+	matched := make([]bool, len(modelRelations))
+	var diff params.BundleRelationsDiff
+	for _, rel := range bundleRelations {
+		found := false
+		for i, modelRel := range modelRelations {
+			if !matched[i] && relationMatches(rel, modelRel) {
+				matched[i] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			diff.BundleAdditions = append(diff.BundleAdditions, rel)
+		}
+	}
+	for i, modelRel := range modelRelations {
+		if !matched[i] {
+			diff.ModelAdditions = append(diff.ModelAdditions, modelRel)
+		}
+	}
+	if diff.BundleAdditions == nil && diff.ModelAdditions == nil {
+		return nil
+	}
+	return &diff
+}
+
+func relationMatches(bundleRel, modelRel []string) bool {
+	if len(bundleRel) != 2 || len(modelRel) != 2 {
+		return false
+	}
+	return endpointMatches(bundleRel[0], modelRel[0]) && endpointMatches(bundleRel[1], modelRel[1]) ||
+		endpointMatches(bundleRel[0], modelRel[1]) && endpointMatches(bundleRel[1], modelRel[0])
+}
+
+func endpointMatches(bundleEndpoint, modelEndpoint string) bool {
+	if strings.Contains(bundleEndpoint, ":") {
+		return bundleEndpoint == modelEndpoint
+	}
+	return strings.HasPrefix(modelEndpoint, bundleEndpoint+":")
+}
@@ -0,0 +1,267 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bundle
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/state"
+)
+
+// bundleOutput is the YAML representation of an exported bundle. The
+// field names match those read by charm.ReadBundleData.
+type bundleOutput struct {
+	Applications map[string]*applicationOutput `yaml:"applications"`
+	Machines     map[string]*machineOutput     `yaml:"machines,omitempty"`
+	Relations    [][]string                    `yaml:"relations,omitempty"`
+}
+
+type applicationOutput struct {
+	Charm       string                 `yaml:"charm"`
+	Series      string                 `yaml:"series,omitempty"`
+	NumUnits    int                    `yaml:"num_units,omitempty"`
+	To          []string               `yaml:"to,omitempty"`
+	Expose      bool                   `yaml:"expose,omitempty"`
+	Options     map[string]interface{} `yaml:"options,omitempty"`
+	Constraints string                 `yaml:"constraints,omitempty"`
+	Storage     map[string]string      `yaml:"storage,omitempty"`
+	Bindings    map[string]string      `yaml:"bindings,omitempty"`
+}
+
+type machineOutput struct {
+	Series      string `yaml:"series,omitempty"`
+	Constraints string `yaml:"constraints,omitempty"`
+}
+
+// exportBundle renders the applications, machines and relations known
+// to backend as bundle YAML.
+func exportBundle(backend Backend) (string, error) {
+	data, err := modelBundle(backend)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(data.Applications) == 0 {
+		return "", errors.NotFoundf("applications in model")
+	}
+	out, err := yaml.Marshal(data)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return string(out), nil
+}
+
+// modelBundle returns the bundle representation of the applications,
+// machines and relations known to backend.
+func modelBundle(backend Backend) (*bundleOutput, error) {
+	machines, err := backend.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	machinesById := make(map[string]*state.Machine)
+	for _, m := range machines {
+		machinesById[m.Id()] = m
+	}
+
+	applications, err := backend.AllApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	data := &bundleOutput{
+		Applications: make(map[string]*applicationOutput),
+		Machines:     make(map[string]*machineOutput),
+	}
+	for _, app := range applications {
+		out, placed, err := exportApplication(app)
+		if err != nil {
+			return nil, errors.Annotatef(err, "application %q", app.Name())
+		}
+		data.Applications[app.Name()] = out
+		for _, id := range placed {
+			if _, ok := data.Machines[id]; ok {
+				continue
+			}
+			m, ok := machinesById[id]
+			if !ok {
+				return nil, errors.NotFoundf("machine %s", id)
+			}
+			if data.Machines[id], err = exportMachine(m); err != nil {
+				return nil, errors.Annotatef(err, "machine %s", id)
+			}
+		}
+	}
+
+	if data.Relations, err = exportRelations(backend); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return data, nil
+}
+
+// exportApplication returns the bundle representation of app, along
+// with the ids of the top level machines its units are placed on.
+func exportApplication(app *state.Application) (*applicationOutput, []string, error) {
+	curl, _ := app.CharmURL()
+	out := &applicationOutput{
+		Charm:  curl.String(),
+		Series: app.Series(),
+		Expose: app.IsExposed(),
+	}
+
+	settings, err := app.ConfigSettings()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if len(settings) > 0 {
+		out.Options = settings
+	}
+
+	cons, err := app.Constraints()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	out.Constraints = cons.String()
+
+	storageConstraints, err := app.StorageConstraints()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	for name, sc := range storageConstraints {
+		if out.Storage == nil {
+			out.Storage = make(map[string]string)
+		}
+		out.Storage[name] = formatStorageConstraints(sc)
+	}
+
+	bindings, err := app.EndpointBindings()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	for endpoint, space := range bindings {
+		if space == "" {
+			continue
+		}
+		if out.Bindings == nil {
+			out.Bindings = make(map[string]string)
+		}
+		out.Bindings[endpoint] = space
+	}
+
+	if !app.IsPrincipal() {
+		// Subordinate units are placed by relations, not machines.
+		return out, nil, nil
+	}
+	units, err := app.AllUnits()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	sort.Sort(unitsByNumber(units))
+	out.NumUnits = len(units)
+	var placed []string
+	for _, unit := range units {
+		machineId, err := unit.AssignedMachineId()
+		if errors.IsNotAssigned(err) {
+			// Leave the placement of all units to the deployer
+			// rather than describe it partially.
+			out.To = nil
+			return out, nil, nil
+		} else if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		placement, topLevel := unitPlacement(machineId)
+		out.To = append(out.To, placement)
+		placed = append(placed, topLevel)
+	}
+	return out, placed, nil
+}
+
+// unitPlacement returns the bundle placement directive for a unit
+// assigned to the machine with the given id, along with the id of the
+// top level machine that hosts it. Bundles cannot describe nested
+// containers, so a unit in one is placed in a container on the top
+// level machine.
+func unitPlacement(machineId string) (string, string) {
+	containerType := state.ContainerTypeFromId(machineId)
+	if containerType == "" {
+		return machineId, machineId
+	}
+	topLevel := state.TopParentId(machineId)
+	return fmt.Sprintf("%s:%s", containerType, topLevel), topLevel
+}
+
+func exportMachine(m *state.Machine) (*machineOutput, error) {
+	cons, err := m.Constraints()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &machineOutput{
+		Series:      m.Series(),
+		Constraints: cons.String(),
+	}, nil
+}
+
+// exportRelations returns the non-peer relations in the model as
+// pairs of "application:endpoint" strings, in a stable order.
+func exportRelations(backend Backend) ([][]string, error) {
+	relations, err := backend.AllRelations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var result [][]string
+	for _, rel := range relations {
+		endpoints := rel.Endpoints()
+		if len(endpoints) != 2 {
+			continue
+		}
+		pair := make([]string, len(endpoints))
+		for i, ep := range endpoints {
+			pair[i] = ep.ApplicationName + ":" + ep.Name
+		}
+		sort.Strings(pair)
+		result = append(result, pair)
+	}
+	sort.Sort(relationsByEndpoints(result))
+	return result, nil
+}
+
+// formatStorageConstraints returns sc in the form accepted by the
+// storage directives of a bundle.
+func formatStorageConstraints(sc state.StorageConstraints) string {
+	if sc.Pool == "" {
+		return fmt.Sprintf("%d,%dM", sc.Count, sc.Size)
+	}
+	return fmt.Sprintf("%s,%d,%dM", sc.Pool, sc.Count, sc.Size)
+}
+
+type unitsByNumber []*state.Unit
+
+func (u unitsByNumber) Len() int      { return len(u) }
+func (u unitsByNumber) Swap(i, j int) { u[i], u[j] = u[j], u[i] }
+func (u unitsByNumber) Less(i, j int) bool {
+	return unitNumber(u[i]) < unitNumber(u[j])
+}
+
+// unitNumber returns the sequence number of the unit within its
+// application.
+func unitNumber(unit *state.Unit) int {
+	name := unit.Name()
+	n, _ := strconv.Atoi(name[strings.LastIndex(name, "/")+1:])
+	return n
+}
+
+type relationsByEndpoints [][]string
+
+func (r relationsByEndpoints) Len() int      { return len(r) }
+func (r relationsByEndpoints) Swap(i, j int) { r[i], r[j] = r[j], r[i] }
+func (r relationsByEndpoints) Less(i, j int) bool {
+	if r[i][0] != r[j][0] {
+		return r[i][0] < r[j][0]
+	}
+	return r[i][1] < r[j][1]
+}
@@ -0,0 +1,215 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bundle_test
+
+import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/apiserver/bundle"
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/instance"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/testing/factory"
+)
+
+type exportBundleSuite struct {
+	jujutesting.JujuConnSuite
+	facade bundle.Bundle
+}
+
+var _ = gc.Suite(&exportBundleSuite{})
+
+func (s *exportBundleSuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+	auth := apiservertesting.FakeAuthorizer{
+		Tag:      s.AdminUserTag(c),
+		AdminTag: s.AdminUserTag(c),
+	}
+	facade, err := bundle.NewFacade(s.State, auth)
+	c.Assert(err, jc.ErrorIsNil)
+	s.facade = facade
+}
+
+func (s *exportBundleSuite) TestExportBundleNoApplications(c *gc.C) {
+	_, err := s.facade.ExportBundle()
+	c.Assert(err, gc.ErrorMatches, "cannot export bundle: applications in model not found")
+}
+
+func (s *exportBundleSuite) TestExportBundlePermission(c *gc.C) {
+	auth := apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("stranger"),
+	}
+	facade, err := bundle.NewFacade(s.State, auth)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = facade.ExportBundle()
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *exportBundleSuite) TestExportBundle(c *gc.C) {
+	wordpress := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	err := wordpress.UpdateConfigSettings(charm.Settings{"blog-title": "Exported"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = wordpress.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	err = wordpress.SetConstraints(constraints.MustParse("mem=4G"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	mysql := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Name:  "mysql",
+		Charm: s.AddTestingCharm(c, "mysql"),
+	})
+	logging := s.AddTestingService(c, "logging", s.AddTestingCharm(c, "logging"))
+
+	host := s.Factory.MakeMachine(c, &factory.MachineParams{
+		Constraints: constraints.MustParse("cores=2"),
+	})
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: wordpress, Machine: host})
+	container, err := s.State.AddMachineInsideMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}, host.Id(), instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: mysql, Machine: container})
+
+	for _, pair := range [][]string{{"wordpress", "mysql"}, {"logging", "wordpress"}} {
+		eps, err := s.State.InferEndpoints(pair...)
+		c.Assert(err, jc.ErrorIsNil)
+		_, err = s.State.AddRelation(eps...)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	result, err := s.facade.ExportBundle()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The exported bundle must be deployable.
+	data, err := charm.ReadBundleData(strings.NewReader(result.Result))
+	c.Assert(err, jc.ErrorIsNil)
+	err = data.Verify(
+		func(s string) error { _, err := constraints.Parse(s); return err },
+		func(s string) error { _, err := storage.ParseConstraints(s); return err },
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var exported map[string]interface{}
+	err = yaml.Unmarshal([]byte(result.Result), &exported)
+	c.Assert(err, jc.ErrorIsNil)
+	wordpressURL, _ := wordpress.CharmURL()
+	mysqlURL, _ := mysql.CharmURL()
+	loggingURL, _ := logging.CharmURL()
+	c.Check(exported["applications"], jc.DeepEquals, map[interface{}]interface{}{
+		"wordpress": map[interface{}]interface{}{
+			"charm":       wordpressURL.String(),
+			"series":      "quantal",
+			"num_units":   1,
+			"to":          []interface{}{host.Id()},
+			"expose":      true,
+			"options":     map[interface{}]interface{}{"blog-title": "Exported"},
+			"constraints": "mem=4096M",
+		},
+		"mysql": map[interface{}]interface{}{
+			"charm":     mysqlURL.String(),
+			"series":    "quantal",
+			"num_units": 1,
+			"to":        []interface{}{"lxd:" + host.Id()},
+		},
+		"logging": map[interface{}]interface{}{
+			"charm":  loggingURL.String(),
+			"series": "quantal",
+		},
+	})
+	c.Check(exported["machines"], jc.DeepEquals, map[interface{}]interface{}{
+		host.Id(): map[interface{}]interface{}{
+			"series":      "quantal",
+			"constraints": "cores=2",
+		},
+	})
+	c.Check(exported["relations"], jc.DeepEquals, []interface{}{
+		[]interface{}{"logging:info", "wordpress:juju-info"},
+		[]interface{}{"mysql:server", "wordpress:db"},
+	})
+}
+
+func (s *exportBundleSuite) TestDiffBundle(c *gc.C) {
+	wordpress := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	err := wordpress.UpdateConfigSettings(charm.Settings{"blog-title": "Exported"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = wordpress.SetConstraints(constraints.MustParse("mem=4G"))
+	c.Assert(err, jc.ErrorIsNil)
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: wordpress})
+	mysql := s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: mysql})
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.facade.DiffBundle(params.BundleChangesParams{
+		BundleDataYAML: `
+            applications:
+                wordpress:
+                    charm: local:quantal/wordpress
+                    num_units: 2
+                    options:
+                        blog-title: Imported
+                    constraints: mem=4096M
+                mysql:
+                    charm: local:quantal/mysql-99
+                    num_units: 1
+                haproxy:
+                    charm: cs:trusty/haproxy-42
+            relations:
+                - - wordpress:db
+                  - mysql
+                - - haproxy
+                  - wordpress
+        `,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Errors, gc.HasLen, 0)
+	mysqlURL, _ := mysql.CharmURL()
+	c.Assert(result.Diff, jc.DeepEquals, &params.BundleDiff{
+		Applications: map[string]params.BundleApplicationDiff{
+			"wordpress": {
+				NumUnits: &params.BundleIntDiff{Bundle: 2, Model: 1},
+				Options: map[string]params.BundleValueDiff{
+					"blog-title": {Bundle: "Imported", Model: "Exported"},
+				},
+			},
+			"mysql": {
+				Charm: &params.BundleStringDiff{Bundle: "local:quantal/mysql-99", Model: mysqlURL.String()},
+			},
+			"haproxy": {Missing: "model"},
+		},
+		Relations: &params.BundleRelationsDiff{
+			BundleAdditions: [][]string{{"haproxy", "wordpress"}},
+		},
+	})
+}
+
+func (s *exportBundleSuite) TestDiffBundleVerificationErrors(c *gc.C) {
+	result, err := s.facade.DiffBundle(params.BundleChangesParams{
+		BundleDataYAML: `
+            applications:
+                django:
+                    charm: django
+                    num_units: -1
+        `,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Diff, gc.IsNil)
+	c.Assert(result.Errors, jc.DeepEquals, []string{
+		`negative number of units specified on application "django"`,
+	})
+}
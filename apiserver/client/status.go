@@ -111,6 +111,7 @@ func (c *Client) StatusHistory(request params.StatusHistoryRequests) params.Stat
 		filter := status.StatusHistoryFilter{
 			Size:     request.Filter.Size,
 			FromDate: request.Filter.Date,
+			ToDate:   request.Filter.ToDate,
 			Delta:    request.Filter.Delta,
 			Exclude:  set.NewStrings(request.Filter.Exclude...),
 		}
@@ -103,6 +103,27 @@ func BeginActions(args params.Entities, actionFn func(string) (state.Action, err
 	return results
 }
 
+// LogActionsMessages records the progress messages of running actions.
+// It's a helper function currently used by the uniter.
+// It needs an actionFn that can fetch an action from state using it's id that's usually created by AuthAndActionFromTagFn
+func LogActionsMessages(args params.ActionMessageParams, actionFn func(string) (state.Action, error)) params.ErrorResults {
+	results := params.ErrorResults{Results: make([]params.ErrorResult, len(args.Messages))}
+
+	for i, arg := range args.Messages {
+		action, err := actionFn(arg.ActionTag)
+		if err != nil {
+			results.Results[i].Error = ServerError(err)
+			continue
+		}
+		if err := action.Log(arg.Message); err != nil {
+			results.Results[i].Error = ServerError(err)
+			continue
+		}
+	}
+
+	return results
+}
+
 // FinishActions saves the result of a completed Action.
 // It's a helper function currently used by the uniter and by machineactions
 // It needs an actionFn that can fetch an action from state using it's id that's usually created by AuthAndActionFromTagFn
@@ -153,6 +174,7 @@ func Actions(args params.Entities, actionFn func(string) (state.Action, error))
 		results.Results[i].Action = &params.Action{
 			Name:       action.Name(),
 			Parameters: action.Parameters(),
+			Timeout:    action.Timeout(),
 		}
 	}
 
@@ -233,18 +255,52 @@ func ConvertActions(ar state.ActionReceiver, fn GetActionsFn) ([]params.ActionRe
 // to params.ActionResult.
 func MakeActionResult(actionReceiverTag names.Tag, action state.Action) params.ActionResult {
 	output, message := action.Results()
+	var log []params.ActionMessage
+	for _, m := range action.Messages() {
+		log = append(log, params.ActionMessage{
+			Timestamp: m.Timestamp,
+			Message:   m.Message,
+		})
+	}
 	return params.ActionResult{
 		Action: &params.Action{
 			Receiver:   actionReceiverTag.String(),
 			Tag:        action.ActionTag().String(),
 			Name:       action.Name(),
 			Parameters: action.Parameters(),
+			Timeout:    action.Timeout(),
 		},
 		Status:    string(action.Status()),
 		Message:   message,
 		Output:    output,
+		Log:       log,
 		Enqueued:  action.Enqueued(),
 		Started:   action.Started(),
 		Completed: action.Completed(),
 	}
 }
+
+// MakeActionSchedule converts a state.ActionSchedule to a
+// params.ActionSchedule.
+func MakeActionSchedule(schedule *state.ActionSchedule) params.ActionSchedule {
+	status, message := schedule.LastStatus()
+	result := params.ActionSchedule{
+		Name:        schedule.Name(),
+		Receiver:    schedule.Receiver().String(),
+		ActionName:  schedule.ActionName(),
+		Parameters:  schedule.Parameters(),
+		Schedule:    schedule.Schedule(),
+		Timeout:     schedule.Timeout(),
+		Enabled:     schedule.Enabled(),
+		LastRun:     schedule.LastRun(),
+		LastStatus:  string(status),
+		LastMessage: message,
+		LastActions: schedule.LastActions(),
+	}
+	if schedule.Enabled() {
+		// The schedule was validated when it was added, so
+		// there is no error to report here.
+		result.NextRun, _ = schedule.Next()
+	}
+	return result
+}
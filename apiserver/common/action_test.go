@@ -5,6 +5,8 @@
 package common_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
@@ -144,7 +146,7 @@ func (s *actionsSuite) TestBeginActions(c *gc.C) {
 func (s *actionsSuite) TestGetActions(c *gc.C) {
 	args := entities("success", "fail", "notPending")
 	actionFn := makeGetActionByTagString(map[string]state.Action{
-		"success":    fakeAction{name: "floosh", status: state.ActionPending},
+		"success":    fakeAction{name: "floosh", status: state.ActionPending, timeout: time.Minute},
 		"notPending": fakeAction{status: state.ActionCancelled},
 	})
 
@@ -152,7 +154,7 @@ func (s *actionsSuite) TestGetActions(c *gc.C) {
 
 	c.Assert(results, jc.DeepEquals, params.ActionResults{
 		[]params.ActionResult{
-			{Action: &params.Action{Name: "floosh"}},
+			{Action: &params.Action{Name: "floosh", Timeout: time.Minute}},
 			{Error: common.ServerError(actionNotFoundErr)},
 			{Error: common.ServerError(common.ErrActionNotAvailable)},
 		},
@@ -185,6 +187,29 @@ func (s *actionsSuite) TestFinishActions(c *gc.C) {
 	})
 }
 
+func (s *actionsSuite) TestLogActionsMessages(c *gc.C) {
+	args := params.ActionMessageParams{
+		[]params.ActionMessageArg{
+			{ActionTag: "success", Message: "hello"},
+			{ActionTag: "notfound", Message: "hello"},
+			{ActionTag: "logFail", Message: "hello"},
+		},
+	}
+	expectErr := errors.New("explosivo")
+	actionFn := makeGetActionByTagString(map[string]state.Action{
+		"success": fakeAction{},
+		"logFail": fakeAction{logErr: expectErr},
+	})
+	results := common.LogActionsMessages(args, actionFn)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		[]params.ErrorResult{
+			{},
+			{common.ServerError(actionNotFoundErr)},
+			{common.ServerError(expectErr)},
+		},
+	})
+}
+
 func (s *actionsSuite) TestWatchActionNotifications(c *gc.C) {
 	args := entities("invalid-actionreceiver", "machine-1", "machine-2", "machine-3")
 	canAccess := makeCanAccess(map[names.Tag]bool{
@@ -334,7 +359,9 @@ type fakeAction struct {
 	name      string
 	beginErr  error
 	finishErr error
+	logErr    error
 	status    state.ActionStatus
+	timeout   time.Duration
 }
 
 func (mock fakeAction) Status() state.ActionStatus {
@@ -357,10 +384,18 @@ func (mock fakeAction) Parameters() map[string]interface{} {
 	return nil
 }
 
+func (mock fakeAction) Timeout() time.Duration {
+	return mock.timeout
+}
+
 func (mock fakeAction) Finish(state.ActionResults) (state.Action, error) {
 	return nil, mock.finishErr
 }
 
+func (mock fakeAction) Log(string) error {
+	return mock.logErr
+}
+
 // entities is a convenience constructor for params.Entities.
 func entities(tags ...string) params.Entities {
 	entities := params.Entities{
@@ -184,6 +184,9 @@ func ServerErrorAndStatus(err error) (*params.Error, int) {
 		status = http.StatusUnauthorized
 	case params.CodeRetry:
 		status = http.StatusServiceUnavailable
+	case params.CodeRateLimitExceeded,
+		params.CodeConnectionLimitExceeded:
+		status = http.StatusTooManyRequests
 	}
 	return err1, status
 }
@@ -190,6 +190,22 @@ var errorTransformTests = []struct {
 	code:       params.CodeModelNotFound,
 	status:     http.StatusNotFound,
 	helperFunc: params.IsCodeModelNotFound,
+}, {
+	err: &params.Error{
+		Message: "rate limit exceeded",
+		Code:    params.CodeRateLimitExceeded,
+	},
+	code:       params.CodeRateLimitExceeded,
+	status:     http.StatusTooManyRequests,
+	helperFunc: params.IsCodeRateLimitExceeded,
+}, {
+	err: &params.Error{
+		Message: "connection limit exceeded",
+		Code:    params.CodeConnectionLimitExceeded,
+	},
+	code:       params.CodeConnectionLimitExceeded,
+	status:     http.StatusTooManyRequests,
+	helperFunc: params.IsCodeConnectionLimitExceeded,
 }, {
 	err:    nil,
 	code:   "",
@@ -239,7 +255,9 @@ func (s *errorsSuite) TestErrorTransform(c *gc.C) {
 			params.CodeMachineHasAttachedStorage,
 			params.CodeDischargeRequired,
 			params.CodeModelNotFound,
-			params.CodeRetry:
+			params.CodeRetry,
+			params.CodeRateLimitExceeded,
+			params.CodeConnectionLimitExceeded:
 			continue
 		case params.CodeOperationBlocked:
 			// ServerError doesn't actually have a case for this code.
@@ -68,6 +68,38 @@ func FilesystemParams(
 	return result, nil
 }
 
+// FilesystemResizeParams returns the parameters for growing the given
+// filesystem, which must be provisioned, to the given size.
+func FilesystemResizeParams(
+	f state.Filesystem,
+	size uint64,
+	poolManager poolmanager.PoolManager,
+	registry storage.ProviderRegistry,
+) (params.FilesystemResizeParams, error) {
+	filesystemInfo, err := f.Info()
+	if err != nil {
+		return params.FilesystemResizeParams{}, errors.Trace(err)
+	}
+	providerType, cfg, err := StoragePoolConfig(filesystemInfo.Pool, poolManager, registry)
+	if err != nil {
+		return params.FilesystemResizeParams{}, errors.Trace(err)
+	}
+	result := params.FilesystemResizeParams{
+		FilesystemTag: f.Tag().String(),
+		FilesystemId:  filesystemInfo.FilesystemId,
+		Size:          size,
+		Provider:      string(providerType),
+		Attributes:    cfg.Attrs(),
+	}
+	volumeTag, err := f.Volume()
+	if err == nil {
+		result.VolumeTag = volumeTag.String()
+	} else if err != state.ErrNoBackingVolume {
+		return params.FilesystemResizeParams{}, errors.Trace(err)
+	}
+	return result, nil
+}
+
 // FilesystemsToState converts a slice of params.Filesystem to a mapping
 // of filesystem tags to state.FilesystemInfo.
 func FilesystemsToState(in []params.Filesystem) (map[names.FilesystemTag]state.FilesystemInfo, error) {
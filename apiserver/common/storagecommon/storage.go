@@ -46,6 +46,10 @@ type StorageInterface interface {
 	// attachment corresponding to the identfified machine and filesystem.
 	WatchFilesystemAttachment(names.MachineTag, names.FilesystemTag) state.NotifyWatcher
 
+	// WatchFilesystem watches for changes to the filesystem with the
+	// specified tag.
+	WatchFilesystem(names.FilesystemTag) state.NotifyWatcher
+
 	// WatchVolumeAttachment watches for changes to the volume attachment
 	// corresponding to the identfified machine and volume.
 	WatchVolumeAttachment(names.MachineTag, names.VolumeTag) state.NotifyWatcher
@@ -139,6 +143,7 @@ func volumeStorageAttachmentInfo(
 	return &storage.StorageAttachmentInfo{
 		storage.StorageKindBlock,
 		devicePath,
+		blockDevice.Size,
 	}, nil
 }
 
@@ -166,9 +171,18 @@ func filesystemStorageAttachmentInfo(
 	if err != nil {
 		return nil, errors.Annotate(err, "getting filesystem attachment info")
 	}
+	// The filesystem is always provisioned before it is attached,
+	// but its size is informational, so we do not insist on it.
+	var size uint64
+	if filesystemInfo, err := filesystem.Info(); err == nil {
+		size = filesystemInfo.Size
+	} else if !errors.IsNotProvisioned(err) {
+		return nil, errors.Annotate(err, "getting filesystem info")
+	}
 	return &storage.StorageAttachmentInfo{
 		storage.StorageKindFilesystem,
 		filesystemAttachmentInfo.MountPoint,
+		size,
 	}, nil
 }
 
@@ -211,8 +225,12 @@ func WatchStorageAttachment(
 		if err != nil {
 			return nil, errors.Annotate(err, "getting storage filesystem")
 		}
+		// We need to watch both the filesystem attachment, and
+		// the filesystem itself. The filesystem's size changes
+		// when it is resized.
 		watchers = []state.NotifyWatcher{
 			st.WatchFilesystemAttachment(machineTag, filesystem.FilesystemTag()),
+			st.WatchFilesystem(filesystem.FilesystemTag()),
 		}
 	default:
 		return nil, errors.Errorf("invalid storage kind %v", storageInstance.Kind())
@@ -66,6 +66,87 @@ func VolumeParams(
 		cfg.Attrs(),
 		volumeTags,
 		nil, // attachment params set by the caller
+		"",  // snapshot ID set by the caller
+	}, nil
+}
+
+// VolumeSnapshotParams returns the parameters for taking the given
+// snapshot of the given volume, which must be provisioned.
+func VolumeSnapshotParams(
+	s state.VolumeSnapshot,
+	v state.Volume,
+	storageInstance state.StorageInstance,
+	modelUUID, controllerUUID string,
+	environConfig *config.Config,
+	poolManager poolmanager.PoolManager,
+	registry storage.ProviderRegistry,
+) (params.VolumeSnapshotParams, error) {
+	volumeInfo, err := v.Info()
+	if err != nil {
+		return params.VolumeSnapshotParams{}, errors.Trace(err)
+	}
+	snapshotTags, err := storageTags(storageInstance, modelUUID, controllerUUID, environConfig)
+	if err != nil {
+		return params.VolumeSnapshotParams{}, errors.Annotate(err, "computing storage tags")
+	}
+	providerType, cfg, err := StoragePoolConfig(s.Pool(), poolManager, registry)
+	if err != nil {
+		return params.VolumeSnapshotParams{}, errors.Trace(err)
+	}
+	return params.VolumeSnapshotParams{
+		Id:         s.Id(),
+		VolumeTag:  v.Tag().String(),
+		VolumeId:   volumeInfo.VolumeId,
+		Provider:   string(providerType),
+		Attributes: cfg.Attrs(),
+		Tags:       snapshotTags,
+	}, nil
+}
+
+// VolumeSnapshotDestroyParams returns the parameters for destroying
+// the given volume snapshot, which must have been taken.
+func VolumeSnapshotDestroyParams(
+	s state.VolumeSnapshot,
+	poolManager poolmanager.PoolManager,
+	registry storage.ProviderRegistry,
+) (params.VolumeSnapshotDestroyParams, error) {
+	snapshotInfo, err := s.Info()
+	if err != nil {
+		return params.VolumeSnapshotDestroyParams{}, errors.Trace(err)
+	}
+	providerType, _, err := StoragePoolConfig(s.Pool(), poolManager, registry)
+	if err != nil {
+		return params.VolumeSnapshotDestroyParams{}, errors.Trace(err)
+	}
+	return params.VolumeSnapshotDestroyParams{
+		Id:         s.Id(),
+		SnapshotId: snapshotInfo.SnapshotId,
+		Provider:   string(providerType),
+	}, nil
+}
+
+// VolumeResizeParams returns the parameters for growing the given
+// volume, which must be provisioned, to the given size.
+func VolumeResizeParams(
+	v state.Volume,
+	size uint64,
+	poolManager poolmanager.PoolManager,
+	registry storage.ProviderRegistry,
+) (params.VolumeResizeParams, error) {
+	volumeInfo, err := v.Info()
+	if err != nil {
+		return params.VolumeResizeParams{}, errors.Trace(err)
+	}
+	providerType, cfg, err := StoragePoolConfig(volumeInfo.Pool, poolManager, registry)
+	if err != nil {
+		return params.VolumeResizeParams{}, errors.Trace(err)
+	}
+	return params.VolumeResizeParams{
+		VolumeTag:  v.Tag().String(),
+		VolumeId:   volumeInfo.VolumeId,
+		Size:       size,
+		Provider:   string(providerType),
+		Attributes: cfg.Attrs(),
 	}, nil
 }
 
@@ -5,6 +5,7 @@ package facadetest
 
 import (
 	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/audit"
 	"github.com/juju/juju/state"
 )
 
@@ -17,6 +18,9 @@ type Context struct {
 	State_     *state.State
 	StatePool_ *state.StatePool
 	ID_        string
+
+	AuditEntrySink_ audit.AuditEntrySinkFn
+
 	// Identity is not part of the facade.Context interface, but is instead
 	// used to make sure that the context objects are the same.
 	Identity string
@@ -52,6 +56,11 @@ func (context Context) StatePool() *state.StatePool {
 	return context.StatePool_
 }
 
+// AuditEntrySink is part of the facade.Context interface.
+func (context Context) AuditEntrySink() audit.AuditEntrySinkFn {
+	return context.AuditEntrySink_
+}
+
 // ID is part of the facade.Context interface.
 func (context Context) ID() string {
 	return context.ID_
@@ -6,6 +6,7 @@ package facade
 import (
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/audit"
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
 )
@@ -66,6 +67,11 @@ type Context interface {
 	// creation of the expensive *State instances.
 	StatePool() *state.StatePool
 
+	// AuditEntrySink returns the sink receiving audit entries for
+	// events that are not visible to the API server's observers,
+	// or nil if auditing is disabled.
+	AuditEntrySink() audit.AuditEntrySinkFn
+
 	// ID returns a string that should almost always be "", unless
 	// this is a watcher facade, in which case it exists in lieu of
 	// actual arguments in the Next() call, and is used as a key
@@ -5,6 +5,7 @@ package firewaller
 
 import (
 	"github.com/juju/errors"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
@@ -20,6 +21,10 @@ import (
 func init() {
 	// Version 0 is no longer supported.
 	common.RegisterStandardFacade("Firewaller", 3, NewFirewallerAPI)
+	// Version 4 adds GetExposeInfo.
+	common.RegisterStandardFacade("Firewaller", 4, NewFirewallerAPI)
+	// Version 5 adds GetEgressRules.
+	common.RegisterStandardFacade("Firewaller", 5, NewFirewallerAPI)
 }
 
 // FirewallerAPI provides access to the Firewaller API facade.
@@ -161,8 +166,8 @@ func (f *FirewallerAPI) watchOneEnvironOpenedPorts(tag names.Tag) (string, []str
 }
 
 // GetMachinePorts returns the port ranges opened on a machine for the specified
-// subnet as a map mapping port ranges to the tags of the units that opened
-// them.
+// subnet, with the tags of the units that opened them and the endpoints
+// for which they were opened.
 func (f *FirewallerAPI) GetMachinePorts(args params.MachinePortsParams) (params.MachinePortsResults, error) {
 	result := params.MachinePortsResults{
 		Results: make([]params.MachinePortsResult, len(args.Params)),
@@ -196,19 +201,16 @@ func (f *FirewallerAPI) GetMachinePorts(args params.MachinePortsParams) (params.
 			continue
 		}
 		if ports != nil {
-			portRangeMap := ports.AllPortRanges()
-			var portRanges []network.PortRange
-			for portRange := range portRangeMap {
-				portRanges = append(portRanges, portRange)
-			}
-			network.SortPortRanges(portRanges)
-
-			for _, portRange := range portRanges {
-				unitTag := names.NewUnitTag(portRangeMap[portRange]).String()
+			for _, portRange := range ports.PortRanges() {
 				result.Results[i].Ports = append(result.Results[i].Ports,
 					params.MachinePortRange{
-						UnitTag:   unitTag,
-						PortRange: params.FromNetworkPortRange(portRange),
+						UnitTag: names.NewUnitTag(portRange.UnitName).String(),
+						PortRange: params.PortRange{
+							FromPort: portRange.FromPort,
+							ToPort:   portRange.ToPort,
+							Protocol: portRange.Protocol,
+						},
+						Endpoint: portRange.Endpoint,
 					})
 			}
 		}
@@ -287,6 +289,138 @@ func (f *FirewallerAPI) GetExposed(args params.Entities) (params.BoolResults, er
 	return result, nil
 }
 
+// GetExposeInfo returns the exposed flag and expose settings for each
+// given application. The CIDRs of the subnets in each exposed endpoint's
+// spaces are included in its CIDRs.
+func (f *FirewallerAPI) GetExposeInfo(args params.Entities) (params.ExposeInfoResults, error) {
+	result := params.ExposeInfoResults{
+		Results: make([]params.ExposeInfoResult, len(args.Entities)),
+	}
+	canAccess, err := f.accessApplication()
+	if err != nil {
+		return params.ExposeInfoResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseApplicationTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		application, err := f.getApplication(canAccess, tag)
+		if err == nil {
+			result.Results[i].Exposed = application.IsExposed()
+			result.Results[i].ExposedEndpoints, err = f.exposedEndpoints(application)
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (f *FirewallerAPI) exposedEndpoints(application *state.Application) (map[string]params.ExposedEndpoint, error) {
+	exposedEndpoints := application.ExposedEndpoints()
+	if len(exposedEndpoints) == 0 {
+		return nil, nil
+	}
+	result := make(map[string]params.ExposedEndpoint, len(exposedEndpoints))
+	for name, exposed := range exposedEndpoints {
+		cidrs := set.NewStrings(exposed.ExposeToCIDRs...)
+		for _, spaceName := range exposed.ExposeToSpaces {
+			space, err := f.st.Space(spaceName)
+			if errors.IsNotFound(err) {
+				// The space has been removed since the application
+				// was exposed, so it no longer grants any access.
+				continue
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+			subnets, err := space.Subnets()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			for _, subnet := range subnets {
+				cidrs.Add(subnet.CIDR())
+			}
+		}
+		result[name] = params.ExposedEndpoint{
+			ExposeToSpaces: exposed.ExposeToSpaces,
+			ExposeToCIDRs:  cidrs.SortedValues(),
+		}
+	}
+	return result, nil
+}
+
+// GetEgressRules returns the effective egress rules of each given
+// application: those set by operators combined with those set by its
+// charm. If there are any, a rule allowing traffic to the controllers'
+// API addresses is added so that the application's agents keep working.
+func (f *FirewallerAPI) GetEgressRules(args params.Entities) (params.EgressRulesResults, error) {
+	result := params.EgressRulesResults{
+		Results: make([]params.EgressRulesResult, len(args.Entities)),
+	}
+	canAccess, err := f.accessApplication()
+	if err != nil {
+		return params.EgressRulesResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseApplicationTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		application, err := f.getApplication(canAccess, tag)
+		if err == nil {
+			result.Results[i].Rules, err = f.egressRules(application)
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (f *FirewallerAPI) egressRules(application *state.Application) ([]params.EgressRule, error) {
+	rules := append(application.EgressRules(), application.CharmEgressRules()...)
+	if len(rules) == 0 {
+		return nil, nil
+	}
+	controllerRules, err := f.controllerEgressRules()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	rules = append(rules, controllerRules...)
+	return params.FromNetworkEgressRules(network.MergeEgressRules(rules)), nil
+}
+
+// controllerEgressRules returns rules allowing traffic to the API
+// addresses of the controllers.
+func (f *FirewallerAPI) controllerEgressRules() ([]network.EgressRule, error) {
+	apiHostPorts, err := f.st.APIHostPorts()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var rules []network.EgressRule
+	for _, hostPorts := range apiHostPorts {
+		for _, hp := range hostPorts {
+			var cidr string
+			switch hp.Type {
+			case network.IPv4Address:
+				cidr = hp.Value + "/32"
+			case network.IPv6Address:
+				cidr = hp.Value + "/128"
+			default:
+				continue
+			}
+			rules = append(rules, network.EgressRule{
+				PortRange: network.PortRange{
+					Protocol: "tcp",
+					FromPort: hp.Port,
+					ToPort:   hp.Port,
+				},
+				DestinationCIDRs: []string{cidr},
+			})
+		}
+	}
+	return rules, nil
+}
+
 // GetAssignedMachine returns the assigned machine tag (if any) for
 // each given unit.
 func (f *FirewallerAPI) GetAssignedMachine(args params.Entities) (params.StringResults, error) {
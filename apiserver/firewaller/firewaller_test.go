@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/apiserver/firewaller"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
 )
@@ -77,6 +78,113 @@ func (s *firewallerSuite) TestGetExposed(c *gc.C) {
 	s.testGetExposed(c, s.firewaller)
 }
 
+func (s *firewallerSuite) TestGetExposeInfo(c *gc.C) {
+	_, err := s.State.AddSpace("dmz", "", nil, true)
+	c.Assert(err, jc.ErrorIsNil)
+	for _, cidr := range []string{"10.0.0.0/24", "10.0.1.0/24"} {
+		_, err = s.State.AddSubnet(state.SubnetInfo{CIDR: cidr, SpaceName: "dmz"})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	err = s.service.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"":    {ExposeToCIDRs: []string{"192.168.0.0/16"}},
+		"url": {ExposeToSpaces: []string{"dmz"}, ExposeToCIDRs: []string{"10.1.0.0/16"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := addFakeEntities(params.Entities{Entities: []params.Entity{
+		{Tag: s.service.Tag().String()},
+	}})
+	result, err := s.firewaller.GetExposeInfo(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ExposeInfoResults{
+		Results: []params.ExposeInfoResult{
+			{
+				Exposed: true,
+				ExposedEndpoints: map[string]params.ExposedEndpoint{
+					"": {ExposeToCIDRs: []string{"192.168.0.0/16"}},
+					"url": {
+						ExposeToSpaces: []string{"dmz"},
+						ExposeToCIDRs:  []string{"10.0.0.0/24", "10.0.1.0/24", "10.1.0.0/16"},
+					},
+				},
+			},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.NotFoundError(`application "bar"`)},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	// Unexposed applications have no expose settings.
+	err = s.service.ClearExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	result, err = s.firewaller.GetExposeInfo(params.Entities{Entities: []params.Entity{
+		{Tag: s.service.Tag().String()},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ExposeInfoResults{
+		Results: []params.ExposeInfoResult{{}},
+	})
+}
+
+func (s *firewallerSuite) TestGetEgressRules(c *gc.C) {
+	err := s.State.SetAPIHostPorts([][]network.HostPort{
+		network.NewHostPorts(17070, "10.0.0.1", "2001:db8::1", "controller.example.com"),
+		network.NewHostPorts(17070, "10.0.0.2"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.service.SetEgressRules([]network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "192.168.0.0/16"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.service.SetCharmEgressRules([]network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+		network.MustNewEgressRule("udp", 53, 53),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := addFakeEntities(params.Entities{Entities: []params.Entity{
+		{Tag: s.service.Tag().String()},
+	}})
+	result, err := s.firewaller.GetEgressRules(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.EgressRulesResults{
+		Results: []params.EgressRulesResult{
+			{Rules: []params.EgressRule{{
+				PortRange:        params.PortRange{FromPort: 443, ToPort: 443, Protocol: "tcp"},
+				DestinationCIDRs: []string{"10.0.0.0/8", "192.168.0.0/16"},
+			}, {
+				PortRange:        params.PortRange{FromPort: 17070, ToPort: 17070, Protocol: "tcp"},
+				DestinationCIDRs: []string{"10.0.0.1/32", "10.0.0.2/32", "2001:db8::1/128"},
+			}, {
+				PortRange:        params.PortRange{FromPort: 53, ToPort: 53, Protocol: "udp"},
+				DestinationCIDRs: []string{"0.0.0.0/0"},
+			}}},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.NotFoundError(`application "bar"`)},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	// Applications without egress rules have unrestricted egress.
+	err = s.service.SetEgressRules(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.service.SetCharmEgressRules(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	result, err = s.firewaller.GetEgressRules(params.Entities{Entities: []params.Entity{
+		{Tag: s.service.Tag().String()},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.EgressRulesResults{
+		Results: []params.EgressRulesResult{{}},
+	})
+}
+
 func (s *firewallerSuite) TestGetAssignedMachine(c *gc.C) {
 	s.testGetAssignedMachine(c, s.firewaller)
 }
@@ -0,0 +1,106 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package hookhistory defines an API end point for reading the hook
+// and action executions recorded by the units of a model.
+package hookhistory
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the state methods used by the HookHistory facade.
+type Backend interface {
+	ModelTag() names.ModelTag
+	Unit(name string) (Unit, error)
+}
+
+// Unit defines the methods of state.Unit used by the HookHistory
+// facade.
+type Unit interface {
+	HookExecutions() ([]state.HookExecution, error)
+}
+
+// API implements the HookHistory facade.
+type API struct {
+	backend    Backend
+	authorizer facade.Authorizer
+}
+
+// NewAPI returns a new HookHistory facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		authorizer: authorizer,
+	}, nil
+}
+
+// HookExecutions returns the recorded hook and action executions of
+// each given unit, oldest first.
+func (api *API) HookExecutions(args params.Entities) (params.HookExecutionsResults, error) {
+	ok, err := api.authorizer.HasPermission(permission.ReadAccess, api.backend.ModelTag())
+	if err != nil {
+		return params.HookExecutionsResults{}, errors.Trace(err)
+	}
+	if !ok {
+		return params.HookExecutionsResults{}, common.ErrPerm
+	}
+	results := params.HookExecutionsResults{
+		Results: make([]params.HookExecutionsResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		executions, err := api.hookExecutions(entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Executions = executions
+	}
+	return results, nil
+}
+
+func (api *API) hookExecutions(tagString string) ([]params.HookExecution, error) {
+	tag, err := names.ParseUnitTag(tagString)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	unit, err := api.backend.Unit(tag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	executions, err := unit.HookExecutions()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]params.HookExecution, len(executions))
+	for i, execution := range executions {
+		result[i] = params.HookExecution{
+			Kind:             execution.Kind,
+			Name:             execution.Name,
+			Started:          execution.Started,
+			Finished:         execution.Finished,
+			ExitCode:         execution.ExitCode,
+			Error:            execution.Error,
+			DroppedToolCalls: execution.DroppedToolCalls,
+		}
+		for _, call := range execution.ToolCalls {
+			result[i].ToolCalls = append(result[i].ToolCalls, params.HookToolCall{
+				Name:     call.Name,
+				Started:  call.Started,
+				Duration: call.Duration,
+				ExitCode: call.ExitCode,
+			})
+		}
+	}
+	return result, nil
+}
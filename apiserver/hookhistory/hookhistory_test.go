@@ -0,0 +1,158 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hookhistory_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/hookhistory"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type hookHistorySuite struct {
+	gitjujutesting.IsolationSuite
+	backend    *mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&hookHistorySuite{})
+
+var t0 = time.Date(2017, time.June, 1, 10, 0, 0, 0, time.UTC)
+
+func (s *hookHistorySuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &mockBackend{
+		units: map[string]*mockUnit{
+			"mysql/0": {executions: []state.HookExecution{{
+				Kind:     "hook",
+				Name:     "install",
+				Started:  t0,
+				Finished: t0.Add(time.Minute),
+				ToolCalls: []state.HookToolCall{{
+					Name:     "status-set",
+					Started:  t0.Add(time.Second),
+					Duration: 30 * time.Millisecond,
+				}},
+			}, {
+				Kind:     "hook",
+				Name:     "start",
+				Started:  t0.Add(2 * time.Minute),
+				Finished: t0.Add(3 * time.Minute),
+				ExitCode: 1,
+				Error:    "exit status 1",
+			}}},
+		},
+	}
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("read"),
+	}
+}
+
+func (s *hookHistorySuite) newAPI(c *gc.C) *hookhistory.API {
+	api, err := hookhistory.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *hookHistorySuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := hookhistory.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *hookHistorySuite) TestHookExecutions(c *gc.C) {
+	result, err := s.newAPI(c).HookExecutions(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "unit-mysql-0"},
+			{Tag: "unit-mysql-1"},
+			{Tag: "application-mysql"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.HookExecutionsResults{
+		Results: []params.HookExecutionsResult{{
+			Executions: []params.HookExecution{{
+				Kind:     "hook",
+				Name:     "install",
+				Started:  t0,
+				Finished: t0.Add(time.Minute),
+				ToolCalls: []params.HookToolCall{{
+					Name:     "status-set",
+					Started:  t0.Add(time.Second),
+					Duration: 30 * time.Millisecond,
+				}},
+			}, {
+				Kind:     "hook",
+				Name:     "start",
+				Started:  t0.Add(2 * time.Minute),
+				Finished: t0.Add(3 * time.Minute),
+				ExitCode: 1,
+				Error:    "exit status 1",
+			}},
+		}, {
+			Error: &params.Error{
+				Code:    params.CodeNotFound,
+				Message: `unit "mysql/1" not found`,
+			},
+		}, {
+			Error: &params.Error{
+				Message: `"application-mysql" is not a valid unit tag`,
+			},
+		}},
+	})
+	s.backend.CheckCalls(c, []gitjujutesting.StubCall{
+		{"ModelTag", nil},
+		{"Unit", []interface{}{"mysql/0"}},
+		{"Unit", []interface{}{"mysql/1"}},
+	})
+}
+
+func (s *hookHistorySuite) TestHookExecutionsRequiresRead(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("someone")
+	_, err := s.newAPI(c).HookExecutions(params.Entities{
+		Entities: []params.Entity{{Tag: "unit-mysql-0"}},
+	})
+	c.Assert(err, gc.Equals, common.ErrPerm)
+	s.backend.CheckCallNames(c, "ModelTag")
+}
+
+type mockBackend struct {
+	gitjujutesting.Stub
+	units map[string]*mockUnit
+}
+
+func (b *mockBackend) ModelTag() names.ModelTag {
+	b.MethodCall(b, "ModelTag")
+	return coretesting.ModelTag
+}
+
+func (b *mockBackend) Unit(name string) (hookhistory.Unit, error) {
+	b.MethodCall(b, "Unit", name)
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	unit, ok := b.units[name]
+	if !ok {
+		return nil, errors.NotFoundf("unit %q", name)
+	}
+	return unit, nil
+}
+
+type mockUnit struct {
+	executions []state.HookExecution
+}
+
+func (u *mockUnit) HookExecutions() ([]state.HookExecution, error) {
+	return u.executions, nil
+}
@@ -14,7 +14,8 @@ import (
 )
 
 // introspectionHandler is an http.Handler that wraps an http.Handler
-// from the worker/introspection package, adding authentication.
+// from the worker/introspection package, or one serving model metrics,
+// adding authentication.
 type introspectionHandler struct {
 	ctx     httpContext
 	handler http.Handler
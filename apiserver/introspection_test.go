@@ -74,3 +74,35 @@ func (s *introspectionSuite) TestAccessDenied(c *gc.C) {
 	defer resp.Body.Close()
 	c.Assert(resp.StatusCode, gc.Equals, http.StatusForbidden)
 }
+
+func (s *introspectionSuite) TestModelMetrics(c *gc.C) {
+	s.Factory.MakeUnit(c, nil)
+
+	url := s.baseURL(c)
+	url.Path = "/introspection/model-metrics"
+	resp := s.sendRequest(c, httpRequestParams{
+		method:   "GET",
+		url:      url.String(),
+		tag:      "user-admin",
+		password: "dummy-secret",
+	})
+	defer resp.Body.Close()
+	c.Assert(resp.StatusCode, gc.Equals, http.StatusOK)
+	content, err := ioutil.ReadAll(resp.Body)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(content), jc.Contains, "juju_model_units{")
+	c.Assert(string(content), jc.Contains, `model_uuid="`+s.BackingState.ModelUUID()+`"`)
+}
+
+func (s *introspectionSuite) TestModelMetricsAccessDenied(c *gc.C) {
+	url := s.baseURL(c)
+	url.Path = "/introspection/model-metrics"
+	resp := s.sendRequest(c, httpRequestParams{
+		method:   "GET",
+		url:      url.String(),
+		tag:      "user-bob",
+		password: "hunter2",
+	})
+	defer resp.Body.Close()
+	c.Assert(resp.StatusCode, gc.Equals, http.StatusForbidden)
+}
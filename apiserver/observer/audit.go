@@ -102,7 +102,7 @@ func (a *AuditRPCObserver) ServerRequest(hdr *rpc.Header, body interface{}) {
 	auditEntry := a.boilerplateAuditEntry()
 	auditEntry.OriginName = a.authenticatedTag
 
-	auditEntry.OriginType = "API request"
+	auditEntry.OriginType = audit.APIRequestOriginType
 	auditEntry.Operation = rpcRequestToOperation(hdr.Request)
 	auditEntry.Data = map[string]interface{}{"request-body": body}
 	err := a.handleAuditEntry(auditEntry)
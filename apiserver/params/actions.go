@@ -25,6 +25,10 @@ const (
 	// ActionRunning is the status of an Action that has been started but
 	// not completed yet.
 	ActionRunning string = "running"
+
+	// ActionAborting is the status of an Action that has been started
+	// and then cancelled, but has not yet been stopped.
+	ActionAborting string = "aborting"
 )
 
 // Actions is a slice of Action for bulk requests.
@@ -38,6 +42,7 @@ type Action struct {
 	Receiver   string                 `json:"receiver"`
 	Name       string                 `json:"name"`
 	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	Timeout    time.Duration          `json:"timeout,omitempty"`
 }
 
 // ActionResults is a slice of ActionResult for bulk requests.
@@ -54,9 +59,16 @@ type ActionResult struct {
 	Status    string                 `json:"status,omitempty"`
 	Message   string                 `json:"message,omitempty"`
 	Output    map[string]interface{} `json:"output,omitempty"`
+	Log       []ActionMessage        `json:"log,omitempty"`
 	Error     *Error                 `json:"error,omitempty"`
 }
 
+// ActionMessage is a timestamped progress message logged by an action.
+type ActionMessage struct {
+	Timestamp time.Time `json:"timestamp"`
+	Message   string    `json:"message"`
+}
+
 // ActionsByReceivers wrap a slice of Actions for API calls.
 type ActionsByReceivers struct {
 	Actions []ActionsByReceiver `json:"actions,omitempty"`
@@ -116,6 +128,19 @@ type ActionExecutionResult struct {
 	Message   string                 `json:"message,omitempty"`
 }
 
+// ActionMessageParams holds the progress messages to be logged by
+// running actions.
+type ActionMessageParams struct {
+	Messages []ActionMessageArg `json:"messages"`
+}
+
+// ActionMessageArg holds a progress message to be logged by the
+// running action with the given tag.
+type ActionMessageArg struct {
+	ActionTag string `json:"action-tag"`
+	Message   string `json:"message"`
+}
+
 // ApplicationsCharmActionsResults holds a slice of ApplicationCharmActionsResult for
 // a bulk result of charm Actions for Applications.
 type ApplicationsCharmActionsResults struct {
@@ -138,3 +163,45 @@ type ActionSpec struct {
 	Description string                 `json:"description"`
 	Params      map[string]interface{} `json:"params"`
 }
+
+// ActionSchedules holds a slice of ActionSchedule for bulk requests.
+type ActionSchedules struct {
+	Schedules []ActionSchedule `json:"schedules,omitempty"`
+}
+
+// ActionSchedule describes a schedule on which an Action is enqueued
+// on a unit, or on all units of an application.
+type ActionSchedule struct {
+	Name       string                 `json:"name"`
+	Receiver   string                 `json:"receiver"`
+	ActionName string                 `json:"action-name"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	Schedule   string                 `json:"schedule"`
+	Timeout    time.Duration          `json:"timeout,omitempty"`
+	Enabled    bool                   `json:"enabled"`
+
+	// The remaining fields are only set in results.
+	NextRun     time.Time `json:"next-run,omitempty"`
+	LastRun     time.Time `json:"last-run,omitempty"`
+	LastStatus  string    `json:"last-status,omitempty"`
+	LastMessage string    `json:"last-message,omitempty"`
+	LastActions []string  `json:"last-actions,omitempty"`
+}
+
+// ActionScheduleNames holds the names of action schedules.
+type ActionScheduleNames struct {
+	Names []string `json:"names"`
+}
+
+// ActionSchedulesEnabled holds a slice of ActionScheduleEnabled for
+// bulk requests.
+type ActionSchedulesEnabled struct {
+	Schedules []ActionScheduleEnabled `json:"schedules"`
+}
+
+// ActionScheduleEnabled holds whether the named action schedule
+// should be enabled.
+type ActionScheduleEnabled struct {
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+}
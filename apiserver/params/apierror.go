@@ -94,6 +94,8 @@ const (
 	CodeDischargeRequired         = "macaroon discharge required"
 	CodeRedirect                  = "redirection required"
 	CodeRetry                     = "retry"
+	CodeRateLimitExceeded         = "rate limit exceeded"
+	CodeConnectionLimitExceeded   = "connection limit exceeded"
 )
 
 // ErrCode returns the error code associated with
@@ -239,3 +241,11 @@ func IsMethodNotAllowed(err error) bool {
 func IsRedirect(err error) bool {
 	return ErrCode(err) == CodeRedirect
 }
+
+func IsCodeRateLimitExceeded(err error) bool {
+	return ErrCode(err) == CodeRateLimitExceeded
+}
+
+func IsCodeConnectionLimitExceeded(err error) bool {
+	return ErrCode(err) == CodeConnectionLimitExceeded
+}
@@ -0,0 +1,40 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import (
+	"time"
+
+	"github.com/juju/version"
+)
+
+// AuditLogQuery holds the criteria used to select audit log
+// entries. Empty fields match every entry.
+type AuditLogQuery struct {
+	UserTag       string     `json:"user-tag,omitempty"`
+	ModelUUID     string     `json:"model-uuid,omitempty"`
+	Operation     string     `json:"operation,omitempty"`
+	RemoteAddress string     `json:"remote-address,omitempty"`
+	From          *time.Time `json:"from,omitempty"`
+	To            *time.Time `json:"to,omitempty"`
+	Limit         int        `json:"limit,omitempty"`
+}
+
+// AuditLogEntry holds a single audit log entry.
+type AuditLogEntry struct {
+	JujuServerVersion version.Number         `json:"juju-server-version"`
+	ModelUUID         string                 `json:"model-uuid"`
+	Timestamp         time.Time              `json:"timestamp"`
+	RemoteAddress     string                 `json:"remote-address"`
+	OriginType        string                 `json:"origin-type"`
+	OriginName        string                 `json:"origin-name"`
+	Operation         string                 `json:"operation"`
+	Data              map[string]interface{} `json:"data,omitempty"`
+}
+
+// AuditLogEntries holds the result of an audit log query, oldest
+// entry first.
+type AuditLogEntries struct {
+	Entries []AuditLogEntry `json:"entries"`
+}
@@ -294,6 +294,21 @@ type RelationUnitsSettings struct {
 	RelationUnits []RelationUnitSettings `json:"relation-units"`
 }
 
+// RelationApplication holds a relation tag, the tag of a unit in the
+// relation, and the tag of the application whose application-level
+// settings the unit accesses.
+type RelationApplication struct {
+	Relation    string `json:"relation"`
+	Unit        string `json:"unit"`
+	Application string `json:"application"`
+}
+
+// RelationApplications holds the arguments for making a
+// ReadApplicationSettings API call.
+type RelationApplications struct {
+	RelationApplications []RelationApplication `json:"relation-applications"`
+}
+
 // RelationResult returns information about a single relation,
 // or an error.
 type RelationResult struct {
@@ -310,6 +325,107 @@ type RelationResults struct {
 	Results []RelationResult `json:"results"`
 }
 
+// GoalStateStatus holds the status of a unit or relation within a
+// goal state.
+type GoalStateStatus struct {
+	Status string     `json:"status"`
+	Since  *time.Time `json:"since,omitempty"`
+}
+
+// UnitsGoalState holds the goal state status of a set of units,
+// keyed by unit name.
+type UnitsGoalState map[string]GoalStateStatus
+
+// GoalState holds the units expected for a unit's own application
+// and, keyed by local endpoint name, for each related application.
+type GoalState struct {
+	Units     UnitsGoalState            `json:"units"`
+	Relations map[string]UnitsGoalState `json:"relations"`
+}
+
+// GoalStateResult holds the goal state of a single unit.
+type GoalStateResult struct {
+	Result *GoalState `json:"result,omitempty"`
+	Error  *Error     `json:"error,omitempty"`
+}
+
+// GoalStateResults holds the results of a GoalStates API call.
+type GoalStateResults struct {
+	Results []GoalStateResult `json:"results"`
+}
+
+// CharmStateResult holds the charm state of a unit or an error.
+type CharmStateResult struct {
+	State map[string]string `json:"state,omitempty"`
+	Error *Error            `json:"error,omitempty"`
+}
+
+// CharmStateResults holds the results of a bulk CharmState call.
+type CharmStateResults struct {
+	Results []CharmStateResult `json:"results"`
+}
+
+// SetCharmStateArg holds the charm state to store for a unit,
+// replacing any state previously stored.
+type SetCharmStateArg struct {
+	Tag   string            `json:"tag"`
+	State map[string]string `json:"state"`
+}
+
+// SetCharmStateArgs holds the arguments of a bulk SetCharmState call.
+type SetCharmStateArgs struct {
+	Args []SetCharmStateArg `json:"args"`
+}
+
+// HookExecution describes a single execution of a hook or action by
+// a unit's agent.
+type HookExecution struct {
+	Kind      string         `json:"kind"`
+	Name      string         `json:"name"`
+	Started   time.Time      `json:"started"`
+	Finished  time.Time      `json:"finished"`
+	ExitCode  int            `json:"exit-code"`
+	Error     string         `json:"error,omitempty"`
+	ToolCalls []HookToolCall `json:"tool-calls,omitempty"`
+
+	// DroppedToolCalls is the number of hook tool calls made after
+	// those in ToolCalls, which were not recorded.
+	DroppedToolCalls int `json:"dropped-tool-calls,omitempty"`
+}
+
+// HookToolCall describes a single invocation of a hook tool during
+// a hook or action execution.
+type HookToolCall struct {
+	Name     string        `json:"name"`
+	Started  time.Time     `json:"started"`
+	Duration time.Duration `json:"duration"`
+	ExitCode int           `json:"exit-code"`
+}
+
+// RecordHookExecutionArg holds a hook execution to record for a unit.
+type RecordHookExecutionArg struct {
+	Tag       string        `json:"tag"`
+	Execution HookExecution `json:"execution"`
+}
+
+// RecordHookExecutionArgs holds the arguments of a bulk
+// RecordHookExecutions call.
+type RecordHookExecutionArgs struct {
+	Args []RecordHookExecutionArg `json:"args"`
+}
+
+// HookExecutionsResult holds the recorded hook executions of a unit,
+// oldest first, or an error.
+type HookExecutionsResult struct {
+	Executions []HookExecution `json:"executions,omitempty"`
+	Error      *Error          `json:"error,omitempty"`
+}
+
+// HookExecutionsResults holds the results of a bulk HookExecutions call.
+type HookExecutionsResults struct {
+	Results []HookExecutionsResult `json:"results"`
+}
+
 // EntityCharmURL holds an entity's tag and a charm URL.
 type EntityCharmURL struct {
 	Tag      string `json:"tag"`
@@ -518,6 +634,10 @@ type RelationUnitsChange struct {
 	// latest known settings version for each.
 	Changed map[string]UnitSettings `json:"changed"`
 
+	// AppChanged holds the latest known version of the application
+	// settings of each counterpart application.
+	AppChanged map[string]int64 `json:"app-changed,omitempty"`
+
 	// Departed holds a set of units that have previously been reported to
 	// be in scope, but which no longer are.
 	Departed []string `json:"departed,omitempty"`
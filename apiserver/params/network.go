@@ -235,6 +235,70 @@ func (pr PortRange) NetworkPortRange() network.PortRange {
 	}
 }
 
+// EgressRule is a network.EgressRule for use in API requests and
+// responses.
+type EgressRule struct {
+	PortRange        PortRange `json:"port-range"`
+	DestinationCIDRs []string  `json:"destination-cidrs,omitempty"`
+}
+
+// FromNetworkEgressRules is a convenience helper to create parameters
+// out of the network type, here for EgressRule.
+func FromNetworkEgressRules(rules []network.EgressRule) []EgressRule {
+	if len(rules) == 0 {
+		return nil
+	}
+	result := make([]EgressRule, len(rules))
+	for i, rule := range rules {
+		result[i] = EgressRule{
+			PortRange:        FromNetworkPortRange(rule.PortRange),
+			DestinationCIDRs: rule.DestinationCIDRs,
+		}
+	}
+	return result
+}
+
+// NetworkEgressRules is a convenience helper to return the parameters
+// as network types, here for EgressRule.
+func NetworkEgressRules(rules []EgressRule) []network.EgressRule {
+	if len(rules) == 0 {
+		return nil
+	}
+	result := make([]network.EgressRule, len(rules))
+	for i, rule := range rules {
+		result[i] = network.EgressRule{
+			PortRange:        rule.PortRange.NetworkPortRange(),
+			DestinationCIDRs: rule.DestinationCIDRs,
+		}
+	}
+	return result
+}
+
+// EgressRulesResult holds the egress rules of an entity, or an error.
+type EgressRulesResult struct {
+	Error *Error       `json:"error,omitempty"`
+	Rules []EgressRule `json:"rules,omitempty"`
+}
+
+// EgressRulesResults holds the results of an API call returning
+// egress rules for multiple entities.
+type EgressRulesResults struct {
+	Results []EgressRulesResult `json:"results"`
+}
+
+// EntityEgressRules holds an entity's tag and the egress rules
+// to set for it.
+type EntityEgressRules struct {
+	Tag   string       `json:"tag"`
+	Rules []EgressRule `json:"rules"`
+}
+
+// EntitiesEgressRules holds the parameters for setting egress rules
+// for multiple entities.
+type EntitiesEgressRules struct {
+	Entities []EntityEgressRules `json:"entities"`
+}
+
 // EntityPort holds an entity's tag, a protocol and a port.
 type EntityPort struct {
 	Tag      string `json:"tag"`
@@ -254,6 +318,12 @@ type EntityPortRange struct {
 	Protocol string `json:"protocol"`
 	FromPort int    `json:"from-port"`
 	ToPort   int    `json:"to-port"`
+
+	// Endpoint, if non-empty, names the unit endpoint for which the
+	// port range is opened or closed; otherwise the range applies to
+	// all of the unit's endpoints. This field is only understood by
+	// Uniter facade version 13 and greater.
+	Endpoint string `json:"endpoint,omitempty"`
 }
 
 // EntitiesPortRanges holds the parameters for making an OpenPorts or
@@ -425,6 +495,10 @@ type MachinePortRange struct {
 	UnitTag     string    `json:"unit-tag"`
 	RelationTag string    `json:"relation-tag"`
 	PortRange   PortRange `json:"port-range"`
+
+	// Endpoint, if non-empty, names the unit endpoint for which the
+	// port range is open; otherwise it is open for all endpoints.
+	Endpoint string `json:"endpoint,omitempty"`
 }
 
 // MachinePorts holds a machine and subnet tags. It's used when referring to
@@ -517,6 +591,21 @@ type MachinePortsResults struct {
 	Results []MachinePortsResult `json:"results"`
 }
 
+// ExposeInfoResult holds the result of a single application in a
+// FirewallerAPI.GetExposeInfo call. The ExposeToCIDRs of each exposed
+// endpoint include the CIDRs of the subnets in its ExposeToSpaces.
+type ExposeInfoResult struct {
+	Error            *Error                     `json:"error,omitempty"`
+	Exposed          bool                       `json:"exposed,omitempty"`
+	ExposedEndpoints map[string]ExposedEndpoint `json:"exposed-endpoints,omitempty"`
+}
+
+// ExposeInfoResults holds the results of a FirewallerAPI.GetExposeInfo
+// call.
+type ExposeInfoResults struct {
+	Results []ExposeInfoResult `json:"results"`
+}
+
 // APIHostPortsResult holds the result of an APIHostPorts
 // call. Each element in the top level slice holds
 // the addresses for one API server.
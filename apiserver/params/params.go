@@ -223,6 +223,7 @@ type ApplicationDeploy struct {
 	Constraints      constraints.Value              `json:"constraints"`
 	Placement        []*instance.Placement          `json:"placement,omitempty"`
 	Storage          map[string]storage.Constraints `json:"storage,omitempty"`
+	AttachStorage    []string                       `json:"attach-storage,omitempty"`
 	EndpointBindings map[string]string              `json:"endpoint-bindings,omitempty"`
 	Resources        map[string]string              `json:"resources,omitempty"`
 }
@@ -281,6 +282,41 @@ type ApplicationSetCharm struct {
 // ApplicationExpose holds the parameters for making the application Expose call.
 type ApplicationExpose struct {
 	ApplicationName string `json:"application"`
+
+	// ExposedEndpoints, if non-empty, holds expose settings keyed on
+	// endpoint name, to be merged with the application's existing
+	// settings. The empty endpoint name applies to all endpoints.
+	// This field is only understood by Application facade version 5
+	// and greater.
+	ExposedEndpoints map[string]ExposedEndpoint `json:"exposed-endpoints,omitempty"`
+}
+
+// ExposedEndpoint describes the sources from which the ports opened
+// by an exposed application endpoint may be accessed.
+type ExposedEndpoint struct {
+	ExposeToSpaces []string `json:"expose-to-spaces,omitempty"`
+	ExposeToCIDRs  []string `json:"expose-to-cidrs,omitempty"`
+}
+
+// ApplicationSetTrust holds the parameters for making the application
+// SetTrust call.
+type ApplicationSetTrust struct {
+	ApplicationName string `json:"application"`
+	Trust           bool   `json:"trust"`
+}
+
+// ApplicationSetEgressRules holds the parameters for making the
+// application SetEgressRules call.
+type ApplicationSetEgressRules struct {
+	ApplicationName string       `json:"application"`
+	Rules           []EgressRule `json:"rules"`
+}
+
+// ApplicationEgressRulesResult holds the results of the application
+// GetEgressRules call.
+type ApplicationEgressRulesResult struct {
+	Rules      []EgressRule `json:"rules,omitempty"`
+	CharmRules []EgressRule `json:"charm-rules,omitempty"`
 }
 
 // ApplicationSet holds the parameters for an application Set
@@ -326,6 +362,12 @@ type ApplicationCharmRelationsResults struct {
 // ApplicationUnexpose holds parameters for the application Unexpose call.
 type ApplicationUnexpose struct {
 	ApplicationName string `json:"application"`
+
+	// ExposedEndpoints, if non-empty, holds the names of the endpoints
+	// whose expose settings are to be removed; the application remains
+	// exposed if settings for other endpoints remain. This field is
+	// only understood by Application facade version 5 and greater.
+	ExposedEndpoints []string `json:"exposed-endpoints,omitempty"`
 }
 
 // ApplicationMetricCredential holds parameters for the SetApplicationCredentials call.
@@ -509,6 +551,9 @@ type StateServingInfo struct {
 	// this will be passed as the KeyFile argument to MongoDB
 	SharedSecret   string `json:"shared-secret"`
 	SystemIdentity string `json:"system-identity"`
+	// The base64-encoded key used to encrypt the values of secrets.
+	// It is held only in the controller agents' config.
+	SecretsKey string `json:"secrets-key,omitempty"`
 }
 
 // IsMasterResult holds the result of an IsMaster API call.
@@ -840,6 +885,69 @@ type BundleChange struct {
 	Requires []string `json:"requires"`
 }
 
+// BundleDiffResults holds the result of the Bundle.DiffBundle call.
+type BundleDiffResults struct {
+	// Diff holds the differences between the bundle and the model.
+	// It is omitted if the provided bundle YAML has verification errors.
+	Diff *BundleDiff `json:"diff,omitempty"`
+	// Errors holds possible bundle verification errors.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// BundleDiff holds the differences between a bundle and a model. Only
+// the applications and relations that differ are included.
+type BundleDiff struct {
+	Applications map[string]BundleApplicationDiff `json:"applications,omitempty"`
+	Relations    *BundleRelationsDiff             `json:"relations,omitempty"`
+}
+
+// BundleApplicationDiff holds the differences between an application
+// in a bundle and the same application in a model.
+type BundleApplicationDiff struct {
+	// Missing is "bundle" or "model" if the application is only
+	// present in the other.
+	Missing     string                     `json:"missing,omitempty"`
+	Charm       *BundleStringDiff          `json:"charm,omitempty"`
+	Series      *BundleStringDiff          `json:"series,omitempty"`
+	NumUnits    *BundleIntDiff             `json:"num-units,omitempty"`
+	Expose      *BundleBoolDiff            `json:"expose,omitempty"`
+	Options     map[string]BundleValueDiff `json:"options,omitempty"`
+	Constraints *BundleStringDiff          `json:"constraints,omitempty"`
+}
+
+// BundleStringDiff holds differing string values from a bundle and a
+// model.
+type BundleStringDiff struct {
+	Bundle string `json:"bundle"`
+	Model  string `json:"model"`
+}
+
+// BundleIntDiff holds differing int values from a bundle and a model.
+type BundleIntDiff struct {
+	Bundle int `json:"bundle"`
+	Model  int `json:"model"`
+}
+
+// BundleBoolDiff holds differing bool values from a bundle and a model.
+type BundleBoolDiff struct {
+	Bundle bool `json:"bundle"`
+	Model  bool `json:"model"`
+}
+
+// BundleValueDiff holds differing application option values from a
+// bundle and a model. A nil value means the option is not set.
+type BundleValueDiff struct {
+	Bundle interface{} `json:"bundle,omitempty"`
+	Model  interface{} `json:"model,omitempty"`
+}
+
+// BundleRelationsDiff holds the relations present in only one of a
+// bundle and a model, each as a pair of endpoints.
+type BundleRelationsDiff struct {
+	BundleAdditions [][]string `json:"bundle-additions,omitempty"`
+	ModelAdditions  [][]string `json:"model-additions,omitempty"`
+}
+
 type MongoVersion struct {
 	Major         int    `json:"major"`
 	Minor         int    `json:"minor"`
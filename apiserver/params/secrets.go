@@ -0,0 +1,83 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import "time"
+
+// AddSecretArg holds the details of a secret to add to a model.
+type AddSecretArg struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description,omitempty"`
+	Values      map[string]string `json:"values"`
+}
+
+// AddSecretArgs holds the arguments for the Secrets.AddSecrets call.
+type AddSecretArgs struct {
+	Args []AddSecretArg `json:"args"`
+}
+
+// RotateSecretArg holds the new values of a secret.
+type RotateSecretArg struct {
+	Name   string            `json:"name"`
+	Values map[string]string `json:"values"`
+}
+
+// RotateSecretArgs holds the arguments for the Secrets.RotateSecrets
+// call.
+type RotateSecretArgs struct {
+	Args []RotateSecretArg `json:"args"`
+}
+
+// GrantSecretArg identifies a secret and an application to grant or
+// revoke access to it.
+type GrantSecretArg struct {
+	Name        string `json:"name"`
+	Application string `json:"application"`
+}
+
+// GrantSecretArgs holds the arguments for the Secrets.GrantSecrets and
+// Secrets.RevokeSecrets calls.
+type GrantSecretArgs struct {
+	Args []GrantSecretArg `json:"args"`
+}
+
+// SecretNames holds the names of secrets.
+type SecretNames struct {
+	Names []string `json:"names"`
+}
+
+// SecretDetails describes a secret, without its values.
+type SecretDetails struct {
+	Name         string    `json:"name"`
+	Description  string    `json:"description,omitempty"`
+	Revision     int       `json:"revision"`
+	Applications []string  `json:"applications"`
+	Created      time.Time `json:"created"`
+	Updated      time.Time `json:"updated"`
+}
+
+// ListSecretsResults holds the result of the Secrets.ListSecrets call.
+type ListSecretsResults struct {
+	Results []SecretDetails `json:"results"`
+}
+
+// SecretValuesResult holds the values of a secret, or an error.
+type SecretValuesResult struct {
+	Revision int               `json:"revision,omitempty"`
+	Values   map[string]string `json:"values,omitempty"`
+	Error    *Error            `json:"error,omitempty"`
+}
+
+// SecretValuesResults holds the result of the Uniter.SecretValues
+// call.
+type SecretValuesResults struct {
+	Results []SecretValuesResult `json:"results"`
+}
+
+// SecretRevisionsResult holds the revisions of the secrets granted to
+// a unit's application, keyed by secret name, or an error.
+type SecretRevisionsResult struct {
+	Revisions map[string]int `json:"revisions"`
+	Error     *Error         `json:"error,omitempty"`
+}
@@ -194,6 +194,7 @@ type History struct {
 type StatusHistoryFilter struct {
 	Size    int            `json:"size"`
 	Date    *time.Time     `json:"date"`
+	ToDate  *time.Time     `json:"to-date,omitempty"`
 	Delta   *time.Duration `json:"delta"`
 	Exclude []string       `json:"exclude"`
 }
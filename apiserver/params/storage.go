@@ -94,6 +94,7 @@ type StorageAttachment struct {
 	Kind     StorageKind `json:"kind"`
 	Location string      `json:"location"`
 	Life     Life        `json:"life"`
+	Size     uint64      `json:"size,omitempty"`
 }
 
 // StorageAttachmentId identifies a storage attachment by the tags of the
@@ -215,6 +216,109 @@ type VolumeParams struct {
 	Attributes map[string]interface{}  `json:"attributes,omitempty"`
 	Tags       map[string]string       `json:"tags,omitempty"`
 	Attachment *VolumeAttachmentParams `json:"attachment,omitempty"`
+	SnapshotId string                  `json:"snapshot-id,omitempty"`
+}
+
+// VolumeSnapshotIds holds the IDs of one or more volume snapshots.
+type VolumeSnapshotIds struct {
+	Ids []string `json:"ids"`
+}
+
+// VolumeSnapshotParams holds the parameters for taking a snapshot
+// of a volume.
+type VolumeSnapshotParams struct {
+	Id         string                 `json:"id"`
+	VolumeTag  string                 `json:"volume-tag"`
+	VolumeId   string                 `json:"volume-id"`
+	Provider   string                 `json:"provider"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+	Tags       map[string]string      `json:"tags,omitempty"`
+}
+
+// VolumeSnapshotParamsResult holds the parameters for taking a volume
+// snapshot, or an error. Result is nil if the snapshot has already
+// been taken, or has failed.
+type VolumeSnapshotParamsResult struct {
+	Result *VolumeSnapshotParams `json:"result,omitempty"`
+	Error  *Error                `json:"error,omitempty"`
+}
+
+// VolumeSnapshotParamsResults holds the parameters for taking
+// multiple volume snapshots.
+type VolumeSnapshotParamsResults struct {
+	Results []VolumeSnapshotParamsResult `json:"results,omitempty"`
+}
+
+// VolumeSnapshotDestroyParams holds the parameters for destroying a
+// volume snapshot.
+type VolumeSnapshotDestroyParams struct {
+	Id         string `json:"id"`
+	SnapshotId string `json:"snapshot-id"`
+	Provider   string `json:"provider"`
+}
+
+// VolumeSnapshotDestroyParamsResult holds the parameters for destroying
+// a volume snapshot, or an error. Result is nil if the snapshot is not
+// Dead.
+type VolumeSnapshotDestroyParamsResult struct {
+	Result *VolumeSnapshotDestroyParams `json:"result,omitempty"`
+	Error  *Error                       `json:"error,omitempty"`
+}
+
+// VolumeSnapshotDestroyParamsResults holds the parameters for
+// destroying multiple volume snapshots.
+type VolumeSnapshotDestroyParamsResults struct {
+	Results []VolumeSnapshotDestroyParamsResult `json:"results,omitempty"`
+}
+
+// VolumeSnapshotResult holds the outcome of taking a volume snapshot:
+// either the provider-allocated snapshot ID and size, or an error.
+type VolumeSnapshotResult struct {
+	Id         string `json:"id"`
+	SnapshotId string `json:"snapshot-id,omitempty"`
+	Size       uint64 `json:"size,omitempty"`
+	Error      *Error `json:"error,omitempty"`
+}
+
+// VolumeSnapshotResults holds the outcomes of taking multiple volume
+// snapshots.
+type VolumeSnapshotResults struct {
+	Results []VolumeSnapshotResult `json:"results"`
+}
+
+// VolumeResizeParams holds the parameters for growing a volume.
+type VolumeResizeParams struct {
+	VolumeTag  string                 `json:"volume-tag"`
+	VolumeId   string                 `json:"volume-id"`
+	Size       uint64                 `json:"size"`
+	Provider   string                 `json:"provider"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+}
+
+// VolumeResizeParamsResult holds the parameters for growing a volume,
+// or an error. Result is nil if the volume has no resize pending.
+type VolumeResizeParamsResult struct {
+	Result *VolumeResizeParams `json:"result,omitempty"`
+	Error  *Error              `json:"error,omitempty"`
+}
+
+// VolumeResizeParamsResults holds the parameters for growing multiple
+// volumes.
+type VolumeResizeParamsResults struct {
+	Results []VolumeResizeParamsResult `json:"results,omitempty"`
+}
+
+// VolumeResizeResult holds the outcome of growing a volume: either
+// the new size of the volume in MiB, or an error.
+type VolumeResizeResult struct {
+	VolumeTag string `json:"volume-tag"`
+	Size      uint64 `json:"size,omitempty"`
+	Error     *Error `json:"error,omitempty"`
+}
+
+// VolumeResizeResults holds the outcomes of growing multiple volumes.
+type VolumeResizeResults struct {
+	Results []VolumeResizeResult `json:"results"`
 }
 
 // VolumeAttachmentParams holds the parameters for creating a volume
@@ -341,6 +445,44 @@ type FilesystemParams struct {
 	Attachment    *FilesystemAttachmentParams `json:"attachment,omitempty"`
 }
 
+// FilesystemResizeParams holds the parameters for growing a filesystem.
+type FilesystemResizeParams struct {
+	FilesystemTag string                 `json:"filesystem-tag"`
+	FilesystemId  string                 `json:"filesystem-id"`
+	VolumeTag     string                 `json:"volume-tag,omitempty"`
+	Size          uint64                 `json:"size"`
+	Provider      string                 `json:"provider"`
+	Attributes    map[string]interface{} `json:"attributes,omitempty"`
+}
+
+// FilesystemResizeParamsResult holds the parameters for growing a
+// filesystem, or an error. Result is nil if the filesystem has no
+// resize pending.
+type FilesystemResizeParamsResult struct {
+	Result *FilesystemResizeParams `json:"result,omitempty"`
+	Error  *Error                  `json:"error,omitempty"`
+}
+
+// FilesystemResizeParamsResults holds the parameters for growing
+// multiple filesystems.
+type FilesystemResizeParamsResults struct {
+	Results []FilesystemResizeParamsResult `json:"results,omitempty"`
+}
+
+// FilesystemResizeResult holds the outcome of growing a filesystem:
+// either the new size of the filesystem in MiB, or an error.
+type FilesystemResizeResult struct {
+	FilesystemTag string `json:"filesystem-tag"`
+	Size          uint64 `json:"size,omitempty"`
+	Error         *Error `json:"error,omitempty"`
+}
+
+// FilesystemResizeResults holds the outcomes of growing multiple
+// filesystems.
+type FilesystemResizeResults struct {
+	Results []FilesystemResizeResult `json:"results"`
+}
+
 // FilesystemAttachmentParams holds the parameters for creating a filesystem
 // attachment.
 type FilesystemAttachmentParams struct {
@@ -724,9 +866,69 @@ type StorageAddParams struct {
 
 	// Constraints are specified storage constraints.
 	Constraints StorageConstraints `json:"storage"`
+
+	// FromSnapshot, if non-empty, is the ID of the volume snapshot
+	// from which to create the storage instance.
+	FromSnapshot string `json:"from-snapshot,omitempty"`
 }
 
 // StoragesAddParams holds storage details to add to units dynamically.
 type StoragesAddParams struct {
 	Storages []StorageAddParams `json:"storages"`
 }
+
+// StorageResizeParams holds the details of a storage instance to resize.
+type StorageResizeParams struct {
+	// StorageTag is the tag of the storage instance to resize.
+	StorageTag string `json:"storage-tag"`
+
+	// Size is the new size of the storage instance, in MiB.
+	Size uint64 `json:"size"`
+}
+
+// StoragesResizeParams holds the details of storage instances to resize.
+type StoragesResizeParams struct {
+	Storage []StorageResizeParams `json:"storage"`
+}
+
+// BulkImportStorageParams contains the parameters for importing a collection
+// of storage entities.
+type BulkImportStorageParams struct {
+	Storage []ImportStorageParams `json:"storage"`
+}
+
+// ImportStorageParams contains the parameters for importing a storage entity.
+type ImportStorageParams struct {
+	// Kind is the kind of the storage entity to import.
+	Kind StorageKind `json:"kind"`
+
+	// Pool is the name of the storage pool into which the storage is to
+	// be imported.
+	Pool string `json:"pool"`
+
+	// ProviderId is the storage provider's unique ID for the storage,
+	// e.g. the EBS volume ID.
+	ProviderId string `json:"provider-id"`
+
+	// StorageName is the name of the storage to assign to the entity.
+	StorageName string `json:"storage-name"`
+}
+
+// ImportStorageResults contains the results of importing a collection of
+// storage entities.
+type ImportStorageResults struct {
+	Results []ImportStorageResult `json:"results"`
+}
+
+// ImportStorageResult contains the result of importing a storage entity.
+type ImportStorageResult struct {
+	Result *ImportStorageDetails `json:"result,omitempty"`
+	Error  *Error                `json:"error,omitempty"`
+}
+
+// ImportStorageDetails contains the details of an imported storage entity.
+type ImportStorageDetails struct {
+	// StorageTag contains the string representation of the storage tag
+	// assigned to the imported storage entity.
+	StorageTag string `json:"storage-tag"`
+}
@@ -0,0 +1,226 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// RateLimitConfig holds the limits applied to the API connections
+// made by users. A zero value for any field means there is no limit.
+// Agents are not subject to these limits.
+type RateLimitConfig struct {
+	// UserRequestRate is the maximum number of requests per second
+	// that each user may make.
+	UserRequestRate int
+
+	// ModelRequestRate is the maximum number of requests per second
+	// that users may make, in total, to each model.
+	ModelRequestRate int
+
+	// UserMaxConnections is the maximum number of concurrent
+	// connections that each user may hold.
+	UserMaxConnections int
+
+	// ModelMaxConnections is the maximum number of concurrent
+	// connections that users may hold, in total, to each model.
+	ModelMaxConnections int
+}
+
+// userLimiter enforces a RateLimitConfig. Requests are limited by a
+// token bucket for each user and model, which holds up to one second's
+// worth of requests. Connections are limited by counting those held by
+// each user and to each model.
+type userLimiter struct {
+	clock  clock.Clock
+	config RateLimitConfig
+
+	// mu guards the fields below it.
+	mu sync.Mutex
+
+	// connections holds the number of connections for each
+	// user and model key.
+	connections map[string]int
+
+	// buckets holds the request token bucket for each user and
+	// model key. A bucket is discarded when the last connection
+	// for its key is released.
+	buckets map[string]*tokenBucket
+}
+
+func newUserLimiter(clock clock.Clock, config RateLimitConfig) *userLimiter {
+	return &userLimiter{
+		clock:       clock,
+		config:      config,
+		connections: make(map[string]int),
+		buckets:     make(map[string]*tokenBucket),
+	}
+}
+
+// enabled reports whether any limits are configured.
+func (l *userLimiter) enabled() bool {
+	return l.config != RateLimitConfig{}
+}
+
+// limitsRequests reports whether request rate limits are configured.
+func (l *userLimiter) limitsRequests() bool {
+	return l.config.UserRequestRate > 0 || l.config.ModelRequestRate > 0
+}
+
+func userLimitKey(user names.UserTag) string {
+	return "user " + user.Id()
+}
+
+func modelLimitKey(modelUUID string) string {
+	return "model " + modelUUID
+}
+
+// acquireConnection records a connection by the given user to the
+// model with the given UUID, which is empty for connections to the
+// controller. It returns a function that must be called when the
+// connection is closed, or an error with the code
+// params.CodeConnectionLimitExceeded if the connection would exceed
+// the configured limits.
+func (l *userLimiter) acquireConnection(user names.UserTag, modelUUID string) (func(), error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	userKey := userLimitKey(user)
+	if max := l.config.UserMaxConnections; max > 0 && l.connections[userKey] >= max {
+		return nil, connectionLimitExceededError("user " + user.Id())
+	}
+	keys := []string{userKey}
+	if modelUUID != "" {
+		modelKey := modelLimitKey(modelUUID)
+		if max := l.config.ModelMaxConnections; max > 0 && l.connections[modelKey] >= max {
+			return nil, connectionLimitExceededError("model " + modelUUID)
+		}
+		keys = append(keys, modelKey)
+	}
+	for _, key := range keys {
+		l.connections[key]++
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { l.releaseConnection(keys) })
+	}, nil
+}
+
+func (l *userLimiter) releaseConnection(keys []string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, key := range keys {
+		l.connections[key]--
+		if l.connections[key] <= 0 {
+			delete(l.connections, key)
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// checkRequest consumes a request from the allowances of the given
+// user and of the model with the given UUID, which is empty for
+// requests made to the controller. It returns an error with the code
+// params.CodeRateLimitExceeded if either allowance is exhausted, in
+// which case neither is consumed.
+func (l *userLimiter) checkRequest(user names.UserTag, modelUUID string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.clock.Now()
+	var buckets []*tokenBucket
+	if rate := l.config.UserRequestRate; rate > 0 {
+		bucket := l.bucket(userLimitKey(user), rate, now)
+		if !bucket.available() {
+			return rateLimitExceededError("user " + user.Id())
+		}
+		buckets = append(buckets, bucket)
+	}
+	if rate := l.config.ModelRequestRate; rate > 0 && modelUUID != "" {
+		bucket := l.bucket(modelLimitKey(modelUUID), rate, now)
+		if !bucket.available() {
+			return rateLimitExceededError("model " + modelUUID)
+		}
+		buckets = append(buckets, bucket)
+	}
+	for _, bucket := range buckets {
+		bucket.take()
+	}
+	return nil
+}
+
+// bucket returns the token bucket for the given key, refilled at the
+// given rate up to the given time.
+func (l *userLimiter) bucket(key string, rate int, now time.Time) *tokenBucket {
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{
+			capacity: float64(rate),
+			tokens:   float64(rate),
+			last:     now,
+		}
+		l.buckets[key] = bucket
+	}
+	bucket.refill(now, rate)
+	return bucket
+}
+
+// tokenBucket holds the state of a token bucket rate limiter.
+type tokenBucket struct {
+	capacity float64
+	tokens   float64
+	last     time.Time
+}
+
+func (b *tokenBucket) refill(now time.Time, rate int) {
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens += elapsed.Seconds() * float64(rate)
+		if b.tokens > b.capacity {
+			b.tokens = b.capacity
+		}
+		b.last = now
+	}
+}
+
+func (b *tokenBucket) available() bool {
+	return b.tokens >= 1
+}
+
+func (b *tokenBucket) take() {
+	b.tokens--
+}
+
+func rateLimitExceededError(subject string) error {
+	return &params.Error{
+		Code:    params.CodeRateLimitExceeded,
+		Message: fmt.Sprintf("request rate limit exceeded for %s", subject),
+	}
+}
+
+func connectionLimitExceededError(subject string) error {
+	return &params.Error{
+		Code:    params.CodeConnectionLimitExceeded,
+		Message: fmt.Sprintf("connection limit exceeded for %s", subject),
+	}
+}
+
+// limitedConnection is a facade.Resource that releases a connection
+// acquired from a userLimiter when it is stopped.
+type limitedConnection struct {
+	release func()
+}
+
+// Stop is part of the facade.Resource interface.
+func (c limitedConnection) Stop() error {
+	c.release()
+	return nil
+}
@@ -0,0 +1,144 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+const (
+	limiterModelUUID      = "b266dff7-eee8-4297-b03a-4692796ec193"
+	limiterOtherModelUUID = "1ab5799e-e72d-4de7-b70d-499edfab0e5c"
+)
+
+type userLimiterSuite struct {
+	testing.IsolationSuite
+	clock *testing.Clock
+	bob   names.UserTag
+	alice names.UserTag
+}
+
+var _ = gc.Suite(&userLimiterSuite{})
+
+func (s *userLimiterSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Date(2017, 5, 1, 12, 0, 0, 0, time.UTC))
+	s.bob = names.NewUserTag("bob")
+	s.alice = names.NewUserTag("alice")
+}
+
+func (s *userLimiterSuite) TestEnabled(c *gc.C) {
+	l := newUserLimiter(s.clock, RateLimitConfig{})
+	c.Assert(l.enabled(), jc.IsFalse)
+	c.Assert(l.limitsRequests(), jc.IsFalse)
+
+	l = newUserLimiter(s.clock, RateLimitConfig{UserMaxConnections: 1})
+	c.Assert(l.enabled(), jc.IsTrue)
+	c.Assert(l.limitsRequests(), jc.IsFalse)
+
+	l = newUserLimiter(s.clock, RateLimitConfig{ModelRequestRate: 1})
+	c.Assert(l.enabled(), jc.IsTrue)
+	c.Assert(l.limitsRequests(), jc.IsTrue)
+}
+
+func (s *userLimiterSuite) TestUserMaxConnections(c *gc.C) {
+	l := newUserLimiter(s.clock, RateLimitConfig{UserMaxConnections: 2})
+	release1, err := l.acquireConnection(s.bob, limiterModelUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = l.acquireConnection(s.bob, limiterOtherModelUUID)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = l.acquireConnection(s.bob, "")
+	c.Assert(err, gc.ErrorMatches, "connection limit exceeded for user bob")
+	c.Assert(err, jc.Satisfies, params.IsCodeConnectionLimitExceeded)
+
+	// Other users are unaffected.
+	_, err = l.acquireConnection(s.alice, limiterModelUUID)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Releasing a connection twice only frees one slot.
+	release1()
+	release1()
+	_, err = l.acquireConnection(s.bob, "")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = l.acquireConnection(s.bob, "")
+	c.Assert(err, jc.Satisfies, params.IsCodeConnectionLimitExceeded)
+}
+
+func (s *userLimiterSuite) TestModelMaxConnections(c *gc.C) {
+	l := newUserLimiter(s.clock, RateLimitConfig{ModelMaxConnections: 1})
+	release, err := l.acquireConnection(s.bob, limiterModelUUID)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = l.acquireConnection(s.alice, limiterModelUUID)
+	c.Assert(err, gc.ErrorMatches, "connection limit exceeded for model "+limiterModelUUID)
+	c.Assert(err, jc.Satisfies, params.IsCodeConnectionLimitExceeded)
+
+	// Connections to other models and the controller are unaffected.
+	_, err = l.acquireConnection(s.alice, limiterOtherModelUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = l.acquireConnection(s.alice, "")
+	c.Assert(err, jc.ErrorIsNil)
+
+	release()
+	_, err = l.acquireConnection(s.alice, limiterModelUUID)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *userLimiterSuite) TestUserRequestRate(c *gc.C) {
+	l := newUserLimiter(s.clock, RateLimitConfig{UserRequestRate: 2})
+	c.Assert(l.checkRequest(s.bob, limiterModelUUID), jc.ErrorIsNil)
+	c.Assert(l.checkRequest(s.bob, limiterOtherModelUUID), jc.ErrorIsNil)
+
+	err := l.checkRequest(s.bob, limiterModelUUID)
+	c.Assert(err, gc.ErrorMatches, "request rate limit exceeded for user bob")
+	c.Assert(err, jc.Satisfies, params.IsCodeRateLimitExceeded)
+	c.Assert(l.checkRequest(s.alice, limiterModelUUID), jc.ErrorIsNil)
+
+	// Half a second refills one request.
+	s.clock.Advance(500 * time.Millisecond)
+	c.Assert(l.checkRequest(s.bob, limiterModelUUID), jc.ErrorIsNil)
+	c.Assert(l.checkRequest(s.bob, limiterModelUUID), jc.Satisfies, params.IsCodeRateLimitExceeded)
+
+	// The allowance never exceeds one second's worth of requests.
+	s.clock.Advance(time.Minute)
+	c.Assert(l.checkRequest(s.bob, limiterModelUUID), jc.ErrorIsNil)
+	c.Assert(l.checkRequest(s.bob, limiterModelUUID), jc.ErrorIsNil)
+	c.Assert(l.checkRequest(s.bob, limiterModelUUID), jc.Satisfies, params.IsCodeRateLimitExceeded)
+}
+
+func (s *userLimiterSuite) TestModelRequestRate(c *gc.C) {
+	l := newUserLimiter(s.clock, RateLimitConfig{
+		UserRequestRate:  2,
+		ModelRequestRate: 1,
+	})
+	c.Assert(l.checkRequest(s.bob, limiterModelUUID), jc.ErrorIsNil)
+
+	err := l.checkRequest(s.alice, limiterModelUUID)
+	c.Assert(err, gc.ErrorMatches, "request rate limit exceeded for model "+limiterModelUUID)
+
+	// The failed request did not consume alice's allowance.
+	c.Assert(l.checkRequest(s.alice, limiterOtherModelUUID), jc.ErrorIsNil)
+	c.Assert(l.checkRequest(s.alice, ""), jc.ErrorIsNil)
+	c.Assert(l.checkRequest(s.alice, ""), jc.Satisfies, params.IsCodeRateLimitExceeded)
+}
+
+func (s *userLimiterSuite) TestReleaseDiscardsState(c *gc.C) {
+	l := newUserLimiter(s.clock, RateLimitConfig{UserRequestRate: 1})
+	release, err := l.acquireConnection(s.bob, limiterModelUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(l.checkRequest(s.bob, limiterModelUUID), jc.ErrorIsNil)
+	c.Assert(l.buckets, gc.HasLen, 1)
+
+	release()
+	c.Assert(l.connections, gc.HasLen, 0)
+	c.Assert(l.buckets, gc.HasLen, 0)
+}
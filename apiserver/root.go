@@ -14,6 +14,7 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/audit"
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/rpc"
 	"github.com/juju/juju/rpc/rpcreflect"
@@ -137,16 +138,27 @@ type apiRoot struct {
 	authorizer  facade.Authorizer
 	objectMutex sync.RWMutex
 	objectCache map[objectKey]reflect.Value
+
+	// auditEntrySink is given to the facades, to record audit
+	// entries for events not seen by the API server's observers.
+	auditEntrySink audit.AuditEntrySinkFn
 }
 
 // newAPIRoot returns a new apiRoot.
-func newAPIRoot(st *state.State, pool *state.StatePool, resources *common.Resources, authorizer facade.Authorizer) *apiRoot {
+func newAPIRoot(
+	st *state.State,
+	pool *state.StatePool,
+	resources *common.Resources,
+	authorizer facade.Authorizer,
+	auditEntrySink audit.AuditEntrySinkFn,
+) *apiRoot {
 	r := &apiRoot{
-		state:       st,
-		pool:        pool,
-		resources:   resources,
-		authorizer:  authorizer,
-		objectCache: make(map[objectKey]reflect.Value),
+		state:          st,
+		pool:           pool,
+		resources:      resources,
+		authorizer:     authorizer,
+		auditEntrySink: auditEntrySink,
+		objectCache:    make(map[objectKey]reflect.Value),
 	}
 	return r
 }
@@ -268,6 +280,11 @@ func (ctx *facadeContext) StatePool() *state.StatePool {
 	return ctx.r.pool
 }
 
+// AuditEntrySink is part of of the facade.Context interface.
+func (ctx *facadeContext) AuditEntrySink() audit.AuditEntrySinkFn {
+	return ctx.r.auditEntrySink
+}
+
 // ID is part of of the facade.Context interface.
 func (ctx *facadeContext) ID() string {
 	return ctx.key.objId
@@ -0,0 +1,199 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package secrets defines an API end point for managing the secrets
+// of a model and the applications granted access to them.
+package secrets
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the state methods used by the Secrets facade.
+type Backend interface {
+	ModelTag() names.ModelTag
+	AddSecret(state.SecretArgs) error
+	Secret(name string) (Secret, error)
+	AllSecrets() ([]Secret, error)
+	RemoveSecret(name string) error
+}
+
+// Secret defines the methods of state.Secret used by the Secrets
+// facade.
+type Secret interface {
+	Name() string
+	Description() string
+	Revision() int
+	Applications() []string
+	Created() time.Time
+	Updated() time.Time
+	Rotate(map[string]string) error
+	Grant(application string) error
+	Revoke(application string) error
+}
+
+// BlockChecker defines the block-checking functionality required by
+// the Secrets facade. This is implemented by
+// apiserver/common.BlockChecker.
+type BlockChecker interface {
+	ChangeAllowed() error
+	RemoveAllowed() error
+}
+
+// API implements the Secrets facade.
+type API struct {
+	backend    Backend
+	authorizer facade.Authorizer
+	check      BlockChecker
+}
+
+// NewAPI returns a new Secrets facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer, check BlockChecker) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		authorizer: authorizer,
+		check:      check,
+	}, nil
+}
+
+func (api *API) checkPermission(access permission.Access) error {
+	ok, err := api.authorizer.HasPermission(access, api.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !ok {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// checkCanChange returns an error if the authenticated user may not
+// manage the model's secrets, or if changes are blocked.
+func (api *API) checkCanChange() error {
+	if err := api.checkPermission(permission.AdminAccess); err != nil {
+		return err
+	}
+	return api.check.ChangeAllowed()
+}
+
+// ListSecrets returns the details, but not the values, of all secrets
+// in the model.
+func (api *API) ListSecrets() (params.ListSecretsResults, error) {
+	if err := api.checkPermission(permission.ReadAccess); err != nil {
+		return params.ListSecretsResults{}, err
+	}
+	secrets, err := api.backend.AllSecrets()
+	if err != nil {
+		return params.ListSecretsResults{}, errors.Trace(err)
+	}
+	result := params.ListSecretsResults{
+		Results: make([]params.SecretDetails, len(secrets)),
+	}
+	for i, secret := range secrets {
+		result.Results[i] = params.SecretDetails{
+			Name:         secret.Name(),
+			Description:  secret.Description(),
+			Revision:     secret.Revision(),
+			Applications: secret.Applications(),
+			Created:      secret.Created(),
+			Updated:      secret.Updated(),
+		}
+	}
+	return result, nil
+}
+
+// AddSecrets adds new secrets to the model.
+func (api *API) AddSecrets(args params.AddSecretArgs) (params.ErrorResults, error) {
+	if err := api.checkCanChange(); err != nil {
+		return params.ErrorResults{}, err
+	}
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		err := api.backend.AddSecret(state.SecretArgs{
+			Name:        arg.Name,
+			Description: arg.Description,
+			Values:      arg.Values,
+		})
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// RotateSecrets replaces the values of existing secrets. The units of
+// applications granted access to a rotated secret run their
+// secret-changed hook.
+func (api *API) RotateSecrets(args params.RotateSecretArgs) (params.ErrorResults, error) {
+	if err := api.checkCanChange(); err != nil {
+		return params.ErrorResults{}, err
+	}
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		secret, err := api.backend.Secret(arg.Name)
+		if err == nil {
+			err = secret.Rotate(arg.Values)
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// GrantSecrets gives applications access to secrets.
+func (api *API) GrantSecrets(args params.GrantSecretArgs) (params.ErrorResults, error) {
+	return api.updateGrants(args, Secret.Grant)
+}
+
+// RevokeSecrets removes applications' access to secrets.
+func (api *API) RevokeSecrets(args params.GrantSecretArgs) (params.ErrorResults, error) {
+	return api.updateGrants(args, Secret.Revoke)
+}
+
+func (api *API) updateGrants(args params.GrantSecretArgs, update func(Secret, string) error) (params.ErrorResults, error) {
+	if err := api.checkCanChange(); err != nil {
+		return params.ErrorResults{}, err
+	}
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		secret, err := api.backend.Secret(arg.Name)
+		if err == nil {
+			err = update(secret, arg.Application)
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// RemoveSecrets removes secrets from the model.
+func (api *API) RemoveSecrets(args params.SecretNames) (params.ErrorResults, error) {
+	if err := api.checkPermission(permission.AdminAccess); err != nil {
+		return params.ErrorResults{}, err
+	}
+	if err := api.check.RemoveAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Names)),
+	}
+	for i, name := range args.Names {
+		err := api.backend.RemoveSecret(name)
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
@@ -0,0 +1,252 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/apiserver/secrets"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type secretsSuite struct {
+	gitjujutesting.IsolationSuite
+	backend    *mockBackend
+	check      *mockBlockChecker
+	authorizer apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&secretsSuite{})
+
+func (s *secretsSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &mockBackend{
+		secrets: map[string]*mockSecret{
+			"db-password": {
+				name:         "db-password",
+				description:  "database credentials",
+				revision:     2,
+				applications: []string{"mysql"},
+			},
+		},
+	}
+	s.check = &mockBlockChecker{}
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("admin"),
+	}
+}
+
+func (s *secretsSuite) newAPI(c *gc.C) *secrets.API {
+	api, err := secrets.NewAPI(s.backend, s.authorizer, s.check)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *secretsSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := secrets.NewAPI(s.backend, s.authorizer, s.check)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *secretsSuite) TestListSecrets(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("read")
+	result, err := s.newAPI(c).ListSecrets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ListSecretsResults{
+		Results: []params.SecretDetails{{
+			Name:         "db-password",
+			Description:  "database credentials",
+			Revision:     2,
+			Applications: []string{"mysql"},
+			Created:      t0,
+			Updated:      t0,
+		}},
+	})
+}
+
+func (s *secretsSuite) TestAddSecrets(c *gc.C) {
+	result, err := s.newAPI(c).AddSecrets(params.AddSecretArgs{
+		Args: []params.AddSecretArg{{
+			Name:   "api-token",
+			Values: map[string]string{"token": "abc"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{}},
+	})
+	s.backend.CheckCalls(c, []gitjujutesting.StubCall{
+		{"ModelTag", nil},
+		{"AddSecret", []interface{}{state.SecretArgs{
+			Name:   "api-token",
+			Values: map[string]string{"token": "abc"},
+		}}},
+	})
+}
+
+func (s *secretsSuite) TestAddSecretsRequiresAdmin(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("write")
+	_, err := s.newAPI(c).AddSecrets(params.AddSecretArgs{
+		Args: []params.AddSecretArg{{Name: "api-token"}},
+	})
+	c.Assert(err, gc.Equals, common.ErrPerm)
+	s.backend.CheckCallNames(c, "ModelTag")
+}
+
+func (s *secretsSuite) TestAddSecretsBlocked(c *gc.C) {
+	s.check.SetErrors(common.OperationBlockedError("no changes"))
+	_, err := s.newAPI(c).AddSecrets(params.AddSecretArgs{
+		Args: []params.AddSecretArg{{Name: "api-token"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "no changes")
+}
+
+func (s *secretsSuite) TestRotateSecrets(c *gc.C) {
+	result, err := s.newAPI(c).RotateSecrets(params.RotateSecretArgs{
+		Args: []params.RotateSecretArg{{
+			Name:   "db-password",
+			Values: map[string]string{"password": "new"},
+		}, {
+			Name:   "api-token",
+			Values: map[string]string{"token": "new"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Code: params.CodeNotFound, Message: `secret "api-token" not found`}},
+		},
+	})
+	s.backend.secrets["db-password"].CheckCalls(c, []gitjujutesting.StubCall{
+		{"Rotate", []interface{}{map[string]string{"password": "new"}}},
+	})
+}
+
+func (s *secretsSuite) TestGrantAndRevokeSecrets(c *gc.C) {
+	api := s.newAPI(c)
+	args := params.GrantSecretArgs{
+		Args: []params.GrantSecretArg{{Name: "db-password", Application: "wordpress"}},
+	}
+	result, err := api.GrantSecrets(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+	result, err = api.RevokeSecrets(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+	s.backend.secrets["db-password"].CheckCalls(c, []gitjujutesting.StubCall{
+		{"Grant", []interface{}{"wordpress"}},
+		{"Revoke", []interface{}{"wordpress"}},
+	})
+}
+
+func (s *secretsSuite) TestRemoveSecrets(c *gc.C) {
+	result, err := s.newAPI(c).RemoveSecrets(params.SecretNames{
+		Names: []string{"db-password"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+	s.backend.CheckCalls(c, []gitjujutesting.StubCall{
+		{"ModelTag", nil},
+		{"RemoveSecret", []interface{}{"db-password"}},
+	})
+	s.check.CheckCallNames(c, "RemoveAllowed")
+}
+
+var t0 = time.Date(2017, time.June, 1, 12, 0, 0, 0, time.UTC)
+
+type mockBackend struct {
+	gitjujutesting.Stub
+	secrets map[string]*mockSecret
+}
+
+func (b *mockBackend) ModelTag() names.ModelTag {
+	b.MethodCall(b, "ModelTag")
+	return coretesting.ModelTag
+}
+
+func (b *mockBackend) AddSecret(args state.SecretArgs) error {
+	b.MethodCall(b, "AddSecret", args)
+	return b.NextErr()
+}
+
+func (b *mockBackend) Secret(name string) (secrets.Secret, error) {
+	b.MethodCall(b, "Secret", name)
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	secret, ok := b.secrets[name]
+	if !ok {
+		return nil, errors.NotFoundf("secret %q", name)
+	}
+	return secret, nil
+}
+
+func (b *mockBackend) AllSecrets() ([]secrets.Secret, error) {
+	b.MethodCall(b, "AllSecrets")
+	var result []secrets.Secret
+	for _, secret := range b.secrets {
+		result = append(result, secret)
+	}
+	return result, b.NextErr()
+}
+
+func (b *mockBackend) RemoveSecret(name string) error {
+	b.MethodCall(b, "RemoveSecret", name)
+	return b.NextErr()
+}
+
+type mockSecret struct {
+	gitjujutesting.Stub
+	name         string
+	description  string
+	revision     int
+	applications []string
+}
+
+func (s *mockSecret) Name() string           { return s.name }
+func (s *mockSecret) Description() string    { return s.description }
+func (s *mockSecret) Revision() int          { return s.revision }
+func (s *mockSecret) Applications() []string { return s.applications }
+func (s *mockSecret) Created() time.Time     { return t0 }
+func (s *mockSecret) Updated() time.Time     { return t0 }
+
+func (s *mockSecret) Rotate(values map[string]string) error {
+	s.MethodCall(s, "Rotate", values)
+	return s.NextErr()
+}
+
+func (s *mockSecret) Grant(application string) error {
+	s.MethodCall(s, "Grant", application)
+	return s.NextErr()
+}
+
+func (s *mockSecret) Revoke(application string) error {
+	s.MethodCall(s, "Revoke", application)
+	return s.NextErr()
+}
+
+type mockBlockChecker struct {
+	gitjujutesting.Stub
+}
+
+func (c *mockBlockChecker) ChangeAllowed() error {
+	c.MethodCall(c, "ChangeAllowed")
+	return c.NextErr()
+}
+
+func (c *mockBlockChecker) RemoveAllowed() error {
+	c.MethodCall(c, "RemoveAllowed")
+	return c.NextErr()
+}
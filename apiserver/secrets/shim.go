@@ -0,0 +1,60 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/state"
+)
+
+// This file contains untested shims to let us wrap state in a sensible
+// interface and avoid writing tests that depend on mongodb. If you were
+// to change any part of it so that it were no longer *obviously* and
+// *trivially* correct, you would be Doing It Wrong.
+
+func init() {
+	common.RegisterStandardFacade("Secrets", 1, newFacade)
+}
+
+// newFacade wraps the supplied *state.State for the use of the API.
+func newFacade(st *state.State, _ facade.Resources, auth facade.Authorizer) (*API, error) {
+	return NewAPI(stateShim{st}, auth, common.NewBlockChecker(st))
+}
+
+// stateShim wraps a *State to implement Backend without pulling in
+// direct mongodb dependencies.
+type stateShim struct {
+	*state.State
+}
+
+// AddSecret is part of the Backend interface.
+func (s stateShim) AddSecret(args state.SecretArgs) error {
+	_, err := s.State.AddSecret(args)
+	return err
+}
+
+// Secret is part of the Backend interface.
+func (s stateShim) Secret(name string) (Secret, error) {
+	secret, err := s.State.Secret(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return secret, nil
+}
+
+// AllSecrets is part of the Backend interface.
+func (s stateShim) AllSecrets() ([]Secret, error) {
+	secrets, err := s.State.AllSecrets()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Secret, len(secrets))
+	for i, secret := range secrets {
+		result[i] = secret
+	}
+	return result, nil
+}
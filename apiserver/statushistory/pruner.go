@@ -4,6 +4,8 @@
 package statushistory
 
 import (
+	"github.com/juju/errors"
+
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
@@ -30,10 +32,25 @@ func NewAPI(st *state.State, _ facade.Resources, auth facade.Authorizer) (*API,
 
 // Prune endpoint removes status history entries until
 // only the ones newer than now - p.MaxHistoryTime remain and
-// the history is smaller than p.MaxHistoryMB.
+// the history is smaller than p.MaxHistoryMB. Either limit is
+// replaced by the model's max-status-history-age or
+// max-status-history-size config, when set; a model size limit
+// applies to the model's own history only, and that history is
+// left alone when other models prune to the controller's limit.
 func (api *API) Prune(p params.StatusHistoryPruneArgs) error {
 	if !api.authorizer.AuthController() {
 		return common.ErrPerm
 	}
-	return state.PruneStatusHistory(api.st, p.MaxHistoryTime, p.MaxHistoryMB)
+	cfg, err := api.st.ModelConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	maxHistoryTime := p.MaxHistoryTime
+	if age := cfg.MaxStatusHistoryAge(); age > 0 {
+		maxHistoryTime = age
+	}
+	if size := cfg.MaxStatusHistorySizeMB(); size > 0 {
+		return state.PruneModelStatusHistory(api.st, maxHistoryTime, int(size))
+	}
+	return state.PruneStatusHistory(api.st, maxHistoryTime, p.MaxHistoryMB)
 }
@@ -86,6 +86,11 @@ const (
 	filesystemAttachmentsCall               = "filesystemAttachments"
 	allFilesystemsCall                      = "allFilesystems"
 	addStorageForUnitCall                   = "addStorageForUnit"
+	addStorageForUnitFromSnapshotCall       = "addStorageForUnitFromSnapshot"
+	addVolumeSnapshotCall                   = "addVolumeSnapshot"
+	destroyVolumeSnapshotCall               = "destroyVolumeSnapshot"
+	resizeStorageInstanceCall               = "resizeStorageInstance"
+	addExistingFilesystemCall               = "addExistingFilesystem"
 	getBlockForTypeCall                     = "getBlockForType"
 	volumeAttachmentCall                    = "volumeAttachment"
 	detachStorageCall                       = "detachStorage"
@@ -235,6 +240,26 @@ func (s *baseStorageSuite) constructState() *mockState {
 			s.stub.AddCall(addStorageForUnitCall)
 			return nil
 		},
+		addStorageForUnitFromSnapshot: func(u names.UnitTag, name, snapshotId string, cons state.StorageConstraints) error {
+			s.stub.AddCall(addStorageForUnitFromSnapshotCall, u, name, snapshotId, cons)
+			return nil
+		},
+		addVolumeSnapshot: func(tag names.VolumeTag) (state.VolumeSnapshot, error) {
+			s.stub.AddCall(addVolumeSnapshotCall, tag)
+			return &mockVolumeSnapshot{id: "3"}, nil
+		},
+		destroyVolumeSnapshot: func(id string) error {
+			s.stub.AddCall(destroyVolumeSnapshotCall, id)
+			return s.stub.NextErr()
+		},
+		resizeStorageInstance: func(tag names.StorageTag, size uint64) error {
+			s.stub.AddCall(resizeStorageInstanceCall, tag, size)
+			return s.stub.NextErr()
+		},
+		addExistingFilesystem: func(info state.FilesystemInfo, v *state.VolumeInfo, storageName string) (names.StorageTag, error) {
+			s.stub.AddCall(addExistingFilesystemCall, info, v, storageName)
+			return s.storageTag, s.stub.NextErr()
+		},
 		getBlockForType: func(t state.BlockType) (state.Block, bool, error) {
 			s.stub.AddCall(getBlockForTypeCall, t)
 			val, found := s.blocks[t]
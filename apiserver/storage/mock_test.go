@@ -47,6 +47,7 @@ type mockState struct {
 	storageInstanceFilesystemAttachment func(m names.MachineTag, f names.FilesystemTag) (state.FilesystemAttachment, error)
 	watchStorageAttachment              func(names.StorageTag, names.UnitTag) state.NotifyWatcher
 	watchFilesystemAttachment           func(names.MachineTag, names.FilesystemTag) state.NotifyWatcher
+	watchFilesystem                     func(names.FilesystemTag) state.NotifyWatcher
 	watchVolumeAttachment               func(names.MachineTag, names.VolumeTag) state.NotifyWatcher
 	watchBlockDevices                   func(names.MachineTag) state.NotifyWatcher
 	modelName                           string
@@ -60,6 +61,11 @@ type mockState struct {
 	filesystemAttachments               func(filesystem names.FilesystemTag) ([]state.FilesystemAttachment, error)
 	allFilesystems                      func() ([]state.Filesystem, error)
 	addStorageForUnit                   func(u names.UnitTag, name string, cons state.StorageConstraints) error
+	addStorageForUnitFromSnapshot       func(u names.UnitTag, name, snapshotId string, cons state.StorageConstraints) error
+	addVolumeSnapshot                   func(names.VolumeTag) (state.VolumeSnapshot, error)
+	destroyVolumeSnapshot               func(string) error
+	resizeStorageInstance               func(names.StorageTag, uint64) error
+	addExistingFilesystem               func(state.FilesystemInfo, *state.VolumeInfo, string) (names.StorageTag, error)
 	getBlockForType                     func(t state.BlockType) (state.Block, bool, error)
 	blockDevices                        func(names.MachineTag) ([]state.BlockDeviceInfo, error)
 	destroyStorageInstance              func(names.StorageTag) error
@@ -106,6 +112,10 @@ func (st *mockState) WatchFilesystemAttachment(mtag names.MachineTag, f names.Fi
 	return st.watchFilesystemAttachment(mtag, f)
 }
 
+func (st *mockState) WatchFilesystem(f names.FilesystemTag) state.NotifyWatcher {
+	return st.watchFilesystem(f)
+}
+
 func (st *mockState) WatchVolumeAttachment(mtag names.MachineTag, v names.VolumeTag) state.NotifyWatcher {
 	return st.watchVolumeAttachment(mtag, v)
 }
@@ -158,6 +168,30 @@ func (st *mockState) AddStorageForUnit(u names.UnitTag, name string, cons state.
 	return st.addStorageForUnit(u, name, cons)
 }
 
+func (st *mockState) AddStorageForUnitFromSnapshot(u names.UnitTag, name, snapshotId string, cons state.StorageConstraints) error {
+	return st.addStorageForUnitFromSnapshot(u, name, snapshotId, cons)
+}
+
+func (st *mockState) AddVolumeSnapshot(tag names.VolumeTag) (state.VolumeSnapshot, error) {
+	return st.addVolumeSnapshot(tag)
+}
+
+func (st *mockState) DestroyVolumeSnapshot(id string) error {
+	return st.destroyVolumeSnapshot(id)
+}
+
+func (st *mockState) ResizeStorageInstance(tag names.StorageTag, size uint64) error {
+	return st.resizeStorageInstance(tag, size)
+}
+
+func (st *mockState) AddExistingFilesystem(
+	info state.FilesystemInfo,
+	backingVolume *state.VolumeInfo,
+	storageName string,
+) (names.StorageTag, error) {
+	return st.addExistingFilesystem(info, backingVolume, storageName)
+}
+
 func (st *mockState) GetBlockForType(t state.BlockType) (state.Block, bool, error) {
 	return st.getBlockForType(t)
 }
@@ -389,3 +423,12 @@ func (b mockBlock) Type() state.BlockType {
 func (b mockBlock) Message() string {
 	return b.msg
 }
+
+type mockVolumeSnapshot struct {
+	state.VolumeSnapshot
+	id string
+}
+
+func (s *mockVolumeSnapshot) Id() string {
+	return s.id
+}
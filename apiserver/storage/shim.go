@@ -22,6 +22,15 @@ import (
 
 func init() {
 	common.RegisterStandardFacade("Storage", 3, newAPI)
+	// Version 4 adds CreateStorageSnapshots, and adding
+	// storage from snapshots.
+	common.RegisterStandardFacade("Storage", 4, newAPI)
+	// Version 5 adds ResizeStorage.
+	common.RegisterStandardFacade("Storage", 5, newAPI)
+	// Version 6 adds Import.
+	common.RegisterStandardFacade("Storage", 6, newAPI)
+	// Version 7 adds DestroyStorageSnapshots.
+	common.RegisterStandardFacade("Storage", 7, newAPI)
 }
 
 func newAPI(
@@ -69,6 +78,9 @@ type storageAccess interface {
 	// WatchFilesystemAttachment is required for storage functionality.
 	WatchFilesystemAttachment(names.MachineTag, names.FilesystemTag) state.NotifyWatcher
 
+	// WatchFilesystem is required for storage functionality.
+	WatchFilesystem(names.FilesystemTag) state.NotifyWatcher
+
 	// WatchVolumeAttachment is required for storage functionality.
 	WatchVolumeAttachment(names.MachineTag, names.VolumeTag) state.NotifyWatcher
 
@@ -111,6 +123,21 @@ type storageAccess interface {
 	// AddStorageForUnit is required for storage add functionality.
 	AddStorageForUnit(tag names.UnitTag, name string, cons state.StorageConstraints) error
 
+	// AddStorageForUnitFromSnapshot is required for storage add functionality.
+	AddStorageForUnitFromSnapshot(tag names.UnitTag, name, snapshotId string, cons state.StorageConstraints) error
+
+	// AddVolumeSnapshot is required for storage snapshot functionality.
+	AddVolumeSnapshot(tag names.VolumeTag) (state.VolumeSnapshot, error)
+
+	// DestroyVolumeSnapshot is required for storage snapshot functionality.
+	DestroyVolumeSnapshot(id string) error
+
+	// ResizeStorageInstance is required for storage resize functionality.
+	ResizeStorageInstance(tag names.StorageTag, size uint64) error
+
+	// AddExistingFilesystem is required for storage import functionality.
+	AddExistingFilesystem(state.FilesystemInfo, *state.VolumeInfo, string) (names.StorageTag, error)
+
 	// GetBlockForType is required to block operations.
 	GetBlockForType(t state.BlockType) (state.Block, bool, error)
 
@@ -718,7 +718,13 @@ func (a *API) AddToUnit(args params.StoragesAddParams) (params.ErrorResults, err
 			continue
 		}
 
-		err = a.storage.AddStorageForUnit(u, one.StorageName, paramsToState(one.Constraints))
+		if one.FromSnapshot != "" {
+			err = a.storage.AddStorageForUnitFromSnapshot(
+				u, one.StorageName, one.FromSnapshot, paramsToState(one.Constraints),
+			)
+		} else {
+			err = a.storage.AddStorageForUnit(u, one.StorageName, paramsToState(one.Constraints))
+		}
 		if err != nil {
 			result[i] = params.ErrorResult{Error: common.ServerError(err)}
 		}
@@ -726,6 +732,194 @@ func (a *API) AddToUnit(args params.StoragesAddParams) (params.ErrorResults, err
 	return params.ErrorResults{Results: result}, nil
 }
 
+// CreateStorageSnapshots requests snapshots of the volumes backing the
+// specified storage instances, returning the IDs of the snapshots. The
+// snapshots are taken asynchronously by the storage provisioner.
+// A "CHANGE" block can block this operation.
+func (a *API) CreateStorageSnapshots(args params.Entities) (params.StringResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.StringResults{}, errors.Trace(err)
+	}
+
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.StringResults{}, errors.Trace(err)
+	}
+
+	snapshotOne := func(arg params.Entity) (string, error) {
+		storageTag, err := names.ParseStorageTag(arg.Tag)
+		if err != nil {
+			return "", err
+		}
+		volume, err := a.storage.StorageInstanceVolume(storageTag)
+		if errors.IsNotFound(err) {
+			if _, err := a.storage.StorageInstance(storageTag); err != nil {
+				return "", err
+			}
+			// The storage instance exists, but is a
+			// filesystem that is not backed by a volume.
+			return "", errors.NotSupportedf(
+				"snapshots of filesystem %s", names.ReadableString(storageTag),
+			)
+		} else if err != nil {
+			return "", err
+		}
+		snapshot, err := a.storage.AddVolumeSnapshot(volume.VolumeTag())
+		if err != nil {
+			return "", err
+		}
+		return snapshot.Id(), nil
+	}
+
+	results := make([]params.StringResult, len(args.Entities))
+	for i, arg := range args.Entities {
+		id, err := snapshotOne(arg)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		results[i].Result = id
+	}
+	return params.StringResults{results}, nil
+}
+
+// DestroyStorageSnapshots requests that the snapshots with the specified
+// IDs be destroyed. The snapshots are destroyed asynchronously by the
+// storage provisioner.
+// A "REMOVE" block can block this operation.
+func (a *API) DestroyStorageSnapshots(args params.VolumeSnapshotIds) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.RemoveAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	results := make([]params.ErrorResult, len(args.Ids))
+	for i, id := range args.Ids {
+		if err := a.storage.DestroyVolumeSnapshot(id); err != nil {
+			results[i].Error = common.ServerError(err)
+		}
+	}
+	return params.ErrorResults{results}, nil
+}
+
+// ResizeStorage requests that the specified storage instances be grown
+// to the specified sizes. The storage is resized asynchronously by the
+// storage provisioner.
+// A "CHANGE" block can block this operation.
+func (a *API) ResizeStorage(args params.StoragesResizeParams) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	result := make([]params.ErrorResult, len(args.Storage))
+	for i, arg := range args.Storage {
+		storageTag, err := names.ParseStorageTag(arg.StorageTag)
+		if err == nil {
+			err = a.storage.ResizeStorageInstance(storageTag, arg.Size)
+		}
+		if err != nil {
+			result[i].Error = common.ServerError(err)
+		}
+	}
+	return params.ErrorResults{result}, nil
+}
+
+// Import imports existing storage into the model.
+// A "CHANGE" block can block this operation.
+func (a *API) Import(args params.BulkImportStorageParams) (params.ImportStorageResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ImportStorageResults{}, errors.Trace(err)
+	}
+
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ImportStorageResults{}, errors.Trace(err)
+	}
+
+	results := make([]params.ImportStorageResult, len(args.Storage))
+	for i, arg := range args.Storage {
+		details, err := a.importStorage(arg)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		results[i].Result = details
+	}
+	return params.ImportStorageResults{results}, nil
+}
+
+func (a *API) importStorage(arg params.ImportStorageParams) (*params.ImportStorageDetails, error) {
+	if arg.Kind != params.StorageKindFilesystem {
+		// TODO support importing block devices when we
+		// support attaching existing block storage to units.
+		return nil, errors.NotSupportedf("storage kind %q", arg.Kind.String())
+	}
+	if !storage.IsValidPoolName(arg.Pool) {
+		return nil, errors.NotValidf("pool name %q", arg.Pool)
+	}
+
+	providerType, cfg, err := storagecommon.StoragePoolConfig(arg.Pool, a.poolManager, a.registry)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	provider, err := a.registry.StorageProvider(providerType)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if provider.Supports(storage.StorageKindFilesystem) {
+		// TODO support importing filesystems from
+		// providers that manage filesystems directly.
+		return nil, errors.NotSupportedf("importing filesystem from %q provider", providerType)
+	}
+	if !provider.Supports(storage.StorageKindBlock) {
+		return nil, errors.NotSupportedf("importing storage from %q provider", providerType)
+	}
+	volumeSource, err := provider.VolumeSource(cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	results, err := volumeSource.DescribeVolumes([]string{arg.ProviderId})
+	if err != nil {
+		return nil, errors.Annotate(err, "describing volume")
+	}
+	if len(results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results))
+	}
+	if results[0].Error != nil {
+		return nil, errors.Annotate(results[0].Error, "describing volume")
+	}
+	volumeInfo := results[0].VolumeInfo
+	if volumeInfo == nil {
+		return nil, errors.NotFoundf("volume %q", arg.ProviderId)
+	}
+
+	storageTag, err := a.storage.AddExistingFilesystem(state.FilesystemInfo{
+		Pool: arg.Pool,
+		Size: volumeInfo.Size,
+	}, &state.VolumeInfo{
+		Pool:       arg.Pool,
+		VolumeId:   volumeInfo.VolumeId,
+		HardwareId: volumeInfo.HardwareId,
+		Size:       volumeInfo.Size,
+		Persistent: volumeInfo.Persistent,
+	}, arg.StorageName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &params.ImportStorageDetails{
+		StorageTag: storageTag.String(),
+	}, nil
+}
+
 // Destroy sets the specified storage entities to Dying, unless they are
 // already Dying or Dead.
 func (a *API) Destroy(args params.Entities) (params.ErrorResults, error) {
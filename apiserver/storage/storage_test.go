@@ -345,6 +345,90 @@ func (s *storageSuite) TestDestroy(c *gc.C) {
 	})
 }
 
+func (s *storageSuite) TestCreateStorageSnapshots(c *gc.C) {
+	results, err := s.api.CreateStorageSnapshots(params.Entities{Entities: []params.Entity{
+		{Tag: "storage-data-0"},
+		{Tag: "storage-foo-0"},
+		{Tag: "volume-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.StringResult{
+		{Result: "3"},
+		{Error: &params.Error{Code: params.CodeNotFound, Message: "storage foo/0 not found"}},
+		{Error: &params.Error{Message: `"volume-0" is not a valid storage tag`}},
+	})
+	s.stub.CheckCallNames(c,
+		getBlockForTypeCall, // Change
+		storageInstanceVolumeCall,
+		addVolumeSnapshotCall,
+		storageInstanceVolumeCall,
+		storageInstanceCall,
+	)
+	s.stub.CheckCall(c, 2, addVolumeSnapshotCall, s.volumeTag)
+}
+
+func (s *storageSuite) TestCreateStorageSnapshotsBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestCreateStorageSnapshotsBlocked")
+	_, err := s.api.CreateStorageSnapshots(params.Entities{Entities: []params.Entity{
+		{Tag: "storage-data-0"},
+	}})
+	s.assertBlocked(c, err, "TestCreateStorageSnapshotsBlocked")
+}
+
+func (s *storageSuite) TestDestroyStorageSnapshots(c *gc.C) {
+	s.stub.SetErrors(nil, errors.New("boom"))
+	results, err := s.api.DestroyStorageSnapshots(params.VolumeSnapshotIds{Ids: []string{"0", "1"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: "boom"}},
+	})
+	s.stub.CheckCallNames(c,
+		getBlockForTypeCall, // Remove
+		getBlockForTypeCall, // Change
+		destroyVolumeSnapshotCall,
+		destroyVolumeSnapshotCall,
+	)
+	s.stub.CheckCall(c, 2, destroyVolumeSnapshotCall, "0")
+	s.stub.CheckCall(c, 3, destroyVolumeSnapshotCall, "1")
+}
+
+func (s *storageSuite) TestDestroyStorageSnapshotsBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestDestroyStorageSnapshotsBlocked")
+	_, err := s.api.DestroyStorageSnapshots(params.VolumeSnapshotIds{Ids: []string{"0"}})
+	s.assertBlocked(c, err, "TestDestroyStorageSnapshotsBlocked")
+}
+
+func (s *storageSuite) TestResizeStorage(c *gc.C) {
+	s.stub.SetErrors(nil, errors.New("boom"))
+	results, err := s.api.ResizeStorage(params.StoragesResizeParams{Storage: []params.StorageResizeParams{
+		{StorageTag: "storage-data-0", Size: 2048},
+		{StorageTag: "storage-data-1", Size: 4096},
+		{StorageTag: "volume-0", Size: 1024},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: "boom"}},
+		{Error: &params.Error{Message: `"volume-0" is not a valid storage tag`}},
+	})
+	s.stub.CheckCallNames(c,
+		getBlockForTypeCall, // Change
+		resizeStorageInstanceCall,
+		resizeStorageInstanceCall,
+	)
+	s.stub.CheckCall(c, 1, resizeStorageInstanceCall, names.NewStorageTag("data/0"), uint64(2048))
+	s.stub.CheckCall(c, 2, resizeStorageInstanceCall, names.NewStorageTag("data/1"), uint64(4096))
+}
+
+func (s *storageSuite) TestResizeStorageBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestResizeStorageBlocked")
+	_, err := s.api.ResizeStorage(params.StoragesResizeParams{Storage: []params.StorageResizeParams{
+		{StorageTag: "storage-data-0", Size: 2048},
+	}})
+	s.assertBlocked(c, err, "TestResizeStorageBlocked")
+}
+
 func (s *storageSuite) TestDetach(c *gc.C) {
 	results, err := s.api.Detach(params.StorageAttachmentIds{[]params.StorageAttachmentId{
 		{StorageTag: "storage-data-0", UnitTag: "unit-mysql-0"},
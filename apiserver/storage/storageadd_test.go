@@ -50,6 +50,21 @@ func (s *storageAddSuite) TestStorageAddUnit(c *gc.C) {
 	s.assertCalls(c, []string{getBlockForTypeCall, addStorageForUnitCall})
 }
 
+func (s *storageAddSuite) TestStorageAddUnitFromSnapshot(c *gc.C) {
+	size := uint64(2048)
+	args := params.StorageAddParams{
+		UnitTag:      s.unitTag.String(),
+		StorageName:  "data",
+		FromSnapshot: "3",
+		Constraints:  params.StorageConstraints{Size: &size},
+	}
+	s.assertStorageAddedNoErrors(c, args)
+	s.assertCalls(c, []string{getBlockForTypeCall, addStorageForUnitFromSnapshotCall})
+	s.stub.CheckCall(c, 1, addStorageForUnitFromSnapshotCall,
+		s.unitTag, "data", "3", state.StorageConstraints{Size: 2048},
+	)
+}
+
 func (s *storageAddSuite) TestStorageAddUnitBlocked(c *gc.C) {
 	s.blockAllChanges(c, "TestStorageAddUnitBlocked")
 
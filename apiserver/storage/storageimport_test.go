@@ -0,0 +1,164 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	jujustorage "github.com/juju/juju/storage"
+	dummystorage "github.com/juju/juju/storage/provider/dummy"
+)
+
+type storageImportSuite struct {
+	baseStorageSuite
+
+	provider     *dummystorage.StorageProvider
+	volumeSource *dummystorage.VolumeSource
+}
+
+var _ = gc.Suite(&storageImportSuite{})
+
+func (s *storageImportSuite) SetUpTest(c *gc.C) {
+	s.baseStorageSuite.SetUpTest(c)
+	s.volumeSource = &dummystorage.VolumeSource{
+		DescribeVolumesFunc: func(volIds []string) ([]jujustorage.DescribeVolumesResult, error) {
+			return []jujustorage.DescribeVolumesResult{{
+				VolumeInfo: &jujustorage.VolumeInfo{
+					VolumeId:   volIds[0],
+					HardwareId: "hw-123",
+					Size:       1024,
+					Persistent: true,
+				},
+			}}, nil
+		},
+	}
+	s.provider = &dummystorage.StorageProvider{
+		StorageScope: jujustorage.ScopeEnviron,
+		IsDynamic:    true,
+		SupportsFunc: func(kind jujustorage.StorageKind) bool {
+			return kind == jujustorage.StorageKindBlock
+		},
+		VolumeSourceFunc: func(*jujustorage.Config) (jujustorage.VolumeSource, error) {
+			return s.volumeSource, nil
+		},
+	}
+	s.registry.Providers["radiance"] = s.provider
+	pool, err := jujustorage.NewConfig("radiance-pool", "radiance", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.pools["radiance-pool"] = pool
+}
+
+func (s *storageImportSuite) TestImport(c *gc.C) {
+	results, err := s.api.Import(params.BulkImportStorageParams{
+		Storage: []params.ImportStorageParams{{
+			Kind:        params.StorageKindFilesystem,
+			Pool:        "radiance-pool",
+			ProviderId:  "vol-123",
+			StorageName: "data",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ImportStorageResult{{
+		Result: &params.ImportStorageDetails{
+			StorageTag: "storage-data-0",
+		},
+	}})
+	s.volumeSource.CheckCall(c, 0, "DescribeVolumes", []string{"vol-123"})
+	s.stub.CheckCallNames(c, getBlockForTypeCall, addExistingFilesystemCall)
+	s.stub.CheckCall(c, 1, addExistingFilesystemCall, state.FilesystemInfo{
+		Pool: "radiance-pool",
+		Size: 1024,
+	}, &state.VolumeInfo{
+		Pool:       "radiance-pool",
+		VolumeId:   "vol-123",
+		HardwareId: "hw-123",
+		Size:       1024,
+		Persistent: true,
+	}, "data")
+}
+
+func (s *storageImportSuite) TestImportError(c *gc.C) {
+	s.stub.SetErrors(errors.New("nope"))
+	results, err := s.api.Import(params.BulkImportStorageParams{
+		Storage: []params.ImportStorageParams{{
+			Kind:        params.StorageKindFilesystem,
+			Pool:        "radiance-pool",
+			ProviderId:  "vol-123",
+			StorageName: "data",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ImportStorageResult{
+		{Error: &params.Error{Message: "nope"}},
+	})
+}
+
+func (s *storageImportSuite) TestImportDescribeVolumesError(c *gc.C) {
+	s.volumeSource.DescribeVolumesFunc = func([]string) ([]jujustorage.DescribeVolumesResult, error) {
+		return []jujustorage.DescribeVolumesResult{{Error: errors.NotFoundf("volume vol-123")}}, nil
+	}
+	results, err := s.api.Import(params.BulkImportStorageParams{
+		Storage: []params.ImportStorageParams{{
+			Kind:        params.StorageKindFilesystem,
+			Pool:        "radiance-pool",
+			ProviderId:  "vol-123",
+			StorageName: "data",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ImportStorageResult{
+		{Error: &params.Error{
+			Message: "describing volume: volume vol-123 not found",
+			Code:    params.CodeNotFound,
+		}},
+	})
+	s.stub.CheckCallNames(c, getBlockForTypeCall)
+}
+
+func (s *storageImportSuite) TestImportUnsupported(c *gc.C) {
+	s.provider.SupportsFunc = func(kind jujustorage.StorageKind) bool {
+		return true
+	}
+	results, err := s.api.Import(params.BulkImportStorageParams{
+		Storage: []params.ImportStorageParams{{
+			Kind:        params.StorageKindBlock,
+			Pool:        "radiance-pool",
+			ProviderId:  "vol-123",
+			StorageName: "data",
+		}, {
+			Kind:        params.StorageKindFilesystem,
+			Pool:        "radiance-pool",
+			ProviderId:  "fs-123",
+			StorageName: "data",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ImportStorageResult{
+		{Error: &params.Error{
+			Message: `storage kind "block" not supported`,
+			Code:    params.CodeNotSupported,
+		}},
+		{Error: &params.Error{
+			Message: `importing filesystem from "radiance" provider not supported`,
+			Code:    params.CodeNotSupported,
+		}},
+	})
+}
+
+func (s *storageImportSuite) TestImportBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestImportBlocked")
+	_, err := s.api.Import(params.BulkImportStorageParams{
+		Storage: []params.ImportStorageParams{{
+			Kind:        params.StorageKindFilesystem,
+			Pool:        "radiance-pool",
+			ProviderId:  "vol-123",
+			StorageName: "data",
+		}},
+	})
+	s.assertBlocked(c, err, "TestImportBlocked")
+}
@@ -56,6 +56,12 @@ type Backend interface {
 	WatchMachineVolumes(names.MachineTag) state.StringsWatcher
 	WatchMachineVolumeAttachments(names.MachineTag) state.StringsWatcher
 	WatchVolumeAttachment(names.MachineTag, names.VolumeTag) state.NotifyWatcher
+	WatchModelVolumeSnapshots() state.StringsWatcher
+	WatchMachineVolumeSnapshots(names.MachineTag) state.StringsWatcher
+	WatchModelVolumeResizes() state.StringsWatcher
+	WatchMachineVolumeResizes(names.MachineTag) state.StringsWatcher
+	WatchModelFilesystemResizes() state.StringsWatcher
+	WatchMachineFilesystemResizes(names.MachineTag) state.StringsWatcher
 
 	StorageInstance(names.StorageTag) (state.StorageInstance, error)
 
@@ -65,6 +71,7 @@ type Backend interface {
 	Volume(names.VolumeTag) (state.Volume, error)
 	VolumeAttachment(names.MachineTag, names.VolumeTag) (state.VolumeAttachment, error)
 	VolumeAttachments(names.VolumeTag) ([]state.VolumeAttachment, error)
+	VolumeSnapshot(string) (state.VolumeSnapshot, error)
 
 	RemoveFilesystem(names.FilesystemTag) error
 	RemoveFilesystemAttachment(names.MachineTag, names.FilesystemTag) error
@@ -75,6 +82,13 @@ type Backend interface {
 	SetFilesystemAttachmentInfo(names.MachineTag, names.FilesystemTag, state.FilesystemAttachmentInfo) error
 	SetVolumeInfo(names.VolumeTag, state.VolumeInfo) error
 	SetVolumeAttachmentInfo(names.MachineTag, names.VolumeTag, state.VolumeAttachmentInfo) error
+	SetVolumeSnapshotInfo(string, state.VolumeSnapshotInfo) error
+	SetVolumeSnapshotFailed(string, string) error
+	RemoveVolumeSnapshot(string) error
+	SetVolumeResized(names.VolumeTag, uint64) error
+	SetVolumeResizeFailed(names.VolumeTag) error
+	SetFilesystemResized(names.FilesystemTag, uint64) error
+	SetFilesystemResizeFailed(names.FilesystemTag) error
 }
 
 type stateShim struct {
@@ -93,7 +93,25 @@ func NewStorageProvisionerAPI(
 			if ok {
 				return canAccessStorageMachine(machineTag, false)
 			}
-			return authorizer.AuthController()
+			if authorizer.AuthController() {
+				return true
+			}
+			// Model-scoped, volume-backed filesystems (e.g. those
+			// imported into the model) are managed by the machine
+			// they are attached to.
+			authMachineTag, ok := authorizer.GetAuthTag().(names.MachineTag)
+			if !ok {
+				return false
+			}
+			if _, err := st.FilesystemAttachment(authMachineTag, tag); err != nil {
+				return false
+			}
+			f, err := st.Filesystem(tag)
+			if err != nil {
+				return false
+			}
+			_, err = f.Volume()
+			return err == nil
 		case names.MachineTag:
 			return allowMachines && canAccessStorageMachine(tag, true)
 		default:
@@ -260,6 +278,24 @@ func (s *StorageProvisionerAPI) WatchFilesystems(args params.Entities) (params.S
 	return s.watchStorageEntities(args, s.st.WatchModelFilesystems, s.st.WatchMachineFilesystems)
 }
 
+// WatchVolumeSnapshots watches for changes to volume snapshots scoped
+// to the entity with the tag passed to NewState.
+func (s *StorageProvisionerAPI) WatchVolumeSnapshots(args params.Entities) (params.StringsWatchResults, error) {
+	return s.watchStorageEntities(args, s.st.WatchModelVolumeSnapshots, s.st.WatchMachineVolumeSnapshots)
+}
+
+// WatchVolumeResizes watches for changes to the pending resizes of
+// volumes scoped to the entity with the tag passed to NewState.
+func (s *StorageProvisionerAPI) WatchVolumeResizes(args params.Entities) (params.StringsWatchResults, error) {
+	return s.watchStorageEntities(args, s.st.WatchModelVolumeResizes, s.st.WatchMachineVolumeResizes)
+}
+
+// WatchFilesystemResizes watches for changes to the pending resizes of
+// filesystems scoped to the entity with the tag passed to NewState.
+func (s *StorageProvisionerAPI) WatchFilesystemResizes(args params.Entities) (params.StringsWatchResults, error) {
+	return s.watchStorageEntities(args, s.st.WatchModelFilesystemResizes, s.st.WatchMachineFilesystemResizes)
+}
+
 func (s *StorageProvisionerAPI) watchStorageEntities(
 	args params.Entities,
 	watchEnvironStorage func() state.StringsWatcher,
@@ -607,6 +643,17 @@ func (s *StorageProvisionerAPI) VolumeParams(args params.Entities) (params.Volum
 				volumeAttachmentParams.ReadOnly,
 			}
 		}
+		if stateVolumeParams, ok := volume.Params(); ok && stateVolumeParams.SnapshotId != "" {
+			snapshot, err := s.st.VolumeSnapshot(stateVolumeParams.SnapshotId)
+			if err != nil {
+				return params.VolumeParams{}, err
+			}
+			snapshotInfo, err := snapshot.Info()
+			if err != nil {
+				return params.VolumeParams{}, err
+			}
+			volumeParams.SnapshotId = snapshotInfo.SnapshotId
+		}
 		return volumeParams, nil
 	}
 	for i, arg := range args.Entities {
@@ -956,6 +1003,329 @@ func (s *StorageProvisionerAPI) SetVolumeInfo(args params.Volumes) (params.Error
 	return results, nil
 }
 
+// VolumeSnapshotParams returns the parameters for taking the volume
+// snapshots with the specified IDs. The result for a snapshot that has
+// already been taken, or that has failed, is nil.
+func (s *StorageProvisionerAPI) VolumeSnapshotParams(args params.VolumeSnapshotIds) (params.VolumeSnapshotParamsResults, error) {
+	canAccess, err := s.getVolumeSnapshotAuthFunc()
+	if err != nil {
+		return params.VolumeSnapshotParamsResults{}, err
+	}
+	modelCfg, err := s.st.ModelConfig()
+	if err != nil {
+		return params.VolumeSnapshotParamsResults{}, err
+	}
+	controllerCfg, err := s.st.ControllerConfig()
+	if err != nil {
+		return params.VolumeSnapshotParamsResults{}, err
+	}
+	results := params.VolumeSnapshotParamsResults{
+		Results: make([]params.VolumeSnapshotParamsResult, len(args.Ids)),
+	}
+	one := func(id string) (*params.VolumeSnapshotParams, error) {
+		if !canAccess(id) {
+			return nil, common.ErrPerm
+		}
+		snapshot, err := s.st.VolumeSnapshot(id)
+		if errors.IsNotFound(err) {
+			return nil, common.ErrPerm
+		} else if err != nil {
+			return nil, err
+		}
+		if _, err := snapshot.Info(); err == nil || snapshot.FailureReason() != "" {
+			return nil, nil
+		}
+		volume, err := s.st.Volume(snapshot.Volume())
+		if err != nil {
+			return nil, err
+		}
+		storageInstance, err := storagecommon.MaybeAssignedStorageInstance(
+			volume.StorageInstance,
+			s.st.StorageInstance,
+		)
+		if err != nil {
+			return nil, err
+		}
+		snapshotParams, err := storagecommon.VolumeSnapshotParams(
+			snapshot, volume, storageInstance,
+			modelCfg.UUID(), controllerCfg.ControllerUUID(),
+			modelCfg, s.poolManager, s.registry,
+		)
+		if err != nil {
+			return nil, err
+		}
+		return &snapshotParams, nil
+	}
+	for i, id := range args.Ids {
+		snapshotParams, err := one(id)
+		results.Results[i].Result = snapshotParams
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// SetVolumeSnapshotResults records the outcome of taking volume
+// snapshots: either the provider's snapshot details, or the reason
+// that the snapshot could not be taken.
+func (s *StorageProvisionerAPI) SetVolumeSnapshotResults(args params.VolumeSnapshotResults) (params.ErrorResults, error) {
+	canAccess, err := s.getVolumeSnapshotAuthFunc()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Results)),
+	}
+	one := func(arg params.VolumeSnapshotResult) error {
+		if !canAccess(arg.Id) {
+			return common.ErrPerm
+		}
+		var err error
+		if arg.Error != nil {
+			err = s.st.SetVolumeSnapshotFailed(arg.Id, arg.Error.Message)
+		} else {
+			err = s.st.SetVolumeSnapshotInfo(arg.Id, state.VolumeSnapshotInfo{
+				SnapshotId: arg.SnapshotId,
+				Size:       arg.Size,
+			})
+		}
+		if errors.IsNotFound(err) {
+			return common.ErrPerm
+		}
+		return errors.Trace(err)
+	}
+	for i, arg := range args.Results {
+		err := one(arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// VolumeResizeParams returns the parameters for growing the volumes
+// with the specified tags. The result for a volume that has no resize
+// pending is nil.
+func (s *StorageProvisionerAPI) VolumeResizeParams(args params.Entities) (params.VolumeResizeParamsResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.VolumeResizeParamsResults{}, err
+	}
+	results := params.VolumeResizeParamsResults{
+		Results: make([]params.VolumeResizeParamsResult, len(args.Entities)),
+	}
+	one := func(arg params.Entity) (*params.VolumeResizeParams, error) {
+		tag, err := names.ParseVolumeTag(arg.Tag)
+		if err != nil || !canAccess(tag) {
+			return nil, common.ErrPerm
+		}
+		volume, err := s.st.Volume(tag)
+		if errors.IsNotFound(err) {
+			return nil, common.ErrPerm
+		} else if err != nil {
+			return nil, err
+		}
+		size, ok := volume.PendingSize()
+		if !ok {
+			return nil, nil
+		}
+		resizeParams, err := storagecommon.VolumeResizeParams(
+			volume, size, s.poolManager, s.registry,
+		)
+		if err != nil {
+			return nil, err
+		}
+		return &resizeParams, nil
+	}
+	for i, arg := range args.Entities {
+		resizeParams, err := one(arg)
+		results.Results[i].Result = resizeParams
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// SetVolumeResizeResults records the outcome of growing volumes: either
+// the new size of the volume, or the reason that it could not be grown.
+func (s *StorageProvisionerAPI) SetVolumeResizeResults(args params.VolumeResizeResults) (params.ErrorResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Results)),
+	}
+	one := func(arg params.VolumeResizeResult) error {
+		tag, err := names.ParseVolumeTag(arg.VolumeTag)
+		if err != nil || !canAccess(tag) {
+			return common.ErrPerm
+		}
+		if arg.Error != nil {
+			err = s.st.SetVolumeResizeFailed(tag)
+		} else {
+			err = s.st.SetVolumeResized(tag, arg.Size)
+		}
+		if errors.IsNotFound(err) {
+			return common.ErrPerm
+		}
+		return errors.Trace(err)
+	}
+	for i, arg := range args.Results {
+		err := one(arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// FilesystemResizeParams returns the parameters for growing the
+// filesystems with the specified tags. The result for a filesystem
+// that has no resize pending is nil.
+func (s *StorageProvisionerAPI) FilesystemResizeParams(args params.Entities) (params.FilesystemResizeParamsResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.FilesystemResizeParamsResults{}, err
+	}
+	results := params.FilesystemResizeParamsResults{
+		Results: make([]params.FilesystemResizeParamsResult, len(args.Entities)),
+	}
+	one := func(arg params.Entity) (*params.FilesystemResizeParams, error) {
+		tag, err := names.ParseFilesystemTag(arg.Tag)
+		if err != nil || !canAccess(tag) {
+			return nil, common.ErrPerm
+		}
+		filesystem, err := s.st.Filesystem(tag)
+		if errors.IsNotFound(err) {
+			return nil, common.ErrPerm
+		} else if err != nil {
+			return nil, err
+		}
+		size, ok := filesystem.PendingSize()
+		if !ok {
+			return nil, nil
+		}
+		resizeParams, err := storagecommon.FilesystemResizeParams(
+			filesystem, size, s.poolManager, s.registry,
+		)
+		if err != nil {
+			return nil, err
+		}
+		return &resizeParams, nil
+	}
+	for i, arg := range args.Entities {
+		resizeParams, err := one(arg)
+		results.Results[i].Result = resizeParams
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// SetFilesystemResizeResults records the outcome of growing filesystems:
+// either the new size of the filesystem, or the reason that it could not
+// be grown.
+func (s *StorageProvisionerAPI) SetFilesystemResizeResults(args params.FilesystemResizeResults) (params.ErrorResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Results)),
+	}
+	one := func(arg params.FilesystemResizeResult) error {
+		tag, err := names.ParseFilesystemTag(arg.FilesystemTag)
+		if err != nil || !canAccess(tag) {
+			return common.ErrPerm
+		}
+		if arg.Error != nil {
+			err = s.st.SetFilesystemResizeFailed(tag)
+		} else {
+			err = s.st.SetFilesystemResized(tag, arg.Size)
+		}
+		if errors.IsNotFound(err) {
+			return common.ErrPerm
+		}
+		return errors.Trace(err)
+	}
+	for i, arg := range args.Results {
+		err := one(arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// VolumeSnapshotDestroyParams returns the parameters for destroying
+// the volume snapshots with the specified IDs. The result for a
+// snapshot that is not Dead is nil.
+func (s *StorageProvisionerAPI) VolumeSnapshotDestroyParams(args params.VolumeSnapshotIds) (params.VolumeSnapshotDestroyParamsResults, error) {
+	canAccess, err := s.getVolumeSnapshotAuthFunc()
+	if err != nil {
+		return params.VolumeSnapshotDestroyParamsResults{}, err
+	}
+	results := params.VolumeSnapshotDestroyParamsResults{
+		Results: make([]params.VolumeSnapshotDestroyParamsResult, len(args.Ids)),
+	}
+	one := func(id string) (*params.VolumeSnapshotDestroyParams, error) {
+		if !canAccess(id) {
+			return nil, common.ErrPerm
+		}
+		snapshot, err := s.st.VolumeSnapshot(id)
+		if errors.IsNotFound(err) {
+			return nil, common.ErrPerm
+		} else if err != nil {
+			return nil, err
+		}
+		if snapshot.Life() != state.Dead {
+			return nil, nil
+		}
+		destroyParams, err := storagecommon.VolumeSnapshotDestroyParams(
+			snapshot, s.poolManager, s.registry,
+		)
+		if err != nil {
+			return nil, err
+		}
+		return &destroyParams, nil
+	}
+	for i, id := range args.Ids {
+		destroyParams, err := one(id)
+		results.Results[i].Result = destroyParams
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// RemoveVolumeSnapshots removes the Dead volume snapshots with the
+// specified IDs from state, once they have been destroyed.
+func (s *StorageProvisionerAPI) RemoveVolumeSnapshots(args params.VolumeSnapshotIds) (params.ErrorResults, error) {
+	canAccess, err := s.getVolumeSnapshotAuthFunc()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Ids)),
+	}
+	one := func(id string) error {
+		if !canAccess(id) {
+			return common.ErrPerm
+		}
+		return errors.Trace(s.st.RemoveVolumeSnapshot(id))
+	}
+	for i, id := range args.Ids {
+		err := one(id)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// getVolumeSnapshotAuthFunc returns a function that reports whether the
+// authenticated agent may access the volume snapshot with the given ID.
+// Volume snapshot IDs share the format, and the machine scoping, of
+// volume IDs, so access is determined as for volumes.
+func (s *StorageProvisionerAPI) getVolumeSnapshotAuthFunc() (func(string) bool, error) {
+	canAccessVolume, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return nil, err
+	}
+	return func(id string) bool {
+		return names.IsValidVolume(id) && canAccessVolume(names.NewVolumeTag(id))
+	}, nil
+}
+
 // SetFilesystemInfo records the details of newly provisioned filesystems.
 func (s *StorageProvisionerAPI) SetFilesystemInfo(args params.Filesystems) (params.ErrorResults, error) {
 	canAccessFilesystem, err := s.getStorageEntityAuthFunc()
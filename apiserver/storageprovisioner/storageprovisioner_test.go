@@ -254,6 +254,64 @@ func (s *provisionerSuite) TestFilesystems(c *gc.C) {
 	})
 }
 
+func (s *provisionerSuite) TestFilesystemsImportedMachine(c *gc.C) {
+	machine := s.factory.MakeMachine(c, &factory.MachineParams{
+		InstanceId: instance.Id("inst-id"),
+	})
+	storageTag, err := s.State.AddExistingFilesystem(state.FilesystemInfo{
+		Pool: "modelscoped-block",
+	}, &state.VolumeInfo{
+		VolumeId: "vol-123",
+		Size:     1024,
+	}, "data")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddExistingFilesystem(state.FilesystemInfo{
+		Pool:         "modelscoped",
+		Size:         2048,
+		FilesystemId: "fs-456",
+	}, nil, "data")
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Attach the imported, volume-backed filesystem to the machine.
+	app, err := s.State.AddApplication(state.AddApplicationArgs{
+		Name:          "storage-filesystem",
+		Charm:         s.AddTestingCharm(c, "storage-filesystem"),
+		NumUnits:      1,
+		AttachStorage: []names.StorageTag{storageTag},
+		Storage: map[string]state.StorageConstraints{
+			"data": {Pool: "modelscoped-block", Size: 1024, Count: 1},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	units, err := app.AllUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	err = units[0].AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Machine agents may access model-scoped, volume-backed
+	// filesystems attached to their machine.
+	s.authorizer.Tag = machine.Tag()
+	s.authorizer.Controller = false
+	results, err := s.api.Filesystems(params.Entities{
+		Entities: []params.Entity{{"filesystem-0"}, {"filesystem-1"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.FilesystemResults{
+		Results: []params.FilesystemResult{
+			{Result: params.Filesystem{
+				FilesystemTag: "filesystem-0",
+				VolumeTag:     "volume-0",
+				Info: params.FilesystemInfo{
+					FilesystemId: "filesystem-0",
+					Size:         1024,
+					Pool:         "modelscoped-block",
+				},
+			}},
+			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+		},
+	})
+}
+
 func (s *provisionerSuite) TestVolumeAttachments(c *gc.C) {
 	s.setupVolumes(c)
 	s.authorizer.Controller = false
@@ -412,6 +470,37 @@ func (s *provisionerSuite) TestVolumeParamsEmptyArgs(c *gc.C) {
 	c.Assert(results.Results, gc.HasLen, 0)
 }
 
+func (s *provisionerSuite) TestVolumeParamsFromSnapshot(c *gc.C) {
+	s.setupVolumes(c)
+	snapshot, err := s.State.AddVolumeSnapshot(names.NewVolumeTag("2"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetVolumeSnapshotInfo(snapshot.Id(), state.VolumeSnapshotInfo{
+		SnapshotId: "snap-123",
+		Size:       4096,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddOneMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+		Volumes: []state.MachineVolumeParams{{
+			Volume: state.VolumeParams{
+				Pool:       "modelscoped",
+				Size:       4096,
+				SnapshotId: snapshot.Id(),
+			},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.api.VolumeParams(params.Entities{
+		Entities: []params.Entity{{"volume-5"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Result.SnapshotId, gc.Equals, "snap-123")
+}
+
 func (s *provisionerSuite) TestFilesystemParams(c *gc.C) {
 	s.setupFilesystems(c)
 	results, err := s.api.FilesystemParams(params.Entities{
@@ -714,6 +803,287 @@ func (s *provisionerSuite) TestWatchVolumes(c *gc.C) {
 	wc.AssertNoChange()
 }
 
+func (s *provisionerSuite) TestWatchVolumeSnapshots(c *gc.C) {
+	s.setupVolumes(c)
+	_, err := s.State.AddVolumeSnapshot(names.NewVolumeTag("0/0"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddVolumeSnapshot(names.NewVolumeTag("2"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	args := params.Entities{Entities: []params.Entity{
+		{"machine-0"},
+		{s.State.ModelTag().String()},
+		{"machine-42"}},
+	}
+	result, err := s.api.WatchVolumeSnapshots(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StringsWatchResults{
+		Results: []params.StringsWatchResult{
+			{StringsWatcherId: "1", Changes: []string{"0/0"}},
+			{StringsWatcherId: "2", Changes: []string{"1"}},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+	c.Assert(s.resources.Count(), gc.Equals, 2)
+	defer statetesting.AssertStop(c, s.resources.Get("1"))
+	defer statetesting.AssertStop(c, s.resources.Get("2"))
+}
+
+func (s *provisionerSuite) TestVolumeSnapshotParams(c *gc.C) {
+	s.setupVolumes(c)
+	_, err := s.State.AddVolumeSnapshot(names.NewVolumeTag("0/0"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddVolumeSnapshot(names.NewVolumeTag("2"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddVolumeSnapshot(names.NewVolumeTag("2"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetVolumeSnapshotInfo("2", state.VolumeSnapshotInfo{SnapshotId: "snap-2"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.api.VolumeSnapshotParams(params.VolumeSnapshotIds{
+		Ids: []string{"0/0", "1", "2", "42", "invalid"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	storageTags := map[string]string{
+		tags.JujuController: testing.ControllerTag.Id(),
+		tags.JujuModel:      testing.ModelTag.Id(),
+	}
+	c.Assert(results, jc.DeepEquals, params.VolumeSnapshotParamsResults{
+		Results: []params.VolumeSnapshotParamsResult{
+			{Result: &params.VolumeSnapshotParams{
+				Id:        "0/0",
+				VolumeTag: "volume-0-0",
+				VolumeId:  "abc",
+				Provider:  "machinescoped",
+				Tags:      storageTags,
+			}},
+			{Result: &params.VolumeSnapshotParams{
+				Id:        "1",
+				VolumeTag: "volume-2",
+				VolumeId:  "def",
+				Provider:  "modelscoped",
+				Tags:      storageTags,
+			}},
+			{}, // already taken
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
+func (s *provisionerSuite) TestSetVolumeSnapshotResults(c *gc.C) {
+	s.setupVolumes(c)
+	_, err := s.State.AddVolumeSnapshot(names.NewVolumeTag("0/0"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddVolumeSnapshot(names.NewVolumeTag("2"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.api.SetVolumeSnapshotResults(params.VolumeSnapshotResults{
+		Results: []params.VolumeSnapshotResult{
+			{Id: "0/0", SnapshotId: "snap-0", Size: 1024},
+			{Id: "1", Error: &params.Error{Message: "out of quota"}},
+			{Id: "42", SnapshotId: "snap-42"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	snapshot, err := s.State.VolumeSnapshot("0/0")
+	c.Assert(err, jc.ErrorIsNil)
+	info, err := snapshot.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, state.VolumeSnapshotInfo{SnapshotId: "snap-0", Size: 1024})
+	snapshot, err = s.State.VolumeSnapshot("1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshot.FailureReason(), gc.Equals, "out of quota")
+}
+
+func (s *provisionerSuite) TestVolumeSnapshotDestroyParams(c *gc.C) {
+	s.setupVolumes(c)
+	_, err := s.State.AddVolumeSnapshot(names.NewVolumeTag("0/0"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddVolumeSnapshot(names.NewVolumeTag("2"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetVolumeSnapshotInfo("0/0", state.VolumeSnapshotInfo{SnapshotId: "snap-0"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.DestroyVolumeSnapshot("0/0")
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.api.VolumeSnapshotDestroyParams(params.VolumeSnapshotIds{
+		Ids: []string{"0/0", "1", "42", "invalid"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.VolumeSnapshotDestroyParamsResults{
+		Results: []params.VolumeSnapshotDestroyParamsResult{
+			{Result: &params.VolumeSnapshotDestroyParams{
+				Id:         "0/0",
+				SnapshotId: "snap-0",
+				Provider:   "machinescoped",
+			}},
+			{}, // not dead
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
+func (s *provisionerSuite) TestRemoveVolumeSnapshots(c *gc.C) {
+	s.setupVolumes(c)
+	_, err := s.State.AddVolumeSnapshot(names.NewVolumeTag("0/0"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddVolumeSnapshot(names.NewVolumeTag("2"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetVolumeSnapshotInfo("0/0", state.VolumeSnapshotInfo{SnapshotId: "snap-0"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.DestroyVolumeSnapshot("0/0")
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.api.RemoveVolumeSnapshots(params.VolumeSnapshotIds{
+		Ids: []string{"0/0", "1", "42", "invalid"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: `cannot remove volume snapshot "1": volume snapshot is not dead`}},
+			{}, // already removed
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+	_, err = s.State.VolumeSnapshot("0/0")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+// setupPendingResize adds a unit with provisioned storage of the given
+// kind from the "modelscoped" pool, and requests that it be resized.
+func (s *provisionerSuite) setupPendingResize(c *gc.C, kind string) names.StorageTag {
+	ch := s.AddTestingCharm(c, "storage-"+kind)
+	sCons := map[string]state.StorageConstraints{
+		"data": {Pool: "modelscoped", Size: 1024, Count: 1},
+	}
+	app := s.AddTestingServiceWithStorage(c, "storage-"+kind, ch, sCons)
+	unit, err := app.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AssignUnit(unit, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	storageTag := names.NewStorageTag("data/0")
+	if kind == "block" {
+		err = s.State.SetVolumeInfo(names.NewVolumeTag("0"), state.VolumeInfo{
+			VolumeId: "vol-0",
+			Size:     1024,
+		})
+	} else {
+		err = s.State.SetFilesystemInfo(names.NewFilesystemTag("0"), state.FilesystemInfo{
+			FilesystemId: "fs-0",
+			Size:         1024,
+		})
+	}
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.ResizeStorageInstance(storageTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	return storageTag
+}
+
+func (s *provisionerSuite) TestVolumeResizeParams(c *gc.C) {
+	s.setupPendingResize(c, "block")
+	results, err := s.api.VolumeResizeParams(params.Entities{
+		Entities: []params.Entity{{"volume-0"}, {"volume-42"}, {"invalid"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.VolumeResizeParamsResults{
+		Results: []params.VolumeResizeParamsResult{
+			{Result: &params.VolumeResizeParams{
+				VolumeTag: "volume-0",
+				VolumeId:  "vol-0",
+				Size:      2048,
+				Provider:  "modelscoped",
+			}},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	err = s.State.SetVolumeResizeFailed(names.NewVolumeTag("0"))
+	c.Assert(err, jc.ErrorIsNil)
+	results, err = s.api.VolumeResizeParams(params.Entities{
+		Entities: []params.Entity{{"volume-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.VolumeResizeParamsResult{{}})
+}
+
+func (s *provisionerSuite) TestSetVolumeResizeResults(c *gc.C) {
+	s.setupPendingResize(c, "block")
+	results, err := s.api.SetVolumeResizeResults(params.VolumeResizeResults{
+		Results: []params.VolumeResizeResult{
+			{VolumeTag: "volume-0", Size: 2048},
+			{VolumeTag: "volume-42", Size: 2048},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	volume, err := s.State.Volume(names.NewVolumeTag("0"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok := volume.PendingSize()
+	c.Assert(ok, jc.IsFalse)
+	info, err := volume.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.Size, gc.Equals, uint64(2048))
+}
+
+func (s *provisionerSuite) TestFilesystemResizeParams(c *gc.C) {
+	s.setupPendingResize(c, "filesystem")
+	results, err := s.api.FilesystemResizeParams(params.Entities{
+		Entities: []params.Entity{{"filesystem-0"}, {"filesystem-42"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.FilesystemResizeParamsResults{
+		Results: []params.FilesystemResizeParamsResult{
+			{Result: &params.FilesystemResizeParams{
+				FilesystemTag: "filesystem-0",
+				FilesystemId:  "fs-0",
+				Size:          2048,
+				Provider:      "modelscoped",
+			}},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
+func (s *provisionerSuite) TestSetFilesystemResizeResults(c *gc.C) {
+	s.setupPendingResize(c, "filesystem")
+	results, err := s.api.SetFilesystemResizeResults(params.FilesystemResizeResults{
+		Results: []params.FilesystemResizeResult{
+			{FilesystemTag: "filesystem-0", Error: &params.Error{Message: "nope"}},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{}},
+	})
+
+	filesystem, err := s.State.Filesystem(names.NewFilesystemTag("0"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok := filesystem.PendingSize()
+	c.Assert(ok, jc.IsFalse)
+	info, err := filesystem.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.Size, gc.Equals, uint64(1024))
+}
+
 func (s *provisionerSuite) TestWatchVolumeAttachments(c *gc.C) {
 	s.setupVolumes(c)
 	s.factory.MakeMachine(c, nil)
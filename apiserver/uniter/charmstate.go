@@ -0,0 +1,86 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// CharmState returns the key/value state stored by the charm of each
+// given unit.
+func (u *UniterAPI) CharmState(args params.Entities) (params.CharmStateResults, error) {
+	result := params.CharmStateResults{
+		Results: make([]params.CharmStateResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.CharmStateResults{}, err
+	}
+	for i, entity := range args.Entities {
+		resultItem := &result.Results[i]
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			resultItem.Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		if !canAccess(tag) {
+			resultItem.Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		unit, err := u.getUnit(tag)
+		if err != nil {
+			resultItem.Error = common.ServerError(err)
+			continue
+		}
+		charmState, err := unit.CharmState()
+		if err != nil {
+			resultItem.Error = common.ServerError(err)
+			continue
+		}
+		resultItem.State = charmState
+	}
+	return result, nil
+}
+
+// SetCharmState replaces the key/value state stored by the charm of
+// each given unit. The size of each unit's state is limited by the
+// controller's max-charm-state-size setting.
+func (u *UniterAPI) SetCharmState(args params.SetCharmStateArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	controllerConfig, err := u.st.ControllerConfig()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	maxSize := controllerConfig.MaxCharmStateSize()
+	for i, arg := range args.Args {
+		resultItem := &result.Results[i]
+		tag, err := names.ParseUnitTag(arg.Tag)
+		if err != nil {
+			resultItem.Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		if !canAccess(tag) {
+			resultItem.Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		unit, err := u.getUnit(tag)
+		if err != nil {
+			resultItem.Error = common.ServerError(err)
+			continue
+		}
+		if err := unit.SetCharmState(arg.State, maxSize); err != nil {
+			resultItem.Error = common.ServerError(err)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,63 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/common/cloudspec"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/state/stateenvirons"
+	jujuversion "github.com/juju/juju/version"
+)
+
+// CloudSpec returns the cloud spec, including the cloud credential, of
+// the model in which the authenticated unit resides. Only units of
+// applications that have been trusted by a model administrator may
+// obtain it.
+func (u *UniterAPI) CloudSpec() (params.CloudSpecResult, error) {
+	app, err := u.st.Application(u.unit.ApplicationName())
+	if err != nil {
+		return params.CloudSpecResult{}, err
+	}
+	if !app.IsTrusted() {
+		return params.CloudSpecResult{Error: common.ServerError(common.ErrPerm)}, nil
+	}
+	if err := u.auditCredentialAccess(app.Name()); err != nil {
+		return params.CloudSpecResult{}, errors.Trace(err)
+	}
+	modelTag := u.st.ModelTag()
+	environConfigGetter := stateenvirons.EnvironConfigGetter{u.st}
+	api := cloudspec.NewCloudSpec(environConfigGetter.CloudSpec, common.AuthFuncForTag(modelTag))
+	logger.Infof("unit %q of trusted application %q obtained the cloud spec of model %q",
+		u.unit.Name(), app.Name(), modelTag.Id())
+	return api.GetCloudSpec(modelTag), nil
+}
+
+// auditCredentialAccess records, when auditing is enabled, that the
+// authenticated unit is obtaining the model's cloud credential. Agent
+// requests are not audited by default, so this is done explicitly.
+// The credential is withheld if it cannot be recorded.
+func (u *UniterAPI) auditCredentialAccess(appName string) error {
+	if u.auditEntrySink == nil {
+		return nil
+	}
+	remoteAddress := "unknown"
+	if address, err := u.unit.PrivateAddress(); err == nil {
+		remoteAddress = address.Value
+	}
+	entry := audit.AuditEntry{
+		JujuServerVersion: jujuversion.Current,
+		ModelUUID:         u.st.ModelUUID(),
+		Timestamp:         u.st.NowToTheSecond(),
+		RemoteAddress:     remoteAddress,
+		OriginType:        audit.UnitOriginType,
+		OriginName:        u.unit.Tag().String(),
+		Operation:         "Uniter - CloudSpec",
+		Data:              map[string]interface{}{"application": appName},
+	}
+	return errors.Annotate(u.auditEntrySink(entry), "cannot record cloud credential access")
+}
@@ -0,0 +1,109 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade/facadetest"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/apiserver/uniter"
+	"github.com/juju/juju/audit"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
+	jujuFactory "github.com/juju/juju/testing/factory"
+)
+
+type cloudSpecAuditSuite struct {
+	jujutesting.JujuConnSuite
+
+	application *state.Application
+	unit        *state.Unit
+	entries     []audit.AuditEntry
+	sinkErr     error
+	uniter      *uniter.UniterAPI
+}
+
+var _ = gc.Suite(&cloudSpecAuditSuite{})
+
+func (s *cloudSpecAuditSuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+
+	s.application = s.Factory.MakeApplication(c, &jujuFactory.ApplicationParams{
+		Name: "wordpress",
+	})
+	s.unit = s.Factory.MakeUnit(c, &jujuFactory.UnitParams{
+		Application: s.application,
+	})
+	s.entries = nil
+	s.sinkErr = nil
+	s.uniter = s.newUniterAPI(c, func(entry audit.AuditEntry) error {
+		s.entries = append(s.entries, entry)
+		return s.sinkErr
+	})
+}
+
+// newUniterAPI creates the Uniter API as the API server does, with
+// the given audit entry sink.
+func (s *cloudSpecAuditSuite) newUniterAPI(c *gc.C, sink audit.AuditEntrySinkFn) *uniter.UniterAPI {
+	resources := common.NewResources()
+	s.AddCleanup(func(_ *gc.C) { resources.StopAll() })
+	factory, err := common.Facades.GetFactory("Uniter", 13)
+	c.Assert(err, jc.ErrorIsNil)
+	api, err := factory(facadetest.Context{
+		Auth_:           apiservertesting.FakeAuthorizer{Tag: s.unit.Tag()},
+		Resources_:      resources,
+		State_:          s.State,
+		AuditEntrySink_: sink,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return api.(*uniter.UniterAPI)
+}
+
+func (s *cloudSpecAuditSuite) TestCloudSpecRecordsAccess(c *gc.C) {
+	result, err := s.uniter.CloudSpec()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.NotNil)
+	c.Assert(s.entries, gc.HasLen, 0)
+
+	err = s.application.SetTrusted()
+	c.Assert(err, jc.ErrorIsNil)
+	result, err = s.uniter.CloudSpec()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+
+	c.Assert(s.entries, gc.HasLen, 1)
+	c.Check(s.entries[0].Validate(), jc.ErrorIsNil)
+	c.Check(s.entries[0].ModelUUID, gc.Equals, s.State.ModelUUID())
+	// The timestamp comes from the state clock, to the second.
+	c.Check(s.entries[0].Timestamp.Nanosecond(), gc.Equals, 0)
+	c.Check(s.entries[0].OriginType, gc.Equals, audit.UnitOriginType)
+	c.Check(s.entries[0].OriginName, gc.Equals, s.unit.Tag().String())
+	c.Check(s.entries[0].Operation, gc.Equals, "Uniter - CloudSpec")
+	c.Check(s.entries[0].Data, jc.DeepEquals, map[string]interface{}{"application": "wordpress"})
+}
+
+func (s *cloudSpecAuditSuite) TestCloudSpecWithheldIfNotRecorded(c *gc.C) {
+	err := s.application.SetTrusted()
+	c.Assert(err, jc.ErrorIsNil)
+	s.sinkErr = errors.New("audit buffer full")
+
+	result, err := s.uniter.CloudSpec()
+	c.Assert(err, gc.ErrorMatches, "cannot record cloud credential access: audit buffer full")
+	c.Assert(result.Result, gc.IsNil)
+}
+
+func (s *cloudSpecAuditSuite) TestCloudSpecAuditingDisabled(c *gc.C) {
+	err := s.application.SetTrusted()
+	c.Assert(err, jc.ErrorIsNil)
+	api := s.newUniterAPI(c, nil)
+
+	result, err := api.CloudSpec()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Result, gc.NotNil)
+}
@@ -0,0 +1,55 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// SetCharmEgressRules replaces the egress rules set by the charm of
+// each given unit's application. Only the leader unit of an application
+// may set them.
+func (u *UniterAPI) SetCharmEgressRules(args params.EntitiesEgressRules) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		err = common.ErrPerm
+		if canAccess(tag) {
+			err = u.setCharmEgressRules(tag, entity.Rules)
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (u *UniterAPI) setCharmEgressRules(tag names.UnitTag, rules []params.EgressRule) error {
+	unit, err := u.getUnit(tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	appName := unit.ApplicationName()
+	token := u.st.LeadershipChecker().LeadershipCheck(appName, unit.Name())
+	if err := token.Check(nil); err != nil {
+		return common.ErrPerm
+	}
+	app, err := u.st.Application(appName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return app.SetCharmEgressRules(params.NetworkEgressRules(rules))
+}
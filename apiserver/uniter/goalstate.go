@@ -0,0 +1,146 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// relationJoined is the goal state status of a related application
+// whose relation is alive.
+const relationJoined = "joined"
+
+// GoalStates returns, for each given unit, the units expected for its
+// application and for every application related to it.
+func (u *UniterAPI) GoalStates(args params.Entities) (params.GoalStateResults, error) {
+	result := params.GoalStateResults{
+		Results: make([]params.GoalStateResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.GoalStateResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		if !canAccess(tag) {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		unit, err := u.getUnit(tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		goalState, err := u.oneGoalState(unit)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Result = goalState
+	}
+	return result, nil
+}
+
+// oneGoalState builds the goal state of the given unit.
+func (u *UniterAPI) oneGoalState(unit *state.Unit) (*params.GoalState, error) {
+	app, err := unit.Application()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	units, err := goalStateUnits(app)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	relations, err := u.goalStateRelations(app)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &params.GoalState{
+		Units:     units,
+		Relations: relations,
+	}, nil
+}
+
+// goalStateRelations returns, keyed by the application's endpoint
+// name, the related applications and their units.
+func (u *UniterAPI) goalStateRelations(app *state.Application) (map[string]params.UnitsGoalState, error) {
+	relations, err := app.Relations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make(map[string]params.UnitsGoalState)
+	for _, rel := range relations {
+		ep, err := rel.Endpoint(app.Name())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		related, err := rel.RelatedEndpoints(app.Name())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		entries, ok := result[ep.Name]
+		if !ok {
+			entries = make(params.UnitsGoalState)
+			result[ep.Name] = entries
+		}
+		status := relationJoined
+		if rel.Life() != state.Alive {
+			status = rel.Life().String()
+		}
+		for _, other := range related {
+			entries[other.ApplicationName] = params.GoalStateStatus{Status: status}
+			otherApp, err := u.st.Application(other.ApplicationName)
+			if errors.IsNotFound(err) {
+				// Applications in other models have no units
+				// visible to this one.
+				continue
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+			units, err := goalStateUnits(otherApp)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			for name, unitStatus := range units {
+				entries[name] = unitStatus
+			}
+		}
+	}
+	return result, nil
+}
+
+// goalStateUnits returns the goal state status of every unit of the
+// given application. Alive units report their workload status; units
+// on their way out report their life.
+func goalStateUnits(app *state.Application) (params.UnitsGoalState, error) {
+	units, err := app.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make(params.UnitsGoalState)
+	for _, unit := range units {
+		if unit.Life() != state.Alive {
+			result[unit.Name()] = params.GoalStateStatus{Status: unit.Life().String()}
+			continue
+		}
+		info, err := unit.Status()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result[unit.Name()] = params.GoalStateStatus{
+			Status: info.Status.String(),
+			Since:  info.Since,
+		}
+	}
+	return result, nil
+}
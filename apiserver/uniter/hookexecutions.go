@@ -0,0 +1,66 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// RecordHookExecutions adds the given hook and action executions to
+// the bounded hook history of each unit.
+func (u *UniterAPI) RecordHookExecutions(args params.RecordHookExecutionArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, arg := range args.Args {
+		resultItem := &result.Results[i]
+		tag, err := names.ParseUnitTag(arg.Tag)
+		if err != nil {
+			resultItem.Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		if !canAccess(tag) {
+			resultItem.Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		unit, err := u.getUnit(tag)
+		if err != nil {
+			resultItem.Error = common.ServerError(err)
+			continue
+		}
+		if err := unit.RecordHookExecution(fromParamsHookExecution(arg.Execution)); err != nil {
+			resultItem.Error = common.ServerError(err)
+		}
+	}
+	return result, nil
+}
+
+func fromParamsHookExecution(in params.HookExecution) state.HookExecution {
+	out := state.HookExecution{
+		Kind:             in.Kind,
+		Name:             in.Name,
+		Started:          in.Started,
+		Finished:         in.Finished,
+		ExitCode:         in.ExitCode,
+		Error:            in.Error,
+		DroppedToolCalls: in.DroppedToolCalls,
+	}
+	for _, call := range in.ToolCalls {
+		out.ToolCalls = append(out.ToolCalls, state.HookToolCall{
+			Name:     call.Name,
+			Started:  call.Started,
+			Duration: call.Duration,
+			ExitCode: call.ExitCode,
+		})
+	}
+	return out
+}
@@ -0,0 +1,68 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state/watcher"
+)
+
+// SecretValues returns the values of the named secrets. The
+// authenticated unit's application must have been granted access to
+// each secret.
+func (u *UniterAPI) SecretValues(args params.SecretNames) (params.SecretValuesResults, error) {
+	result := params.SecretValuesResults{
+		Results: make([]params.SecretValuesResult, len(args.Names)),
+	}
+	application := u.unit.ApplicationName()
+	for i, name := range args.Names {
+		secret, err := u.st.Secret(name)
+		if err == nil && !secret.IsGranted(application) {
+			err = common.ErrPerm
+		}
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		values, err := secret.Values()
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Revision = secret.Revision()
+		result.Results[i].Values = values
+	}
+	return result, nil
+}
+
+// SecretRevisions returns the current revisions of the secrets granted
+// to the authenticated unit's application.
+func (u *UniterAPI) SecretRevisions() (params.SecretRevisionsResult, error) {
+	secrets, err := u.st.SecretsGrantedTo(u.unit.ApplicationName())
+	if err != nil {
+		return params.SecretRevisionsResult{Error: common.ServerError(err)}, nil
+	}
+	revisions := make(map[string]int, len(secrets))
+	for _, secret := range secrets {
+		revisions[secret.Name()] = secret.Revision()
+	}
+	return params.SecretRevisionsResult{Revisions: revisions}, nil
+}
+
+// WatchSecrets returns a NotifyWatcher that fires when the secrets in
+// the model change. Clients should call SecretRevisions to find out
+// which secrets granted to the unit's application have changed.
+func (u *UniterAPI) WatchSecrets() (params.NotifyWatchResult, error) {
+	watch := u.st.WatchSecrets()
+	// Consume the initial event, as for the other notify watchers.
+	if _, ok := <-watch.Changes(); ok {
+		return params.NotifyWatchResult{
+			NotifyWatcherId: u.resources.Register(watch),
+		}, nil
+	}
+	return params.NotifyWatchResult{
+		Error: common.ServerError(watcher.EnsureErr(watch)),
+	}, nil
+}
@@ -161,6 +161,10 @@ func (s *storageSuite) TestWatchStorageAttachmentFilesystem(c *gc.C) {
 		changes: make(chan struct{}, 1),
 	}
 	storageWatcher.changes <- struct{}{}
+	filesystemAttachmentWatcher := &mockNotifyWatcher{
+		changes: make(chan struct{}, 1),
+	}
+	filesystemAttachmentWatcher.changes <- struct{}{}
 	filesystemWatcher := &mockNotifyWatcher{
 		changes: make(chan struct{}, 1),
 	}
@@ -192,6 +196,11 @@ func (s *storageSuite) TestWatchStorageAttachmentFilesystem(c *gc.C) {
 			calls = append(calls, "WatchFilesystemAttachment")
 			c.Assert(m, gc.DeepEquals, machineTag)
 			c.Assert(f, gc.DeepEquals, filesystemTag)
+			return filesystemAttachmentWatcher
+		},
+		watchFilesystem: func(f names.FilesystemTag) state.NotifyWatcher {
+			calls = append(calls, "WatchFilesystem")
+			c.Assert(f, gc.DeepEquals, filesystemTag)
 			return filesystemWatcher
 		},
 	}
@@ -215,6 +224,7 @@ func (s *storageSuite) TestWatchStorageAttachmentFilesystem(c *gc.C) {
 		"StorageInstance",
 		"StorageInstanceFilesystem",
 		"WatchFilesystemAttachment",
+		"WatchFilesystem",
 		"WatchStorageAttachment",
 	})
 }
@@ -484,6 +494,7 @@ type mockStorageState struct {
 	watchStorageAttachments       func(names.UnitTag) state.StringsWatcher
 	watchStorageAttachment        func(names.StorageTag, names.UnitTag) state.NotifyWatcher
 	watchFilesystemAttachment     func(names.MachineTag, names.FilesystemTag) state.NotifyWatcher
+	watchFilesystem               func(names.FilesystemTag) state.NotifyWatcher
 	watchVolumeAttachment         func(names.MachineTag, names.VolumeTag) state.NotifyWatcher
 	watchBlockDevices             func(names.MachineTag) state.NotifyWatcher
 	addUnitStorage                func(u names.UnitTag, name string, cons state.StorageConstraints) error
@@ -526,6 +537,10 @@ func (m *mockStorageState) WatchFilesystemAttachment(mtag names.MachineTag, f na
 	return m.watchFilesystemAttachment(mtag, f)
 }
 
+func (m *mockStorageState) WatchFilesystem(f names.FilesystemTag) state.NotifyWatcher {
+	return m.watchFilesystem(f)
+}
+
 func (m *mockStorageState) WatchVolumeAttachment(mtag names.MachineTag, v names.VolumeTag) state.NotifyWatcher {
 	return m.watchVolumeAttachment(mtag, v)
 }
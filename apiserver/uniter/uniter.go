@@ -7,6 +7,7 @@ package uniter
 
 import (
 	"fmt"
+	"reflect"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -18,6 +19,7 @@ import (
 	leadershipapiserver "github.com/juju/juju/apiserver/leadership"
 	"github.com/juju/juju/apiserver/meterstatus"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/audit"
 	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
@@ -29,10 +31,238 @@ var logger = loggo.GetLogger("juju.apiserver.uniter")
 
 func init() {
 	common.RegisterStandardFacade("Uniter", 4, NewUniterAPIV4)
+	// Version 5 adds ActionStatus.
+	common.RegisterStandardFacade("Uniter", 5, NewUniterAPIV5)
+	// Version 6 adds LogActionsMessages.
+	common.RegisterStandardFacade("Uniter", 6, NewUniterAPIV6)
+	// Version 7 adds GoalStates.
+	common.RegisterStandardFacade("Uniter", 7, NewUniterAPIV7)
+	// Version 8 adds ReadApplicationSettings and UpdateApplicationSettings.
+	common.RegisterStandardFacade("Uniter", 8, NewUniterAPIV8)
+	// Version 9 adds CharmState and SetCharmState.
+	common.RegisterStandardFacade("Uniter", 9, NewUniterAPIV9)
+	// Version 10 adds CloudSpec.
+	common.RegisterFacade("Uniter", 10, newUniterAPIV10, reflect.TypeOf((*UniterAPIV10)(nil)))
+	// Version 11 adds SecretValues, SecretRevisions and WatchSecrets.
+	common.RegisterFacade("Uniter", 11, newUniterAPIV11, reflect.TypeOf((*UniterAPIV11)(nil)))
+	// Version 12 adds RecordHookExecutions.
+	common.RegisterFacade("Uniter", 12, newUniterAPIV12, reflect.TypeOf((*UniterAPIV12)(nil)))
+	// Version 13 adds SetCharmEgressRules, and endpoints to OpenPorts
+	// and ClosePorts.
+	common.RegisterFacade("Uniter", 13, newUniterAPI, reflect.TypeOf((*UniterAPI)(nil)))
+}
+
+// The versions of the API including CloudSpec are created from the
+// facade context, so that they can record credential access with the
+// controller's audit entry sink.
+
+func newUniterAPIV10(ctx facade.Context) (facade.Facade, error) {
+	api, err := NewUniterAPIV10(ctx.State(), ctx.Resources(), ctx.Auth())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	api.auditEntrySink = ctx.AuditEntrySink()
+	return api, nil
+}
+
+func newUniterAPIV11(ctx facade.Context) (facade.Facade, error) {
+	api, err := NewUniterAPIV11(ctx.State(), ctx.Resources(), ctx.Auth())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	api.auditEntrySink = ctx.AuditEntrySink()
+	return api, nil
+}
+
+func newUniterAPIV12(ctx facade.Context) (facade.Facade, error) {
+	api, err := NewUniterAPIV12(ctx.State(), ctx.Resources(), ctx.Auth())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	api.auditEntrySink = ctx.AuditEntrySink()
+	return api, nil
+}
+
+func newUniterAPI(ctx facade.Context) (facade.Facade, error) {
+	api, err := NewUniterAPI(ctx.State(), ctx.Resources(), ctx.Auth())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	api.auditEntrySink = ctx.AuditEntrySink()
+	return api, nil
+}
+
+// UniterAPIV4 implements version 4 of the Uniter API, which does not
+// have the methods added by later versions.
+type UniterAPIV4 struct {
+	*UniterAPIV5
+}
+
+// NewUniterAPIV4 creates a new instance of the Uniter API, version 4.
+func NewUniterAPIV4(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV4, error) {
+	api, err := NewUniterAPIV5(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &UniterAPIV4{api}, nil
+}
+
+// ActionStatus isn't on the v4 API. Methods with more than one
+// argument are not exposed by the RPC layer, so this hides the
+// method of the embedded API.
+func (*UniterAPIV4) ActionStatus(_, _ struct{}) {}
+
+// UniterAPIV5 implements version 5 of the Uniter API.
+type UniterAPIV5 struct {
+	*UniterAPIV6
+}
+
+// NewUniterAPIV5 creates a new instance of the Uniter API, version 5.
+func NewUniterAPIV5(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV5, error) {
+	api, err := NewUniterAPIV6(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &UniterAPIV5{api}, nil
+}
+
+// LogActionsMessages isn't on the v5 API.
+func (*UniterAPIV5) LogActionsMessages(_, _ struct{}) {}
+
+// UniterAPIV6 implements version 6 of the Uniter API.
+type UniterAPIV6 struct {
+	*UniterAPIV7
+}
+
+// NewUniterAPIV6 creates a new instance of the Uniter API, version 6.
+func NewUniterAPIV6(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV6, error) {
+	api, err := NewUniterAPIV7(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &UniterAPIV6{api}, nil
+}
+
+// GoalStates isn't on the v6 API.
+func (*UniterAPIV6) GoalStates(_, _ struct{}) {}
+
+// UniterAPIV7 implements version 7 of the Uniter API.
+type UniterAPIV7 struct {
+	*UniterAPIV8
+}
+
+// NewUniterAPIV7 creates a new instance of the Uniter API, version 7.
+func NewUniterAPIV7(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV7, error) {
+	api, err := NewUniterAPIV8(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &UniterAPIV7{api}, nil
+}
+
+// ReadApplicationSettings isn't on the v7 API.
+func (*UniterAPIV7) ReadApplicationSettings(_, _ struct{}) {}
+
+// UpdateApplicationSettings isn't on the v7 API.
+func (*UniterAPIV7) UpdateApplicationSettings(_, _ struct{}) {}
+
+// UniterAPIV8 implements version 8 of the Uniter API.
+type UniterAPIV8 struct {
+	*UniterAPIV9
+}
+
+// NewUniterAPIV8 creates a new instance of the Uniter API, version 8.
+func NewUniterAPIV8(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV8, error) {
+	api, err := NewUniterAPIV9(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &UniterAPIV8{api}, nil
+}
+
+// CharmState isn't on the v8 API.
+func (*UniterAPIV8) CharmState(_, _ struct{}) {}
+
+// SetCharmState isn't on the v8 API.
+func (*UniterAPIV8) SetCharmState(_, _ struct{}) {}
+
+// UniterAPIV9 implements version 9 of the Uniter API.
+type UniterAPIV9 struct {
+	*UniterAPIV10
+}
+
+// NewUniterAPIV9 creates a new instance of the Uniter API, version 9.
+func NewUniterAPIV9(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV9, error) {
+	api, err := NewUniterAPIV10(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &UniterAPIV9{api}, nil
+}
+
+// CloudSpec isn't on the v9 API.
+func (*UniterAPIV9) CloudSpec(_, _ struct{}) {}
+
+// UniterAPIV10 implements version 10 of the Uniter API.
+type UniterAPIV10 struct {
+	*UniterAPIV11
+}
+
+// NewUniterAPIV10 creates a new instance of the Uniter API, version 10.
+func NewUniterAPIV10(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV10, error) {
+	api, err := NewUniterAPIV11(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &UniterAPIV10{api}, nil
+}
+
+// SecretValues isn't on the v10 API.
+func (*UniterAPIV10) SecretValues(_, _ struct{}) {}
+
+// SecretRevisions isn't on the v10 API.
+func (*UniterAPIV10) SecretRevisions(_, _ struct{}) {}
+
+// WatchSecrets isn't on the v10 API.
+func (*UniterAPIV10) WatchSecrets(_, _ struct{}) {}
+
+// UniterAPIV11 implements version 11 of the Uniter API.
+type UniterAPIV11 struct {
+	*UniterAPIV12
+}
+
+// NewUniterAPIV11 creates a new instance of the Uniter API, version 11.
+func NewUniterAPIV11(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV11, error) {
+	api, err := NewUniterAPIV12(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &UniterAPIV11{api}, nil
+}
+
+// RecordHookExecutions isn't on the v11 API.
+func (*UniterAPIV11) RecordHookExecutions(_, _ struct{}) {}
+
+// UniterAPIV12 implements version 12 of the Uniter API.
+type UniterAPIV12 struct {
+	*UniterAPI
 }
 
-// UniterAPIV3 implements the API version 3, used by the uniter worker.
-type UniterAPIV3 struct {
+// NewUniterAPIV12 creates a new instance of the Uniter API, version 12.
+func NewUniterAPIV12(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV12, error) {
+	api, err := NewUniterAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &UniterAPIV12{api}, nil
+}
+
+// SetCharmEgressRules isn't on the v12 API.
+func (*UniterAPIV12) SetCharmEgressRules(_, _ struct{}) {}
+
+// UniterAPI implements the latest version (v13) of the Uniter API,
+// used by the uniter worker.
+type UniterAPI struct {
 	*common.LifeGetter
 	*StatusAPI
 	*common.DeadEnsurer
@@ -51,10 +281,14 @@ type UniterAPIV3 struct {
 	unit          *state.Unit
 	accessMachine common.GetAuthFunc
 	StorageAPI
+
+	// auditEntrySink, if non-nil, records access to the cloud
+	// credential.
+	auditEntrySink audit.AuditEntrySinkFn
 }
 
-// NewUniterAPIV4 creates a new instance of the Uniter API, version 3.
-func NewUniterAPIV4(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV3, error) {
+// NewUniterAPI creates a new instance of the latest Uniter API.
+func NewUniterAPI(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPI, error) {
 	if !authorizer.AuthUnitAgent() {
 		return nil, common.ErrPerm
 	}
@@ -116,7 +350,7 @@ func NewUniterAPIV4(st *state.State, resources facade.Resources, authorizer faca
 		return nil, errors.Annotate(err, "could not create meter status API handler")
 	}
 	accessUnitOrService := common.AuthAny(accessUnit, accessService)
-	return &UniterAPIV3{
+	return &UniterAPI{
 		LifeGetter:                 common.NewLifeGetter(st, accessUnitOrService),
 		DeadEnsurer:                common.NewDeadEnsurer(st, accessUnit),
 		AgentEntityWatcher:         common.NewAgentEntityWatcher(st, resources, accessUnitOrService),
@@ -142,7 +376,7 @@ func NewUniterAPIV4(st *state.State, resources facade.Resources, authorizer faca
 
 // AllMachinePorts returns all opened port ranges for each given
 // machine (on all networks).
-func (u *UniterAPIV3) AllMachinePorts(args params.Entities) (params.MachinePortsResults, error) {
+func (u *UniterAPI) AllMachinePorts(args params.Entities) (params.MachinePortsResults, error) {
 	result := params.MachinePortsResults{
 		Results: make([]params.MachinePortsResult, len(args.Entities)),
 	}
@@ -159,7 +393,7 @@ func (u *UniterAPIV3) AllMachinePorts(args params.Entities) (params.MachinePorts
 // AssignedMachine returns the machine tag for each given unit tag, or
 // an error satisfying params.IsCodeNotAssigned when a unit has no
 // assigned machine.
-func (u *UniterAPIV3) AssignedMachine(args params.Entities) (params.StringResults, error) {
+func (u *UniterAPI) AssignedMachine(args params.Entities) (params.StringResults, error) {
 	result := params.StringResults{
 		Results: make([]params.StringResult, len(args.Entities)),
 	}
@@ -192,11 +426,11 @@ func (u *UniterAPIV3) AssignedMachine(args params.Entities) (params.StringResult
 	return result, nil
 }
 
-func (u *UniterAPIV3) getMachine(tag names.MachineTag) (*state.Machine, error) {
+func (u *UniterAPI) getMachine(tag names.MachineTag) (*state.Machine, error) {
 	return u.st.Machine(tag.Id())
 }
 
-func (u *UniterAPIV3) getOneMachinePorts(canAccess common.AuthFunc, machineTag string) params.MachinePortsResult {
+func (u *UniterAPI) getOneMachinePorts(canAccess common.AuthFunc, machineTag string) params.MachinePortsResult {
 	tag, err := names.ParseMachineTag(machineTag)
 	if err != nil {
 		return params.MachinePortsResult{Error: common.ServerError(common.ErrPerm)}
@@ -214,19 +448,15 @@ func (u *UniterAPIV3) getOneMachinePorts(canAccess common.AuthFunc, machineTag s
 	}
 	var resultPorts []params.MachinePortRange
 	for _, ports := range allPorts {
-		// AllPortRanges gives a map, but apis require a stable order
-		// for results, so sort the port ranges.
-		portRangesToUnits := ports.AllPortRanges()
-		portRanges := make([]network.PortRange, 0, len(portRangesToUnits))
-		for portRange := range portRangesToUnits {
-			portRanges = append(portRanges, portRange)
-		}
-		network.SortPortRanges(portRanges)
-		for _, portRange := range portRanges {
-			unitName := portRangesToUnits[portRange]
+		for _, portRange := range ports.PortRanges() {
 			resultPorts = append(resultPorts, params.MachinePortRange{
-				UnitTag:   names.NewUnitTag(unitName).String(),
-				PortRange: params.FromNetworkPortRange(portRange),
+				UnitTag: names.NewUnitTag(portRange.UnitName).String(),
+				PortRange: params.PortRange{
+					FromPort: portRange.FromPort,
+					ToPort:   portRange.ToPort,
+					Protocol: portRange.Protocol,
+				},
+				Endpoint: portRange.Endpoint,
 			})
 		}
 	}
@@ -236,7 +466,7 @@ func (u *UniterAPIV3) getOneMachinePorts(canAccess common.AuthFunc, machineTag s
 }
 
 // PublicAddress returns the public address for each given unit, if set.
-func (u *UniterAPIV3) PublicAddress(args params.Entities) (params.StringResults, error) {
+func (u *UniterAPI) PublicAddress(args params.Entities) (params.StringResults, error) {
 	result := params.StringResults{
 		Results: make([]params.StringResult, len(args.Entities)),
 	}
@@ -270,7 +500,7 @@ func (u *UniterAPIV3) PublicAddress(args params.Entities) (params.StringResults,
 }
 
 // PrivateAddress returns the private address for each given unit, if set.
-func (u *UniterAPIV3) PrivateAddress(args params.Entities) (params.StringResults, error) {
+func (u *UniterAPI) PrivateAddress(args params.Entities) (params.StringResults, error) {
 	result := params.StringResults{
 		Results: make([]params.StringResult, len(args.Entities)),
 	}
@@ -315,7 +545,7 @@ var getZone = func(st *state.State, tag names.Tag) (string, error) {
 }
 
 // AvailabilityZone returns the availability zone for each given unit, if applicable.
-func (u *UniterAPIV3) AvailabilityZone(args params.Entities) (params.StringResults, error) {
+func (u *UniterAPI) AvailabilityZone(args params.Entities) (params.StringResults, error) {
 	var results params.StringResults
 
 	canAccess, err := u.accessUnit()
@@ -352,7 +582,7 @@ func (u *UniterAPIV3) AvailabilityZone(args params.Entities) (params.StringResul
 }
 
 // Resolved returns the current resolved setting for each given unit.
-func (u *UniterAPIV3) Resolved(args params.Entities) (params.ResolvedModeResults, error) {
+func (u *UniterAPI) Resolved(args params.Entities) (params.ResolvedModeResults, error) {
 	result := params.ResolvedModeResults{
 		Results: make([]params.ResolvedModeResult, len(args.Entities)),
 	}
@@ -380,7 +610,7 @@ func (u *UniterAPIV3) Resolved(args params.Entities) (params.ResolvedModeResults
 }
 
 // ClearResolved removes any resolved setting from each given unit.
-func (u *UniterAPIV3) ClearResolved(args params.Entities) (params.ErrorResults, error) {
+func (u *UniterAPI) ClearResolved(args params.Entities) (params.ErrorResults, error) {
 	result := params.ErrorResults{
 		Results: make([]params.ErrorResult, len(args.Entities)),
 	}
@@ -409,7 +639,7 @@ func (u *UniterAPIV3) ClearResolved(args params.Entities) (params.ErrorResults,
 
 // GetPrincipal returns the result of calling PrincipalName() and
 // converting it to a tag, on each given unit.
-func (u *UniterAPIV3) GetPrincipal(args params.Entities) (params.StringBoolResults, error) {
+func (u *UniterAPI) GetPrincipal(args params.Entities) (params.StringBoolResults, error) {
 	result := params.StringBoolResults{
 		Results: make([]params.StringBoolResult, len(args.Entities)),
 	}
@@ -442,7 +672,7 @@ func (u *UniterAPIV3) GetPrincipal(args params.Entities) (params.StringBoolResul
 
 // Destroy advances all given Alive units' lifecycles as far as
 // possible. See state/Unit.Destroy().
-func (u *UniterAPIV3) Destroy(args params.Entities) (params.ErrorResults, error) {
+func (u *UniterAPI) Destroy(args params.Entities) (params.ErrorResults, error) {
 	result := params.ErrorResults{
 		Results: make([]params.ErrorResult, len(args.Entities)),
 	}
@@ -470,7 +700,7 @@ func (u *UniterAPIV3) Destroy(args params.Entities) (params.ErrorResults, error)
 }
 
 // DestroyAllSubordinates destroys all subordinates of each given unit.
-func (u *UniterAPIV3) DestroyAllSubordinates(args params.Entities) (params.ErrorResults, error) {
+func (u *UniterAPI) DestroyAllSubordinates(args params.Entities) (params.ErrorResults, error) {
 	result := params.ErrorResults{
 		Results: make([]params.ErrorResult, len(args.Entities)),
 	}
@@ -498,7 +728,7 @@ func (u *UniterAPIV3) DestroyAllSubordinates(args params.Entities) (params.Error
 }
 
 // HasSubordinates returns the whether each given unit has any subordinates.
-func (u *UniterAPIV3) HasSubordinates(args params.Entities) (params.BoolResults, error) {
+func (u *UniterAPI) HasSubordinates(args params.Entities) (params.BoolResults, error) {
 	result := params.BoolResults{
 		Results: make([]params.BoolResult, len(args.Entities)),
 	}
@@ -528,7 +758,7 @@ func (u *UniterAPIV3) HasSubordinates(args params.Entities) (params.BoolResults,
 
 // CharmModifiedVersion returns the most CharmModifiedVersion for all given
 // units or services.
-func (u *UniterAPIV3) CharmModifiedVersion(args params.Entities) (params.IntResults, error) {
+func (u *UniterAPI) CharmModifiedVersion(args params.Entities) (params.IntResults, error) {
 	results := params.IntResults{
 		Results: make([]params.IntResult, len(args.Entities)),
 	}
@@ -549,7 +779,7 @@ func (u *UniterAPIV3) CharmModifiedVersion(args params.Entities) (params.IntResu
 	return results, nil
 }
 
-func (u *UniterAPIV3) charmModifiedVersion(tagStr string, canAccess func(names.Tag) bool) (int, error) {
+func (u *UniterAPI) charmModifiedVersion(tagStr string, canAccess func(names.Tag) bool) (int, error) {
 	tag, err := names.ParseTag(tagStr)
 	if err != nil {
 		return -1, common.ErrPerm
@@ -577,7 +807,7 @@ func (u *UniterAPIV3) charmModifiedVersion(tagStr string, canAccess func(names.T
 }
 
 // CharmURL returns the charm URL for all given units or services.
-func (u *UniterAPIV3) CharmURL(args params.Entities) (params.StringBoolResults, error) {
+func (u *UniterAPI) CharmURL(args params.Entities) (params.StringBoolResults, error) {
 	result := params.StringBoolResults{
 		Results: make([]params.StringBoolResult, len(args.Entities)),
 	}
@@ -614,7 +844,7 @@ func (u *UniterAPIV3) CharmURL(args params.Entities) (params.StringBoolResults,
 
 // SetCharmURL sets the charm URL for each given unit. An error will
 // be returned if a unit is dead, or the charm URL is not know.
-func (u *UniterAPIV3) SetCharmURL(args params.EntitiesCharmURL) (params.ErrorResults, error) {
+func (u *UniterAPI) SetCharmURL(args params.EntitiesCharmURL) (params.ErrorResults, error) {
 	result := params.ErrorResults{
 		Results: make([]params.ErrorResult, len(args.Entities)),
 	}
@@ -646,7 +876,7 @@ func (u *UniterAPIV3) SetCharmURL(args params.EntitiesCharmURL) (params.ErrorRes
 }
 
 // WorkloadVersion returns the workload version for all given units or services.
-func (u *UniterAPIV3) WorkloadVersion(args params.Entities) (params.StringResults, error) {
+func (u *UniterAPI) WorkloadVersion(args params.Entities) (params.StringResults, error) {
 	result := params.StringResults{
 		Results: make([]params.StringResult, len(args.Entities)),
 	}
@@ -682,7 +912,7 @@ func (u *UniterAPIV3) WorkloadVersion(args params.Entities) (params.StringResult
 
 // SetWorkloadVersion sets the workload version for each given unit. An error will
 // be returned if a unit is dead.
-func (u *UniterAPIV3) SetWorkloadVersion(args params.EntityWorkloadVersions) (params.ErrorResults, error) {
+func (u *UniterAPI) SetWorkloadVersion(args params.EntityWorkloadVersions) (params.ErrorResults, error) {
 	result := params.ErrorResults{
 		Results: make([]params.ErrorResult, len(args.Entities)),
 	}
@@ -716,7 +946,7 @@ func (u *UniterAPIV3) SetWorkloadVersion(args params.EntityWorkloadVersions) (pa
 
 // OpenPorts sets the policy of the port range with protocol to be
 // opened, for all given units.
-func (u *UniterAPIV3) OpenPorts(args params.EntitiesPortRanges) (params.ErrorResults, error) {
+func (u *UniterAPI) OpenPorts(args params.EntitiesPortRanges) (params.ErrorResults, error) {
 	result := params.ErrorResults{
 		Results: make([]params.ErrorResult, len(args.Entities)),
 	}
@@ -735,7 +965,7 @@ func (u *UniterAPIV3) OpenPorts(args params.EntitiesPortRanges) (params.ErrorRes
 			var unit *state.Unit
 			unit, err = u.getUnit(tag)
 			if err == nil {
-				err = unit.OpenPorts(entity.Protocol, entity.FromPort, entity.ToPort)
+				err = unit.OpenPortsOnEndpoint(entity.Endpoint, entity.Protocol, entity.FromPort, entity.ToPort)
 			}
 		}
 		result.Results[i].Error = common.ServerError(err)
@@ -745,7 +975,7 @@ func (u *UniterAPIV3) OpenPorts(args params.EntitiesPortRanges) (params.ErrorRes
 
 // ClosePorts sets the policy of the port range with protocol to be
 // closed, for all given units.
-func (u *UniterAPIV3) ClosePorts(args params.EntitiesPortRanges) (params.ErrorResults, error) {
+func (u *UniterAPI) ClosePorts(args params.EntitiesPortRanges) (params.ErrorResults, error) {
 	result := params.ErrorResults{
 		Results: make([]params.ErrorResult, len(args.Entities)),
 	}
@@ -764,7 +994,7 @@ func (u *UniterAPIV3) ClosePorts(args params.EntitiesPortRanges) (params.ErrorRe
 			var unit *state.Unit
 			unit, err = u.getUnit(tag)
 			if err == nil {
-				err = unit.ClosePorts(entity.Protocol, entity.FromPort, entity.ToPort)
+				err = unit.ClosePortsOnEndpoint(entity.Endpoint, entity.Protocol, entity.FromPort, entity.ToPort)
 			}
 		}
 		result.Results[i].Error = common.ServerError(err)
@@ -775,7 +1005,7 @@ func (u *UniterAPIV3) ClosePorts(args params.EntitiesPortRanges) (params.ErrorRe
 // WatchConfigSettings returns a NotifyWatcher for observing changes
 // to each unit's service configuration settings. See also
 // state/watcher.go:Unit.WatchConfigSettings().
-func (u *UniterAPIV3) WatchConfigSettings(args params.Entities) (params.NotifyWatchResults, error) {
+func (u *UniterAPI) WatchConfigSettings(args params.Entities) (params.NotifyWatchResults, error) {
 	result := params.NotifyWatchResults{
 		Results: make([]params.NotifyWatchResult, len(args.Entities)),
 	}
@@ -804,7 +1034,7 @@ func (u *UniterAPIV3) WatchConfigSettings(args params.Entities) (params.NotifyWa
 // incoming action calls to a unit. See also state/watcher.go
 // Unit.WatchActionNotifications(). This method is called from
 // api/uniter/uniter.go WatchActionNotifications().
-func (u *UniterAPIV3) WatchActionNotifications(args params.Entities) (params.StringsWatchResults, error) {
+func (u *UniterAPI) WatchActionNotifications(args params.Entities) (params.StringsWatchResults, error) {
 	tagToActionReceiver := common.TagToActionReceiverFn(u.st.FindEntity)
 	watchOne := common.WatchOneActionReceiverNotifications(tagToActionReceiver, u.resources.Register)
 	canAccess, err := u.accessUnit()
@@ -816,7 +1046,7 @@ func (u *UniterAPIV3) WatchActionNotifications(args params.Entities) (params.Str
 
 // ConfigSettings returns the complete set of service charm config
 // settings available to each given unit.
-func (u *UniterAPIV3) ConfigSettings(args params.Entities) (params.ConfigSettingsResults, error) {
+func (u *UniterAPI) ConfigSettings(args params.Entities) (params.ConfigSettingsResults, error) {
 	result := params.ConfigSettingsResults{
 		Results: make([]params.ConfigSettingsResult, len(args.Entities)),
 	}
@@ -850,7 +1080,7 @@ func (u *UniterAPIV3) ConfigSettings(args params.Entities) (params.ConfigSetting
 // WatchApplicationRelations returns a StringsWatcher, for each given
 // service, that notifies of changes to the lifecycles of relations
 // involving that service.
-func (u *UniterAPIV3) WatchApplicationRelations(args params.Entities) (params.StringsWatchResults, error) {
+func (u *UniterAPI) WatchApplicationRelations(args params.Entities) (params.StringsWatchResults, error) {
 	result := params.StringsWatchResults{
 		Results: make([]params.StringsWatchResult, len(args.Entities)),
 	}
@@ -875,7 +1105,7 @@ func (u *UniterAPIV3) WatchApplicationRelations(args params.Entities) (params.St
 
 // CharmArchiveSha256 returns the SHA256 digest of the charm archive
 // (bundle) data for each charm url in the given parameters.
-func (u *UniterAPIV3) CharmArchiveSha256(args params.CharmURLs) (params.StringResults, error) {
+func (u *UniterAPI) CharmArchiveSha256(args params.CharmURLs) (params.StringResults, error) {
 	result := params.StringResults{
 		Results: make([]params.StringResult, len(args.URLs)),
 	}
@@ -900,7 +1130,7 @@ func (u *UniterAPIV3) CharmArchiveSha256(args params.CharmURLs) (params.StringRe
 
 // Relation returns information about all given relation/unit pairs,
 // including their id, key and the local endpoint.
-func (u *UniterAPIV3) Relation(args params.RelationUnits) (params.RelationResults, error) {
+func (u *UniterAPI) Relation(args params.RelationUnits) (params.RelationResults, error) {
 	result := params.RelationResults{
 		Results: make([]params.RelationResult, len(args.RelationUnits)),
 	}
@@ -920,7 +1150,7 @@ func (u *UniterAPIV3) Relation(args params.RelationUnits) (params.RelationResult
 
 // Actions returns the Actions by Tags passed and ensures that the Unit asking
 // for them is the same Unit that has the Actions.
-func (u *UniterAPIV3) Actions(args params.Entities) (params.ActionResults, error) {
+func (u *UniterAPI) Actions(args params.Entities) (params.ActionResults, error) {
 	canAccess, err := u.accessUnit()
 	if err != nil {
 		return params.ActionResults{}, err
@@ -931,7 +1161,7 @@ func (u *UniterAPIV3) Actions(args params.Entities) (params.ActionResults, error
 }
 
 // BeginActions marks the actions represented by the passed in Tags as running.
-func (u *UniterAPIV3) BeginActions(args params.Entities) (params.ErrorResults, error) {
+func (u *UniterAPI) BeginActions(args params.Entities) (params.ErrorResults, error) {
 	canAccess, err := u.accessUnit()
 	if err != nil {
 		return params.ErrorResults{}, err
@@ -941,8 +1171,44 @@ func (u *UniterAPIV3) BeginActions(args params.Entities) (params.ErrorResults, e
 	return common.BeginActions(args, actionFn), nil
 }
 
+// ActionStatus returns the current status of the Actions represented by
+// the passed in Tags. The uniter uses it to learn whether a running
+// Action has been cancelled.
+func (u *UniterAPI) ActionStatus(args params.Entities) (params.StringResults, error) {
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.StringResults{}, err
+	}
+
+	actionFn := common.AuthAndActionFromTagFn(canAccess, u.st.ActionByTag)
+	results := params.StringResults{
+		Results: make([]params.StringResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		action, err := actionFn(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = string(action.Status())
+	}
+	return results, nil
+}
+
+// LogActionsMessages records the progress messages logged by running
+// Actions.
+func (u *UniterAPI) LogActionsMessages(args params.ActionMessageParams) (params.ErrorResults, error) {
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+
+	actionFn := common.AuthAndActionFromTagFn(canAccess, u.st.ActionByTag)
+	return common.LogActionsMessages(args, actionFn), nil
+}
+
 // FinishActions saves the result of a completed Action
-func (u *UniterAPIV3) FinishActions(args params.ActionExecutionResults) (params.ErrorResults, error) {
+func (u *UniterAPI) FinishActions(args params.ActionExecutionResults) (params.ErrorResults, error) {
 	canAccess, err := u.accessUnit()
 	if err != nil {
 		return params.ErrorResults{}, err
@@ -955,7 +1221,7 @@ func (u *UniterAPIV3) FinishActions(args params.ActionExecutionResults) (params.
 // RelationById returns information about all given relations,
 // specified by their ids, including their key and the local
 // endpoint.
-func (u *UniterAPIV3) RelationById(args params.RelationIds) (params.RelationResults, error) {
+func (u *UniterAPI) RelationById(args params.RelationIds) (params.RelationResults, error) {
 	result := params.RelationResults{
 		Results: make([]params.RelationResult, len(args.RelationIds)),
 	}
@@ -972,7 +1238,7 @@ func (u *UniterAPIV3) RelationById(args params.RelationIds) (params.RelationResu
 // JoinedRelations returns the tags of all relations for which each supplied unit
 // has entered scope. It should be called RelationsInScope, but it's not convenient
 // to make that change until we have versioned APIs.
-func (u *UniterAPIV3) JoinedRelations(args params.Entities) (params.StringsResults, error) {
+func (u *UniterAPI) JoinedRelations(args params.Entities) (params.StringsResults, error) {
 	result := params.StringsResults{
 		Results: make([]params.StringsResult, len(args.Entities)),
 	}
@@ -1003,7 +1269,7 @@ func (u *UniterAPIV3) JoinedRelations(args params.Entities) (params.StringsResul
 }
 
 // CurrentModel returns the name and UUID for the current juju model.
-func (u *UniterAPIV3) CurrentModel() (params.ModelResult, error) {
+func (u *UniterAPI) CurrentModel() (params.ModelResult, error) {
 	result := params.ModelResult{}
 	env, err := u.st.Model()
 	if err == nil {
@@ -1019,7 +1285,7 @@ func (u *UniterAPIV3) CurrentModel() (params.ModelResult, error) {
 // TODO(dimitern): Refactor the uniter to call this instead of calling
 // ModelConfig() just to get the provider type. Once we have machine
 // addresses, this might be completely unnecessary though.
-func (u *UniterAPIV3) ProviderType() (params.StringResult, error) {
+func (u *UniterAPI) ProviderType() (params.StringResult, error) {
 	result := params.StringResult{}
 	cfg, err := u.st.ModelConfig()
 	if err == nil {
@@ -1031,7 +1297,7 @@ func (u *UniterAPIV3) ProviderType() (params.StringResult, error) {
 // EnterScope ensures each unit has entered its scope in the relation,
 // for all of the given relation/unit pairs. See also
 // state.RelationUnit.EnterScope().
-func (u *UniterAPIV3) EnterScope(args params.RelationUnits) (params.ErrorResults, error) {
+func (u *UniterAPI) EnterScope(args params.RelationUnits) (params.ErrorResults, error) {
 	result := params.ErrorResults{
 		Results: make([]params.ErrorResult, len(args.RelationUnits)),
 	}
@@ -1073,7 +1339,7 @@ func (u *UniterAPIV3) EnterScope(args params.RelationUnits) (params.ErrorResults
 // LeaveScope signals each unit has left its scope in the relation,
 // for all of the given relation/unit pairs. See also
 // state.RelationUnit.LeaveScope().
-func (u *UniterAPIV3) LeaveScope(args params.RelationUnits) (params.ErrorResults, error) {
+func (u *UniterAPI) LeaveScope(args params.RelationUnits) (params.ErrorResults, error) {
 	result := params.ErrorResults{
 		Results: make([]params.ErrorResult, len(args.RelationUnits)),
 	}
@@ -1098,7 +1364,7 @@ func (u *UniterAPIV3) LeaveScope(args params.RelationUnits) (params.ErrorResults
 
 // ReadSettings returns the local settings of each given set of
 // relation/unit.
-func (u *UniterAPIV3) ReadSettings(args params.RelationUnits) (params.SettingsResults, error) {
+func (u *UniterAPI) ReadSettings(args params.RelationUnits) (params.SettingsResults, error) {
 	result := params.SettingsResults{
 		Results: make([]params.SettingsResult, len(args.RelationUnits)),
 	}
@@ -1127,7 +1393,7 @@ func (u *UniterAPIV3) ReadSettings(args params.RelationUnits) (params.SettingsRe
 
 // ReadRemoteSettings returns the remote settings of each given set of
 // relation/local unit/remote unit.
-func (u *UniterAPIV3) ReadRemoteSettings(args params.RelationUnitPairs) (params.SettingsResults, error) {
+func (u *UniterAPI) ReadRemoteSettings(args params.RelationUnitPairs) (params.SettingsResults, error) {
 	result := params.SettingsResults{
 		Results: make([]params.SettingsResult, len(args.RelationUnitPairs)),
 	}
@@ -1162,7 +1428,7 @@ func (u *UniterAPIV3) ReadRemoteSettings(args params.RelationUnitPairs) (params.
 // UpdateSettings persists all changes made to the local settings of
 // all given pairs of relation and unit. Keys with empty values are
 // considered a signal to delete these values.
-func (u *UniterAPIV3) UpdateSettings(args params.RelationUnitsSettings) (params.ErrorResults, error) {
+func (u *UniterAPI) UpdateSettings(args params.RelationUnitsSettings) (params.ErrorResults, error) {
 	result := params.ErrorResults{
 		Results: make([]params.ErrorResult, len(args.RelationUnits)),
 	}
@@ -1196,10 +1462,89 @@ func (u *UniterAPIV3) UpdateSettings(args params.RelationUnitsSettings) (params.
 	return result, nil
 }
 
+// ReadApplicationSettings returns the application-level settings of
+// each given relation and application, as seen by the given unit. A
+// unit may read the settings of any application in a relation it
+// belongs to, but only the leader may read its own application's.
+func (u *UniterAPI) ReadApplicationSettings(args params.RelationApplications) (params.SettingsResults, error) {
+	result := params.SettingsResults{
+		Results: make([]params.SettingsResult, len(args.RelationApplications)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.SettingsResults{}, err
+	}
+	for i, arg := range args.RelationApplications {
+		settings, err := u.readOneApplicationSettings(canAccess, arg)
+		if err == nil {
+			result.Results[i].Settings, err = convertRelationSettings(settings)
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (u *UniterAPI) readOneApplicationSettings(canAccess common.AuthFunc, arg params.RelationApplication) (map[string]interface{}, error) {
+	unitTag, err := names.ParseUnitTag(arg.Unit)
+	if err != nil {
+		return nil, common.ErrPerm
+	}
+	appTag, err := names.ParseApplicationTag(arg.Application)
+	if err != nil {
+		return nil, common.ErrPerm
+	}
+	rel, unit, err := u.getRelationAndUnit(canAccess, arg.Relation, unitTag)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := rel.Endpoint(unit.ApplicationName()); err != nil {
+		return nil, common.ErrPerm
+	}
+	if appTag.Id() == unit.ApplicationName() {
+		token := u.st.LeadershipChecker().LeadershipCheck(appTag.Id(), unit.Name())
+		if err := token.Check(nil); err != nil {
+			return nil, common.ErrPerm
+		}
+	}
+	return rel.ApplicationSettings(appTag.Id())
+}
+
+// UpdateApplicationSettings applies the given settings changes to the
+// application-level settings of each given unit's application in the
+// given relation. Only the leader of the application may do this.
+func (u *UniterAPI) UpdateApplicationSettings(args params.RelationUnitsSettings) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.RelationUnits)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, arg := range args.RelationUnits {
+		unitTag, err := names.ParseUnitTag(arg.Unit)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		rel, unit, err := u.getRelationAndUnit(canAccess, arg.Relation, unitTag)
+		if err == nil {
+			appName := unit.ApplicationName()
+			token := u.st.LeadershipChecker().LeadershipCheck(appName, unit.Name())
+			if token.Check(nil) != nil {
+				err = common.ErrPerm
+			} else {
+				err = rel.UpdateApplicationSettings(appName, token, arg.Settings)
+			}
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
 // WatchRelationUnits returns a RelationUnitsWatcher for observing
 // changes to every unit in the supplied relation that is visible to
 // the supplied unit. See also state/watcher.go:RelationUnit.Watch().
-func (u *UniterAPIV3) WatchRelationUnits(args params.RelationUnits) (params.RelationUnitsWatchResults, error) {
+func (u *UniterAPI) WatchRelationUnits(args params.RelationUnits) (params.RelationUnitsWatchResults, error) {
 	result := params.RelationUnitsWatchResults{
 		Results: make([]params.RelationUnitsWatchResult, len(args.RelationUnits)),
 	}
@@ -1224,7 +1569,7 @@ func (u *UniterAPIV3) WatchRelationUnits(args params.RelationUnits) (params.Rela
 
 // WatchUnitAddresses returns a NotifyWatcher for observing changes
 // to each unit's addresses.
-func (u *UniterAPIV3) WatchUnitAddresses(args params.Entities) (params.NotifyWatchResults, error) {
+func (u *UniterAPI) WatchUnitAddresses(args params.Entities) (params.NotifyWatchResults, error) {
 	result := params.NotifyWatchResults{
 		Results: make([]params.NotifyWatchResult, len(args.Entities)),
 	}
@@ -1249,15 +1594,15 @@ func (u *UniterAPIV3) WatchUnitAddresses(args params.Entities) (params.NotifyWat
 	return result, nil
 }
 
-func (u *UniterAPIV3) getUnit(tag names.UnitTag) (*state.Unit, error) {
+func (u *UniterAPI) getUnit(tag names.UnitTag) (*state.Unit, error) {
 	return u.st.Unit(tag.Id())
 }
 
-func (u *UniterAPIV3) getService(tag names.ApplicationTag) (*state.Application, error) {
+func (u *UniterAPI) getService(tag names.ApplicationTag) (*state.Application, error) {
 	return u.st.Application(tag.Id())
 }
 
-func (u *UniterAPIV3) getRelationUnit(canAccess common.AuthFunc, relTag string, unitTag names.UnitTag) (*state.RelationUnit, error) {
+func (u *UniterAPI) getRelationUnit(canAccess common.AuthFunc, relTag string, unitTag names.UnitTag) (*state.RelationUnit, error) {
 	rel, unit, err := u.getRelationAndUnit(canAccess, relTag, unitTag)
 	if err != nil {
 		return nil, err
@@ -1265,7 +1610,7 @@ func (u *UniterAPIV3) getRelationUnit(canAccess common.AuthFunc, relTag string,
 	return rel.Unit(unit)
 }
 
-func (u *UniterAPIV3) getOneRelationById(relId int) (params.RelationResult, error) {
+func (u *UniterAPI) getOneRelationById(relId int) (params.RelationResult, error) {
 	nothing := params.RelationResult{}
 	rel, err := u.st.Relation(relId)
 	if errors.IsNotFound(err) {
@@ -1296,7 +1641,7 @@ func (u *UniterAPIV3) getOneRelationById(relId int) (params.RelationResult, erro
 	return result, nil
 }
 
-func (u *UniterAPIV3) getRelationAndUnit(canAccess common.AuthFunc, relTag string, unitTag names.UnitTag) (*state.Relation, *state.Unit, error) {
+func (u *UniterAPI) getRelationAndUnit(canAccess common.AuthFunc, relTag string, unitTag names.UnitTag) (*state.Relation, *state.Unit, error) {
 	tag, err := names.ParseRelationTag(relTag)
 	if err != nil {
 		return nil, nil, common.ErrPerm
@@ -1314,7 +1659,7 @@ func (u *UniterAPIV3) getRelationAndUnit(canAccess common.AuthFunc, relTag strin
 	return rel, unit, err
 }
 
-func (u *UniterAPIV3) prepareRelationResult(rel *state.Relation, unit *state.Unit) (params.RelationResult, error) {
+func (u *UniterAPI) prepareRelationResult(rel *state.Relation, unit *state.Unit) (params.RelationResult, error) {
 	nothing := params.RelationResult{}
 	ep, err := rel.Endpoint(unit.ApplicationName())
 	if err != nil {
@@ -1333,7 +1678,7 @@ func (u *UniterAPIV3) prepareRelationResult(rel *state.Relation, unit *state.Uni
 	}, nil
 }
 
-func (u *UniterAPIV3) getOneRelation(canAccess common.AuthFunc, relTag, unitTag string) (params.RelationResult, error) {
+func (u *UniterAPI) getOneRelation(canAccess common.AuthFunc, relTag, unitTag string) (params.RelationResult, error) {
 	nothing := params.RelationResult{}
 	tag, err := names.ParseUnitTag(unitTag)
 	if err != nil {
@@ -1346,7 +1691,7 @@ func (u *UniterAPIV3) getOneRelation(canAccess common.AuthFunc, relTag, unitTag
 	return u.prepareRelationResult(rel, unit)
 }
 
-func (u *UniterAPIV3) destroySubordinates(principal *state.Unit) error {
+func (u *UniterAPI) destroySubordinates(principal *state.Unit) error {
 	subordinates := principal.SubordinateNames()
 	for _, subName := range subordinates {
 		unit, err := u.getUnit(names.NewUnitTag(subName))
@@ -1360,7 +1705,7 @@ func (u *UniterAPIV3) destroySubordinates(principal *state.Unit) error {
 	return nil
 }
 
-func (u *UniterAPIV3) watchOneServiceRelations(tag names.ApplicationTag) (params.StringsWatchResult, error) {
+func (u *UniterAPI) watchOneServiceRelations(tag names.ApplicationTag) (params.StringsWatchResult, error) {
 	nothing := params.StringsWatchResult{}
 	service, err := u.getService(tag)
 	if err != nil {
@@ -1377,7 +1722,7 @@ func (u *UniterAPIV3) watchOneServiceRelations(tag names.ApplicationTag) (params
 	return nothing, watcher.EnsureErr(watch)
 }
 
-func (u *UniterAPIV3) watchOneUnitConfigSettings(tag names.UnitTag) (string, error) {
+func (u *UniterAPI) watchOneUnitConfigSettings(tag names.UnitTag) (string, error) {
 	unit, err := u.getUnit(tag)
 	if err != nil {
 		return "", err
@@ -1396,7 +1741,7 @@ func (u *UniterAPIV3) watchOneUnitConfigSettings(tag names.UnitTag) (string, err
 	return "", watcher.EnsureErr(watch)
 }
 
-func (u *UniterAPIV3) watchOneUnitAddresses(tag names.UnitTag) (string, error) {
+func (u *UniterAPI) watchOneUnitAddresses(tag names.UnitTag) (string, error) {
 	unit, err := u.getUnit(tag)
 	if err != nil {
 		return "", err
@@ -1420,7 +1765,7 @@ func (u *UniterAPIV3) watchOneUnitAddresses(tag names.UnitTag) (string, error) {
 	return "", watcher.EnsureErr(watch)
 }
 
-func (u *UniterAPIV3) watchOneRelationUnit(relUnit *state.RelationUnit) (params.RelationUnitsWatchResult, error) {
+func (u *UniterAPI) watchOneRelationUnit(relUnit *state.RelationUnit) (params.RelationUnitsWatchResult, error) {
 	watch := relUnit.Watch()
 	// Consume the initial event and forward it to the result.
 	if changes, ok := <-watch.Changes(); ok {
@@ -1432,7 +1777,7 @@ func (u *UniterAPIV3) watchOneRelationUnit(relUnit *state.RelationUnit) (params.
 	return params.RelationUnitsWatchResult{}, watcher.EnsureErr(watch)
 }
 
-func (u *UniterAPIV3) checkRemoteUnit(relUnit *state.RelationUnit, remoteUnitTag string) (string, error) {
+func (u *UniterAPI) checkRemoteUnit(relUnit *state.RelationUnit, remoteUnitTag string) (string, error) {
 	// Make sure the unit is indeed remote.
 	if remoteUnitTag == u.auth.GetAuthTag().String() {
 		return "", common.ErrPerm
@@ -1523,7 +1868,7 @@ func leadershipSettingsAccessorFactory(
 }
 
 // AddMetricBatches adds the metrics for the specified unit.
-func (u *UniterAPIV3) AddMetricBatches(args params.MetricBatchParams) (params.ErrorResults, error) {
+func (u *UniterAPI) AddMetricBatches(args params.MetricBatchParams) (params.ErrorResults, error) {
 	result := params.ErrorResults{
 		Results: make([]params.ErrorResult, len(args.Batches)),
 	}
@@ -1564,7 +1909,7 @@ func (u *UniterAPIV3) AddMetricBatches(args params.MetricBatchParams) (params.Er
 
 // NetworkConfig returns information about all given relation/unit pairs,
 // including their id, key and the local endpoint.
-func (u *UniterAPIV3) NetworkConfig(args params.UnitsNetworkConfig) (params.UnitNetworkConfigResults, error) {
+func (u *UniterAPI) NetworkConfig(args params.UnitsNetworkConfig) (params.UnitNetworkConfigResults, error) {
 	result := params.UnitNetworkConfigResults{
 		Results: make([]params.UnitNetworkConfigResult, len(args.Args)),
 	}
@@ -1585,7 +1930,7 @@ func (u *UniterAPIV3) NetworkConfig(args params.UnitsNetworkConfig) (params.Unit
 	return result, nil
 }
 
-func (u *UniterAPIV3) getOneNetworkConfig(canAccess common.AuthFunc, unitTagArg, bindingName string) ([]params.NetworkConfig, error) {
+func (u *UniterAPI) getOneNetworkConfig(canAccess common.AuthFunc, unitTagArg, bindingName string) ([]params.NetworkConfig, error) {
 	unitTag, err := names.ParseUnitTag(unitTagArg)
 	if err != nil {
 		return nil, errors.Trace(err)
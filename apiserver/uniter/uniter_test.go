@@ -5,6 +5,7 @@ package uniter_test
 
 import (
 	"fmt"
+	"reflect"
 	"time"
 
 	"github.com/juju/errors"
@@ -21,6 +22,7 @@ import (
 	"github.com/juju/juju/apiserver/uniter"
 	"github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/network"
+	"github.com/juju/juju/rpc/rpcreflect"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/multiwatcher"
 	statetesting "github.com/juju/juju/state/testing"
@@ -37,7 +39,7 @@ type uniterSuite struct {
 
 	authorizer apiservertesting.FakeAuthorizer
 	resources  *common.Resources
-	uniter     *uniter.UniterAPIV3
+	uniter     *uniter.UniterAPI
 
 	machine0      *state.Machine
 	machine1      *state.Machine
@@ -114,19 +116,19 @@ func (s *uniterSuite) SetUpTest(c *gc.C) {
 	s.resources = common.NewResources()
 	s.AddCleanup(func(_ *gc.C) { s.resources.StopAll() })
 
-	uniterAPIV3, err := uniter.NewUniterAPIV4(
+	uniterAPI, err := uniter.NewUniterAPI(
 		s.State,
 		s.resources,
 		s.authorizer,
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	s.uniter = uniterAPIV3
+	s.uniter = uniterAPI
 }
 
 func (s *uniterSuite) TestUniterFailsWithNonUnitAgentUser(c *gc.C) {
 	anAuthorizer := s.authorizer
 	anAuthorizer.Tag = names.NewMachineTag("9")
-	_, err := uniter.NewUniterAPIV4(s.State, s.resources, anAuthorizer)
+	_, err := uniter.NewUniterAPI(s.State, s.resources, anAuthorizer)
 	c.Assert(err, gc.NotNil)
 	c.Assert(err, gc.ErrorMatches, "permission denied")
 }
@@ -600,7 +602,7 @@ func (s *uniterSuite) TestGetPrincipal(c *gc.C) {
 	// Now try as subordinate's agent.
 	subAuthorizer := s.authorizer
 	subAuthorizer.Tag = subordinate.Tag()
-	subUniter, err := uniter.NewUniterAPIV4(s.State, s.resources, subAuthorizer)
+	subUniter, err := uniter.NewUniterAPI(s.State, s.resources, subAuthorizer)
 	c.Assert(err, jc.ErrorIsNil)
 
 	result, err = subUniter.GetPrincipal(args)
@@ -1216,7 +1218,7 @@ func (s *uniterSuite) TestActionsWrongUnit(c *gc.C) {
 	mysqlUnitAuthorizer := apiservertesting.FakeAuthorizer{
 		Tag: s.mysqlUnit.Tag(),
 	}
-	mysqlUnitFacade, err := uniter.NewUniterAPIV4(s.State, s.resources, mysqlUnitAuthorizer)
+	mysqlUnitFacade, err := uniter.NewUniterAPI(s.State, s.resources, mysqlUnitAuthorizer)
 	c.Assert(err, jc.ErrorIsNil)
 
 	action, err := s.wordpressUnit.AddAction("fakeaction", nil)
@@ -1377,6 +1379,53 @@ func (s *uniterSuite) TestBeginActions(c *gc.C) {
 	c.Assert(started.After(enqueued) || started.Equal(enqueued), jc.IsTrue, gc.Commentf("started should be after or equal to enqueued time"))
 }
 
+func (s *uniterSuite) TestActionStatus(c *gc.C) {
+	action, err := s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	action, err = action.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: action.ActionTag().String()},
+		{Tag: "action-feedface-0123-4567-8901-2345deadbeef"},
+	}}
+	res, err := s.uniter.ActionStatus(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res.Results, gc.HasLen, 2)
+	c.Assert(res.Results[0].Error, gc.IsNil)
+	c.Assert(res.Results[0].Result, gc.Equals, params.ActionRunning)
+	c.Assert(res.Results[1].Error, gc.NotNil)
+
+	_, err = action.Cancel()
+	c.Assert(err, jc.ErrorIsNil)
+	res, err = s.uniter.ActionStatus(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res.Results[0].Result, gc.Equals, params.ActionAborting)
+}
+
+func (s *uniterSuite) TestLogActionsMessages(c *gc.C) {
+	action, err := s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	action, err = action.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.ActionMessageParams{Messages: []params.ActionMessageArg{
+		{ActionTag: action.ActionTag().String(), Message: "working hard"},
+		{ActionTag: "action-feedface-0123-4567-8901-2345deadbeef", Message: "nope"},
+	}}
+	res, err := s.uniter.LogActionsMessages(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res.Results, gc.HasLen, 2)
+	c.Assert(res.Results[0].Error, gc.IsNil)
+	c.Assert(res.Results[1].Error, gc.NotNil)
+
+	action, err = s.State.ActionByTag(action.ActionTag())
+	c.Assert(err, jc.ErrorIsNil)
+	messages := action.Messages()
+	c.Assert(messages, gc.HasLen, 1)
+	c.Assert(messages[0].Message, gc.Equals, "working hard")
+}
+
 func (s *uniterSuite) TestRelation(c *gc.C) {
 	rel := s.addRelation(c, "wordpress", "mysql")
 	wpEp, err := rel.Endpoint("wordpress")
@@ -1598,6 +1647,176 @@ func (s *uniterSuite) TestJoinedRelations(c *gc.C) {
 	check()
 }
 
+func (s *uniterSuite) TestGoalStates(c *gc.C) {
+	s.addRelation(c, "wordpress", "mysql")
+	goalStatus := func(unit *state.Unit) params.GoalStateStatus {
+		info, err := unit.Status()
+		c.Assert(err, jc.ErrorIsNil)
+		return params.GoalStateStatus{
+			Status: info.Status.String(),
+			Since:  info.Since,
+		}
+	}
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: s.wordpressUnit.Tag().String()},
+		{Tag: s.mysqlUnit.Tag().String()},
+		{Tag: "application-wordpress"},
+		{Tag: "machine-0"},
+	}}
+	result, err := s.uniter.GoalStates(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.GoalStateResults{
+		Results: []params.GoalStateResult{{
+			Result: &params.GoalState{
+				Units: params.UnitsGoalState{
+					"wordpress/0": goalStatus(s.wordpressUnit),
+				},
+				Relations: map[string]params.UnitsGoalState{
+					"db": {
+						"mysql":   {Status: "joined"},
+						"mysql/0": goalStatus(s.mysqlUnit),
+					},
+				},
+			},
+		},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
+func (s *uniterSuite) TestGoalStatesDyingUnit(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, &jujuFactory.UnitParams{
+		Application: s.wordpress,
+		Machine:     s.machine0,
+	})
+	now := time.Now()
+	err := unit.SetAgentStatus(status.StatusInfo{
+		Status: status.Idle,
+		Since:  &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: s.wordpressUnit.Tag().String()},
+	}}
+	result, err := s.uniter.GoalStates(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	units := result.Results[0].Result.Units
+	c.Assert(units, gc.HasLen, 2)
+	c.Assert(units["wordpress/1"], jc.DeepEquals, params.GoalStateStatus{Status: "dying"})
+}
+
+func (s *uniterSuite) TestApplicationSettings(c *gc.C) {
+	rel := s.addRelation(c, "wordpress", "mysql")
+	err := s.State.LeadershipClaimer().ClaimLeadership("wordpress", "wordpress/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	updateArgs := params.RelationUnitsSettings{RelationUnits: []params.RelationUnitSettings{
+		{Relation: rel.Tag().String(), Unit: "unit-wordpress-0", Settings: params.Settings{"venue": "home"}},
+		{Relation: rel.Tag().String(), Unit: "unit-mysql-0", Settings: params.Settings{"venue": "away"}},
+		{Relation: "relation-42", Unit: "unit-wordpress-0", Settings: params.Settings{"venue": "away"}},
+	}}
+	updateResult, err := s.uniter.UpdateApplicationSettings(updateArgs)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(updateResult, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	err = s.State.LeadershipClaimer().ClaimLeadership("mysql", "mysql/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	token := s.State.LeadershipChecker().LeadershipCheck("mysql", "mysql/0")
+	err = rel.UpdateApplicationSettings("mysql", token, map[string]string{"host": "db"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	readArgs := params.RelationApplications{RelationApplications: []params.RelationApplication{
+		{Relation: rel.Tag().String(), Unit: "unit-wordpress-0", Application: "application-wordpress"},
+		{Relation: rel.Tag().String(), Unit: "unit-wordpress-0", Application: "application-mysql"},
+		{Relation: rel.Tag().String(), Unit: "unit-mysql-0", Application: "application-mysql"},
+		{Relation: rel.Tag().String(), Unit: "unit-wordpress-0", Application: "unit-mysql-0"},
+		{Relation: "relation-42", Unit: "unit-wordpress-0", Application: "application-mysql"},
+	}}
+	readResult, err := s.uniter.ReadApplicationSettings(readArgs)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(readResult, gc.DeepEquals, params.SettingsResults{
+		Results: []params.SettingsResult{
+			{Settings: params.Settings{"venue": "home"}},
+			{Settings: params.Settings{"host": "db"}},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
+func (s *uniterSuite) TestApplicationSettingsNotLeader(c *gc.C) {
+	rel := s.addRelation(c, "wordpress", "mysql")
+	err := s.State.LeadershipClaimer().ClaimLeadership("wordpress", "wordpress/1", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	updateResult, err := s.uniter.UpdateApplicationSettings(params.RelationUnitsSettings{
+		RelationUnits: []params.RelationUnitSettings{
+			{Relation: rel.Tag().String(), Unit: "unit-wordpress-0", Settings: params.Settings{"venue": "home"}},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(updateResult.Results[0].Error, jc.DeepEquals, apiservertesting.ErrUnauthorized)
+
+	readResult, err := s.uniter.ReadApplicationSettings(params.RelationApplications{
+		RelationApplications: []params.RelationApplication{
+			{Relation: rel.Tag().String(), Unit: "unit-wordpress-0", Application: "application-wordpress"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(readResult.Results[0].Error, jc.DeepEquals, apiservertesting.ErrUnauthorized)
+}
+
+func (s *uniterSuite) TestSetCharmEgressRules(c *gc.C) {
+	rules := []params.EgressRule{{
+		PortRange:        params.PortRange{FromPort: 443, ToPort: 443, Protocol: "tcp"},
+		DestinationCIDRs: []string{"10.0.0.0/8"},
+	}}
+	args := params.EntitiesEgressRules{Entities: []params.EntityEgressRules{
+		{Tag: "unit-wordpress-0", Rules: rules},
+		{Tag: "unit-mysql-0", Rules: rules},
+		{Tag: "application-wordpress", Rules: rules},
+	}}
+
+	// Only the leader may set the charm's egress rules.
+	result, err := s.uniter.SetCharmEgressRules(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results[0].Error, jc.DeepEquals, apiservertesting.ErrUnauthorized)
+
+	err = s.State.LeadershipClaimer().ClaimLeadership("wordpress", "wordpress/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	result, err = s.uniter.SetCharmEgressRules(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	err = s.wordpress.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.wordpress.CharmEgressRules(), jc.DeepEquals, []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+	})
+	c.Assert(s.wordpress.EgressRules(), gc.IsNil)
+}
+
 func (s *uniterSuite) TestReadSettings(c *gc.C) {
 	rel := s.addRelation(c, "wordpress", "mysql")
 	relUnit, err := rel.Unit(s.wordpressUnit)
@@ -1877,6 +2096,7 @@ func (s *uniterSuite) TestWatchRelationUnits(c *gc.C) {
 		Changed: map[string]params.UnitSettings{
 			"mysql/0": params.UnitSettings{changed.Version},
 		},
+		AppChanged: map[string]int64{"mysql": 0},
 	}
 	c.Assert(result, gc.DeepEquals, params.RelationUnitsWatchResults{
 		Results: []params.RelationUnitsWatchResult{
@@ -2270,7 +2490,7 @@ func (s *uniterSuite) TestAllMachinePorts(c *gc.C) {
 type unitMetricBatchesSuite struct {
 	uniterSuite
 	*commontesting.ModelWatcherTest
-	uniter *uniter.UniterAPIV3
+	uniter *uniter.UniterAPI
 }
 
 var _ = gc.Suite(&unitMetricBatchesSuite{})
@@ -2282,7 +2502,7 @@ func (s *unitMetricBatchesSuite) SetUpTest(c *gc.C) {
 		Tag: s.meteredUnit.Tag(),
 	}
 	var err error
-	s.uniter, err = uniter.NewUniterAPIV4(
+	s.uniter, err = uniter.NewUniterAPI(
 		s.State,
 		s.resources,
 		meteredAuthorizer,
@@ -2552,7 +2772,7 @@ func (s *uniterNetworkConfigSuite) setupUniterAPIForUnit(c *gc.C, givenUnit *sta
 	}
 
 	var err error
-	s.base.uniter, err = uniter.NewUniterAPIV4(
+	s.base.uniter, err = uniter.NewUniterAPI(
 		s.base.State,
 		s.base.resources,
 		s.base.authorizer,
@@ -2656,3 +2876,200 @@ func (s *uniterNetworkConfigSuite) TestNetworkConfigForImplicitlyBoundEndpoint(c
 		},
 	})
 }
+
+func (s *uniterSuite) TestCharmState(c *gc.C) {
+	setArgs := params.SetCharmStateArgs{Args: []params.SetCharmStateArg{
+		{Tag: "unit-wordpress-0", State: map[string]string{"foo": "bar"}},
+		{Tag: "unit-mysql-0", State: map[string]string{"foo": "bar"}},
+		{Tag: "application-wordpress", State: map[string]string{"foo": "bar"}},
+	}}
+	setResult, err := s.uniter.SetCharmState(setArgs)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(setResult, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-wordpress-0"},
+		{Tag: "unit-mysql-0"},
+	}}
+	result, err := s.uniter.CharmState(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.CharmStateResults{
+		Results: []params.CharmStateResult{
+			{State: map[string]string{"foo": "bar"}},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
+func (s *uniterSuite) TestRecordHookExecutions(c *gc.C) {
+	started := time.Date(2017, time.June, 1, 10, 0, 0, 0, time.UTC)
+	execution := params.HookExecution{
+		Kind:     "hook",
+		Name:     "config-changed",
+		Started:  started,
+		Finished: started.Add(time.Minute),
+		ExitCode: 1,
+		Error:    "exit status 1",
+		ToolCalls: []params.HookToolCall{{
+			Name:     "config-get",
+			Started:  started.Add(time.Second),
+			Duration: 10 * time.Millisecond,
+		}},
+	}
+	args := params.RecordHookExecutionArgs{Args: []params.RecordHookExecutionArg{
+		{Tag: "unit-wordpress-0", Execution: execution},
+		{Tag: "unit-mysql-0", Execution: execution},
+		{Tag: "application-wordpress", Execution: execution},
+	}}
+	result, err := s.uniter.RecordHookExecutions(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	executions, err := s.wordpressUnit.HookExecutions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(executions, jc.DeepEquals, []state.HookExecution{{
+		Kind:     "hook",
+		Name:     "config-changed",
+		Started:  started,
+		Finished: started.Add(time.Minute),
+		ExitCode: 1,
+		Error:    "exit status 1",
+		ToolCalls: []state.HookToolCall{{
+			Name:     "config-get",
+			Started:  started.Add(time.Second),
+			Duration: 10 * time.Millisecond,
+		}},
+	}})
+}
+
+func (s *uniterSuite) TestCloudSpec(c *gc.C) {
+	result, err := s.uniter.CloudSpec()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.CloudSpecResult{
+		Error: apiservertesting.ErrUnauthorized,
+	})
+
+	err = s.wordpress.SetTrusted()
+	c.Assert(err, jc.ErrorIsNil)
+	result, err = s.uniter.CloudSpec()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Result, gc.NotNil)
+	c.Assert(result.Result.Type, gc.Equals, "dummy")
+	c.Assert(result.Result.Name, gc.Equals, "dummy")
+}
+
+func (s *uniterSuite) addSecret(c *gc.C, name string, grant bool) *state.Secret {
+	secret, err := s.State.AddSecret(state.SecretArgs{
+		Name:   name,
+		Values: map[string]string{"password": "sekrit"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	if grant {
+		err = secret.Grant("wordpress")
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	return secret
+}
+
+func (s *uniterSuite) TestSecretValues(c *gc.C) {
+	s.addSecret(c, "db-password", true)
+	s.addSecret(c, "other", false)
+
+	result, err := s.uniter.SecretValues(params.SecretNames{
+		Names: []string{"db-password", "other", "missing"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.SecretValuesResults{
+		Results: []params.SecretValuesResult{
+			{Revision: 1, Values: map[string]string{"password": "sekrit"}},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.NotFoundError(`secret "missing"`)},
+		},
+	})
+}
+
+func (s *uniterSuite) TestSecretRevisions(c *gc.C) {
+	secret := s.addSecret(c, "db-password", true)
+	s.addSecret(c, "other", false)
+	err := secret.Rotate(map[string]string{"password": "new"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.uniter.SecretRevisions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.SecretRevisionsResult{
+		Revisions: map[string]int{"db-password": 2},
+	})
+}
+
+func (s *uniterSuite) TestWatchSecrets(c *gc.C) {
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	result, err := s.uniter.WatchSecrets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.NotifyWatchResult{NotifyWatcherId: "1"})
+
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	resource := s.resources.Get("1")
+	defer statetesting.AssertStop(c, resource)
+
+	wc := statetesting.NewNotifyWatcherC(c, s.State, resource.(state.NotifyWatcher))
+	wc.AssertNoChange()
+	s.addSecret(c, "db-password", false)
+	wc.AssertOneChange()
+}
+
+func (s *uniterSuite) TestOlderVersionsMaskNewMethods(c *gc.C) {
+	// Each API in apis is one version newer than the last, and
+	// adds the corresponding methods in added.
+	apis := []interface{}{
+		&uniter.UniterAPIV4{},
+		&uniter.UniterAPIV5{},
+		&uniter.UniterAPIV6{},
+		&uniter.UniterAPIV7{},
+		&uniter.UniterAPIV8{},
+		&uniter.UniterAPIV9{},
+		&uniter.UniterAPIV10{},
+		&uniter.UniterAPIV11{},
+		&uniter.UniterAPIV12{},
+		&uniter.UniterAPI{},
+	}
+	added := [][]string{
+		nil,
+		{"ActionStatus"},
+		{"LogActionsMessages"},
+		{"GoalStates"},
+		{"ReadApplicationSettings", "UpdateApplicationSettings"},
+		{"CharmState", "SetCharmState"},
+		{"CloudSpec"},
+		{"SecretValues", "SecretRevisions", "WatchSecrets"},
+		{"RecordHookExecutions"},
+		{"SetCharmEgressRules"},
+	}
+	for i, api := range apis {
+		objType := rpcreflect.ObjTypeOf(reflect.TypeOf(api))
+		for j, methods := range added {
+			for _, name := range methods {
+				_, err := objType.Method(name)
+				comment := gc.Commentf("%s on version %d", name, i+4)
+				if j <= i {
+					c.Check(err, jc.ErrorIsNil, comment)
+				} else {
+					c.Check(err, gc.Equals, rpcreflect.ErrMethodNotFound, comment)
+				}
+			}
+		}
+	}
+}
@@ -16,6 +16,18 @@ import (
 // AuditEntry to a backing store and return an error upon failure.
 type AuditEntrySinkFn func(AuditEntry) error
 
+const (
+	// APIRequestOriginType is the origin type of entries recording
+	// API requests; the origin name is the tag of the entity that
+	// made the request.
+	APIRequestOriginType = "API request"
+
+	// UnitOriginType is the origin type of entries recording things
+	// done by the controller on behalf of a unit; the origin name is
+	// the unit's tag.
+	UnitOriginType = "unit"
+)
+
 // AuditEntry represents an auditted event.
 type AuditEntry struct {
 	// JujuServerVersion is the version of the jujud that recorded
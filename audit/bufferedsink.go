@@ -0,0 +1,145 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit
+
+import (
+	"sync"
+
+	"github.com/juju/errors"
+	"gopkg.in/tomb.v1"
+)
+
+// BufferedSinkConfig holds the parameters for a BufferedSink.
+type BufferedSinkConfig struct {
+	// Sink is the sink that buffered entries are delivered to.
+	Sink AuditEntrySinkFn
+
+	// BufferSize is the number of entries that may be queued
+	// before new entries are dropped.
+	BufferSize int
+
+	// ErrorHandler is called with any error returned by Sink.
+	ErrorHandler func(error)
+}
+
+// Validate checks that the config can be used to create a
+// BufferedSink.
+func (config BufferedSinkConfig) Validate() error {
+	if config.Sink == nil {
+		return errors.NotValidf("nil Sink")
+	}
+	if config.BufferSize <= 0 {
+		return errors.NotValidf("non-positive BufferSize")
+	}
+	if config.ErrorHandler == nil {
+		return errors.NotValidf("nil ErrorHandler")
+	}
+	return nil
+}
+
+// BufferedSink decouples the caller from a potentially slow sink.
+// Entries are queued and delivered from a separate goroutine, so
+// Handle never blocks; if the queue is full the entry is dropped
+// and an error is returned instead.
+type BufferedSink struct {
+	tomb    tomb.Tomb
+	config  BufferedSinkConfig
+	entries chan AuditEntry
+
+	// mu guards stopped, so that no entry can be queued once the
+	// remaining entries are being drained.
+	mu      sync.RWMutex
+	stopped bool
+}
+
+// NewBufferedSink starts delivering entries queued with Handle
+// to the configured sink. The returned BufferedSink is a worker;
+// when it is killed, the entries still queued are delivered before
+// it stops, unless delivering one of them fails.
+func NewBufferedSink(config BufferedSinkConfig) (*BufferedSink, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	s := &BufferedSink{
+		config:  config,
+		entries: make(chan AuditEntry, config.BufferSize),
+	}
+	go func() {
+		defer s.tomb.Done()
+		s.tomb.Kill(s.loop())
+	}()
+	return s, nil
+}
+
+// Handle is an AuditEntrySinkFn which queues the entry for
+// delivery.
+func (s *BufferedSink) Handle(entry AuditEntry) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.stopped {
+		return errors.New("audit sink stopped")
+	}
+	select {
+	case s.entries <- entry:
+		return nil
+	default:
+		return errors.Errorf("audit buffer full, dropping %q entry", entry.Operation)
+	}
+}
+
+// Kill is part of the worker.Worker interface.
+func (s *BufferedSink) Kill() {
+	s.tomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (s *BufferedSink) Wait() error {
+	return s.tomb.Wait()
+}
+
+func (s *BufferedSink) loop() error {
+	for {
+		// Check for stopping first, so that once killed the
+		// remaining entries are all handled by drain.
+		select {
+		case <-s.tomb.Dying():
+			s.drain()
+			return tomb.ErrDying
+		default:
+		}
+		select {
+		case <-s.tomb.Dying():
+			s.drain()
+			return tomb.ErrDying
+		case entry := <-s.entries:
+			if err := s.config.Sink(entry); err != nil {
+				s.config.ErrorHandler(errors.Trace(err))
+			}
+		}
+	}
+}
+
+// drain stops further entries being queued, and delivers those still
+// queued. If delivery fails the sink is assumed to be unavailable,
+// and the remaining entries are dropped rather than holding up the
+// shutdown of the caller.
+func (s *BufferedSink) drain() {
+	s.mu.Lock()
+	s.stopped = true
+	s.mu.Unlock()
+	for {
+		select {
+		case entry := <-s.entries:
+			if err := s.config.Sink(entry); err != nil {
+				s.config.ErrorHandler(errors.Trace(err))
+				if dropped := len(s.entries); dropped > 0 {
+					s.config.ErrorHandler(errors.Errorf("audit sink stopped, dropping %d queued entries", dropped))
+				}
+				return
+			}
+		default:
+			return
+		}
+	}
+}
@@ -0,0 +1,200 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/audit"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type bufferedSinkSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&bufferedSinkSuite{})
+
+var _ worker.Worker = (*audit.BufferedSink)(nil)
+
+func (s *bufferedSinkSuite) TestValidate(c *gc.C) {
+	valid := audit.BufferedSinkConfig{
+		Sink:         func(audit.AuditEntry) error { return nil },
+		BufferSize:   1,
+		ErrorHandler: func(error) {},
+	}
+	c.Assert(valid.Validate(), jc.ErrorIsNil)
+
+	config := valid
+	config.Sink = nil
+	c.Check(config.Validate(), gc.ErrorMatches, "nil Sink not valid")
+
+	config = valid
+	config.BufferSize = 0
+	c.Check(config.Validate(), gc.ErrorMatches, "non-positive BufferSize not valid")
+
+	config = valid
+	config.ErrorHandler = nil
+	c.Check(config.Validate(), gc.ErrorMatches, "nil ErrorHandler not valid")
+}
+
+func (s *bufferedSinkSuite) TestDelivers(c *gc.C) {
+	delivered := make(chan audit.AuditEntry)
+	errs := make(chan error, 1)
+	sink, err := audit.NewBufferedSink(audit.BufferedSinkConfig{
+		Sink: func(entry audit.AuditEntry) error {
+			delivered <- entry
+			return errors.New("boom")
+		},
+		BufferSize:   1,
+		ErrorHandler: func(err error) { errs <- err },
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer worker.Stop(sink)
+
+	entry := validEntry()
+	err = sink.Handle(entry)
+	c.Assert(err, jc.ErrorIsNil)
+
+	select {
+	case got := <-delivered:
+		c.Assert(got, jc.DeepEquals, entry)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("entry not delivered")
+	}
+	select {
+	case err := <-errs:
+		c.Assert(err, gc.ErrorMatches, "boom")
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("error not reported")
+	}
+}
+
+func (s *bufferedSinkSuite) TestDropsWhenFull(c *gc.C) {
+	block := make(chan struct{})
+	started := make(chan struct{}, 1)
+	sink, err := audit.NewBufferedSink(audit.BufferedSinkConfig{
+		Sink: func(audit.AuditEntry) error {
+			started <- struct{}{}
+			<-block
+			return nil
+		},
+		BufferSize:   1,
+		ErrorHandler: func(error) {},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer worker.Stop(sink)
+	defer close(block)
+
+	// The first entry is taken by the delivery goroutine, which
+	// then blocks; the second fills the buffer.
+	c.Assert(sink.Handle(validEntry()), jc.ErrorIsNil)
+	select {
+	case <-started:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("delivery not started")
+	}
+	c.Assert(sink.Handle(validEntry()), jc.ErrorIsNil)
+
+	entry := validEntry()
+	entry.Operation = "dropped"
+	err = sink.Handle(entry)
+	c.Assert(err, gc.ErrorMatches, `audit buffer full, dropping "dropped" entry`)
+}
+
+func (s *bufferedSinkSuite) TestHandleAfterStop(c *gc.C) {
+	sink, err := audit.NewBufferedSink(audit.BufferedSinkConfig{
+		Sink:         func(audit.AuditEntry) error { return nil },
+		BufferSize:   1,
+		ErrorHandler: func(error) {},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = worker.Stop(sink)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = sink.Handle(validEntry())
+	c.Assert(err, gc.ErrorMatches, "audit sink stopped")
+}
+
+func (s *bufferedSinkSuite) TestDrainsOnStop(c *gc.C) {
+	block := make(chan struct{})
+	started := make(chan struct{}, 1)
+	var delivered []string
+	sink, err := audit.NewBufferedSink(audit.BufferedSinkConfig{
+		Sink: func(entry audit.AuditEntry) error {
+			select {
+			case started <- struct{}{}:
+				<-block
+			default:
+			}
+			delivered = append(delivered, entry.Operation)
+			return nil
+		},
+		BufferSize:   2,
+		ErrorHandler: func(err error) { c.Errorf("unexpected error: %v", err) },
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	for _, operation := range []string{"first", "second", "third"} {
+		entry := validEntry()
+		entry.Operation = operation
+		c.Assert(sink.Handle(entry), jc.ErrorIsNil)
+		if operation == "first" {
+			select {
+			case <-started:
+			case <-time.After(coretesting.LongWait):
+				c.Fatalf("delivery not started")
+			}
+		}
+	}
+	sink.Kill()
+	close(block)
+	c.Assert(sink.Wait(), jc.ErrorIsNil)
+	c.Assert(delivered, jc.DeepEquals, []string{"first", "second", "third"})
+}
+
+func (s *bufferedSinkSuite) TestDrainStopsOnError(c *gc.C) {
+	block := make(chan struct{})
+	started := make(chan struct{}, 1)
+	var errs []string
+	sink, err := audit.NewBufferedSink(audit.BufferedSinkConfig{
+		Sink: func(entry audit.AuditEntry) error {
+			if entry.Operation == "first" {
+				started <- struct{}{}
+				<-block
+				return nil
+			}
+			return errors.New("unreachable")
+		},
+		BufferSize:   3,
+		ErrorHandler: func(err error) { errs = append(errs, err.Error()) },
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	for _, operation := range []string{"first", "second", "third", "fourth"} {
+		entry := validEntry()
+		entry.Operation = operation
+		c.Assert(sink.Handle(entry), jc.ErrorIsNil)
+		if operation == "first" {
+			select {
+			case <-started:
+			case <-time.After(coretesting.LongWait):
+				c.Fatalf("delivery not started")
+			}
+		}
+	}
+	sink.Kill()
+	close(block)
+	c.Assert(sink.Wait(), jc.ErrorIsNil)
+	c.Assert(errs, jc.DeepEquals, []string{
+		"unreachable",
+		"audit sink stopped, dropping 2 queued entries",
+	})
+}
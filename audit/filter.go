@@ -0,0 +1,62 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit
+
+import (
+	"strings"
+)
+
+// Filter describes the audit entries a sink is interested in. An
+// empty field matches every entry; a non-empty field matches an
+// entry if any of its values match.
+type Filter struct {
+	// Operations holds operation prefixes to match, e.g.
+	// "Application:" or "Application:v4 - Deploy".
+	Operations []string
+
+	// OriginTypes holds the origin types to match exactly.
+	OriginTypes []string
+
+	// ModelUUIDs holds the model UUIDs to match exactly.
+	ModelUUIDs []string
+}
+
+// Match returns whether the entry is selected by the filter.
+func (f Filter) Match(entry AuditEntry) bool {
+	if len(f.Operations) > 0 && !matchAny(f.Operations, entry.Operation, strings.HasPrefix) {
+		return false
+	}
+	if len(f.OriginTypes) > 0 && !matchAny(f.OriginTypes, entry.OriginType, equals) {
+		return false
+	}
+	if len(f.ModelUUIDs) > 0 && !matchAny(f.ModelUUIDs, entry.ModelUUID, equals) {
+		return false
+	}
+	return true
+}
+
+// NewFilteredSink returns an audit entry sink which passes the
+// entries matched by the filter on to the given sink, and silently
+// discards everything else.
+func NewFilteredSink(filter Filter, sink AuditEntrySinkFn) AuditEntrySinkFn {
+	return func(entry AuditEntry) error {
+		if !filter.Match(entry) {
+			return nil
+		}
+		return sink(entry)
+	}
+}
+
+func matchAny(patterns []string, value string, match func(string, string) bool) bool {
+	for _, pattern := range patterns {
+		if match(value, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+func equals(a, b string) bool {
+	return a == b
+}
@@ -0,0 +1,88 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/audit"
+)
+
+type filterSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&filterSuite{})
+
+func (s *filterSuite) TestEmptyFilterMatchesEverything(c *gc.C) {
+	c.Assert(audit.Filter{}.Match(validEntry()), jc.IsTrue)
+}
+
+func (s *filterSuite) TestMatchOperationPrefix(c *gc.C) {
+	entry := validEntry()
+	entry.Operation = "Application:v4 - Deploy"
+
+	filter := audit.Filter{Operations: []string{"Client:", "Application:"}}
+	c.Assert(filter.Match(entry), jc.IsTrue)
+
+	filter = audit.Filter{Operations: []string{"Client:"}}
+	c.Assert(filter.Match(entry), jc.IsFalse)
+}
+
+func (s *filterSuite) TestMatchOriginType(c *gc.C) {
+	entry := validEntry()
+	entry.OriginType = "API request"
+
+	filter := audit.Filter{OriginTypes: []string{"API request"}}
+	c.Assert(filter.Match(entry), jc.IsTrue)
+
+	filter = audit.Filter{OriginTypes: []string{"API"}}
+	c.Assert(filter.Match(entry), jc.IsFalse)
+}
+
+func (s *filterSuite) TestMatchModelUUID(c *gc.C) {
+	entry := validEntry()
+
+	filter := audit.Filter{ModelUUIDs: []string{"other", entry.ModelUUID}}
+	c.Assert(filter.Match(entry), jc.IsTrue)
+
+	filter = audit.Filter{ModelUUIDs: []string{"other"}}
+	c.Assert(filter.Match(entry), jc.IsFalse)
+}
+
+func (s *filterSuite) TestAllFieldsMustMatch(c *gc.C) {
+	entry := validEntry()
+	entry.Operation = "Application:v4 - Deploy"
+
+	filter := audit.Filter{
+		Operations: []string{"Application:"},
+		ModelUUIDs: []string{"other"},
+	}
+	c.Assert(filter.Match(entry), jc.IsFalse)
+}
+
+func (s *filterSuite) TestFilteredSink(c *gc.C) {
+	var received []audit.AuditEntry
+	sink := audit.NewFilteredSink(
+		audit.Filter{Operations: []string{"Application:"}},
+		func(entry audit.AuditEntry) error {
+			received = append(received, entry)
+			return errors.New("boom")
+		},
+	)
+
+	ignored := validEntry()
+	ignored.Operation = "Client:v1 - FullStatus"
+	err := sink(ignored)
+	c.Assert(err, jc.ErrorIsNil)
+
+	matched := validEntry()
+	matched.Operation = "Application:v4 - Deploy"
+	err = sink(matched)
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(received, jc.DeepEquals, []audit.AuditEntry{matched})
+}
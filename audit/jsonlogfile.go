@@ -0,0 +1,70 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit
+
+import (
+	"encoding/json"
+	"io"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// jsonEntry is the serialised form of an AuditEntry written by
+// the JSON sinks.
+type jsonEntry struct {
+	JujuServerVersion string                 `json:"juju-server-version"`
+	ModelUUID         string                 `json:"model-uuid"`
+	Timestamp         string                 `json:"timestamp"`
+	RemoteAddress     string                 `json:"remote-address"`
+	OriginType        string                 `json:"origin-type"`
+	OriginName        string                 `json:"origin-name"`
+	Operation         string                 `json:"operation"`
+	Data              map[string]interface{} `json:"data,omitempty"`
+}
+
+func marshalJSONEntry(entry AuditEntry) ([]byte, error) {
+	data, err := json.Marshal(jsonEntry{
+		JujuServerVersion: entry.JujuServerVersion.String(),
+		ModelUUID:         entry.ModelUUID,
+		Timestamp:         entry.Timestamp.In(time.UTC).Format(time.RFC3339Nano),
+		RemoteAddress:     entry.RemoteAddress,
+		OriginType:        entry.OriginType,
+		OriginName:        entry.OriginName,
+		Operation:         entry.Operation,
+		Data:              entry.Data,
+	})
+	return data, errors.Trace(err)
+}
+
+// NewJSONLogFileSink returns an audit entry sink which writes one
+// JSON document per line to an audit.json file in the specified
+// directory. The file is rotated once it reaches maxSizeMB, keeping
+// at most maxBackups old files.
+func NewJSONLogFileSink(logDir string, maxSizeMB, maxBackups int) AuditEntrySinkFn {
+	logPath := filepath.Join(logDir, "audit.json")
+	if err := primeLogFile(logPath); err != nil {
+		// This isn't a fatal error so log and continue if priming
+		// fails.
+		logger.Errorf("Unable to prime %s (proceeding anyway): %v", logPath, err)
+	}
+	return newJSONWriterSink(&lumberjack.Logger{
+		Filename:   logPath,
+		MaxSize:    maxSizeMB,
+		MaxBackups: maxBackups,
+	})
+}
+
+func newJSONWriterSink(w io.Writer) AuditEntrySinkFn {
+	return func(entry AuditEntry) error {
+		data, err := marshalJSONEntry(entry)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		_, err = w.Write(append(data, '\n'))
+		return errors.Trace(err)
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/audit"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type jsonLogFileSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&jsonLogFileSuite{})
+
+func (s *jsonLogFileSuite) TestLogging(c *gc.C) {
+	dir := c.MkDir()
+	sink := audit.NewJSONLogFileSink(dir, 10, 1)
+
+	modelUUID := coretesting.ModelTag.Id()
+	err := sink(audit.AuditEntry{
+		JujuServerVersion: version.MustParse("2.2.0"),
+		Timestamp:         time.Date(2017, time.June, 1, 23, 2, 1, 0, time.UTC),
+		ModelUUID:         modelUUID,
+		RemoteAddress:     "10.0.0.1",
+		OriginType:        "API request",
+		OriginName:        "user-admin",
+		Operation:         "Application:v4 - Deploy",
+		Data:              map[string]interface{}{"foo": "bar"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = sink(audit.AuditEntry{
+		JujuServerVersion: version.MustParse("2.2.0"),
+		Timestamp:         time.Date(2017, time.June, 1, 23, 2, 2, 0, time.UTC),
+		ModelUUID:         modelUUID,
+		RemoteAddress:     "10.0.0.2",
+		OriginType:        "API request",
+		OriginName:        "user-admin",
+		Operation:         "Client:v1 - FullStatus",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	logContents, err := ioutil.ReadFile(filepath.Join(dir, "audit.json"))
+	c.Assert(err, jc.ErrorIsNil)
+	line0 := `{"juju-server-version":"2.2.0","model-uuid":"` + modelUUID + `",` +
+		`"timestamp":"2017-06-01T23:02:01Z","remote-address":"10.0.0.1",` +
+		`"origin-type":"API request","origin-name":"user-admin",` +
+		`"operation":"Application:v4 - Deploy","data":{"foo":"bar"}}` + "\n"
+	line1 := `{"juju-server-version":"2.2.0","model-uuid":"` + modelUUID + `",` +
+		`"timestamp":"2017-06-01T23:02:02Z","remote-address":"10.0.0.2",` +
+		`"origin-type":"API request","origin-name":"user-admin",` +
+		`"operation":"Client:v1 - FullStatus"}` + "\n"
+	c.Assert(string(logContents), gc.Equals, line0+line1)
+}
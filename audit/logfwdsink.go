@@ -0,0 +1,73 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/logfwd"
+)
+
+// RecordSender sends log records to a remote destination. The
+// syslog client in logfwd/syslog is a RecordSender.
+type RecordSender interface {
+	Send([]logfwd.Record) error
+}
+
+// NewLogForwardSink returns an audit entry sink which converts each
+// entry into a log record and sends it using the given sender. The
+// record's message is the entry serialised as JSON.
+func NewLogForwardSink(sender RecordSender, controllerUUID string) AuditEntrySinkFn {
+	return func(entry AuditEntry) error {
+		rec, err := recordFromEntry(entry, controllerUUID)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return errors.Trace(sender.Send([]logfwd.Record{rec}))
+	}
+}
+
+func recordFromEntry(entry AuditEntry, controllerUUID string) (logfwd.Record, error) {
+	msg, err := marshalJSONEntry(entry)
+	if err != nil {
+		return logfwd.Record{}, errors.Trace(err)
+	}
+	origin := logfwd.Origin{
+		ControllerUUID: controllerUUID,
+		ModelUUID:      entry.ModelUUID,
+		Type:           logfwd.OriginTypeUnknown,
+	}
+	if tag, err := names.ParseTag(entry.OriginName); err == nil {
+		if o, err := logfwd.OriginForJuju(tag, controllerUUID, entry.ModelUUID, entry.JujuServerVersion); err == nil {
+			origin = o
+		}
+	}
+	// The syslog client derives the app name from the software
+	// name and model UUID, and needs it to be at least 48
+	// characters long.
+	origin.Software = logfwd.Software{
+		PrivateEnterpriseNumber: canonicalPEN,
+		Name:                    "jujud-controller-audit",
+		Version:                 entry.JujuServerVersion,
+	}
+	rec := logfwd.Record{
+		Origin:    origin,
+		Timestamp: entry.Timestamp,
+		Level:     loggo.INFO,
+		Location: logfwd.SourceLocation{
+			Module: "juju.audit",
+		},
+		Message: string(msg),
+	}
+	if err := rec.Validate(); err != nil {
+		return logfwd.Record{}, errors.Trace(err)
+	}
+	return rec, nil
+}
+
+// canonicalPEN is the IANA-registered private enterprise number
+// assigned to Canonical.
+const canonicalPEN = 28978
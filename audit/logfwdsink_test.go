@@ -0,0 +1,61 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit_test
+
+import (
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/logfwd"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type logForwardSinkSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&logForwardSinkSuite{})
+
+type recordingSender struct {
+	records []logfwd.Record
+}
+
+func (s *recordingSender) Send(records []logfwd.Record) error {
+	s.records = append(s.records, records...)
+	return nil
+}
+
+func (s *logForwardSinkSuite) TestSendsRecord(c *gc.C) {
+	var sender recordingSender
+	sink := audit.NewLogForwardSink(&sender, coretesting.ControllerTag.Id())
+
+	entry := validEntry()
+	entry.OriginName = "user-admin"
+	err := sink(entry)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(sender.records, gc.HasLen, 1)
+	rec := sender.records[0]
+	c.Check(rec.Origin.ControllerUUID, gc.Equals, coretesting.ControllerTag.Id())
+	c.Check(rec.Origin.ModelUUID, gc.Equals, entry.ModelUUID)
+	c.Check(rec.Origin.Type, gc.Equals, logfwd.OriginType(logfwd.OriginTypeUser))
+	c.Check(rec.Origin.Name, gc.Equals, "admin")
+	c.Check(rec.Timestamp, gc.Equals, entry.Timestamp)
+	c.Check(rec.Level, gc.Equals, loggo.INFO)
+	c.Check(rec.Message, jc.Contains, `"operation":"."`)
+}
+
+func (s *logForwardSinkSuite) TestUnknownOrigin(c *gc.C) {
+	var sender recordingSender
+	sink := audit.NewLogForwardSink(&sender, coretesting.ControllerTag.Id())
+
+	err := sink(validEntry())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sender.records, gc.HasLen, 1)
+	c.Check(sender.records[0].Origin.Type, gc.Equals, logfwd.OriginTypeUnknown)
+	c.Check(sender.records[0].Origin.Name, gc.Equals, "")
+}
@@ -0,0 +1,35 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// NewMultiSink returns an audit entry sink which sends each entry
+// to every one of the given sinks. All sinks are tried even if one
+// of them fails; the returned error reports every failure.
+func NewMultiSink(sinks ...AuditEntrySinkFn) AuditEntrySinkFn {
+	return func(entry AuditEntry) error {
+		var errs []error
+		for _, sink := range sinks {
+			if err := sink(entry); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		switch len(errs) {
+		case 0:
+			return nil
+		case 1:
+			return errs[0]
+		}
+		messages := make([]string, len(errs))
+		for i, err := range errs {
+			messages[i] = err.Error()
+		}
+		return errors.Errorf("%d audit sinks failed: %s", len(errs), strings.Join(messages, "; "))
+	}
+}
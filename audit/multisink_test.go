@@ -0,0 +1,63 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/audit"
+)
+
+type multiSinkSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&multiSinkSuite{})
+
+func (s *multiSinkSuite) TestSendsToAllSinks(c *gc.C) {
+	var calls []string
+	sink := audit.NewMultiSink(
+		func(audit.AuditEntry) error {
+			calls = append(calls, "a")
+			return nil
+		},
+		func(audit.AuditEntry) error {
+			calls = append(calls, "b")
+			return nil
+		},
+	)
+	err := sink(validEntry())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(calls, jc.DeepEquals, []string{"a", "b"})
+}
+
+func (s *multiSinkSuite) TestSingleFailure(c *gc.C) {
+	var calls []string
+	sink := audit.NewMultiSink(
+		func(audit.AuditEntry) error {
+			calls = append(calls, "a")
+			return errors.NotFoundf("a")
+		},
+		func(audit.AuditEntry) error {
+			calls = append(calls, "b")
+			return nil
+		},
+	)
+	err := sink(validEntry())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(calls, jc.DeepEquals, []string{"a", "b"})
+}
+
+func (s *multiSinkSuite) TestMultipleFailures(c *gc.C) {
+	sink := audit.NewMultiSink(
+		func(audit.AuditEntry) error { return errors.New("a failed") },
+		func(audit.AuditEntry) error { return nil },
+		func(audit.AuditEntry) error { return errors.New("c failed") },
+	)
+	err := sink(validEntry())
+	c.Assert(err, gc.ErrorMatches, "2 audit sinks failed: a failed; c failed")
+}
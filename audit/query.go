@@ -0,0 +1,72 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit
+
+import (
+	"net"
+	"strings"
+	"time"
+)
+
+// Query describes a selection of audit entries. Empty fields match
+// every entry.
+type Query struct {
+	// OriginName selects entries triggered by the named origin,
+	// e.g. "user-bob".
+	OriginName string
+
+	// ModelUUID selects entries recorded on the given model.
+	ModelUUID string
+
+	// Operation selects entries whose operation starts with the
+	// given prefix, e.g. "Application:".
+	Operation string
+
+	// RemoteAddress selects entries triggered from the given
+	// address. It matches either the full address or just its host
+	// part, so "10.0.0.1" matches "10.0.0.1:35624".
+	RemoteAddress string
+
+	// From selects entries recorded at or after the given time.
+	From time.Time
+
+	// To selects entries recorded before the given time.
+	To time.Time
+
+	// Limit, if positive, restricts the selection to the most
+	// recent Limit matching entries.
+	Limit int
+}
+
+// Match returns whether the entry is selected by the query. Limit
+// is not taken into account.
+func (q Query) Match(entry AuditEntry) bool {
+	if q.OriginName != "" && entry.OriginName != q.OriginName {
+		return false
+	}
+	if q.ModelUUID != "" && entry.ModelUUID != q.ModelUUID {
+		return false
+	}
+	if !strings.HasPrefix(entry.Operation, q.Operation) {
+		return false
+	}
+	if q.RemoteAddress != "" && !matchAddress(q.RemoteAddress, entry.RemoteAddress) {
+		return false
+	}
+	if !q.From.IsZero() && entry.Timestamp.Before(q.From) {
+		return false
+	}
+	if !q.To.IsZero() && !entry.Timestamp.Before(q.To) {
+		return false
+	}
+	return true
+}
+
+func matchAddress(want, addr string) bool {
+	if addr == want {
+		return true
+	}
+	host, _, err := net.SplitHostPort(addr)
+	return err == nil && host == want
+}
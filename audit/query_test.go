@@ -0,0 +1,56 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/audit"
+)
+
+type querySuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&querySuite{})
+
+func (s *querySuite) TestEmptyQueryMatchesEverything(c *gc.C) {
+	c.Assert(audit.Query{}.Match(validEntry()), jc.IsTrue)
+}
+
+func (s *querySuite) TestMatch(c *gc.C) {
+	t0 := time.Date(2017, time.June, 1, 12, 0, 0, 0, time.UTC)
+	entry := validEntry()
+	entry.OriginName = "user-bob"
+	entry.Operation = "Application:v4 - Deploy"
+	entry.RemoteAddress = "10.0.0.1:35624"
+	entry.Timestamp = t0
+
+	for i, test := range []struct {
+		query audit.Query
+		match bool
+	}{
+		{audit.Query{OriginName: "user-bob"}, true},
+		{audit.Query{OriginName: "user-mary"}, false},
+		{audit.Query{ModelUUID: entry.ModelUUID}, true},
+		{audit.Query{ModelUUID: "other"}, false},
+		{audit.Query{Operation: "Application:"}, true},
+		{audit.Query{Operation: "Client:"}, false},
+		{audit.Query{RemoteAddress: "10.0.0.1:35624"}, true},
+		{audit.Query{RemoteAddress: "10.0.0.1"}, true},
+		{audit.Query{RemoteAddress: "10.0.0.12"}, false},
+		{audit.Query{From: t0}, true},
+		{audit.Query{From: t0.Add(time.Nanosecond)}, false},
+		{audit.Query{To: t0.Add(time.Nanosecond)}, true},
+		{audit.Query{To: t0}, false},
+		{audit.Query{OriginName: "user-bob", Operation: "Client:"}, false},
+	} {
+		c.Logf("test %d: %+v", i, test.query)
+		c.Check(test.query.Match(entry), gc.Equals, test.match)
+	}
+}
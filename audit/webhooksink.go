@@ -0,0 +1,36 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/juju/errors"
+)
+
+// NewWebhookSink returns an audit entry sink which POSTs each entry
+// as a JSON document to the given URL. Any response other than a
+// 2xx status is treated as a failure.
+func NewWebhookSink(client *http.Client, url string) AuditEntrySinkFn {
+	return func(entry AuditEntry) error {
+		data, err := marshalJSONEntry(entry)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		resp, err := client.Post(url, "application/json", bytes.NewReader(data))
+		if err != nil {
+			return errors.Annotate(err, "sending audit entry")
+		}
+		defer resp.Body.Close()
+		// Drain the body so the connection can be reused.
+		io.Copy(ioutil.Discard, resp.Body)
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return errors.Errorf("sending audit entry: unexpected response %q", resp.Status)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/audit"
+)
+
+type webhookSinkSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&webhookSinkSuite{})
+
+func (s *webhookSinkSuite) TestPostsEntry(c *gc.C) {
+	var received map[string]interface{}
+	var contentType string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		contentType = req.Header.Get("Content-Type")
+		body, err := ioutil.ReadAll(req.Body)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(json.Unmarshal(body, &received), jc.ErrorIsNil)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	entry := validEntry()
+	sink := audit.NewWebhookSink(http.DefaultClient, srv.URL)
+	err := sink(entry)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(contentType, gc.Equals, "application/json")
+	c.Assert(received["model-uuid"], gc.Equals, entry.ModelUUID)
+	c.Assert(received["remote-address"], gc.Equals, entry.RemoteAddress)
+	c.Assert(received["operation"], gc.Equals, entry.Operation)
+}
+
+func (s *webhookSinkSuite) TestErrorStatus(c *gc.C) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		http.Error(w, "nope", http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	sink := audit.NewWebhookSink(http.DefaultClient, srv.URL)
+	err := sink(validEntry())
+	c.Assert(err, gc.ErrorMatches, `sending audit entry: unexpected response "503 Service Unavailable"`)
+}
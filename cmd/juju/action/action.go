@@ -38,8 +38,9 @@ type APIClient interface {
 	// Entities.
 	ListCompleted(params.Entities) (params.ActionsByReceivers, error)
 
-	// Cancel attempts to cancel a queued up Action from running.
-	Cancel(params.Actions) (params.ActionResults, error)
+	// Cancel attempts to cancel the Actions with the given tags. Queued
+	// Actions are removed from the queue, and running Actions are stopped.
+	Cancel(params.Entities) (params.ActionResults, error)
 
 	// ApplicationCharmActions is a single query which uses ApplicationsCharmsActions to
 	// get the charm.Actions for a single Service by tag.
@@ -56,6 +57,18 @@ type APIClient interface {
 	// FindActionsByNames takes a list of names and finds a corresponding list of
 	// Actions for every name.
 	FindActionsByNames(params.FindActionsByNames) (params.ActionsByNames, error)
+
+	// AddSchedules adds schedules on which Actions are enqueued.
+	AddSchedules(params.ActionSchedules) (params.ErrorResults, error)
+
+	// ListSchedules returns all of the action schedules in the model.
+	ListSchedules() (params.ActionSchedules, error)
+
+	// SetSchedulesEnabled enables or disables action schedules.
+	SetSchedulesEnabled(params.ActionSchedulesEnabled) (params.ErrorResults, error)
+
+	// RemoveSchedules removes action schedules.
+	RemoveSchedules(params.ActionScheduleNames) (params.ErrorResults, error)
 }
 
 // ActionCommandBase is the base type for action sub-commands.
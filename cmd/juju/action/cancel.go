@@ -0,0 +1,97 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+func NewCancelCommand() cmd.Command {
+	return modelcmd.Wrap(&cancelCommand{})
+}
+
+// cancelCommand cancels pending or running Actions by ID.
+type cancelCommand struct {
+	ActionCommandBase
+	out          cmd.Output
+	requestedIds []string
+}
+
+const cancelDoc = `
+Cancel the Actions matching the given IDs or partial ID prefixes. Each
+prefix must match exactly one Action.
+
+Actions that have not yet started are removed from the queue and marked
+as cancelled. Actions that are running are marked as aborting, and are
+stopped by the unit agent; once stopped they are marked as cancelled.
+Actions that have already finished cannot be cancelled.
+
+Examples:
+    juju cancel-action 42ac36fa
+    juju cancel-action 42ac36fa 8ba6f21c
+`
+
+// SetFlags sets up the output.
+func (c *cancelCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ActionCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
+}
+
+func (c *cancelCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "cancel-action",
+		Args:    "<action ID>|<action ID prefix> [...]",
+		Purpose: "Cancel pending or running actions.",
+		Doc:     cancelDoc,
+	}
+}
+
+func (c *cancelCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no action ID specified")
+	}
+	c.requestedIds = args
+	return nil
+}
+
+func (c *cancelCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	entities := make([]params.Entity, len(c.requestedIds))
+	for i, id := range c.requestedIds {
+		tag, err := getActionTagByPrefix(api, id)
+		if err != nil {
+			return err
+		}
+		entities[i] = params.Entity{Tag: tag.String()}
+	}
+
+	results, err := api.Cancel(params.Entities{Entities: entities})
+	if err != nil {
+		return err
+	}
+	if len(results.Results) != len(entities) {
+		return errors.Errorf("expected %d results, got %d", len(entities), len(results.Results))
+	}
+
+	if err := c.out.Write(ctx, resultsToMap(results.Results)); err != nil {
+		return errors.Trace(err)
+	}
+	for _, result := range results.Results {
+		if result.Error != nil {
+			return cmd.ErrSilent
+		}
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"bytes"
+
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/action"
+	"github.com/juju/juju/testing"
+)
+
+type CancelSuite struct {
+	BaseActionSuite
+}
+
+var _ = gc.Suite(&CancelSuite{})
+
+func (s *CancelSuite) TestInit(c *gc.C) {
+	wrapped, _ := action.NewCancelCommandForTest(s.store)
+	err := testing.InitCommand(wrapped, []string{"-m", "admin"})
+	c.Assert(err, gc.ErrorMatches, "no action ID specified")
+
+	wrapped, cancelCmd := action.NewCancelCommandForTest(s.store)
+	err = testing.InitCommand(wrapped, []string{"-m", "admin", "deadbeef", "feedface"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cancelCmd.RequestedIds(), jc.DeepEquals, []string{"deadbeef", "feedface"})
+}
+
+func (s *CancelSuite) TestRun(c *gc.C) {
+	tag1 := "action-deadbeef-0000-4000-8000-feedfacebeef"
+	tag2 := "action-feedface-0000-4000-8000-feedfacebeef"
+	results := []params.ActionResult{{
+		Action: &params.Action{Tag: tag1, Receiver: "unit-mysql-0"},
+		Status: params.ActionCancelled,
+	}, {
+		Action: &params.Action{Tag: tag2, Receiver: "unit-mysql-1"},
+		Status: params.ActionAborting,
+	}}
+	client := &fakeAPIClient{
+		actionTagMatches: params.FindTagsResults{Matches: map[string][]params.Entity{
+			"deadbeef": {{Tag: tag1}},
+			"feedface": {{Tag: tag2}},
+		}},
+		actionResults: results,
+	}
+	restore := s.patchAPIClient(client)
+	defer restore()
+
+	cancelCmd, _ := action.NewCancelCommandForTest(s.store)
+	ctx, err := testing.RunCommand(c, cancelCmd, "-m", "admin", "deadbeef", "feedface")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(client.cancelledActions, jc.DeepEquals, params.Entities{Entities: []params.Entity{
+		{Tag: tag1}, {Tag: tag2},
+	}})
+
+	out := &bytes.Buffer{}
+	err = cmd.FormatYaml(out, action.ActionResultsToMap(results))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, out.String())
+}
+
+func (s *CancelSuite) TestRunNoMatch(c *gc.C) {
+	client := &fakeAPIClient{}
+	restore := s.patchAPIClient(client)
+	defer restore()
+
+	cancelCmd, _ := action.NewCancelCommandForTest(s.store)
+	_, err := testing.RunCommand(c, cancelCmd, "-m", "admin", "deadbeef")
+	c.Assert(err, gc.ErrorMatches, `actions for identifier "deadbeef" not found`)
+	c.Assert(client.cancelledActions.Entities, gc.HasLen, 0)
+}
+
+func (s *CancelSuite) TestRunCancelError(c *gc.C) {
+	tag := "action-deadbeef-0000-4000-8000-feedfacebeef"
+	client := &fakeAPIClient{
+		actionTagMatches: tagsForIdPrefix("deadbeef", tag),
+		actionResults: []params.ActionResult{{
+			Error: &params.Error{Message: `cannot cancel action "deadbeef-0000-4000-8000-feedfacebeef": action is completed`},
+		}},
+	}
+	restore := s.patchAPIClient(client)
+	defer restore()
+
+	cancelCmd, _ := action.NewCancelCommandForTest(s.store)
+	ctx, err := testing.RunCommand(c, cancelCmd, "-m", "admin", "deadbeef")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(testing.Stdout(ctx), jc.Contains, "action is completed")
+}
@@ -4,6 +4,8 @@
 package action
 
 import (
+	"time"
+
 	"github.com/juju/cmd"
 	"gopkg.in/juju/names.v2"
 
@@ -15,12 +17,21 @@ import (
 var (
 	NewActionAPIClient = &newAPIClient
 	AddValueToMap      = addValueToMap
+	WatchInterval      = &watchInterval
 )
 
 type ShowOutputCommand struct {
 	*showOutputCommand
 }
 
+type CancelCommand struct {
+	*cancelCommand
+}
+
+func (c *CancelCommand) RequestedIds() []string {
+	return c.requestedIds
+}
+
 type StatusCommand struct {
 	*statusCommand
 }
@@ -49,6 +60,30 @@ func (c *RunCommand) Args() [][]string {
 	return c.args
 }
 
+func (c *RunCommand) Timeout() time.Duration {
+	return c.timeout
+}
+
+type ScheduleCommand struct {
+	*scheduleCommand
+}
+
+func (c *ScheduleCommand) Name() string {
+	return c.name
+}
+
+func (c *ScheduleCommand) Receiver() names.Tag {
+	return c.receiver
+}
+
+func (c *ScheduleCommand) ActionName() string {
+	return c.actionName
+}
+
+func (c *ScheduleCommand) Schedule() string {
+	return c.schedule
+}
+
 type ListCommand struct {
 	*listCommand
 }
@@ -73,6 +108,12 @@ func NewStatusCommandForTest(store jujuclient.ClientStore) (cmd.Command, *Status
 	return modelcmd.Wrap(c), &StatusCommand{c}
 }
 
+func NewCancelCommandForTest(store jujuclient.ClientStore) (cmd.Command, *CancelCommand) {
+	c := &cancelCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c), &CancelCommand{c}
+}
+
 func NewListCommandForTest(store jujuclient.ClientStore) (cmd.Command, *ListCommand) {
 	c := &listCommand{}
 	c.SetClientStore(store)
@@ -85,6 +126,36 @@ func NewRunCommandForTest(store jujuclient.ClientStore) (cmd.Command, *RunComman
 	return modelcmd.Wrap(c, modelcmd.WrapSkipDefaultModel), &RunCommand{c}
 }
 
+func NewScheduleCommandForTest(store jujuclient.ClientStore) (cmd.Command, *ScheduleCommand) {
+	c := &scheduleCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c), &ScheduleCommand{c}
+}
+
+func NewListSchedulesCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &listSchedulesCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
+func NewEnableScheduleCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &manageScheduleCommand{operation: enableSchedules}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
+func NewDisableScheduleCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &manageScheduleCommand{operation: disableSchedules}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
+func NewRemoveScheduleCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &manageScheduleCommand{operation: removeSchedules}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
 func ActionResultsToMap(results []params.ActionResult) map[string]interface{} {
 	return resultsToMap(results)
 }
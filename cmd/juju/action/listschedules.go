@@ -0,0 +1,156 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+func NewListSchedulesCommand() cmd.Command {
+	return modelcmd.Wrap(&listSchedulesCommand{})
+}
+
+// listSchedulesCommand lists the action schedules in a model.
+type listSchedulesCommand struct {
+	ActionCommandBase
+	out cmd.Output
+}
+
+const listSchedulesDoc = `
+List the schedules on which actions are queued in the model, with the
+time each schedule will next run, and the outcome of its most recent run.
+
+See also:
+    schedule-action
+    show-action-status
+`
+
+// SetFlags is part of the cmd.Command interface.
+func (c *listSchedulesCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ActionCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatSchedulesTabular,
+	})
+}
+
+// Info is part of the cmd.Command interface.
+func (c *listSchedulesCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "list-schedules",
+		Purpose: "List action schedules.",
+		Doc:     listSchedulesDoc,
+		Aliases: []string{"schedules"},
+	}
+}
+
+// Init is part of the cmd.Command interface.
+func (c *listSchedulesCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// Run is part of the cmd.Command interface.
+func (c *listSchedulesCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	results, err := api.ListSchedules()
+	if err != nil {
+		return err
+	}
+	if len(results.Schedules) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No action schedules in this model.")
+		return nil
+	}
+	return c.out.Write(ctx, formatSchedules(results.Schedules))
+}
+
+// scheduleOutput is the yaml and json representation of an action
+// schedule.
+type scheduleOutput struct {
+	Target      string                 `yaml:"target" json:"target"`
+	Action      string                 `yaml:"action" json:"action"`
+	Parameters  map[string]interface{} `yaml:"parameters,omitempty" json:"parameters,omitempty"`
+	Schedule    string                 `yaml:"schedule" json:"schedule"`
+	Timeout     string                 `yaml:"timeout,omitempty" json:"timeout,omitempty"`
+	Enabled     bool                   `yaml:"enabled" json:"enabled"`
+	NextRun     string                 `yaml:"next-run,omitempty" json:"next-run,omitempty"`
+	LastRun     string                 `yaml:"last-run,omitempty" json:"last-run,omitempty"`
+	LastStatus  string                 `yaml:"last-status,omitempty" json:"last-status,omitempty"`
+	LastMessage string                 `yaml:"last-message,omitempty" json:"last-message,omitempty"`
+	LastActions []string               `yaml:"last-actions,omitempty" json:"last-actions,omitempty"`
+}
+
+// formatSchedules converts schedules into a map of scheduleOutput,
+// keyed by schedule name.
+func formatSchedules(schedules []params.ActionSchedule) map[string]scheduleOutput {
+	result := make(map[string]scheduleOutput)
+	for _, schedule := range schedules {
+		out := scheduleOutput{
+			Target:      schedule.Receiver,
+			Action:      schedule.ActionName,
+			Parameters:  schedule.Parameters,
+			Schedule:    schedule.Schedule,
+			Enabled:     schedule.Enabled,
+			NextRun:     formatScheduleTime(schedule.NextRun),
+			LastRun:     formatScheduleTime(schedule.LastRun),
+			LastStatus:  schedule.LastStatus,
+			LastMessage: schedule.LastMessage,
+			LastActions: schedule.LastActions,
+		}
+		if tag, err := names.ParseTag(schedule.Receiver); err == nil {
+			out.Target = tag.Id()
+		}
+		if schedule.Timeout > 0 {
+			out.Timeout = schedule.Timeout.String()
+		}
+		result[schedule.Name] = out
+	}
+	return result
+}
+
+func formatScheduleTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// formatSchedulesTabular writes the schedules in tabular format.
+func formatSchedulesTabular(writer io.Writer, value interface{}) error {
+	schedules, ok := value.(map[string]scheduleOutput)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", schedules, value)
+	}
+	tw := output.TabWriter(writer)
+	fmt.Fprintln(tw, "Name\tTarget\tAction\tSchedule\tEnabled\tNext run\tLast run\tLast status")
+	var scheduleNames []string
+	for name := range schedules {
+		scheduleNames = append(scheduleNames, name)
+	}
+	sort.Strings(scheduleNames)
+	for _, name := range scheduleNames {
+		s := schedules[name]
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%t\t%s\t%s\t%s\n",
+			name, s.Target, s.Action, s.Schedule, s.Enabled, s.NextRun, s.LastRun, s.LastStatus)
+	}
+	tw.Flush()
+	return nil
+}
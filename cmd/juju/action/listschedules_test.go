@@ -0,0 +1,90 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/action"
+	"github.com/juju/juju/testing"
+)
+
+type ListSchedulesSuite struct {
+	BaseActionSuite
+}
+
+var _ = gc.Suite(&ListSchedulesSuite{})
+
+var testSchedules = []params.ActionSchedule{{
+	Name:        "mysql-backup",
+	Receiver:    "application-mysql",
+	ActionName:  "backup",
+	Parameters:  map[string]interface{}{"out": "backup.tgz"},
+	Schedule:    "0 2 * * *",
+	Timeout:     time.Hour,
+	Enabled:     true,
+	NextRun:     time.Date(2017, 3, 16, 2, 0, 0, 0, time.UTC),
+	LastRun:     time.Date(2017, 3, 15, 2, 0, 0, 0, time.UTC),
+	LastStatus:  "enqueued",
+	LastActions: []string{"f47ac10b-58cc-4372-a567-0e02b2c3d479"},
+}, {
+	Name:       "cache-flush",
+	Receiver:   "unit-cache-0",
+	ActionName: "flush",
+	Schedule:   "@every 30m",
+}}
+
+func (s *ListSchedulesSuite) TestInit(c *gc.C) {
+	err := testing.InitCommand(action.NewListSchedulesCommandForTest(s.store), []string{"-m", "admin", "foo"})
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["foo"\]`)
+}
+
+func (s *ListSchedulesSuite) TestRunTabular(c *gc.C) {
+	restore := s.patchAPIClient(&fakeAPIClient{schedules: testSchedules})
+	defer restore()
+
+	ctx, err := testing.RunCommand(c, action.NewListSchedulesCommandForTest(s.store), "-m", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stdout(ctx), gc.Equals, ""+
+		"Name          Target   Action  Schedule    Enabled  Next run              Last run              Last status\n"+
+		"cache-flush   cache/0  flush   @every 30m  false                                                \n"+
+		"mysql-backup  mysql    backup  0 2 * * *   true     2017-03-16T02:00:00Z  2017-03-15T02:00:00Z  enqueued\n")
+}
+
+func (s *ListSchedulesSuite) TestRunYAML(c *gc.C) {
+	restore := s.patchAPIClient(&fakeAPIClient{schedules: testSchedules[:1]})
+	defer restore()
+
+	ctx, err := testing.RunCommand(c, action.NewListSchedulesCommandForTest(s.store), "-m", "admin", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stdout(ctx), gc.Equals, `
+mysql-backup:
+  target: mysql
+  action: backup
+  parameters:
+    out: backup.tgz
+  schedule: 0 2 * * *
+  timeout: 1h0m0s
+  enabled: true
+  next-run: "2017-03-16T02:00:00Z"
+  last-run: "2017-03-15T02:00:00Z"
+  last-status: enqueued
+  last-actions:
+  - f47ac10b-58cc-4372-a567-0e02b2c3d479
+`[1:])
+}
+
+func (s *ListSchedulesSuite) TestRunNoSchedules(c *gc.C) {
+	restore := s.patchAPIClient(&fakeAPIClient{})
+	defer restore()
+
+	ctx, err := testing.RunCommand(c, action.NewListSchedulesCommandForTest(s.store), "-m", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stdout(ctx), gc.Equals, "")
+	c.Check(testing.Stderr(ctx), gc.Equals, "No action schedules in this model.\n")
+}
@@ -0,0 +1,148 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// scheduleOperation identifies what a manageScheduleCommand does to
+// the named schedules.
+type scheduleOperation string
+
+const (
+	enableSchedules  scheduleOperation = "enable"
+	disableSchedules scheduleOperation = "disable"
+	removeSchedules  scheduleOperation = "remove"
+)
+
+func NewEnableScheduleCommand() cmd.Command {
+	return modelcmd.Wrap(&manageScheduleCommand{operation: enableSchedules})
+}
+
+func NewDisableScheduleCommand() cmd.Command {
+	return modelcmd.Wrap(&manageScheduleCommand{operation: disableSchedules})
+}
+
+func NewRemoveScheduleCommand() cmd.Command {
+	return modelcmd.Wrap(&manageScheduleCommand{operation: removeSchedules})
+}
+
+// manageScheduleCommand enables, disables or removes action schedules
+// by name.
+type manageScheduleCommand struct {
+	ActionCommandBase
+	operation scheduleOperation
+	names     []string
+}
+
+var manageScheduleInfo = map[scheduleOperation]cmd.Info{
+	enableSchedules: {
+		Purpose: "Enable action schedules.",
+		Doc: `
+Enable the named action schedules, so that their actions are queued when
+the schedules next fall due. Runs missed while a schedule was disabled are
+not made up.
+
+Examples:
+    juju enable-schedule mysql-backup
+
+See also:
+    disable-schedule
+    list-schedules
+`,
+	},
+	disableSchedules: {
+		Purpose: "Disable action schedules.",
+		Doc: `
+Disable the named action schedules, so that their actions are no longer
+queued. Actions already queued by the schedules are unaffected.
+
+Examples:
+    juju disable-schedule mysql-backup
+
+See also:
+    enable-schedule
+    list-schedules
+`,
+	},
+	removeSchedules: {
+		Purpose: "Remove action schedules.",
+		Doc: `
+Remove the named action schedules. Actions already queued by the schedules
+are unaffected.
+
+Examples:
+    juju remove-schedule mysql-backup
+
+See also:
+    list-schedules
+    schedule-action
+`,
+	},
+}
+
+// Info is part of the cmd.Command interface.
+func (c *manageScheduleCommand) Info() *cmd.Info {
+	info := manageScheduleInfo[c.operation]
+	info.Name = string(c.operation) + "-schedule"
+	info.Args = "<schedule name> [...]"
+	return &info
+}
+
+// Init is part of the cmd.Command interface.
+func (c *manageScheduleCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no schedule name specified")
+	}
+	c.names = args
+	return nil
+}
+
+// Run is part of the cmd.Command interface.
+func (c *manageScheduleCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	var results params.ErrorResults
+	switch c.operation {
+	case removeSchedules:
+		results, err = api.RemoveSchedules(params.ActionScheduleNames{Names: c.names})
+	default:
+		args := params.ActionSchedulesEnabled{
+			Schedules: make([]params.ActionScheduleEnabled, len(c.names)),
+		}
+		for i, name := range c.names {
+			args.Schedules[i] = params.ActionScheduleEnabled{
+				Name:    name,
+				Enabled: c.operation == enableSchedules,
+			}
+		}
+		results, err = api.SetSchedulesEnabled(args)
+	}
+	if err != nil {
+		return err
+	}
+	if len(results.Results) != len(c.names) {
+		return errors.Errorf("expected %d results, got %d", len(c.names), len(results.Results))
+	}
+	failed := false
+	for i, result := range results.Results {
+		if result.Error != nil {
+			ctx.Infof("cannot %s schedule %q: %v", c.operation, c.names[i], result.Error)
+			failed = true
+		}
+	}
+	if failed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
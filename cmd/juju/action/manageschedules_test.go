@@ -0,0 +1,62 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/action"
+	"github.com/juju/juju/testing"
+)
+
+type ManageSchedulesSuite struct {
+	BaseActionSuite
+}
+
+var _ = gc.Suite(&ManageSchedulesSuite{})
+
+func (s *ManageSchedulesSuite) TestInit(c *gc.C) {
+	err := testing.InitCommand(action.NewEnableScheduleCommandForTest(s.store), []string{"-m", "admin"})
+	c.Assert(err, gc.ErrorMatches, "no schedule name specified")
+}
+
+func (s *ManageSchedulesSuite) TestEnable(c *gc.C) {
+	client := &fakeAPIClient{errorResults: []params.ErrorResult{{}, {}}}
+	restore := s.patchAPIClient(client)
+	defer restore()
+
+	_, err := testing.RunCommand(c, action.NewEnableScheduleCommandForTest(s.store), "-m", "admin", "a", "b")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(client.enabledSchedules, jc.DeepEquals, params.ActionSchedulesEnabled{
+		Schedules: []params.ActionScheduleEnabled{{Name: "a", Enabled: true}, {Name: "b", Enabled: true}},
+	})
+}
+
+func (s *ManageSchedulesSuite) TestDisable(c *gc.C) {
+	client := &fakeAPIClient{errorResults: []params.ErrorResult{{}}}
+	restore := s.patchAPIClient(client)
+	defer restore()
+
+	_, err := testing.RunCommand(c, action.NewDisableScheduleCommandForTest(s.store), "-m", "admin", "a")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(client.enabledSchedules, jc.DeepEquals, params.ActionSchedulesEnabled{
+		Schedules: []params.ActionScheduleEnabled{{Name: "a", Enabled: false}},
+	})
+}
+
+func (s *ManageSchedulesSuite) TestRemove(c *gc.C) {
+	client := &fakeAPIClient{errorResults: []params.ErrorResult{{}, {
+		Error: &params.Error{Message: `action schedule "b" not found`},
+	}}}
+	restore := s.patchAPIClient(client)
+	defer restore()
+
+	ctx, err := testing.RunCommand(c, action.NewRemoveScheduleCommandForTest(s.store), "-m", "admin", "a", "b")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Check(client.removedSchedules, jc.DeepEquals, params.ActionScheduleNames{Names: []string{"a", "b"}})
+	c.Check(testing.Stderr(ctx), gc.Equals, `cannot remove schedule "b": action schedule "b" not found`+"\n")
+}
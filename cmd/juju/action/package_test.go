@@ -122,11 +122,18 @@ type fakeAPIClient struct {
 	delay              *time.Timer
 	timeout            *time.Timer
 	actionResults      []params.ActionResult
+	actionResultsSeq   [][]params.ActionResult
 	enqueuedActions    params.Actions
 	actionsByReceivers []params.ActionsByReceiver
 	actionTagMatches   params.FindTagsResults
 	actionsByNames     params.ActionsByNames
+	cancelledActions   params.Entities
 	charmActions       map[string]params.ActionSpec
+	addedSchedules     params.ActionSchedules
+	enabledSchedules   params.ActionSchedulesEnabled
+	removedSchedules   params.ActionScheduleNames
+	schedules          []params.ActionSchedule
+	errorResults       []params.ErrorResult
 	apiErr             error
 }
 
@@ -165,7 +172,8 @@ func (c *fakeAPIClient) ListCompleted(args params.Entities) (params.ActionsByRec
 	}, c.apiErr
 }
 
-func (c *fakeAPIClient) Cancel(args params.Actions) (params.ActionResults, error) {
+func (c *fakeAPIClient) Cancel(args params.Entities) (params.ActionResults, error) {
+	c.cancelledActions = args
 	return params.ActionResults{
 		Results: c.actionResults,
 	}, c.apiErr
@@ -180,6 +188,16 @@ func (c *fakeAPIClient) Actions(args params.Entities) (params.ActionResults, err
 	// to prevent the test hanging.  If the given wait is up, then return
 	// the results; otherwise, return a pending status.
 
+	// If the test supplies a sequence of results, return the next
+	// one, repeating the last when the sequence runs out.
+	if n := len(c.actionResultsSeq); n > 0 {
+		results := c.actionResultsSeq[0]
+		if n > 1 {
+			c.actionResultsSeq = c.actionResultsSeq[1:]
+		}
+		return params.ActionResults{Results: results}, c.apiErr
+	}
+
 	// First, sync.
 	_ = <-time.NewTimer(0 * time.Second).C
 
@@ -209,3 +227,22 @@ func (c *fakeAPIClient) FindActionTagsByPrefix(arg params.FindTags) (params.Find
 func (c *fakeAPIClient) FindActionsByNames(args params.FindActionsByNames) (params.ActionsByNames, error) {
 	return c.actionsByNames, c.apiErr
 }
+
+func (c *fakeAPIClient) AddSchedules(args params.ActionSchedules) (params.ErrorResults, error) {
+	c.addedSchedules = args
+	return params.ErrorResults{Results: c.errorResults}, c.apiErr
+}
+
+func (c *fakeAPIClient) ListSchedules() (params.ActionSchedules, error) {
+	return params.ActionSchedules{Schedules: c.schedules}, c.apiErr
+}
+
+func (c *fakeAPIClient) SetSchedulesEnabled(args params.ActionSchedulesEnabled) (params.ErrorResults, error) {
+	c.enabledSchedules = args
+	return params.ErrorResults{Results: c.errorResults}, c.apiErr
+}
+
+func (c *fakeAPIClient) RemoveSchedules(args params.ActionScheduleNames) (params.ErrorResults, error) {
+	c.removedSchedules = args
+	return params.ErrorResults{Results: c.errorResults}, c.apiErr
+}
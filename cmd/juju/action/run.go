@@ -35,6 +35,7 @@ type runCommand struct {
 	paramsYAML   cmd.FileVar
 	parseStrings bool
 	wait         waitFlag
+	timeout      time.Duration
 	out          cmd.Output
 	args         [][]string
 }
@@ -56,6 +57,11 @@ is a boolean true in YAML.
 If --params is passed, along with key.key...=value explicit arguments, the
 explicit arguments will override the parameter file.
 
+If --timeout is passed, the Action will be stopped and marked as failed if
+it runs for longer than the given duration. Without it, the default timeout
+given by the "timeout" key of the Action's definition in actions.yaml, if
+any, is used.
+
 Examples:
 
 $ juju run-action mysql/3 backup --wait
@@ -113,6 +119,10 @@ file:
 $ juju run-action sleeper/0 pause time=1000
 ...
 
+$ juju run-action mysql/3 backup --timeout 30m
+...
+The Action will be stopped if it has not finished after 30 minutes.
+
 $ juju run-action sleeper/0 pause --string-args time=1000
 ...
 The value for the "time" param will be the string literal "1000".
@@ -128,6 +138,7 @@ func (c *runCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(&c.paramsYAML, "params", "Path to yaml-formatted params file")
 	f.BoolVar(&c.parseStrings, "string-args", false, "Use raw string values of CLI args")
 	f.Var(&c.wait, "wait", "Wait for results, with optional timeout")
+	f.DurationVar(&c.timeout, "timeout", 0, "Stop the action if it runs for longer than this")
 }
 
 func (c *runCommand) Info() *cmd.Info {
@@ -141,6 +152,9 @@ func (c *runCommand) Info() *cmd.Info {
 
 // Init gets the unit tag, and checks for other correct args.
 func (c *runCommand) Init(args []string) error {
+	if c.timeout < 0 {
+		return errors.New("timeout must not be negative")
+	}
 	switch len(args) {
 	case 0:
 		return errors.New("no unit specified")
@@ -162,24 +176,31 @@ func (c *runCommand) Init(args []string) error {
 			return nil
 		}
 		// Parse CLI key-value args if they exist.
-		c.args = make([][]string, 0)
-		for _, arg := range args[2:] {
-			thisArg := strings.SplitN(arg, "=", 2)
-			if len(thisArg) != 2 {
-				return errors.Errorf("argument %q must be of the form key...=value", arg)
-			}
-			keySlice := strings.Split(thisArg[0], ".")
-			// check each key for validity
-			for _, key := range keySlice {
-				if valid := keyRule.MatchString(key); !valid {
-					return errors.Errorf("key %q must start and end with lowercase alphanumeric, and contain only lowercase alphanumeric and hyphens", key)
-				}
+		var err error
+		c.args, err = parseKeyValueArgs(args[2:])
+		return err
+	}
+}
+
+// parseKeyValueArgs parses key.key.key...=value arguments, returning
+// a slice of the form {..., [key, key, key, key, value], ...}.
+func parseKeyValueArgs(args []string) ([][]string, error) {
+	result := make([][]string, 0)
+	for _, arg := range args {
+		thisArg := strings.SplitN(arg, "=", 2)
+		if len(thisArg) != 2 {
+			return nil, errors.Errorf("argument %q must be of the form key...=value", arg)
+		}
+		keySlice := strings.Split(thisArg[0], ".")
+		// check each key for validity
+		for _, key := range keySlice {
+			if valid := keyRule.MatchString(key); !valid {
+				return nil, errors.Errorf("key %q must start and end with lowercase alphanumeric, and contain only lowercase alphanumeric and hyphens", key)
 			}
-			// c.args={..., [key, key, key, key, value]}
-			c.args = append(c.args, append(keySlice, thisArg[1]))
 		}
-		return nil
+		result = append(result, append(keySlice, thisArg[1]))
 	}
+	return result, nil
 }
 
 func (c *runCommand) Run(ctx *cmd.Context) error {
@@ -189,64 +210,17 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 	}
 	defer api.Close()
 
-	actionParams := map[string]interface{}{}
-
-	if c.paramsYAML.Path != "" {
-		b, err := c.paramsYAML.Read(ctx)
-		if err != nil {
-			return err
-		}
-
-		err = yaml.Unmarshal(b, &actionParams)
-		if err != nil {
-			return err
-		}
-
-		conformantParams, err := common.ConformYAML(actionParams)
-		if err != nil {
-			return err
-		}
-
-		betterParams, ok := conformantParams.(map[string]interface{})
-		if !ok {
-			return errors.New("params must contain a YAML map with string keys")
-		}
-
-		actionParams = betterParams
-	}
-
-	// If we had explicit args {..., [key, key, key, key, value], ...}
-	// then iterate and set params ..., key.key.key.key=value, ...
-	for _, argSlice := range c.args {
-		valueIndex := len(argSlice) - 1
-		keys := argSlice[:valueIndex]
-		value := argSlice[valueIndex]
-		cleansedValue := interface{}(value)
-		if !c.parseStrings {
-			err := yaml.Unmarshal([]byte(value), &cleansedValue)
-			if err != nil {
-				return err
-			}
-		}
-		// Insert the value in the map.
-		addValueToMap(keys, cleansedValue, actionParams)
-	}
-
-	conformantParams, err := common.ConformYAML(actionParams)
+	actionParams, err := buildActionParams(ctx, c.paramsYAML, c.args, c.parseStrings)
 	if err != nil {
 		return err
 	}
 
-	typedConformantParams, ok := conformantParams.(map[string]interface{})
-	if !ok {
-		return errors.Errorf("params must be a map, got %T", typedConformantParams)
-	}
-
 	actionParam := params.Actions{
 		Actions: []params.Action{{
 			Receiver:   c.unitTag.String(),
 			Name:       c.actionName,
 			Parameters: actionParams,
+			Timeout:    c.timeout,
 		}},
 	}
 
@@ -298,3 +272,63 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 	output["action-id"] = tag.Id() // Action ID is required in case we timed out.
 	return c.out.Write(ctx, output)
 }
+
+// buildActionParams returns the parameters for an Action, read from
+// the given params file, if any, and overridden by the given key-value
+// args as returned by parseKeyValueArgs. Values in args are parsed as
+// YAML unless parseStrings is true.
+func buildActionParams(ctx *cmd.Context, paramsYAML cmd.FileVar, args [][]string, parseStrings bool) (map[string]interface{}, error) {
+	actionParams := map[string]interface{}{}
+
+	if paramsYAML.Path != "" {
+		b, err := paramsYAML.Read(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		err = yaml.Unmarshal(b, &actionParams)
+		if err != nil {
+			return nil, err
+		}
+
+		conformantParams, err := common.ConformYAML(actionParams)
+		if err != nil {
+			return nil, err
+		}
+
+		betterParams, ok := conformantParams.(map[string]interface{})
+		if !ok {
+			return nil, errors.New("params must contain a YAML map with string keys")
+		}
+
+		actionParams = betterParams
+	}
+
+	// If we had explicit args {..., [key, key, key, key, value], ...}
+	// then iterate and set params ..., key.key.key.key=value, ...
+	for _, argSlice := range args {
+		valueIndex := len(argSlice) - 1
+		keys := argSlice[:valueIndex]
+		value := argSlice[valueIndex]
+		cleansedValue := interface{}(value)
+		if !parseStrings {
+			err := yaml.Unmarshal([]byte(value), &cleansedValue)
+			if err != nil {
+				return nil, err
+			}
+		}
+		// Insert the value in the map.
+		addValueToMap(keys, cleansedValue, actionParams)
+	}
+
+	conformantParams, err := common.ConformYAML(actionParams)
+	if err != nil {
+		return nil, err
+	}
+
+	typedConformantParams, ok := conformantParams.(map[string]interface{})
+	if !ok {
+		return nil, errors.Errorf("params must be a map, got %T", typedConformantParams)
+	}
+	return actionParams, nil
+}
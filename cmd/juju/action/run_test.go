@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"errors"
 	"strings"
+	"time"
 	"unicode/utf8"
 
 	jc "github.com/juju/testing/checkers"
@@ -64,6 +65,7 @@ func (s *RunSuite) TestInit(c *gc.C) {
 		expectParamsYamlPath string
 		expectParseStrings   bool
 		expectKVArgs         [][]string
+		expectTimeout        time.Duration
 		expectOutput         string
 		expectError          string
 	}{{
@@ -158,6 +160,16 @@ func (s *RunSuite) TestInit(c *gc.C) {
 			{"foo", "baz", "bo", "y"},
 			{"bar", "foo", "hello"},
 		},
+	}, {
+		should:        "handle --timeout",
+		args:          []string{validUnitId, "valid-action-name", "--timeout", "90s"},
+		expectUnit:    names.NewUnitTag(validUnitId),
+		expectAction:  "valid-action-name",
+		expectTimeout: 90 * time.Second,
+	}, {
+		should:      "fail with negative --timeout",
+		args:        []string{validUnitId, "valid-action-name", "--timeout", "-1s"},
+		expectError: "timeout must not be negative",
 	}}
 
 	for i, t := range tests {
@@ -173,6 +185,7 @@ func (s *RunSuite) TestInit(c *gc.C) {
 				c.Check(command.ParamsYAML().Path, gc.Equals, t.expectParamsYamlPath)
 				c.Check(command.Args(), jc.DeepEquals, t.expectKVArgs)
 				c.Check(command.ParseStrings(), gc.Equals, t.expectParseStrings)
+				c.Check(command.Timeout(), gc.Equals, t.expectTimeout)
 			} else {
 				c.Check(err, gc.ErrorMatches, t.expectError)
 			}
@@ -252,6 +265,18 @@ func (s *RunSuite) TestRun(c *gc.C) {
 			Parameters: map[string]interface{}{},
 			Receiver:   names.NewUnitTag(validUnitId).String(),
 		},
+	}, {
+		should:   "enqueue an action with a timeout",
+		withArgs: []string{validUnitId, "some-action", "--timeout", "5m"},
+		withActionResults: []params.ActionResult{{
+			Action: &params.Action{Tag: validActionTagString},
+		}},
+		expectedActionEnqueued: params.Action{
+			Name:       "some-action",
+			Parameters: map[string]interface{}{},
+			Receiver:   names.NewUnitTag(validUnitId).String(),
+			Timeout:    5 * time.Minute,
+		},
 	}, {
 		should: "enqueue an action with some explicit params",
 		withArgs: []string{validUnitId, "some-action",
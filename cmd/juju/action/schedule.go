@@ -0,0 +1,159 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/actions"
+)
+
+func NewScheduleCommand() cmd.Command {
+	return modelcmd.Wrap(&scheduleCommand{})
+}
+
+// scheduleCommand adds a schedule on which an Action is enqueued on a
+// unit, or on all units of an application.
+type scheduleCommand struct {
+	ActionCommandBase
+	name         string
+	receiver     names.Tag
+	actionName   string
+	schedule     string
+	paramsYAML   cmd.FileVar
+	parseStrings bool
+	timeout      time.Duration
+	args         [][]string
+}
+
+const scheduleDoc = `
+Schedule an Action to be queued repeatedly on a unit, or on every unit of
+an application, by the controller.
+
+The schedule is either a cron expression of five fields (minute, hour, day
+of month, month and day of week), one of @hourly, @daily, @weekly, @monthly
+or @yearly, or "@every <duration>". Schedules are evaluated in UTC.
+
+Params are given as for 'juju run-action', and are validated each time the
+Action is queued. The outcome of the most recent run of each schedule can
+be seen with 'juju list-schedules'.
+
+The schedule is named with --name; by default it is named after the target
+and the Action. Schedules may be disabled, re-enabled and removed by name
+with 'juju disable-schedule', 'juju enable-schedule' and
+'juju remove-schedule'.
+
+Examples:
+    juju schedule-action mysql backup "0 2 * * *" out=/var/backups
+    juju schedule-action mysql/0 rotate-logs @daily --name rotate-mysql-0
+    juju schedule-action cache flush "@every 30m" --timeout 5m
+
+See also:
+    list-schedules
+    run-action
+`
+
+// SetFlags is part of the cmd.Command interface.
+func (c *scheduleCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ActionCommandBase.SetFlags(f)
+	f.StringVar(&c.name, "name", "", "Name of the schedule")
+	f.Var(&c.paramsYAML, "params", "Path to yaml-formatted params file")
+	f.BoolVar(&c.parseStrings, "string-args", false, "Use raw string values of CLI args")
+	f.DurationVar(&c.timeout, "timeout", 0, "Stop each action if it runs for longer than this")
+}
+
+// Info is part of the cmd.Command interface.
+func (c *scheduleCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "schedule-action",
+		Args:    "<application>|<unit> <action name> <schedule> [key.key.key...=value]",
+		Purpose: "Queue an action repeatedly on a schedule.",
+		Doc:     scheduleDoc,
+	}
+}
+
+// Init is part of the cmd.Command interface.
+func (c *scheduleCommand) Init(args []string) error {
+	if c.timeout < 0 {
+		return errors.New("timeout must not be negative")
+	}
+	switch len(args) {
+	case 0:
+		return errors.New("no application or unit specified")
+	case 1:
+		return errors.New("no action specified")
+	case 2:
+		return errors.New("no schedule specified")
+	}
+	target := args[0]
+	switch {
+	case names.IsValidUnit(target):
+		c.receiver = names.NewUnitTag(target)
+	case names.IsValidApplication(target):
+		c.receiver = names.NewApplicationTag(target)
+	default:
+		return errors.Errorf("invalid application or unit name %q", target)
+	}
+	c.actionName = args[1]
+	if !ActionNameRule.MatchString(c.actionName) {
+		return errors.Errorf("invalid action name %q", c.actionName)
+	}
+	c.schedule = args[2]
+	if _, err := actions.ParseSchedule(c.schedule); err != nil {
+		return errors.Trace(err)
+	}
+	if c.name == "" {
+		c.name = strings.Replace(target, "/", "-", -1) + "-" + c.actionName
+	}
+	if !keyRule.MatchString(c.name) {
+		return errors.Errorf("invalid schedule name %q", c.name)
+	}
+	var err error
+	c.args, err = parseKeyValueArgs(args[3:])
+	return err
+}
+
+// Run is part of the cmd.Command interface.
+func (c *scheduleCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	actionParams, err := buildActionParams(ctx, c.paramsYAML, c.args, c.parseStrings)
+	if err != nil {
+		return err
+	}
+
+	results, err := api.AddSchedules(params.ActionSchedules{
+		Schedules: []params.ActionSchedule{{
+			Name:       c.name,
+			Receiver:   c.receiver.String(),
+			ActionName: c.actionName,
+			Parameters: actionParams,
+			Schedule:   c.schedule,
+			Timeout:    c.timeout,
+		}},
+	})
+	if err != nil {
+		return err
+	}
+	if len(results.Results) != 1 {
+		return errors.New("illegal number of results returned")
+	}
+	if err := results.Results[0].Error; err != nil {
+		return err
+	}
+	ctx.Infof("Added action schedule %q.", c.name)
+	return nil
+}
@@ -0,0 +1,122 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/action"
+	"github.com/juju/juju/testing"
+)
+
+type ScheduleSuite struct {
+	BaseActionSuite
+}
+
+var _ = gc.Suite(&ScheduleSuite{})
+
+func (s *ScheduleSuite) TestInit(c *gc.C) {
+	for i, t := range []struct {
+		args       []string
+		name       string
+		receiver   names.Tag
+		actionName string
+		schedule   string
+		err        string
+	}{{
+		err: "no application or unit specified",
+	}, {
+		args: []string{"mysql"},
+		err:  "no action specified",
+	}, {
+		args: []string{"mysql", "backup"},
+		err:  "no schedule specified",
+	}, {
+		args: []string{"something-strange-", "backup", "@daily"},
+		err:  `invalid application or unit name "something-strange-"`,
+	}, {
+		args: []string{"mysql", "Backup", "@daily"},
+		err:  `invalid action name "Backup"`,
+	}, {
+		args: []string{"mysql", "backup", "whenever"},
+		err:  `schedule "whenever" \(expected 5 fields, got 1\) not valid`,
+	}, {
+		args: []string{"mysql", "backup", "@daily", "--name", "Nightly"},
+		err:  `invalid schedule name "Nightly"`,
+	}, {
+		args: []string{"mysql", "backup", "@daily", "--timeout", "-1s"},
+		err:  "timeout must not be negative",
+	}, {
+		args: []string{"mysql", "backup", "@daily", "out"},
+		err:  `argument "out" must be of the form key...=value`,
+	}, {
+		args:       []string{"mysql", "backup", "0 2 * * *"},
+		name:       "mysql-backup",
+		receiver:   names.NewApplicationTag("mysql"),
+		actionName: "backup",
+		schedule:   "0 2 * * *",
+	}, {
+		args:       []string{"mysql/0", "backup", "@daily", "--name", "nightly", "out=foo"},
+		name:       "nightly",
+		receiver:   names.NewUnitTag("mysql/0"),
+		actionName: "backup",
+		schedule:   "@daily",
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		wrapped, command := action.NewScheduleCommandForTest(s.store)
+		args := append([]string{"-m", "admin"}, t.args...)
+		err := testing.InitCommand(wrapped, args)
+		if t.err != "" {
+			c.Check(err, gc.ErrorMatches, t.err)
+			continue
+		}
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(command.Name(), gc.Equals, t.name)
+		c.Check(command.Receiver(), gc.Equals, t.receiver)
+		c.Check(command.ActionName(), gc.Equals, t.actionName)
+		c.Check(command.Schedule(), gc.Equals, t.schedule)
+	}
+}
+
+func (s *ScheduleSuite) TestRun(c *gc.C) {
+	client := &fakeAPIClient{errorResults: []params.ErrorResult{{}}}
+	restore := s.patchAPIClient(client)
+	defer restore()
+
+	wrapped, _ := action.NewScheduleCommandForTest(s.store)
+	ctx, err := testing.RunCommand(c, wrapped, "-m", "admin",
+		"mysql", "backup", "0 2 * * *", "--timeout", "1h", "out=backup.tgz", "file.kind=xz")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stderr(ctx), gc.Equals, "Added action schedule \"mysql-backup\".\n")
+	c.Check(client.addedSchedules, jc.DeepEquals, params.ActionSchedules{
+		Schedules: []params.ActionSchedule{{
+			Name:       "mysql-backup",
+			Receiver:   "application-mysql",
+			ActionName: "backup",
+			Parameters: map[string]interface{}{
+				"out":  "backup.tgz",
+				"file": map[string]interface{}{"kind": "xz"},
+			},
+			Schedule: "0 2 * * *",
+			Timeout:  time.Hour,
+		}},
+	})
+}
+
+func (s *ScheduleSuite) TestRunError(c *gc.C) {
+	client := &fakeAPIClient{errorResults: []params.ErrorResult{{
+		Error: &params.Error{Message: `cannot add action schedule: action schedule "mysql-backup" already exists`},
+	}}}
+	restore := s.patchAPIClient(client)
+	defer restore()
+
+	wrapped, _ := action.NewScheduleCommandForTest(s.store)
+	_, err := testing.RunCommand(c, wrapped, "-m", "admin", "mysql", "backup", "@daily")
+	c.Assert(err, gc.ErrorMatches, `cannot add action schedule: action schedule "mysql-backup" already exists`)
+}
@@ -4,6 +4,7 @@
 package action
 
 import (
+	"fmt"
 	"regexp"
 	"time"
 
@@ -27,8 +28,13 @@ type showOutputCommand struct {
 	requestedId string
 	fullSchema  bool
 	wait        string
+	watch       bool
 }
 
+// watchInterval is the time between queries for new progress
+// messages when --watch is used.
+var watchInterval = 2 * time.Second
+
 const showOutputDoc = `
 Show the results returned by an action with the given ID.  A partial ID may
 also be used.  To block until the result is known completed or failed, use
@@ -38,6 +44,11 @@ to wait indefinitely.  If units are left off, seconds are assumed.
 The default behavior without --wait is to immediately check and return; if
 the results are "pending" then only the available information will be
 displayed.  This is also the behavior when any negative time is given.
+
+To follow the progress of a running action, use the --watch flag. Messages
+logged by the action with action-log are shown as they arrive, and the
+results are displayed when the action finishes. Unless --wait is also given,
+--watch waits indefinitely.
 `
 
 // Set up the output.
@@ -45,6 +56,7 @@ func (c *showOutputCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ActionCommandBase.SetFlags(f)
 	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
 	f.StringVar(&c.wait, "wait", "-1s", "Wait for results")
+	f.BoolVar(&c.watch, "watch", false, "Show progress messages until the action finishes")
 }
 
 func (c *showOutputCommand) Info() *cmd.Info {
@@ -83,6 +95,9 @@ func (c *showOutputCommand) Run(ctx *cmd.Context) error {
 	if err != nil {
 		return err
 	}
+	if c.watch && waitDur < 0 {
+		waitDur = 0
+	}
 
 	api, err := c.NewActionAPIClient()
 	if err != nil {
@@ -104,7 +119,12 @@ func (c *showOutputCommand) Run(ctx *cmd.Context) error {
 		wait = time.NewTimer(waitDur)
 	}
 
-	result, err := GetActionResult(api, c.requestedId, wait)
+	var result params.ActionResult
+	if c.watch {
+		result, err = watchActionResult(ctx, api, c.requestedId, wait)
+	} else {
+		result, err = GetActionResult(api, c.requestedId, wait)
+	}
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -160,6 +180,53 @@ func timerLoop(api APIClient, requestedId string, wait, tick *time.Timer) (param
 	}
 }
 
+// watchActionResult repeatedly fetches an action until it is in a
+// completed state, writing any new progress messages to stderr as they
+// arrive. It waits for a maximum of "wait" before returning with the
+// latest action status.
+func watchActionResult(ctx *cmd.Context, api APIClient, requestedId string, wait *time.Timer) (params.ActionResult, error) {
+	var last *params.ActionMessage
+	for {
+		result, err := fetchResult(api, requestedId)
+		if err != nil {
+			return result, err
+		}
+		for _, m := range unseenMessages(result.Log, last) {
+			fmt.Fprintf(ctx.Stderr, "%s %s\n", m.Timestamp.UTC().Format(time.RFC3339), m.Message)
+		}
+		if n := len(result.Log); n > 0 {
+			last = &result.Log[n-1]
+		}
+
+		switch result.Status {
+		case params.ActionRunning, params.ActionPending:
+		default:
+			return result, nil
+		}
+
+		select {
+		case <-wait.C:
+			return result, nil
+		case <-time.After(watchInterval):
+		}
+	}
+}
+
+// unseenMessages returns the messages in log that follow the last one
+// seen. The oldest messages of long-running actions are discarded, so
+// the last message seen is found by searching rather than by position.
+func unseenMessages(log []params.ActionMessage, last *params.ActionMessage) []params.ActionMessage {
+	if last == nil {
+		return log
+	}
+	for i := len(log) - 1; i >= 0; i-- {
+		if log[i].Message == last.Message && log[i].Timestamp.Equal(last.Timestamp) {
+			return log[i+1:]
+		}
+	}
+	return log
+}
+
 // fetchResult queries the given API for the given Action ID prefix, and
 // makes sure the results are acceptable, returning an error if they are not.
 func fetchResult(api APIClient, requestedId string) (params.ActionResult, error) {
@@ -204,9 +271,15 @@ func FormatActionResult(result params.ActionResult) map[string]interface{} {
 	if len(result.Output) != 0 {
 		response["results"] = result.Output
 	}
-
-	if result.Enqueued.IsZero() && result.Started.IsZero() && result.Completed.IsZero() {
-		return response
+	if len(result.Log) != 0 {
+		log := make([]map[string]string, len(result.Log))
+		for i, m := range result.Log {
+			log[i] = map[string]string{
+				"timestamp": m.Timestamp.String(),
+				"message":   m.Message,
+			}
+		}
+		response["log"] = log
 	}
 
 	responseTiming := make(map[string]string)
@@ -219,7 +292,12 @@ func FormatActionResult(result params.ActionResult) map[string]interface{} {
 			responseTiming[k] = v.String()
 		}
 	}
-	response["timing"] = responseTiming
+	if result.Action != nil && result.Action.Timeout > 0 {
+		responseTiming["timeout"] = result.Action.Timeout.String()
+	}
+	if len(responseTiming) > 0 {
+		response["timing"] = responseTiming
+	}
 
 	return response
 }
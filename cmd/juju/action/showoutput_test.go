@@ -163,6 +163,36 @@ results:
   foo:
     bar: baz
 status: complete
+timing:
+  completed: 2015-02-14 08:15:30 +0000 UTC
+  enqueued: 2015-02-14 08:13:00 +0000 UTC
+  started: 2015-02-14 08:15:00 +0000 UTC
+`[1:],
+	}, {
+		should:            "pretty-print action output with progress messages",
+		withClientQueryID: validActionId,
+		withAPITimeout:    10 * time.Second,
+		withTags:          tagsForIdPrefix(validActionId, validActionTagString),
+		withAPIResponse: []params.ActionResult{{
+			Status: "completed",
+			Log: []params.ActionMessage{{
+				Timestamp: time.Date(2015, time.February, 14, 8, 15, 10, 0, time.UTC),
+				Message:   "dumping database",
+			}, {
+				Timestamp: time.Date(2015, time.February, 14, 8, 15, 20, 0, time.UTC),
+				Message:   "compressing dump",
+			}},
+			Enqueued:  time.Date(2015, time.February, 14, 8, 13, 0, 0, time.UTC),
+			Started:   time.Date(2015, time.February, 14, 8, 15, 0, 0, time.UTC),
+			Completed: time.Date(2015, time.February, 14, 8, 15, 30, 0, time.UTC),
+		}},
+		expectedOutput: `
+log:
+- message: dumping database
+  timestamp: 2015-02-14 08:15:10 +0000 UTC
+- message: compressing dump
+  timestamp: 2015-02-14 08:15:20 +0000 UTC
+status: completed
 timing:
   completed: 2015-02-14 08:15:30 +0000 UTC
   enqueued: 2015-02-14 08:13:00 +0000 UTC
@@ -265,6 +295,28 @@ status: completed
 timing:
   completed: 2015-02-14 08:15:30 +0000 UTC
   enqueued: 2015-02-14 08:13:00 +0000 UTC
+`[1:],
+	}, {
+		should:            "pretty-print a timed out action",
+		withClientQueryID: validActionId,
+		withAPITimeout:    10 * time.Second,
+		withTags:          tagsForIdPrefix(validActionId, validActionTagString),
+		withAPIResponse: []params.ActionResult{{
+			Action:    &params.Action{Tag: validActionTagString, Timeout: 30 * time.Second},
+			Status:    "failed",
+			Message:   "action timed out after 30s",
+			Enqueued:  time.Date(2015, time.February, 14, 8, 13, 0, 0, time.UTC),
+			Started:   time.Date(2015, time.February, 14, 8, 15, 0, 0, time.UTC),
+			Completed: time.Date(2015, time.February, 14, 8, 15, 30, 0, time.UTC),
+		}},
+		expectedOutput: `
+message: action timed out after 30s
+status: failed
+timing:
+  completed: 2015-02-14 08:15:30 +0000 UTC
+  enqueued: 2015-02-14 08:13:00 +0000 UTC
+  started: 2015-02-14 08:15:00 +0000 UTC
+  timeout: 30s
 `[1:],
 	}}
 
@@ -290,6 +342,46 @@ timing:
 	}
 }
 
+func (s *ShowOutputSuite) TestWatch(c *gc.C) {
+	s.PatchValue(action.WatchInterval, time.Duration(0))
+	first := params.ActionMessage{
+		Timestamp: time.Date(2015, time.February, 14, 8, 15, 10, 0, time.UTC),
+		Message:   "dumping database",
+	}
+	second := params.ActionMessage{
+		Timestamp: time.Date(2015, time.February, 14, 8, 15, 20, 0, time.UTC),
+		Message:   "compressing dump",
+	}
+	client := makeFakeClient(0, 10*time.Second,
+		tagsForIdPrefix(validActionId, validActionTagString),
+		nil, params.ActionsByNames{}, "",
+	)
+	client.actionResultsSeq = [][]params.ActionResult{
+		{{Status: params.ActionPending}},
+		{{Status: params.ActionRunning, Log: []params.ActionMessage{first}}},
+		{{Status: params.ActionRunning, Log: []params.ActionMessage{first}}},
+		{{Status: params.ActionCompleted, Log: []params.ActionMessage{first, second}}},
+	}
+	unpatch := s.BaseActionSuite.patchAPIClient(client)
+	defer unpatch()
+
+	cmd, _ := action.NewShowOutputCommandForTest(s.store)
+	ctx, err := testing.RunCommand(c, cmd, "-m", "admin", validActionId, "--watch")
+	c.Assert(err, gc.IsNil)
+	c.Check(testing.Stderr(ctx), gc.Equals, `
+2015-02-14T08:15:10Z dumping database
+2015-02-14T08:15:20Z compressing dump
+`[1:])
+	c.Check(testing.Stdout(ctx), gc.Equals, `
+log:
+- message: dumping database
+  timestamp: 2015-02-14 08:15:10 +0000 UTC
+- message: compressing dump
+  timestamp: 2015-02-14 08:15:20 +0000 UTC
+status: completed
+`[1:])
+}
+
 func testRunHelper(c *gc.C, s *ShowOutputSuite, client *fakeAPIClient, expectedErr, expectedOutput, wait, query, modelFlag string) {
 	unpatch := s.BaseActionSuite.patchAPIClient(client)
 	defer unpatch()
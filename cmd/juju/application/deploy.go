@@ -267,6 +267,10 @@ type DeployCommand struct {
 	// the storage name defined in that application's charm storage metadata.
 	BundleStorage map[string]map[string]storage.Constraints
 
+	// AttachStorage is a list of storage IDs, identifying storage to
+	// attach to the unit created by deploy.
+	AttachStorage []string
+
 	// Resources is a map of resource name to filename to be uploaded on deploy.
 	Resources map[string]string
 
@@ -416,7 +420,7 @@ func (c *DeployCommand) Info() *cmd.Info {
 var (
 	// charmOnlyFlags and bundleOnlyFlags are used to validate flags based on
 	// whether we are deploying a charm or a bundle.
-	charmOnlyFlags        = []string{"bind", "config", "constraints", "force", "n", "num-units", "series", "to", "resource"}
+	charmOnlyFlags        = []string{"bind", "config", "constraints", "force", "n", "num-units", "series", "to", "resource", "attach-storage"}
 	bundleOnlyFlags       = []string{}
 	modelCommandBaseFlags = []string{"B", "no-browser-login"}
 )
@@ -434,6 +438,7 @@ func (c *DeployCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.Force, "force", false, "Allow a charm to be deployed to a machine running an unsupported series")
 	f.Var(storageFlag{&c.Storage, &c.BundleStorage}, "storage", "Charm storage constraints")
 	f.Var(stringMap{&c.Resources}, "resource", "Resource to be uploaded to the controller")
+	f.Var(cmd.NewAppendStringsValue(&c.AttachStorage), "attach-storage", "Existing storage to attach to the deployed unit")
 	f.StringVar(&c.BindToSpaces, "bind", "", "Configure application endpoint bindings to spaces")
 
 	for _, step := range c.Steps {
@@ -464,6 +469,14 @@ func (c *DeployCommand) Init(args []string) error {
 	if err := c.parseBind(); err != nil {
 		return err
 	}
+	for _, storageId := range c.AttachStorage {
+		if !names.IsValidStorage(storageId) {
+			return errors.NotValidf("storage ID %q", storageId)
+		}
+	}
+	if len(c.AttachStorage) > 0 && c.NumUnits != 1 {
+		return errors.New("--attach-storage cannot be used with -n")
+	}
 	return c.UnitCommandBase.Init(args)
 }
 
@@ -599,6 +612,7 @@ func (c *DeployCommand) deployCharm(
 		ConfigYAML:       string(configYAML),
 		Placement:        c.Placement,
 		Storage:          c.Storage,
+		AttachStorage:    c.AttachStorage,
 		Resources:        ids,
 		EndpointBindings: c.Bindings,
 	}))
@@ -92,6 +92,12 @@ var initErrorTests = []struct {
 	}, {
 		args: []string{"charm", "application", "--force"},
 		err:  `--force is only used with --series`,
+	}, {
+		args: []string{"charm", "--attach-storage", "foo/0", "-n", "2"},
+		err:  `--attach-storage cannot be used with -n`,
+	}, {
+		args: []string{"charm", "--attach-storage", "foo"},
+		err:  `storage ID "foo" not valid`,
 	},
 }
 
@@ -331,6 +337,28 @@ func (s *DeploySuite) TestStorage(c *gc.C) {
 	})
 }
 
+func (s *DeploySuite) TestAttachStorage(c *gc.C) {
+	storageTag, err := s.State.AddExistingFilesystem(state.FilesystemInfo{
+		Pool: "modelscoped-block",
+	}, &state.VolumeInfo{
+		VolumeId: "vol-123",
+		Size:     1024,
+	}, "data")
+	c.Assert(err, jc.ErrorIsNil)
+
+	ch := testcharms.Repo.CharmArchivePath(s.CharmsPath, "storage-filesystem")
+	err = runDeploy(c, ch, "--attach-storage", storageTag.Id(), "--series", "trusty")
+	c.Assert(err, jc.ErrorIsNil)
+
+	unit, err := s.State.Unit("storage-filesystem/0")
+	c.Assert(err, jc.ErrorIsNil)
+	storageInstance, err := s.State.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	owner, ok := storageInstance.Owner()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(owner, gc.Equals, unit.Tag())
+}
+
 func (s *DeploySuite) TestPlacement(c *gc.C) {
 	ch := testcharms.Repo.ClonedDirPath(s.CharmsPath, "dummy")
 	// Add a machine that will be ignored due to placement directive.
@@ -0,0 +1,279 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/bundle"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+var usageDiffBundleSummary = `
+Compares a bundle with the current model.`[1:]
+
+var usageDiffBundleDetails = `
+Compares the applications and relations of a local bundle with those of
+the current model, and shows how they differ. For each application, the
+charm, series, number of units, exposure, options and constraints are
+compared. A charm in the bundle that omits the series or revision
+matches any series or revision in the model, and a relation endpoint in
+the bundle that omits the relation name matches any endpoint of the
+application.
+
+The bundle is given as a path to a bundle YAML file or to a directory
+containing a bundle.yaml file.
+
+Examples:
+    juju diff-bundle ./bundle.yaml
+    juju diff-bundle ./mediawiki --format yaml
+
+See also:
+    deploy
+    export-bundle`[1:]
+
+// NewDiffBundleCommand returns a command to compare a bundle with the
+// current model.
+func NewDiffBundleCommand() cmd.Command {
+	return modelcmd.Wrap(&diffBundleCommand{})
+}
+
+// diffBundleCommand compares a bundle with the current model.
+type diffBundleCommand struct {
+	modelcmd.ModelCommandBase
+	out        cmd.Output
+	api        DiffBundleAPI
+	bundlePath string
+}
+
+// DiffBundleAPI specifies the used function calls of the Bundle facade.
+type DiffBundleAPI interface {
+	Close() error
+	DiffBundle(bundleYAML string) (*params.BundleDiff, error)
+}
+
+// Info is part of the cmd.Command interface.
+func (c *diffBundleCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "diff-bundle",
+		Args:    "<bundle file or directory>",
+		Purpose: usageDiffBundleSummary,
+		Doc:     usageDiffBundleDetails,
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *diffBundleCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatBundleDiffTabular,
+	})
+}
+
+// Init is part of the cmd.Command interface.
+func (c *diffBundleCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no bundle specified")
+	}
+	c.bundlePath = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+func (c *diffBundleCommand) getAPI() (DiffBundleAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return bundle.NewClient(root), nil
+}
+
+// Run is part of the cmd.Command interface.
+func (c *diffBundleCommand) Run(ctx *cmd.Context) error {
+	path := ctx.AbsPath(c.bundlePath)
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		path = filepath.Join(path, "bundle.yaml")
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return errors.Annotate(err, "cannot read bundle")
+	}
+
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	diff, err := client.DiffBundle(string(data))
+	if err != nil {
+		return err
+	}
+	return c.out.Write(ctx, formatBundleDiff(diff))
+}
+
+// bundleDiffOutput is the yaml and json representation of the
+// differences between a bundle and a model.
+type bundleDiffOutput struct {
+	Applications map[string]applicationDiffOutput `yaml:"applications,omitempty" json:"applications,omitempty"`
+	Relations    *relationsDiffOutput             `yaml:"relations,omitempty" json:"relations,omitempty"`
+}
+
+type applicationDiffOutput struct {
+	Missing     string                     `yaml:"missing,omitempty" json:"missing,omitempty"`
+	Charm       *valueDiffOutput           `yaml:"charm,omitempty" json:"charm,omitempty"`
+	Series      *valueDiffOutput           `yaml:"series,omitempty" json:"series,omitempty"`
+	NumUnits    *valueDiffOutput           `yaml:"num_units,omitempty" json:"num_units,omitempty"`
+	Expose      *valueDiffOutput           `yaml:"expose,omitempty" json:"expose,omitempty"`
+	Options     map[string]valueDiffOutput `yaml:"options,omitempty" json:"options,omitempty"`
+	Constraints *valueDiffOutput           `yaml:"constraints,omitempty" json:"constraints,omitempty"`
+}
+
+type valueDiffOutput struct {
+	Bundle interface{} `yaml:"bundle" json:"bundle"`
+	Model  interface{} `yaml:"model" json:"model"`
+}
+
+type relationsDiffOutput struct {
+	BundleAdditions [][]string `yaml:"bundle-additions,omitempty" json:"bundle-additions,omitempty"`
+	ModelAdditions  [][]string `yaml:"model-additions,omitempty" json:"model-additions,omitempty"`
+}
+
+func formatBundleDiff(diff *params.BundleDiff) bundleDiffOutput {
+	var out bundleDiffOutput
+	if diff == nil {
+		return out
+	}
+	for name, app := range diff.Applications {
+		appOut := applicationDiffOutput{Missing: app.Missing}
+		if app.Charm != nil {
+			appOut.Charm = &valueDiffOutput{app.Charm.Bundle, app.Charm.Model}
+		}
+		if app.Series != nil {
+			appOut.Series = &valueDiffOutput{app.Series.Bundle, app.Series.Model}
+		}
+		if app.NumUnits != nil {
+			appOut.NumUnits = &valueDiffOutput{app.NumUnits.Bundle, app.NumUnits.Model}
+		}
+		if app.Expose != nil {
+			appOut.Expose = &valueDiffOutput{app.Expose.Bundle, app.Expose.Model}
+		}
+		for key, value := range app.Options {
+			if appOut.Options == nil {
+				appOut.Options = make(map[string]valueDiffOutput)
+			}
+			appOut.Options[key] = valueDiffOutput{value.Bundle, value.Model}
+		}
+		if app.Constraints != nil {
+			appOut.Constraints = &valueDiffOutput{app.Constraints.Bundle, app.Constraints.Model}
+		}
+		if out.Applications == nil {
+			out.Applications = make(map[string]applicationDiffOutput)
+		}
+		out.Applications[name] = appOut
+	}
+	if diff.Relations != nil {
+		out.Relations = &relationsDiffOutput{
+			BundleAdditions: diff.Relations.BundleAdditions,
+			ModelAdditions:  diff.Relations.ModelAdditions,
+		}
+	}
+	return out
+}
+
+// formatBundleDiffTabular writes a human readable summary of the
+// differences between a bundle and a model.
+func formatBundleDiffTabular(writer io.Writer, value interface{}) error {
+	diff, ok := value.(bundleDiffOutput)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", diff, value)
+	}
+	if len(diff.Applications) == 0 && diff.Relations == nil {
+		fmt.Fprintln(writer, "The bundle and the model match.")
+		return nil
+	}
+	tw := output.TabWriter(writer)
+	if len(diff.Applications) > 0 {
+		fmt.Fprintln(tw, "Application\tField\tBundle\tModel")
+		var appNames []string
+		for name := range diff.Applications {
+			appNames = append(appNames, name)
+		}
+		sort.Strings(appNames)
+		for _, name := range appNames {
+			for _, row := range applicationDiffRows(diff.Applications[name]) {
+				fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", name, row[0], row[1], row[2])
+			}
+		}
+	}
+	if diff.Relations != nil {
+		if len(diff.Applications) > 0 {
+			fmt.Fprintln(tw)
+		}
+		fmt.Fprintln(tw, "Relation\tOnly in")
+		for _, rel := range diff.Relations.BundleAdditions {
+			fmt.Fprintf(tw, "%s\tbundle\n", strings.Join(rel, " "))
+		}
+		for _, rel := range diff.Relations.ModelAdditions {
+			fmt.Fprintf(tw, "%s\tmodel\n", strings.Join(rel, " "))
+		}
+	}
+	tw.Flush()
+	return nil
+}
+
+// applicationDiffRows returns the field, bundle value and model value
+// of each difference in an application, ordered by field.
+func applicationDiffRows(app applicationDiffOutput) [][3]string {
+	switch app.Missing {
+	case "model":
+		return [][3]string{{"", "present", "missing"}}
+	case "bundle":
+		return [][3]string{{"", "missing", "present"}}
+	}
+	var rows [][3]string
+	add := func(field string, diff *valueDiffOutput) {
+		if diff != nil {
+			rows = append(rows, [3]string{field, formatDiffValue(diff.Bundle), formatDiffValue(diff.Model)})
+		}
+	}
+	add("charm", app.Charm)
+	add("series", app.Series)
+	add("num_units", app.NumUnits)
+	add("expose", app.Expose)
+	var keys []string
+	for key := range app.Options {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		diff := app.Options[key]
+		add("options."+key, &diff)
+	}
+	add("constraints", app.Constraints)
+	return rows
+}
+
+func formatDiffValue(v interface{}) string {
+	if v == nil || v == "" {
+		return "-"
+	}
+	return fmt.Sprint(v)
+}
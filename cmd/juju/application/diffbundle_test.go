@@ -0,0 +1,179 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type DiffBundleSuite struct {
+	coretesting.FakeJujuXDGDataHomeSuite
+	api        *mockDiffBundleAPI
+	store      *jujuclienttesting.MemStore
+	bundlePath string
+}
+
+var _ = gc.Suite(&DiffBundleSuite{})
+
+const diffBundleYAML = `
+applications:
+  wordpress:
+    charm: cs:wordpress
+    num_units: 2
+`
+
+func (s *DiffBundleSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.api = &mockDiffBundleAPI{Stub: &testing.Stub{}}
+	s.store = jujuclienttesting.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	err := s.store.UpdateModel("testing", "admin/mymodel", jujuclient.ModelDetails{
+		coretesting.ModelTag.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.store.Models["testing"].CurrentModel = "admin/mymodel"
+
+	dir := c.MkDir()
+	s.bundlePath = filepath.Join(dir, "bundle.yaml")
+	err = ioutil.WriteFile(s.bundlePath, []byte(diffBundleYAML), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *DiffBundleSuite) runDiffBundle(c *gc.C, args ...string) (string, error) {
+	ctx, err := coretesting.RunCommand(c, application.NewDiffBundleCommandForTest(s.api, s.store), args...)
+	if err != nil {
+		return "", err
+	}
+	return coretesting.Stdout(ctx), nil
+}
+
+func (s *DiffBundleSuite) TestNoArguments(c *gc.C) {
+	_, err := s.runDiffBundle(c)
+	c.Assert(err, gc.ErrorMatches, "no bundle specified")
+}
+
+func (s *DiffBundleSuite) TestTooManyArguments(c *gc.C) {
+	_, err := s.runDiffBundle(c, "bundle.yaml", "extra")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+func (s *DiffBundleSuite) TestMissingBundle(c *gc.C) {
+	_, err := s.runDiffBundle(c, filepath.Join(c.MkDir(), "nope.yaml"))
+	c.Assert(err, gc.ErrorMatches, "cannot read bundle: .*")
+	s.api.CheckNoCalls(c)
+}
+
+func (s *DiffBundleSuite) TestNoDifferences(c *gc.C) {
+	s.api.diff = &params.BundleDiff{}
+	out, err := s.runDiffBundle(c, filepath.Dir(s.bundlePath))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, "The bundle and the model match.\n")
+	s.api.CheckCalls(c, []testing.StubCall{
+		{"DiffBundle", []interface{}{diffBundleYAML}},
+		{"Close", nil},
+	})
+}
+
+func (s *DiffBundleSuite) TestTabular(c *gc.C) {
+	s.api.diff = testBundleDiff
+	out, err := s.runDiffBundle(c, s.bundlePath)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, `
+Application  Field               Bundle     Model
+haproxy                          present    missing
+wordpress    num_units           2          1
+wordpress    options.blog-title  Imported   -
+wordpress    constraints         mem=4096M  -
+
+Relation                   Only in
+haproxy wordpress          bundle
+mysql:server wordpress:db  model
+`[1:])
+}
+
+func (s *DiffBundleSuite) TestYAML(c *gc.C) {
+	s.api.diff = testBundleDiff
+	out, err := s.runDiffBundle(c, s.bundlePath, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, `
+applications:
+  haproxy:
+    missing: model
+  wordpress:
+    num_units:
+      bundle: 2
+      model: 1
+    options:
+      blog-title:
+        bundle: Imported
+        model: null
+    constraints:
+      bundle: mem=4096M
+      model: ""
+relations:
+  bundle-additions:
+  - - haproxy
+    - wordpress
+  model-additions:
+  - - mysql:server
+    - wordpress:db
+`[1:])
+}
+
+func (s *DiffBundleSuite) TestAPIError(c *gc.C) {
+	s.api.SetErrors(errors.New("the provided bundle has the following errors:\nboom"))
+	_, err := s.runDiffBundle(c, s.bundlePath)
+	c.Assert(err, gc.ErrorMatches, "the provided bundle has the following errors:\nboom")
+}
+
+var testBundleDiff = &params.BundleDiff{
+	Applications: map[string]params.BundleApplicationDiff{
+		"haproxy": {Missing: "model"},
+		"wordpress": {
+			NumUnits: &params.BundleIntDiff{Bundle: 2, Model: 1},
+			Options: map[string]params.BundleValueDiff{
+				"blog-title": {Bundle: "Imported"},
+			},
+			Constraints: &params.BundleStringDiff{Bundle: "mem=4096M"},
+		},
+	},
+	Relations: &params.BundleRelationsDiff{
+		BundleAdditions: [][]string{{"haproxy", "wordpress"}},
+		ModelAdditions:  [][]string{{"mysql:server", "wordpress:db"}},
+	},
+}
+
+type mockDiffBundleAPI struct {
+	*testing.Stub
+	diff *params.BundleDiff
+}
+
+func (m *mockDiffBundleAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return m.NextErr()
+}
+
+func (m *mockDiffBundleAPI) DiffBundle(bundleYAML string) (*params.BundleDiff, error) {
+	m.MethodCall(m, "DiffBundle", bundleYAML)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.diff, nil
+}
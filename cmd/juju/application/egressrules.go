@@ -0,0 +1,234 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/network"
+)
+
+var usageSetEgressRulesSummary = `
+Restricts the outgoing traffic of an application's machines.`[1:]
+
+var usageSetEgressRulesDetails = `
+Egress rules limit the destinations to which the machines hosting an
+application's units may send traffic. Until an application on a machine
+has egress rules, the machine's outgoing traffic is unrestricted. Once it
+has, outgoing traffic is allowed only if it matches the egress rules of
+one of the applications on the machine, or if it is destined for the
+controller. Charms may add their own egress rules, which are shown by
+get-egress-rules.
+
+Each rule takes the form <port-range>[@<cidr>[,<cidr>...]], where the
+port range is a port or range of ports, optionally followed by a protocol
+of "tcp" (the default) or "udp". A rule without CIDRs allows traffic to
+any destination.
+
+The given rules replace those previously set for the application; use
+--reset to remove them all. Only model administrators may set egress
+rules. Egress rules are enforced only on clouds whose firewalls support
+them; currently these are Amazon EC2 in a VPC, Google Compute Engine,
+and OpenStack with Neutron security groups.
+
+Examples:
+    juju set-egress-rules mysql 53/udp@10.0.0.2/32 443/tcp
+    juju set-egress-rules wordpress 80-443/tcp@10.0.0.0/8,192.168.0.0/16
+    juju set-egress-rules --reset mysql
+
+See also:
+    get-egress-rules
+    expose`[1:]
+
+var usageGetEgressRulesSummary = `
+Displays the egress rules of an application.`[1:]
+
+var usageGetEgressRulesDetails = `
+Shows the egress rules set for an application by operators, with
+set-egress-rules, and those set by its charm.
+
+Examples:
+    juju get-egress-rules mysql
+    juju get-egress-rules --format json mysql
+
+See also:
+    set-egress-rules`[1:]
+
+type applicationEgressRulesAPI interface {
+	Close() error
+	SetEgressRules(application string, rules []network.EgressRule) error
+	GetEgressRules(application string) ([]network.EgressRule, []network.EgressRule, error)
+}
+
+func newEgressRulesAPIFunc(c *modelcmd.ModelCommandBase) func() (applicationEgressRulesAPI, error) {
+	return func() (applicationEgressRulesAPI, error) {
+		root, err := c.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+}
+
+// NewSetEgressRulesCommand returns a command to set the egress rules
+// of an application.
+func NewSetEgressRulesCommand() cmd.Command {
+	cmd := &setEgressRulesCommand{}
+	cmd.newAPIFunc = newEgressRulesAPIFunc(&cmd.ModelCommandBase)
+	return modelcmd.Wrap(cmd)
+}
+
+// setEgressRulesCommand is responsible for setting the egress rules
+// of applications.
+type setEgressRulesCommand struct {
+	modelcmd.ModelCommandBase
+	ApplicationName string
+	Rules           []network.EgressRule
+	Reset           bool
+	newAPIFunc      func() (applicationEgressRulesAPI, error)
+}
+
+func (c *setEgressRulesCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "set-egress-rules",
+		Args:    "<application name> [<rule> ...]",
+		Purpose: usageSetEgressRulesSummary,
+		Doc:     usageSetEgressRulesDetails,
+	}
+}
+
+func (c *setEgressRulesCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.Reset, "reset", false, "Remove all egress rules set for the application")
+}
+
+func (c *setEgressRulesCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application name specified")
+	}
+	if !names.IsValidApplication(args[0]) {
+		return errors.Errorf("invalid application name %q", args[0])
+	}
+	c.ApplicationName, args = args[0], args[1:]
+	if c.Reset {
+		if len(args) > 0 {
+			return errors.New("cannot specify egress rules with --reset")
+		}
+		return nil
+	}
+	if len(args) == 0 {
+		return errors.New("no egress rules specified")
+	}
+	for _, arg := range args {
+		rule, err := network.ParseEgressRule(arg)
+		if err != nil {
+			return errors.Annotatef(err, "invalid egress rule %q", arg)
+		}
+		c.Rules = append(c.Rules, rule)
+	}
+	return nil
+}
+
+// Run replaces the egress rules of the application.
+func (c *setEgressRulesCommand) Run(_ *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	return block.ProcessBlockedError(client.SetEgressRules(c.ApplicationName, c.Rules), block.BlockChange)
+}
+
+// NewGetEgressRulesCommand returns a command to display the egress rules
+// of an application.
+func NewGetEgressRulesCommand() cmd.Command {
+	cmd := &getEgressRulesCommand{}
+	cmd.newAPIFunc = newEgressRulesAPIFunc(&cmd.ModelCommandBase)
+	return modelcmd.Wrap(cmd)
+}
+
+// getEgressRulesCommand is responsible for displaying the egress rules
+// of applications.
+type getEgressRulesCommand struct {
+	modelcmd.ModelCommandBase
+	ApplicationName string
+	out             cmd.Output
+	newAPIFunc      func() (applicationEgressRulesAPI, error)
+}
+
+func (c *getEgressRulesCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "get-egress-rules",
+		Args:    "<application name>",
+		Purpose: usageGetEgressRulesSummary,
+		Doc:     usageGetEgressRulesDetails,
+	}
+}
+
+func (c *getEgressRulesCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+}
+
+func (c *getEgressRulesCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application name specified")
+	}
+	if !names.IsValidApplication(args[0]) {
+		return errors.Errorf("invalid application name %q", args[0])
+	}
+	c.ApplicationName = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// egressRulesOutput is the serialisation format of get-egress-rules.
+type egressRulesOutput struct {
+	Rules      []string `yaml:"rules,omitempty" json:"rules,omitempty"`
+	CharmRules []string `yaml:"charm-rules,omitempty" json:"charm-rules,omitempty"`
+}
+
+// Run displays the egress rules of the application.
+func (c *getEgressRulesCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	rules, charmRules, err := client.GetEgressRules(c.ApplicationName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.out.Write(ctx, egressRulesOutput{
+		Rules:      formatEgressRules(rules),
+		CharmRules: formatEgressRules(charmRules),
+	})
+}
+
+// formatEgressRules returns the rules in the form accepted by
+// set-egress-rules.
+func formatEgressRules(rules []network.EgressRule) []string {
+	if len(rules) == 0 {
+		return nil
+	}
+	result := make([]string, len(rules))
+	for i, rule := range rules {
+		result[i] = rule.PortRange.String()
+		destinations := strings.Join(rule.DestinationCIDRs, ",")
+		if destinations != "" && destinations != "0.0.0.0/0" {
+			result[i] += "@" + destinations
+		}
+	}
+	return result
+}
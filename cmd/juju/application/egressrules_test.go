@@ -0,0 +1,153 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/network"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type EgressRulesSuite struct {
+	testing.IsolationSuite
+	mockAPI *mockEgressRulesAPI
+}
+
+func (s *EgressRulesSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockEgressRulesAPI{Stub: &testing.Stub{}}
+}
+
+var _ = gc.Suite(&EgressRulesSuite{})
+
+func (s *EgressRulesSuite) runSet(c *gc.C, args ...string) error {
+	_, err := coretesting.RunCommand(c, NewSetEgressRulesCommandForTest(s.mockAPI), args...)
+	return err
+}
+
+func (s *EgressRulesSuite) runGet(c *gc.C, args ...string) (string, error) {
+	ctx, err := coretesting.RunCommand(c, NewGetEgressRulesCommandForTest(s.mockAPI), args...)
+	if err != nil {
+		return "", err
+	}
+	return coretesting.Stdout(ctx), nil
+}
+
+func (s *EgressRulesSuite) TestSetInit(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no application name specified",
+	}, {
+		args: []string{"mysql"},
+		err:  "no egress rules specified",
+	}, {
+		args: []string{"mysql/0", "443/tcp"},
+		err:  `invalid application name "mysql/0"`,
+	}, {
+		args: []string{"mysql", "foo/tcp"},
+		err:  `invalid egress rule "foo/tcp": invalid port "foo": .*`,
+	}, {
+		args: []string{"mysql", "443/tcp@10.0.0.0"},
+		err:  `invalid egress rule "443/tcp@10.0.0.0": invalid CIDR address: 10.0.0.0`,
+	}, {
+		args: []string{"--reset", "mysql", "443/tcp"},
+		err:  "cannot specify egress rules with --reset",
+	}} {
+		c.Logf("test %d: %q", i, t.args)
+		err := s.runSet(c, t.args...)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+	s.mockAPI.CheckNoCalls(c)
+}
+
+func (s *EgressRulesSuite) TestSet(c *gc.C) {
+	err := s.runSet(c, "mysql", "53/UDP@10.0.0.2/32,10.0.0.3/32", "443/tcp")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"SetEgressRules", []interface{}{"mysql", []network.EgressRule{
+			network.MustNewEgressRule("udp", 53, 53, "10.0.0.2/32", "10.0.0.3/32"),
+			network.MustNewEgressRule("tcp", 443, 443),
+		}}},
+		{"Close", nil},
+	})
+}
+
+func (s *EgressRulesSuite) TestSetReset(c *gc.C) {
+	err := s.runSet(c, "--reset", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"SetEgressRules", []interface{}{"mysql", []network.EgressRule(nil)}},
+		{"Close", nil},
+	})
+}
+
+func (s *EgressRulesSuite) TestSetBlocked(c *gc.C) {
+	s.mockAPI.SetErrors(common.OperationBlockedError("TestSetBlocked"))
+	err := s.runSet(c, "mysql", "443/tcp")
+	coretesting.AssertOperationWasBlocked(c, err, ".*TestSetBlocked.*")
+}
+
+func (s *EgressRulesSuite) TestGet(c *gc.C) {
+	s.mockAPI.rules = []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "0.0.0.0/0"),
+		network.MustNewEgressRule("udp", 53, 53, "10.0.0.2/32", "10.0.0.3/32"),
+	}
+	s.mockAPI.charmRules = []network.EgressRule{
+		network.MustNewEgressRule("tcp", 8000, 8080, "192.168.0.0/16"),
+	}
+	out, err := s.runGet(c, "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, `
+rules:
+- 443/tcp
+- 53/udp@10.0.0.2/32,10.0.0.3/32
+charm-rules:
+- 8000-8080/tcp@192.168.0.0/16
+`[1:])
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"GetEgressRules", []interface{}{"mysql"}},
+		{"Close", nil},
+	})
+
+	out, err = s.runGet(c, "--format", "json", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, `{"rules":["443/tcp","53/udp@10.0.0.2/32,10.0.0.3/32"],"charm-rules":["8000-8080/tcp@192.168.0.0/16"]}`+"\n")
+}
+
+func (s *EgressRulesSuite) TestGetFail(c *gc.C) {
+	s.mockAPI.SetErrors(errors.New(`application "mysql" not found`))
+	_, err := s.runGet(c, "mysql")
+	c.Assert(err, gc.ErrorMatches, `application "mysql" not found`)
+}
+
+type mockEgressRulesAPI struct {
+	*testing.Stub
+	rules      []network.EgressRule
+	charmRules []network.EgressRule
+}
+
+func (s *mockEgressRulesAPI) Close() error {
+	s.MethodCall(s, "Close")
+	return s.NextErr()
+}
+
+func (s *mockEgressRulesAPI) SetEgressRules(application string, rules []network.EgressRule) error {
+	s.MethodCall(s, "SetEgressRules", application, rules)
+	return s.NextErr()
+}
+
+func (s *mockEgressRulesAPI) GetEgressRules(application string) ([]network.EgressRule, []network.EgressRule, error) {
+	s.MethodCall(s, "GetEgressRules", application)
+	if err := s.NextErr(); err != nil {
+		return nil, nil, err
+	}
+	return s.rules, s.charmRules, nil
+}
@@ -74,6 +74,32 @@ func NewConsumeCommandForTest(api applicationConsumeAPI) cmd.Command {
 	return modelcmd.Wrap(&consumeCommand{api: api})
 }
 
+// NewTrustCommandForTest returns a TrustCommand with the api provided as specified.
+func NewTrustCommandForTest(api applicationTrustAPI) cmd.Command {
+	cmd := &trustCommand{newAPIFunc: func() (applicationTrustAPI, error) {
+		return api, nil
+	}}
+	return modelcmd.Wrap(cmd)
+}
+
+// NewSetEgressRulesCommandForTest returns a SetEgressRulesCommand with
+// the api provided as specified.
+func NewSetEgressRulesCommandForTest(api applicationEgressRulesAPI) cmd.Command {
+	cmd := &setEgressRulesCommand{newAPIFunc: func() (applicationEgressRulesAPI, error) {
+		return api, nil
+	}}
+	return modelcmd.Wrap(cmd)
+}
+
+// NewGetEgressRulesCommandForTest returns a GetEgressRulesCommand with
+// the api provided as specified.
+func NewGetEgressRulesCommandForTest(api applicationEgressRulesAPI) cmd.Command {
+	cmd := &getEgressRulesCommand{newAPIFunc: func() (applicationEgressRulesAPI, error) {
+		return api, nil
+	}}
+	return modelcmd.Wrap(cmd)
+}
+
 type Patcher interface {
 	PatchValue(dest, value interface{})
 }
@@ -86,3 +112,10 @@ func PatchNewCharmStoreClient(s Patcher, url string) {
 		})
 	})
 }
+
+// NewDiffBundleCommandForTest returns a DiffBundleCommand with the api provided as specified.
+func NewDiffBundleCommandForTest(api DiffBundleAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &diffBundleCommand{api: api}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
@@ -4,10 +4,15 @@
 package application
 
 import (
+	"net"
+	"strings"
+
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 
 	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
 )
@@ -19,8 +24,25 @@ var usageExposeDetails = `
 Adjusts the firewall rules and any relevant security mechanisms of the
 cloud to allow public access to the application.
 
+By default, the ports opened by the application's units may be accessed
+from anywhere. Access may instead be limited to particular sources with
+--to-cidrs, which takes a comma-separated list of CIDRs, and --to-spaces,
+which takes a comma-separated list of spaces whose subnets are allowed
+access.
+
+The --endpoints option takes a comma-separated list of application
+endpoints to which the expose settings apply. The ports a unit opens for
+a particular endpoint may be accessed from the sources that endpoint is
+exposed to, or if it has no expose settings, from the sources given for
+all endpoints. The ports a unit opens for all of its endpoints may be
+accessed from the sources of every exposed endpoint. Running the command
+again for the same endpoints replaces their expose settings, and expose
+settings for other endpoints are kept.
+
 Examples:
     juju expose wordpress
+    juju expose wordpress --to-cidrs 10.0.0.0/24,192.168.1.0/24
+    juju expose mysql --endpoints db --to-spaces internal
 
 See also: 
     unexpose`[1:]
@@ -34,6 +56,12 @@ func NewExposeCommand() cmd.Command {
 type exposeCommand struct {
 	modelcmd.ModelCommandBase
 	ApplicationName string
+
+	endpoints string
+	toCIDRs   string
+	toSpaces  string
+
+	exposedEndpoints map[string]params.ExposedEndpoint
 }
 
 func (c *exposeCommand) Info() *cmd.Info {
@@ -45,18 +73,65 @@ func (c *exposeCommand) Info() *cmd.Info {
 	}
 }
 
+// SetFlags implements Command.SetFlags.
+func (c *exposeCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.endpoints, "endpoints", "", "Comma-separated list of endpoints to expose")
+	f.StringVar(&c.toCIDRs, "to-cidrs", "", "Comma-separated list of CIDRs allowed access")
+	f.StringVar(&c.toSpaces, "to-spaces", "", "Comma-separated list of spaces allowed access")
+}
+
 func (c *exposeCommand) Init(args []string) error {
 	if len(args) == 0 {
 		return errors.New("no application name specified")
 	}
 	c.ApplicationName = args[0]
-	return cmd.CheckEmpty(args[1:])
+	if err := cmd.CheckEmpty(args[1:]); err != nil {
+		return err
+	}
+
+	toCIDRs := splitCommaList(c.toCIDRs)
+	for _, cidr := range toCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return errors.NotValidf("CIDR %q", cidr)
+		}
+	}
+	toSpaces := splitCommaList(c.toSpaces)
+	endpoints := splitCommaList(c.endpoints)
+	if len(endpoints) == 0 && len(toCIDRs) == 0 && len(toSpaces) == 0 {
+		return nil
+	}
+	if len(endpoints) == 0 {
+		// The empty endpoint name applies to all endpoints.
+		endpoints = []string{""}
+	}
+	c.exposedEndpoints = make(map[string]params.ExposedEndpoint)
+	for _, endpoint := range endpoints {
+		c.exposedEndpoints[endpoint] = params.ExposedEndpoint{
+			ExposeToSpaces: toSpaces,
+			ExposeToCIDRs:  toCIDRs,
+		}
+	}
+	return nil
+}
+
+// splitCommaList splits a comma-separated list, ignoring empty items.
+func splitCommaList(s string) []string {
+	var result []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
 }
 
 type serviceExposeAPI interface {
 	Close() error
 	Expose(serviceName string) error
+	ExposeEndpoints(serviceName string, exposed map[string]params.ExposedEndpoint) error
 	Unexpose(serviceName string) error
+	UnexposeEndpoints(serviceName string, endpoints []string) error
 }
 
 func (c *exposeCommand) getAPI() (serviceExposeAPI, error) {
@@ -75,5 +150,10 @@ func (c *exposeCommand) Run(_ *cmd.Context) error {
 		return err
 	}
 	defer client.Close()
-	return block.ProcessBlockedError(client.Expose(c.ApplicationName), block.BlockChange)
+	if len(c.exposedEndpoints) > 0 {
+		err = client.ExposeEndpoints(c.ApplicationName, c.exposedEndpoints)
+	} else {
+		err = client.Expose(c.ApplicationName)
+	}
+	return block.ProcessBlockedError(err, block.BlockChange)
 }
@@ -11,6 +11,7 @@ import (
 
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/rpc"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/testcharms"
 	"github.com/juju/juju/testing"
 )
@@ -59,6 +60,56 @@ func (s *ExposeSuite) TestExpose(c *gc.C) {
 	})
 }
 
+func (s *ExposeSuite) TestExposeEndpoints(c *gc.C) {
+	_, err := s.State.AddSpace("dmz", "", nil, true)
+	c.Assert(err, jc.ErrorIsNil)
+	ch := testcharms.Repo.CharmArchivePath(s.CharmsPath, "mysql")
+	err = runDeploy(c, ch, "--series", "trusty")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = runExpose(c, "mysql", "--to-cidrs", "10.0.0.0/24,10.0.1.0/24")
+	c.Assert(err, jc.ErrorIsNil)
+	err = runExpose(c, "mysql", "--endpoints", "server", "--to-spaces", "dmz")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertExposed(c, "mysql")
+
+	app, err := s.State.Application("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.ExposedEndpoints(), jc.DeepEquals, map[string]state.ExposedEndpoint{
+		"":       {ExposeToCIDRs: []string{"10.0.0.0/24", "10.0.1.0/24"}},
+		"server": {ExposeToSpaces: []string{"dmz"}},
+	})
+
+	err = runUnexpose(c, "mysql", "--endpoints", "server")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertExposed(c, "mysql")
+	err = app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.ExposedEndpoints(), jc.DeepEquals, map[string]state.ExposedEndpoint{
+		"": {ExposeToCIDRs: []string{"10.0.0.0/24", "10.0.1.0/24"}},
+	})
+}
+
+func (s *ExposeSuite) TestExposeInitErrors(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no application name specified",
+	}, {
+		args: []string{"mysql", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}, {
+		args: []string{"mysql", "--to-cidrs", "10.0.0.0"},
+		err:  `CIDR "10.0.0.0" not valid`,
+	}} {
+		c.Logf("test %d", i)
+		err := testing.InitCommand(NewExposeCommand(), t.args)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
 func (s *ExposeSuite) TestBlockExpose(c *gc.C) {
 	ch := testcharms.Repo.CharmArchivePath(s.CharmsPath, "dummy")
 	err := runDeploy(c, ch, "some-application-name", "--series", "trusty")
@@ -0,0 +1,93 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageTrustSummary = `
+Grants an application access to the model's cloud credential.`[1:]
+
+var usageTrustDetails = `
+Trusted applications may obtain the cloud credential and cloud details of
+the model from within their hooks, using the credential-get hook tool.
+This allows charms that integrate with the cloud, such as storage or load
+balancer integrators, to use the cloud API without having credentials
+supplied in their configuration.
+
+Only model administrators may trust an application. Access to the
+credential by trusted applications is recorded in the audit log.
+
+Examples:
+    juju trust aws-integrator
+    juju trust --remove aws-integrator
+
+See also: 
+    expose`[1:]
+
+// NewTrustCommand returns a command to grant or revoke an application's
+// access to the model's cloud credential.
+func NewTrustCommand() cmd.Command {
+	cmd := &trustCommand{}
+	cmd.newAPIFunc = func() (applicationTrustAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// trustCommand is responsible for trusting applications.
+type trustCommand struct {
+	modelcmd.ModelCommandBase
+	ApplicationName string
+	Remove          bool
+	newAPIFunc      func() (applicationTrustAPI, error)
+}
+
+func (c *trustCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "trust",
+		Args:    "<application name>",
+		Purpose: usageTrustSummary,
+		Doc:     usageTrustDetails,
+	}
+}
+
+func (c *trustCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.Remove, "remove", false, "Revoke the application's access to the cloud credential")
+}
+
+func (c *trustCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application name specified")
+	}
+	c.ApplicationName = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+type applicationTrustAPI interface {
+	Close() error
+	SetTrust(application string, trust bool) error
+}
+
+// Run sets or clears the trusted flag of the application.
+func (c *trustCommand) Run(_ *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	return block.ProcessBlockedError(client.SetTrust(c.ApplicationName, !c.Remove), block.BlockChange)
+}
@@ -6,6 +6,7 @@ package application
 import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 
 	"github.com/juju/juju/api/application"
 	"github.com/juju/juju/cmd/juju/block"
@@ -20,8 +21,13 @@ Adjusts the firewall rules and any relevant security mechanisms of the
 cloud to deny public access to the application.
 An application is unexposed by default when it gets created.
 
+The --endpoints option takes a comma-separated list of endpoints whose
+expose settings are to be removed. The application remains exposed if
+expose settings for other endpoints remain.
+
 Examples:
     juju unexpose wordpress
+    juju unexpose mysql --endpoints db
 
 See also: 
     expose`[1:]
@@ -35,6 +41,8 @@ func NewUnexposeCommand() cmd.Command {
 type unexposeCommand struct {
 	modelcmd.ModelCommandBase
 	ApplicationName string
+
+	endpoints string
 }
 
 func (c *unexposeCommand) Info() *cmd.Info {
@@ -46,6 +54,12 @@ func (c *unexposeCommand) Info() *cmd.Info {
 	}
 }
 
+// SetFlags implements Command.SetFlags.
+func (c *unexposeCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.endpoints, "endpoints", "", "Comma-separated list of endpoints to unexpose")
+}
+
 func (c *unexposeCommand) Init(args []string) error {
 	if len(args) == 0 {
 		return errors.New("no application name specified")
@@ -70,5 +84,10 @@ func (c *unexposeCommand) Run(_ *cmd.Context) error {
 		return err
 	}
 	defer client.Close()
-	return block.ProcessBlockedError(client.Unexpose(c.ApplicationName), block.BlockChange)
+	if endpoints := splitCommaList(c.endpoints); len(endpoints) > 0 {
+		err = client.UnexposeEndpoints(c.ApplicationName, endpoints)
+	} else {
+		err = client.Unexpose(c.ApplicationName)
+	}
+	return block.ProcessBlockedError(err, block.BlockChange)
 }
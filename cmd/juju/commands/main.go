@@ -37,6 +37,7 @@ import (
 	"github.com/juju/juju/cmd/juju/metricsdebug"
 	"github.com/juju/juju/cmd/juju/model"
 	rcmd "github.com/juju/juju/cmd/juju/romulus/commands"
+	"github.com/juju/juju/cmd/juju/secrets"
 	"github.com/juju/juju/cmd/juju/setmeterstatus"
 	"github.com/juju/juju/cmd/juju/space"
 	"github.com/juju/juju/cmd/juju/status"
@@ -287,6 +288,8 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(status.NewStatusCommand())
 	r.Register(newSwitchCommand())
 	r.Register(status.NewStatusHistoryCommand())
+	r.Register(status.NewHookHistoryCommand())
+	r.Register(status.NewWaitCommand())
 
 	// Error resolution and debugging commands.
 	r.Register(newDefaultRunCommand())
@@ -351,6 +354,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(model.NewDestroyCommand())
 	r.Register(model.NewGrantCommand())
 	r.Register(model.NewRevokeCommand())
+	r.Register(model.NewExportBundleCommand())
 	r.Register(model.NewShowCommand())
 
 	r.Register(newMigrateCommand())
@@ -364,6 +368,12 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(action.NewRunCommand())
 	r.Register(action.NewShowOutputCommand())
 	r.Register(action.NewListCommand())
+	r.Register(action.NewCancelCommand())
+	r.Register(action.NewScheduleCommand())
+	r.Register(action.NewListSchedulesCommand())
+	r.Register(action.NewEnableScheduleCommand())
+	r.Register(action.NewDisableScheduleCommand())
+	r.Register(action.NewRemoveScheduleCommand())
 
 	// Manage controller availability
 	r.Register(newEnableHACommand())
@@ -372,8 +382,12 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(application.NewAddUnitCommand())
 	r.Register(application.NewConfigCommand())
 	r.Register(application.NewDefaultDeployCommand())
+	r.Register(application.NewDiffBundleCommand())
 	r.Register(application.NewExposeCommand())
 	r.Register(application.NewUnexposeCommand())
+	r.Register(application.NewTrustCommand())
+	r.Register(application.NewSetEgressRulesCommand())
+	r.Register(application.NewGetEgressRulesCommand())
 	r.Register(application.NewServiceGetConstraintsCommand())
 	r.Register(application.NewServiceSetConstraintsCommand())
 
@@ -382,6 +396,14 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(block.NewListCommand())
 	r.Register(block.NewEnableCommand())
 
+	// Manage secrets
+	r.Register(secrets.NewAddCommand())
+	r.Register(secrets.NewRotateCommand())
+	r.Register(secrets.NewGrantCommand())
+	r.Register(secrets.NewRevokeCommand())
+	r.Register(secrets.NewRemoveCommand())
+	r.Register(secrets.NewListCommand())
+
 	// Manage storage
 	r.Register(storage.NewAddCommand())
 	r.Register(storage.NewListCommand())
@@ -389,6 +411,10 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(storage.NewPoolListCommand())
 	r.Register(storage.NewShowCommand())
 	r.Register(storage.NewRemoveStorageCommandWithAPI())
+	r.Register(storage.NewCreateSnapshotCommandWithAPI())
+	r.Register(storage.NewRemoveSnapshotCommandWithAPI())
+	r.Register(storage.NewResizeCommandWithAPI())
+	r.Register(storage.NewImportFilesystemCommandWithAPI())
 	if featureflag.Enabled(feature.PersistentStorage) {
 		r.Register(storage.NewDetachStorageCommandWithAPI())
 		r.Register(storage.NewAttachStorageCommandWithAPI())
@@ -422,6 +448,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(controller.NewEnableDestroyControllerCommand())
 	r.Register(controller.NewShowControllerCommand())
 	r.Register(controller.NewGetConfigCommand())
+	r.Register(controller.NewAuditLogCommand())
 
 	// Debug Metrics
 	r.Register(metricsdebug.New())
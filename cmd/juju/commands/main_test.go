@@ -393,6 +393,7 @@ var commandNames = []string{
 	"add-machine",
 	"add-model",
 	"add-relation",
+	"add-secret",
 	"add-space",
 	"add-ssh-key",
 	"add-storage",
@@ -403,11 +404,13 @@ var commandNames = []string{
 	"agreements",
 	"allocate",
 	"attach",
+	"audit-log",
 	"autoload-credentials",
 	"backups",
 	"bootstrap",
 	"budgets",
 	"cached-images",
+	"cancel-action",
 	"change-user-password",
 	"charm",
 	"clouds",
@@ -418,27 +421,35 @@ var commandNames = []string{
 	"create-backup",
 	"create-budget",
 	"create-storage-pool",
+	"create-storage-snapshot",
 	"credentials",
 	"debug-hooks",
 	"debug-log",
 	"deploy",
 	"destroy-controller",
 	"destroy-model",
+	"diff-bundle",
 	"disable-command",
+	"disable-schedule",
 	"disable-user",
 	"disabled-commands",
 	"download-backup",
 	"enable-command",
 	"enable-destroy-controller",
 	"enable-ha",
+	"enable-schedule",
 	"enable-user",
+	"export-bundle",
 	"expose",
 	"get-constraints",
+	"get-egress-rules",
 	"get-model-constraints",
 	"grant",
+	"grant-secret",
 	"gui",
 	"help",
 	"help-tool",
+	"import-filesystem",
 	"import-ssh-key",
 	"kill-controller",
 	"list-actions",
@@ -456,6 +467,8 @@ var commandNames = []string{
 	"list-plans",
 	"list-regions",
 	"list-resources",
+	"list-schedules",
+	"list-secrets",
 	"list-spaces",
 	"list-ssh-keys",
 	"list-storage",
@@ -482,22 +495,32 @@ var commandNames = []string{
 	"remove-credential",
 	"remove-machine",
 	"remove-relation",
+	"remove-schedule",
+	"remove-secret",
 	"remove-ssh-key",
 	"remove-storage",
+	"remove-storage-snapshot",
 	"remove-unit",
 	"remove-user",
+	"resize-storage",
 	"resolved",
 	"resources",
 	"restore-backup",
 	"retry-provisioning",
 	"revoke",
+	"revoke-secret",
+	"rotate-secret",
 	"run",
 	"run-action",
+	"schedule-action",
+	"schedules",
 	"scp",
+	"secrets",
 	"set-budget",
 	"set-constraints",
 	"set-default-credential",
 	"set-default-region",
+	"set-egress-rules",
 	"set-meter-status",
 	"set-model-constraints",
 	"set-plan",
@@ -507,6 +530,7 @@ var commandNames = []string{
 	"show-budget",
 	"show-cloud",
 	"show-controller",
+	"show-hook-history",
 	"show-machine",
 	"show-model",
 	"show-status",
@@ -522,6 +546,7 @@ var commandNames = []string{
 	"subnets",
 	"switch",
 	"sync-tools",
+	"trust",
 	"unexpose",
 	"unregister",
 	"update-allocation",
@@ -533,6 +558,7 @@ var commandNames = []string{
 	"upload-backup",
 	"users",
 	"version",
+	"wait",
 	"whoami",
 }
 
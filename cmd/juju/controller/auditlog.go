@@ -0,0 +1,293 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/auditlog"
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/jujuclient"
+)
+
+// NewAuditLogCommand returns a command that queries the controller's
+// audit log.
+func NewAuditLogCommand() cmd.Command {
+	return modelcmd.WrapController(&auditLogCommand{clock: clock.WallClock})
+}
+
+// auditLogCommand queries the audit entries recorded by a
+// controller.
+type auditLogCommand struct {
+	modelcmd.ControllerCommandBase
+	out   cmd.Output
+	api   auditLogAPI
+	clock clock.Clock
+
+	user          string
+	model         string
+	operation     string
+	remoteAddress string
+	from          string
+	to            string
+	limit         int
+	tail          bool
+
+	query         audit.Query
+	headerWritten bool
+}
+
+// auditLogAPI defines the API methods used by the audit-log command.
+type auditLogAPI interface {
+	Close() error
+	Query(audit.Query) ([]audit.AuditEntry, error)
+}
+
+// auditLogPollInterval is how often the audit log is polled for new
+// entries when tailing.
+const auditLogPollInterval = 2 * time.Second
+
+const auditLogDoc = `
+Shows the audit entries recorded by the controller, most recent last.
+Only controller superusers may read the audit log, and entries are
+only recorded if the controller has "auditing-enabled" set.
+
+Entries can be selected by the user that triggered them, the model
+they were recorded on, the API operation performed (as a prefix, so
+"Application:" matches every call on the Application facade), the
+remote address of the client, and a time range. Times may be given
+as YYYY-MM-DD or in RFC3339 format, and are interpreted as UTC.
+
+With --tail the command keeps running and shows new entries as they
+are recorded.
+
+Examples:
+
+    juju audit-log
+    juju audit-log --user bob --from 2017-06-01 --to 2017-06-02
+    juju audit-log --model mymodel --operation Application: -n 100
+    juju audit-log --remote-address 10.0.0.1 --format json
+    juju audit-log --tail
+
+See also:
+    controller-config
+`
+
+// Info implements Command.Info.
+func (c *auditLogCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "audit-log",
+		Purpose: "Displays the controller's audit log.",
+		Doc:     auditLogDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *auditLogCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.StringVar(&c.user, "user", "", "Only show entries triggered by this user")
+	f.StringVar(&c.model, "model", "", "Only show entries recorded on this model (name or UUID)")
+	f.StringVar(&c.operation, "operation", "", "Only show entries whose operation starts with this prefix")
+	f.StringVar(&c.remoteAddress, "remote-address", "", "Only show entries triggered from this address")
+	f.StringVar(&c.from, "from", "", "Only show entries recorded at or after this time")
+	f.StringVar(&c.to, "to", "", "Only show entries recorded before this time")
+	f.IntVar(&c.limit, "n", 50, "Show at most this many of the most recent entries (0 for all)")
+	f.BoolVar(&c.tail, "tail", false, "Wait for and show new entries")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": c.formatTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *auditLogCommand) Init(args []string) error {
+	if err := cmd.CheckEmpty(args); err != nil {
+		return err
+	}
+	if c.user != "" {
+		if !names.IsValidUser(c.user) {
+			return errors.NotValidf("user name %q", c.user)
+		}
+		c.query.OriginName = names.NewUserTag(c.user).String()
+	}
+	if c.limit < 0 {
+		return errors.New("-n must not be negative")
+	}
+	var err error
+	if c.query.From, err = parseAuditLogTime(c.from); err != nil {
+		return errors.Annotate(err, "invalid --from")
+	}
+	if c.query.To, err = parseAuditLogTime(c.to); err != nil {
+		return errors.Annotate(err, "invalid --to")
+	}
+	if c.tail && !c.query.To.IsZero() {
+		return errors.New("--tail cannot be combined with --to")
+	}
+	c.query.Operation = c.operation
+	c.query.RemoteAddress = c.remoteAddress
+	c.query.Limit = c.limit
+	return nil
+}
+
+func parseAuditLogTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t.UTC(), nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, errors.Errorf("expected YYYY-MM-DD or RFC3339 time, got %q", value)
+	}
+	return t.UTC(), nil
+}
+
+func (c *auditLogCommand) getAPI() (auditLogAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return auditlog.NewClient(root), nil
+}
+
+// modelUUID resolves the --model flag to a model UUID.
+func (c *auditLogCommand) modelUUID() (string, error) {
+	if c.model == "" || names.IsValidModel(c.model) {
+		return c.model, nil
+	}
+	controllerName := c.ControllerName()
+	modelName := c.model
+	if !jujuclient.IsQualifiedModelName(modelName) {
+		accountDetails, err := c.ClientStore().AccountDetails(controllerName)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		modelName = jujuclient.JoinOwnerModelName(names.NewUserTag(accountDetails.User), modelName)
+	}
+	details, err := c.ClientStore().ModelByName(controllerName, modelName)
+	if err != nil {
+		return "", errors.Annotatef(err, "model %q", c.model)
+	}
+	return details.ModelUUID, nil
+}
+
+// Run implements Command.Run.
+func (c *auditLogCommand) Run(ctx *cmd.Context) error {
+	var err error
+	if c.query.ModelUUID, err = c.modelUUID(); err != nil {
+		return errors.Trace(err)
+	}
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	interrupted := make(chan os.Signal, 1)
+	ctx.InterruptNotify(interrupted)
+	defer ctx.StopInterruptNotify(interrupted)
+
+	query := c.query
+	for {
+		entries, err := client.Query(query)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if len(entries) > 0 || !c.tail {
+			if err := c.out.Write(ctx, toAuditLogEntries(entries)); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		if !c.tail {
+			return nil
+		}
+		if len(entries) > 0 {
+			query.From = entries[len(entries)-1].Timestamp.Add(time.Nanosecond)
+		}
+		// Once caught up, every new entry should be shown.
+		query.Limit = 0
+		select {
+		case <-interrupted:
+			return nil
+		case <-c.clock.After(auditLogPollInterval):
+		}
+	}
+}
+
+// auditLogEntry is the serialisation format for an audit entry.
+type auditLogEntry struct {
+	Timestamp     time.Time              `yaml:"timestamp" json:"timestamp"`
+	ModelUUID     string                 `yaml:"model-uuid" json:"model-uuid"`
+	OriginType    string                 `yaml:"origin-type" json:"origin-type"`
+	OriginName    string                 `yaml:"origin-name" json:"origin-name"`
+	RemoteAddress string                 `yaml:"remote-address" json:"remote-address"`
+	Operation     string                 `yaml:"operation" json:"operation"`
+	Data          map[string]interface{} `yaml:"data,omitempty" json:"data,omitempty"`
+}
+
+func toAuditLogEntries(entries []audit.AuditEntry) []auditLogEntry {
+	result := make([]auditLogEntry, len(entries))
+	for i, entry := range entries {
+		result[i] = auditLogEntry{
+			Timestamp:     entry.Timestamp,
+			ModelUUID:     entry.ModelUUID,
+			OriginType:    entry.OriginType,
+			OriginName:    entry.OriginName,
+			RemoteAddress: entry.RemoteAddress,
+			Operation:     entry.Operation,
+			Data:          entry.Data,
+		}
+	}
+	return result
+}
+
+// formatTabular writes the entries as a table. When tailing, the
+// header is only written before the first batch of entries.
+func (c *auditLogCommand) formatTabular(writer io.Writer, value interface{}) error {
+	entries, ok := value.([]auditLogEntry)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", entries, value)
+	}
+	if len(entries) == 0 && !c.headerWritten {
+		fmt.Fprintln(writer, "No audit entries to display.")
+		return nil
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	if !c.headerWritten {
+		w.Println("Time", "User", "Model", "Remote address", "Operation")
+		c.headerWritten = true
+	}
+	for _, entry := range entries {
+		user := entry.OriginName
+		if tag, err := names.ParseUserTag(user); err == nil {
+			user = tag.Id()
+		}
+		w.Println(
+			entry.Timestamp.UTC().Format("2006-01-02 15:04:05Z"),
+			user,
+			entry.ModelUUID,
+			entry.RemoteAddress,
+			entry.Operation,
+		)
+	}
+	tw.Flush()
+	return nil
+}
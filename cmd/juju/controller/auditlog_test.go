@@ -0,0 +1,222 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/cmd/juju/controller"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type AuditLogSuite struct {
+	baseControllerSuite
+	api   *fakeAuditLogAPI
+	clock *testing.Clock
+}
+
+var _ = gc.Suite(&AuditLogSuite{})
+
+func (s *AuditLogSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+	s.modelsYaml = `
+controllers:
+  mallards:
+    models:
+      admin/my-model:
+        uuid: deadbeef-0bad-400d-8000-4b1d0d06f00d
+    current-model: admin/my-model
+`
+	s.createTestClientStore(c)
+	s.api = &fakeAuditLogAPI{}
+	s.clock = testing.NewClock(time.Date(2017, time.June, 1, 12, 0, 0, 0, time.UTC))
+}
+
+func (s *AuditLogSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := controller.NewAuditLogCommandForTest(s.api, s.store, s.clock)
+	return coretesting.RunCommand(c, command, args...)
+}
+
+func (s *AuditLogSuite) entries() []audit.AuditEntry {
+	return []audit.AuditEntry{{
+		JujuServerVersion: version.MustParse("2.2.0"),
+		ModelUUID:         "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		Timestamp:         time.Date(2017, time.June, 1, 11, 0, 0, 0, time.UTC),
+		RemoteAddress:     "10.0.0.1:1234",
+		OriginType:        "API request",
+		OriginName:        "user-bob",
+		Operation:         "Application:v4 - Deploy",
+	}, {
+		JujuServerVersion: version.MustParse("2.2.0"),
+		ModelUUID:         "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		Timestamp:         time.Date(2017, time.June, 1, 11, 30, 0, 0, time.UTC),
+		RemoteAddress:     "10.0.0.2:1234",
+		OriginType:        "API request",
+		OriginName:        "user-mary",
+		Operation:         "Client:v1 - FullStatus",
+	}}
+}
+
+func (s *AuditLogSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}, {
+		args: []string{"--user", "not valid!"},
+		err:  `user name "not valid!" not valid`,
+	}, {
+		args: []string{"--from", "yesterday"},
+		err:  `invalid --from: expected YYYY-MM-DD or RFC3339 time, got "yesterday"`,
+	}, {
+		args: []string{"--to", "2017-13-01"},
+		err:  `invalid --to: expected YYYY-MM-DD or RFC3339 time, got "2017-13-01"`,
+	}, {
+		args: []string{"--tail", "--to", "2017-06-01"},
+		err:  `--tail cannot be combined with --to`,
+	}, {
+		args: []string{"-n", "-1"},
+		err:  `-n must not be negative`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.run(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *AuditLogSuite) TestQuery(c *gc.C) {
+	_, err := s.run(c,
+		"--user", "bob",
+		"--model", "my-model",
+		"--operation", "Application:",
+		"--remote-address", "10.0.0.1",
+		"--from", "2017-06-01",
+		"--to", "2017-06-01T13:00:00Z",
+		"-n", "10",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCalls(c, []testing.StubCall{
+		{"Query", []interface{}{audit.Query{
+			OriginName:    "user-bob",
+			ModelUUID:     "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+			Operation:     "Application:",
+			RemoteAddress: "10.0.0.1",
+			From:          time.Date(2017, time.June, 1, 0, 0, 0, 0, time.UTC),
+			To:            time.Date(2017, time.June, 1, 13, 0, 0, 0, time.UTC),
+			Limit:         10,
+		}}},
+		{"Close", nil},
+	})
+}
+
+func (s *AuditLogSuite) TestQueryUnknownModel(c *gc.C) {
+	_, err := s.run(c, "--model", "other")
+	c.Assert(err, gc.ErrorMatches, `model "other": model mallards:admin/other not found`)
+}
+
+func (s *AuditLogSuite) TestTabular(c *gc.C) {
+	s.api.results = [][]audit.AuditEntry{s.entries()}
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stdout(ctx), gc.Equals, `
+Time                  User  Model                                 Remote address  Operation
+2017-06-01 11:00:00Z  bob   deadbeef-0bad-400d-8000-4b1d0d06f00d  10.0.0.1:1234   Application:v4 - Deploy
+2017-06-01 11:30:00Z  mary  deadbeef-0bad-400d-8000-4b1d0d06f00d  10.0.0.2:1234   Client:v1 - FullStatus
+`[1:])
+}
+
+func (s *AuditLogSuite) TestTabularEmpty(c *gc.C) {
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stdout(ctx), gc.Equals, "No audit entries to display.\n")
+}
+
+func (s *AuditLogSuite) TestYAML(c *gc.C) {
+	s.api.results = [][]audit.AuditEntry{s.entries()[:1]}
+	ctx, err := s.run(c, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stdout(ctx), gc.Equals, `
+- timestamp: 2017-06-01T11:00:00Z
+  model-uuid: deadbeef-0bad-400d-8000-4b1d0d06f00d
+  origin-type: API request
+  origin-name: user-bob
+  remote-address: 10.0.0.1:1234
+  operation: Application:v4 - Deploy
+`[1:])
+}
+
+func (s *AuditLogSuite) TestTail(c *gc.C) {
+	entries := s.entries()
+	s.api.results = [][]audit.AuditEntry{entries[:1], nil, entries[1:]}
+	s.api.SetErrors(nil, nil, nil, errors.New("stop"))
+
+	done := make(chan error)
+	var ctx *cmd.Context
+	go func() {
+		var err error
+		ctx, err = s.run(c, "--tail")
+		done <- err
+	}()
+	for i := 0; i < 3; i++ {
+		err := s.clock.WaitAdvance(2*time.Second, coretesting.LongWait, 1)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	select {
+	case err := <-done:
+		c.Assert(err, gc.ErrorMatches, "stop")
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("command did not finish")
+	}
+
+	calls := s.api.Calls()
+	c.Assert(calls, gc.HasLen, 5)
+	c.Check(calls[0].Args[0].(audit.Query).Limit, gc.Equals, 50)
+	for i, expectFrom := range []time.Time{
+		entries[0].Timestamp.Add(time.Nanosecond),
+		entries[0].Timestamp.Add(time.Nanosecond),
+		entries[1].Timestamp.Add(time.Nanosecond),
+	} {
+		query := calls[i+1].Args[0].(audit.Query)
+		c.Check(query.From, gc.Equals, expectFrom)
+		c.Check(query.Limit, gc.Equals, 0)
+	}
+	c.Assert(coretesting.Stdout(ctx), gc.Equals, `
+Time                  User  Model                                 Remote address  Operation
+2017-06-01 11:00:00Z  bob   deadbeef-0bad-400d-8000-4b1d0d06f00d  10.0.0.1:1234   Application:v4 - Deploy
+2017-06-01 11:30:00Z  mary  deadbeef-0bad-400d-8000-4b1d0d06f00d  10.0.0.2:1234  Client:v1 - FullStatus
+`[1:])
+}
+
+type fakeAuditLogAPI struct {
+	testing.Stub
+	results [][]audit.AuditEntry
+}
+
+func (f *fakeAuditLogAPI) Query(query audit.Query) ([]audit.AuditEntry, error) {
+	f.MethodCall(f, "Query", query)
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	if len(f.results) == 0 {
+		return nil, nil
+	}
+	result := f.results[0]
+	f.results = f.results[1:]
+	return result, nil
+}
+
+func (f *fakeAuditLogAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
@@ -156,6 +156,14 @@ func NewGetConfigCommandForTest(api controllerAPI, store jujuclient.ClientStore)
 	return modelcmd.WrapController(c)
 }
 
+// NewAuditLogCommandForTest returns an auditLogCommand with the api
+// and clock provided as specified.
+func NewAuditLogCommandForTest(api auditLogAPI, store jujuclient.ClientStore, clock clock.Clock) cmd.Command {
+	c := &auditLogCommand{api: api, clock: clock}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
 type CtrData ctrData
 type ModelData modelData
 
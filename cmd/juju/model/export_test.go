@@ -107,3 +107,10 @@ func NewRevokeCommandForTest(api RevokeModelAPI, store jujuclient.ClientStore) (
 	cmd.SetClientStore(store)
 	return modelcmd.WrapController(cmd), &RevokeCommand{cmd}
 }
+
+// NewExportBundleCommandForTest returns an ExportBundleCommand with the api provided as specified.
+func NewExportBundleCommandForTest(api ExportBundleAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &exportBundleCommand{api: api}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
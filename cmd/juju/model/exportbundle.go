@@ -0,0 +1,111 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/bundle"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewExportBundleCommand returns a fully constructed export-bundle command.
+func NewExportBundleCommand() cmd.Command {
+	return modelcmd.Wrap(&exportBundleCommand{})
+}
+
+// exportBundleCommand writes the current model out as a bundle.
+type exportBundleCommand struct {
+	modelcmd.ModelCommandBase
+	api      ExportBundleAPI
+	filename string
+}
+
+// ExportBundleAPI specifies the used function calls of the Bundle facade.
+type ExportBundleAPI interface {
+	Close() error
+	ExportBundle() (string, error)
+}
+
+const exportBundleHelpDoc = `
+Exports the applications, machines and relations of the current model as
+a bundle, which can be deployed with "juju deploy" to reproduce the model.
+
+Each application is exported with its charm URL, series, configuration,
+constraints, endpoint bindings, storage directives and exposure, and its
+units are placed on the machines and containers they occupy now.
+Subordinate applications are placed by their relations. Charms deployed
+from local directories are exported with their "local:" URLs, which must
+be replaced with charm paths before the bundle is deployed.
+
+The bundle is written to stdout unless --filename is given.
+
+Examples:
+
+    juju export-bundle
+    juju export-bundle --filename mymodel.yaml
+
+See also:
+    deploy
+`
+
+// Info implements Command.
+func (c *exportBundleCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "export-bundle",
+		Purpose: "Exports the current model as a bundle.",
+		Doc:     exportBundleHelpDoc,
+	}
+}
+
+// SetFlags implements Command.
+func (c *exportBundleCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.filename, "filename", "", "Bundle file to write")
+}
+
+// Init implements Command.
+func (c *exportBundleCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+func (c *exportBundleCommand) getAPI() (ExportBundleAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	api, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Annotate(err, "opening API connection")
+	}
+	return bundle.NewClient(api), nil
+}
+
+// Run implements Command.
+func (c *exportBundleCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	data, err := client.ExportBundle()
+	if err != nil {
+		return err
+	}
+	if c.filename == "" {
+		_, err := fmt.Fprint(ctx.Stdout, data)
+		return err
+	}
+	path := ctx.AbsPath(c.filename)
+	if err := ioutil.WriteFile(path, []byte(data), 0644); err != nil {
+		return errors.Annotate(err, "cannot write bundle")
+	}
+	ctx.Infof("Bundle successfully exported to %s", path)
+	return nil
+}
@@ -0,0 +1,95 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testing"
+)
+
+type ExportBundleCommandSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake  fakeExportBundleClient
+	store *jujuclienttesting.MemStore
+}
+
+var _ = gc.Suite(&ExportBundleCommandSuite{})
+
+type fakeExportBundleClient struct {
+	gitjujutesting.Stub
+}
+
+func (f *fakeExportBundleClient) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeExportBundleClient) ExportBundle() (string, error) {
+	f.MethodCall(f, "ExportBundle")
+	if err := f.NextErr(); err != nil {
+		return "", err
+	}
+	return "applications:\n  mysql:\n    charm: cs:xenial/mysql-57\n    num_units: 1\n", nil
+}
+
+func (s *ExportBundleCommandSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake.ResetCalls()
+	s.store = jujuclienttesting.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	err := s.store.UpdateModel("testing", "admin/mymodel", jujuclient.ModelDetails{
+		testing.ModelTag.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.store.Models["testing"].CurrentModel = "admin/mymodel"
+}
+
+func (s *ExportBundleCommandSuite) TestInit(c *gc.C) {
+	_, err := testing.RunCommand(c, model.NewExportBundleCommandForTest(&s.fake, s.store), "foo")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["foo"\]`)
+}
+
+func (s *ExportBundleCommandSuite) TestExportToStdout(c *gc.C) {
+	ctx, err := testing.RunCommand(c, model.NewExportBundleCommandForTest(&s.fake, s.store))
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCallNames(c, "ExportBundle", "Close")
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"applications:\n"+
+		"  mysql:\n"+
+		"    charm: cs:xenial/mysql-57\n"+
+		"    num_units: 1\n")
+}
+
+func (s *ExportBundleCommandSuite) TestExportToFile(c *gc.C) {
+	dir := c.MkDir()
+	ctx, err := testing.RunCommand(c, model.NewExportBundleCommandForTest(&s.fake, s.store),
+		"--filename", filepath.Join(dir, "bundle.yaml"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, "")
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, "bundle.yaml"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "applications:\n  mysql:\n    charm: cs:xenial/mysql-57\n    num_units: 1\n")
+}
+
+func (s *ExportBundleCommandSuite) TestExportError(c *gc.C) {
+	s.fake.SetErrors(errors.New("cannot export bundle: applications in model not found"))
+	_, err := testing.RunCommand(c, model.NewExportBundleCommandForTest(&s.fake, s.store))
+	c.Assert(err, gc.ErrorMatches, "cannot export bundle: applications in model not found")
+	s.fake.CheckCallNames(c, "ExportBundle", "Close")
+}
@@ -0,0 +1,85 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const addCommandDoc = `
+Adds a secret to the model.
+
+A secret is a named set of key=value pairs, stored encrypted by the
+controller. Secret values are only available to the applications the
+secret has been granted to, using the secret-get hook tool. A value
+beginning with "@" is read from the named file.
+
+Examples:
+    juju add-secret db-password username=admin password=sekrit
+    juju add-secret --description "site certificate" tls cert=@cert.pem key=@key.pem
+
+See also:
+    grant-secret
+    rotate-secret
+    secrets
+`
+
+// NewAddCommand returns a command to add a secret to the model.
+func NewAddCommand() cmd.Command {
+	return modelcmd.Wrap(&addCommand{})
+}
+
+// addCommand adds a secret to the model.
+type addCommand struct {
+	secretsCommandBase
+	Name        string
+	Description string
+	Values      map[string]string
+}
+
+// Info implements Command.Info.
+func (c *addCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "add-secret",
+		Args:    "<name> <key>=<value> ...",
+		Purpose: "Adds a secret to the model.",
+		Doc:     addCommandDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *addCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.Description, "description", "", "A description of the secret")
+}
+
+// Init implements Command.Init.
+func (c *addCommand) Init(args []string) (err error) {
+	if len(args) == 0 {
+		return errors.New("no secret name specified")
+	}
+	c.Name = args[0]
+	c.Values, err = parseValues(args[1:])
+	return err
+}
+
+// Run implements Command.Run.
+func (c *addCommand) Run(ctx *cmd.Context) error {
+	values, err := readValues(ctx, c.Values)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	api, err := c.newAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+	err = api.AddSecret(c.Name, c.Description, values)
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
@@ -0,0 +1,49 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"github.com/juju/cmd"
+
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+func newCommandBaseForTest(api SecretsAPI) secretsCommandBase {
+	return secretsCommandBase{newAPIFunc: func() (SecretsAPI, error) {
+		return api, nil
+	}}
+}
+
+// NewAddCommandForTest returns an add-secret command using the given api.
+func NewAddCommandForTest(api SecretsAPI) cmd.Command {
+	return modelcmd.Wrap(&addCommand{secretsCommandBase: newCommandBaseForTest(api)})
+}
+
+// NewRotateCommandForTest returns a rotate-secret command using the given api.
+func NewRotateCommandForTest(api SecretsAPI) cmd.Command {
+	return modelcmd.Wrap(&rotateCommand{secretsCommandBase: newCommandBaseForTest(api)})
+}
+
+// NewGrantCommandForTest returns a grant-secret command using the given api.
+func NewGrantCommandForTest(api SecretsAPI) cmd.Command {
+	return modelcmd.Wrap(&grantCommand{secretsCommandBase: newCommandBaseForTest(api)})
+}
+
+// NewRevokeCommandForTest returns a revoke-secret command using the given api.
+func NewRevokeCommandForTest(api SecretsAPI) cmd.Command {
+	return modelcmd.Wrap(&grantCommand{
+		secretsCommandBase: newCommandBaseForTest(api),
+		revoke:             true,
+	})
+}
+
+// NewRemoveCommandForTest returns a remove-secret command using the given api.
+func NewRemoveCommandForTest(api SecretsAPI) cmd.Command {
+	return modelcmd.Wrap(&removeCommand{secretsCommandBase: newCommandBaseForTest(api)})
+}
+
+// NewListCommandForTest returns a secrets command using the given api.
+func NewListCommandForTest(api SecretsAPI) cmd.Command {
+	return modelcmd.Wrap(&listCommand{secretsCommandBase: newCommandBaseForTest(api)})
+}
@@ -0,0 +1,102 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const grantCommandDoc = `
+Grants an application access to a secret.
+
+Units of the application may then read the secret's values with the
+secret-get hook tool.
+
+Examples:
+    juju grant-secret db-password wordpress
+
+See also:
+    revoke-secret
+    secrets
+`
+
+const revokeCommandDoc = `
+Revokes an application's access to a secret.
+
+Examples:
+    juju revoke-secret db-password wordpress
+
+See also:
+    grant-secret
+    secrets
+`
+
+// NewGrantCommand returns a command to grant an application access to
+// a secret.
+func NewGrantCommand() cmd.Command {
+	return modelcmd.Wrap(&grantCommand{})
+}
+
+// NewRevokeCommand returns a command to revoke an application's access
+// to a secret.
+func NewRevokeCommand() cmd.Command {
+	return modelcmd.Wrap(&grantCommand{revoke: true})
+}
+
+// grantCommand grants or revokes an application's access to a secret.
+type grantCommand struct {
+	secretsCommandBase
+	Name        string
+	Application string
+	revoke      bool
+}
+
+// Info implements Command.Info.
+func (c *grantCommand) Info() *cmd.Info {
+	if c.revoke {
+		return &cmd.Info{
+			Name:    "revoke-secret",
+			Args:    "<name> <application>",
+			Purpose: "Revokes an application's access to a secret.",
+			Doc:     revokeCommandDoc,
+		}
+	}
+	return &cmd.Info{
+		Name:    "grant-secret",
+		Args:    "<name> <application>",
+		Purpose: "Grants an application access to a secret.",
+		Doc:     grantCommandDoc,
+	}
+}
+
+// Init implements Command.Init.
+func (c *grantCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no secret name specified")
+	case 1:
+		return errors.New("no application name specified")
+	}
+	c.Name, c.Application = args[0], args[1]
+	return cmd.CheckEmpty(args[2:])
+}
+
+// Run implements Command.Run.
+func (c *grantCommand) Run(_ *cmd.Context) error {
+	api, err := c.newAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+	if c.revoke {
+		err = api.RevokeSecret(c.Name, c.Application)
+	} else {
+		err = api.GrantSecret(c.Name, c.Application)
+	}
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
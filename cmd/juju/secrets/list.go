@@ -0,0 +1,127 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"io"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+const listCommandDoc = `
+Lists the secrets in the model.
+
+Secret values are never shown; only the name, revision and grants of
+each secret are listed.
+
+Examples:
+    juju secrets
+    juju secrets --format yaml
+
+See also:
+    add-secret
+    grant-secret
+`
+
+// NewListCommand returns a command to list the secrets in the model.
+func NewListCommand() cmd.Command {
+	return modelcmd.Wrap(&listCommand{})
+}
+
+// listCommand lists the secrets in the model.
+type listCommand struct {
+	secretsCommandBase
+	out cmd.Output
+}
+
+// Info implements Command.Info.
+func (c *listCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "secrets",
+		Purpose: "Lists the secrets in the model.",
+		Doc:     listCommandDoc,
+		Aliases: []string{"list-secrets"},
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *listCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatSecretsTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *listCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// SecretInfo defines the serialization behaviour of a secret.
+type SecretInfo struct {
+	Name         string    `yaml:"name" json:"name"`
+	Description  string    `yaml:"description,omitempty" json:"description,omitempty"`
+	Revision     int       `yaml:"revision" json:"revision"`
+	Applications []string  `yaml:"applications,omitempty" json:"applications,omitempty"`
+	Updated      time.Time `yaml:"updated" json:"updated"`
+}
+
+// Run implements Command.Run.
+func (c *listCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	results, err := api.ListSecrets()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(results) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No secrets to display.")
+		return nil
+	}
+	secrets := make([]SecretInfo, len(results))
+	for i, result := range results {
+		secrets[i] = SecretInfo{
+			Name:         result.Name,
+			Description:  result.Description,
+			Revision:     result.Revision,
+			Applications: result.Applications,
+			Updated:      result.Updated,
+		}
+	}
+	return c.out.Write(ctx, secrets)
+}
+
+func formatSecretsTabular(writer io.Writer, value interface{}) error {
+	secrets, ok := value.([]SecretInfo)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", secrets, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Secret", "Revision", "Applications", "Updated", "Description")
+	for _, secret := range secrets {
+		w.Println(
+			secret.Name,
+			secret.Revision,
+			strings.Join(secret.Applications, ","),
+			common.FormatTime(&secret.Updated, false),
+			secret.Description,
+		)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,67 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const removeCommandDoc = `
+Removes a secret from the model.
+
+The secret's values are discarded, and it is no longer available to any
+application it was granted to.
+
+Examples:
+    juju remove-secret db-password
+
+See also:
+    add-secret
+    secrets
+`
+
+// NewRemoveCommand returns a command to remove a secret from the model.
+func NewRemoveCommand() cmd.Command {
+	return modelcmd.Wrap(&removeCommand{})
+}
+
+// removeCommand removes a secret from the model.
+type removeCommand struct {
+	secretsCommandBase
+	Name string
+}
+
+// Info implements Command.Info.
+func (c *removeCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "remove-secret",
+		Args:    "<name>",
+		Purpose: "Removes a secret from the model.",
+		Doc:     removeCommandDoc,
+	}
+}
+
+// Init implements Command.Init.
+func (c *removeCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no secret name specified")
+	}
+	c.Name = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run implements Command.Run.
+func (c *removeCommand) Run(_ *cmd.Context) error {
+	api, err := c.newAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+	err = api.RemoveSecret(c.Name)
+	return block.ProcessBlockedError(err, block.BlockRemove)
+}
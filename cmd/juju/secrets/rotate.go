@@ -0,0 +1,74 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const rotateCommandDoc = `
+Replaces the values of a secret.
+
+All of the secret's values are replaced by those given; keys that are
+not specified are removed. Units of the applications the secret is
+granted to are notified by the secret-changed hook.
+
+Examples:
+    juju rotate-secret db-password username=admin password=n3w-sekrit
+
+See also:
+    add-secret
+    secrets
+`
+
+// NewRotateCommand returns a command to replace the values of a secret.
+func NewRotateCommand() cmd.Command {
+	return modelcmd.Wrap(&rotateCommand{})
+}
+
+// rotateCommand replaces the values of a secret.
+type rotateCommand struct {
+	secretsCommandBase
+	Name   string
+	Values map[string]string
+}
+
+// Info implements Command.Info.
+func (c *rotateCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "rotate-secret",
+		Args:    "<name> <key>=<value> ...",
+		Purpose: "Replaces the values of a secret.",
+		Doc:     rotateCommandDoc,
+	}
+}
+
+// Init implements Command.Init.
+func (c *rotateCommand) Init(args []string) (err error) {
+	if len(args) == 0 {
+		return errors.New("no secret name specified")
+	}
+	c.Name = args[0]
+	c.Values, err = parseValues(args[1:])
+	return err
+}
+
+// Run implements Command.Run.
+func (c *rotateCommand) Run(ctx *cmd.Context) error {
+	values, err := readValues(ctx, c.Values)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	api, err := c.newAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+	err = api.RotateSecret(c.Name, values)
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
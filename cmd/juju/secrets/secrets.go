@@ -0,0 +1,85 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package secrets provides the commands used to manage the secrets of
+// a model, and their grants to applications.
+package secrets
+
+import (
+	"io/ioutil"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/secrets"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// SecretsAPI defines the API methods used by the secrets commands.
+type SecretsAPI interface {
+	Close() error
+	ListSecrets() ([]params.SecretDetails, error)
+	AddSecret(name, description string, values map[string]string) error
+	RotateSecret(name string, values map[string]string) error
+	GrantSecret(name, application string) error
+	RevokeSecret(name, application string) error
+	RemoveSecret(name string) error
+}
+
+// secretsCommandBase is embedded in all of the secrets commands.
+type secretsCommandBase struct {
+	modelcmd.ModelCommandBase
+	newAPIFunc func() (SecretsAPI, error)
+}
+
+// newAPI returns a client for the Secrets facade of the current model.
+func (c *secretsCommandBase) newAPI() (SecretsAPI, error) {
+	if c.newAPIFunc != nil {
+		return c.newAPIFunc()
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return secrets.NewClient(root), nil
+}
+
+// parseValues parses key=value arguments into a map. A value beginning
+// with "@" names a file to read the value from; the file is read later,
+// by readValues.
+func parseValues(args []string) (map[string]string, error) {
+	if len(args) == 0 {
+		return nil, errors.New("no secret values specified")
+	}
+	values := make(map[string]string)
+	for _, arg := range args {
+		parts := strings.SplitN(arg, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, errors.Errorf("expected key=value, got %q", arg)
+		}
+		if _, ok := values[parts[0]]; ok {
+			return nil, errors.Errorf("key %q specified more than once", parts[0])
+		}
+		values[parts[0]] = parts[1]
+	}
+	return values, nil
+}
+
+// readValues returns a copy of the given values, with any value
+// beginning with "@" replaced by the content of the file it names.
+func readValues(ctx *cmd.Context, values map[string]string) (map[string]string, error) {
+	result := make(map[string]string, len(values))
+	for key, value := range values {
+		if strings.HasPrefix(value, "@") {
+			data, err := ioutil.ReadFile(ctx.AbsPath(value[1:]))
+			if err != nil {
+				return nil, errors.Annotatef(err, "cannot read value of %q", key)
+			}
+			value = string(data)
+		}
+		result[key] = value
+	}
+	return result, nil
+}
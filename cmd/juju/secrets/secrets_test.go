@@ -0,0 +1,226 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/secrets"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type SecretsSuite struct {
+	testing.IsolationSuite
+	api *mockSecretsAPI
+}
+
+var _ = gc.Suite(&SecretsSuite{})
+
+func (s *SecretsSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.api = &mockSecretsAPI{Stub: &testing.Stub{}}
+}
+
+func (s *SecretsSuite) run(c *gc.C, command cmd.Command, args ...string) (*cmd.Context, error) {
+	return coretesting.RunCommand(c, command, args...)
+}
+
+func (s *SecretsSuite) TestAddSecret(c *gc.C) {
+	_, err := s.run(c, secrets.NewAddCommandForTest(s.api),
+		"--description", "database credentials",
+		"db-password", "username=admin", "password=se=krit",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCalls(c, []testing.StubCall{
+		{"AddSecret", []interface{}{
+			"db-password", "database credentials",
+			map[string]string{"username": "admin", "password": "se=krit"},
+		}},
+		{"Close", nil},
+	})
+}
+
+func (s *SecretsSuite) TestAddSecretFromFile(c *gc.C) {
+	dir := c.MkDir()
+	err := ioutil.WriteFile(filepath.Join(dir, "cert.pem"), []byte("-----BEGIN CERTIFICATE-----"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	command := secrets.NewAddCommandForTest(s.api)
+	ctx := coretesting.ContextForDir(c, dir)
+	err = coretesting.InitCommand(command, []string{"tls", "cert=@cert.pem"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = command.Run(ctx)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "AddSecret", "tls", "", map[string]string{
+		"cert": "-----BEGIN CERTIFICATE-----",
+	})
+}
+
+func (s *SecretsSuite) TestAddSecretInvalidArgs(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no secret name specified",
+	}, {
+		args: []string{"db-password"},
+		err:  "no secret values specified",
+	}, {
+		args: []string{"db-password", "password"},
+		err:  `expected key=value, got "password"`,
+	}, {
+		args: []string{"db-password", "=sekrit"},
+		err:  `expected key=value, got "=sekrit"`,
+	}, {
+		args: []string{"db-password", "a=1", "a=2"},
+		err:  `key "a" specified more than once`,
+	}} {
+		c.Logf("test %d: %q", i, test.args)
+		_, err := s.run(c, secrets.NewAddCommandForTest(s.api), test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	s.api.CheckNoCalls(c)
+}
+
+func (s *SecretsSuite) TestAddSecretBlocked(c *gc.C) {
+	s.api.SetErrors(common.OperationBlockedError("TestAddSecretBlocked"))
+	_, err := s.run(c, secrets.NewAddCommandForTest(s.api), "db-password", "password=sekrit")
+	coretesting.AssertOperationWasBlocked(c, err, ".*TestAddSecretBlocked.*")
+}
+
+func (s *SecretsSuite) TestRotateSecret(c *gc.C) {
+	_, err := s.run(c, secrets.NewRotateCommandForTest(s.api), "db-password", "password=new")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCalls(c, []testing.StubCall{
+		{"RotateSecret", []interface{}{"db-password", map[string]string{"password": "new"}}},
+		{"Close", nil},
+	})
+}
+
+func (s *SecretsSuite) TestRotateSecretFail(c *gc.C) {
+	s.api.SetErrors(errors.New("boom"))
+	_, err := s.run(c, secrets.NewRotateCommandForTest(s.api), "db-password", "password=new")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *SecretsSuite) TestGrantSecret(c *gc.C) {
+	_, err := s.run(c, secrets.NewGrantCommandForTest(s.api), "db-password", "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCalls(c, []testing.StubCall{
+		{"GrantSecret", []interface{}{"db-password", "wordpress"}},
+		{"Close", nil},
+	})
+}
+
+func (s *SecretsSuite) TestGrantSecretInvalidArgs(c *gc.C) {
+	_, err := s.run(c, secrets.NewGrantCommandForTest(s.api))
+	c.Assert(err, gc.ErrorMatches, "no secret name specified")
+	_, err = s.run(c, secrets.NewGrantCommandForTest(s.api), "db-password")
+	c.Assert(err, gc.ErrorMatches, "no application name specified")
+	_, err = s.run(c, secrets.NewGrantCommandForTest(s.api), "db-password", "wordpress", "mysql")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["mysql"\]`)
+}
+
+func (s *SecretsSuite) TestRevokeSecret(c *gc.C) {
+	_, err := s.run(c, secrets.NewRevokeCommandForTest(s.api), "db-password", "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCalls(c, []testing.StubCall{
+		{"RevokeSecret", []interface{}{"db-password", "wordpress"}},
+		{"Close", nil},
+	})
+}
+
+func (s *SecretsSuite) TestRemoveSecret(c *gc.C) {
+	_, err := s.run(c, secrets.NewRemoveCommandForTest(s.api), "db-password")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCalls(c, []testing.StubCall{
+		{"RemoveSecret", []interface{}{"db-password"}},
+		{"Close", nil},
+	})
+}
+
+func (s *SecretsSuite) TestRemoveSecretBlocked(c *gc.C) {
+	s.api.SetErrors(common.OperationBlockedError("TestRemoveSecretBlocked"))
+	_, err := s.run(c, secrets.NewRemoveCommandForTest(s.api), "db-password")
+	coretesting.AssertOperationWasBlocked(c, err, ".*TestRemoveSecretBlocked.*")
+}
+
+func (s *SecretsSuite) TestListSecretsEmpty(c *gc.C) {
+	ctx, err := s.run(c, secrets.NewListCommandForTest(s.api))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(coretesting.Stderr(ctx), gc.Equals, "No secrets to display.\n")
+}
+
+func (s *SecretsSuite) TestListSecretsYAML(c *gc.C) {
+	s.api.secrets = []params.SecretDetails{{
+		Name:         "db-password",
+		Description:  "database credentials",
+		Revision:     2,
+		Applications: []string{"mysql", "wordpress"},
+		Updated:      time.Date(2017, time.June, 1, 12, 0, 0, 0, time.UTC),
+	}}
+	ctx, err := s.run(c, secrets.NewListCommandForTest(s.api), "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stdout(ctx), gc.Equals, `
+- name: db-password
+  description: database credentials
+  revision: 2
+  applications:
+  - mysql
+  - wordpress
+  updated: 2017-06-01T12:00:00Z
+`[1:])
+}
+
+type mockSecretsAPI struct {
+	*testing.Stub
+	secrets []params.SecretDetails
+}
+
+func (m *mockSecretsAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return m.NextErr()
+}
+
+func (m *mockSecretsAPI) ListSecrets() ([]params.SecretDetails, error) {
+	m.MethodCall(m, "ListSecrets")
+	return m.secrets, m.NextErr()
+}
+
+func (m *mockSecretsAPI) AddSecret(name, description string, values map[string]string) error {
+	m.MethodCall(m, "AddSecret", name, description, values)
+	return m.NextErr()
+}
+
+func (m *mockSecretsAPI) RotateSecret(name string, values map[string]string) error {
+	m.MethodCall(m, "RotateSecret", name, values)
+	return m.NextErr()
+}
+
+func (m *mockSecretsAPI) GrantSecret(name, application string) error {
+	m.MethodCall(m, "GrantSecret", name, application)
+	return m.NextErr()
+}
+
+func (m *mockSecretsAPI) RevokeSecret(name, application string) error {
+	m.MethodCall(m, "RevokeSecret", name, application)
+	return m.NextErr()
+}
+
+func (m *mockSecretsAPI) RemoveSecret(name string) error {
+	m.MethodCall(m, "RemoveSecret", name)
+	return m.NextErr()
+}
@@ -4,24 +4,38 @@
 package status
 
 import (
+	"encoding/csv"
 	"fmt"
+	"io"
 	"os"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
+	"github.com/juju/utils"
 	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/juju/osenv"
 	"github.com/juju/juju/status"
 )
 
-// TODO(peritto666) - add tests
+type statusHistoryAPI interface {
+	StatusHistory(kind status.HistoryKind, tag names.Tag, filter status.StatusHistoryFilter) (status.History, error)
+	Status(patterns []string) (*params.FullStatus, error)
+	Close() error
+}
+
+var newAPIClientForStatusHistory = func(c *statusHistoryCommand) (statusHistoryAPI, error) {
+	return c.NewAPIClient()
+}
 
 // NewStatusHistoryCommand returns a command that reports the history
 // of status changes for the specified unit.
@@ -36,9 +50,11 @@ type statusHistoryCommand struct {
 	backlogSize          int
 	backlogSizeDays      int
 	backlogDate          string
+	backlogToDate        string
 	isoTime              bool
 	entityName           string
 	date                 time.Time
+	toDate               time.Time
 	includeStatusUpdates bool
 }
 
@@ -56,6 +72,21 @@ The statuses are available for the following types.
     container: will show statuses for containers.
  and sorted by time of occurrence.
  The default is unit.
+
+For the unit types, an application name may be given instead of a unit
+name, in which case the statuses of all of the application's units are
+reported together.
+
+--from-date and --to-date accept either a date (YYYY-MM-DD) or an RFC3339
+timestamp, and may be combined to select a time window.
+
+The tabular output collapses repeated entries; the yaml, json and csv
+formats report every entry and are suitable for export.
+
+Examples:
+    juju show-status-log mysql/0
+    juju show-status-log --type workload -n 50 mysql
+    juju show-status-log --from-date 2017-06-01 --to-date 2017-06-02 --format csv mysql
 `
 
 func (c *statusHistoryCommand) Info() *cmd.Info {
@@ -73,8 +104,15 @@ func (c *statusHistoryCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.IntVar(&c.backlogSize, "n", 0, "Returns the last N logs (cannot be combined with --days or --date)")
 	f.IntVar(&c.backlogSizeDays, "days", 0, "Returns the logs for the past <days> days (cannot be combined with -n or --date)")
 	f.StringVar(&c.backlogDate, "from-date", "", "Returns logs for any date after the passed one, the expected date format is YYYY-MM-DD (cannot be combined with -n or --days)")
+	f.StringVar(&c.backlogToDate, "to-date", "", "Returns logs for any date before the passed one, the expected date format is YYYY-MM-DD")
 	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
 	f.BoolVar(&c.includeStatusUpdates, "include-status-updates", false, "Inlcude update status hook messages in the returned logs")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"csv":     formatHistoryCSV,
+		"tabular": formatHistoryTabular,
+	})
 }
 
 func (c *statusHistoryCommand) Init(args []string) error {
@@ -108,11 +146,21 @@ func (c *statusHistoryCommand) Init(args []string) error {
 	}
 	if c.backlogDate != "" {
 		var err error
-		c.date, err = time.Parse("2006-01-02", c.backlogDate)
+		c.date, err = parseHistoryDate(c.backlogDate)
 		if err != nil {
 			return errors.Annotate(err, "parsing backlog date")
 		}
 	}
+	if c.backlogToDate != "" {
+		var err error
+		c.toDate, err = parseHistoryDate(c.backlogToDate)
+		if err != nil {
+			return errors.Annotate(err, "parsing backlog end date")
+		}
+		if !c.date.IsZero() && !c.toDate.After(c.date) {
+			return errors.Errorf("backlog end date must be after the backlog date")
+		}
+	}
 
 	kind := status.HistoryKind(c.outputContent)
 	if kind.Valid() {
@@ -121,10 +169,18 @@ func (c *statusHistoryCommand) Init(args []string) error {
 	return errors.Errorf("unexpected status type %q", c.outputContent)
 }
 
+// parseHistoryDate parses either a date or an RFC3339 timestamp.
+func parseHistoryDate(value string) (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
 const runningHookMSG = "running update-status hook"
 
 func (c *statusHistoryCommand) Run(ctx *cmd.Context) error {
-	apiclient, err := c.NewAPIClient()
+	apiclient, err := newAPIClientForStatusHistory(c)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -147,41 +203,167 @@ func (c *statusHistoryCommand) Run(ctx *cmd.Context) error {
 	if !c.date.IsZero() {
 		filterArgs.FromDate = &c.date
 	}
-	var tag names.Tag
+	if !c.toDate.IsZero() {
+		filterArgs.ToDate = &c.toDate
+	}
+	var tags []names.Tag
 	switch kind {
 	case status.KindUnit, status.KindWorkload, status.KindUnitAgent:
-		if !names.IsValidUnit(c.entityName) {
+		switch {
+		case names.IsValidUnit(c.entityName):
+			tags = []names.Tag{names.NewUnitTag(c.entityName)}
+		case names.IsValidApplication(c.entityName):
+			if tags, err = applicationUnitTags(apiclient, c.entityName); err != nil {
+				return errors.Trace(err)
+			}
+		default:
 			return errors.Errorf("%q is not a valid name for a %s", c.entityName, kind)
 		}
-		tag = names.NewUnitTag(c.entityName)
 	default:
 		if !names.IsValidMachine(c.entityName) {
 			return errors.Errorf("%q is not a valid name for a %s", c.entityName, kind)
 		}
-		tag = names.NewMachineTag(c.entityName)
+		tags = []names.Tag{names.NewMachineTag(c.entityName)}
 	}
-	statuses, err := apiclient.StatusHistory(kind, tag, filterArgs)
-	historyLen := len(statuses)
+
+	tabular := c.out.Name() == "tabular"
+	var entries []historyEntry
+	for _, tag := range tags {
+		statuses, err := apiclient.StatusHistory(kind, tag, filterArgs)
+		if err != nil {
+			if len(statuses) == 0 && len(tags) == 1 {
+				return errors.Trace(err)
+			}
+			// Display any error, but continue to print status if some was returned
+			fmt.Fprintf(ctx.Stderr, "%v\n", err)
+		}
+		if tabular {
+			statuses = statuses.SquashLogs(1)
+			statuses = statuses.SquashLogs(2)
+			statuses = statuses.SquashLogs(3)
+		}
+		for _, v := range statuses {
+			entries = append(entries, c.historyEntry(tag.Id(), v))
+		}
+	}
+	if len(entries) == 0 {
+		return errors.Errorf("no status history available")
+	}
+	if len(tags) > 1 {
+		sort.Stable(byEntryTime(entries))
+	}
+	return c.out.Write(ctx, entries)
+}
+
+// applicationUnitTags returns the tags of the units, including
+// subordinate units, of the named application.
+func applicationUnitTags(apiclient statusHistoryAPI, appName string) ([]names.Tag, error) {
+	fullStatus, err := apiclient.Status([]string{appName})
 	if err != nil {
-		if historyLen == 0 {
-			return errors.Trace(err)
+		return nil, errors.Trace(err)
+	}
+	if _, ok := fullStatus.Applications[appName]; !ok {
+		return nil, errors.NotFoundf("application %q", appName)
+	}
+	unitNames := set.NewStrings()
+	var addUnits func(units map[string]params.UnitStatus)
+	addUnits = func(units map[string]params.UnitStatus) {
+		for name, unit := range units {
+			if appNameFromUnit(name) == appName {
+				unitNames.Add(name)
+			}
+			addUnits(unit.Subordinates)
 		}
-		// Display any error, but continue to print status if some was returned
-		fmt.Fprintf(ctx.Stderr, "%v\n", err)
 	}
+	for _, app := range fullStatus.Applications {
+		addUnits(app.Units)
+	}
+	if unitNames.IsEmpty() {
+		return nil, errors.Errorf("application %q has no units", appName)
+	}
+	var tags []names.Tag
+	for _, name := range utils.SortStringsNaturally(unitNames.Values()) {
+		tags = append(tags, names.NewUnitTag(name))
+	}
+	return tags, nil
+}
 
-	if historyLen == 0 {
-		return errors.Errorf("no status history available")
+func appNameFromUnit(unitName string) string {
+	name, _ := names.UnitApplication(unitName)
+	return name
+}
+
+// historyEntry is the serialisation format for a status history entry.
+type historyEntry struct {
+	Entity  string `yaml:"entity" json:"entity"`
+	Time    string `yaml:"time" json:"time"`
+	Type    string `yaml:"type" json:"type"`
+	Status  string `yaml:"status" json:"status"`
+	Message string `yaml:"message,omitempty" json:"message,omitempty"`
+
+	since time.Time
+}
+
+func (c *statusHistoryCommand) historyEntry(entity string, s status.DetailedStatus) historyEntry {
+	entry := historyEntry{
+		Entity:  entity,
+		Type:    string(s.Kind),
+		Status:  string(s.Status),
+		Message: s.Info,
 	}
+	if s.Since != nil {
+		entry.since = *s.Since
+		switch {
+		case c.out.Name() == "tabular":
+			entry.Time = common.FormatTime(s.Since, c.isoTime)
+		case c.isoTime:
+			entry.Time = s.Since.UTC().Format(time.RFC3339)
+		default:
+			entry.Time = s.Since.Format(time.RFC3339)
+		}
+	}
+	return entry
+}
+
+type byEntryTime []historyEntry
 
-	table := [][]string{{"TIME", "TYPE", "STATUS", "MESSAGE"}}
-	lengths := []int{1, 1, 1, 1}
+func (s byEntryTime) Len() int {
+	return len(s)
+}
+func (s byEntryTime) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+func (s byEntryTime) Less(i, j int) bool {
+	return s[i].since.Before(s[j].since)
+}
 
-	statuses = statuses.SquashLogs(1)
-	statuses = statuses.SquashLogs(2)
-	statuses = statuses.SquashLogs(3)
-	for _, v := range statuses {
-		fields := []string{common.FormatTime(v.Since, c.isoTime), string(v.Kind), string(v.Status), v.Info}
+func formatHistoryTabular(writer io.Writer, value interface{}) error {
+	entries, ok := value.([]historyEntry)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", entries, value)
+	}
+	entities := set.NewStrings()
+	for _, entry := range entries {
+		entities.Add(entry.Entity)
+	}
+	header := []string{"TIME", "TYPE", "STATUS", "MESSAGE"}
+	row := func(entry historyEntry) []string {
+		return []string{entry.Time, entry.Type, entry.Status, entry.Message}
+	}
+	if entities.Size() > 1 {
+		header = append([]string{"ENTITY"}, header...)
+		row = func(entry historyEntry) []string {
+			return []string{entry.Entity, entry.Time, entry.Type, entry.Status, entry.Message}
+		}
+	}
+
+	table := [][]string{header}
+	lengths := make([]int, len(header))
+	for i := range lengths {
+		lengths[i] = 1
+	}
+	for _, entry := range entries {
+		fields := row(entry)
 		for k, v := range fields {
 			if len(v) > lengths[k] {
 				lengths[k] = len(v)
@@ -189,9 +371,35 @@ func (c *statusHistoryCommand) Run(ctx *cmd.Context) error {
 		}
 		table = append(table, fields)
 	}
-	f := fmt.Sprintf("%%-%ds\t%%-%ds\t%%-%ds\t%%-%ds\n", lengths[0], lengths[1], lengths[2], lengths[3])
+	columns := make([]string, len(lengths))
+	for i, length := range lengths {
+		columns[i] = fmt.Sprintf("%%-%ds", length)
+	}
+	f := strings.Join(columns, "\t") + "\n"
 	for _, v := range table {
-		fmt.Printf(f, v[0], v[1], v[2], v[3])
+		fields := make([]interface{}, len(v))
+		for i := range v {
+			fields[i] = v[i]
+		}
+		fmt.Fprintf(writer, f, fields...)
 	}
 	return nil
 }
+
+func formatHistoryCSV(writer io.Writer, value interface{}) error {
+	entries, ok := value.([]historyEntry)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", entries, value)
+	}
+	w := csv.NewWriter(writer)
+	if err := w.Write([]string{"entity", "time", "type", "status", "message"}); err != nil {
+		return errors.Trace(err)
+	}
+	for _, entry := range entries {
+		if err := w.Write([]string{entry.Entity, entry.Time, entry.Type, entry.Status, entry.Message}); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	w.Flush()
+	return errors.Trace(w.Error())
+}
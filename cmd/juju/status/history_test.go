@@ -0,0 +1,202 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/set"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/status"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type HistorySuite struct {
+	coretesting.BaseSuite
+	api *fakeHistoryAPI
+}
+
+var _ = gc.Suite(&HistorySuite{})
+
+func (s *HistorySuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.api = &fakeHistoryAPI{
+		status: &params.FullStatus{
+			Applications: map[string]params.ApplicationStatus{
+				"mysql": {
+					Units: map[string]params.UnitStatus{
+						"mysql/0": {Subordinates: map[string]params.UnitStatus{
+							"logging/0": {},
+						}},
+						"mysql/1": {Subordinates: map[string]params.UnitStatus{
+							"logging/1": {},
+						}},
+					},
+				},
+				"logging": {},
+			},
+		},
+		history: map[string]status.History{
+			"mysql/0": {
+				historyStatus(status.KindWorkload, status.Maintenance, "installing", 0),
+				historyStatus(status.KindWorkload, status.Active, "ready", 10*time.Minute),
+			},
+			"mysql/1": {
+				historyStatus(status.KindWorkload, status.Active, "ready", 5*time.Minute),
+			},
+			"logging/1": {
+				historyStatus(status.KindWorkload, status.Active, "", 0),
+			},
+		},
+	}
+	s.PatchValue(&newAPIClientForStatusHistory, func(*statusHistoryCommand) (statusHistoryAPI, error) {
+		return s.api, nil
+	})
+}
+
+func (s *HistorySuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := &statusHistoryCommand{}
+	command.SetClientStore(newTestClientStore())
+	return coretesting.RunCommand(c, modelcmd.Wrap(command), args...)
+}
+
+func historyStatus(kind status.HistoryKind, value status.Status, info string, offset time.Duration) status.DetailedStatus {
+	since := time.Date(2017, time.June, 1, 10, 0, 0, 0, time.UTC).Add(offset)
+	return status.DetailedStatus{
+		Kind:   kind,
+		Status: value,
+		Info:   info,
+		Since:  &since,
+	}
+}
+
+type fakeHistoryAPI struct {
+	testing.Stub
+	status  *params.FullStatus
+	history map[string]status.History
+}
+
+func (a *fakeHistoryAPI) StatusHistory(kind status.HistoryKind, tag names.Tag, filter status.StatusHistoryFilter) (status.History, error) {
+	a.MethodCall(a, "StatusHistory", kind, tag, filter)
+	return a.history[tag.Id()], a.NextErr()
+}
+
+func (a *fakeHistoryAPI) Status(patterns []string) (*params.FullStatus, error) {
+	a.MethodCall(a, "Status", patterns)
+	return a.status, a.NextErr()
+}
+
+func (a *fakeHistoryAPI) Close() error {
+	a.MethodCall(a, "Close")
+	return a.NextErr()
+}
+
+func (s *HistorySuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{},
+		err:  "entity name is missing.",
+	}, {
+		args: []string{"--from-date", "2017-06-01", "--to-date", "2017-06-02T12:00:00Z", "mysql"},
+	}, {
+		args: []string{"--from-date", "2017-06-02", "--to-date", "2017-06-01", "mysql"},
+		err:  "backlog end date must be after the backlog date",
+	}, {
+		args: []string{"--to-date", "yesterday", "mysql"},
+		err:  `parsing backlog end date: .*`,
+	}, {
+		args: []string{"-n", "5", "--days", "2", "mysql"},
+		err:  "backlog size, backlog date and backlog days back cannot be specified together",
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		command := &statusHistoryCommand{}
+		command.SetClientStore(newTestClientStore())
+		err := coretesting.InitCommand(modelcmd.Wrap(command), test.args)
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
+
+func (s *HistorySuite) TestUnitTabular(c *gc.C) {
+	ctx, err := s.run(c, "--utc", "--type", "workload", "mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(coretesting.Stdout(ctx), gc.Equals, ""+
+		"TIME                \tTYPE    \tSTATUS     \tMESSAGE   \n"+
+		"2017-06-01 10:00:00Z\tworkload\tmaintenance\tinstalling\n"+
+		"2017-06-01 10:10:00Z\tworkload\tactive     \tready     \n",
+	)
+	s.api.CheckCalls(c, []testing.StubCall{
+		{"StatusHistory", []interface{}{
+			status.KindWorkload,
+			names.NewUnitTag("mysql/0"),
+			status.StatusHistoryFilter{Size: 20, Exclude: set.NewStrings(runningHookMSG)},
+		}},
+		{"Close", nil},
+	})
+}
+
+func (s *HistorySuite) TestApplicationCSV(c *gc.C) {
+	ctx, err := s.run(c, "--utc", "--format", "csv",
+		"--from-date", "2017-06-01", "--to-date", "2017-06-02", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(coretesting.Stdout(ctx), gc.Equals, ""+
+		"entity,time,type,status,message\n"+
+		"mysql/0,2017-06-01T10:00:00Z,workload,maintenance,installing\n"+
+		"mysql/1,2017-06-01T10:05:00Z,workload,active,ready\n"+
+		"mysql/0,2017-06-01T10:10:00Z,workload,active,ready\n",
+	)
+	from := time.Date(2017, time.June, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2017, time.June, 2, 0, 0, 0, 0, time.UTC)
+	filter := status.StatusHistoryFilter{
+		FromDate: &from,
+		ToDate:   &to,
+		Exclude:  set.NewStrings(runningHookMSG),
+	}
+	s.api.CheckCalls(c, []testing.StubCall{
+		{"Status", []interface{}{[]string{"mysql"}}},
+		{"StatusHistory", []interface{}{status.KindUnit, names.NewUnitTag("mysql/0"), filter}},
+		{"StatusHistory", []interface{}{status.KindUnit, names.NewUnitTag("mysql/1"), filter}},
+		{"Close", nil},
+	})
+}
+
+func (s *HistorySuite) TestSubordinateApplicationYAML(c *gc.C) {
+	ctx, err := s.run(c, "--utc", "--format", "yaml", "logging")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(coretesting.Stdout(ctx), gc.Equals, `
+- entity: logging/1
+  time: "2017-06-01T10:00:00Z"
+  type: workload
+  status: active
+`[1:])
+	s.api.CheckCallNames(c, "Status", "StatusHistory", "StatusHistory", "Close")
+}
+
+func (s *HistorySuite) TestApplicationTabularShowsEntity(c *gc.C) {
+	ctx, err := s.run(c, "--utc", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(coretesting.Stdout(ctx), gc.Equals, ""+
+		"ENTITY \tTIME                \tTYPE    \tSTATUS     \tMESSAGE   \n"+
+		"mysql/0\t2017-06-01 10:00:00Z\tworkload\tmaintenance\tinstalling\n"+
+		"mysql/1\t2017-06-01 10:05:00Z\tworkload\tactive     \tready     \n"+
+		"mysql/0\t2017-06-01 10:10:00Z\tworkload\tactive     \tready     \n",
+	)
+}
+
+func (s *HistorySuite) TestApplicationNotFound(c *gc.C) {
+	_, err := s.run(c, "wordpress")
+	c.Assert(err, gc.ErrorMatches, `application "wordpress" not found`)
+}
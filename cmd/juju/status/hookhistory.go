@@ -0,0 +1,219 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/hookhistory"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+type hookHistoryAPI interface {
+	HookExecutions(unit names.UnitTag) ([]params.HookExecution, error)
+	Close() error
+}
+
+var newAPIClientForHookHistory = func(c *hookHistoryCommand) (hookHistoryAPI, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return hookhistory.NewClient(root), nil
+}
+
+// NewHookHistoryCommand returns a command that reports the recent hook
+// and action executions of a unit.
+func NewHookHistoryCommand() cmd.Command {
+	return modelcmd.Wrap(&hookHistoryCommand{})
+}
+
+type hookHistoryCommand struct {
+	modelcmd.ModelCommandBase
+	out     cmd.Output
+	unit    names.UnitTag
+	size    int
+	isoTime bool
+}
+
+var hookHistoryDoc = `
+Reports the most recent hook and action executions of a unit, with how
+long each ran, its exit code, and the hook tools it called. This helps to
+diagnose charms with slow hooks, or hooks that fail intermittently.
+
+The controller keeps a limited number of executions for each unit, and
+discards the oldest as new executions are recorded. Only the first 100 hook
+tool calls of each execution are recorded; later calls are counted, and
+shown as "N more" in the tabular output.
+
+The tabular output lists the hook tools each execution called, with the
+time each call took. The yaml and json formats include the full detail of
+every execution.
+
+Examples:
+    juju show-hook-history mysql/0
+    juju show-hook-history -n 5 --format yaml mysql/0
+
+See also:
+    show-status-log
+`
+
+// Info implements Command.Info.
+func (c *hookHistoryCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "show-hook-history",
+		Args:    "<unit>",
+		Purpose: "Output the recent hook and action executions of a unit.",
+		Doc:     hookHistoryDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *hookHistoryCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.IntVar(&c.size, "n", 0, "Show only the last N executions")
+	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatHookHistoryTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *hookHistoryCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no unit specified")
+	case 1:
+		if !names.IsValidUnit(args[0]) {
+			return errors.NotValidf("unit name %q", args[0])
+		}
+		c.unit = names.NewUnitTag(args[0])
+	default:
+		return cmd.CheckEmpty(args[1:])
+	}
+	if c.size < 0 {
+		return errors.NotValidf("negative -n")
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *hookHistoryCommand) Run(ctx *cmd.Context) error {
+	client, err := newAPIClientForHookHistory(c)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	executions, err := client.HookExecutions(c.unit)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(executions) == 0 {
+		ctx.Infof("No hook executions recorded for %s.", c.unit.Id())
+		return nil
+	}
+	if c.size > 0 && len(executions) > c.size {
+		executions = executions[len(executions)-c.size:]
+	}
+	entries := make([]hookExecutionEntry, len(executions))
+	for i, execution := range executions {
+		entries[i] = c.hookExecutionEntry(execution)
+	}
+	return c.out.Write(ctx, entries)
+}
+
+// hookExecutionEntry is the serialisation format for a hook execution.
+type hookExecutionEntry struct {
+	Started      string          `yaml:"started" json:"started"`
+	Kind         string          `yaml:"kind" json:"kind"`
+	Name         string          `yaml:"name" json:"name"`
+	Duration     string          `yaml:"duration" json:"duration"`
+	ExitCode     int             `yaml:"exit-code" json:"exit-code"`
+	Error        string          `yaml:"error,omitempty" json:"error,omitempty"`
+	Tools        []toolCallEntry `yaml:"tools,omitempty" json:"tools,omitempty"`
+	DroppedTools int             `yaml:"dropped-tools,omitempty" json:"dropped-tools,omitempty"`
+}
+
+// toolCallEntry is the serialisation format for a hook tool call.
+type toolCallEntry struct {
+	Name     string `yaml:"name" json:"name"`
+	Started  string `yaml:"started" json:"started"`
+	Duration string `yaml:"duration" json:"duration"`
+	ExitCode int    `yaml:"exit-code" json:"exit-code"`
+}
+
+func (c *hookHistoryCommand) formatTime(t time.Time) string {
+	switch {
+	case c.out.Name() == "tabular":
+		return common.FormatTime(&t, c.isoTime)
+	case c.isoTime:
+		return t.UTC().Format(time.RFC3339Nano)
+	default:
+		return t.Format(time.RFC3339Nano)
+	}
+}
+
+func (c *hookHistoryCommand) hookExecutionEntry(execution params.HookExecution) hookExecutionEntry {
+	entry := hookExecutionEntry{
+		Started:      c.formatTime(execution.Started),
+		Kind:         execution.Kind,
+		Name:         execution.Name,
+		Duration:     formatMillis(execution.Finished.Sub(execution.Started)),
+		ExitCode:     execution.ExitCode,
+		Error:        execution.Error,
+		DroppedTools: execution.DroppedToolCalls,
+	}
+	for _, call := range execution.ToolCalls {
+		entry.Tools = append(entry.Tools, toolCallEntry{
+			Name:     call.Name,
+			Started:  c.formatTime(call.Started),
+			Duration: formatMillis(call.Duration),
+			ExitCode: call.ExitCode,
+		})
+	}
+	return entry
+}
+
+// formatMillis formats the duration truncated to milliseconds.
+func formatMillis(d time.Duration) string {
+	return (d / time.Millisecond * time.Millisecond).String()
+}
+
+func formatHookHistoryTabular(writer io.Writer, value interface{}) error {
+	entries, ok := value.([]hookExecutionEntry)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", entries, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("TIME", "KIND", "NAME", "DURATION", "EXIT", "TOOLS")
+	for _, entry := range entries {
+		tools := make([]string, len(entry.Tools))
+		for i, tool := range entry.Tools {
+			tools[i] = fmt.Sprintf("%s %s", tool.Name, tool.Duration)
+			if tool.ExitCode != 0 {
+				tools[i] += fmt.Sprintf(" (exit %d)", tool.ExitCode)
+			}
+		}
+		if entry.DroppedTools > 0 {
+			tools = append(tools, fmt.Sprintf("%d more", entry.DroppedTools))
+		}
+		w.Println(entry.Started, entry.Kind, entry.Name, entry.Duration, entry.ExitCode, strings.Join(tools, ", "))
+	}
+	return tw.Flush()
+}
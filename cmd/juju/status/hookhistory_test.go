@@ -0,0 +1,183 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type HookHistorySuite struct {
+	coretesting.BaseSuite
+	api *fakeHookHistoryAPI
+}
+
+var _ = gc.Suite(&HookHistorySuite{})
+
+func (s *HookHistorySuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	t0 := time.Date(2017, time.June, 1, 10, 0, 0, 0, time.UTC)
+	s.api = &fakeHookHistoryAPI{
+		executions: []params.HookExecution{{
+			Kind:     "hook",
+			Name:     "install",
+			Started:  t0,
+			Finished: t0.Add(time.Minute + 250*time.Millisecond),
+			ToolCalls: []params.HookToolCall{{
+				Name:     "status-set",
+				Started:  t0.Add(time.Second),
+				Duration: 30 * time.Millisecond,
+			}, {
+				Name:     "config-get",
+				Started:  t0.Add(2 * time.Second),
+				Duration: 1500 * time.Microsecond,
+				ExitCode: 1,
+			}},
+		}, {
+			Kind:     "hook",
+			Name:     "start",
+			Started:  t0.Add(2 * time.Minute),
+			Finished: t0.Add(2*time.Minute + 5*time.Second),
+			ExitCode: 1,
+			Error:    "exit status 1",
+		}},
+	}
+	s.PatchValue(&newAPIClientForHookHistory, func(*hookHistoryCommand) (hookHistoryAPI, error) {
+		return s.api, nil
+	})
+}
+
+func (s *HookHistorySuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := &hookHistoryCommand{}
+	command.SetClientStore(newTestClientStore())
+	return coretesting.RunCommand(c, modelcmd.Wrap(command), args...)
+}
+
+type fakeHookHistoryAPI struct {
+	testing.Stub
+	executions []params.HookExecution
+}
+
+func (a *fakeHookHistoryAPI) HookExecutions(unit names.UnitTag) ([]params.HookExecution, error) {
+	a.MethodCall(a, "HookExecutions", unit)
+	return a.executions, a.NextErr()
+}
+
+func (a *fakeHookHistoryAPI) Close() error {
+	a.MethodCall(a, "Close")
+	return a.NextErr()
+}
+
+func (s *HookHistorySuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"mysql/0"},
+	}, {
+		args: []string{},
+		err:  "no unit specified",
+	}, {
+		args: []string{"mysql"},
+		err:  `unit name "mysql" not valid`,
+	}, {
+		args: []string{"mysql/0", "mysql/1"},
+		err:  `unrecognized args: \["mysql/1"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		command := &hookHistoryCommand{}
+		command.SetClientStore(newTestClientStore())
+		err := coretesting.InitCommand(modelcmd.Wrap(command), test.args)
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
+
+func (s *HookHistorySuite) TestTabular(c *gc.C) {
+	ctx, err := s.run(c, "--utc", "mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(coretesting.Stdout(ctx), gc.Equals, ""+
+		"TIME                  KIND  NAME     DURATION  EXIT  TOOLS\n"+
+		"2017-06-01 10:00:00Z  hook  install  1m0.25s   0     status-set 30ms, config-get 1ms (exit 1)\n"+
+		"2017-06-01 10:02:00Z  hook  start    5s        1     \n",
+	)
+	s.api.CheckCalls(c, []testing.StubCall{
+		{"HookExecutions", []interface{}{names.NewUnitTag("mysql/0")}},
+		{"Close", nil},
+	})
+}
+
+func (s *HookHistorySuite) TestTabularDroppedTools(c *gc.C) {
+	s.api.executions[0].DroppedToolCalls = 7
+	ctx, err := s.run(c, "--utc", "mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(coretesting.Stdout(ctx), gc.Equals, ""+
+		"TIME                  KIND  NAME     DURATION  EXIT  TOOLS\n"+
+		"2017-06-01 10:00:00Z  hook  install  1m0.25s   0     status-set 30ms, config-get 1ms (exit 1), 7 more\n"+
+		"2017-06-01 10:02:00Z  hook  start    5s        1     \n",
+	)
+}
+
+func (s *HookHistorySuite) TestYAML(c *gc.C) {
+	ctx, err := s.run(c, "--utc", "--format", "yaml", "mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(coretesting.Stdout(ctx), gc.Equals, `
+- started: "2017-06-01T10:00:00Z"
+  kind: hook
+  name: install
+  duration: 1m0.25s
+  exit-code: 0
+  tools:
+  - name: status-set
+    started: "2017-06-01T10:00:01Z"
+    duration: 30ms
+    exit-code: 0
+  - name: config-get
+    started: "2017-06-01T10:00:02Z"
+    duration: 1ms
+    exit-code: 1
+- started: "2017-06-01T10:02:00Z"
+  kind: hook
+  name: start
+  duration: 5s
+  exit-code: 1
+  error: exit status 1
+`[1:])
+}
+
+func (s *HookHistorySuite) TestLastN(c *gc.C) {
+	ctx, err := s.run(c, "--utc", "--format", "json", "-n", "1", "mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(coretesting.Stdout(ctx), gc.Equals,
+		`[{"started":"2017-06-01T10:02:00Z","kind":"hook","name":"start","duration":"5s","exit-code":1,"error":"exit status 1"}]`+"\n",
+	)
+}
+
+func (s *HookHistorySuite) TestNoExecutions(c *gc.C) {
+	s.api.executions = nil
+	ctx, err := s.run(c, "mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(coretesting.Stdout(ctx), gc.Equals, "")
+	c.Check(coretesting.Stderr(ctx), gc.Equals, "No hook executions recorded for mysql/0.\n")
+}
+
+func (s *HookHistorySuite) TestError(c *gc.C) {
+	s.api.SetErrors(errors.NotFoundf("unit %q", "mysql/0"))
+	_, err := s.run(c, "mysql/0")
+	c.Assert(err, gc.ErrorMatches, `unit "mysql/0" not found`)
+	s.api.CheckCallNames(c, "HookExecutions", "Close")
+}
@@ -8,38 +8,64 @@ import (
 	"io"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
 	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
 
+	"github.com/juju/juju/api"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/juju/osenv"
+	"github.com/juju/juju/state/multiwatcher"
 )
 
 var logger = loggo.GetLogger("juju.cmd.juju.status")
 
 type statusAPI interface {
 	Status(patterns []string) (*params.FullStatus, error)
+	WatchAll() (allWatcher, error)
 	Close() error
 }
 
+// allWatcher is the subset of *api.AllWatcher used by
+// "juju status --watch" to learn when the model has changed.
+type allWatcher interface {
+	Next() ([]multiwatcher.Delta, error)
+	Stop() error
+}
+
+// statusAPIClient adapts *api.Client to the statusAPI interface.
+type statusAPIClient struct {
+	*api.Client
+}
+
+// WatchAll is part of the statusAPI interface.
+func (c statusAPIClient) WatchAll() (allWatcher, error) {
+	return c.Client.WatchAll()
+}
+
 // NewStatusCommand returns a new command, which reports on the
 // runtime state of various system entities.
 func NewStatusCommand() cmd.Command {
-	return modelcmd.Wrap(&statusCommand{})
+	return modelcmd.Wrap(&statusCommand{clock: clock.WallClock})
 }
 
 type statusCommand struct {
 	modelcmd.ModelCommandBase
-	out      cmd.Output
-	patterns []string
-	isoTime  bool
-	api      statusAPI
-
-	color bool
+	out        cmd.Output
+	formatters map[string]cmd.Formatter
+	patterns   []string
+	isoTime    bool
+	watch      bool
+	api        statusAPI
+	clock      clock.Clock
+
+	color         bool
+	watchInterval time.Duration
 }
 
 var usageSummary = `
@@ -73,10 +99,21 @@ The available output formats are:
 - json: Displays information about the model, machines, applications, and units
       in structured JSON format.
 
+With --watch, the command keeps running and redraws the status whenever the
+model changes, until interrupted. The status is fetched from the controller
+once and then kept up to date with the changes reported by the model; changes
+are gathered for --watch-interval (10s by default) between redraws. Lines that
+changed since the previous frame are highlighted when color output is in use.
+Filter patterns are honoured, and entities which did not match them when the
+command started are not shown. New subordinate units, and agents which stop
+reporting, are only shown once the command is restarted.
+
 Examples:
     juju show-status
     juju show-status mysql
     juju show-status nova-*
+    juju show-status --watch
+    juju show-status --watch --watch-interval 30s
 
 See also:
     machines
@@ -99,10 +136,12 @@ func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
 	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
 	f.BoolVar(&c.color, "color", false, "Force use of ANSI color codes")
+	f.BoolVar(&c.watch, "watch", false, "Redraw the status whenever the model changes")
+	f.DurationVar(&c.watchInterval, "watch-interval", defaultStatusWatchInterval, "Minimum time between redraws with --watch")
 
 	defaultFormat := "tabular"
 
-	c.out.AddFlags(f, defaultFormat, map[string]cmd.Formatter{
+	c.formatters = map[string]cmd.Formatter{
 		"yaml":    cmd.FormatYaml,
 		"json":    cmd.FormatJson,
 		"short":   FormatOneline,
@@ -110,11 +149,15 @@ func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 		"line":    FormatOneline,
 		"tabular": c.FormatTabular,
 		"summary": FormatSummary,
-	})
+	}
+	c.out.AddFlags(f, defaultFormat, c.formatters)
 }
 
 func (c *statusCommand) Init(args []string) error {
 	c.patterns = args
+	if c.watch && c.watchInterval <= 0 {
+		return errors.New("--watch-interval must be positive")
+	}
 	// If use of ISO time not specified on command line,
 	// check env var.
 	if !c.isoTime {
@@ -130,7 +173,11 @@ func (c *statusCommand) Init(args []string) error {
 }
 
 var newAPIClientForStatus = func(c *statusCommand) (statusAPI, error) {
-	return c.NewAPIClient()
+	client, err := c.NewAPIClient()
+	if err != nil {
+		return nil, err
+	}
+	return statusAPIClient{client}, nil
 }
 
 func (c *statusCommand) Run(ctx *cmd.Context) error {
@@ -140,24 +187,33 @@ func (c *statusCommand) Run(ctx *cmd.Context) error {
 	}
 	defer apiclient.Close()
 
+	if c.watch {
+		return c.runWatch(ctx, apiclient)
+	}
+	formatted, err := c.getStatus(ctx, apiclient)
+	if err != nil {
+		return err
+	}
+	return c.out.Write(ctx, formatted)
+}
+
+// getStatus fetches the status matching the command's filter patterns
+// and returns it ready for formatting.
+func (c *statusCommand) getStatus(ctx *cmd.Context, apiclient statusAPI) (formattedStatus, error) {
 	status, err := apiclient.Status(c.patterns)
 	if err != nil {
 		if status == nil {
 			// Status call completely failed, there is nothing to report
-			return err
+			return formattedStatus{}, err
 		}
 		// Display any error, but continue to print status if some was returned
 		fmt.Fprintf(ctx.Stderr, "%v\n", err)
 	} else if status == nil {
-		return errors.Errorf("unable to obtain the current status")
+		return formattedStatus{}, errors.Errorf("unable to obtain the current status")
 	}
 
 	formatter := newStatusFormatter(status, c.ControllerName(), c.isoTime)
-	formatted, err := formatter.format()
-	if err != nil {
-		return err
-	}
-	return c.out.Write(ctx, formatted)
+	return formatter.format()
 }
 
 func (c *statusCommand) FormatTabular(writer io.Writer, value interface{}) error {
@@ -0,0 +1,322 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/clock"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/state/multiwatcher"
+	"github.com/juju/juju/status"
+)
+
+// Exit codes returned by "juju wait" when the model does not settle.
+// Codes 1 and 2 are used by all commands for general and usage errors.
+const (
+	// WaitTimeoutExitCode is returned when the timeout expires before
+	// every selected unit has reached the requested statuses.
+	WaitTimeoutExitCode = 3
+
+	// WaitErrorExitCode is returned when a selected unit's agent or
+	// workload enters the error status.
+	WaitErrorExitCode = 4
+)
+
+type waitAPI interface {
+	WatchAll() (allWatcher, error)
+	Close() error
+}
+
+// NewWaitCommand returns a command which blocks until the units in a
+// model reach the requested agent and workload statuses.
+func NewWaitCommand() cmd.Command {
+	return modelcmd.Wrap(&waitCommand{clock: clock.WallClock})
+}
+
+type waitCommand struct {
+	modelcmd.ModelCommandBase
+	api   waitAPI
+	clock clock.Clock
+
+	timeout        time.Duration
+	settle         time.Duration
+	agentStatus    string
+	workloadStatus string
+
+	applications     set.Strings
+	units            set.Strings
+	agentStatuses    set.Strings
+	workloadStatuses set.Strings
+}
+
+var waitDoc = `
+Blocks until every unit in the model, or in the selected applications and
+units, reports the requested agent and workload statuses.
+
+By default the command waits for all agents to be "idle" and all workloads
+to be "active". Several acceptable statuses may be given, separated by commas.
+Selected applications must have at least one unit, and selected units must
+exist, for the model to be considered settled.
+
+Units often pass briefly through the requested statuses between hooks, so
+the statuses must hold for the --settle period before the command returns.
+Any change to a selected unit during that period restarts it; a settle
+period of 0 returns as soon as the statuses are first reached.
+
+The command exits with one of the following codes:
+
+    0  every selected unit reached the requested statuses
+    1  the command failed (for example, the connection was lost)
+    2  the command line was invalid
+    3  the timeout expired before the units settled
+    4  a selected unit's agent or workload is in the "error" status
+
+Examples:
+    juju wait
+    juju wait --timeout 20m mysql wordpress/0
+    juju wait --workload-status active,unknown
+    juju wait --settle 1m
+
+See also:
+    show-status
+`
+
+// Info implements Command.Info.
+func (c *waitCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "wait",
+		Args:    "[<application> | <unit> ...]",
+		Purpose: "Wait for the units in a model to settle.",
+		Doc:     waitDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *waitCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.DurationVar(&c.timeout, "timeout", 0, "Give up after this long (0 waits forever)")
+	f.DurationVar(&c.settle, "settle", 15*time.Second, "How long the statuses must hold with no unit changes")
+	f.StringVar(&c.agentStatus, "agent-status", string(status.Idle), "Comma separated agent statuses to wait for")
+	f.StringVar(&c.workloadStatus, "workload-status", string(status.Active), "Comma separated workload statuses to wait for")
+}
+
+// Init implements Command.Init.
+func (c *waitCommand) Init(args []string) error {
+	if c.timeout < 0 {
+		return errors.NotValidf("negative timeout")
+	}
+	if c.settle < 0 {
+		return errors.NotValidf("negative settle period")
+	}
+	c.applications = set.NewStrings()
+	c.units = set.NewStrings()
+	for _, arg := range args {
+		switch {
+		case names.IsValidUnit(arg):
+			c.units.Add(arg)
+		case names.IsValidApplication(arg):
+			c.applications.Add(arg)
+		default:
+			return errors.NotValidf("application or unit name %q", arg)
+		}
+	}
+	var err error
+	if c.agentStatuses, err = parseStatuses(c.agentStatus); err != nil {
+		return errors.Annotate(err, "invalid --agent-status")
+	}
+	if c.workloadStatuses, err = parseStatuses(c.workloadStatus); err != nil {
+		return errors.Annotate(err, "invalid --workload-status")
+	}
+	return nil
+}
+
+func parseStatuses(value string) (set.Strings, error) {
+	statuses := set.NewStrings()
+	for _, s := range strings.Split(value, ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		statuses.Add(s)
+	}
+	if statuses.IsEmpty() {
+		return nil, errors.New("no statuses specified")
+	}
+	return statuses, nil
+}
+
+func (c *waitCommand) newAPI() (waitAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	client, err := c.NewAPIClient()
+	if err != nil {
+		return nil, err
+	}
+	return statusAPIClient{client}, nil
+}
+
+// Run implements Command.Run.
+func (c *waitCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	watcher, err := client.WatchAll()
+	if err != nil {
+		return errors.Annotate(err, "cannot watch model")
+	}
+	defer watcher.Stop()
+
+	interrupted := make(chan os.Signal, 1)
+	ctx.InterruptNotify(interrupted)
+	defer ctx.StopInterruptNotify(interrupted)
+
+	type nextResult struct {
+		deltas []multiwatcher.Delta
+		err    error
+	}
+	next := make(chan nextResult)
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			deltas, err := watcher.Next()
+			select {
+			case next <- nextResult{deltas, err}:
+			case <-done:
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	var timeout <-chan time.Time
+	if c.timeout > 0 {
+		timeout = c.clock.After(c.timeout)
+	}
+	// settled fires once the selected units have held the requested
+	// statuses for the settle period; it is nil while they have not.
+	var settled <-chan time.Time
+	units := make(map[string]*multiwatcher.UnitInfo)
+	for {
+		select {
+		case <-interrupted:
+			return errors.New("interrupted")
+		case <-timeout:
+			waiting := fmt.Sprintf("the model to stay settled for %v", c.settle)
+			if pending := c.pending(units); len(pending) > 0 {
+				waiting = strings.Join(pending, ", ")
+			}
+			fmt.Fprintf(ctx.Stderr, "timed out after %v waiting for %s\n", c.timeout, waiting)
+			return cmd.NewRcPassthroughError(WaitTimeoutExitCode)
+		case <-settled:
+			ctx.Infof("model settled")
+			return nil
+		case result := <-next:
+			if result.err != nil {
+				return errors.Annotate(result.err, "watching model")
+			}
+			changed := false
+			for _, delta := range result.deltas {
+				unit, ok := delta.Entity.(*multiwatcher.UnitInfo)
+				if !ok {
+					continue
+				}
+				if c.selected(unit) {
+					changed = true
+				}
+				if delta.Removed {
+					delete(units, unit.Name)
+				} else {
+					units[unit.Name] = unit
+				}
+			}
+			if failed := c.failed(units); len(failed) > 0 {
+				fmt.Fprintf(ctx.Stderr, "%s in error status\n", strings.Join(failed, ", "))
+				return cmd.NewRcPassthroughError(WaitErrorExitCode)
+			}
+			if pending := c.pending(units); len(pending) > 0 {
+				settled = nil
+				continue
+			}
+			if c.settle == 0 {
+				ctx.Infof("model settled")
+				return nil
+			}
+			if settled == nil || changed {
+				settled = c.clock.After(c.settle)
+			}
+		}
+	}
+}
+
+// selected reports whether the unit is one being waited for.
+func (c *waitCommand) selected(unit *multiwatcher.UnitInfo) bool {
+	if c.applications.IsEmpty() && c.units.IsEmpty() {
+		return true
+	}
+	return c.applications.Contains(unit.Application) || c.units.Contains(unit.Name)
+}
+
+// failed returns the sorted names of the selected units whose agent or
+// workload is in error.
+func (c *waitCommand) failed(units map[string]*multiwatcher.UnitInfo) []string {
+	var failed []string
+	for name, unit := range units {
+		if !c.selected(unit) {
+			continue
+		}
+		if unit.AgentStatus.Current == status.Error || unit.WorkloadStatus.Current == status.Error {
+			failed = append(failed, name)
+		}
+	}
+	sort.Strings(failed)
+	return failed
+}
+
+// pending returns a sorted description of everything still being
+// waited for: selected units which have not reached the requested
+// statuses, and selected applications or units which do not exist yet.
+func (c *waitCommand) pending(units map[string]*multiwatcher.UnitInfo) []string {
+	var pending []string
+	seenApplications := set.NewStrings()
+	for name, unit := range units {
+		if !c.selected(unit) {
+			continue
+		}
+		seenApplications.Add(unit.Application)
+		agent, workload := unit.AgentStatus.Current, unit.WorkloadStatus.Current
+		if !c.agentStatuses.Contains(string(agent)) || !c.workloadStatuses.Contains(string(workload)) {
+			pending = append(pending, fmt.Sprintf("%s (agent: %s, workload: %s)", name, agent, workload))
+		}
+	}
+	for _, name := range c.applications.Values() {
+		if !seenApplications.Contains(name) {
+			pending = append(pending, fmt.Sprintf("application %s (no units)", name))
+		}
+	}
+	for _, name := range c.units.Values() {
+		if _, ok := units[name]; !ok {
+			pending = append(pending, fmt.Sprintf("%s (not found)", name))
+		}
+	}
+	sort.Strings(pending)
+	return pending
+}
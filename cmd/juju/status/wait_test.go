@@ -0,0 +1,278 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/state/multiwatcher"
+	"github.com/juju/juju/status"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type WaitSuite struct {
+	coretesting.BaseSuite
+	store   *jujuclienttesting.MemStore
+	clock   *testing.Clock
+	watcher *fakeWaitWatcher
+}
+
+var _ = gc.Suite(&WaitSuite{})
+
+func (s *WaitSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.store = newTestClientStore()
+	s.clock = testing.NewClock(time.Date(2017, time.June, 1, 12, 0, 0, 0, time.UTC))
+	s.watcher = &fakeWaitWatcher{
+		deltas:  make(chan []multiwatcher.Delta, 10),
+		stopped: make(chan struct{}),
+	}
+}
+
+// newTestClientStore returns a client store with a current controller
+// and model, for running model commands against fake APIs.
+func newTestClientStore() *jujuclienttesting.MemStore {
+	store := jujuclienttesting.NewMemStore()
+	store.CurrentControllerName = "ctrl"
+	store.Controllers["ctrl"] = jujuclient.ControllerDetails{}
+	store.Models["ctrl"] = &jujuclient.ControllerModels{
+		Models:       map[string]jujuclient.ModelDetails{"admin/default": {"default-uuid"}},
+		CurrentModel: "admin/default",
+	}
+	store.Accounts["ctrl"] = jujuclient.AccountDetails{User: "admin"}
+	return store
+}
+
+func (s *WaitSuite) run(c *gc.C, args ...string) (int, *cmd.Context) {
+	command := &waitCommand{
+		api:   &fakeWaitAPI{watcher: s.watcher},
+		clock: s.clock,
+	}
+	command.SetClientStore(s.store)
+	ctx := coretesting.Context(c)
+	code := cmd.Main(modelcmd.Wrap(command), ctx, args)
+	return code, ctx
+}
+
+// start runs the command in the background. It returns a channel
+// closed when the command finishes, and a function which waits for
+// that and returns the command's results.
+func (s *WaitSuite) start(c *gc.C, args ...string) (<-chan struct{}, func() (int, *cmd.Context)) {
+	done := make(chan struct{})
+	var (
+		code int
+		ctx  *cmd.Context
+	)
+	go func() {
+		defer close(done)
+		code, ctx = s.run(c, args...)
+	}()
+	return done, func() (int, *cmd.Context) {
+		select {
+		case <-done:
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for command to finish")
+		}
+		return code, ctx
+	}
+}
+
+type fakeWaitAPI struct {
+	watcher *fakeWaitWatcher
+}
+
+func (a *fakeWaitAPI) WatchAll() (allWatcher, error) {
+	return a.watcher, nil
+}
+
+func (a *fakeWaitAPI) Close() error {
+	return nil
+}
+
+type fakeWaitWatcher struct {
+	deltas  chan []multiwatcher.Delta
+	stopped chan struct{}
+}
+
+func (w *fakeWaitWatcher) Next() ([]multiwatcher.Delta, error) {
+	select {
+	case deltas, ok := <-w.deltas:
+		if !ok {
+			return nil, errors.New("connection lost")
+		}
+		return deltas, nil
+	case <-w.stopped:
+		return nil, errors.New("watcher was stopped")
+	}
+}
+
+func (w *fakeWaitWatcher) Stop() error {
+	close(w.stopped)
+	return nil
+}
+
+func unitDelta(name string, agent, workload status.Status) multiwatcher.Delta {
+	return multiwatcher.Delta{Entity: &multiwatcher.UnitInfo{
+		Name:           name,
+		Application:    name[:len(name)-2],
+		AgentStatus:    multiwatcher.StatusInfo{Current: agent},
+		WorkloadStatus: multiwatcher.StatusInfo{Current: workload},
+	}}
+}
+
+func (s *WaitSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"mysql", "wordpress/0"},
+	}, {
+		args: []string{"--agent-status", " ,"},
+		err:  "invalid --agent-status: no statuses specified",
+	}, {
+		args: []string{"--timeout", "-1s"},
+		err:  "negative timeout not valid",
+	}, {
+		args: []string{"--settle", "-1s"},
+		err:  "negative settle period not valid",
+	}, {
+		args: []string{"foo/bar"},
+		err:  `application or unit name "foo/bar" not valid`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		command := &waitCommand{}
+		command.SetClientStore(s.store)
+		err := coretesting.InitCommand(modelcmd.Wrap(command), test.args)
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
+
+func (s *WaitSuite) TestSettled(c *gc.C) {
+	s.watcher.deltas <- []multiwatcher.Delta{
+		unitDelta("mysql/0", status.Executing, status.Maintenance),
+		unitDelta("wordpress/0", status.Idle, status.Active),
+	}
+	s.watcher.deltas <- []multiwatcher.Delta{
+		unitDelta("mysql/0", status.Idle, status.Active),
+	}
+	_, wait := s.start(c)
+	err := s.clock.WaitAdvance(15*time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	code, ctx := wait()
+	c.Check(code, gc.Equals, 0)
+	c.Check(coretesting.Stderr(ctx), gc.Equals, "model settled\n")
+	c.Check(s.watcher.deltas, gc.HasLen, 0)
+}
+
+func (s *WaitSuite) TestSettleRestartsOnUnitChange(c *gc.C) {
+	s.watcher.deltas <- []multiwatcher.Delta{
+		unitDelta("mysql/0", status.Idle, status.Active),
+	}
+	done, wait := s.start(c, "--settle", "1m")
+	err := s.clock.WaitAdvance(50*time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The unit changes again before the settle period has passed, so
+	// the period starts over.
+	s.watcher.deltas <- []multiwatcher.Delta{
+		unitDelta("mysql/0", status.Idle, status.Active),
+	}
+	err = s.clock.WaitAdvance(50*time.Second, coretesting.LongWait, 2)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case <-done:
+		c.Fatalf("command finished before the restarted settle period")
+	case <-time.After(coretesting.ShortWait):
+	}
+	s.clock.Advance(10 * time.Second)
+	code, _ := wait()
+	c.Check(code, gc.Equals, 0)
+	c.Check(s.watcher.deltas, gc.HasLen, 0)
+}
+
+func (s *WaitSuite) TestSettleZero(c *gc.C) {
+	s.watcher.deltas <- []multiwatcher.Delta{
+		unitDelta("mysql/0", status.Idle, status.Active),
+	}
+	code, ctx := s.run(c, "--settle", "0")
+	c.Check(code, gc.Equals, 0)
+	c.Check(coretesting.Stderr(ctx), gc.Equals, "model settled\n")
+}
+
+func (s *WaitSuite) TestTimeoutWhileSettling(c *gc.C) {
+	s.watcher.deltas <- []multiwatcher.Delta{
+		unitDelta("mysql/0", status.Idle, status.Active),
+	}
+	_, wait := s.start(c, "--timeout", "1m", "--settle", "2m")
+	err := s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 2)
+	c.Assert(err, jc.ErrorIsNil)
+	code, ctx := wait()
+	c.Check(code, gc.Equals, WaitTimeoutExitCode)
+	c.Check(coretesting.Stderr(ctx), gc.Equals,
+		"timed out after 1m0s waiting for the model to stay settled for 2m0s\n")
+}
+
+func (s *WaitSuite) TestSettledSelected(c *gc.C) {
+	s.watcher.deltas <- []multiwatcher.Delta{
+		unitDelta("mysql/0", status.Idle, status.Unknown),
+		unitDelta("wordpress/0", status.Executing, status.Error),
+	}
+	code, _ := s.run(c, "--settle", "0", "--workload-status", "active,unknown", "mysql")
+	c.Check(code, gc.Equals, 0)
+}
+
+func (s *WaitSuite) TestWaitsForSelectedUnitsToExist(c *gc.C) {
+	s.watcher.deltas <- []multiwatcher.Delta{
+		unitDelta("mysql/0", status.Idle, status.Active),
+	}
+	s.watcher.deltas <- []multiwatcher.Delta{
+		unitDelta("mysql/1", status.Idle, status.Active),
+	}
+	code, _ := s.run(c, "--settle", "0", "mysql/1")
+	c.Check(code, gc.Equals, 0)
+	c.Check(s.watcher.deltas, gc.HasLen, 0)
+}
+
+func (s *WaitSuite) TestError(c *gc.C) {
+	s.watcher.deltas <- []multiwatcher.Delta{
+		unitDelta("mysql/0", status.Idle, status.Error),
+		unitDelta("mysql/1", status.Error, status.Maintenance),
+	}
+	code, ctx := s.run(c)
+	c.Check(code, gc.Equals, WaitErrorExitCode)
+	c.Check(coretesting.Stderr(ctx), gc.Equals, "mysql/0, mysql/1 in error status\n")
+}
+
+func (s *WaitSuite) TestTimeout(c *gc.C) {
+	s.watcher.deltas <- []multiwatcher.Delta{
+		unitDelta("mysql/0", status.Executing, status.Maintenance),
+	}
+	_, wait := s.start(c, "--timeout", "10m", "mysql", "wordpress")
+	err := s.clock.WaitAdvance(10*time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	code, ctx := wait()
+	c.Check(code, gc.Equals, WaitTimeoutExitCode)
+	c.Check(coretesting.Stderr(ctx), gc.Equals, "timed out after 10m0s waiting for "+
+		"application wordpress (no units), mysql/0 (agent: executing, workload: maintenance)\n")
+}
+
+func (s *WaitSuite) TestWatcherError(c *gc.C) {
+	close(s.watcher.deltas)
+	code, ctx := s.run(c)
+	c.Check(code, gc.Equals, 1)
+	c.Check(coretesting.Stderr(ctx), gc.Equals, "error: watching model: connection lost\n")
+}
@@ -0,0 +1,204 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/mattn/go-isatty"
+
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+// defaultStatusWatchInterval is the default minimum time between two
+// redraws of "juju status --watch". Deltas arriving within the
+// interval are coalesced into a single frame.
+const defaultStatusWatchInterval = 10 * time.Second
+
+const (
+	// clearScreen moves the cursor home and clears the terminal.
+	clearScreen = "\x1b[H\x1b[2J"
+
+	highlightOn  = "\x1b[1m"
+	highlightOff = "\x1b[0m"
+)
+
+// runWatch redraws the status every time the model's all-watcher
+// reports a change, until the command is interrupted. The full status
+// is fetched once; later frames are rendered from that status, kept
+// up to date with the all-watcher's deltas.
+func (c *statusCommand) runWatch(ctx *cmd.Context, apiclient statusAPI) error {
+	// Start watching before fetching the status, so no change made
+	// in between is missed.
+	watcher, err := apiclient.WatchAll()
+	if err != nil {
+		return errors.Annotate(err, "cannot watch model")
+	}
+	defer watcher.Stop()
+
+	status, err := apiclient.Status(c.patterns)
+	if err != nil {
+		if status == nil {
+			return errors.Trace(err)
+		}
+		fmt.Fprintf(ctx.Stderr, "%v\n", err)
+	} else if status == nil {
+		return errors.Errorf("unable to obtain the current status")
+	}
+	model := newStatusModel(status, len(c.patterns) > 0)
+
+	interrupted := make(chan os.Signal, 1)
+	ctx.InterruptNotify(interrupted)
+	defer ctx.StopInterruptNotify(interrupted)
+
+	done := make(chan struct{})
+	defer close(done)
+	deltas := make(chan []multiwatcher.Delta)
+	errs := make(chan error, 1)
+	go func() {
+		for {
+			d, err := watcher.Next()
+			if err != nil {
+				errs <- err
+				return
+			}
+			select {
+			case deltas <- d:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	terminal := isTerminal(ctx.Stdout)
+	color := c.color || terminal
+	var previous string
+	render := func() error {
+		formatted, err := newStatusFormatter(model.status, c.ControllerName(), c.isoTime).format()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		frame, err := c.formatFrame(formatted, color)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if frame == previous {
+			return nil
+		}
+		if err := writeFrame(ctx.Stdout, frame, previous, terminal, color); err != nil {
+			return errors.Trace(err)
+		}
+		previous = frame
+		return nil
+	}
+
+	// redraw is nil when a frame may be drawn straight away, and
+	// otherwise fires once the watch interval since the last frame
+	// has elapsed.
+	var redraw <-chan time.Time
+	dirty := true
+	for {
+		if dirty && redraw == nil {
+			if err := render(); err != nil {
+				return errors.Trace(err)
+			}
+			dirty = false
+			redraw = c.clock.After(c.watchInterval)
+		}
+		select {
+		case <-interrupted:
+			return nil
+		case err := <-errs:
+			// Show the last changes seen before giving up.
+			if dirty {
+				if err := render(); err != nil {
+					return errors.Trace(err)
+				}
+			}
+			return errors.Annotate(err, "watching model")
+		case d := <-deltas:
+			if model.apply(d) {
+				dirty = true
+			}
+		case <-redraw:
+			redraw = nil
+		}
+	}
+}
+
+// formatFrame renders the status with the selected output format.
+func (c *statusCommand) formatFrame(formatted formattedStatus, color bool) (string, error) {
+	var buf bytes.Buffer
+	name := c.out.Name()
+	if name == "tabular" {
+		if err := FormatTabular(&buf, color, formatted); err != nil {
+			return "", err
+		}
+	} else {
+		formatter, ok := c.formatters[name]
+		if !ok {
+			return "", errors.NotValidf("format %q", name)
+		}
+		if err := formatter(&buf, formatted); err != nil {
+			return "", err
+		}
+	}
+	frame := buf.String()
+	if !strings.HasSuffix(frame, "\n") {
+		frame += "\n"
+	}
+	return frame, nil
+}
+
+// writeFrame writes frame to out. On a terminal the screen is cleared
+// first, otherwise frames are separated by a blank line. When color is
+// enabled, lines which were not part of the previous frame are
+// highlighted.
+func writeFrame(out io.Writer, frame, previous string, terminal, color bool) error {
+	var buf bytes.Buffer
+	if terminal {
+		buf.WriteString(clearScreen)
+	} else if previous != "" {
+		buf.WriteString("\n")
+	}
+	if color && previous != "" {
+		seen := make(map[string]bool)
+		for _, line := range strings.Split(previous, "\n") {
+			seen[line] = true
+		}
+		lines := strings.Split(strings.TrimSuffix(frame, "\n"), "\n")
+		for _, line := range lines {
+			if !seen[line] && strings.TrimSpace(line) != "" {
+				line = highlight(line)
+			}
+			fmt.Fprintln(&buf, line)
+		}
+	} else {
+		buf.WriteString(frame)
+	}
+	_, err := out.Write(buf.Bytes())
+	return err
+}
+
+// highlight makes line bold, re-applying the highlight after any
+// reset emitted by the color codes already present in the line.
+func highlight(line string) string {
+	line = strings.Replace(line, highlightOff, highlightOff+highlightOn, -1)
+	return highlightOn + line + highlightOff
+}
+
+func isTerminal(out io.Writer) bool {
+	f, ok := out.(*os.File)
+	if !ok {
+		return false
+	}
+	return isatty.IsTerminal(f.Fd())
+}
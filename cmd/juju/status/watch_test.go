@@ -0,0 +1,208 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state/multiwatcher"
+	"github.com/juju/juju/status"
+)
+
+// watchAPIClient returns the given status, and serves an all-watcher
+// which reports the given batches of deltas in order, stopping once
+// all of them have been reported.
+type watchAPIClient struct {
+	status       *params.FullStatus
+	deltas       [][]multiwatcher.Delta
+	patternsUsed [][]string
+	stopped      bool
+	closed       bool
+}
+
+func newWatchAPIClient(status *params.FullStatus, deltas ...[]multiwatcher.Delta) *watchAPIClient {
+	return &watchAPIClient{
+		status: status,
+		deltas: deltas,
+	}
+}
+
+func (a *watchAPIClient) Status(patterns []string) (*params.FullStatus, error) {
+	a.patternsUsed = append(a.patternsUsed, patterns)
+	return a.status, nil
+}
+
+func (a *watchAPIClient) WatchAll() (allWatcher, error) {
+	return &watchAllWatcher{client: a}, nil
+}
+
+func (a *watchAPIClient) Close() error {
+	a.closed = true
+	return nil
+}
+
+type watchAllWatcher struct {
+	client *watchAPIClient
+	seen   int
+}
+
+func (w *watchAllWatcher) Next() ([]multiwatcher.Delta, error) {
+	if w.seen == len(w.client.deltas) {
+		return nil, errors.New("watcher stopped")
+	}
+	w.seen++
+	return w.client.deltas[w.seen-1], nil
+}
+
+func (w *watchAllWatcher) Stop() error {
+	w.client.stopped = true
+	return nil
+}
+
+func watchFullStatus(units map[string]string) *params.FullStatus {
+	status := &params.FullStatus{
+		Model: params.ModelStatusInfo{
+			Name:     "controller",
+			CloudTag: "cloud-dummy",
+		},
+		Applications: map[string]params.ApplicationStatus{
+			"mysql": {
+				Charm: "cs:quantal/mysql-1",
+				Units: make(map[string]params.UnitStatus),
+			},
+		},
+	}
+	for name, workload := range units {
+		status.Applications["mysql"].Units[name] = params.UnitStatus{
+			AgentStatus:    params.DetailedStatus{Status: "idle"},
+			WorkloadStatus: params.DetailedStatus{Status: workload},
+			PublicAddress:  "10.0.0.1",
+		}
+	}
+	return status
+}
+
+func watchUnitDelta(name, workload string) multiwatcher.Delta {
+	return multiwatcher.Delta{Entity: &multiwatcher.UnitInfo{
+		Name:           name,
+		Application:    "mysql",
+		CharmURL:       "cs:quantal/mysql-1",
+		PublicAddress:  "10.0.0.1",
+		AgentStatus:    multiwatcher.StatusInfo{Current: status.Idle},
+		WorkloadStatus: multiwatcher.StatusInfo{Current: status.Status(workload)},
+	}}
+}
+
+func (s *StatusSuite) patchWatch(client *watchAPIClient) {
+	s.PatchValue(&newAPIClientForStatus, func(_ *statusCommand) (statusAPI, error) {
+		return client, nil
+	})
+}
+
+func (s *StatusSuite) TestWatchRedrawsOnChange(c *gc.C) {
+	client := newWatchAPIClient(
+		watchFullStatus(map[string]string{"mysql/0": "maintenance"}),
+		[]multiwatcher.Delta{watchUnitDelta("mysql/0", "maintenance")},
+		[]multiwatcher.Delta{watchUnitDelta("mysql/0", "active")},
+	)
+	s.patchWatch(client)
+
+	code, stdout, stderr := runStatus(c, "--watch", "--watch-interval", "1ms", "--format", "oneline", "mysql")
+	c.Check(code, gc.Equals, 1)
+	c.Check(string(stderr), gc.Equals, "error: watching model: watcher stopped\n")
+	// The unchanged unit does not produce a frame.
+	c.Check(string(stdout), gc.Equals, ""+
+		"\n- mysql/0: 10.0.0.1 (agent:idle, workload:maintenance)\n"+
+		"\n"+
+		"\n- mysql/0: 10.0.0.1 (agent:idle, workload:active)\n",
+	)
+	// The status is only fetched once.
+	c.Check(client.patternsUsed, jc.DeepEquals, [][]string{{"mysql"}})
+	c.Check(client.stopped, jc.IsTrue)
+	c.Check(client.closed, jc.IsTrue)
+}
+
+func (s *StatusSuite) TestWatchHighlightsChangedLines(c *gc.C) {
+	client := newWatchAPIClient(
+		watchFullStatus(map[string]string{"mysql/0": "active", "mysql/1": "maintenance"}),
+		[]multiwatcher.Delta{watchUnitDelta("mysql/1", "active")},
+	)
+	s.patchWatch(client)
+
+	code, stdout, _ := runStatus(c, "--watch", "--watch-interval", "1ms", "--color", "--format", "oneline")
+	c.Check(code, gc.Equals, 1)
+	frames := strings.SplitN(string(stdout), "\n\n", 2)
+	c.Assert(frames, gc.HasLen, 2)
+	c.Check(frames[0], gc.Equals, ""+
+		"\n- mysql/0: 10.0.0.1 (agent:idle, workload:active)"+
+		"\n- mysql/1: 10.0.0.1 (agent:idle, workload:maintenance)",
+	)
+	c.Check(frames[1], gc.Equals, ""+
+		"\n- mysql/0: 10.0.0.1 (agent:idle, workload:active)\n"+
+		"\x1b[1m- mysql/1: 10.0.0.1 (agent:idle, workload:active)\x1b[0m\n",
+	)
+}
+
+func (s *StatusSuite) TestWatchAddsAndRemovesUnits(c *gc.C) {
+	removed := watchUnitDelta("mysql/0", "active")
+	removed.Removed = true
+	client := newWatchAPIClient(
+		watchFullStatus(map[string]string{"mysql/0": "active"}),
+		[]multiwatcher.Delta{watchUnitDelta("mysql/1", "waiting"), removed},
+	)
+	s.patchWatch(client)
+
+	code, stdout, _ := runStatus(c, "--watch", "--watch-interval", "1ms", "--format", "oneline")
+	c.Check(code, gc.Equals, 1)
+	c.Check(string(stdout), gc.Equals, ""+
+		"\n- mysql/0: 10.0.0.1 (agent:idle, workload:active)\n"+
+		"\n"+
+		"\n- mysql/1: 10.0.0.1 (agent:idle, workload:waiting)\n",
+	)
+}
+
+func (s *StatusSuite) TestWatchIgnoresUnitsOutsidePatterns(c *gc.C) {
+	client := newWatchAPIClient(
+		watchFullStatus(map[string]string{"mysql/0": "active"}),
+		[]multiwatcher.Delta{watchUnitDelta("mysql/1", "waiting")},
+	)
+	s.patchWatch(client)
+
+	code, stdout, _ := runStatus(c, "--watch", "--watch-interval", "1ms", "--format", "oneline", "mysql/0")
+	c.Check(code, gc.Equals, 1)
+	c.Check(string(stdout), gc.Equals, "\n- mysql/0: 10.0.0.1 (agent:idle, workload:active)\n")
+}
+
+func (s *StatusSuite) TestWatchNotSupported(c *gc.C) {
+	client := fakeAPIClient{}
+	s.PatchValue(&newAPIClientForStatus, func(_ *statusCommand) (statusAPI, error) {
+		return &client, nil
+	})
+
+	code, _, stderr := runStatus(c, "--watch")
+	c.Check(code, gc.Equals, 1)
+	c.Check(string(stderr), gc.Equals, "error: cannot watch model: watching not supported\n")
+	c.Check(client.closeCalled, jc.IsTrue)
+}
+
+func (s *StatusSuite) TestWatchIntervalDefault(c *gc.C) {
+	com, err := initStatusCommand("--watch")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(com.watchInterval, gc.Equals, defaultStatusWatchInterval)
+}
+
+func (s *StatusSuite) TestWatchIntervalInvalid(c *gc.C) {
+	_, err := initStatusCommand("--watch", "--watch-interval", "0s")
+	c.Assert(err, gc.ErrorMatches, "--watch-interval must be positive")
+}
+
+func (s *StatusSuite) TestHighlightReappliesAfterReset(c *gc.C) {
+	c.Check(highlight("a \x1b[32mgreen\x1b[0m b"), gc.Equals,
+		"\x1b[1ma \x1b[32mgreen\x1b[0m\x1b[1m b\x1b[0m")
+}
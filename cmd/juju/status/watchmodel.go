@@ -0,0 +1,412 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"strings"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+// statusModel keeps a full status up to date by applying the deltas
+// reported by the model's all-watcher, so "juju status --watch" only
+// needs to fetch the full status once.
+//
+// The deltas do not carry everything the controller derives when it
+// computes the full status, so some information is only as fresh as
+// the initial status: new subordinate units are not shown (a unit's
+// delta does not name its principal), and agents which stop
+// reporting are not marked as lost.
+type statusModel struct {
+	status *params.FullStatus
+
+	// filtered is set when the initial status was restricted by
+	// patterns; entities which were not part of it are then ignored
+	// rather than added.
+	filtered bool
+
+	// subordinates holds the names of subordinate applications,
+	// which are needed to describe relation endpoints.
+	subordinates map[string]bool
+}
+
+func newStatusModel(status *params.FullStatus, filtered bool) *statusModel {
+	if status.Machines == nil {
+		status.Machines = make(map[string]params.MachineStatus)
+	}
+	if status.Applications == nil {
+		status.Applications = make(map[string]params.ApplicationStatus)
+	}
+	if status.RemoteApplications == nil {
+		status.RemoteApplications = make(map[string]params.RemoteApplicationStatus)
+	}
+	m := &statusModel{
+		status:       status,
+		filtered:     filtered,
+		subordinates: make(map[string]bool),
+	}
+	for name, app := range status.Applications {
+		if len(app.SubordinateTo) > 0 {
+			m.subordinates[name] = true
+		}
+	}
+	return m
+}
+
+// apply updates the status with the given deltas. It reports whether
+// any delta affected an entity shown in the status.
+func (m *statusModel) apply(deltas []multiwatcher.Delta) bool {
+	changed := false
+	for _, delta := range deltas {
+		var applied bool
+		switch info := delta.Entity.(type) {
+		case *multiwatcher.ModelInfo:
+			applied = m.applyModel(info, delta.Removed)
+		case *multiwatcher.MachineInfo:
+			applied = m.applyMachine(info, delta.Removed)
+		case *multiwatcher.ApplicationInfo:
+			applied = m.applyApplication(info, delta.Removed)
+		case *multiwatcher.RemoteApplicationInfo:
+			applied = m.applyRemoteApplication(info, delta.Removed)
+		case *multiwatcher.UnitInfo:
+			applied = m.applyUnit(info, delta.Removed)
+		case *multiwatcher.RelationInfo:
+			applied = m.applyRelation(info, delta.Removed)
+		}
+		changed = changed || applied
+	}
+	return changed
+}
+
+func (m *statusModel) applyModel(info *multiwatcher.ModelInfo, removed bool) bool {
+	if removed {
+		return false
+	}
+	m.status.Model.Name = info.Name
+	m.status.Model.ModelStatus = detailedStatus(m.status.Model.ModelStatus, info.Status, info.Life)
+	return true
+}
+
+func (m *statusModel) applyMachine(info *multiwatcher.MachineInfo, removed bool) bool {
+	machines, found := m.machines(info.Id)
+	if removed {
+		if _, ok := machines[info.Id]; !ok {
+			return false
+		}
+		delete(machines, info.Id)
+		return true
+	}
+	machine, ok := machines[info.Id]
+	if !ok {
+		if m.filtered || !found {
+			return false
+		}
+		machine = params.MachineStatus{Id: info.Id}
+	}
+	machine.AgentStatus = detailedStatus(machine.AgentStatus, info.AgentStatus, info.Life)
+	machine.InstanceStatus = detailedStatus(machine.InstanceStatus, info.InstanceStatus, "")
+	machine.InstanceId = instance.Id(info.InstanceId)
+	machine.Series = info.Series
+	machine.Jobs = info.Jobs
+	machine.HasVote = info.HasVote
+	machine.WantsVote = info.WantsVote
+	if address, ok := network.SelectPublicAddress(networkAddresses(info.Addresses)); ok {
+		machine.DNSName = address.Value
+	}
+	machines[info.Id] = machine
+	return true
+}
+
+// machines returns the map holding the machine with the given id:
+// the top level machines, or the containers of its host. It returns
+// false if the host is not part of the status.
+func (m *statusModel) machines(id string) (map[string]params.MachineStatus, bool) {
+	// Container ids have the form <host>/<type>/<number>.
+	parts := strings.Split(id, "/")
+	if len(parts) < 3 {
+		return m.status.Machines, true
+	}
+	hostId := strings.Join(parts[:len(parts)-2], "/")
+	hosts, found := m.machines(hostId)
+	if !found {
+		return nil, false
+	}
+	host, ok := hosts[hostId]
+	if !ok {
+		return nil, false
+	}
+	if host.Containers == nil {
+		host.Containers = make(map[string]params.MachineStatus)
+		hosts[hostId] = host
+	}
+	return host.Containers, true
+}
+
+func (m *statusModel) applyApplication(info *multiwatcher.ApplicationInfo, removed bool) bool {
+	if removed {
+		delete(m.subordinates, info.Name)
+		if _, ok := m.status.Applications[info.Name]; !ok {
+			return false
+		}
+		delete(m.status.Applications, info.Name)
+		return true
+	}
+	if info.Subordinate {
+		m.subordinates[info.Name] = true
+	}
+	app, ok := m.status.Applications[info.Name]
+	if !ok {
+		if m.filtered {
+			return false
+		}
+		app = params.ApplicationStatus{
+			Relations: make(map[string][]string),
+			Units:     make(map[string]params.UnitStatus),
+		}
+	}
+	app.Charm = info.CharmURL
+	app.Exposed = info.Exposed
+	app.Life = lifeString(info.Life)
+	app.Status = detailedStatus(app.Status, info.Status, info.Life)
+	m.status.Applications[info.Name] = app
+	return true
+}
+
+func (m *statusModel) applyRemoteApplication(info *multiwatcher.RemoteApplicationInfo, removed bool) bool {
+	if removed {
+		if _, ok := m.status.RemoteApplications[info.Name]; !ok {
+			return false
+		}
+		delete(m.status.RemoteApplications, info.Name)
+		return true
+	}
+	app, ok := m.status.RemoteApplications[info.Name]
+	if !ok {
+		if m.filtered {
+			return false
+		}
+		app = params.RemoteApplicationStatus{
+			ApplicationName: info.Name,
+			Relations:       make(map[string][]string),
+		}
+	}
+	app.ApplicationURL = info.ApplicationURL
+	app.Life = lifeString(info.Life)
+	app.Status = detailedStatus(app.Status, info.Status, info.Life)
+	m.status.RemoteApplications[info.Name] = app
+	return true
+}
+
+func (m *statusModel) applyUnit(info *multiwatcher.UnitInfo, removed bool) bool {
+	units, ok := m.units(info)
+	if !ok {
+		return false
+	}
+	if removed {
+		if _, ok := units[info.Name]; !ok {
+			return false
+		}
+		delete(units, info.Name)
+		return true
+	}
+	unit, ok := units[info.Name]
+	if !ok {
+		if m.filtered || info.Subordinate {
+			return false
+		}
+	}
+	unit.WorkloadStatus = detailedStatus(unit.WorkloadStatus, info.WorkloadStatus, "")
+	unit.AgentStatus = detailedStatus(unit.AgentStatus, info.AgentStatus, "")
+	unit.PublicAddress = info.PublicAddress
+	if !info.Subordinate {
+		unit.Machine = info.MachineId
+	}
+	unit.OpenedPorts = nil
+	for _, r := range info.PortRanges {
+		unit.OpenedPorts = append(unit.OpenedPorts, network.PortRange{
+			FromPort: r.FromPort,
+			ToPort:   r.ToPort,
+			Protocol: r.Protocol,
+		}.String())
+	}
+	// Like the full status, only show the charm of a unit which is
+	// not running the application's charm.
+	unit.Charm = ""
+	if app, ok := m.status.Applications[info.Application]; ok && app.Charm != info.CharmURL {
+		unit.Charm = info.CharmURL
+	}
+	units[info.Name] = unit
+	return true
+}
+
+// units returns the map holding the given unit: the units of its
+// application for a principal, or the subordinates of its principal.
+// A new subordinate unit cannot be placed, since the delta does not
+// name its principal.
+func (m *statusModel) units(info *multiwatcher.UnitInfo) (map[string]params.UnitStatus, bool) {
+	app, ok := m.status.Applications[info.Application]
+	if !ok {
+		return nil, false
+	}
+	if !info.Subordinate {
+		if app.Units == nil {
+			app.Units = make(map[string]params.UnitStatus)
+			m.status.Applications[info.Application] = app
+		}
+		return app.Units, true
+	}
+	for _, principalApp := range m.status.Applications {
+		for _, principal := range principalApp.Units {
+			if _, ok := principal.Subordinates[info.Name]; ok {
+				return principal.Subordinates, true
+			}
+		}
+	}
+	return nil, false
+}
+
+func (m *statusModel) applyRelation(info *multiwatcher.RelationInfo, removed bool) bool {
+	for i, relation := range m.status.Relations {
+		if relation.Key != info.Key {
+			continue
+		}
+		if !removed {
+			return false
+		}
+		m.status.Relations = append(m.status.Relations[:i], m.status.Relations[i+1:]...)
+		m.updateRelatedApplications(info, false)
+		return true
+	}
+	if removed || len(info.Endpoints) == 0 {
+		return false
+	}
+	if m.filtered {
+		// Only show relations of the applications in the status.
+		shown := false
+		for _, ep := range info.Endpoints {
+			if _, ok := m.status.Applications[ep.ApplicationName]; ok {
+				shown = true
+			}
+		}
+		if !shown {
+			return false
+		}
+	}
+	relation := params.RelationStatus{
+		Id:        info.Id,
+		Key:       info.Key,
+		Interface: info.Endpoints[0].Relation.Interface,
+		Scope:     info.Endpoints[0].Relation.Scope,
+	}
+	for _, ep := range info.Endpoints {
+		relation.Endpoints = append(relation.Endpoints, params.EndpointStatus{
+			ApplicationName: ep.ApplicationName,
+			Name:            ep.Relation.Name,
+			Role:            ep.Relation.Role,
+			Subordinate:     ep.Relation.Scope == "container" && m.subordinates[ep.ApplicationName],
+		})
+	}
+	m.status.Relations = append(m.status.Relations, relation)
+	m.updateRelatedApplications(info, true)
+	return true
+}
+
+// updateRelatedApplications adds or removes the applications at the
+// other end of the relation from the relations of each application
+// taking part in it.
+func (m *statusModel) updateRelatedApplications(info *multiwatcher.RelationInfo, add bool) {
+	for _, ep := range info.Endpoints {
+		var related []string
+		for _, other := range info.Endpoints {
+			if other.ApplicationName != ep.ApplicationName || len(info.Endpoints) == 1 {
+				related = append(related, other.ApplicationName)
+			}
+		}
+		if app, ok := m.status.Applications[ep.ApplicationName]; ok {
+			if app.Relations == nil {
+				app.Relations = make(map[string][]string)
+				m.status.Applications[ep.ApplicationName] = app
+			}
+			updateRelated(app.Relations, ep.Relation.Name, related, add)
+		}
+		if app, ok := m.status.RemoteApplications[ep.ApplicationName]; ok {
+			if app.Relations == nil {
+				app.Relations = make(map[string][]string)
+				m.status.RemoteApplications[ep.ApplicationName] = app
+			}
+			updateRelated(app.Relations, ep.Relation.Name, related, add)
+		}
+	}
+}
+
+func updateRelated(relations map[string][]string, endpoint string, related []string, add bool) {
+	existing := relations[endpoint]
+	for _, name := range related {
+		index := -1
+		for i, existingName := range existing {
+			if existingName == name {
+				index = i
+				break
+			}
+		}
+		switch {
+		case add && index < 0:
+			existing = append(existing, name)
+		case !add && index >= 0:
+			existing = append(existing[:index], existing[index+1:]...)
+		}
+	}
+	if len(existing) == 0 {
+		delete(relations, endpoint)
+		return
+	}
+	relations[endpoint] = existing
+}
+
+// detailedStatus returns the status reported by the all-watcher in
+// the form used by the full status, keeping the fields which the
+// all-watcher does not report from the current status.
+func detailedStatus(current params.DetailedStatus, info multiwatcher.StatusInfo, life multiwatcher.Life) params.DetailedStatus {
+	result := params.DetailedStatus{
+		Status:  string(info.Current),
+		Info:    info.Message,
+		Data:    info.Data,
+		Since:   info.Since,
+		Kind:    current.Kind,
+		Version: info.Version,
+		Life:    current.Life,
+		Err:     info.Err,
+	}
+	if result.Version == "" {
+		result.Version = current.Version
+	}
+	if life != "" {
+		result.Life = lifeString(life)
+	}
+	return result
+}
+
+// lifeString returns the life as shown by the full status, which
+// leaves it empty for alive entities.
+func lifeString(life multiwatcher.Life) string {
+	if life == "alive" {
+		return ""
+	}
+	return string(life)
+}
+
+func networkAddresses(addresses []multiwatcher.Address) []network.Address {
+	result := make([]network.Address, len(addresses))
+	for i, address := range addresses {
+		result[i] = network.Address{
+			Value: address.Value,
+			Type:  network.AddressType(address.Type),
+			Scope: network.Scope(address.Scope),
+		}
+	}
+	return result
+}
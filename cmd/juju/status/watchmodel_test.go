@@ -0,0 +1,181 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state/multiwatcher"
+	"github.com/juju/juju/status"
+)
+
+type StatusModelSuite struct{}
+
+var _ = gc.Suite(&StatusModelSuite{})
+
+func (s *StatusModelSuite) TestMachines(c *gc.C) {
+	model := newStatusModel(&params.FullStatus{
+		Machines: map[string]params.MachineStatus{
+			"0": {Id: "0", AgentStatus: params.DetailedStatus{Status: "pending", Kind: "machine"}},
+		},
+	}, false)
+
+	changed := model.apply([]multiwatcher.Delta{{
+		Entity: &multiwatcher.MachineInfo{
+			Id:          "0",
+			InstanceId:  "inst-0",
+			Series:      "xenial",
+			AgentStatus: multiwatcher.StatusInfo{Current: status.Started},
+			Addresses: []multiwatcher.Address{
+				{Value: "10.0.0.1", Type: "ipv4", Scope: "local-cloud"},
+				{Value: "1.2.3.4", Type: "ipv4", Scope: "public"},
+			},
+		},
+	}, {
+		Entity: &multiwatcher.MachineInfo{
+			Id:          "0/lxd/0",
+			AgentStatus: multiwatcher.StatusInfo{Current: status.Pending},
+		},
+	}, {
+		Entity: &multiwatcher.MachineInfo{
+			Id:   "1",
+			Life: "dying",
+		},
+	}})
+	c.Assert(changed, jc.IsTrue)
+
+	machine := model.status.Machines["0"]
+	c.Check(machine.InstanceId, gc.Equals, instance.Id("inst-0"))
+	c.Check(machine.Series, gc.Equals, "xenial")
+	c.Check(machine.DNSName, gc.Equals, "1.2.3.4")
+	c.Check(machine.AgentStatus.Status, gc.Equals, "started")
+	c.Check(machine.AgentStatus.Kind, gc.Equals, "machine")
+	c.Check(machine.Containers["0/lxd/0"].AgentStatus.Status, gc.Equals, "pending")
+	c.Check(model.status.Machines["1"].AgentStatus.Life, gc.Equals, "dying")
+
+	changed = model.apply([]multiwatcher.Delta{{
+		Entity:  &multiwatcher.MachineInfo{Id: "0/lxd/0"},
+		Removed: true,
+	}})
+	c.Assert(changed, jc.IsTrue)
+	c.Check(model.status.Machines["0"].Containers, gc.HasLen, 0)
+}
+
+func (s *StatusModelSuite) TestUnits(c *gc.C) {
+	model := newStatusModel(&params.FullStatus{
+		Applications: map[string]params.ApplicationStatus{
+			"wordpress": {
+				Charm: "cs:wordpress-2",
+				Units: map[string]params.UnitStatus{
+					"wordpress/0": {
+						Subordinates: map[string]params.UnitStatus{
+							"logging/0": {},
+						},
+					},
+				},
+			},
+			"logging": {SubordinateTo: []string{"wordpress"}},
+		},
+	}, false)
+
+	changed := model.apply([]multiwatcher.Delta{{
+		Entity: &multiwatcher.UnitInfo{
+			Name:           "wordpress/0",
+			Application:    "wordpress",
+			CharmURL:       "cs:wordpress-1",
+			MachineId:      "0",
+			PortRanges:     []multiwatcher.PortRange{{FromPort: 80, ToPort: 80, Protocol: "tcp"}},
+			WorkloadStatus: multiwatcher.StatusInfo{Current: status.Active},
+		},
+	}, {
+		Entity: &multiwatcher.UnitInfo{
+			Name:           "logging/0",
+			Application:    "logging",
+			Subordinate:    true,
+			WorkloadStatus: multiwatcher.StatusInfo{Current: status.Blocked},
+		},
+	}, {
+		// Without the principal, a new subordinate cannot be placed.
+		Entity: &multiwatcher.UnitInfo{
+			Name:        "logging/1",
+			Application: "logging",
+			Subordinate: true,
+		},
+	}})
+	c.Assert(changed, jc.IsTrue)
+
+	unit := model.status.Applications["wordpress"].Units["wordpress/0"]
+	c.Check(unit.Machine, gc.Equals, "0")
+	c.Check(unit.Charm, gc.Equals, "cs:wordpress-1")
+	c.Check(unit.OpenedPorts, jc.DeepEquals, []string{"80/tcp"})
+	c.Check(unit.WorkloadStatus.Status, gc.Equals, "active")
+	c.Check(unit.Subordinates, gc.HasLen, 1)
+	c.Check(unit.Subordinates["logging/0"].WorkloadStatus.Status, gc.Equals, "blocked")
+}
+
+func (s *StatusModelSuite) TestFilteredIgnoresNewEntities(c *gc.C) {
+	model := newStatusModel(&params.FullStatus{
+		Applications: map[string]params.ApplicationStatus{
+			"mysql": {Units: map[string]params.UnitStatus{}},
+		},
+	}, true)
+
+	changed := model.apply([]multiwatcher.Delta{
+		{Entity: &multiwatcher.MachineInfo{Id: "0"}},
+		{Entity: &multiwatcher.ApplicationInfo{Name: "wordpress"}},
+		{Entity: &multiwatcher.UnitInfo{Name: "mysql/0", Application: "mysql"}},
+	})
+	c.Assert(changed, jc.IsFalse)
+	c.Check(model.status.Machines, gc.HasLen, 0)
+	c.Check(model.status.Applications, gc.HasLen, 1)
+	c.Check(model.status.Applications["mysql"].Units, gc.HasLen, 0)
+}
+
+func (s *StatusModelSuite) TestRelations(c *gc.C) {
+	model := newStatusModel(&params.FullStatus{
+		Applications: map[string]params.ApplicationStatus{
+			"wordpress": {},
+			"logging":   {SubordinateTo: []string{"wordpress"}},
+		},
+	}, false)
+
+	relation := &multiwatcher.RelationInfo{
+		Key: "logging:info wordpress:juju-info",
+		Id:  1,
+		Endpoints: []multiwatcher.Endpoint{{
+			ApplicationName: "logging",
+			Relation:        multiwatcher.CharmRelation{Name: "info", Role: "requirer", Interface: "juju-info", Scope: "container"},
+		}, {
+			ApplicationName: "wordpress",
+			Relation:        multiwatcher.CharmRelation{Name: "juju-info", Role: "provider", Interface: "juju-info", Scope: "container"},
+		}},
+	}
+	changed := model.apply([]multiwatcher.Delta{{Entity: relation}})
+	c.Assert(changed, jc.IsTrue)
+	c.Check(model.status.Relations, jc.DeepEquals, []params.RelationStatus{{
+		Id:        1,
+		Key:       "logging:info wordpress:juju-info",
+		Interface: "juju-info",
+		Scope:     "container",
+		Endpoints: []params.EndpointStatus{
+			{ApplicationName: "logging", Name: "info", Role: "requirer", Subordinate: true},
+			{ApplicationName: "wordpress", Name: "juju-info", Role: "provider"},
+		},
+	}})
+	c.Check(model.status.Applications["logging"].Relations, jc.DeepEquals, map[string][]string{
+		"info": {"wordpress"},
+	})
+	c.Check(model.status.Applications["wordpress"].Relations, jc.DeepEquals, map[string][]string{
+		"juju-info": {"logging"},
+	})
+
+	changed = model.apply([]multiwatcher.Delta{{Entity: relation, Removed: true}})
+	c.Assert(changed, jc.IsTrue)
+	c.Check(model.status.Relations, gc.HasLen, 0)
+	c.Check(model.status.Applications["logging"].Relations, gc.HasLen, 0)
+	c.Check(model.status.Applications["wordpress"].Relations, gc.HasLen, 0)
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
 
@@ -73,6 +74,21 @@ Examples:
       juju add-storage u/0 data=1 
     or
       juju add-storage u/0 data 
+
+
+    # Add 1 storage instance for "data" storage to unit u/0, restored
+    # from the volume snapshot with ID 3:
+
+      juju add-storage --from-snapshot 3 u/0 data
+
+When --from-snapshot is specified, exactly one storage directive may be
+given, and it must be for block storage. The new volume is created in the
+same pool as the snapshotted volume, and is at least as large as it. For
+machine-scoped storage, such as loop devices, the unit must be on the same
+machine as the snapshotted volume.
+
+See also:
+    create-storage-snapshot
 `
 	addCommandAgs = `
 <unit name> <storage directive> ...
@@ -92,6 +108,16 @@ type addCommand struct {
 	// defined in charm storage metadata.
 	storageCons map[string]storage.Constraints
 	newAPIFunc  func() (StorageAddAPI, error)
+
+	// fromSnapshot is the ID of the volume snapshot from
+	// which to restore the storage, if any.
+	fromSnapshot string
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *addCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.StorageCommandBase.SetFlags(f)
+	f.StringVar(&c.fromSnapshot, "from-snapshot", "", "Restore the storage from the volume snapshot with this ID")
 }
 
 // Init implements Command.Init.
@@ -107,7 +133,13 @@ func (c *addCommand) Init(args []string) (err error) {
 	c.unitTag = names.NewUnitTag(u).String()
 
 	c.storageCons, err = storage.ParseConstraintsMap(args[1:], false)
-	return
+	if err != nil {
+		return err
+	}
+	if c.fromSnapshot != "" && len(c.storageCons) != 1 {
+		return errors.New("--from-snapshot requires exactly one storage directive")
+	}
+	return nil
 }
 
 // Info implements Command.Info.
@@ -196,6 +228,7 @@ func (c *addCommand) createStorageAddParams() []params.StorageAddParams {
 					&cons.Size,
 					&cons.Count,
 				},
+				FromSnapshot: c.fromSnapshot,
 			})
 	}
 
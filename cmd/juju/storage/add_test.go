@@ -106,6 +106,26 @@ func (s *addSuite) TestAddSuccess(c *gc.C) {
 	}
 }
 
+func (s *addSuite) TestAddFromSnapshot(c *gc.C) {
+	s.args = []string{"--from-snapshot", "0/3", "tst/123", "data"}
+	var added []params.StorageAddParams
+	s.mockAPI.addToUnitFunc = func(storages []params.StorageAddParams) ([]params.ErrorResult, error) {
+		added = storages
+		return make([]params.ErrorResult, len(storages)), nil
+	}
+	s.assertAddOutput(c, "added \"data\"\n", "")
+	c.Assert(added, gc.HasLen, 1)
+	c.Assert(added[0].UnitTag, gc.Equals, "unit-tst-123")
+	c.Assert(added[0].StorageName, gc.Equals, "data")
+	c.Assert(added[0].FromSnapshot, gc.Equals, "0/3")
+}
+
+func (s *addSuite) TestAddFromSnapshotMultipleDirectives(c *gc.C) {
+	s.args = []string{"--from-snapshot", "3", "tst/123", "data", "logs"}
+	expectedErr := "--from-snapshot requires exactly one storage directive"
+	s.assertAddErrorOutput(c, expectedErr, "", visibleErrorMessage(expectedErr))
+}
+
 func (s *addSuite) TestAddOperationAborted(c *gc.C) {
 	s.args = []string{"tst/123", "data=676"}
 	s.mockAPI.addToUnitFunc = func(storages []params.StorageAddParams) ([]params.ErrorResult, error) {
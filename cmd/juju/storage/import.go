@@ -0,0 +1,166 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"regexp"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/storage"
+)
+
+var validStorageName = regexp.MustCompile("^" + names.StorageNameSnippet + "$")
+
+// NewImportFilesystemCommandWithAPI returns a command
+// used to import a filesystem.
+func NewImportFilesystemCommandWithAPI() cmd.Command {
+	cmd := &importFilesystemCommand{}
+	cmd.newStorageImporterCloser = func() (StorageImporterCloser, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// NewImportFilesystemCommand returns a command
+// used to import a filesystem.
+func NewImportFilesystemCommand(new NewStorageImporterCloserFunc) cmd.Command {
+	cmd := &importFilesystemCommand{}
+	cmd.newStorageImporterCloser = new
+	return modelcmd.Wrap(cmd)
+}
+
+const (
+	importFilesystemCommandDoc = `
+Import an existing filesystem into the model. This will lead to the model
+taking ownership of the storage, so you must take care not to import storage
+that is in use by another Juju model.
+
+To import a filesystem, you must specify three things:
+
+ - the storage pool which identifies the storage provider and its
+   configuration
+ - the storage provider ID for the volume on which the filesystem
+   resides, as reported by the cloud
+ - the storage name to assign to the filesystem, which must match the
+   storage name used by the charm the storage will be attached to
+
+The storage provider must be dynamic and model-scoped, and must manage
+filesystems on block devices (e.g. "ebs", "cinder" or "gce"). The volume
+is validated with the cloud before it is imported. The filesystem may be
+either on the volume's first partition, or directly on the volume if it
+has no partitions.
+
+Once imported, Juju will create an associated storage instance using the
+given storage name. The storage will be detached, and may be attached to
+a new unit with "juju deploy --attach-storage".
+
+Examples:
+    # Import an existing filesystem backed by an EBS volume,
+    # and assign it the "pgdata" storage name. Juju will
+    # associate a storage instance ID like "pgdata/0" with
+    # the volume and filesystem contained within.
+    juju import-filesystem ebs vol-123456 pgdata
+
+    # Deploy a new application, attaching the imported storage.
+    juju deploy postgresql --attach-storage pgdata/0
+
+See also:
+    deploy
+    storage
+`
+	importFilesystemCommandArgs = `<storage-pool> <storage-provider-id> <storage-name>`
+)
+
+type importFilesystemCommand struct {
+	StorageCommandBase
+	newStorageImporterCloser NewStorageImporterCloserFunc
+	storagePool              string
+	storageProviderId        string
+	storageName              string
+}
+
+// Info implements Command.Info.
+func (c *importFilesystemCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "import-filesystem",
+		Purpose: "Imports a filesystem into the model.",
+		Doc:     importFilesystemCommandDoc,
+		Args:    importFilesystemCommandArgs,
+	}
+}
+
+// Init implements Command.Init.
+func (c *importFilesystemCommand) Init(args []string) error {
+	if len(args) != 3 {
+		return errors.New("import-filesystem requires a storage pool, a storage provider ID and a storage name")
+	}
+	if !storage.IsValidPoolName(args[0]) {
+		return errors.NotValidf("pool name %q", args[0])
+	}
+	if args[1] == "" {
+		return errors.NotValidf("empty storage provider ID")
+	}
+	if !validStorageName.MatchString(args[2]) {
+		return errors.NotValidf("storage name %q", args[2])
+	}
+	c.storagePool = args[0]
+	c.storageProviderId = args[1]
+	c.storageName = args[2]
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *importFilesystemCommand) Run(ctx *cmd.Context) error {
+	importer, err := c.newStorageImporterCloser()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer importer.Close()
+
+	ctx.Infof(
+		"importing %q from storage pool %q as storage %q",
+		c.storageProviderId, c.storagePool, c.storageName,
+	)
+	storageTag, err := importer.Import(
+		storage.StorageKindFilesystem,
+		c.storagePool,
+		c.storageProviderId,
+		c.storageName,
+	)
+	if err != nil {
+		if params.IsCodeUnauthorized(err) {
+			common.PermissionsMessage(ctx.Stderr, "import filesystems")
+		}
+		return err
+	}
+	ctx.Infof("imported storage %s", storageTag.Id())
+	return nil
+}
+
+// NewStorageImporterCloserFunc is the type of a function that returns
+// a StorageImporterCloser.
+type NewStorageImporterCloserFunc func() (StorageImporterCloser, error)
+
+// StorageImporterCloser extends StorageImporter with a Closer method.
+type StorageImporterCloser interface {
+	StorageImporter
+	Close() error
+}
+
+// StorageImporter defines an interface for importing existing
+// storage into the model.
+type StorageImporter interface {
+	Import(
+		kind storage.StorageKind,
+		storagePool string,
+		storageProviderId string,
+		storageName string,
+	) (names.StorageTag, error)
+}
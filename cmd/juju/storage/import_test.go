@@ -0,0 +1,110 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+	jujustorage "github.com/juju/juju/storage"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ImportFilesystemSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ImportFilesystemSuite{})
+
+func (s *ImportFilesystemSuite) TestImportFilesystem(c *gc.C) {
+	var fake fakeStorageImporter
+	cmd := storage.NewImportFilesystemCommand(fake.new)
+	ctx, err := coretesting.RunCommand(c, cmd, "ebs", "vol-123", "pgdata")
+	c.Assert(err, jc.ErrorIsNil)
+	fake.CheckCallNames(c, "NewStorageImporterCloser", "Import", "Close")
+	fake.CheckCall(c, 1, "Import",
+		jujustorage.StorageKindFilesystem, "ebs", "vol-123", "pgdata",
+	)
+	c.Assert(coretesting.Stderr(ctx), gc.Equals, `
+importing "vol-123" from storage pool "ebs" as storage "pgdata"
+imported storage pgdata/0
+`[1:])
+}
+
+func (s *ImportFilesystemSuite) TestImportFilesystemError(c *gc.C) {
+	var fake fakeStorageImporter
+	fake.SetErrors(nil, &params.Error{Message: "volume vol-123 not found"})
+	cmd := storage.NewImportFilesystemCommand(fake.new)
+	_, err := coretesting.RunCommand(c, cmd, "ebs", "vol-123", "pgdata")
+	c.Assert(err, gc.ErrorMatches, "volume vol-123 not found")
+	fake.CheckCallNames(c, "NewStorageImporterCloser", "Import", "Close")
+}
+
+func (s *ImportFilesystemSuite) TestImportFilesystemUnauthorizedError(c *gc.C) {
+	var fake fakeStorageImporter
+	fake.SetErrors(nil, &params.Error{Code: params.CodeUnauthorized, Message: "nope"})
+	cmd := storage.NewImportFilesystemCommand(fake.new)
+	ctx, err := coretesting.RunCommand(c, cmd, "ebs", "vol-123", "pgdata")
+	c.Assert(err, gc.ErrorMatches, "nope")
+	c.Assert(coretesting.Stderr(ctx), gc.Equals, `
+importing "vol-123" from storage pool "ebs" as storage "pgdata"
+
+You do not have permission to import filesystems.
+You may ask an administrator to grant you access with "juju grant".
+
+`[1:])
+}
+
+func (s *ImportFilesystemSuite) TestImportFilesystemInitErrors(c *gc.C) {
+	for _, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "import-filesystem requires a storage pool, a storage provider ID and a storage name",
+	}, {
+		args: []string{"ebs", "vol-123"},
+		err:  "import-filesystem requires a storage pool, a storage provider ID and a storage name",
+	}, {
+		args: []string{"ebs!", "vol-123", "pgdata"},
+		err:  `pool name "ebs!" not valid`,
+	}, {
+		args: []string{"ebs", "", "pgdata"},
+		err:  `empty storage provider ID not valid`,
+	}, {
+		args: []string{"ebs", "vol-123", "pgdata/0"},
+		err:  `storage name "pgdata/0" not valid`,
+	}} {
+		var fake fakeStorageImporter
+		cmd := storage.NewImportFilesystemCommand(fake.new)
+		_, err := coretesting.RunCommand(c, cmd, t.args...)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+type fakeStorageImporter struct {
+	testing.Stub
+}
+
+func (f *fakeStorageImporter) new() (storage.StorageImporterCloser, error) {
+	f.MethodCall(f, "NewStorageImporterCloser")
+	return f, f.NextErr()
+}
+
+func (f *fakeStorageImporter) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeStorageImporter) Import(
+	kind jujustorage.StorageKind,
+	storagePool, storageProviderId, storageName string,
+) (names.StorageTag, error) {
+	f.MethodCall(f, "Import", kind, storagePool, storageProviderId, storageName)
+	return names.NewStorageTag(storageName + "/0"), f.NextErr()
+}
@@ -0,0 +1,121 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewRemoveSnapshotCommandWithAPI returns a command
+// used to remove storage snapshots.
+func NewRemoveSnapshotCommandWithAPI() cmd.Command {
+	cmd := &removeSnapshotCommand{}
+	cmd.newStorageSnapshotRemoverCloser = func() (StorageSnapshotRemoverCloser, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// NewRemoveSnapshotCommand returns a command
+// used to remove storage snapshots.
+func NewRemoveSnapshotCommand(new NewStorageSnapshotRemoverCloserFunc) cmd.Command {
+	cmd := &removeSnapshotCommand{}
+	cmd.newStorageSnapshotRemoverCloser = new
+	return modelcmd.Wrap(cmd)
+}
+
+const (
+	removeSnapshotCommandDoc = `
+Removes storage snapshots. Specify one or more snapshot IDs, as output
+by "juju create-storage-snapshot".
+
+Snapshots are destroyed asynchronously by the storage provider. A
+snapshot that has not yet been taken is removed once it has been. A
+snapshot cannot be removed while storage is being created from it.
+
+Examples:
+    juju remove-storage-snapshot 0 1
+
+See also:
+    create-storage-snapshot
+`
+	removeSnapshotCommandArgs = `<snapshot> [<snapshot> ...]`
+)
+
+type removeSnapshotCommand struct {
+	StorageCommandBase
+	newStorageSnapshotRemoverCloser NewStorageSnapshotRemoverCloserFunc
+	snapshotIds                     []string
+}
+
+// Info implements Command.Info.
+func (c *removeSnapshotCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "remove-storage-snapshot",
+		Purpose: "Removes storage snapshots.",
+		Doc:     removeSnapshotCommandDoc,
+		Args:    removeSnapshotCommandArgs,
+	}
+}
+
+// Init implements Command.Init.
+func (c *removeSnapshotCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("remove-storage-snapshot requires at least one snapshot ID")
+	}
+	c.snapshotIds = args
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *removeSnapshotCommand) Run(ctx *cmd.Context) error {
+	remover, err := c.newStorageSnapshotRemoverCloser()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer remover.Close()
+
+	results, err := remover.DestroyStorageSnapshots(c.snapshotIds)
+	if err != nil {
+		if params.IsCodeUnauthorized(err) {
+			common.PermissionsMessage(ctx.Stderr, "remove storage snapshots")
+		}
+		return err
+	}
+	anyFailed := false
+	for i, result := range results {
+		if result.Error != nil {
+			ctx.Infof("failed to remove snapshot %s: %s", c.snapshotIds[i], result.Error)
+			anyFailed = true
+			continue
+		}
+		ctx.Infof("removing snapshot %s", c.snapshotIds[i])
+	}
+	if anyFailed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+// NewStorageSnapshotRemoverCloserFunc is the type of a function that
+// returns a StorageSnapshotRemoverCloser.
+type NewStorageSnapshotRemoverCloserFunc func() (StorageSnapshotRemoverCloser, error)
+
+// StorageSnapshotRemoverCloser extends StorageSnapshotRemover with a
+// Closer method.
+type StorageSnapshotRemoverCloser interface {
+	StorageSnapshotRemover
+	Close() error
+}
+
+// StorageSnapshotRemover defines an interface for destroying storage
+// snapshots with the specified IDs.
+type StorageSnapshotRemover interface {
+	DestroyStorageSnapshots([]string) ([]params.ErrorResult, error)
+}
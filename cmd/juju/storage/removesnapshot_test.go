@@ -0,0 +1,88 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type RemoveSnapshotSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&RemoveSnapshotSuite{})
+
+func (s *RemoveSnapshotSuite) TestRemoveSnapshot(c *gc.C) {
+	fake := fakeStorageSnapshotRemover{results: []params.ErrorResult{{}, {}}}
+	cmd := storage.NewRemoveSnapshotCommand(fake.new)
+	ctx, err := coretesting.RunCommand(c, cmd, "0", "1")
+	c.Assert(err, jc.ErrorIsNil)
+	fake.CheckCallNames(c, "NewStorageSnapshotRemoverCloser", "DestroyStorageSnapshots", "Close")
+	fake.CheckCall(c, 1, "DestroyStorageSnapshots", []string{"0", "1"})
+	c.Assert(coretesting.Stderr(ctx), gc.Equals, `
+removing snapshot 0
+removing snapshot 1
+`[1:])
+}
+
+func (s *RemoveSnapshotSuite) TestRemoveSnapshotError(c *gc.C) {
+	fake := fakeStorageSnapshotRemover{results: []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: "bar"}},
+	}}
+	removeCmd := storage.NewRemoveSnapshotCommand(fake.new)
+	ctx, err := coretesting.RunCommand(c, removeCmd, "0", "1")
+	c.Assert(coretesting.Stderr(ctx), gc.Equals, `
+removing snapshot 0
+failed to remove snapshot 1: bar
+`[1:])
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+}
+
+func (s *RemoveSnapshotSuite) TestRemoveSnapshotUnauthorizedError(c *gc.C) {
+	var fake fakeStorageSnapshotRemover
+	fake.SetErrors(nil, &params.Error{Code: params.CodeUnauthorized, Message: "nope"})
+	cmd := storage.NewRemoveSnapshotCommand(fake.new)
+	ctx, err := coretesting.RunCommand(c, cmd, "0")
+	c.Assert(err, gc.ErrorMatches, "nope")
+	c.Assert(coretesting.Stderr(ctx), gc.Equals, `
+You do not have permission to remove storage snapshots.
+You may ask an administrator to grant you access with "juju grant".
+
+`)
+}
+
+func (s *RemoveSnapshotSuite) TestRemoveSnapshotInitErrors(c *gc.C) {
+	var fake fakeStorageSnapshotRemover
+	cmd := storage.NewRemoveSnapshotCommand(fake.new)
+	_, err := coretesting.RunCommand(c, cmd)
+	c.Assert(err, gc.ErrorMatches, "remove-storage-snapshot requires at least one snapshot ID")
+}
+
+type fakeStorageSnapshotRemover struct {
+	testing.Stub
+	results []params.ErrorResult
+}
+
+func (f *fakeStorageSnapshotRemover) new() (storage.StorageSnapshotRemoverCloser, error) {
+	f.MethodCall(f, "NewStorageSnapshotRemoverCloser")
+	return f, f.NextErr()
+}
+
+func (f *fakeStorageSnapshotRemover) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeStorageSnapshotRemover) DestroyStorageSnapshots(ids []string) ([]params.ErrorResult, error) {
+	f.MethodCall(f, "DestroyStorageSnapshots", ids)
+	return f.results, f.NextErr()
+}
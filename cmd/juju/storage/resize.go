@@ -0,0 +1,132 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewResizeCommandWithAPI returns a command
+// used to resize storage.
+func NewResizeCommandWithAPI() cmd.Command {
+	cmd := &resizeCommand{}
+	cmd.newStorageResizerCloser = func() (StorageResizerCloser, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// NewResizeCommand returns a command
+// used to resize storage.
+func NewResizeCommand(new NewStorageResizerCloserFunc) cmd.Command {
+	cmd := &resizeCommand{}
+	cmd.newStorageResizerCloser = new
+	return modelcmd.Wrap(cmd)
+}
+
+const (
+	resizeCommandDoc = `
+Grows the specified storage to the specified size. Specify the storage
+ID, as output by "juju storage", and the new size. The size is a number,
+optionally followed by one of the suffixes M, G, T or P; if no suffix is
+given, the size is in MiB.
+
+Storage is resized asynchronously. The storage provider grows the volume
+backing the storage, and the machine agent then grows any filesystem on
+it to fill the volume. Once the unit's storage has grown, the charm is
+notified with a "<storage-name>-storage-resized" hook.
+
+Storage may only be grown, not shrunk, and only if the storage provider
+supports resizing. The ebs, cinder, gce and loop providers support
+resizing. Cinder can only grow attached volumes on clouds that support
+volume API microversion 3.42 or later. An EBS volume can be modified
+at most once every six hours.
+
+Examples:
+    juju resize-storage pgdata/0 200G
+
+See also:
+    add-storage
+    storage
+`
+	resizeCommandArgs = `<storage> <size>`
+)
+
+type resizeCommand struct {
+	StorageCommandBase
+	newStorageResizerCloser NewStorageResizerCloserFunc
+	storageId               string
+	size                    uint64
+}
+
+// Info implements Command.Info.
+func (c *resizeCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "resize-storage",
+		Purpose: "Grows storage to a new size.",
+		Doc:     resizeCommandDoc,
+		Args:    resizeCommandArgs,
+	}
+}
+
+// Init implements Command.Init.
+func (c *resizeCommand) Init(args []string) error {
+	if len(args) != 2 {
+		return errors.New("resize-storage requires a storage ID and a size")
+	}
+	if !names.IsValidStorage(args[0]) {
+		return errors.NotValidf("storage ID %q", args[0])
+	}
+	size, err := utils.ParseSize(args[1])
+	if err != nil {
+		return errors.Annotate(err, "cannot parse size")
+	}
+	if size == 0 {
+		return errors.NotValidf("size 0")
+	}
+	c.storageId = args[0]
+	c.size = size
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *resizeCommand) Run(ctx *cmd.Context) error {
+	resizer, err := c.newStorageResizerCloser()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resizer.Close()
+
+	if err := resizer.ResizeStorage(c.storageId, c.size); err != nil {
+		if params.IsCodeUnauthorized(err) {
+			common.PermissionsMessage(ctx.Stderr, "resize storage")
+		}
+		return err
+	}
+	ctx.Infof("resizing %s to %dMiB", c.storageId, c.size)
+	return nil
+}
+
+// NewStorageResizerCloserFunc is the type of a function that returns
+// a StorageResizerCloser.
+type NewStorageResizerCloserFunc func() (StorageResizerCloser, error)
+
+// StorageResizerCloser extends StorageResizer with a Closer method.
+type StorageResizerCloser interface {
+	StorageResizer
+	Close() error
+}
+
+// StorageResizer defines an interface for resizing the storage
+// instance with the specified ID.
+type StorageResizer interface {
+	ResizeStorage(storageId string, size uint64) error
+}
@@ -0,0 +1,98 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ResizeSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ResizeSuite{})
+
+func (s *ResizeSuite) TestResize(c *gc.C) {
+	var fake fakeStorageResizer
+	cmd := storage.NewResizeCommand(fake.new)
+	ctx, err := coretesting.RunCommand(c, cmd, "pgdata/0", "200G")
+	c.Assert(err, jc.ErrorIsNil)
+	fake.CheckCallNames(c, "NewStorageResizerCloser", "ResizeStorage", "Close")
+	fake.CheckCall(c, 1, "ResizeStorage", "pgdata/0", uint64(200*1024))
+	c.Assert(coretesting.Stderr(ctx), gc.Equals, "resizing pgdata/0 to 204800MiB\n")
+}
+
+func (s *ResizeSuite) TestResizeError(c *gc.C) {
+	var fake fakeStorageResizer
+	fake.SetErrors(nil, &params.Error{Message: "storage cannot be shrunk"})
+	cmd := storage.NewResizeCommand(fake.new)
+	_, err := coretesting.RunCommand(c, cmd, "pgdata/0", "1024")
+	c.Assert(err, gc.ErrorMatches, "storage cannot be shrunk")
+	fake.CheckCall(c, 1, "ResizeStorage", "pgdata/0", uint64(1024))
+}
+
+func (s *ResizeSuite) TestResizeUnauthorizedError(c *gc.C) {
+	var fake fakeStorageResizer
+	fake.SetErrors(nil, &params.Error{Code: params.CodeUnauthorized, Message: "nope"})
+	cmd := storage.NewResizeCommand(fake.new)
+	ctx, err := coretesting.RunCommand(c, cmd, "pgdata/0", "1G")
+	c.Assert(err, gc.ErrorMatches, "nope")
+	c.Assert(coretesting.Stderr(ctx), gc.Equals, `
+You do not have permission to resize storage.
+You may ask an administrator to grant you access with "juju grant".
+
+`)
+}
+
+func (s *ResizeSuite) TestResizeInitErrors(c *gc.C) {
+	for _, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "resize-storage requires a storage ID and a size",
+	}, {
+		args: []string{"pgdata/0"},
+		err:  "resize-storage requires a storage ID and a size",
+	}, {
+		args: []string{"pgdata", "1G"},
+		err:  `storage ID "pgdata" not valid`,
+	}, {
+		args: []string{"pgdata/0", "big"},
+		err:  `cannot parse size: .*`,
+	}, {
+		args: []string{"pgdata/0", "0"},
+		err:  `size 0 not valid`,
+	}} {
+		var fake fakeStorageResizer
+		cmd := storage.NewResizeCommand(fake.new)
+		_, err := coretesting.RunCommand(c, cmd, t.args...)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+type fakeStorageResizer struct {
+	testing.Stub
+}
+
+func (f *fakeStorageResizer) new() (storage.StorageResizerCloser, error) {
+	f.MethodCall(f, "NewStorageResizerCloser")
+	return f, f.NextErr()
+}
+
+func (f *fakeStorageResizer) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeStorageResizer) ResizeStorage(id string, size uint64) error {
+	f.MethodCall(f, "ResizeStorage", id, size)
+	return f.NextErr()
+}
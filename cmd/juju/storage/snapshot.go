@@ -0,0 +1,126 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewCreateSnapshotCommandWithAPI returns a command
+// used to create snapshots of storage.
+func NewCreateSnapshotCommandWithAPI() cmd.Command {
+	cmd := &createSnapshotCommand{}
+	cmd.newStorageSnapshotterCloser = func() (StorageSnapshotterCloser, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// NewCreateSnapshotCommand returns a command
+// used to create snapshots of storage.
+func NewCreateSnapshotCommand(new NewStorageSnapshotterCloserFunc) cmd.Command {
+	cmd := &createSnapshotCommand{}
+	cmd.newStorageSnapshotterCloser = new
+	return modelcmd.Wrap(cmd)
+}
+
+const (
+	createSnapshotCommandDoc = `
+Creates point-in-time snapshots of the volumes backing the specified
+storage. Specify one or more storage IDs, as output by "juju storage".
+
+Snapshots are taken asynchronously by the storage provider. The IDs of
+the requested snapshots are printed, and may be passed to
+"juju add-storage --from-snapshot" to create new storage from them.
+
+Only storage backed by volumes may be snapshotted, and only if the
+storage provider supports snapshots. Any filesystem on the volume is not
+quiesced, so the snapshot is crash-consistent; stop writes to the storage
+first if a consistent snapshot is required.
+
+Examples:
+    juju create-storage-snapshot pgdata/0
+
+See also:
+    add-storage
+    remove-storage-snapshot
+`
+	createSnapshotCommandArgs = `<storage> [<storage> ...]`
+)
+
+type createSnapshotCommand struct {
+	StorageCommandBase
+	newStorageSnapshotterCloser NewStorageSnapshotterCloserFunc
+	storageIds                  []string
+}
+
+// Info implements Command.Info.
+func (c *createSnapshotCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "create-storage-snapshot",
+		Purpose: "Creates snapshots of storage.",
+		Doc:     createSnapshotCommandDoc,
+		Args:    createSnapshotCommandArgs,
+	}
+}
+
+// Init implements Command.Init.
+func (c *createSnapshotCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("create-storage-snapshot requires at least one storage ID")
+	}
+	c.storageIds = args
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *createSnapshotCommand) Run(ctx *cmd.Context) error {
+	snapshotter, err := c.newStorageSnapshotterCloser()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer snapshotter.Close()
+
+	results, err := snapshotter.CreateStorageSnapshots(c.storageIds)
+	if err != nil {
+		if params.IsCodeUnauthorized(err) {
+			common.PermissionsMessage(ctx.Stderr, "create storage snapshots")
+		}
+		return err
+	}
+	anyFailed := false
+	for i, result := range results {
+		if result.Error != nil {
+			ctx.Infof("failed to snapshot %s: %s", c.storageIds[i], result.Error)
+			anyFailed = true
+			continue
+		}
+		ctx.Infof("creating snapshot %s of %s", result.Result, c.storageIds[i])
+	}
+	if anyFailed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+// NewStorageSnapshotterCloserFunc is the type of a function that returns
+// a StorageSnapshotterCloser.
+type NewStorageSnapshotterCloserFunc func() (StorageSnapshotterCloser, error)
+
+// StorageSnapshotterCloser extends StorageSnapshotter with a Closer method.
+type StorageSnapshotterCloser interface {
+	StorageSnapshotter
+	Close() error
+}
+
+// StorageSnapshotter defines an interface for creating snapshots of
+// storage instances with the specified IDs.
+type StorageSnapshotter interface {
+	CreateStorageSnapshots([]string) ([]params.StringResult, error)
+}
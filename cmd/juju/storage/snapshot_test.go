@@ -0,0 +1,91 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type CreateSnapshotSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&CreateSnapshotSuite{})
+
+func (s *CreateSnapshotSuite) TestCreateSnapshot(c *gc.C) {
+	fake := fakeStorageSnapshotter{results: []params.StringResult{
+		{Result: "0"},
+		{Result: "1/1"},
+	}}
+	cmd := storage.NewCreateSnapshotCommand(fake.new)
+	ctx, err := coretesting.RunCommand(c, cmd, "pgdata/0", "pgdata/1")
+	c.Assert(err, jc.ErrorIsNil)
+	fake.CheckCallNames(c, "NewStorageSnapshotterCloser", "CreateStorageSnapshots", "Close")
+	fake.CheckCall(c, 1, "CreateStorageSnapshots", []string{"pgdata/0", "pgdata/1"})
+	c.Assert(coretesting.Stderr(ctx), gc.Equals, `
+creating snapshot 0 of pgdata/0
+creating snapshot 1/1 of pgdata/1
+`[1:])
+}
+
+func (s *CreateSnapshotSuite) TestCreateSnapshotError(c *gc.C) {
+	fake := fakeStorageSnapshotter{results: []params.StringResult{
+		{Result: "0"},
+		{Error: &params.Error{Message: "bar"}},
+	}}
+	snapshotCmd := storage.NewCreateSnapshotCommand(fake.new)
+	ctx, err := coretesting.RunCommand(c, snapshotCmd, "pgdata/0", "pgdata/1")
+	c.Assert(coretesting.Stderr(ctx), gc.Equals, `
+creating snapshot 0 of pgdata/0
+failed to snapshot pgdata/1: bar
+`[1:])
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+}
+
+func (s *CreateSnapshotSuite) TestCreateSnapshotUnauthorizedError(c *gc.C) {
+	var fake fakeStorageSnapshotter
+	fake.SetErrors(nil, &params.Error{Code: params.CodeUnauthorized, Message: "nope"})
+	cmd := storage.NewCreateSnapshotCommand(fake.new)
+	ctx, err := coretesting.RunCommand(c, cmd, "pgdata/0")
+	c.Assert(err, gc.ErrorMatches, "nope")
+	c.Assert(coretesting.Stderr(ctx), gc.Equals, `
+You do not have permission to create storage snapshots.
+You may ask an administrator to grant you access with "juju grant".
+
+`)
+}
+
+func (s *CreateSnapshotSuite) TestCreateSnapshotInitErrors(c *gc.C) {
+	var fake fakeStorageSnapshotter
+	cmd := storage.NewCreateSnapshotCommand(fake.new)
+	_, err := coretesting.RunCommand(c, cmd)
+	c.Assert(err, gc.ErrorMatches, "create-storage-snapshot requires at least one storage ID")
+}
+
+type fakeStorageSnapshotter struct {
+	testing.Stub
+	results []params.StringResult
+}
+
+func (f *fakeStorageSnapshotter) new() (storage.StorageSnapshotterCloser, error) {
+	f.MethodCall(f, "NewStorageSnapshotterCloser")
+	return f, f.NextErr()
+}
+
+func (f *fakeStorageSnapshotter) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeStorageSnapshotter) CreateStorageSnapshots(ids []string) ([]params.StringResult, error) {
+	f.MethodCall(f, "CreateStorageSnapshots", ids)
+	return f.results, f.NextErr()
+}
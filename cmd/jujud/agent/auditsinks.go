@@ -0,0 +1,164 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agent
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/syslog"
+)
+
+// auditWebhookTimeout is the time allowed for each request made to
+// the audit webhook.
+const auditWebhookTimeout = 30 * time.Second
+
+// auditBufferSize is the number of audit entries that may be waiting
+// to be sent to each remote sink before further entries are dropped.
+const auditBufferSize = 1000
+
+// newConfiguredAuditSink returns an audit entry sink delivering
+// entries to the JSON log file, syslog server and webhook configured
+// in the controller config, restricted by the configured filter, and
+// a function which stops the sink. It returns a nil sink if none of
+// them is configured.
+//
+// The JSON log file is written synchronously. The syslog server and
+// webhook each have their own buffer, so that an unavailable one
+// neither holds up the request being audited nor causes entries for
+// the other sinks to be dropped; delivery failures are passed to the
+// error handler. Stopping the sink delivers the entries still
+// buffered.
+func newConfiguredAuditSink(
+	cfg controller.Config, logDir string, errorHandler func(error),
+) (_ audit.AuditEntrySinkFn, stop func(), err error) {
+	var sinks []audit.AuditEntrySinkFn
+	var stopFuncs []func()
+	stop = func() {
+		for _, f := range stopFuncs {
+			f()
+		}
+	}
+	defer func() {
+		if err != nil {
+			stop()
+		}
+	}()
+	addBuffered := func(sink audit.AuditEntrySinkFn) error {
+		buffered, err := audit.NewBufferedSink(audit.BufferedSinkConfig{
+			Sink:         sink,
+			BufferSize:   auditBufferSize,
+			ErrorHandler: errorHandler,
+		})
+		if err != nil {
+			return errors.Trace(err)
+		}
+		sinks = append(sinks, buffered.Handle)
+		stopFuncs = append(stopFuncs, func() {
+			if err := worker.Stop(buffered); err != nil {
+				errorHandler(errors.Trace(err))
+			}
+		})
+		return nil
+	}
+
+	if maxSizeMB := cfg.AuditLogMaxSizeMB(); maxSizeMB > 0 {
+		sinks = append(sinks, audit.NewJSONLogFileSink(logDir, maxSizeMB, cfg.AuditLogMaxBackups()))
+	}
+	if syslogCfg, ok := cfg.AuditSyslog(); ok {
+		sender := &auditSyslogSender{cfg: syslogCfg}
+		if err := addBuffered(audit.NewLogForwardSink(sender, cfg.ControllerUUID())); err != nil {
+			return nil, nil, errors.Annotate(err, "cannot create audit syslog sink")
+		}
+		// The buffer is stopped, and so drained, before the
+		// connection is closed.
+		stopFuncs = append(stopFuncs, sender.close)
+	}
+	if url := cfg.AuditWebhookURL(); url != "" {
+		client := &http.Client{Timeout: auditWebhookTimeout}
+		if err := addBuffered(audit.NewWebhookSink(client, url)); err != nil {
+			return nil, nil, errors.Annotate(err, "cannot create audit webhook sink")
+		}
+	}
+	if len(sinks) == 0 {
+		return nil, stop, nil
+	}
+	filter := audit.Filter{
+		Operations:  cfg.AuditFilterOperations(),
+		OriginTypes: cfg.AuditFilterOriginTypes(),
+		ModelUUIDs:  cfg.AuditFilterModels(),
+	}
+	sink := audit.NewFilteredSink(filter, audit.NewMultiSink(sinks...))
+	if len(filter.OriginTypes) > 0 {
+		// Origin types were chosen explicitly, so agent requests
+		// are sent if they were asked for.
+		return sink, stop, nil
+	}
+	return func(entry audit.AuditEntry) error {
+		if isAgentRequest(entry) {
+			return nil
+		}
+		return sink(entry)
+	}, stop, nil
+}
+
+// isAgentRequest returns whether the entry records an API request
+// made by an agent rather than by a user. Agent requests are not
+// audited unless explicitly asked for.
+func isAgentRequest(entry audit.AuditEntry) bool {
+	if entry.OriginType != audit.APIRequestOriginType {
+		return false
+	}
+	_, err := names.ParseUserTag(entry.OriginName)
+	return err != nil
+}
+
+// auditSyslogSender is an audit.RecordSender which connects to the
+// audit syslog server when first used, and again after a failed send,
+// so that an unavailable server never stops the API server starting.
+type auditSyslogSender struct {
+	cfg syslog.RawConfig
+
+	mu     sync.Mutex
+	client *syslog.Client
+}
+
+// Send is part of the audit.RecordSender interface.
+func (s *auditSyslogSender) Send(records []logfwd.Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.client == nil {
+		client, err := syslog.Open(s.cfg)
+		if err != nil {
+			return errors.Annotate(err, "connecting to audit syslog server")
+		}
+		s.client = client
+	}
+	if err := s.client.Send(records); err != nil {
+		s.client.Close()
+		s.client = nil
+		return errors.Annotate(err, "sending audit entry to syslog server")
+	}
+	return nil
+}
+
+func (s *auditSyslogSender) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.client == nil {
+		return
+	}
+	if err := s.client.Close(); err != nil {
+		logger.Errorf("closing audit syslog connection: %v", err)
+	}
+	s.client = nil
+}
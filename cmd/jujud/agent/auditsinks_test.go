@@ -0,0 +1,201 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agent
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/controller"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type auditSinksSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&auditSinksSuite{})
+
+func (s *auditSinksSuite) TestNoConfiguredSinks(c *gc.C) {
+	cfg, err := controller.NewConfig(coretesting.ControllerTag.Id(), coretesting.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	sink, stop, err := newConfiguredAuditSink(cfg, c.MkDir(), noAuditErrors(c))
+	c.Assert(err, jc.ErrorIsNil)
+	defer stop()
+	c.Assert(sink, gc.IsNil)
+}
+
+func (s *auditSinksSuite) TestConfiguredSinks(c *gc.C) {
+	received := make(chan string, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var entry struct {
+			Operation string `json:"operation"`
+		}
+		err := json.NewDecoder(req.Body).Decode(&entry)
+		c.Check(err, jc.ErrorIsNil)
+		received <- entry.Operation
+	}))
+	defer server.Close()
+
+	logDir := c.MkDir()
+	cfg, err := controller.NewConfig(coretesting.ControllerTag.Id(), coretesting.CACert, map[string]interface{}{
+		controller.AuditLogMaxSizeKey:       10,
+		controller.AuditWebhookURLKey:       server.URL,
+		controller.AuditFilterOperationsKey: "Application:",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	sink, stop, err := newConfiguredAuditSink(cfg, logDir, noAuditErrors(c))
+	c.Assert(err, jc.ErrorIsNil)
+	defer stop()
+	c.Assert(sink, gc.NotNil)
+
+	for _, operation := range []string{"Application:v4 - Deploy", "Client:v1 - FullStatus"} {
+		err := sink(auditEntry("user-admin", operation))
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	// Only the entry selected by the filter is delivered. The JSON
+	// log file is written synchronously; the webhook asynchronously.
+	c.Assert(readAuditOperations(c, logDir), jc.DeepEquals, []string{"Application:v4 - Deploy"})
+	select {
+	case operation := <-received:
+		c.Assert(operation, gc.Equals, "Application:v4 - Deploy")
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("webhook not called")
+	}
+	select {
+	case operation := <-received:
+		c.Fatalf("unexpected entry %q", operation)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *auditSinksSuite) TestStopDeliversBufferedEntries(c *gc.C) {
+	received := make(chan string, 3)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var entry struct {
+			Operation string `json:"operation"`
+		}
+		err := json.NewDecoder(req.Body).Decode(&entry)
+		c.Check(err, jc.ErrorIsNil)
+		received <- entry.Operation
+	}))
+	defer server.Close()
+
+	cfg, err := controller.NewConfig(coretesting.ControllerTag.Id(), coretesting.CACert, map[string]interface{}{
+		controller.AuditWebhookURLKey: server.URL,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	sink, stop, err := newConfiguredAuditSink(cfg, c.MkDir(), noAuditErrors(c))
+	c.Assert(err, jc.ErrorIsNil)
+
+	operations := []string{"Application:v4 - Deploy", "Application:v4 - Expose", "Application:v4 - Unexpose"}
+	for _, operation := range operations {
+		err := sink(auditEntry("user-admin", operation))
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	stop()
+
+	close(received)
+	var delivered []string
+	for operation := range received {
+		delivered = append(delivered, operation)
+	}
+	c.Assert(delivered, jc.DeepEquals, operations)
+}
+
+func (s *auditSinksSuite) TestAgentRequestsNotSentByDefault(c *gc.C) {
+	logDir := c.MkDir()
+	cfg, err := controller.NewConfig(coretesting.ControllerTag.Id(), coretesting.CACert, map[string]interface{}{
+		controller.AuditLogMaxSizeKey: 10,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	sink, stop, err := newConfiguredAuditSink(cfg, logDir, noAuditErrors(c))
+	c.Assert(err, jc.ErrorIsNil)
+	defer stop()
+
+	err = sink(auditEntry("machine-0", "Uniter:v4 - Watch"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = sink(auditEntry("user-admin", "Application:v4 - Deploy"))
+	c.Assert(err, jc.ErrorIsNil)
+	unitEntry := auditEntry("unit-wordpress-0", "Uniter - CloudSpec")
+	unitEntry.OriginType = audit.UnitOriginType
+	err = sink(unitEntry)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(readAuditOperations(c, logDir), jc.DeepEquals, []string{
+		"Application:v4 - Deploy",
+		"Uniter - CloudSpec",
+	})
+}
+
+func (s *auditSinksSuite) TestFilterOriginTypes(c *gc.C) {
+	logDir := c.MkDir()
+	cfg, err := controller.NewConfig(coretesting.ControllerTag.Id(), coretesting.CACert, map[string]interface{}{
+		controller.AuditLogMaxSizeKey:        10,
+		controller.AuditFilterOriginTypesKey: audit.APIRequestOriginType,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	sink, stop, err := newConfiguredAuditSink(cfg, logDir, noAuditErrors(c))
+	c.Assert(err, jc.ErrorIsNil)
+	defer stop()
+
+	// Agent requests are sent when API requests are asked for.
+	err = sink(auditEntry("machine-0", "Uniter:v4 - Watch"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = sink(auditEntry("user-admin", "Application:v4 - Deploy"))
+	c.Assert(err, jc.ErrorIsNil)
+	unitEntry := auditEntry("unit-wordpress-0", "Uniter - CloudSpec")
+	unitEntry.OriginType = audit.UnitOriginType
+	err = sink(unitEntry)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(readAuditOperations(c, logDir), jc.DeepEquals, []string{
+		"Uniter:v4 - Watch",
+		"Application:v4 - Deploy",
+	})
+}
+
+func auditEntry(originName, operation string) audit.AuditEntry {
+	return audit.AuditEntry{
+		JujuServerVersion: version.MustParse("2.2.0"),
+		ModelUUID:         coretesting.ModelTag.Id(),
+		Timestamp:         time.Now().UTC(),
+		RemoteAddress:     "10.0.0.1",
+		OriginType:        audit.APIRequestOriginType,
+		OriginName:        originName,
+		Operation:         operation,
+	}
+}
+
+func noAuditErrors(c *gc.C) func(error) {
+	return func(err error) {
+		c.Errorf("unexpected audit error: %v", err)
+	}
+}
+
+func readAuditOperations(c *gc.C, logDir string) []string {
+	data, err := ioutil.ReadFile(filepath.Join(logDir, "audit.json"))
+	c.Assert(err, jc.ErrorIsNil)
+	var operations []string
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		var entry struct {
+			Operation string `json:"operation"`
+		}
+		err := json.Unmarshal([]byte(line), &entry)
+		c.Assert(err, jc.ErrorIsNil)
+		operations = append(operations, entry.Operation)
+	}
+	return operations
+}
@@ -48,6 +48,7 @@ var (
 		"migration-inactive-flag",
 		"migration-master",
 		"application-scaler",
+		"action-scheduler",
 		"space-importer",
 		"state-cleaner",
 		"status-history-pruner",
@@ -844,6 +844,10 @@ func (a *MachineAgent) openStateForUpgrade() (*state.State, error) {
 	if !ok {
 		return nil, errors.New("no state info available")
 	}
+	secretsKey, err := agent.SecretsKey(agentConfig)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	st, err := state.Open(state.OpenParams{
 		Clock:              clock.WallClock,
 		ControllerTag:      agentConfig.Controller(),
@@ -854,6 +858,7 @@ func (a *MachineAgent) openStateForUpgrade() (*state.State, error) {
 			stateenvirons.GetNewEnvironFunc(environs.New),
 		),
 		RunTransactionObserver: a.txnmetricsCollector.AfterRunTransaction,
+		SecretsKey:             secretsKey,
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -1198,18 +1203,30 @@ func (a *MachineAgent) newAPIserverWorker(
 		return nil, errors.Annotate(err, "cannot fetch the controller config")
 	}
 
+	auditSink, stopAuditSink, err := newAuditEntrySink(st, controllerConfig, logDir, auditErrorHandler)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot create audit sink")
+	}
+
 	newObserver, err := newObserverFn(
 		controllerConfig,
 		clock.WallClock,
 		jujuversion.Current,
 		agentConfig.Model().Id(),
-		newAuditEntrySink(st, logDir),
+		auditSink,
 		auditErrorHandler,
 		a.prometheusRegistry,
 	)
 	if err != nil {
+		stopAuditSink()
 		return nil, errors.Annotate(err, "cannot create RPC observer factory")
 	}
+	// Facades record audit entries only if auditing is enabled, as
+	// the audit observer does.
+	var facadeAuditSink audit.AuditEntrySinkFn
+	if controllerConfig.AuditingEnabled() {
+		facadeAuditSink = auditSink
+	}
 	statePool := state.NewStatePool(st)
 	a.statePool.pool = statePool
 
@@ -1236,26 +1253,41 @@ func (a *MachineAgent) newAPIserverWorker(
 		AutocertDNSName:               controllerConfig.AutocertDNSName(),
 		AllowModelAccess:              controllerConfig.AllowModelAccess(),
 		NewObserver:                   newObserver,
+		AuditEntrySink:                facadeAuditSink,
 		StatePool:                     statePool,
 		RegisterIntrospectionHandlers: registerIntrospectionHandlers,
+		RateLimits: apiserver.RateLimitConfig{
+			UserRequestRate:     controllerConfig.APIUserRequestRate(),
+			ModelRequestRate:    controllerConfig.APIModelRequestRate(),
+			UserMaxConnections:  controllerConfig.APIUserMaxConnections(),
+			ModelMaxConnections: controllerConfig.APIModelMaxConnections(),
+		},
 	})
 	if err != nil {
+		stopAuditSink()
 		return nil, errors.Annotate(err, "cannot start api server worker")
 	}
+	go func() {
+		server.Wait()
+		stopAuditSink()
+	}()
 
 	return server, nil
 }
 
-func newAuditEntrySink(st *state.State, logDir string) audit.AuditEntrySinkFn {
+// newAuditEntrySink returns the audit entry sink used by the API
+// server, and a function which stops it. Entries for user requests
+// are saved synchronously to the database and the audit log file, as
+// are other entries not recording agent requests; entries are also
+// sent to any further sinks configured in the controller config.
+func newAuditEntrySink(
+	st *state.State, controllerConfig controller.Config, logDir string, errorHandler func(error),
+) (audit.AuditEntrySinkFn, func(), error) {
 	persistFn := st.PutAuditEntryFn()
 	fileSinkFn := audit.NewLogFileSink(logDir)
-	return func(entry audit.AuditEntry) error {
-		// We don't care about auditing anything but user actions.
-		if _, err := names.ParseUserTag(entry.OriginName); err != nil {
-			return nil
-		}
-		// TODO(wallyworld) - Pinger requests should not originate as a user action.
-		if strings.HasPrefix(entry.Operation, "Pinger:") {
+	sink := func(entry audit.AuditEntry) error {
+		// We don't record agent requests locally.
+		if isAgentRequest(entry) {
 			return nil
 		}
 		persistErr := persistFn(entry)
@@ -1268,6 +1300,20 @@ func newAuditEntrySink(st *state.State, logDir string) audit.AuditEntrySinkFn {
 		}
 		return errors.Annotate(persistErr, "cannot save audit record to file or database")
 	}
+	configuredSink, stop, err := newConfiguredAuditSink(controllerConfig, logDir, errorHandler)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if configuredSink != nil {
+		sink = audit.NewMultiSink(sink, configuredSink)
+	}
+	return func(entry audit.AuditEntry) error {
+		// TODO(wallyworld) - Pinger requests should not originate as a user action.
+		if strings.HasPrefix(entry.Operation, "Pinger:") {
+			return nil
+		}
+		return sink(entry)
+	}, stop, nil
 }
 
 func newObserverFn(
@@ -1446,6 +1492,15 @@ func openState(
 	if !ok {
 		return nil, nil, errors.Errorf("no state info available")
 	}
+	secretsKey, err := agent.SecretsKey(agentConfig)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if secretsKey == nil {
+		// Controllers bootstrapped before secrets were supported
+		// have no key until one is added by hand.
+		logger.Warningf("no secrets key configured; secrets cannot be added or read through this controller")
+	}
 	st, err := state.Open(state.OpenParams{
 		Clock:              clock.WallClock,
 		ControllerTag:      agentConfig.Controller(),
@@ -1456,6 +1511,7 @@ func openState(
 			stateenvirons.GetNewEnvironFunc(environs.New),
 		),
 		RunTransactionObserver: runTransactionObserver,
+		SecretsKey:             secretsKey,
 	})
 	if err != nil {
 		return nil, nil, err
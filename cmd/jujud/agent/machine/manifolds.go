@@ -481,7 +481,7 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			APICallerName: apiCallerName,
 			Sinks: []logforwarder.LogSinkSpec{{
 				Name:   "juju-log-forward",
-				OpenFn: sinks.OpenSyslog,
+				OpenFn: sinks.Open,
 			}},
 		})),
 	}
@@ -19,6 +19,7 @@ import (
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/feature"
 	jworker "github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/actionscheduler"
 	"github.com/juju/juju/worker/agent"
 	"github.com/juju/juju/worker/apicaller"
 	"github.com/juju/juju/worker/apiconfigwatcher"
@@ -268,6 +269,12 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewFacade:     applicationscaler.NewFacade,
 			NewWorker:     applicationscaler.New,
 		})),
+		actionSchedulerName: ifNotMigrating(actionscheduler.Manifold(actionscheduler.ManifoldConfig{
+			APICallerName: apiCallerName,
+			ClockName:     clockName,
+			NewFacade:     actionscheduler.NewFacade,
+			NewWorker:     actionscheduler.NewWorker,
+		})),
 		instancePollerName: ifNotMigrating(instancepoller.Manifold(instancepoller.ManifoldConfig{
 			APICallerName: apiCallerName,
 			EnvironName:   environTrackerName,
@@ -398,6 +405,7 @@ const (
 	firewallerName           = "firewaller"
 	unitAssignerName         = "unit-assigner"
 	applicationScalerName    = "application-scaler"
+	actionSchedulerName      = "action-scheduler"
 	instancePollerName       = "instance-poller"
 	charmRevisionUpdaterName = "charm-revision-updater"
 	metricWorkerName         = "metric-worker"
@@ -5,6 +5,7 @@ package main
 
 import (
 	"bytes"
+	"encoding/base64"
 	"fmt"
 	"io/ioutil"
 	"net"
@@ -207,12 +208,19 @@ func (c *BootstrapCommand) Run(_ *cmd.Context) error {
 	if err != nil {
 		return err
 	}
+	// Generate the key used to encrypt the values of secrets. It is
+	// kept in the agent config of each controller, and never in Mongo.
+	secretsKey, err := state.GenerateSecretsKey()
+	if err != nil {
+		return err
+	}
 	info, ok := agentConfig.StateServingInfo()
 	if !ok {
 		return fmt.Errorf("bootstrap machine config has no state serving info")
 	}
 	info.SharedSecret = sharedSecret
 	info.SystemIdentity = privateKey
+	info.SecretsKey = base64.StdEncoding.EncodeToString(secretsKey)
 	err = c.ChangeConfig(func(agentConfig agent.ConfigSetter) error {
 		agentConfig.SetStateServingInfo(info)
 		mmprof, err := mongo.NewMemoryProfile(args.ControllerConfig.MongoMemoryProfile())
@@ -708,6 +708,19 @@ func (s *BootstrapSuite) TestSystemIdentityWritten(c *gc.C) {
 	c.Assert(string(data), gc.Equals, "private-key")
 }
 
+func (s *BootstrapSuite) TestSecretsKeyWritten(c *gc.C) {
+	machineConf, cmd, err := s.initBootstrapCommand(c, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = cmd.Run(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	agentConf, err := agent.ReadConfig(agent.ConfigPath(machineConf.DataDir(), names.NewMachineTag("0")))
+	c.Assert(err, jc.ErrorIsNil)
+	key, err := agent.SecretsKey(agentConf)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(key, gc.HasLen, 32)
+}
+
 func (s *BootstrapSuite) TestDownloadedToolsMetadata(c *gc.C) {
 	// Tools downloaded by cloud-init script.
 	s.testToolsMetadata(c, false)
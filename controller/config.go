@@ -5,6 +5,7 @@ package controller
 
 import (
 	"net/url"
+	"strings"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -14,6 +15,7 @@ import (
 	"gopkg.in/macaroon-bakery.v1/bakery"
 
 	"github.com/juju/juju/cert"
+	"github.com/juju/juju/logfwd/syslog"
 )
 
 var logger = loggo.GetLogger("juju.controller")
@@ -73,6 +75,76 @@ const (
 	// detault
 	MongoMemoryProfile = "mongo-memory-profile"
 
+	// APIUserRequestRateKey sets the maximum number of API requests
+	// per second that each user may make. Zero means no limit.
+	APIUserRequestRateKey = "api-user-request-rate"
+
+	// APIModelRequestRateKey sets the maximum number of API requests
+	// per second that users may make, in total, to each model. Zero
+	// means no limit.
+	APIModelRequestRateKey = "api-model-request-rate"
+
+	// APIUserMaxConnectionsKey sets the maximum number of concurrent
+	// API connections that each user may hold. Zero means no limit.
+	APIUserMaxConnectionsKey = "api-user-max-connections"
+
+	// APIModelMaxConnectionsKey sets the maximum number of concurrent
+	// API connections that users may hold, in total, to each model.
+	// Zero means no limit.
+	APIModelMaxConnectionsKey = "api-model-max-connections"
+
+	// MaxCharmStateSizeKey sets the maximum size, in bytes, of the
+	// key/value state that a charm may store for each of its units.
+	// Zero means no limit.
+	MaxCharmStateSizeKey = "max-charm-state-size"
+
+	// AuditLogMaxSizeKey sets the size, in megabytes, at which the
+	// audit.json log file is rotated. Zero means audit entries are
+	// not written as JSON.
+	AuditLogMaxSizeKey = "audit-log-max-size"
+
+	// AuditLogMaxBackupsKey sets the number of rotated audit.json
+	// log files to keep. Zero means all rotated files are kept.
+	AuditLogMaxBackupsKey = "audit-log-max-backups"
+
+	// AuditSyslogHostKey sets the hostname:port of a syslog server
+	// to which audit entries are forwarded.
+	AuditSyslogHostKey = "audit-syslog-host"
+
+	// AuditSyslogCACertKey sets the certificate of the CA that
+	// signed the audit syslog server certificate, in PEM format.
+	AuditSyslogCACertKey = "audit-syslog-ca-cert"
+
+	// AuditSyslogClientCertKey sets the client certificate used to
+	// connect to the audit syslog server, in PEM format.
+	AuditSyslogClientCertKey = "audit-syslog-client-cert"
+
+	// AuditSyslogClientKeyKey sets the client key used to connect
+	// to the audit syslog server, in PEM format.
+	AuditSyslogClientKeyKey = "audit-syslog-client-key"
+
+	// AuditWebhookURLKey sets the URL to which audit entries are
+	// POSTed as JSON documents.
+	AuditWebhookURLKey = "audit-webhook-url"
+
+	// AuditFilterOperationsKey sets a comma-separated list of
+	// operation prefixes, e.g. "Application:,Client:v1 - FullStatus";
+	// only matching audit entries are sent to the JSON log file,
+	// syslog and webhook sinks.
+	AuditFilterOperationsKey = "audit-filter-operations"
+
+	// AuditFilterOriginTypesKey sets a comma-separated list of origin
+	// types, e.g. "API request,unit"; only matching audit entries are
+	// sent to the JSON log file, syslog and webhook sinks. By default
+	// API requests made by agents rather than users are not sent;
+	// they are when "API request" is listed.
+	AuditFilterOriginTypesKey = "audit-filter-origin-types"
+
+	// AuditFilterModelsKey sets a comma-separated list of model UUIDs;
+	// only matching audit entries are sent to the JSON log file,
+	// syslog and webhook sinks.
+	AuditFilterModelsKey = "audit-filter-models"
+
 	// Attribute Defaults
 
 	// DefaultAuditingEnabled contains the default value for the
@@ -91,6 +163,10 @@ const (
 
 	// DefaultMongoMemoryProfile is the default profile used by mongo.
 	DefaultMongoMemoryProfile = MongoProfLow
+
+	// DefaultMaxCharmStateSize is the default maximum size, in bytes,
+	// of a unit's charm state.
+	DefaultMaxCharmStateSize = 2 * 1024 * 1024
 )
 
 // ControllerOnlyConfigAttributes are attributes which are only relevant
@@ -107,6 +183,21 @@ var ControllerOnlyConfigAttributes = []string{
 	SetNUMAControlPolicyKey,
 	StatePort,
 	MongoMemoryProfile,
+	APIUserRequestRateKey,
+	APIModelRequestRateKey,
+	APIUserMaxConnectionsKey,
+	APIModelMaxConnectionsKey,
+	MaxCharmStateSizeKey,
+	AuditLogMaxSizeKey,
+	AuditLogMaxBackupsKey,
+	AuditSyslogHostKey,
+	AuditSyslogCACertKey,
+	AuditSyslogClientCertKey,
+	AuditSyslogClientKeyKey,
+	AuditWebhookURLKey,
+	AuditFilterOperationsKey,
+	AuditFilterOriginTypesKey,
+	AuditFilterModelsKey,
 }
 
 // ControllerOnlyAttribute returns true if the specified attribute name
@@ -160,6 +251,17 @@ func (c Config) mustInt(name string) int {
 	return value
 }
 
+// asInt returns the named attribute as an integer, returning 0
+// if it isn't found.
+func (c Config) asInt(name string) int {
+	// Values obtained over the api are encoded as float64.
+	if value, ok := c[name].(float64); ok {
+		return int(value)
+	}
+	value, _ := c[name].(int)
+	return value
+}
+
 // asString is a private helper method to keep the ugly string casting
 // in once place. It returns the given named attribute as a string,
 // returning "" if it isn't found.
@@ -269,6 +371,106 @@ func (c Config) AllowModelAccess() bool {
 	return value
 }
 
+// APIUserRequestRate returns the maximum number of API requests per
+// second that each user may make, or 0 if there is no limit.
+func (c Config) APIUserRequestRate() int {
+	return c.asInt(APIUserRequestRateKey)
+}
+
+// APIModelRequestRate returns the maximum number of API requests per
+// second that users may make to each model, or 0 if there is no limit.
+func (c Config) APIModelRequestRate() int {
+	return c.asInt(APIModelRequestRateKey)
+}
+
+// APIUserMaxConnections returns the maximum number of concurrent API
+// connections that each user may hold, or 0 if there is no limit.
+func (c Config) APIUserMaxConnections() int {
+	return c.asInt(APIUserMaxConnectionsKey)
+}
+
+// APIModelMaxConnections returns the maximum number of concurrent API
+// connections that users may hold to each model, or 0 if there is no
+// limit.
+func (c Config) APIModelMaxConnections() int {
+	return c.asInt(APIModelMaxConnectionsKey)
+}
+
+// MaxCharmStateSize returns the maximum size, in bytes, of the charm
+// state stored for each unit, or 0 if there is no limit.
+func (c Config) MaxCharmStateSize() int {
+	if _, ok := c[MaxCharmStateSizeKey]; !ok {
+		return DefaultMaxCharmStateSize
+	}
+	return c.asInt(MaxCharmStateSizeKey)
+}
+
+// AuditLogMaxSizeMB returns the size, in megabytes, at which the
+// audit.json log file is rotated, or 0 if audit entries are not
+// written as JSON.
+func (c Config) AuditLogMaxSizeMB() int {
+	return c.asInt(AuditLogMaxSizeKey)
+}
+
+// AuditLogMaxBackups returns the number of rotated audit.json log
+// files to keep, or 0 if all of them are kept.
+func (c Config) AuditLogMaxBackups() int {
+	return c.asInt(AuditLogMaxBackupsKey)
+}
+
+// AuditSyslog returns the config for forwarding audit entries to a
+// syslog server, and whether forwarding is configured.
+func (c Config) AuditSyslog() (syslog.RawConfig, bool) {
+	host := c.asString(AuditSyslogHostKey)
+	if host == "" {
+		return syslog.RawConfig{}, false
+	}
+	return syslog.RawConfig{
+		Enabled:    true,
+		Protocol:   syslog.ProtocolSyslog,
+		Host:       host,
+		CACert:     c.asString(AuditSyslogCACertKey),
+		ClientCert: c.asString(AuditSyslogClientCertKey),
+		ClientKey:  c.asString(AuditSyslogClientKeyKey),
+	}, true
+}
+
+// AuditWebhookURL returns the URL to which audit entries are POSTed,
+// or "" if there is none.
+func (c Config) AuditWebhookURL() string {
+	return c.asString(AuditWebhookURLKey)
+}
+
+// AuditFilterOperations returns the operation prefixes selecting the
+// audit entries sent to the JSON log file, syslog and webhook sinks.
+func (c Config) AuditFilterOperations() []string {
+	return c.asList(AuditFilterOperationsKey)
+}
+
+// AuditFilterOriginTypes returns the origin types selecting the audit
+// entries sent to the JSON log file, syslog and webhook sinks.
+func (c Config) AuditFilterOriginTypes() []string {
+	return c.asList(AuditFilterOriginTypesKey)
+}
+
+// AuditFilterModels returns the model UUIDs selecting the audit
+// entries sent to the JSON log file, syslog and webhook sinks.
+func (c Config) AuditFilterModels() []string {
+	return c.asList(AuditFilterModelsKey)
+}
+
+// asList returns the named comma-separated attribute as a list of
+// strings, returning nil if it isn't found.
+func (c Config) asList(name string) []string {
+	var values []string
+	for _, v := range strings.Split(c.asString(name), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
 // Validate ensures that config is a valid configuration.
 func Validate(c Config) error {
 	if v, ok := c[IdentityPublicKey].(string); ok {
@@ -310,6 +512,36 @@ func Validate(c Config) error {
 		}
 	}
 
+	for _, key := range []string{
+		APIUserRequestRateKey,
+		APIModelRequestRateKey,
+		APIUserMaxConnectionsKey,
+		APIModelMaxConnectionsKey,
+		MaxCharmStateSizeKey,
+		AuditLogMaxSizeKey,
+		AuditLogMaxBackupsKey,
+	} {
+		if c.asInt(key) < 0 {
+			return errors.Errorf("%s: expected non-negative integer, got %d", key, c.asInt(key))
+		}
+	}
+
+	if syslogCfg, ok := c.AuditSyslog(); ok {
+		if err := syslogCfg.Validate(); err != nil {
+			return errors.Annotate(err, "invalid audit syslog config")
+		}
+	}
+
+	if v := c.AuditWebhookURL(); v != "" {
+		u, err := url.Parse(v)
+		if err != nil {
+			return errors.Annotate(err, "invalid audit webhook URL")
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return errors.Errorf("%s: expected http or https URL, got %q", AuditWebhookURLKey, v)
+		}
+	}
+
 	return nil
 }
 
@@ -320,25 +552,55 @@ func GenerateControllerCertAndKey(caCert, caKey string, hostAddresses []string)
 }
 
 var configChecker = schema.FieldMap(schema.Fields{
-	AuditingEnabled:         schema.Bool(),
-	APIPort:                 schema.ForceInt(),
-	StatePort:               schema.ForceInt(),
-	IdentityURL:             schema.String(),
-	IdentityPublicKey:       schema.String(),
-	SetNUMAControlPolicyKey: schema.Bool(),
-	AutocertURLKey:          schema.String(),
-	AutocertDNSNameKey:      schema.String(),
-	AllowModelAccessKey:     schema.Bool(),
-	MongoMemoryProfile:      schema.String(),
+	AuditingEnabled:           schema.Bool(),
+	APIPort:                   schema.ForceInt(),
+	StatePort:                 schema.ForceInt(),
+	IdentityURL:               schema.String(),
+	IdentityPublicKey:         schema.String(),
+	SetNUMAControlPolicyKey:   schema.Bool(),
+	AutocertURLKey:            schema.String(),
+	AutocertDNSNameKey:        schema.String(),
+	AllowModelAccessKey:       schema.Bool(),
+	MongoMemoryProfile:        schema.String(),
+	APIUserRequestRateKey:     schema.ForceInt(),
+	APIModelRequestRateKey:    schema.ForceInt(),
+	APIUserMaxConnectionsKey:  schema.ForceInt(),
+	APIModelMaxConnectionsKey: schema.ForceInt(),
+	MaxCharmStateSizeKey:      schema.ForceInt(),
+	AuditLogMaxSizeKey:        schema.ForceInt(),
+	AuditLogMaxBackupsKey:     schema.ForceInt(),
+	AuditSyslogHostKey:        schema.String(),
+	AuditSyslogCACertKey:      schema.String(),
+	AuditSyslogClientCertKey:  schema.String(),
+	AuditSyslogClientKeyKey:   schema.String(),
+	AuditWebhookURLKey:        schema.String(),
+	AuditFilterOperationsKey:  schema.String(),
+	AuditFilterOriginTypesKey: schema.String(),
+	AuditFilterModelsKey:      schema.String(),
 }, schema.Defaults{
-	APIPort:                 DefaultAPIPort,
-	AuditingEnabled:         DefaultAuditingEnabled,
-	StatePort:               DefaultStatePort,
-	IdentityURL:             schema.Omit,
-	IdentityPublicKey:       schema.Omit,
-	SetNUMAControlPolicyKey: DefaultNUMAControlPolicy,
-	AutocertURLKey:          schema.Omit,
-	AutocertDNSNameKey:      schema.Omit,
-	AllowModelAccessKey:     schema.Omit,
-	MongoMemoryProfile:      schema.Omit,
+	APIPort:                   DefaultAPIPort,
+	AuditingEnabled:           DefaultAuditingEnabled,
+	StatePort:                 DefaultStatePort,
+	IdentityURL:               schema.Omit,
+	IdentityPublicKey:         schema.Omit,
+	SetNUMAControlPolicyKey:   DefaultNUMAControlPolicy,
+	AutocertURLKey:            schema.Omit,
+	AutocertDNSNameKey:        schema.Omit,
+	AllowModelAccessKey:       schema.Omit,
+	MongoMemoryProfile:        schema.Omit,
+	APIUserRequestRateKey:     schema.Omit,
+	APIModelRequestRateKey:    schema.Omit,
+	APIUserMaxConnectionsKey:  schema.Omit,
+	APIModelMaxConnectionsKey: schema.Omit,
+	MaxCharmStateSizeKey:      schema.Omit,
+	AuditLogMaxSizeKey:        schema.Omit,
+	AuditLogMaxBackupsKey:     schema.Omit,
+	AuditSyslogHostKey:        schema.Omit,
+	AuditSyslogCACertKey:      schema.Omit,
+	AuditSyslogClientCertKey:  schema.Omit,
+	AuditSyslogClientKeyKey:   schema.Omit,
+	AuditWebhookURLKey:        schema.Omit,
+	AuditFilterOperationsKey:  schema.Omit,
+	AuditFilterOriginTypesKey: schema.Omit,
+	AuditFilterModelsKey:      schema.Omit,
 })
@@ -15,6 +15,7 @@ import (
 
 	"github.com/juju/juju/cert"
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/logfwd/syslog"
 	"github.com/juju/juju/testing"
 )
 
@@ -121,6 +122,65 @@ var validateTests = []struct {
 		controller.CACertKey:         testing.CACert,
 	},
 	expectError: `invalid identity public key: wrong length for base64 key, got 3 want 32`,
+}, {
+	about: "API rate limits OK",
+	config: controller.Config{
+		controller.APIUserRequestRateKey:     10,
+		controller.APIModelMaxConnectionsKey: 100,
+		controller.CACertKey:                 testing.CACert,
+	},
+}, {
+	about: "negative API rate limit",
+	config: controller.Config{
+		controller.APIUserMaxConnectionsKey: -1,
+		controller.CACertKey:                testing.CACert,
+	},
+	expectError: `api-user-max-connections: expected non-negative integer, got -1`,
+}, {
+	about: "negative charm state size",
+	config: controller.Config{
+		controller.MaxCharmStateSizeKey: -1,
+		controller.CACertKey:            testing.CACert,
+	},
+	expectError: `max-charm-state-size: expected non-negative integer, got -1`,
+}, {
+	about: "negative audit log size",
+	config: controller.Config{
+		controller.AuditLogMaxSizeKey: -1,
+		controller.CACertKey:          testing.CACert,
+	},
+	expectError: `audit-log-max-size: expected non-negative integer, got -1`,
+}, {
+	about: "audit syslog OK",
+	config: controller.Config{
+		controller.AuditSyslogHostKey:       "10.0.0.1:6514",
+		controller.AuditSyslogCACertKey:     testing.CACert,
+		controller.AuditSyslogClientCertKey: testing.ServerCert,
+		controller.AuditSyslogClientKeyKey:  testing.ServerKey,
+		controller.CACertKey:                testing.CACert,
+	},
+}, {
+	about: "audit syslog missing client key",
+	config: controller.Config{
+		controller.AuditSyslogHostKey:       "10.0.0.1:6514",
+		controller.AuditSyslogCACertKey:     testing.CACert,
+		controller.AuditSyslogClientCertKey: testing.ServerCert,
+		controller.CACertKey:                testing.CACert,
+	},
+	expectError: `invalid audit syslog config: validating TLS config: parsing client key pair: .*`,
+}, {
+	about: "audit webhook URL OK",
+	config: controller.Config{
+		controller.AuditWebhookURLKey: "https://audit.example.com/entries",
+		controller.CACertKey:          testing.CACert,
+	},
+}, {
+	about: "audit webhook URL not HTTP",
+	config: controller.Config{
+		controller.AuditWebhookURLKey: "ftp://audit.example.com/entries",
+		controller.CACertKey:          testing.CACert,
+	},
+	expectError: `audit-webhook-url: expected http or https URL, got "ftp://audit.example.com/entries"`,
 }}
 
 func (s *ConfigSuite) TestValidate(c *gc.C) {
@@ -134,3 +194,78 @@ func (s *ConfigSuite) TestValidate(c *gc.C) {
 		}
 	}
 }
+
+func (s *ConfigSuite) TestAPIRateLimits(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, map[string]interface{}{
+		controller.APIUserRequestRateKey:     5,
+		controller.APIModelRequestRateKey:    50,
+		controller.APIUserMaxConnectionsKey:  float64(10),
+		controller.APIModelMaxConnectionsKey: 100,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.APIUserRequestRate(), gc.Equals, 5)
+	c.Assert(cfg.APIModelRequestRate(), gc.Equals, 50)
+	c.Assert(cfg.APIUserMaxConnections(), gc.Equals, 10)
+	c.Assert(cfg.APIModelMaxConnections(), gc.Equals, 100)
+}
+
+func (s *ConfigSuite) TestAPIRateLimitsDefault(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.APIUserRequestRate(), gc.Equals, 0)
+	c.Assert(cfg.APIModelRequestRate(), gc.Equals, 0)
+	c.Assert(cfg.APIUserMaxConnections(), gc.Equals, 0)
+	c.Assert(cfg.APIModelMaxConnections(), gc.Equals, 0)
+}
+
+func (s *ConfigSuite) TestMaxCharmStateSize(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.MaxCharmStateSize(), gc.Equals, controller.DefaultMaxCharmStateSize)
+
+	cfg, err = controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, map[string]interface{}{
+		controller.MaxCharmStateSizeKey: 1024,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.MaxCharmStateSize(), gc.Equals, 1024)
+}
+
+func (s *ConfigSuite) TestAuditSinks(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AuditLogMaxSizeMB(), gc.Equals, 0)
+	_, ok := cfg.AuditSyslog()
+	c.Assert(ok, jc.IsFalse)
+	c.Assert(cfg.AuditWebhookURL(), gc.Equals, "")
+	c.Assert(cfg.AuditFilterOperations(), gc.HasLen, 0)
+
+	cfg, err = controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, map[string]interface{}{
+		controller.AuditLogMaxSizeKey:        300,
+		controller.AuditLogMaxBackupsKey:     10,
+		controller.AuditSyslogHostKey:        "10.0.0.1:6514",
+		controller.AuditSyslogCACertKey:      testing.CACert,
+		controller.AuditSyslogClientCertKey:  testing.ServerCert,
+		controller.AuditSyslogClientKeyKey:   testing.ServerKey,
+		controller.AuditWebhookURLKey:        "https://audit.example.com/entries",
+		controller.AuditFilterOperationsKey:  "Application:, Client:v1 - FullStatus",
+		controller.AuditFilterOriginTypesKey: "API request, unit",
+		controller.AuditFilterModelsKey:      testing.ModelTag.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AuditLogMaxSizeMB(), gc.Equals, 300)
+	c.Assert(cfg.AuditLogMaxBackups(), gc.Equals, 10)
+	syslogCfg, ok := cfg.AuditSyslog()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(syslogCfg, jc.DeepEquals, syslog.RawConfig{
+		Enabled:    true,
+		Protocol:   syslog.ProtocolSyslog,
+		Host:       "10.0.0.1:6514",
+		CACert:     testing.CACert,
+		ClientCert: testing.ServerCert,
+		ClientKey:  testing.ServerKey,
+	})
+	c.Assert(cfg.AuditWebhookURL(), gc.Equals, "https://audit.example.com/entries")
+	c.Assert(cfg.AuditFilterOperations(), jc.DeepEquals, []string{"Application:", "Client:v1 - FullStatus"})
+	c.Assert(cfg.AuditFilterOriginTypes(), jc.DeepEquals, []string{"API request", "unit"})
+	c.Assert(cfg.AuditFilterModels(), jc.DeepEquals, []string{testing.ModelTag.Id()})
+}
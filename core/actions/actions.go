@@ -5,12 +5,41 @@
 package actions
 
 import (
+	"time"
+
+	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6-unstable"
 )
 
 // JujuRunActionName defines the action name used by juju-run.
 const JujuRunActionName = "juju-run"
 
+// TimeoutKey is the key in an action's definition in actions.yaml
+// that holds the default timeout for the action, as a duration string
+// such as "10m".
+const TimeoutKey = "timeout"
+
+// DefaultTimeout returns the default timeout defined for the action
+// with the given spec, or zero if it has none.
+func DefaultTimeout(spec charm.ActionSpec) (time.Duration, error) {
+	value, ok := spec.Params[TimeoutKey]
+	if !ok {
+		return 0, nil
+	}
+	str, ok := value.(string)
+	if !ok {
+		return 0, errors.NotValidf("timeout %v (expected a duration string)", value)
+	}
+	timeout, err := time.ParseDuration(str)
+	if err != nil {
+		return 0, errors.NotValidf("timeout %q", str)
+	}
+	if timeout < 0 {
+		return 0, errors.NotValidf("negative timeout %q", str)
+	}
+	return timeout, nil
+}
+
 // PredefinedActionsSpec defines a spec for each predefined action.
 var PredefinedActionsSpec = map[string]charm.ActionSpec{
 	JujuRunActionName: charm.ActionSpec{
@@ -0,0 +1,52 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actions_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/core/actions"
+)
+
+type ActionsSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ActionsSuite{})
+
+func (s *ActionsSuite) TestDefaultTimeout(c *gc.C) {
+	for i, test := range []struct {
+		params  map[string]interface{}
+		timeout time.Duration
+		err     string
+	}{{
+		params: map[string]interface{}{},
+	}, {
+		params:  map[string]interface{}{"timeout": "90s"},
+		timeout: 90 * time.Second,
+	}, {
+		params: map[string]interface{}{"timeout": 10},
+		err:    `timeout 10 \(expected a duration string\) not valid`,
+	}, {
+		params: map[string]interface{}{"timeout": "soon"},
+		err:    `timeout "soon" not valid`,
+	}, {
+		params: map[string]interface{}{"timeout": "-1m"},
+		err:    `negative timeout "-1m" not valid`,
+	}} {
+		c.Logf("test %d: %v", i, test.params)
+		timeout, err := actions.DefaultTimeout(charm.ActionSpec{Params: test.params})
+		if test.err != "" {
+			c.Check(err, gc.ErrorMatches, test.err)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(timeout, gc.Equals, test.timeout)
+	}
+}
@@ -0,0 +1,217 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actions
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// Schedule describes when a scheduled action should next run.
+type Schedule interface {
+	// Next returns the first time strictly after t at which the
+	// schedule fires. All calculations are made in UTC.
+	Next(t time.Time) time.Time
+}
+
+// scheduleMacros maps the supported "@" shorthands onto their
+// equivalent five field cron expressions.
+var scheduleMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// everyPrefix introduces a fixed interval schedule, e.g. "@every 90m".
+const everyPrefix = "@every "
+
+// ParseSchedule parses a schedule expression. The expression is either
+// a standard five field cron expression (minute, hour, day of month,
+// month and day of week), one of the macros @yearly, @annually,
+// @monthly, @weekly, @daily, @midnight or @hourly, or "@every <duration>"
+// for a fixed interval of at least one minute.
+//
+// Each cron field may be "*", a single value, a range "a-b", or a
+// comma separated list of those; any of which may be followed by a
+// step "/n". Days of the week run from 0 (Sunday) to 6; 7 is also
+// accepted for Sunday.
+func ParseSchedule(spec string) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if strings.HasPrefix(spec, everyPrefix) {
+		interval, err := time.ParseDuration(strings.TrimSpace(spec[len(everyPrefix):]))
+		if err != nil {
+			return nil, errors.NotValidf("schedule %q", spec)
+		}
+		if interval < time.Minute {
+			return nil, errors.NotValidf("schedule %q with interval less than a minute", spec)
+		}
+		return everySchedule(interval), nil
+	}
+	if expanded, ok := scheduleMacros[spec]; ok {
+		spec = expanded
+	}
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, errors.NotValidf("schedule %q (expected 5 fields, got %d)", spec, len(fields))
+	}
+	var s cronSchedule
+	for i, bounds := range cronFields {
+		bits, err := parseCronField(fields[i], bounds)
+		if err != nil {
+			return nil, errors.Annotatef(err, "parsing schedule %q", spec)
+		}
+		switch i {
+		case 0:
+			s.minute = bits
+		case 1:
+			s.hour = bits
+		case 2:
+			s.dom = bits
+			s.domStar = fields[i] == "*"
+		case 3:
+			s.month = bits
+		case 4:
+			// Fold Sunday-as-7 onto 0.
+			if bits&(1<<7) != 0 {
+				bits = bits&^(1<<7) | 1
+			}
+			s.dow = bits
+			s.dowStar = fields[i] == "*"
+		}
+	}
+	return &s, nil
+}
+
+// everySchedule fires at a fixed interval.
+type everySchedule time.Duration
+
+// Next is part of the Schedule interface.
+func (s everySchedule) Next(t time.Time) time.Time {
+	return t.UTC().Add(time.Duration(s)).Truncate(time.Second)
+}
+
+type fieldBounds struct {
+	name     string
+	min, max uint
+}
+
+var cronFields = []fieldBounds{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+// parseCronField returns a bit set with a bit set for each value
+// matched by the field.
+func parseCronField(field string, bounds fieldBounds) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangeSpec, step := part, uint(1)
+		if i := strings.Index(part, "/"); i >= 0 {
+			n, err := strconv.ParseUint(part[i+1:], 10, 8)
+			if err != nil || n == 0 {
+				return 0, errors.NotValidf("%s step %q", bounds.name, part[i+1:])
+			}
+			rangeSpec, step = part[:i], uint(n)
+		}
+		var lo, hi uint
+		switch {
+		case rangeSpec == "*":
+			lo, hi = bounds.min, bounds.max
+		case strings.Contains(rangeSpec, "-"):
+			ends := strings.SplitN(rangeSpec, "-", 2)
+			var err error
+			if lo, err = parseCronValue(ends[0], bounds); err != nil {
+				return 0, errors.Trace(err)
+			}
+			if hi, err = parseCronValue(ends[1], bounds); err != nil {
+				return 0, errors.Trace(err)
+			}
+			if lo > hi {
+				return 0, errors.NotValidf("%s range %q", bounds.name, rangeSpec)
+			}
+		default:
+			var err error
+			if lo, err = parseCronValue(rangeSpec, bounds); err != nil {
+				return 0, errors.Trace(err)
+			}
+			hi = lo
+			if step > 1 {
+				hi = bounds.max
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << v
+		}
+	}
+	return bits, nil
+}
+
+func parseCronValue(s string, bounds fieldBounds) (uint, error) {
+	n, err := strconv.ParseUint(s, 10, 8)
+	if err != nil || uint(n) < bounds.min || uint(n) > bounds.max {
+		return 0, errors.NotValidf("%s %q", bounds.name, s)
+	}
+	return uint(n), nil
+}
+
+// cronSchedule is a Schedule defined by a cron expression.
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+
+	// domStar and dowStar record whether the day fields were
+	// unrestricted; if both are restricted, a day matches when
+	// either field does, as with cron.
+	domStar, dowStar bool
+}
+
+// maxScheduleSearch bounds the search for the next matching time,
+// so that expressions that can never match (e.g. "0 0 31 2 *") do
+// not loop forever.
+const maxScheduleSearch = 5 * 366 * 24 * time.Hour
+
+// Next is part of the Schedule interface. If the schedule can never
+// fire, Next returns the zero time.
+func (s *cronSchedule) Next(t time.Time) time.Time {
+	t = t.UTC().Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(maxScheduleSearch)
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = t.Truncate(time.Hour).Add(time.Hour)
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (s *cronSchedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
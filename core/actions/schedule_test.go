@@ -0,0 +1,102 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actions_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/actions"
+)
+
+type ScheduleSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ScheduleSuite{})
+
+func (s *ScheduleSuite) TestNext(c *gc.C) {
+	base := time.Date(2017, 3, 15, 10, 30, 0, 0, time.UTC)
+	for i, test := range []struct {
+		spec string
+		next time.Time
+	}{{
+		spec: "*/15 * * * *",
+		next: time.Date(2017, 3, 15, 10, 45, 0, 0, time.UTC),
+	}, {
+		spec: "0 2 * * *",
+		next: time.Date(2017, 3, 16, 2, 0, 0, 0, time.UTC),
+	}, {
+		spec: "@hourly",
+		next: time.Date(2017, 3, 15, 11, 0, 0, 0, time.UTC),
+	}, {
+		spec: "@monthly",
+		next: time.Date(2017, 4, 1, 0, 0, 0, 0, time.UTC),
+	}, {
+		spec: "0 0 * * 0",
+		next: time.Date(2017, 3, 19, 0, 0, 0, 0, time.UTC),
+	}, {
+		spec: "0 0 * * 7",
+		next: time.Date(2017, 3, 19, 0, 0, 0, 0, time.UTC),
+	}, {
+		// Restricting both day fields matches either.
+		spec: "0 0 1 * 1",
+		next: time.Date(2017, 3, 20, 0, 0, 0, 0, time.UTC),
+	}, {
+		spec: "30 10 29 2 *",
+		next: time.Date(2020, 2, 29, 10, 30, 0, 0, time.UTC),
+	}, {
+		spec: "5,10-12 9-17/4 * 1-6 1-5",
+		next: time.Date(2017, 3, 15, 13, 5, 0, 0, time.UTC),
+	}, {
+		spec: "@every 90m",
+		next: time.Date(2017, 3, 15, 12, 0, 0, 0, time.UTC),
+	}, {
+		// February 31st never happens.
+		spec: "0 0 31 2 *",
+	}} {
+		c.Logf("test %d: %q", i, test.spec)
+		schedule, err := actions.ParseSchedule(test.spec)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(schedule.Next(base), gc.Equals, test.next)
+	}
+}
+
+func (s *ScheduleSuite) TestParseScheduleErrors(c *gc.C) {
+	for i, test := range []struct {
+		spec string
+		err  string
+	}{{
+		spec: "",
+		err:  `schedule "" \(expected 5 fields, got 0\) not valid`,
+	}, {
+		spec: "* * * *",
+		err:  `schedule "\* \* \* \*" \(expected 5 fields, got 4\) not valid`,
+	}, {
+		spec: "60 * * * *",
+		err:  `parsing schedule "60 \* \* \* \*": minute "60" not valid`,
+	}, {
+		spec: "a * * * *",
+		err:  `parsing schedule "a \* \* \* \*": minute "a" not valid`,
+	}, {
+		spec: "5-1 * * * *",
+		err:  `parsing schedule "5-1 \* \* \* \*": minute range "5-1" not valid`,
+	}, {
+		spec: "* * * * */0",
+		err:  `parsing schedule "\* \* \* \* \*/0": day of week step "0" not valid`,
+	}, {
+		spec: "@every soon",
+		err:  `schedule "@every soon" not valid`,
+	}, {
+		spec: "@every 10s",
+		err:  `schedule "@every 10s" with interval less than a minute not valid`,
+	}} {
+		c.Logf("test %d: %q", i, test.spec)
+		_, err := actions.ParseSchedule(test.spec)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
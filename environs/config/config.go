@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -110,6 +111,10 @@ const (
 	// LogForwardEnabled determines whether the log forward functionality is enabled.
 	LogForwardEnabled = "logforward-enabled"
 
+	// LogFwdProtocol sets the protocol used to forward logs. The
+	// syslog-* settings configure the target for every protocol.
+	LogFwdProtocol = "logforward-protocol"
+
 	// LogFwdSyslogHost sets the hostname:port of the syslog server.
 	LogFwdSyslogHost = "syslog-host"
 
@@ -137,6 +142,16 @@ const (
 	// is stored against the model.
 	ExtraInfoKey = "extra-info"
 
+	// MaxStatusHistoryAgeKey is the key for the maximum age of status
+	// history entries kept for this model, overriding the controller's
+	// pruning settings.
+	MaxStatusHistoryAgeKey = "max-status-history-age"
+
+	// MaxStatusHistorySizeKey is the key for the maximum size of this
+	// model's status history before its entries are pruned, overriding
+	// the controller's pruning settings.
+	MaxStatusHistorySizeKey = "max-status-history-size"
+
 	//
 	// Deprecated Settings Attributes
 	//
@@ -478,6 +493,19 @@ func Validate(cfg, old *Config) error {
 		return errors.Annotate(err, "validating resource tags")
 	}
 
+	if v, ok := cfg.defined[MaxStatusHistoryAgeKey].(string); ok && v != "" {
+		if d, err := time.ParseDuration(v); err != nil {
+			return errors.Annotatef(err, "invalid %s in model configuration", MaxStatusHistoryAgeKey)
+		} else if d < 0 {
+			return errors.Errorf("invalid %s in model configuration: negative duration %q", MaxStatusHistoryAgeKey, v)
+		}
+	}
+	if v, ok := cfg.defined[MaxStatusHistorySizeKey].(string); ok && v != "" {
+		if _, err := utils.ParseSize(v); err != nil {
+			return errors.Annotatef(err, "invalid %s in model configuration", MaxStatusHistorySizeKey)
+		}
+	}
+
 	// Check the immutable config values.  These can't change
 	if old != nil {
 		for _, attr := range immutableAttributes {
@@ -679,6 +707,11 @@ func (c *Config) LogFwdSyslog() (*syslog.RawConfig, bool) {
 		lfCfg.Enabled = s.(bool)
 	}
 
+	if s, ok := c.defined[LogFwdProtocol]; ok && s != "" {
+		partial = true
+		lfCfg.Protocol = s.(string)
+	}
+
 	if s, ok := c.defined[LogFwdSyslogHost]; ok && s != "" {
 		partial = true
 		lfCfg.Host = s.(string)
@@ -801,6 +834,27 @@ func (c *Config) TransmitVendorMetrics() bool {
 	}
 }
 
+// MaxStatusHistoryAge returns the maximum age of status history entries
+// kept for this model, or zero if the controller's setting applies.
+func (c *Config) MaxStatusHistoryAge() time.Duration {
+	// Value has already been validated.
+	d, _ := time.ParseDuration(c.asString(MaxStatusHistoryAgeKey))
+	return d
+}
+
+// MaxStatusHistorySizeMB returns the maximum size, in megabytes, of this
+// model's status history before its entries are pruned, or zero if the
+// controller's setting applies.
+func (c *Config) MaxStatusHistorySizeMB() uint {
+	v := c.asString(MaxStatusHistorySizeKey)
+	if v == "" {
+		return 0
+	}
+	// Value has already been validated.
+	size, _ := utils.ParseSize(v)
+	return uint(size)
+}
+
 // ProvisionerHarvestMode reports the harvesting methodology the
 // provisioner should take.
 func (c *Config) ProvisionerHarvestMode() HarvestMode {
@@ -958,7 +1012,11 @@ var alwaysOptional = schema.Defaults{
 	AuthorizedKeysKey: schema.Omit,
 	ExtraInfoKey:      schema.Omit,
 
+	MaxStatusHistoryAgeKey:  schema.Omit,
+	MaxStatusHistorySizeKey: schema.Omit,
+
 	LogForwardEnabled:      schema.Omit,
+	LogFwdProtocol:         schema.Omit,
 	LogFwdSyslogHost:       schema.Omit,
 	LogFwdSyslogCACert:     schema.Omit,
 	LogFwdSyslogClientCert: schema.Omit,
@@ -1215,6 +1273,16 @@ var configSchema = environschema.Fields{
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	MaxStatusHistoryAgeKey: {
+		Description: "The maximum age of status history entries kept for the model, e.g. \"72h\". Overrides the controller's pruning age when set.",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	MaxStatusHistorySizeKey: {
+		Description: "The maximum size of the model's status history before its entries are pruned, e.g. \"512M\". Overrides the controller's pruning size when set: the model's entries are then pruned against this limit only, and are not removed to keep the controller's status history under its size.",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 	"firewall-mode": {
 		Description: `The mode to use for network firewalling.
 
@@ -1293,12 +1361,18 @@ global or per instance security groups.`,
 		Group:       environschema.EnvironGroup,
 	},
 	LogForwardEnabled: {
-		Description: `Whether syslog forwarding is enabled.`,
+		Description: `Whether log forwarding is enabled.`,
 		Type:        environschema.Tbool,
 		Group:       environschema.EnvironGroup,
 	},
+	LogFwdProtocol: {
+		Description: `The protocol used to forward logs to the server configured by syslog-host (default syslog).`,
+		Type:        environschema.Tstring,
+		Values:      []interface{}{syslog.ProtocolSyslog, syslog.ProtocolJSON, syslog.ProtocolGELFUDP, syslog.ProtocolGELFTCP, syslog.ProtocolElasticsearch},
+		Group:       environschema.EnvironGroup,
+	},
 	LogFwdSyslogHost: {
-		Description: `The hostname:port of the syslog server.`,
+		Description: `The hostname:port of the syslog server, or of the log forwarding server for other protocols.`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
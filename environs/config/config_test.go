@@ -520,12 +520,59 @@ var configTests = []configTest{
 			"syslog-client-key":  serverKey2,
 		}),
 		err: `invalid syslog forwarding config: validating TLS config: parsing client key pair: (crypto/)?tls: private key does not match public key`,
+	}, {
+		about:       "Invalid log forwarding protocol",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-enabled":  true,
+			"logforward-protocol": "bogus",
+			"syslog-host":         "10.0.0.1:12345",
+		}),
+		err: `logforward-protocol: .*"bogus".*`,
+	}, {
+		about:       "GELF over UDP with TLS",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-enabled":  true,
+			"logforward-protocol": "gelf-udp",
+			"syslog-host":         "10.0.0.1:12201",
+			"syslog-ca-cert":      testing.CACert,
+		}),
+		err: `invalid syslog forwarding config: TLS with protocol "gelf-udp" not supported`,
 	}, {
 		about:       "net-bond-reconfigure-delay value",
 		useDefaults: config.UseDefaults,
 		attrs: minimalConfigAttrs.Merge(testing.Attrs{
 			config.NetBondReconfigureDelayKey: 1234,
 		}),
+	}, {
+		about:       "status history retention overrides",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			config.MaxStatusHistoryAgeKey:  "72h",
+			config.MaxStatusHistorySizeKey: "1G",
+		}),
+	}, {
+		about:       "invalid max-status-history-age",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			config.MaxStatusHistoryAgeKey: "a while",
+		}),
+		err: `invalid max-status-history-age in model configuration: time: invalid duration .*`,
+	}, {
+		about:       "negative max-status-history-age",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			config.MaxStatusHistoryAgeKey: "-1h",
+		}),
+		err: `invalid max-status-history-age in model configuration: negative duration "-1h"`,
+	}, {
+		about:       "invalid max-status-history-size",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			config.MaxStatusHistorySizeKey: "lots",
+		}),
+		err: `invalid max-status-history-size in model configuration: .*`,
 	}, {
 		about:       "transmit-vendor-metrics asserted with default value",
 		useDefaults: config.UseDefaults,
@@ -550,6 +597,14 @@ var configTests = []configTest{
 			"syslog-client-cert": testing.ServerCert,
 			"syslog-client-key":  testing.ServerKey,
 		}),
+	}, {
+		about:       "Valid JSON log forwarding config without TLS",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-enabled":  true,
+			"logforward-protocol": "json",
+			"syslog-host":         "localhost:5170",
+		}),
 	},
 }
 
@@ -630,6 +685,10 @@ func (test configTest) check(c *gc.C, home *gitjujutesting.FakeHome) {
 		c.Assert(hasLogCfg, jc.IsTrue)
 		c.Assert(lfCfg.Enabled, gc.Equals, v)
 	}
+	if v, ok := test.attrs["logforward-protocol"].(string); ok {
+		c.Assert(hasLogCfg, jc.IsTrue)
+		c.Assert(lfCfg.Protocol, gc.Equals, v)
+	}
 	if v, ok := test.attrs["syslog-ca-cert"].(string); v != "" {
 		c.Assert(hasLogCfg, jc.IsTrue)
 		c.Assert(lfCfg.CACert, gc.Equals, v)
@@ -730,6 +789,14 @@ func (test configTest) check(c *gc.C, home *gitjujutesting.FakeHome) {
 	if val, ok := test.attrs[config.NetBondReconfigureDelayKey].(int); ok {
 		c.Assert(cfg.NetBondReconfigureDelay(), gc.Equals, val)
 	}
+
+	if _, ok := test.attrs[config.MaxStatusHistoryAgeKey]; ok {
+		c.Assert(cfg.MaxStatusHistoryAge(), gc.Equals, 72*time.Hour)
+		c.Assert(cfg.MaxStatusHistorySizeMB(), gc.Equals, uint(1024))
+	} else {
+		c.Assert(cfg.MaxStatusHistoryAge(), gc.Equals, time.Duration(0))
+		c.Assert(cfg.MaxStatusHistorySizeMB(), gc.Equals, uint(0))
+	}
 }
 
 func (test configTest) assertDuration(c *gc.C, name string, actual time.Duration, defaultInSeconds int) {
@@ -379,6 +379,33 @@ type Firewaller interface {
 	IngressRules() ([]network.IngressRule, error)
 }
 
+// EgressFirewaller is implemented by environs that can restrict the
+// outgoing traffic of the machines in the model to known destinations.
+//
+// Outgoing traffic is not restricted until an egress rule is opened.
+// Once any egress rule is open, outgoing traffic that matches no open
+// egress rule is dropped; when the last egress rule is closed, outgoing
+// traffic is no longer restricted.
+type EgressFirewaller interface {
+	// OpenEgressRules allows outgoing traffic matching the given rules
+	// for the whole environment. Must only be used if the environment
+	// was setup with the FwGlobal firewall mode.
+	OpenEgressRules(rules []network.EgressRule) error
+
+	// CloseEgressRules removes the given egress rules from the whole
+	// environment. Must only be used if the environment was setup with
+	// the FwGlobal firewall mode.
+	CloseEgressRules(rules []network.EgressRule) error
+
+	// EgressRules returns the egress rules applied to the whole
+	// environment. Must only be used if the environment was setup with
+	// the FwGlobal firewall mode. It is expected that there be only one
+	// egress rule result for a given port range - the rule's
+	// DestinationCIDRs will contain all applicable destinations for
+	// that port range.
+	EgressRules() ([]network.EgressRule, error)
+}
+
 // InstanceTagger is an interface that can be used for tagging instances.
 type InstanceTagger interface {
 	// TagInstance tags the given instance with the specified tags.
@@ -57,6 +57,28 @@ type Instance interface {
 	IngressRules(machineId string) ([]network.IngressRule, error)
 }
 
+// EgressFirewaller is implemented by instances that can restrict their
+// outgoing traffic to known destinations. Outgoing traffic is not
+// restricted until an egress rule is opened; see environs.EgressFirewaller.
+type EgressFirewaller interface {
+	// OpenEgressRules allows outgoing traffic matching the given rules
+	// from the instance, which should have been started with the given
+	// machine id.
+	OpenEgressRules(machineId string, rules []network.EgressRule) error
+
+	// CloseEgressRules removes the given egress rules from the instance,
+	// which should have been started with the given machine id.
+	CloseEgressRules(machineId string, rules []network.EgressRule) error
+
+	// EgressRules returns the set of egress rules for the instance,
+	// which should have been applied to the given machine id. The rules
+	// are returned as sorted by network.SortEgressRules(). It is
+	// expected that there be only one egress rule result for a given
+	// port range - the rule's DestinationCIDRs will contain all
+	// applicable destinations for that port range.
+	EgressRules(machineId string) ([]network.EgressRule, error)
+}
+
 // HardwareCharacteristics represents the characteristics of the instance (if known).
 // Attributes that are nil are unknown or not supported.
 type HardwareCharacteristics struct {
@@ -12,6 +12,7 @@ import (
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6-unstable"
 	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/instance"
@@ -36,6 +37,9 @@ type DeployApplicationParams struct {
 	EndpointBindings map[string]string
 	// Resources is a map of resource name to IDs of pending resources.
 	Resources map[string]string
+	// AttachStorage contains a list of storage instances to attach
+	// to the unit that is deployed.
+	AttachStorage []names.StorageTag
 }
 
 type ApplicationDeployer interface {
@@ -79,6 +83,7 @@ func DeployApplication(st ApplicationDeployer, args DeployApplicationParams) (*s
 		Charm:            args.Charm,
 		Channel:          args.Channel,
 		Storage:          stateStorageConstraints(args.Storage),
+		AttachStorage:    args.AttachStorage,
 		Settings:         settings,
 		NumUnits:         args.NumUnits,
 		Placement:        args.Placement,
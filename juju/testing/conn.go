@@ -484,6 +484,7 @@ func newState(controllerUUID string, environ environs.Environ, mongoInfo *mongo.
 		MongoInfo:          mongoInfo,
 		MongoDialOpts:      opts,
 		NewPolicy:          newPolicyFunc,
+		SecretsKey:         testing.SecretsKey,
 	}
 	st, err := state.Open(args)
 	if errors.IsUnauthorized(errors.Cause(err)) {
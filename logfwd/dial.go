@@ -0,0 +1,43 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logfwd
+
+import (
+	"crypto/tls"
+	"net"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// DialTimeout is the maximum time taken to connect
+// to a log forwarding target.
+const DialTimeout = 30 * time.Second
+
+// HostPort returns the given host with the default port
+// added if the host does not already specify one.
+func HostPort(host, defaultPort string) string {
+	if _, _, err := net.SplitHostPort(host); err == nil {
+		return host
+	}
+	return net.JoinHostPort(host, defaultPort)
+}
+
+// DialTCP opens a TCP connection to the given address. The connection
+// uses TLS if tlsConfig is not nil.
+func DialTCP(addr string, tlsConfig *tls.Config) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: DialTimeout}
+	if tlsConfig != nil {
+		conn, err := tls.DialWithDialer(dialer, "tcp", addr, tlsConfig)
+		if err != nil {
+			return nil, errors.Annotatef(err, "connecting to %s", addr)
+		}
+		return conn, nil
+	}
+	conn, err := dialer.Dial("tcp", addr)
+	if err != nil {
+		return nil, errors.Annotatef(err, "connecting to %s", addr)
+	}
+	return conn, nil
+}
@@ -0,0 +1,24 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logfwd_test
+
+import (
+	"github.com/juju/testing"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd"
+)
+
+type DialSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&DialSuite{})
+
+func (s *DialSuite) TestHostPort(c *gc.C) {
+	c.Check(logfwd.HostPort("a.b.c", "514"), gc.Equals, "a.b.c:514")
+	c.Check(logfwd.HostPort("a.b.c:9876", "514"), gc.Equals, "a.b.c:9876")
+	c.Check(logfwd.HostPort("10.0.0.1", "514"), gc.Equals, "10.0.0.1:514")
+	c.Check(logfwd.HostPort("::1", "514"), gc.Equals, "[::1]:514")
+}
@@ -3,6 +3,6 @@
 
 // The logfwd package contains the tools needed to do log record
 // forwarding in juju. The common code sits at the top level. The
-// different forwarding targets (e.g. syslog) are provided through
+// different forwarding targets (e.g. syslog, GELF) are provided through
 // sub-packages.
 package logfwd
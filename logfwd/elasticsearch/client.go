@@ -0,0 +1,158 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package elasticsearch
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/ndjson"
+	"github.com/juju/juju/logfwd/syslog"
+)
+
+const (
+	// DefaultPort is the port used when the configured
+	// host does not specify one.
+	DefaultPort = "9200"
+
+	// Index is the index that log records are added to.
+	Index = "juju"
+
+	// DocumentType is the type given to the indexed log records.
+	DocumentType = "log"
+
+	requestTimeout = time.Minute
+)
+
+// Client sends log records to an Elasticsearch server.
+type Client struct {
+	url       string
+	transport *http.Transport
+	client    *http.Client
+}
+
+// Open returns a client that sends log records to the server in the
+// given config. HTTPS is used if any certificates are configured.
+func Open(cfg syslog.RawConfig) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	tlsCfg, err := cfg.TLSConfig()
+	if err != nil {
+		return nil, errors.Annotate(err, "constructing TLS config")
+	}
+	scheme := "http"
+	if tlsCfg != nil {
+		scheme = "https"
+	}
+	transport := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		Dial: (&net.Dialer{
+			Timeout: logfwd.DialTimeout,
+		}).Dial,
+		TLSClientConfig: tlsCfg,
+	}
+	return &Client{
+		url:       fmt.Sprintf("%s://%s/_bulk", scheme, logfwd.HostPort(cfg.Host, DefaultPort)),
+		transport: transport,
+		client: &http.Client{
+			Timeout:   requestTimeout,
+			Transport: transport,
+		},
+	}, nil
+}
+
+type bulkAction struct {
+	Index bulkIndex `json:"index"`
+}
+
+type bulkIndex struct {
+	Index string `json:"_index"`
+	Type  string `json:"_type"`
+	ID    string `json:"_id"`
+}
+
+type bulkResponse struct {
+	Errors bool `json:"errors"`
+	Items  []struct {
+		Index struct {
+			ID     string     `json:"_id"`
+			Status int        `json:"status"`
+			Error  *bulkError `json:"error"`
+		} `json:"index"`
+	} `json:"items"`
+}
+
+type bulkError struct {
+	Type   string `json:"type"`
+	Reason string `json:"reason"`
+}
+
+// Send indexes the records with a single bulk request. Each document
+// is given an ID derived from its model and record ID, so that records
+// sent again after a restart replace those already indexed.
+func (client *Client) Send(records []logfwd.Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+	var body bytes.Buffer
+	enc := json.NewEncoder(&body)
+	for _, rec := range records {
+		action := bulkAction{bulkIndex{
+			Index: Index,
+			Type:  DocumentType,
+			ID:    fmt.Sprintf("%s:%d", rec.Origin.ModelUUID, rec.ID),
+		}}
+		if err := enc.Encode(action); err != nil {
+			return errors.Trace(err)
+		}
+		if err := enc.Encode(ndjson.NewDocument(rec)); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	req, err := http.NewRequest("POST", client.url, &body)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	resp, err := client.client.Do(req)
+	if err != nil {
+		return errors.Annotate(err, "sending bulk request")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		return errors.Errorf("bulk request failed: %s: %s", resp.Status, bytes.TrimSpace(msg))
+	}
+
+	var result bulkResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return errors.Annotate(err, "decoding bulk response")
+	}
+	if !result.Errors {
+		return nil
+	}
+	for _, item := range result.Items {
+		if e := item.Index.Error; e != nil {
+			return errors.Errorf("indexing record %s: %s: %s", item.Index.ID, e.Type, e.Reason)
+		}
+	}
+	return errors.New("bulk request failed")
+}
+
+// Close closes any idle connections to the server.
+func (client *Client) Close() error {
+	client.transport.CloseIdleConnections()
+	return nil
+}
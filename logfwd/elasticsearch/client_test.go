@@ -0,0 +1,140 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package elasticsearch_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/elasticsearch"
+	"github.com/juju/juju/logfwd/syslog"
+)
+
+type ClientSuite struct {
+	testing.IsolationSuite
+	server   *httptest.Server
+	requests []*http.Request
+	lines    [][]string
+	response string
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+func (s *ClientSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.requests = nil
+	s.lines = nil
+	s.response = `{"took":1,"errors":false,"items":[]}`
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		s.requests = append(s.requests, req)
+		var lines []string
+		scanner := bufio.NewScanner(req.Body)
+		for scanner.Scan() {
+			lines = append(lines, scanner.Text())
+		}
+		s.lines = append(s.lines, lines)
+		fmt.Fprint(w, s.response)
+	}))
+	s.AddCleanup(func(*gc.C) { s.server.Close() })
+}
+
+func (s *ClientSuite) open(c *gc.C) *elasticsearch.Client {
+	client, err := elasticsearch.Open(syslog.RawConfig{
+		Enabled:  true,
+		Protocol: syslog.ProtocolElasticsearch,
+		Host:     strings.TrimPrefix(s.server.URL, "http://"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) { client.Close() })
+	return client
+}
+
+func record(id int64, message string) logfwd.Record {
+	return logfwd.Record{
+		ID: id,
+		Origin: logfwd.Origin{
+			ControllerUUID: "9f484882-2f18-4fd2-967d-db9663db7bea",
+			ModelUUID:      "deadbeef-2f18-4fd2-967d-db9663db7bea",
+			Hostname:       "machine-0.deadbeef-2f18-4fd2-967d-db9663db7bea",
+		},
+		Timestamp: time.Date(2017, 5, 1, 12, 0, 0, 0, time.UTC),
+		Level:     loggo.INFO,
+		Message:   message,
+	}
+}
+
+func decode(c *gc.C, line string) map[string]interface{} {
+	var out map[string]interface{}
+	err := json.Unmarshal([]byte(line), &out)
+	c.Assert(err, jc.ErrorIsNil)
+	return out
+}
+
+func (s *ClientSuite) TestSend(c *gc.C) {
+	client := s.open(c)
+	err := client.Send([]logfwd.Record{record(10, "hello"), record(11, "world")})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.requests, gc.HasLen, 1)
+	req := s.requests[0]
+	c.Check(req.Method, gc.Equals, "POST")
+	c.Check(req.URL.Path, gc.Equals, "/_bulk")
+	c.Check(req.Header.Get("Content-Type"), gc.Equals, "application/x-ndjson")
+
+	lines := s.lines[0]
+	c.Assert(lines, gc.HasLen, 4)
+	c.Check(decode(c, lines[0]), jc.DeepEquals, map[string]interface{}{
+		"index": map[string]interface{}{
+			"_index": "juju",
+			"_type":  "log",
+			"_id":    "deadbeef-2f18-4fd2-967d-db9663db7bea:10",
+		},
+	})
+	doc := decode(c, lines[1])
+	c.Check(doc["id"], gc.Equals, float64(10))
+	c.Check(doc["level"], gc.Equals, "info")
+	c.Check(doc["message"], gc.Equals, "hello")
+	c.Check(doc["model-uuid"], gc.Equals, "deadbeef-2f18-4fd2-967d-db9663db7bea")
+	c.Check(decode(c, lines[2])["index"].(map[string]interface{})["_id"], gc.Equals, "deadbeef-2f18-4fd2-967d-db9663db7bea:11")
+	c.Check(decode(c, lines[3])["message"], gc.Equals, "world")
+}
+
+func (s *ClientSuite) TestSendNoRecords(c *gc.C) {
+	client := s.open(c)
+	err := client.Send(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.requests, gc.HasLen, 0)
+}
+
+func (s *ClientSuite) TestSendItemErrors(c *gc.C) {
+	s.response = `{"took":1,"errors":true,"items":[
+		{"index":{"_id":"deadbeef-2f18-4fd2-967d-db9663db7bea:10","status":201}},
+		{"index":{"_id":"deadbeef-2f18-4fd2-967d-db9663db7bea:11","status":400,
+			"error":{"type":"mapper_parsing_exception","reason":"failed to parse"}}}
+	]}`
+	client := s.open(c)
+	err := client.Send([]logfwd.Record{record(10, "hello"), record(11, "world")})
+	c.Assert(err, gc.ErrorMatches,
+		`indexing record deadbeef-2f18-4fd2-967d-db9663db7bea:11: mapper_parsing_exception: failed to parse`)
+}
+
+func (s *ClientSuite) TestSendHTTPError(c *gc.C) {
+	s.server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		http.Error(w, "go away", http.StatusServiceUnavailable)
+	})
+	client := s.open(c)
+	err := client.Send([]logfwd.Record{record(10, "hello")})
+	c.Assert(err, gc.ErrorMatches, `bulk request failed: 503 Service Unavailable: go away`)
+}
@@ -0,0 +1,7 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// The elasticsearch package holds the tools needed to perform log
+// forwarding from Juju to an Elasticsearch server, using its _bulk
+// API over HTTP or HTTPS.
+package elasticsearch
@@ -0,0 +1,147 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package gelf
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"io"
+	"net"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/syslog"
+)
+
+// DefaultPort is the port used when the configured
+// host does not specify one.
+const DefaultPort = "12201"
+
+const (
+	// maxDatagramSize is the largest UDP datagram that will be sent;
+	// larger messages are split into chunks.
+	maxDatagramSize = 8192
+
+	// chunkHeaderSize is the size of the header prepended to each
+	// chunk: two magic bytes, an 8-byte message ID, the sequence
+	// number and the sequence count.
+	chunkHeaderSize = 12
+
+	// maxChunks is the maximum number of chunks a message
+	// may be split into.
+	maxChunks = 128
+)
+
+// Client sends log records to a remote host as GELF messages.
+type Client struct {
+	conn net.Conn
+	udp  bool
+}
+
+// Open connects to the host in the given config and wraps that
+// connection in a new client. The protocol in the config determines
+// whether UDP or TCP is used; TCP connections use TLS if any
+// certificates are configured.
+func Open(cfg syslog.RawConfig) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	addr := logfwd.HostPort(cfg.Host, DefaultPort)
+	switch protocol := cfg.ProtocolOrDefault(); protocol {
+	case syslog.ProtocolGELFUDP:
+		conn, err := net.DialTimeout("udp", addr, logfwd.DialTimeout)
+		if err != nil {
+			return nil, errors.Annotatef(err, "connecting to %s", addr)
+		}
+		return &Client{conn: conn, udp: true}, nil
+	case syslog.ProtocolGELFTCP:
+		tlsCfg, err := cfg.TLSConfig()
+		if err != nil {
+			return nil, errors.Annotate(err, "constructing TLS config")
+		}
+		conn, err := logfwd.DialTCP(addr, tlsCfg)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return &Client{conn: conn}, nil
+	default:
+		return nil, errors.NotValidf("GELF protocol %q", protocol)
+	}
+}
+
+// Send sends the records to the remote host.
+func (client *Client) Send(records []logfwd.Record) error {
+	for _, rec := range records {
+		msg, err := NewMessage(rec)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		data, err := json.Marshal(msg)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if client.udp {
+			err = client.sendDatagrams(data)
+		} else {
+			// Messages sent over TCP are delimited by a null byte.
+			_, err = client.conn.Write(append(data, 0))
+		}
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// sendDatagrams sends the message data in a single datagram if it
+// fits, or as a sequence of GELF chunks otherwise.
+func (client *Client) sendDatagrams(data []byte) error {
+	if len(data) <= maxDatagramSize {
+		_, err := client.conn.Write(data)
+		return errors.Trace(err)
+	}
+	chunks, err := chunk(data)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, c := range chunks {
+		if _, err := client.conn.Write(c); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// chunk splits the message data into GELF chunks.
+func chunk(data []byte) ([][]byte, error) {
+	const payloadSize = maxDatagramSize - chunkHeaderSize
+	count := (len(data) + payloadSize - 1) / payloadSize
+	if count > maxChunks {
+		return nil, errors.Errorf("message too large (%d bytes)", len(data))
+	}
+	var id [8]byte
+	if _, err := io.ReadFull(rand.Reader, id[:]); err != nil {
+		return nil, errors.Annotate(err, "generating message ID")
+	}
+	chunks := make([][]byte, 0, count)
+	for i := 0; i < count; i++ {
+		end := (i + 1) * payloadSize
+		if end > len(data) {
+			end = len(data)
+		}
+		c := make([]byte, 0, chunkHeaderSize+end-i*payloadSize)
+		c = append(c, 0x1e, 0x0f)
+		c = append(c, id[:]...)
+		c = append(c, byte(i), byte(count))
+		c = append(c, data[i*payloadSize:end]...)
+		chunks = append(chunks, c)
+	}
+	return chunks, nil
+}
+
+// Close closes the client's connection.
+func (client *Client) Close() error {
+	return errors.Trace(client.conn.Close())
+}
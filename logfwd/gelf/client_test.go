@@ -0,0 +1,224 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package gelf_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/gelf"
+	"github.com/juju/juju/logfwd/syslog"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ClientSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+var testRecord = logfwd.Record{
+	ID: 10,
+	Origin: logfwd.Origin{
+		ControllerUUID: "9f484882-2f18-4fd2-967d-db9663db7bea",
+		ModelUUID:      "deadbeef-2f18-4fd2-967d-db9663db7bea",
+		Hostname:       "machine-0.deadbeef-2f18-4fd2-967d-db9663db7bea",
+		Type:           logfwd.OriginTypeMachine,
+		Name:           "0",
+		Software: logfwd.Software{
+			PrivateEnterpriseNumber: 28978,
+			Name:                    "jujud-machine-agent",
+			Version:                 version.MustParse("2.0.1"),
+		},
+	},
+	Timestamp: time.Date(2017, 5, 1, 12, 0, 0, 500000000, time.UTC),
+	Level:     loggo.ERROR,
+	Location: logfwd.SourceLocation{
+		Module:   "juju.worker.uniter",
+		Filename: "uniter.go",
+		Line:     42,
+	},
+	Message: "something went wrong",
+}
+
+var expectedMessage = map[string]interface{}{
+	"version":           "1.1",
+	"host":              "machine-0.deadbeef-2f18-4fd2-967d-db9663db7bea",
+	"short_message":     "something went wrong",
+	"timestamp":         1493640000.5,
+	"level":             float64(3),
+	"_record_id":        float64(10),
+	"_controller_uuid":  "9f484882-2f18-4fd2-967d-db9663db7bea",
+	"_model_uuid":       "deadbeef-2f18-4fd2-967d-db9663db7bea",
+	"_module":           "juju.worker.uniter",
+	"_source":           "uniter.go:42",
+	"_origin":           "0",
+	"_software_name":    "jujud-machine-agent",
+	"_software_version": "2.0.1",
+}
+
+func decode(c *gc.C, data []byte) map[string]interface{} {
+	var msg map[string]interface{}
+	err := json.Unmarshal(data, &msg)
+	c.Assert(err, jc.ErrorIsNil)
+	return msg
+}
+
+func (s *ClientSuite) TestNewMessageLevels(c *gc.C) {
+	for level, expect := range map[loggo.Level]int{
+		loggo.CRITICAL: 2,
+		loggo.ERROR:    3,
+		loggo.WARNING:  4,
+		loggo.INFO:     6,
+		loggo.DEBUG:    7,
+		loggo.TRACE:    7,
+	} {
+		rec := testRecord
+		rec.Level = level
+		msg, err := gelf.NewMessage(rec)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(msg.Level, gc.Equals, expect, gc.Commentf("level %v", level))
+	}
+
+	rec := testRecord
+	rec.Level = loggo.UNSPECIFIED
+	_, err := gelf.NewMessage(rec)
+	c.Check(err, gc.ErrorMatches, `unsupported log level .*`)
+}
+
+// listenUDP starts a UDP listener, and returns its address and
+// a channel on which the datagrams it receives are sent.
+func (s *ClientSuite) listenUDP(c *gc.C) (string, <-chan []byte) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) { conn.Close() })
+
+	datagrams := make(chan []byte, 10)
+	go func() {
+		for {
+			buf := make([]byte, 65536)
+			n, _, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			datagrams <- buf[:n]
+		}
+	}()
+	return conn.LocalAddr().String(), datagrams
+}
+
+func nextMessage(c *gc.C, datagrams <-chan []byte) []byte {
+	select {
+	case data := <-datagrams:
+		return data
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for message")
+	}
+	panic("unreachable")
+}
+
+func (s *ClientSuite) TestSendUDP(c *gc.C) {
+	addr, datagrams := s.listenUDP(c)
+	client, err := gelf.Open(syslog.RawConfig{
+		Enabled:  true,
+		Protocol: syslog.ProtocolGELFUDP,
+		Host:     addr,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer client.Close()
+
+	err = client.Send([]logfwd.Record{testRecord})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(decode(c, nextMessage(c, datagrams)), jc.DeepEquals, expectedMessage)
+}
+
+func (s *ClientSuite) TestSendUDPChunked(c *gc.C) {
+	addr, datagrams := s.listenUDP(c)
+	client, err := gelf.Open(syslog.RawConfig{
+		Enabled:  true,
+		Protocol: syslog.ProtocolGELFUDP,
+		Host:     addr,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer client.Close()
+
+	rec := testRecord
+	rec.Message = strings.Repeat("x", 20000)
+	err = client.Send([]logfwd.Record{rec})
+	c.Assert(err, jc.ErrorIsNil)
+
+	var id []byte
+	var data []byte
+	for i := 0; i < 3; i++ {
+		chunk := nextMessage(c, datagrams)
+		c.Assert(len(chunk) <= 8192, jc.IsTrue)
+		c.Assert(chunk[:2], jc.DeepEquals, []byte{0x1e, 0x0f})
+		if id == nil {
+			id = chunk[2:10]
+		}
+		c.Assert(chunk[2:10], jc.DeepEquals, id)
+		c.Assert(chunk[10], gc.Equals, byte(i))
+		c.Assert(chunk[11], gc.Equals, byte(3))
+		data = append(data, chunk[12:]...)
+	}
+	c.Check(decode(c, data)["short_message"], gc.Equals, rec.Message)
+}
+
+func (s *ClientSuite) TestSendTCP(c *gc.C) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, jc.ErrorIsNil)
+	defer listener.Close()
+
+	messages := make(chan []byte, 10)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		for {
+			data, err := r.ReadBytes(0)
+			if err != nil {
+				return
+			}
+			messages <- data[:len(data)-1]
+		}
+	}()
+
+	client, err := gelf.Open(syslog.RawConfig{
+		Enabled:  true,
+		Protocol: syslog.ProtocolGELFTCP,
+		Host:     listener.Addr().String(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer client.Close()
+
+	second := testRecord
+	second.ID = 11
+	err = client.Send([]logfwd.Record{testRecord, second})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(decode(c, nextMessage(c, messages)), jc.DeepEquals, expectedMessage)
+	c.Check(decode(c, nextMessage(c, messages))["_record_id"], gc.Equals, float64(11))
+}
+
+func (s *ClientSuite) TestOpenWrongProtocol(c *gc.C) {
+	_, err := gelf.Open(syslog.RawConfig{
+		Enabled:  true,
+		Protocol: syslog.ProtocolJSON,
+		Host:     "a.b.c",
+	})
+	c.Assert(err, gc.ErrorMatches, `GELF protocol "json" not valid`)
+}
@@ -0,0 +1,88 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package gelf
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/logfwd"
+)
+
+// Message is a GELF 1.1 message. Additional fields are
+// prefixed with an underscore, as the format requires.
+type Message struct {
+	Version         string  `json:"version"`
+	Host            string  `json:"host"`
+	ShortMessage    string  `json:"short_message"`
+	Timestamp       float64 `json:"timestamp"`
+	Level           int     `json:"level"`
+	RecordID        int64   `json:"_record_id"`
+	ControllerUUID  string  `json:"_controller_uuid"`
+	ModelUUID       string  `json:"_model_uuid"`
+	Module          string  `json:"_module,omitempty"`
+	Source          string  `json:"_source,omitempty"`
+	Origin          string  `json:"_origin,omitempty"`
+	SoftwareName    string  `json:"_software_name,omitempty"`
+	SoftwareVersion string  `json:"_software_version,omitempty"`
+}
+
+// The syslog severities used for the GELF level field.
+const (
+	levelCritical      = 2
+	levelError         = 3
+	levelWarning       = 4
+	levelInformational = 6
+	levelDebug         = 7
+)
+
+// NewMessage returns the GELF message for the given record.
+func NewMessage(rec logfwd.Record) (Message, error) {
+	msg := Message{
+		Version:        "1.1",
+		Host:           rec.Origin.Hostname,
+		ShortMessage:   rec.Message,
+		Timestamp:      float64(rec.Timestamp.UnixNano()) / 1e9,
+		RecordID:       rec.ID,
+		ControllerUUID: rec.Origin.ControllerUUID,
+		ModelUUID:      rec.Origin.ModelUUID,
+		Module:         rec.Location.Module,
+		Origin:         rec.Origin.Name,
+		SoftwareName:   rec.Origin.Software.Name,
+	}
+	if msg.Host == "" {
+		msg.Host = rec.Origin.Name
+	}
+	if msg.ShortMessage == "" {
+		// GELF requires a non-empty short message.
+		msg.ShortMessage = "-"
+	}
+	if rec.Location.Filename != "" {
+		msg.Source = rec.Location.Filename
+		if rec.Location.Line > 0 {
+			msg.Source = fmt.Sprintf("%s:%d", rec.Location.Filename, rec.Location.Line)
+		}
+	}
+	if msg.SoftwareName != "" {
+		msg.SoftwareVersion = rec.Origin.Software.Version.String()
+	}
+
+	switch rec.Level {
+	case loggo.CRITICAL:
+		msg.Level = levelCritical
+	case loggo.ERROR:
+		msg.Level = levelError
+	case loggo.WARNING:
+		msg.Level = levelWarning
+	case loggo.INFO:
+		msg.Level = levelInformational
+	case loggo.DEBUG, loggo.TRACE:
+		msg.Level = levelDebug
+	default:
+		return msg, errors.Errorf("unsupported log level %q", rec.Level)
+	}
+	return msg, nil
+}
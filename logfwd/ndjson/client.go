@@ -0,0 +1,66 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ndjson
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/syslog"
+)
+
+// DefaultPort is the port used when the configured
+// host does not specify one.
+const DefaultPort = "5170"
+
+// Client sends log records to a remote host as newline-delimited
+// JSON documents.
+type Client struct {
+	conn net.Conn
+	w    *bufio.Writer
+	enc  *json.Encoder
+}
+
+// Open connects to the host in the given config and wraps that
+// connection in a new client. The connection uses TLS if any
+// certificates are configured.
+func Open(cfg syslog.RawConfig) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	tlsCfg, err := cfg.TLSConfig()
+	if err != nil {
+		return nil, errors.Annotate(err, "constructing TLS config")
+	}
+	conn, err := logfwd.DialTCP(logfwd.HostPort(cfg.Host, DefaultPort), tlsCfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := bufio.NewWriter(conn)
+	return &Client{
+		conn: conn,
+		w:    w,
+		enc:  json.NewEncoder(w),
+	}, nil
+}
+
+// Send sends the records to the remote host, one document per line.
+func (client *Client) Send(records []logfwd.Record) error {
+	for _, rec := range records {
+		// Encode terminates each document with a newline.
+		if err := client.enc.Encode(NewDocument(rec)); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return errors.Trace(client.w.Flush())
+}
+
+// Close closes the client's connection.
+func (client *Client) Close() error {
+	return errors.Trace(client.conn.Close())
+}
@@ -0,0 +1,143 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ndjson_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"time"
+
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/ndjson"
+	"github.com/juju/juju/logfwd/syslog"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ClientSuite struct {
+	testing.IsolationSuite
+	listener net.Listener
+	lines    chan string
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+func (s *ClientSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, jc.ErrorIsNil)
+	s.listener = listener
+	s.AddCleanup(func(*gc.C) { listener.Close() })
+
+	s.lines = make(chan string, 10)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		scanner := bufio.NewScanner(conn)
+		for scanner.Scan() {
+			s.lines <- scanner.Text()
+		}
+	}()
+}
+
+func (s *ClientSuite) nextDocument(c *gc.C) map[string]interface{} {
+	select {
+	case line := <-s.lines:
+		var doc map[string]interface{}
+		err := json.Unmarshal([]byte(line), &doc)
+		c.Assert(err, jc.ErrorIsNil)
+		return doc
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for document")
+	}
+	panic("unreachable")
+}
+
+var testRecord = logfwd.Record{
+	ID: 10,
+	Origin: logfwd.Origin{
+		ControllerUUID: "9f484882-2f18-4fd2-967d-db9663db7bea",
+		ModelUUID:      "deadbeef-2f18-4fd2-967d-db9663db7bea",
+		Hostname:       "machine-0.deadbeef-2f18-4fd2-967d-db9663db7bea",
+		Type:           logfwd.OriginTypeMachine,
+		Name:           "0",
+		Software: logfwd.Software{
+			PrivateEnterpriseNumber: 28978,
+			Name:                    "jujud-machine-agent",
+			Version:                 version.MustParse("2.0.1"),
+		},
+	},
+	Timestamp: time.Date(2017, 5, 1, 12, 0, 0, 0, time.UTC),
+	Level:     loggo.ERROR,
+	Location: logfwd.SourceLocation{
+		Module:   "juju.worker.uniter",
+		Filename: "uniter.go",
+		Line:     42,
+	},
+	Message: "something went wrong",
+}
+
+func (s *ClientSuite) TestSend(c *gc.C) {
+	client, err := ndjson.Open(syslog.RawConfig{
+		Enabled:  true,
+		Protocol: syslog.ProtocolJSON,
+		Host:     s.listener.Addr().String(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer client.Close()
+
+	second := testRecord
+	second.ID = 11
+	second.Level = loggo.INFO
+	second.Message = "all better"
+	err = client.Send([]logfwd.Record{testRecord, second})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.nextDocument(c), jc.DeepEquals, map[string]interface{}{
+		"id":               float64(10),
+		"timestamp":        "2017-05-01T12:00:00Z",
+		"level":            "error",
+		"module":           "juju.worker.uniter",
+		"source":           "uniter.go:42",
+		"message":          "something went wrong",
+		"controller-uuid":  "9f484882-2f18-4fd2-967d-db9663db7bea",
+		"model-uuid":       "deadbeef-2f18-4fd2-967d-db9663db7bea",
+		"hostname":         "machine-0.deadbeef-2f18-4fd2-967d-db9663db7bea",
+		"origin":           "0",
+		"software-name":    "jujud-machine-agent",
+		"software-version": "2.0.1",
+	})
+	doc := s.nextDocument(c)
+	c.Check(doc["id"], gc.Equals, float64(11))
+	c.Check(doc["level"], gc.Equals, "info")
+	c.Check(doc["message"], gc.Equals, "all better")
+}
+
+func (s *ClientSuite) TestOpenInvalidConfig(c *gc.C) {
+	_, err := ndjson.Open(syslog.RawConfig{
+		Enabled:  true,
+		Protocol: syslog.ProtocolJSON,
+	})
+	c.Assert(err, gc.ErrorMatches, `Host "" not valid`)
+}
+
+func (s *ClientSuite) TestOpenConnectionRefused(c *gc.C) {
+	addr := s.listener.Addr().String()
+	s.listener.Close()
+	_, err := ndjson.Open(syslog.RawConfig{
+		Enabled:  true,
+		Protocol: syslog.ProtocolJSON,
+		Host:     addr,
+	})
+	c.Assert(err, gc.ErrorMatches, "connecting to "+addr+": .*")
+}
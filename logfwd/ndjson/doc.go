@@ -0,0 +1,7 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// The ndjson package holds the tools needed to perform log forwarding
+// from Juju to a remote host that accepts newline-delimited JSON
+// documents over TCP or TLS.
+package ndjson
@@ -0,0 +1,54 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ndjson
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/juju/juju/logfwd"
+)
+
+// Document is the JSON representation of a log record.
+type Document struct {
+	ID              int64     `json:"id"`
+	Timestamp       time.Time `json:"timestamp"`
+	Level           string    `json:"level"`
+	Module          string    `json:"module,omitempty"`
+	Source          string    `json:"source,omitempty"`
+	Message         string    `json:"message"`
+	ControllerUUID  string    `json:"controller-uuid"`
+	ModelUUID       string    `json:"model-uuid"`
+	Hostname        string    `json:"hostname,omitempty"`
+	Origin          string    `json:"origin,omitempty"`
+	SoftwareName    string    `json:"software-name,omitempty"`
+	SoftwareVersion string    `json:"software-version,omitempty"`
+}
+
+// NewDocument returns the JSON document for the given record.
+func NewDocument(rec logfwd.Record) Document {
+	doc := Document{
+		ID:             rec.ID,
+		Timestamp:      rec.Timestamp.UTC(),
+		Level:          strings.ToLower(rec.Level.String()),
+		Module:         rec.Location.Module,
+		Message:        rec.Message,
+		ControllerUUID: rec.Origin.ControllerUUID,
+		ModelUUID:      rec.Origin.ModelUUID,
+		Hostname:       rec.Origin.Hostname,
+		Origin:         rec.Origin.Name,
+		SoftwareName:   rec.Origin.Software.Name,
+	}
+	if rec.Location.Filename != "" {
+		doc.Source = rec.Location.Filename
+		if rec.Location.Line > 0 {
+			doc.Source = fmt.Sprintf("%s:%d", rec.Location.Filename, rec.Location.Line)
+		}
+	}
+	if doc.SoftwareName != "" {
+		doc.SoftwareVersion = rec.Origin.Software.Version.String()
+	}
+	return doc
+}
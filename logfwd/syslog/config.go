@@ -12,18 +12,53 @@ import (
 	"github.com/juju/utils/cert"
 )
 
+// The protocols that may be used to forward log records.
+const (
+	// ProtocolSyslog forwards records as RFC 5424 messages over TLS.
+	ProtocolSyslog = "syslog"
+
+	// ProtocolJSON forwards records as newline-delimited JSON
+	// documents over TCP, or TLS if certificates are configured.
+	ProtocolJSON = "json"
+
+	// ProtocolGELFUDP forwards records as GELF messages over UDP.
+	ProtocolGELFUDP = "gelf-udp"
+
+	// ProtocolGELFTCP forwards records as GELF messages over TCP,
+	// or TLS if certificates are configured.
+	ProtocolGELFTCP = "gelf-tcp"
+
+	// ProtocolElasticsearch forwards records to the _bulk API of an
+	// Elasticsearch server over HTTP, or HTTPS if certificates are
+	// configured.
+	ProtocolElasticsearch = "elasticsearch"
+)
+
+// Protocols holds all of the supported forwarding protocols.
+var Protocols = []string{
+	ProtocolSyslog,
+	ProtocolJSON,
+	ProtocolGELFUDP,
+	ProtocolGELFTCP,
+	ProtocolElasticsearch,
+}
+
 // RawConfig holds the raw configuration data for a connection to a
-// syslog forwarding target.
+// log forwarding target.
 type RawConfig struct {
 	// Enabled is true if the log forwarding feature is enabled.
 	Enabled bool
 
+	// Protocol is the protocol used to forward records to the
+	// target. If empty, ProtocolSyslog is used.
+	Protocol string
+
 	// Host is the host-port of the syslog host. The format is:
 	//
 	//   [domain-or-ip-addr] or [domain-or-ip-addr][:port]
 	//
-	// If the port is not set then the default TLS port (6514) will
-	// be used.
+	// If the port is not set then the default port for the
+	// protocol will be used (6514 for syslog over TLS).
 	Host string
 
 	// CACert is the TLS CA certificate (x.509, PEM-encoded) to use
@@ -39,13 +74,26 @@ type RawConfig struct {
 	ClientKey string
 }
 
+// ProtocolOrDefault returns the configured protocol, or
+// ProtocolSyslog if none is set.
+func (cfg RawConfig) ProtocolOrDefault() string {
+	if cfg.Protocol == "" {
+		return ProtocolSyslog
+	}
+	return cfg.Protocol
+}
+
 // Validate ensures that the config is currently valid.
 func (cfg RawConfig) Validate() error {
+	if err := cfg.validateProtocol(); err != nil {
+		return errors.Trace(err)
+	}
+
 	if err := cfg.validateHost(); err != nil {
 		return errors.Trace(err)
 	}
 
-	if cfg.Enabled || cfg.ClientKey != "" || cfg.ClientCert != "" || cfg.CACert != "" {
+	if cfg.usesTLS() {
 		if _, err := cfg.tlsConfig(); err != nil {
 			return errors.Annotate(err, "validating TLS config")
 		}
@@ -53,6 +101,24 @@ func (cfg RawConfig) Validate() error {
 	return nil
 }
 
+func (cfg RawConfig) validateProtocol() error {
+	protocol := cfg.ProtocolOrDefault()
+	known := false
+	for _, p := range Protocols {
+		if protocol == p {
+			known = true
+			break
+		}
+	}
+	if !known {
+		return errors.NotValidf("Protocol %q", cfg.Protocol)
+	}
+	if protocol == ProtocolGELFUDP && cfg.hasTLSConfig() {
+		return errors.NotSupportedf("TLS with protocol %q", protocol)
+	}
+	return nil
+}
+
 func (cfg RawConfig) validateHost() error {
 	host, _, err := net.SplitHostPort(cfg.Host)
 	if err != nil {
@@ -64,21 +130,50 @@ func (cfg RawConfig) validateHost() error {
 	return nil
 }
 
-func (cfg RawConfig) tlsConfig() (*tls.Config, error) {
-	clientCert, err := tls.X509KeyPair([]byte(cfg.ClientCert), []byte(cfg.ClientKey))
-	if err != nil {
-		return nil, errors.Annotate(err, "parsing client key pair")
+func (cfg RawConfig) hasTLSConfig() bool {
+	return cfg.ClientKey != "" || cfg.ClientCert != "" || cfg.CACert != ""
+}
+
+// usesTLS reports whether connections to the target are made over
+// TLS. Syslog targets always use TLS; the other protocols use it
+// only when certificates are configured.
+func (cfg RawConfig) usesTLS() bool {
+	if cfg.ProtocolOrDefault() == ProtocolSyslog {
+		return cfg.Enabled || cfg.hasTLSConfig()
 	}
+	return cfg.hasTLSConfig()
+}
 
-	caCert, err := cert.ParseCert(cfg.CACert)
-	if err != nil {
-		return nil, errors.Annotate(err, "parsing CA certificate")
+// TLSConfig returns the TLS configuration to use when connecting to
+// the target, or nil if the target is to be contacted without TLS.
+func (cfg RawConfig) TLSConfig() (*tls.Config, error) {
+	if !cfg.usesTLS() {
+		return nil, nil
+	}
+	tlsCfg, err := cfg.tlsConfig()
+	return tlsCfg, errors.Trace(err)
+}
+
+func (cfg RawConfig) tlsConfig() (*tls.Config, error) {
+	var tlsCfg tls.Config
+	// The client certificate is optional for protocols
+	// other than syslog.
+	if cfg.ProtocolOrDefault() == ProtocolSyslog || cfg.ClientCert != "" || cfg.ClientKey != "" {
+		clientCert, err := tls.X509KeyPair([]byte(cfg.ClientCert), []byte(cfg.ClientKey))
+		if err != nil {
+			return nil, errors.Annotate(err, "parsing client key pair")
+		}
+		tlsCfg.Certificates = []tls.Certificate{clientCert}
 	}
-	rootCAs := x509.NewCertPool()
-	rootCAs.AddCert(caCert)
 
-	return &tls.Config{
-		Certificates: []tls.Certificate{clientCert},
-		RootCAs:      rootCAs,
-	}, nil
+	if cfg.ProtocolOrDefault() == ProtocolSyslog || cfg.CACert != "" {
+		caCert, err := cert.ParseCert(cfg.CACert)
+		if err != nil {
+			return nil, errors.Annotate(err, "parsing CA certificate")
+		}
+		rootCAs := x509.NewCertPool()
+		rootCAs.AddCert(caCert)
+		tlsCfg.RootCAs = rootCAs
+	}
+	return &tlsCfg, nil
 }
@@ -91,6 +91,71 @@ func (s *ConfigSuite) TestRawValidateMissingHostname(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, `Host ":9876" not valid`)
 }
 
+func (s *ConfigSuite) TestRawValidateProtocols(c *gc.C) {
+	for _, protocol := range syslog.Protocols {
+		cfg := syslog.RawConfig{
+			Enabled:  true,
+			Protocol: protocol,
+			Host:     "a.b.c:9876",
+		}
+		if protocol == syslog.ProtocolSyslog {
+			cfg.CACert = coretesting.CACert
+			cfg.ClientCert = coretesting.ServerCert
+			cfg.ClientKey = coretesting.ServerKey
+		}
+		c.Check(cfg.Validate(), jc.ErrorIsNil, gc.Commentf("protocol %q", protocol))
+	}
+}
+
+func (s *ConfigSuite) TestRawValidateUnknownProtocol(c *gc.C) {
+	cfg := syslog.RawConfig{
+		Enabled:  true,
+		Protocol: "carrier-pigeon",
+		Host:     "a.b.c:9876",
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, gc.ErrorMatches, `Protocol "carrier-pigeon" not valid`)
+}
+
+func (s *ConfigSuite) TestRawValidateGELFUDPWithTLS(c *gc.C) {
+	cfg := syslog.RawConfig{
+		Enabled:  true,
+		Protocol: syslog.ProtocolGELFUDP,
+		Host:     "a.b.c:9876",
+		CACert:   coretesting.CACert,
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, gc.ErrorMatches, `TLS with protocol "gelf-udp" not supported`)
+}
+
+func (s *ConfigSuite) TestTLSConfig(c *gc.C) {
+	cfg := syslog.RawConfig{
+		Enabled:  true,
+		Protocol: syslog.ProtocolJSON,
+		Host:     "a.b.c:9876",
+	}
+	tlsCfg, err := cfg.TLSConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(tlsCfg, gc.IsNil)
+
+	// A CA certificate alone is enough to enable TLS.
+	cfg.CACert = coretesting.CACert
+	tlsCfg, err = cfg.TLSConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(tlsCfg.RootCAs, gc.NotNil)
+	c.Check(tlsCfg.Certificates, gc.HasLen, 0)
+
+	cfg.ClientCert = coretesting.ServerCert
+	cfg.ClientKey = coretesting.ServerKey
+	tlsCfg, err = cfg.TLSConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(tlsCfg.Certificates, gc.HasLen, 1)
+}
+
 func (s *ConfigSuite) TestRawValidateMissingCACert(c *gc.C) {
 	cfg := syslog.RawConfig{
 		Host:       "a.b.c:9876",
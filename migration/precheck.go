@@ -5,6 +5,7 @@ package migration
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/juju/errors"
 	"github.com/juju/version"
@@ -14,6 +15,7 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/cloud"
 	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
@@ -34,6 +36,9 @@ type PrecheckBackend interface {
 	ControllerBackend() (PrecheckBackendCloser, error)
 	CloudCredential(tag names.CloudCredentialTag) (cloud.Credential, error)
 	ListPendingResources(string) ([]resource.Resource, error)
+	AllSecretNames() ([]string, error)
+	AllActionScheduleNames() ([]string, error)
+	RelationApplicationSettingsNames() ([]string, error)
 }
 
 // PrecheckBackendCloser adds the Close method to the standard
@@ -74,6 +79,10 @@ type PrecheckApplication interface {
 	CharmURL() (*charm.URL, bool)
 	AllUnits() ([]PrecheckUnit, error)
 	MinUnits() int
+	IsTrusted() bool
+	ExposedEndpoints() map[string]state.ExposedEndpoint
+	EgressRules() []network.EgressRule
+	CharmEgressRules() []network.EgressRule
 }
 
 // PrecheckUnit describes state interface for a unit needed by
@@ -86,6 +95,8 @@ type PrecheckUnit interface {
 	AgentStatus() (status.StatusInfo, error)
 	Status() (status.StatusInfo, error)
 	AgentPresence() (bool, error)
+	CharmState() (map[string]string, error)
+	OpenedPortRanges() ([]state.PortRange, error)
 }
 
 // SourcePrecheck checks the state of the source controller to make
@@ -104,6 +115,39 @@ func SourcePrecheck(backend PrecheckBackend) error {
 		return errors.Trace(err)
 	}
 
+	// Secret values are encrypted with a key held only by the source
+	// controller, so they cannot be migrated.
+	if secrets, err := backend.AllSecretNames(); err != nil {
+		return errors.Annotate(err, "checking secrets")
+	} else if len(secrets) > 0 {
+		return errors.Errorf(
+			"model has secrets (%s), which cannot be migrated",
+			strings.Join(secrets, ", "),
+		)
+	}
+
+	// Action schedules are not exported, so migrating would silently
+	// drop them.
+	if schedules, err := backend.AllActionScheduleNames(); err != nil {
+		return errors.Annotate(err, "checking action schedules")
+	} else if len(schedules) > 0 {
+		return errors.Errorf(
+			"model has action schedules (%s), which cannot be migrated",
+			strings.Join(schedules, ", "),
+		)
+	}
+
+	// The model description cannot yet represent the application
+	// settings of relations, so migrating would silently drop them.
+	if names, err := backend.RelationApplicationSettingsNames(); err != nil {
+		return errors.Annotate(err, "checking relation application settings")
+	} else if len(names) > 0 {
+		return errors.Errorf(
+			"model has relation application settings (%s), which cannot be migrated",
+			strings.Join(names, ", "),
+		)
+	}
+
 	if cleanupNeeded, err := backend.NeedsCleanup(); err != nil {
 		return errors.Annotate(err, "checking cleanups")
 	} else if cleanupNeeded {
@@ -295,6 +339,34 @@ func checkApplications(backend PrecheckBackend) error {
 		if app.Life() != state.Alive {
 			return errors.Errorf("application %s is %s", app.Name(), app.Life())
 		}
+		// The model description cannot yet represent trust, and
+		// credential-get would stop working after migration.
+		if app.IsTrusted() {
+			return errors.Errorf("application %s is trusted, which cannot be migrated", app.Name())
+		}
+		// Nor can it represent expose settings. Settings that allow
+		// access from anywhere are equivalent to the exposed flag
+		// alone; rather than widen access to the application's ports
+		// in the target model, refuse any others.
+		if exposeSettingsRestrictAccess(app.ExposedEndpoints()) {
+			return errors.Errorf(
+				"application %s has expose settings that restrict access, which cannot be migrated; "+
+					"expose the application without --endpoints, --to-cidrs or --to-spaces",
+				app.Name(),
+			)
+		}
+		// Nor egress rules; dropping them would let the application's
+		// machines send traffic anywhere.
+		if len(app.EgressRules()) > 0 {
+			return errors.Errorf(
+				"application %s has egress rules, which cannot be migrated; "+
+					"remove them with set-egress-rules --reset",
+				app.Name(),
+			)
+		}
+		if len(app.CharmEgressRules()) > 0 {
+			return errors.Errorf("application %s has egress rules set by its charm, which cannot be migrated", app.Name())
+		}
 		err := checkUnits(app, modelVersion)
 		if err != nil {
 			return errors.Trace(err)
@@ -340,10 +412,51 @@ func checkUnits(app PrecheckApplication, modelVersion version.Number) error {
 		if appCharmURL.String() != unitCharmURL.String() {
 			return errors.Errorf("unit %s is upgrading", unit.Name())
 		}
+
+		// The model description cannot yet represent charm state, so
+		// migrating would silently drop it.
+		if charmState, err := unit.CharmState(); err != nil {
+			return errors.Annotatef(err, "retrieving unit %s charm state", unit.Name())
+		} else if len(charmState) > 0 {
+			return errors.Errorf("unit %s has charm state, which cannot be migrated", unit.Name())
+		}
+
+		// Nor the endpoints for which ports are opened.
+		portRanges, err := unit.OpenedPortRanges()
+		if err != nil && !errors.IsNotAssigned(errors.Cause(err)) {
+			return errors.Annotatef(err, "retrieving unit %s opened ports", unit.Name())
+		}
+		for _, portRange := range portRanges {
+			if portRange.Endpoint != state.AllEndpoints {
+				return errors.Errorf(
+					"unit %s has ports opened for endpoint %q, which cannot be migrated",
+					unit.Name(), portRange.Endpoint,
+				)
+			}
+		}
 	}
 	return nil
 }
 
+// exposeSettingsRestrictAccess reports whether the given expose
+// settings limit access to an exposed application's ports, i.e.
+// whether they differ from an exposed application with no settings.
+func exposeSettingsRestrictAccess(settings map[string]state.ExposedEndpoint) bool {
+	for name, exposed := range settings {
+		if name != state.AllEndpoints {
+			// Ports opened for other endpoints are not exposed.
+			return true
+		}
+		if len(exposed.ExposeToSpaces) > 0 {
+			return true
+		}
+		if len(exposed.ExposeToCIDRs) != 1 || exposed.ExposeToCIDRs[0] != "0.0.0.0/0" {
+			return true
+		}
+	}
+	return false
+}
+
 func checkUnitAgentStatus(unit PrecheckUnit) error {
 	statusData, _ := common.UnitStatus(unit)
 	if statusData.Err != nil {
@@ -4,6 +4,8 @@
 package migration
 
 import (
+	"fmt"
+
 	"github.com/juju/errors"
 	"github.com/juju/version"
 
@@ -143,3 +145,52 @@ func (s *precheckAppShim) AllUnits() ([]PrecheckUnit, error) {
 	}
 	return out, nil
 }
+
+// AllSecretNames implements PrecheckBackend.
+func (s *precheckShim) AllSecretNames() ([]string, error) {
+	secrets, err := s.State.AllSecrets()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	names := make([]string, len(secrets))
+	for i, secret := range secrets {
+		names[i] = secret.Name()
+	}
+	return names, nil
+}
+
+// AllActionScheduleNames implements PrecheckBackend.
+func (s *precheckShim) AllActionScheduleNames() ([]string, error) {
+	schedules, err := s.State.AllActionSchedules()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	names := make([]string, len(schedules))
+	for i, schedule := range schedules {
+		names[i] = schedule.Name()
+	}
+	return names, nil
+}
+
+// RelationApplicationSettingsNames implements PrecheckBackend. It
+// returns the "<application> in <relation>" names of the non-empty
+// application settings of the model's relations.
+func (s *precheckShim) RelationApplicationSettingsNames() ([]string, error) {
+	relations, err := s.State.AllRelations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var names []string
+	for _, relation := range relations {
+		for _, ep := range relation.Endpoints() {
+			settings, err := relation.ApplicationSettings(ep.ApplicationName)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if len(settings) > 0 {
+				names = append(names, fmt.Sprintf("%s in %q", ep.ApplicationName, relation))
+			}
+		}
+	}
+	return names, nil
+}
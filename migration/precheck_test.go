@@ -14,6 +14,7 @@ import (
 	"github.com/juju/juju/cloud"
 	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/migration"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/resource/resourcetesting"
 	"github.com/juju/juju/state"
@@ -108,6 +109,48 @@ func (*SourcePrecheckSuite) TestCleanupsNeeded(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "cleanup needed")
 }
 
+func (*SourcePrecheckSuite) TestSecretsError(c *gc.C) {
+	backend := newFakeBackend()
+	backend.secretsErr = errors.New("boom")
+	err := migration.SourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "checking secrets: boom")
+}
+
+func (*SourcePrecheckSuite) TestSecrets(c *gc.C) {
+	backend := newFakeBackend()
+	backend.secrets = []string{"db-password", "api-token"}
+	err := migration.SourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, `model has secrets \(db-password, api-token\), which cannot be migrated`)
+}
+
+func (*SourcePrecheckSuite) TestActionSchedulesError(c *gc.C) {
+	backend := newFakeBackend()
+	backend.actionSchedulesErr = errors.New("boom")
+	err := migration.SourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "checking action schedules: boom")
+}
+
+func (*SourcePrecheckSuite) TestActionSchedules(c *gc.C) {
+	backend := newFakeBackend()
+	backend.actionSchedules = []string{"nightly-backup", "hourly-sync"}
+	err := migration.SourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, `model has action schedules \(nightly-backup, hourly-sync\), which cannot be migrated`)
+}
+
+func (*SourcePrecheckSuite) TestRelationApplicationSettingsError(c *gc.C) {
+	backend := newFakeBackend()
+	backend.relationAppSettingsErr = errors.New("boom")
+	err := migration.SourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "checking relation application settings: boom")
+}
+
+func (*SourcePrecheckSuite) TestRelationApplicationSettings(c *gc.C) {
+	backend := newFakeBackend()
+	backend.relationAppSettings = []string{`wordpress in "wordpress:db mysql:server"`}
+	err := migration.SourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, `model has relation application settings \(wordpress in "wordpress:db mysql:server"\), which cannot be migrated`)
+}
+
 func (s *SourcePrecheckSuite) TestIsUpgradingError(c *gc.C) {
 	backend := newFakeBackend()
 	backend.controllerBackend.isUpgradingErr = errors.New("boom")
@@ -169,6 +212,70 @@ func (s *SourcePrecheckSuite) TestDyingApplication(c *gc.C) {
 	c.Assert(err.Error(), gc.Equals, "application foo is dying")
 }
 
+func (s *SourcePrecheckSuite) TestTrustedApplication(c *gc.C) {
+	backend := &fakeBackend{
+		apps: []migration.PrecheckApplication{
+			&fakeApp{
+				name:    "foo",
+				trusted: true,
+			},
+		},
+	}
+	err := migration.SourcePrecheck(backend)
+	c.Assert(err.Error(), gc.Equals, "application foo is trusted, which cannot be migrated")
+}
+
+func (s *SourcePrecheckSuite) TestApplicationExposeSettings(c *gc.C) {
+	backend := &fakeBackend{
+		apps: []migration.PrecheckApplication{
+			&fakeApp{
+				name: "foo",
+				exposed: map[string]state.ExposedEndpoint{
+					state.AllEndpoints: {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+				},
+			},
+		},
+	}
+	err := migration.SourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "application foo has expose settings that restrict access, which cannot be migrated; .*")
+}
+
+func (s *SourcePrecheckSuite) TestApplicationUnrestrictedExposeSettings(c *gc.C) {
+	backend := newHappyBackend()
+	backend.controllerBackend = newHappyBackend()
+	backend.apps[0].(*fakeApp).exposed = map[string]state.ExposedEndpoint{
+		state.AllEndpoints: {ExposeToCIDRs: []string{"0.0.0.0/0"}},
+	}
+	err := migration.SourcePrecheck(backend)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *SourcePrecheckSuite) TestApplicationEgressRules(c *gc.C) {
+	backend := &fakeBackend{
+		apps: []migration.PrecheckApplication{
+			&fakeApp{
+				name:   "foo",
+				egress: []network.EgressRule{network.MustNewEgressRule("udp", 53, 53)},
+			},
+		},
+	}
+	err := migration.SourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "application foo has egress rules, which cannot be migrated; .*")
+}
+
+func (s *SourcePrecheckSuite) TestApplicationCharmEgressRules(c *gc.C) {
+	backend := &fakeBackend{
+		apps: []migration.PrecheckApplication{
+			&fakeApp{
+				name:        "foo",
+				charmEgress: []network.EgressRule{network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8")},
+			},
+		},
+	}
+	err := migration.SourcePrecheck(backend)
+	c.Assert(err.Error(), gc.Equals, "application foo has egress rules set by its charm, which cannot be migrated")
+}
+
 func (s *SourcePrecheckSuite) TestWithPendingMinUnits(c *gc.C) {
 	backend := &fakeBackend{
 		apps: []migration.PrecheckApplication{
@@ -263,6 +370,39 @@ func (s *SourcePrecheckSuite) TestUnitLost(c *gc.C) {
 	c.Assert(err.Error(), gc.Equals, "unit foo/0 not idle or executing (lost)")
 }
 
+func (s *SourcePrecheckSuite) TestUnitCharmState(c *gc.C) {
+	backend := &fakeBackend{
+		apps: []migration.PrecheckApplication{
+			&fakeApp{
+				name: "foo",
+				units: []migration.PrecheckUnit{
+					&fakeUnit{name: "foo/0", charmState: map[string]string{"foo": "bar"}},
+				},
+			},
+		},
+	}
+	err := migration.SourcePrecheck(backend)
+	c.Assert(err.Error(), gc.Equals, "unit foo/0 has charm state, which cannot be migrated")
+}
+
+func (s *SourcePrecheckSuite) TestUnitEndpointPorts(c *gc.C) {
+	backend := &fakeBackend{
+		apps: []migration.PrecheckApplication{
+			&fakeApp{
+				name: "foo",
+				units: []migration.PrecheckUnit{
+					&fakeUnit{name: "foo/0", portRanges: []state.PortRange{
+						{UnitName: "foo/0", FromPort: 80, ToPort: 80, Protocol: "tcp"},
+						{UnitName: "foo/0", FromPort: 80, ToPort: 80, Protocol: "tcp", Endpoint: "db"},
+					}},
+				},
+			},
+		},
+	}
+	err := migration.SourcePrecheck(backend)
+	c.Assert(err.Error(), gc.Equals, `unit foo/0 has ports opened for endpoint "db", which cannot be migrated`)
+}
+
 func (*SourcePrecheckSuite) TestDyingControllerModel(c *gc.C) {
 	backend := newFakeBackend()
 	backend.controllerBackend.model.life = state.Dying
@@ -655,6 +795,15 @@ type fakeBackend struct {
 	pendingResources    []resource.Resource
 	pendingResourcesErr error
 
+	secrets    []string
+	secretsErr error
+
+	actionSchedules    []string
+	actionSchedulesErr error
+
+	relationAppSettings    []string
+	relationAppSettingsErr error
+
 	controllerBackend *fakeBackend
 }
 
@@ -703,6 +852,18 @@ func (b *fakeBackend) ListPendingResources(app string) ([]resource.Resource, err
 	return b.pendingResources, b.pendingResourcesErr
 }
 
+func (b *fakeBackend) AllSecretNames() ([]string, error) {
+	return b.secrets, b.secretsErr
+}
+
+func (b *fakeBackend) AllActionScheduleNames() ([]string, error) {
+	return b.actionSchedules, b.actionSchedulesErr
+}
+
+func (b *fakeBackend) RelationApplicationSettingsNames() ([]string, error) {
+	return b.relationAppSettings, b.relationAppSettingsErr
+}
+
 func (b *fakeBackend) ControllerBackend() (migration.PrecheckBackendCloser, error) {
 	if b.controllerBackend == nil {
 		return b, nil
@@ -806,11 +967,15 @@ func (m *fakeMachine) ShouldRebootOrShutdown() (state.RebootAction, error) {
 }
 
 type fakeApp struct {
-	name     string
-	life     state.Life
-	charmURL string
-	units    []migration.PrecheckUnit
-	minunits int
+	name        string
+	life        state.Life
+	charmURL    string
+	units       []migration.PrecheckUnit
+	minunits    int
+	trusted     bool
+	exposed     map[string]state.ExposedEndpoint
+	egress      []network.EgressRule
+	charmEgress []network.EgressRule
 }
 
 func (a *fakeApp) Name() string {
@@ -837,6 +1002,22 @@ func (a *fakeApp) MinUnits() int {
 	return a.minunits
 }
 
+func (a *fakeApp) IsTrusted() bool {
+	return a.trusted
+}
+
+func (a *fakeApp) ExposedEndpoints() map[string]state.ExposedEndpoint {
+	return a.exposed
+}
+
+func (a *fakeApp) EgressRules() []network.EgressRule {
+	return a.egress
+}
+
+func (a *fakeApp) CharmEgressRules() []network.EgressRule {
+	return a.charmEgress
+}
+
 type fakeUnit struct {
 	name        string
 	version     version.Binary
@@ -844,6 +1025,8 @@ type fakeUnit struct {
 	charmURL    string
 	agentStatus status.Status
 	lost        bool
+	charmState  map[string]string
+	portRanges  []state.PortRange
 }
 
 func (u *fakeUnit) Name() string {
@@ -890,3 +1073,11 @@ func (u *fakeUnit) Status() (status.StatusInfo, error) {
 func (u *fakeUnit) AgentPresence() (bool, error) {
 	return !u.lost, nil
 }
+
+func (u *fakeUnit) CharmState() (map[string]string, error) {
+	return u.charmState, nil
+}
+
+func (u *fakeUnit) OpenedPortRanges() ([]state.PortRange, error) {
+	return u.portRanges, nil
+}
@@ -72,3 +72,91 @@ func (*FirewallSuite) TestNewIngressRuleBadCIDR(c *gc.C) {
 	_, err := network.NewIngressRule("tcp", 80, 100, "0.0.0.0/0", "192.168.0/24")
 	c.Assert(err, gc.ErrorMatches, "invalid CIDR address: 192.168.0/24")
 }
+
+func (*FirewallSuite) TestEgressRuleStrings(c *gc.C) {
+	rule := network.MustNewEgressRule("tcp", 443, 443)
+	c.Assert(rule.String(), gc.Equals, "443/tcp")
+	c.Assert(rule.GoString(), gc.Equals, "443/tcp")
+
+	rule = network.MustNewEgressRule("tcp", 443, 443, "0.0.0.0/0")
+	c.Assert(rule.String(), gc.Equals, "443/tcp")
+
+	rule = network.MustNewEgressRule("udp", 53, 54, "10.0.0.2/32", "10.0.0.3/32")
+	c.Assert(rule.String(), gc.Equals, "53-54/udp to 10.0.0.2/32,10.0.0.3/32")
+	c.Assert(rule.GoString(), gc.Equals, "53-54/udp to 10.0.0.2/32,10.0.0.3/32")
+}
+
+func (*FirewallSuite) TestNewEgressRule(c *gc.C) {
+	rule, err := network.NewEgressRule("tcp", 80, 100, "10.0.0.0/8", "192.168.1.0/24")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rule.Protocol, gc.Equals, "tcp")
+	c.Assert(rule.FromPort, gc.Equals, 80)
+	c.Assert(rule.ToPort, gc.Equals, 100)
+	c.Assert(rule.DestinationCIDRs, jc.DeepEquals, []string{"10.0.0.0/8", "192.168.1.0/24"})
+
+	rule, err = network.NewEgressRule("tcp", 80, 100)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rule.DestinationCIDRs, gc.IsNil)
+}
+
+func (*FirewallSuite) TestNewEgressRuleBadCIDR(c *gc.C) {
+	_, err := network.NewEgressRule("tcp", 80, 100, "192.168.0/24")
+	c.Assert(err, gc.ErrorMatches, "invalid CIDR address: 192.168.0/24")
+}
+
+func (*FirewallSuite) TestParseEgressRule(c *gc.C) {
+	for i, t := range []struct {
+		rule     string
+		expected network.EgressRule
+		err      string
+	}{{
+		rule:     "443",
+		expected: network.MustNewEgressRule("tcp", 443, 443),
+	}, {
+		rule:     "53/UDP@10.0.0.2/32",
+		expected: network.MustNewEgressRule("udp", 53, 53, "10.0.0.2/32"),
+	}, {
+		rule:     "8000-8080/tcp@10.0.0.0/8,2001:db8::/32",
+		expected: network.MustNewEgressRule("tcp", 8000, 8080, "10.0.0.0/8", "2001:db8::/32"),
+	}, {
+		rule: "443/icmp",
+		err:  `invalid protocol "icmp", expected "tcp" or "udp"`,
+	}, {
+		rule: "443@10.0.0.0",
+		err:  "invalid CIDR address: 10.0.0.0",
+	}} {
+		c.Logf("test %d: %s", i, t.rule)
+		rule, err := network.ParseEgressRule(t.rule)
+		if t.err != "" {
+			c.Check(err, gc.ErrorMatches, t.err)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(rule, jc.DeepEquals, t.expected)
+	}
+}
+
+func (*FirewallSuite) TestSortEgressRules(c *gc.C) {
+	rule1 := network.MustNewEgressRule("udp", 10, 100, "10.0.0.0/8")
+	rule2 := network.MustNewEgressRule("tcp", 80, 90, "10.0.0.0/8")
+	rule3 := network.MustNewEgressRule("tcp", 80, 80, "192.168.1.0/24")
+	rule4 := network.MustNewEgressRule("tcp", 80, 80, "10.0.0.0/8")
+
+	rules := []network.EgressRule{rule1, rule2, rule3, rule4}
+	network.SortEgressRules(rules)
+	c.Assert(rules, jc.DeepEquals, []network.EgressRule{rule4, rule3, rule2, rule1})
+}
+
+func (*FirewallSuite) TestMergeEgressRules(c *gc.C) {
+	c.Assert(network.MergeEgressRules(nil), gc.IsNil)
+	rules := network.MergeEgressRules([]network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "192.168.0.0/16"),
+		network.MustNewEgressRule("UDP", 53, 53, "10.0.0.2/32"),
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8", "192.168.0.0/16"),
+		network.MustNewEgressRule("udp", 53, 53),
+	})
+	c.Assert(rules, jc.DeepEquals, []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8", "192.168.0.0/16"),
+		network.MustNewEgressRule("udp", 53, 53, "0.0.0.0/0", "10.0.0.2/32"),
+	})
+}
@@ -101,11 +101,16 @@ func (c *sshInstanceConfigurator) ChangeIngressRules(ipAddress string, insert bo
 		insertArg = "-D"
 	}
 	for _, port := range rules {
+		sourceArg := ""
+		if sourceCIDRs := ingressRuleSourceCIDRs(port); len(sourceCIDRs) > 0 {
+			// iptables adds a rule for each of the sources.
+			sourceArg = fmt.Sprintf("-s %s ", strings.Join(sourceCIDRs, ","))
+		}
 		if port.ToPort-port.FromPort > 0 {
-			cmd += fmt.Sprintf("sudo iptables -d %s %s INPUT -p %s --match multiport --dports %d:%d -j ACCEPT\n", ipAddress, insertArg, port.Protocol, port.FromPort, port.ToPort)
+			cmd += fmt.Sprintf("sudo iptables -d %s %s%s INPUT -p %s --match multiport --dports %d:%d -j ACCEPT\n", ipAddress, sourceArg, insertArg, port.Protocol, port.FromPort, port.ToPort)
 		} else {
 
-			cmd += fmt.Sprintf("sudo iptables -d %s %s INPUT -p %s --dport %d -j ACCEPT\n", ipAddress, insertArg, port.Protocol, port.FromPort)
+			cmd += fmt.Sprintf("sudo iptables -d %s %s%s INPUT -p %s --dport %d -j ACCEPT\n", ipAddress, sourceArg, insertArg, port.Protocol, port.FromPort)
 		}
 	}
 	cmd += "sudo /etc/init.d/iptables-persistent save\n"
@@ -119,6 +124,17 @@ func (c *sshInstanceConfigurator) ChangeIngressRules(ipAddress string, insert bo
 	return nil
 }
 
+// ingressRuleSourceCIDRs returns the source CIDRs of the given rule,
+// or nil if the rule allows access from everywhere.
+func ingressRuleSourceCIDRs(rule network.IngressRule) []string {
+	for _, cidr := range rule.SourceCIDRs {
+		if cidr == "0.0.0.0/0" {
+			return nil
+		}
+	}
+	return rule.SourceCIDRs
+}
+
 // FindIngressRules implements InstanceConfigurator interface.
 func (c *sshInstanceConfigurator) FindIngressRules() ([]network.IngressRule, error) {
 	cmd := "sudo iptables -L INPUT -n"
@@ -135,8 +151,33 @@ func (c *sshInstanceConfigurator) FindIngressRules() ([]network.IngressRule, err
 	//target     prot opt source               destination
 	//ACCEPT     tcp  --  0.0.0.0/0            192.168.0.1  multiport dports 3456:3458
 	//ACCEPT     tcp  --  0.0.0.0/0            192.168.0.2  tcp dpt:12345
+	//ACCEPT     tcp  --  10.0.0.0/24          192.168.0.2  tcp dpt:12345
 
 	res := make([]network.IngressRule, 0)
+	var addRule = func(protocol string, from, to int, source string) {
+		// Rules for the same ports from different sources are
+		// combined into a single ingress rule.
+		for i, rule := range res {
+			if rule.Protocol != protocol || rule.FromPort != from || rule.ToPort != to {
+				continue
+			}
+			if source != "0.0.0.0/0" && len(rule.SourceCIDRs) > 0 {
+				res[i].SourceCIDRs = append(rule.SourceCIDRs, source)
+			} else {
+				res[i].SourceCIDRs = nil
+			}
+			return
+		}
+		if source == "0.0.0.0/0" {
+			res = append(res, network.NewOpenIngressRule(protocol, from, to))
+			return
+		}
+		rule, err := network.NewIngressRule(protocol, from, to, source)
+		if err != nil {
+			return
+		}
+		res = append(res, rule)
+	}
 	var addSinglePortRange = func(items []string) {
 		ports := strings.Split(items[6], ":")
 		if len(ports) != 2 {
@@ -147,7 +188,7 @@ func (c *sshInstanceConfigurator) FindIngressRules() ([]network.IngressRule, err
 			return
 		}
 
-		res = append(res, network.NewOpenIngressRule(items[1], int(to), int(to)))
+		addRule(items[1], int(to), int(to), items[3])
 	}
 	var addMultiplePortRange = func(items []string) {
 		ports := strings.Split(items[7], ":")
@@ -163,7 +204,7 @@ func (c *sshInstanceConfigurator) FindIngressRules() ([]network.IngressRule, err
 			return
 		}
 
-		res = append(res, network.NewOpenIngressRule(items[1], int(from), int(to)))
+		addRule(items[1], int(from), int(to), items[3])
 	}
 
 	for i, line := range strings.Split(string(output), "\n") {
@@ -171,10 +212,10 @@ func (c *sshInstanceConfigurator) FindIngressRules() ([]network.IngressRule, err
 			continue
 		}
 		items := strings.Split(line, " ")
-		if len(items) == 7 && items[0] == "ACCEPT" && items[3] == "0.0.0.0/0" {
+		if len(items) == 7 && items[0] == "ACCEPT" {
 			addSinglePortRange(items)
 		}
-		if len(items) == 8 && items[0] == "ACCEPT" && items[3] == "0.0.0.0/0" && items[5] != "multiport" && items[6] != "dports" {
+		if len(items) == 8 && items[0] == "ACCEPT" && items[5] != "multiport" && items[6] != "dports" {
 			addMultiplePortRange(items)
 		}
 	}
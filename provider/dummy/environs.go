@@ -40,6 +40,7 @@ import (
 	"github.com/juju/utils/arch"
 	"github.com/juju/utils/clock"
 	"github.com/juju/utils/series"
+	"github.com/juju/utils/set"
 	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/environschema.v1"
@@ -243,6 +244,7 @@ type environState struct {
 	maxAddr        int // maximum allocated address last byte
 	insts          map[instance.Id]*dummyInstance
 	globalRules    network.IngressRuleSlice
+	globalEgress   network.EgressRuleSlice
 	bootstrapped   bool
 	apiListener    net.Listener
 	apiServer      *apiserver.Server
@@ -786,6 +788,7 @@ func (e *environ) Bootstrap(ctx environs.BootstrapContext, args environs.Bootstr
 				MongoInfo:        info,
 				MongoDialOpts:    mongotest.DialOpts(),
 				NewPolicy:        estate.newStatePolicy,
+				SecretsKey:       testing.SecretsKey,
 			})
 			if err != nil {
 				return err
@@ -1462,6 +1465,86 @@ func (e *environ) IngressRules() (rules []network.IngressRule, err error) {
 	return
 }
 
+// OpenEgressRules is specified in environs.EgressFirewaller.
+func (e *environ) OpenEgressRules(rules []network.EgressRule) error {
+	if mode := e.ecfg().FirewallMode(); mode != config.FwGlobal {
+		return fmt.Errorf("invalid firewall mode %q for opening egress rules on model", mode)
+	}
+	estate, err := e.state()
+	if err != nil {
+		return err
+	}
+	estate.mu.Lock()
+	defer estate.mu.Unlock()
+	estate.globalEgress = openEgressRules(estate.globalEgress, rules)
+	return nil
+}
+
+// CloseEgressRules is specified in environs.EgressFirewaller.
+func (e *environ) CloseEgressRules(rules []network.EgressRule) error {
+	if mode := e.ecfg().FirewallMode(); mode != config.FwGlobal {
+		return fmt.Errorf("invalid firewall mode %q for closing egress rules on model", mode)
+	}
+	estate, err := e.state()
+	if err != nil {
+		return err
+	}
+	estate.mu.Lock()
+	defer estate.mu.Unlock()
+	estate.globalEgress = closeEgressRules(estate.globalEgress, rules)
+	return nil
+}
+
+// EgressRules is specified in environs.EgressFirewaller.
+func (e *environ) EgressRules() (rules []network.EgressRule, err error) {
+	if mode := e.ecfg().FirewallMode(); mode != config.FwGlobal {
+		return nil, fmt.Errorf("invalid firewall mode %q for retrieving egress rules from model", mode)
+	}
+	estate, err := e.state()
+	if err != nil {
+		return nil, err
+	}
+	estate.mu.Lock()
+	defer estate.mu.Unlock()
+	rules = append(rules, estate.globalEgress...)
+	network.SortEgressRules(rules)
+	return
+}
+
+// openEgressRules returns the existing rules extended to allow traffic
+// to the destinations of the given rules.
+func openEgressRules(existing network.EgressRuleSlice, rules []network.EgressRule) network.EgressRuleSlice {
+	var all []network.EgressRule
+	all = append(all, existing...)
+	all = append(all, rules...)
+	return network.MergeEgressRules(all)
+}
+
+// closeEgressRules returns the existing rules without the destinations
+// of the given rules. Rules left with no destinations are removed.
+func closeEgressRules(existing network.EgressRuleSlice, rules []network.EgressRule) network.EgressRuleSlice {
+	var result network.EgressRuleSlice
+	for _, rule := range network.MergeEgressRules(existing) {
+		cidrs := set.NewStrings(rule.DestinationCIDRs...)
+		for _, r := range rules {
+			if r.PortRange != rule.PortRange {
+				continue
+			}
+			if len(r.DestinationCIDRs) == 0 {
+				cidrs.Remove("0.0.0.0/0")
+			}
+			for _, cidr := range r.DestinationCIDRs {
+				cidrs.Remove(cidr)
+			}
+		}
+		if !cidrs.IsEmpty() {
+			rule.DestinationCIDRs = cidrs.SortedValues()
+			result = append(result, rule)
+		}
+	}
+	return result
+}
+
 func (*environ) Provider() environs.EnvironProvider {
 	return &dummy
 }
@@ -1469,6 +1552,7 @@ func (*environ) Provider() environs.EnvironProvider {
 type dummyInstance struct {
 	state        *environState
 	rules        network.IngressRuleSlice
+	egressRules  network.EgressRuleSlice
 	id           instance.Id
 	status       string
 	machineId    string
@@ -1646,6 +1730,64 @@ func (inst *dummyInstance) IngressRules(machineId string) (rules []network.Ingre
 	return
 }
 
+// OpenEgressRules is specified in instance.EgressFirewaller.
+func (inst *dummyInstance) OpenEgressRules(machineId string, rules []network.EgressRule) error {
+	defer delay()
+	if inst.firewallMode != config.FwInstance {
+		return fmt.Errorf("invalid firewall mode %q for opening egress rules on instance",
+			inst.firewallMode)
+	}
+	if inst.machineId != machineId {
+		panic(fmt.Errorf("OpenEgressRules with mismatched machine id, expected %q got %q", inst.machineId, machineId))
+	}
+	inst.state.mu.Lock()
+	defer inst.state.mu.Unlock()
+	if err := inst.checkBroken("OpenEgressRules"); err != nil {
+		return err
+	}
+	inst.egressRules = openEgressRules(inst.egressRules, rules)
+	return nil
+}
+
+// CloseEgressRules is specified in instance.EgressFirewaller.
+func (inst *dummyInstance) CloseEgressRules(machineId string, rules []network.EgressRule) error {
+	defer delay()
+	if inst.firewallMode != config.FwInstance {
+		return fmt.Errorf("invalid firewall mode %q for closing egress rules on instance",
+			inst.firewallMode)
+	}
+	if inst.machineId != machineId {
+		panic(fmt.Errorf("CloseEgressRules with mismatched machine id, expected %q got %q", inst.machineId, machineId))
+	}
+	inst.state.mu.Lock()
+	defer inst.state.mu.Unlock()
+	if err := inst.checkBroken("CloseEgressRules"); err != nil {
+		return err
+	}
+	inst.egressRules = closeEgressRules(inst.egressRules, rules)
+	return nil
+}
+
+// EgressRules is specified in instance.EgressFirewaller.
+func (inst *dummyInstance) EgressRules(machineId string) (rules []network.EgressRule, err error) {
+	defer delay()
+	if inst.firewallMode != config.FwInstance {
+		return nil, fmt.Errorf("invalid firewall mode %q for retrieving egress rules from instance",
+			inst.firewallMode)
+	}
+	if inst.machineId != machineId {
+		panic(fmt.Errorf("EgressRules with mismatched machine id, expected %q got %q", inst.machineId, machineId))
+	}
+	inst.state.mu.Lock()
+	defer inst.state.mu.Unlock()
+	if err := inst.checkBroken("EgressRules"); err != nil {
+		return nil, err
+	}
+	rules = append(rules, inst.egressRules...)
+	network.SortEgressRules(rules)
+	return
+}
+
 // providerDelay controls the delay before dummy responds.
 // non empty values in JUJU_DUMMY_DELAY will be parsed as
 // time.Durations into this value.
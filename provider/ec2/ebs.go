@@ -5,6 +5,7 @@ package ec2
 
 import (
 	"regexp"
+	"strconv"
 	"sync"
 	"time"
 
@@ -60,6 +61,7 @@ const (
 	deviceInUse        = "InvalidDevice.InUse"
 	attachmentNotFound = "InvalidAttachment.NotFound"
 	volumeNotFound     = "InvalidVolume.NotFound"
+	snapshotNotFound   = "InvalidSnapshot.NotFound"
 )
 
 const (
@@ -75,7 +77,8 @@ const (
 )
 
 // Limits for volume parameters. See:
-//   http://docs.aws.amazon.com/AWSEC2/latest/UserGuide/EBSVolumeTypes.html
+//
+//	http://docs.aws.amazon.com/AWSEC2/latest/UserGuide/EBSVolumeTypes.html
 const (
 	// minMagneticVolumeSizeGiB is the minimum size for magnetic volumes in GiB.
 	minMagneticVolumeSizeGiB = 1
@@ -248,6 +251,8 @@ type ebsVolumeSource struct {
 }
 
 var _ storage.VolumeSource = (*ebsVolumeSource)(nil)
+var _ storage.VolumeSnapshotter = (*ebsVolumeSource)(nil)
+var _ storage.VolumeResizer = (*ebsVolumeSource)(nil)
 
 // parseVolumeOptions uses storage volume parameters to make a struct used to create volumes.
 func parseVolumeOptions(size uint64, attrs map[string]interface{}) (_ ec2.CreateVolume, _ error) {
@@ -345,6 +350,7 @@ func (v *ebsVolumeSource) createVolume(p storage.VolumeParams, instances instanc
 	}
 	vol, _ := parseVolumeOptions(p.Size, p.Attributes)
 	vol.AvailZone = inst.AvailZone
+	vol.SnapshotId = p.SnapshotId
 	resp, err := v.env.ec2.CreateVolume(vol)
 	if err != nil {
 		return nil, nil, errors.Trace(err)
@@ -573,6 +579,174 @@ func destroyVolume(client *ec2.EC2, volumeId string) (err error) {
 	return nil
 }
 
+// CreateVolumeSnapshots is specified on the storage.VolumeSnapshotter interface.
+func (v *ebsVolumeSource) CreateVolumeSnapshots(params []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error) {
+	results := make([]storage.CreateVolumeSnapshotsResult, len(params))
+	for i, p := range params {
+		snapshot, err := v.createVolumeSnapshot(p)
+		if err != nil {
+			results[i].Error = err
+			continue
+		}
+		results[i].VolumeSnapshot = snapshot
+	}
+	return results, nil
+}
+
+func (v *ebsVolumeSource) createVolumeSnapshot(p storage.VolumeSnapshotParams) (_ *storage.VolumeSnapshot, err error) {
+	var snapshotId string
+	defer func() {
+		if err == nil || snapshotId == "" {
+			return
+		}
+		if err := deleteSnapshot(v.env.ec2, snapshotId); err != nil {
+			logger.Errorf("error cleaning up snapshot %v: %v", snapshotId, err)
+		}
+	}()
+
+	description := "juju snapshot of " + resourceName(p.Volume, v.envName)
+	resp, err := createSnapshot(v.env.ec2, p.VolumeId, description)
+	if err != nil {
+		return nil, errors.Annotatef(err, "creating snapshot of %q", p.VolumeId)
+	}
+	snapshotId = resp.Snapshot.Id
+
+	// Tag.
+	resourceTags := make(map[string]string)
+	for k, v := range p.ResourceTags {
+		resourceTags[k] = v
+	}
+	resourceTags[tagName] = resourceName(p.Volume, v.envName)
+	if err := tagSnapshot(v.env.ec2, resourceTags, snapshotId); err != nil {
+		return nil, errors.Annotatef(err, "tagging snapshot %q", snapshotId)
+	}
+
+	sizeInGib, err := strconv.ParseUint(resp.Snapshot.VolumeSize, 10, 64)
+	if err != nil {
+		return nil, errors.Annotatef(err, "parsing size of snapshot %q", snapshotId)
+	}
+	return &storage.VolumeSnapshot{
+		Volume:     p.Volume,
+		SnapshotId: snapshotId,
+		Size:       gibToMib(sizeInGib),
+	}, nil
+}
+
+// ResizeVolumes is specified on the storage.VolumeResizer interface.
+func (v *ebsVolumeSource) ResizeVolumes(params []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	results := make([]storage.ResizeVolumesResult, len(params))
+	for i, p := range params {
+		size, err := v.resizeVolume(p)
+		if err != nil {
+			results[i].Error = err
+			continue
+		}
+		results[i].Size = size
+	}
+	return results, nil
+}
+
+// volumeModification describes the progress of an EBS volume
+// modification. amz.v3 does not support ModifyVolume, so the
+// response types are defined here.
+type volumeModification struct {
+	VolumeId          string `xml:"volumeId"`
+	ModificationState string `xml:"modificationState"`
+	StatusMessage     string `xml:"statusMessage"`
+	TargetSize        uint64 `xml:"targetSize"`
+}
+
+type modifyVolumeResp struct {
+	RequestId          string             `xml:"requestId"`
+	VolumeModification volumeModification `xml:"volumeModification"`
+}
+
+type describeVolumesModificationsResp struct {
+	RequestId           string               `xml:"requestId"`
+	VolumeModifications []volumeModification `xml:"volumeModificationSet>item"`
+}
+
+const (
+	volumeModificationOptimizing = "optimizing"
+	volumeModificationCompleted  = "completed"
+	volumeModificationFailed     = "failed"
+)
+
+var resizeVolumeAttempt = utils.AttemptStrategy{
+	Total: 5 * time.Minute,
+	Delay: 5 * time.Second,
+}
+
+func (v *ebsVolumeSource) resizeVolume(p storage.VolumeResizeParams) (uint64, error) {
+	sizeInGib := mibToGib(p.Size)
+	var resp modifyVolumeResp
+	if err := ec2Query(v.env.ec2, "ModifyVolume", map[string]string{
+		"VolumeId": p.VolumeId,
+		"Size":     strconv.FormatUint(sizeInGib, 10),
+	}, &resp); err != nil {
+		return 0, errors.Annotatef(err, "resizing %q", p.VolumeId)
+	}
+
+	// The volume may be used at its new size as soon as the
+	// modification enters the "optimizing" state; there is no
+	// need to wait for it to complete.
+	modification := resp.VolumeModification
+	for a := resizeVolumeAttempt.Start(); ; {
+		switch modification.ModificationState {
+		case volumeModificationOptimizing, volumeModificationCompleted:
+			return gibToMib(modification.TargetSize), nil
+		case volumeModificationFailed:
+			return 0, errors.Errorf(
+				"resizing %q failed: %s", p.VolumeId, modification.StatusMessage,
+			)
+		}
+		if !a.Next() {
+			return 0, errors.Errorf(
+				"timed out waiting for %q to be resized (%s)",
+				p.VolumeId, modification.ModificationState,
+			)
+		}
+		var resp describeVolumesModificationsResp
+		if err := ec2Query(v.env.ec2, "DescribeVolumesModifications", map[string]string{
+			"VolumeId.1": p.VolumeId,
+		}, &resp); err != nil {
+			return 0, errors.Annotatef(err, "querying modification of %q", p.VolumeId)
+		}
+		if len(resp.VolumeModifications) != 1 {
+			return 0, errors.Errorf(
+				"expected one modification of %q, got %d",
+				p.VolumeId, len(resp.VolumeModifications),
+			)
+		}
+		modification = resp.VolumeModifications[0]
+	}
+}
+
+// The EC2 snapshot operations are variables so that they may be
+// replaced in tests, as the ec2test server does not support snapshots.
+var (
+	createSnapshot = (*ec2.EC2).CreateSnapshot
+	tagSnapshot    = func(client *ec2.EC2, tags map[string]string, snapshotId string) error {
+		return tagResources(client, tags, snapshotId)
+	}
+	deleteSnapshot = func(client *ec2.EC2, snapshotId string) error {
+		_, err := client.DeleteSnapshots([]string{snapshotId})
+		return err
+	}
+)
+
+// DestroyVolumeSnapshots is specified on the storage.VolumeSnapshotter interface.
+func (v *ebsVolumeSource) DestroyVolumeSnapshots(snapshotIds []string) ([]error, error) {
+	results := make([]error, len(snapshotIds))
+	for i, snapshotId := range snapshotIds {
+		err := deleteSnapshot(v.env.ec2, snapshotId)
+		if err != nil && ec2ErrCode(err) != snapshotNotFound {
+			results[i] = errors.Annotatef(err, "destroying snapshot %q", snapshotId)
+		}
+	}
+	return results, nil
+}
+
 // ValidateVolumeParams is specified on the storage.VolumeSource interface.
 func (v *ebsVolumeSource) ValidateVolumeParams(params storage.VolumeParams) error {
 	vol, err := parseVolumeOptions(params.Size, params.Attributes)
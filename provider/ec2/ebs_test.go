@@ -4,6 +4,7 @@
 package ec2_test
 
 import (
+	"encoding/xml"
 	"fmt"
 	"sort"
 	"strconv"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
 	"github.com/juju/utils/arch"
 	"github.com/juju/utils/clock"
 	"github.com/juju/utils/series"
@@ -254,6 +256,126 @@ func (s *ebsSuite) TestCreateVolumes(c *gc.C) {
 	s.assertCreateVolumes(c, vs, "")
 }
 
+func (s *ebsSuite) TestCreateVolumesFromSnapshot(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	instanceId := s.srv.ec2srv.NewInstances(1, "m1.medium", imageId, ec2test.Running, nil)[0]
+	results, err := vs.CreateVolumes([]storage.VolumeParams{{
+		Tag:        names.NewVolumeTag("0"),
+		Size:       10 * 1024,
+		Provider:   ec2.EBS_ProviderType,
+		SnapshotId: "snap-123",
+		Attachment: &storage.VolumeAttachmentParams{
+			AttachmentParams: storage.AttachmentParams{
+				InstanceId: instance.Id(instanceId),
+			},
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+
+	ec2Vols, err := s.client.Volumes([]string{results[0].Volume.VolumeId}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ec2Vols.Volumes, gc.HasLen, 1)
+	c.Assert(ec2Vols.Volumes[0].SnapshotId, gc.Equals, "snap-123")
+}
+
+// patchSnapshots replaces the EC2 snapshot operations, which the
+// ec2test server does not support, and returns the calls made.
+func (s *ebsSuite) patchSnapshots(c *gc.C, failCreate, failTag, failDelete string) *[]string {
+	var calls []string
+	s.PatchValue(ec2.CreateSnapshot, func(client *awsec2.EC2, volumeId, description string) (*awsec2.CreateSnapshotResp, error) {
+		calls = append(calls, fmt.Sprintf("CreateSnapshot %s %s", volumeId, description))
+		if volumeId == failCreate {
+			return nil, errors.New("no snapshot for you")
+		}
+		resp := &awsec2.CreateSnapshotResp{}
+		resp.Snapshot = awsec2.Snapshot{
+			Id:         "snap-" + volumeId,
+			VolumeId:   volumeId,
+			VolumeSize: "2",
+		}
+		return resp, nil
+	})
+	s.PatchValue(ec2.TagSnapshot, func(client *awsec2.EC2, tags map[string]string, snapshotId string) error {
+		calls = append(calls, fmt.Sprintf("TagSnapshot %s %v", snapshotId, tags))
+		if snapshotId == failTag {
+			return errors.New("no tags for you")
+		}
+		return nil
+	})
+	s.PatchValue(ec2.DeleteSnapshot, func(client *awsec2.EC2, snapshotId string) error {
+		calls = append(calls, "DeleteSnapshot "+snapshotId)
+		switch snapshotId {
+		case failDelete:
+			return errors.New("no deleting for you")
+		case "snap-gone":
+			return &awsec2.Error{Code: "InvalidSnapshot.NotFound"}
+		}
+		return nil
+	})
+	return &calls
+}
+
+func (s *ebsSuite) TestCreateVolumeSnapshots(c *gc.C) {
+	calls := s.patchSnapshots(c, "vol-bad", "snap-vol-untaggable", "")
+	vs := s.volumeSource(c, nil)
+	snapshotter, ok := vs.(storage.VolumeSnapshotter)
+	c.Assert(ok, jc.IsTrue)
+	results, err := snapshotter.CreateVolumeSnapshots([]storage.VolumeSnapshotParams{{
+		Volume:       names.NewVolumeTag("0"),
+		VolumeId:     "vol-0",
+		Provider:     ec2.EBS_ProviderType,
+		ResourceTags: map[string]string{"abc": "123"},
+	}, {
+		Volume:   names.NewVolumeTag("1"),
+		VolumeId: "vol-bad",
+		Provider: ec2.EBS_ProviderType,
+	}, {
+		Volume:   names.NewVolumeTag("2"),
+		VolumeId: "vol-untaggable",
+		Provider: ec2.EBS_ProviderType,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 3)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].VolumeSnapshot, jc.DeepEquals, &storage.VolumeSnapshot{
+		Volume:     names.NewVolumeTag("0"),
+		SnapshotId: "snap-vol-0",
+		Size:       2048,
+	})
+	c.Assert(results[1].Error, gc.ErrorMatches, `creating snapshot of "vol-bad": no snapshot for you`)
+	c.Assert(results[2].Error, gc.ErrorMatches, `tagging snapshot "snap-vol-untaggable": no tags for you`)
+
+	// The snapshot that could not be tagged is cleaned up.
+	c.Assert(*calls, jc.DeepEquals, []string{
+		"CreateSnapshot vol-0 juju snapshot of juju-sample-volume-0",
+		"TagSnapshot snap-vol-0 map[Name:juju-sample-volume-0 abc:123]",
+		"CreateSnapshot vol-bad juju snapshot of juju-sample-volume-1",
+		"CreateSnapshot vol-untaggable juju snapshot of juju-sample-volume-2",
+		"TagSnapshot snap-vol-untaggable map[Name:juju-sample-volume-2]",
+		"DeleteSnapshot snap-vol-untaggable",
+	})
+}
+
+func (s *ebsSuite) TestDestroyVolumeSnapshots(c *gc.C) {
+	calls := s.patchSnapshots(c, "", "", "snap-bad")
+	vs := s.volumeSource(c, nil)
+	errs, err := vs.(storage.VolumeSnapshotter).DestroyVolumeSnapshots([]string{
+		"snap-0", "snap-gone", "snap-bad",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, gc.HasLen, 3)
+	c.Assert(errs[0], jc.ErrorIsNil)
+	c.Assert(errs[1], jc.ErrorIsNil)
+	c.Assert(errs[2], gc.ErrorMatches, `destroying snapshot "snap-bad": no deleting for you`)
+	c.Assert(*calls, jc.DeepEquals, []string{
+		"DeleteSnapshot snap-0",
+		"DeleteSnapshot snap-gone",
+		"DeleteSnapshot snap-bad",
+	})
+}
+
 func (s *ebsSuite) TestVolumeTags(c *gc.C) {
 	vs := s.volumeSource(c, nil)
 	results, err := s.createVolumes(vs, "")
@@ -389,6 +511,91 @@ func (s *ebsSuite) TestDestroyVolumesStillAttached(c *gc.C) {
 	c.Assert(ec2Vols.Volumes[0].Size, gc.Equals, 20)
 }
 
+func (s *ebsSuite) patchEC2Query(c *gc.C, responses ...string) *[]string {
+	var calls []string
+	s.PatchValue(ec2.ResizeVolumeAttempt, utils.AttemptStrategy{Total: time.Second})
+	s.PatchValue(ec2.EC2Query, func(
+		client *awsec2.EC2, action string, params map[string]string, resp interface{},
+	) error {
+		c.Assert(responses, gc.Not(gc.HasLen), 0)
+		calls = append(calls, fmt.Sprintf("%s %v", action, params))
+		body := responses[0]
+		responses = responses[1:]
+		if body == "" {
+			return &awsec2.Error{
+				StatusCode: 400,
+				Code:       "IncorrectModificationState",
+				Message:    "volume is being modified",
+			}
+		}
+		return xml.Unmarshal([]byte(body), resp)
+	})
+	return &calls
+}
+
+func (s *ebsSuite) TestResizeVolumes(c *gc.C) {
+	calls := s.patchEC2Query(c, `
+<ModifyVolumeResponse>
+  <volumeModification>
+    <volumeId>vol-0</volumeId>
+    <modificationState>modifying</modificationState>
+    <targetSize>3</targetSize>
+  </volumeModification>
+</ModifyVolumeResponse>`, `
+<DescribeVolumesModificationsResponse>
+  <volumeModificationSet>
+    <item>
+      <volumeId>vol-0</volumeId>
+      <modificationState>optimizing</modificationState>
+      <targetSize>3</targetSize>
+    </item>
+  </volumeModificationSet>
+</DescribeVolumesModificationsResponse>`)
+
+	vs := s.volumeSource(c, nil)
+	resizer, ok := vs.(storage.VolumeResizer)
+	c.Assert(ok, jc.IsTrue)
+	results, err := resizer.ResizeVolumes([]storage.VolumeResizeParams{{
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: "vol-0",
+		Size:     2500,
+		Provider: ec2.EBS_ProviderType,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.ResizeVolumesResult{{Size: 3072}})
+	c.Assert(*calls, jc.DeepEquals, []string{
+		"ModifyVolume map[Size:3 VolumeId:vol-0]",
+		"DescribeVolumesModifications map[VolumeId.1:vol-0]",
+	})
+}
+
+func (s *ebsSuite) TestResizeVolumesErrors(c *gc.C) {
+	s.patchEC2Query(c, "", `
+<ModifyVolumeResponse>
+  <volumeModification>
+    <volumeId>vol-1</volumeId>
+    <modificationState>failed</modificationState>
+    <statusMessage>out of capacity</statusMessage>
+    <targetSize>3</targetSize>
+  </volumeModification>
+</ModifyVolumeResponse>`)
+
+	vs := s.volumeSource(c, nil)
+	results, err := vs.(storage.VolumeResizer).ResizeVolumes([]storage.VolumeResizeParams{{
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: "vol-0",
+		Size:     2048,
+	}, {
+		Volume:   names.NewVolumeTag("1"),
+		VolumeId: "vol-1",
+		Size:     3072,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0].Error, gc.ErrorMatches, `resizing "vol-0": volume is being modified \(IncorrectModificationState\)`)
+	c.Assert(results[1].Error, gc.ErrorMatches, `resizing "vol-1" failed: out of capacity`)
+}
+
 func (s *ebsSuite) TestDescribeVolumes(c *gc.C) {
 	vs := s.volumeSource(c, nil)
 	s.assertCreateVolumes(c, vs, "")
@@ -0,0 +1,242 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"strconv"
+
+	"github.com/juju/errors"
+	"gopkg.in/amz.v3/ec2"
+
+	"github.com/juju/juju/network"
+)
+
+// allowAllEgressProtocol is the protocol of the egress permission EC2
+// adds to every new VPC security group, allowing all outgoing traffic.
+const allowAllEgressProtocol = "-1"
+
+// allowAllEgressPerm is the egress permission EC2 adds to every new
+// VPC security group.
+var allowAllEgressPerm = ec2.IPPerm{
+	Protocol:  allowAllEgressProtocol,
+	SourceIPs: []string{defaultRouteCIDRBlock},
+}
+
+// egressGroupInfo holds the egress permissions of a security group.
+// amz.v3 does not decode them from DescribeSecurityGroups responses.
+type egressGroupInfo struct {
+	Id    string       `xml:"groupId"`
+	VPCId string       `xml:"vpcId"`
+	Perms []ec2.IPPerm `xml:"ipPermissionsEgress>item"`
+}
+
+type describeEgressResp struct {
+	RequestId string            `xml:"requestId"`
+	Groups    []egressGroupInfo `xml:"securityGroupInfo>item"`
+}
+
+type egressResp struct {
+	RequestId string `xml:"requestId"`
+	Return    bool   `xml:"return"`
+}
+
+// egressGroupByName returns the egress permissions of the security
+// group with the given name. Egress rules are only supported by VPC
+// security groups; for EC2-Classic groups an error satisfying
+// errors.IsNotSupported is returned.
+func (e *environ) egressGroupByName(name string) (egressGroupInfo, error) {
+	g, err := e.groupByName(name)
+	if err != nil {
+		return egressGroupInfo{}, errors.Trace(err)
+	}
+	var resp describeEgressResp
+	if err := ec2Query(e.ec2, "DescribeSecurityGroups", map[string]string{
+		"GroupId.1": g.Id,
+	}, &resp); err != nil {
+		return egressGroupInfo{}, errors.Annotatef(err, "fetching security group %q", name)
+	}
+	if len(resp.Groups) != 1 {
+		return egressGroupInfo{}, errors.NotFoundf("security group %q", name)
+	}
+	group := resp.Groups[0]
+	if group.VPCId == "" {
+		return egressGroupInfo{}, errors.NotSupportedf("egress rules on EC2-Classic")
+	}
+	group.Id = g.Id
+	return group, nil
+}
+
+// egressParams returns the query parameters for authorizing or
+// revoking the given egress permissions of a security group.
+func egressParams(groupId string, perms []ec2.IPPerm) map[string]string {
+	params := map[string]string{"GroupId": groupId}
+	for i, perm := range perms {
+		prefix := "IpPermissions." + strconv.Itoa(i+1)
+		params[prefix+".IpProtocol"] = perm.Protocol
+		if perm.Protocol != allowAllEgressProtocol {
+			params[prefix+".FromPort"] = strconv.Itoa(perm.FromPort)
+			params[prefix+".ToPort"] = strconv.Itoa(perm.ToPort)
+		}
+		for j, ip := range perm.SourceIPs {
+			params[prefix+".IpRanges."+strconv.Itoa(j+1)+".CidrIp"] = ip
+		}
+	}
+	return params
+}
+
+// authorizeEgress adds the given egress permissions to the security
+// group. Permissions the group already has are ignored.
+func (e *environ) authorizeEgress(groupId string, perms []ec2.IPPerm) error {
+	var resp egressResp
+	err := ec2Query(e.ec2, "AuthorizeSecurityGroupEgress", egressParams(groupId, perms), &resp)
+	if err != nil && ec2ErrCode(err) == "InvalidPermission.Duplicate" {
+		if len(perms) == 1 {
+			return nil
+		}
+		// As with ingress, the permissions that were not duplicates
+		// have been ignored; authorize each one individually.
+		for i := range perms {
+			err := ec2Query(e.ec2, "AuthorizeSecurityGroupEgress", egressParams(groupId, perms[i:i+1]), &resp)
+			if err != nil && ec2ErrCode(err) != "InvalidPermission.Duplicate" {
+				return errors.Annotatef(err, "cannot open egress rule %v", perms[i])
+			}
+		}
+		return nil
+	}
+	return errors.Annotate(err, "cannot open egress rules")
+}
+
+// revokeEgress removes the given egress permissions from the security
+// group. Permissions the group does not have are ignored.
+func (e *environ) revokeEgress(groupId string, perms []ec2.IPPerm) error {
+	var resp egressResp
+	err := ec2Query(e.ec2, "RevokeSecurityGroupEgress", egressParams(groupId, perms), &resp)
+	if err != nil && ec2ErrCode(err) == "InvalidPermission.NotFound" {
+		if len(perms) == 1 {
+			return nil
+		}
+		for i := range perms {
+			err := ec2Query(e.ec2, "RevokeSecurityGroupEgress", egressParams(groupId, perms[i:i+1]), &resp)
+			if err != nil && ec2ErrCode(err) != "InvalidPermission.NotFound" {
+				return errors.Annotatef(err, "cannot close egress rule %v", perms[i])
+			}
+		}
+		return nil
+	}
+	return errors.Annotate(err, "cannot close egress rules")
+}
+
+func egressRulesToIPPerms(rules []network.EgressRule) []ec2.IPPerm {
+	ipPerms := make([]ec2.IPPerm, len(rules))
+	for i, r := range rules {
+		ipPerms[i] = ec2.IPPerm{
+			Protocol: r.Protocol,
+			FromPort: r.FromPort,
+			ToPort:   r.ToPort,
+		}
+		if len(r.DestinationCIDRs) == 0 {
+			ipPerms[i].SourceIPs = []string{defaultRouteCIDRBlock}
+		} else {
+			ipPerms[i].SourceIPs = make([]string, len(r.DestinationCIDRs))
+			copy(ipPerms[i].SourceIPs, r.DestinationCIDRs)
+		}
+	}
+	return ipPerms
+}
+
+// openEgressRulesInGroup adds the egress rules to the named group. EC2
+// allows all outgoing traffic from new VPC security groups, so that
+// permission is revoked from the group and from the juju group to make
+// the rules effective.
+func (e *environ) openEgressRulesInGroup(name string, rules []network.EgressRule) error {
+	if len(rules) == 0 {
+		return nil
+	}
+	group, err := e.egressGroupByName(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := e.authorizeEgress(group.Id, egressRulesToIPPerms(rules)); err != nil {
+		return errors.Trace(err)
+	}
+	jujuGroup, err := e.egressGroupByName(e.jujuGroupName())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, g := range []egressGroupInfo{group, jujuGroup} {
+		if !hasAllowAllEgressPerm(g.Perms) {
+			continue
+		}
+		if err := e.revokeEgress(g.Id, []ec2.IPPerm{allowAllEgressPerm}); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// closeEgressRulesInGroup removes the egress rules from the named
+// group. Once the group has no egress rules left, all outgoing traffic
+// is allowed again.
+func (e *environ) closeEgressRulesInGroup(name string, rules []network.EgressRule) error {
+	if len(rules) == 0 {
+		return nil
+	}
+	group, err := e.egressGroupByName(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := e.revokeEgress(group.Id, egressRulesToIPPerms(rules)); err != nil {
+		return errors.Trace(err)
+	}
+	group, err = e.egressGroupByName(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(group.Perms) > 0 {
+		return nil
+	}
+	// The last egress rule has gone, so outgoing traffic is no longer
+	// restricted.
+	return e.authorizeEgress(group.Id, []ec2.IPPerm{allowAllEgressPerm})
+}
+
+func (e *environ) egressRulesInGroup(name string) (rules []network.EgressRule, err error) {
+	group, err := e.egressGroupByName(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, p := range group.Perms {
+		// Skip the permission allowing all outgoing traffic
+		// created by EC2.
+		if p.Protocol == allowAllEgressProtocol {
+			continue
+		}
+		ips := p.SourceIPs
+		if len(ips) == 0 {
+			ips = []string{defaultRouteCIDRBlock}
+		}
+		rule, err := network.NewEgressRule(p.Protocol, p.FromPort, p.ToPort, ips...)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		rules = append(rules, rule)
+	}
+	return network.MergeEgressRules(rules), nil
+}
+
+// hasAllowAllEgressPerm reports whether the permissions include the
+// one allowing all outgoing traffic.
+func hasAllowAllEgressPerm(perms []ec2.IPPerm) bool {
+	for _, p := range perms {
+		if p.Protocol != allowAllEgressProtocol {
+			continue
+		}
+		for _, ip := range p.SourceIPs {
+			if ip == defaultRouteCIDRBlock {
+				return true
+			}
+		}
+	}
+	return false
+}
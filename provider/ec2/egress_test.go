@@ -0,0 +1,202 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2_test
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/set"
+	amzec2 "gopkg.in/amz.v3/ec2"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/provider/ec2"
+)
+
+// fakeEgress emulates the egress security group actions that ec2test
+// does not support. Permissions are held per group as
+// "<protocol> <from> <to> <cidr>" strings; every group starts with the
+// permission allowing all outgoing traffic.
+type fakeEgress struct {
+	vpcId string
+	perms map[string]set.Strings
+	calls []string
+}
+
+const allowAllEgress = "-1 0 0 0.0.0.0/0"
+
+func (f *fakeEgress) groupPerms(groupId string) set.Strings {
+	perms, ok := f.perms[groupId]
+	if !ok {
+		perms = set.NewStrings(allowAllEgress)
+		f.perms[groupId] = perms
+	}
+	return perms
+}
+
+func (f *fakeEgress) query(_ *amzec2.EC2, action string, params map[string]string, resp interface{}) error {
+	f.calls = append(f.calls, action)
+	switch action {
+	case "DescribeSecurityGroups":
+		groupId := params["GroupId.1"]
+		var items []string
+		for _, perm := range f.groupPerms(groupId).SortedValues() {
+			var protocol, from, to, cidr string
+			fmt.Sscan(perm, &protocol, &from, &to, &cidr)
+			items = append(items, fmt.Sprintf(
+				"<item><ipProtocol>%s</ipProtocol><fromPort>%s</fromPort><toPort>%s</toPort>"+
+					"<ipRanges><item><cidrIp>%s</cidrIp></item></ipRanges></item>",
+				protocol, from, to, cidr,
+			))
+		}
+		body := fmt.Sprintf(
+			"<DescribeSecurityGroupsResponse><securityGroupInfo><item><groupId>%s</groupId>"+
+				"<vpcId>%s</vpcId><ipPermissionsEgress>%s</ipPermissionsEgress></item>"+
+				"</securityGroupInfo></DescribeSecurityGroupsResponse>",
+			groupId, f.vpcId, strings.Join(items, ""),
+		)
+		return xml.Unmarshal([]byte(body), resp)
+	case "AuthorizeSecurityGroupEgress", "RevokeSecurityGroupEgress":
+		perms := f.groupPerms(params["GroupId"])
+		for i := 1; ; i++ {
+			prefix := "IpPermissions." + strconv.Itoa(i)
+			protocol, ok := params[prefix+".IpProtocol"]
+			if !ok {
+				break
+			}
+			from, to := params[prefix+".FromPort"], params[prefix+".ToPort"]
+			if protocol == "-1" {
+				from, to = "0", "0"
+			}
+			for j := 1; ; j++ {
+				cidr, ok := params[prefix+".IpRanges."+strconv.Itoa(j)+".CidrIp"]
+				if !ok {
+					break
+				}
+				perm := strings.Join([]string{protocol, from, to, cidr}, " ")
+				if action == "AuthorizeSecurityGroupEgress" {
+					perms.Add(perm)
+				} else {
+					perms.Remove(perm)
+				}
+			}
+		}
+		return xml.Unmarshal([]byte("<Response><return>true</return></Response>"), resp)
+	}
+	return errors.Errorf("unexpected action %q", action)
+}
+
+func (t *localServerSuite) prepareEgress(c *gc.C, vpcId string) (environs.Environ, *fakeEgress, map[string]string) {
+	if vpcId != "" {
+		t.srv.defaultVPC.IsDefault = false
+		err := t.srv.ec2srv.UpdateVPC(*t.srv.defaultVPC)
+		c.Assert(err, jc.ErrorIsNil)
+		t.TestConfig["vpc-id"] = vpcId
+		defer delete(t.TestConfig, "vpc-id")
+	}
+	t.TestConfig["firewall-mode"] = "global"
+	defer delete(t.TestConfig, "firewall-mode")
+	env := t.Prepare(c)
+
+	groupIds := make(map[string]string)
+	for _, name := range []string{ec2.JujuGroupName(env), ec2.GlobalGroupName(env)} {
+		resp, err := t.client.CreateSecurityGroup(vpcId, name, "juju group")
+		c.Assert(err, jc.ErrorIsNil)
+		groupIds[name] = resp.Id
+	}
+
+	fake := &fakeEgress{vpcId: vpcId, perms: make(map[string]set.Strings)}
+	t.PatchValue(ec2.EC2Query, fake.query)
+	return env, fake, groupIds
+}
+
+func (t *localServerSuite) TestOpenEgressRules(c *gc.C) {
+	env, fake, groupIds := t.prepareEgress(c, t.srv.defaultVPC.Id)
+	fw := env.(environs.EgressFirewaller)
+
+	err := fw.OpenEgressRules([]network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8", "192.168.0.0/16"),
+		network.MustNewEgressRule("udp", 53, 53),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	rules, err := fw.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8", "192.168.0.0/16"),
+		network.MustNewEgressRule("udp", 53, 53, "0.0.0.0/0"),
+	})
+
+	// Outgoing traffic is no longer allowed everywhere, from the
+	// global group nor the juju group.
+	globalPerms := fake.perms[groupIds[ec2.GlobalGroupName(env)]]
+	c.Assert(globalPerms.SortedValues(), jc.DeepEquals, []string{
+		"tcp 443 443 10.0.0.0/8",
+		"tcp 443 443 192.168.0.0/16",
+		"udp 53 53 0.0.0.0/0",
+	})
+	jujuPerms := fake.perms[groupIds[ec2.JujuGroupName(env)]]
+	c.Assert(jujuPerms.IsEmpty(), jc.IsTrue)
+}
+
+func (t *localServerSuite) TestCloseEgressRules(c *gc.C) {
+	env, fake, groupIds := t.prepareEgress(c, t.srv.defaultVPC.Id)
+	fw := env.(environs.EgressFirewaller)
+
+	err := fw.OpenEgressRules([]network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+		network.MustNewEgressRule("udp", 53, 53),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = fw.CloseEgressRules([]network.EgressRule{
+		network.MustNewEgressRule("udp", 53, 53),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err := fw.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+	})
+
+	// Closing the last rule allows all outgoing traffic again.
+	err = fw.CloseEgressRules([]network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err = fw.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, gc.HasLen, 0)
+	globalPerms := fake.perms[groupIds[ec2.GlobalGroupName(env)]]
+	c.Assert(globalPerms.SortedValues(), jc.DeepEquals, []string{allowAllEgress})
+}
+
+func (t *localServerSuite) TestEgressRulesNotSupportedOnEC2Classic(c *gc.C) {
+	env, fake, _ := t.prepareEgress(c, "")
+	fw := env.(environs.EgressFirewaller)
+
+	err := fw.OpenEgressRules([]network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443),
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	_, err = fw.EgressRules()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(fake.calls, jc.DeepEquals, []string{"DescribeSecurityGroups", "DescribeSecurityGroups"})
+}
+
+func (t *localServerSuite) TestEgressRulesInvalidFirewallMode(c *gc.C) {
+	t.TestConfig["firewall-mode"] = "instance"
+	defer delete(t.TestConfig, "firewall-mode")
+	env := t.Prepare(c)
+	err := env.(environs.EgressFirewaller).OpenEgressRules([]network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443),
+	})
+	c.Assert(err, gc.ErrorMatches, `invalid firewall mode "instance" for opening egress rules on model`)
+}
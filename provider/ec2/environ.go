@@ -58,6 +58,8 @@ var (
 	aliveInstanceStates = []string{"pending", "running"}
 )
 
+var _ environs.EgressFirewaller = (*environ)(nil)
+
 type environ struct {
 	name  string
 	cloud environs.CloudSpec
@@ -1331,6 +1333,38 @@ func (e *environ) IngressRules() ([]network.IngressRule, error) {
 	return e.ingressRulesInGroup(e.globalGroupName())
 }
 
+// OpenEgressRules is specified in environs.EgressFirewaller.
+func (e *environ) OpenEgressRules(rules []network.EgressRule) error {
+	if e.Config().FirewallMode() != config.FwGlobal {
+		return errors.Errorf("invalid firewall mode %q for opening egress rules on model", e.Config().FirewallMode())
+	}
+	if err := e.openEgressRulesInGroup(e.globalGroupName(), rules); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("opened egress rules in global group: %v", rules)
+	return nil
+}
+
+// CloseEgressRules is specified in environs.EgressFirewaller.
+func (e *environ) CloseEgressRules(rules []network.EgressRule) error {
+	if e.Config().FirewallMode() != config.FwGlobal {
+		return errors.Errorf("invalid firewall mode %q for closing egress rules on model", e.Config().FirewallMode())
+	}
+	if err := e.closeEgressRulesInGroup(e.globalGroupName(), rules); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("closed egress rules in global group: %v", rules)
+	return nil
+}
+
+// EgressRules is specified in environs.EgressFirewaller.
+func (e *environ) EgressRules() ([]network.EgressRule, error) {
+	if e.Config().FirewallMode() != config.FwGlobal {
+		return nil, errors.Errorf("invalid firewall mode %q for retrieving egress rules from model", e.Config().FirewallMode())
+	}
+	return e.egressRulesInGroup(e.globalGroupName())
+}
+
 func (*environ) Provider() environs.EnvironProvider {
 	return &providerInstance
 }
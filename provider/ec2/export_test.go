@@ -25,6 +25,10 @@ func JujuGroupName(e environs.Environ) string {
 	return e.(*environ).jujuGroupName()
 }
 
+func GlobalGroupName(e environs.Environ) string {
+	return e.(*environ).globalGroupName()
+}
+
 func MachineGroupName(e environs.Environ, machineId string) string {
 	return e.(*environ).machineGroupName(machineId)
 }
@@ -80,6 +84,11 @@ func UseTestImageData(c *gc.C, files map[string]string) {
 var (
 	ShortAttempt                   = &shortAttempt
 	DestroyVolumeAttempt           = &destroyVolumeAttempt
+	ResizeVolumeAttempt            = &resizeVolumeAttempt
+	EC2Query                       = &ec2Query
+	CreateSnapshot                 = &createSnapshot
+	TagSnapshot                    = &tagSnapshot
+	DeleteSnapshot                 = &deleteSnapshot
 	DeleteSecurityGroupInsistently = &deleteSecurityGroupInsistently
 	TerminateInstancesById         = &terminateInstancesById
 )
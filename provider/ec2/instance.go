@@ -24,7 +24,10 @@ func (inst *ec2Instance) String() string {
 	return string(inst.Id())
 }
 
-var _ instance.Instance = (*ec2Instance)(nil)
+var (
+	_ instance.Instance         = (*ec2Instance)(nil)
+	_ instance.EgressFirewaller = (*ec2Instance)(nil)
+)
 
 func (inst *ec2Instance) Id() instance.Id {
 	return instance.Id(inst.InstanceId)
@@ -112,3 +115,40 @@ func (inst *ec2Instance) IngressRules(machineId string) ([]network.IngressRule,
 	}
 	return ranges, nil
 }
+
+// OpenEgressRules is specified in instance.EgressFirewaller.
+func (inst *ec2Instance) OpenEgressRules(machineId string, rules []network.EgressRule) error {
+	if inst.e.Config().FirewallMode() != config.FwInstance {
+		return fmt.Errorf("invalid firewall mode %q for opening egress rules on instance",
+			inst.e.Config().FirewallMode())
+	}
+	name := inst.e.machineGroupName(machineId)
+	if err := inst.e.openEgressRulesInGroup(name, rules); err != nil {
+		return err
+	}
+	logger.Infof("opened egress rules in security group %s: %v", name, rules)
+	return nil
+}
+
+// CloseEgressRules is specified in instance.EgressFirewaller.
+func (inst *ec2Instance) CloseEgressRules(machineId string, rules []network.EgressRule) error {
+	if inst.e.Config().FirewallMode() != config.FwInstance {
+		return fmt.Errorf("invalid firewall mode %q for closing egress rules on instance",
+			inst.e.Config().FirewallMode())
+	}
+	name := inst.e.machineGroupName(machineId)
+	if err := inst.e.closeEgressRulesInGroup(name, rules); err != nil {
+		return err
+	}
+	logger.Infof("closed egress rules in security group %s: %v", name, rules)
+	return nil
+}
+
+// EgressRules is specified in instance.EgressFirewaller.
+func (inst *ec2Instance) EgressRules(machineId string) ([]network.EgressRule, error) {
+	if inst.e.Config().FirewallMode() != config.FwInstance {
+		return nil, fmt.Errorf("invalid firewall mode %q for retrieving egress rules from instance",
+			inst.e.Config().FirewallMode())
+	}
+	return inst.e.egressRulesInGroup(inst.e.machineGroupName(machineId))
+}
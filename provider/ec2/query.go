@@ -0,0 +1,74 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"encoding/xml"
+	"net/http"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/amz.v3/aws"
+	"gopkg.in/amz.v3/ec2"
+)
+
+// queryAPIVersion is the EC2 API version used for requests that
+// amz.v3 does not support. It must be recent enough to include
+// ModifyVolume and DescribeVolumesModifications.
+const queryAPIVersion = "2016-11-15"
+
+// ec2Query issues an EC2 Query API request for the given action,
+// signed with the client's credentials, and decodes the XML response
+// into resp. It exists to support actions that amz.v3 does not yet
+// provide; errors are returned as *ec2.Error, like those from amz.v3.
+var ec2Query = func(client *ec2.EC2, action string, params map[string]string, resp interface{}) error {
+	req, err := http.NewRequest("GET", client.Region.EC2Endpoint, nil)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	query := req.URL.Query()
+	for name, value := range params {
+		query.Add(name, value)
+	}
+	now := time.Now().UTC()
+	query.Set("Action", action)
+	query.Set("Version", queryAPIVersion)
+	query.Set("Timestamp", now.Format(time.RFC3339))
+	req.URL.RawQuery = query.Encode()
+	req.Header.Set("x-amz-date", now.Format(aws.ISO8601BasicFormat))
+	if err := client.Sign(req, client.Auth); err != nil {
+		return errors.Annotate(err, "signing request")
+	}
+
+	r, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer r.Body.Close()
+	if r.StatusCode != http.StatusOK {
+		return queryError(r)
+	}
+	if err := xml.NewDecoder(r.Body).Decode(resp); err != nil {
+		return errors.Annotatef(err, "decoding %s response", action)
+	}
+	return nil
+}
+
+func queryError(r *http.Response) error {
+	var resp struct {
+		Errors    []ec2.Error `xml:"Errors>Error"`
+		RequestId string      `xml:"RequestID"`
+	}
+	xml.NewDecoder(r.Body).Decode(&resp)
+	var err ec2.Error
+	if len(resp.Errors) > 0 {
+		err = resp.Errors[0]
+	}
+	err.RequestId = resp.RequestId
+	err.StatusCode = r.StatusCode
+	if err.Message == "" {
+		err.Message = r.Status
+	}
+	return &err
+}
@@ -0,0 +1,104 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"gopkg.in/amz.v3/aws"
+	awsec2 "gopkg.in/amz.v3/ec2"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/provider/ec2"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type querySuite struct {
+	coretesting.BaseSuite
+
+	server   *httptest.Server
+	client   *awsec2.EC2
+	requests []*http.Request
+	status   int
+	body     string
+}
+
+var _ = gc.Suite(&querySuite{})
+
+func (s *querySuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.requests = nil
+	s.status = http.StatusOK
+	s.body = ""
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		s.requests = append(s.requests, req)
+		w.WriteHeader(s.status)
+		w.Write([]byte(s.body))
+	}))
+	s.AddCleanup(func(*gc.C) { s.server.Close() })
+	region := aws.Region{Name: "test", EC2Endpoint: s.server.URL}
+	s.client = awsec2.New(
+		aws.Auth{AccessKey: "access", SecretKey: "secret"},
+		region, aws.SignV4Factory(region.Name, "ec2"),
+	)
+}
+
+func (s *querySuite) TestQuery(c *gc.C) {
+	s.body = `
+<ModifyVolumeResponse>
+  <requestId>req-0</requestId>
+  <volumeModification>
+    <targetSize>3</targetSize>
+  </volumeModification>
+</ModifyVolumeResponse>`
+	var resp struct {
+		RequestId  string `xml:"requestId"`
+		TargetSize int    `xml:"volumeModification>targetSize"`
+	}
+	err := (*ec2.EC2Query)(s.client, "ModifyVolume", map[string]string{
+		"VolumeId": "vol-0",
+		"Size":     "3",
+	}, &resp)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resp.RequestId, gc.Equals, "req-0")
+	c.Assert(resp.TargetSize, gc.Equals, 3)
+
+	c.Assert(s.requests, gc.HasLen, 1)
+	req := s.requests[0]
+	c.Assert(req.Method, gc.Equals, "GET")
+	c.Assert(req.Header.Get("Authorization"), jc.HasPrefix, "AWS4-HMAC-SHA256 Credential=access/")
+	query, err := url.ParseQuery(req.URL.RawQuery)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(query.Get("Action"), gc.Equals, "ModifyVolume")
+	c.Assert(query.Get("Version"), gc.Equals, "2016-11-15")
+	c.Assert(query.Get("VolumeId"), gc.Equals, "vol-0")
+	c.Assert(query.Get("Size"), gc.Equals, "3")
+	c.Assert(query.Get("Timestamp"), gc.Not(gc.Equals), "")
+}
+
+func (s *querySuite) TestQueryError(c *gc.C) {
+	s.status = http.StatusBadRequest
+	s.body = `
+<Response>
+  <Errors>
+    <Error>
+      <Code>InvalidVolume.NotFound</Code>
+      <Message>no such volume</Message>
+    </Error>
+  </Errors>
+  <RequestID>req-1</RequestID>
+</Response>`
+	var resp struct{}
+	err := (*ec2.EC2Query)(s.client, "ModifyVolume", nil, &resp)
+	c.Assert(err, gc.ErrorMatches, `no such volume \(InvalidVolume.NotFound\)`)
+	c.Assert(ec2.EC2ErrCode(err), gc.Equals, "InvalidVolume.NotFound")
+	ec2err, ok := errors.Cause(err).(*awsec2.Error)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(ec2err.StatusCode, gc.Equals, http.StatusBadRequest)
+	c.Assert(ec2err.RequestId, gc.Equals, "req-1")
+}
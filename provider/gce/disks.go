@@ -64,6 +64,9 @@ func (g *storageProvider) FilesystemSource(providerConfig *storage.Config) (stor
 	return nil, errors.NotSupportedf("filesystems")
 }
 
+var _ storage.VolumeSnapshotter = (*volumeSource)(nil)
+var _ storage.VolumeResizer = (*volumeSource)(nil)
+
 type volumeSource struct {
 	gce       gceConnection
 	envName   string // non-unique, informational only
@@ -205,6 +208,7 @@ func (v *volumeSource) createOneVolume(p storage.VolumeParams, instances instanc
 		Name:               volumeName,
 		PersistentDiskType: persistentType,
 		Description:        v.modelUUID,
+		SnapshotName:       p.SnapshotId,
 	}
 
 	gceDisks, err := v.gce.CreateDisks(zone, []google.DiskSpec{disk})
@@ -245,6 +249,81 @@ func (v *volumeSource) createOneVolume(p storage.VolumeParams, instances instanc
 	return volume, volumeAttachment, nil
 }
 
+// CreateVolumeSnapshots is specified on the storage.VolumeSnapshotter interface.
+func (v *volumeSource) CreateVolumeSnapshots(params []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error) {
+	results := make([]storage.CreateVolumeSnapshotsResult, len(params))
+	for i, p := range params {
+		snapshot, err := v.createOneVolumeSnapshot(p)
+		if err != nil {
+			results[i].Error = err
+			continue
+		}
+		results[i].VolumeSnapshot = snapshot
+	}
+	return results, nil
+}
+
+func (v *volumeSource) createOneVolumeSnapshot(p storage.VolumeSnapshotParams) (*storage.VolumeSnapshot, error) {
+	zone, _, err := parseVolumeId(p.VolumeId)
+	if err != nil {
+		return nil, errors.Annotatef(err, "invalid volume id %q", p.VolumeId)
+	}
+	snapshotUUID, err := utils.NewUUID()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot generate uuid to name the snapshot")
+	}
+	// Snapshots are global, so unlike volumes their
+	// names do not need to be qualified by zone.
+	snapshotName := "snap-" + snapshotUUID.String()
+	snapshot, err := v.gce.CreateSnapshot(zone, p.VolumeId, snapshotName)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot snapshot volume %q", p.VolumeId)
+	}
+	return &storage.VolumeSnapshot{
+		Volume:     p.Volume,
+		SnapshotId: snapshot.Name,
+		Size:       snapshot.Size,
+	}, nil
+}
+
+// ResizeVolumes is specified on the storage.VolumeResizer interface.
+func (v *volumeSource) ResizeVolumes(params []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	results := make([]storage.ResizeVolumesResult, len(params))
+	for i, p := range params {
+		size, err := v.resizeOneVolume(p)
+		if err != nil {
+			results[i].Error = err
+			continue
+		}
+		results[i].Size = size
+	}
+	return results, nil
+}
+
+func (v *volumeSource) resizeOneVolume(p storage.VolumeResizeParams) (uint64, error) {
+	zone, _, err := parseVolumeId(p.VolumeId)
+	if err != nil {
+		return 0, errors.Annotatef(err, "invalid volume id %q", p.VolumeId)
+	}
+	sizeGB := mibToGib(p.Size)
+	if err := v.gce.ResizeDisk(zone, p.VolumeId, sizeGB); err != nil {
+		return 0, errors.Annotatef(err, "cannot resize volume %q", p.VolumeId)
+	}
+	return sizeGB * 1024, nil
+}
+
+// DestroyVolumeSnapshots is specified on the storage.VolumeSnapshotter interface.
+func (v *volumeSource) DestroyVolumeSnapshots(snapshotNames []string) ([]error, error) {
+	results := make([]error, len(snapshotNames))
+	for i, snapshotName := range snapshotNames {
+		err := v.gce.RemoveSnapshot(snapshotName)
+		if err != nil && !errors.IsNotFound(err) {
+			results[i] = errors.Annotatef(err, "cannot destroy snapshot %q", snapshotName)
+		}
+	}
+	return results, nil
+}
+
 func (v *volumeSource) DestroyVolumes(volNames []string) ([]error, error) {
 	var wg sync.WaitGroup
 	wg.Add(len(volNames))
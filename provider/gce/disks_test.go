@@ -166,6 +166,99 @@ func (s *volumeSourceSuite) TestCreateVolumes(c *gc.C) {
 	c.Assert(call[0].InstanceId, gc.Equals, string(s.instId))
 }
 
+func (s *volumeSourceSuite) TestCreateVolumesFromSnapshot(c *gc.C) {
+	s.FakeConn.Insts = []google.Instance{*s.BaseInstance}
+	s.FakeConn.GoogleDisks = []*google.Disk{s.BaseDisk}
+	s.FakeConn.GoogleDisk = s.BaseDisk
+	s.FakeConn.AttachedDisk = &google.AttachedDisk{
+		VolumeName: s.BaseDisk.Name,
+		DeviceName: "home-zone-1234567",
+		Mode:       "READ_WRITE",
+	}
+	s.params[0].SnapshotId = "snap-123"
+	res, err := s.source.CreateVolumes(s.params)
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(res, gc.HasLen, 1)
+	c.Assert(res[0].Error, jc.ErrorIsNil)
+
+	createCalled, call := s.FakeConn.WasCalled("CreateDisks")
+	c.Check(call, gc.HasLen, 1)
+	c.Assert(createCalled, jc.IsTrue)
+	c.Assert(call[0].Disks[0].SnapshotName, gc.Equals, "snap-123")
+}
+
+func (s *volumeSourceSuite) TestCreateVolumeSnapshots(c *gc.C) {
+	s.FakeConn.Snapshot = &google.Snapshot{Name: "snap-123", Size: 10240}
+	snapshotter, ok := s.source.(storage.VolumeSnapshotter)
+	c.Assert(ok, jc.IsTrue)
+	res, err := snapshotter.CreateVolumeSnapshots([]storage.VolumeSnapshotParams{{
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: "home-zone--volume-name",
+		Provider: "gce",
+	}, {
+		Volume:   names.NewVolumeTag("1"),
+		VolumeId: "invalid",
+		Provider: "gce",
+	}})
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(res, gc.HasLen, 2)
+	c.Assert(res[0].Error, jc.ErrorIsNil)
+	c.Assert(res[0].VolumeSnapshot, jc.DeepEquals, &storage.VolumeSnapshot{
+		Volume:     names.NewVolumeTag("0"),
+		SnapshotId: "snap-123",
+		Size:       10240,
+	})
+	c.Assert(res[1].Error, gc.ErrorMatches, `invalid volume id "invalid": malformed volume id "invalid"`)
+
+	snapshotCalled, call := s.FakeConn.WasCalled("CreateSnapshot")
+	c.Check(call, gc.HasLen, 1)
+	c.Assert(snapshotCalled, jc.IsTrue)
+	c.Assert(call[0].ZoneName, gc.Equals, "home-zone")
+	c.Assert(call[0].VolumeName, gc.Equals, "home-zone--volume-name")
+	c.Assert(call[0].ID, jc.HasPrefix, "snap-")
+}
+
+func (s *volumeSourceSuite) TestDestroyVolumeSnapshots(c *gc.C) {
+	snapshotter, ok := s.source.(storage.VolumeSnapshotter)
+	c.Assert(ok, jc.IsTrue)
+	errs, err := snapshotter.DestroyVolumeSnapshots([]string{"snap-123"})
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(errs, jc.DeepEquals, []error{nil})
+
+	removeCalled, call := s.FakeConn.WasCalled("RemoveSnapshot")
+	c.Check(call, gc.HasLen, 1)
+	c.Assert(removeCalled, jc.IsTrue)
+	c.Assert(call[0].ID, gc.Equals, "snap-123")
+}
+
+func (s *volumeSourceSuite) TestResizeVolumes(c *gc.C) {
+	resizer, ok := s.source.(storage.VolumeResizer)
+	c.Assert(ok, jc.IsTrue)
+	res, err := resizer.ResizeVolumes([]storage.VolumeResizeParams{{
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: "home-zone--volume-name",
+		Size:     3000,
+		Provider: "gce",
+	}, {
+		Volume:   names.NewVolumeTag("1"),
+		VolumeId: "invalid",
+		Size:     3000,
+		Provider: "gce",
+	}})
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(res, gc.HasLen, 2)
+	// Sizes are rounded up to the nearest GiB.
+	c.Assert(res[0], jc.DeepEquals, storage.ResizeVolumesResult{Size: 3072})
+	c.Assert(res[1].Error, gc.ErrorMatches, `invalid volume id "invalid": malformed volume id "invalid"`)
+
+	resizeCalled, call := s.FakeConn.WasCalled("ResizeDisk")
+	c.Check(call, gc.HasLen, 1)
+	c.Assert(resizeCalled, jc.IsTrue)
+	c.Assert(call[0].ZoneName, gc.Equals, "home-zone")
+	c.Assert(call[0].VolumeName, gc.Equals, "home-zone--volume-name")
+	c.Assert(call[0].Size, gc.Equals, uint64(3))
+}
+
 func (s *volumeSourceSuite) TestDestroyVolumes(c *gc.C) {
 	errs, err := s.source.DestroyVolumes([]string{"a--volume-name"})
 	c.Check(err, jc.ErrorIsNil)
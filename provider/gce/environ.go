@@ -34,6 +34,10 @@ type gceConnection interface {
 	OpenPorts(fwname string, rules ...network.IngressRule) error
 	ClosePorts(fwname string, rules ...network.IngressRule) error
 
+	EgressRules(target string) ([]network.EgressRule, error)
+	OpenEgressRules(target string, rules ...network.EgressRule) error
+	CloseEgressRules(target string, rules ...network.EgressRule) error
+
 	AvailabilityZones(region string) ([]google.AvailabilityZone, error)
 
 	// Storage related methods.
@@ -54,6 +58,13 @@ type gceConnection interface {
 	// DetachDisk will detach <volumeName> disk from <instanceId> if possible
 	// and return error.
 	DetachDisk(zone, instanceId, volumeName string) error
+	// CreateSnapshot will create a snapshot named <snapshotName> of the
+	// disk <diskName> in <zone>, and return a Snapshot representing it.
+	CreateSnapshot(zone, diskName, snapshotName string) (*google.Snapshot, error)
+	// RemoveSnapshot will destroy the snapshot identified by <name>.
+	RemoveSnapshot(name string) error
+	// ResizeDisk will grow the disk <diskName> in <zone> to <sizeGB>.
+	ResizeDisk(zone, diskName string, sizeGB uint64) error
 	// InstanceDisks returns a list of the disks attached to the passed instance.
 	InstanceDisks(zone, instanceId string) ([]*google.AttachedDisk, error)
 	// ListMachineTypes returns a list of machines available in the project and zone provided.
@@ -6,10 +6,13 @@ package gce
 import (
 	"github.com/juju/errors"
 
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/provider/common"
 )
 
+var _ environs.EgressFirewaller = (*environ)(nil)
+
 // globalFirewallName returns the name to use for the global firewall.
 func (env *environ) globalFirewallName() string {
 	return common.EnvFullName(env.uuid)
@@ -38,3 +41,27 @@ func (env *environ) IngressRules() ([]network.IngressRule, error) {
 	rules, err := env.gce.IngressRules(env.globalFirewallName())
 	return rules, errors.Trace(err)
 }
+
+// OpenEgressRules allows outgoing traffic matching the given rules for
+// the whole environment. Must only be used if the environment was setup
+// with the FwGlobal firewall mode.
+func (env *environ) OpenEgressRules(rules []network.EgressRule) error {
+	err := env.gce.OpenEgressRules(env.globalFirewallName(), rules...)
+	return errors.Trace(err)
+}
+
+// CloseEgressRules removes the given egress rules from the whole
+// environment. Must only be used if the environment was setup with the
+// FwGlobal firewall mode.
+func (env *environ) CloseEgressRules(rules []network.EgressRule) error {
+	err := env.gce.CloseEgressRules(env.globalFirewallName(), rules...)
+	return errors.Trace(err)
+}
+
+// EgressRules returns the egress rules applicable for the whole
+// environment. Must only be used if the environment was setup with the
+// FwGlobal firewall mode.
+func (env *environ) EgressRules() ([]network.EgressRule, error) {
+	rules, err := env.gce.EgressRules(env.globalFirewallName())
+	return rules, errors.Trace(err)
+}
@@ -7,6 +7,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/provider/gce"
 )
 
@@ -75,3 +76,41 @@ func (s *environNetSuite) TestPortsAPI(c *gc.C) {
 	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "Ports")
 	c.Check(s.FakeConn.Calls[0].FirewallName, gc.Equals, fwname)
 }
+
+var egressRules = []network.EgressRule{
+	network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+}
+
+func (s *environNetSuite) TestOpenEgressRulesAPI(c *gc.C) {
+	fwname := gce.GlobalFirewallName(s.Env)
+	err := s.Env.OpenEgressRules(egressRules)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "OpenEgressRules")
+	c.Check(s.FakeConn.Calls[0].FirewallName, gc.Equals, fwname)
+	c.Check(s.FakeConn.Calls[0].EgressRules, jc.DeepEquals, egressRules)
+}
+
+func (s *environNetSuite) TestCloseEgressRulesAPI(c *gc.C) {
+	fwname := gce.GlobalFirewallName(s.Env)
+	err := s.Env.CloseEgressRules(egressRules)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "CloseEgressRules")
+	c.Check(s.FakeConn.Calls[0].FirewallName, gc.Equals, fwname)
+	c.Check(s.FakeConn.Calls[0].EgressRules, jc.DeepEquals, egressRules)
+}
+
+func (s *environNetSuite) TestEgressRules(c *gc.C) {
+	s.FakeConn.Egress = egressRules
+
+	rules, err := s.Env.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(rules, jc.DeepEquals, egressRules)
+	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "EgressRules")
+	c.Check(s.FakeConn.Calls[0].FirewallName, gc.Equals, gce.GlobalFirewallName(s.Env))
+}
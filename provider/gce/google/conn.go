@@ -4,6 +4,8 @@
 package google
 
 import (
+	"net/http"
+
 	"github.com/juju/errors"
 	"google.golang.org/api/compute/v1"
 )
@@ -71,6 +73,13 @@ type rawConnectionWrapper interface {
 	// Detach disk detaches device diskDeviceName (if it exists and its attached)
 	// form the machine with id instanceId.
 	DetachDisk(project, zone, instanceId, diskDeviceName string) error
+	// CreateSnapshot will create a snapshot of the disk identified by
+	// disk, as specified in spec, and return the created snapshot.
+	CreateSnapshot(project, zone, disk string, spec *compute.Snapshot) (*compute.Snapshot, error)
+	// RemoveSnapshot will delete the snapshot identified by name.
+	RemoveSnapshot(project, name string) error
+	// ResizeDisk will grow the disk identified by disk to sizeGb.
+	ResizeDisk(project, zone, disk string, sizeGb int64) error
 	// InstanceDisks returns the disks attached to the instance identified
 	// by instanceId
 	InstanceDisks(project, zone, instanceId string) ([]*compute.AttachedDisk, error)
@@ -100,20 +109,20 @@ type Connection struct {
 // result in an error. All errors that happen while authenticating and
 // connecting are returned by Connect.
 func Connect(connCfg ConnectionConfig, creds *Credentials) (*Connection, error) {
-	raw, err := newRawConnection(creds)
+	raw, client, err := newRawConnection(creds)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 
 	conn := &Connection{
-		raw:       &rawConn{raw},
+		raw:       &rawConn{raw, client},
 		region:    connCfg.Region,
 		projectID: connCfg.ProjectID,
 	}
 	return conn, nil
 }
 
-var newRawConnection = func(creds *Credentials) (*compute.Service, error) {
+var newRawConnection = func(creds *Credentials) (*compute.Service, *http.Client, error) {
 	return newConnection(creds)
 }
 
@@ -59,6 +59,34 @@ func (gce *Connection) Disk(zone, name string) (*Disk, error) {
 	return NewDisk(d), nil
 }
 
+// CreateSnapshot implements storage section of gceConnection.
+func (gce *Connection) CreateSnapshot(zone, diskName, snapshotName string) (*Snapshot, error) {
+	snapshot, err := gce.raw.CreateSnapshot(gce.projectID, zone, diskName, &compute.Snapshot{
+		Name: snapshotName,
+	})
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot create snapshot %q", snapshotName)
+	}
+	return &Snapshot{
+		Name: snapshot.Name,
+		Size: gibToMib(snapshot.DiskSizeGb),
+	}, nil
+}
+
+// RemoveSnapshot implements storage section of gceConnection.
+func (gce *Connection) RemoveSnapshot(name string) error {
+	return gce.raw.RemoveSnapshot(gce.projectID, name)
+}
+
+// ResizeDisk implements storage section of gceConnection.
+func (gce *Connection) ResizeDisk(zone, diskName string, sizeGB uint64) error {
+	err := gce.raw.ResizeDisk(gce.projectID, zone, diskName, int64(sizeGB))
+	if err != nil {
+		return errors.Annotatef(err, "cannot resize disk %q", diskName)
+	}
+	return nil
+}
+
 // deviceName will generate a device name from the passed
 // <zone> and <diskId>, the device name must not be confused
 // with the volume name, as it is used mainly to name the
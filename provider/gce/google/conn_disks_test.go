@@ -40,6 +40,61 @@ func (s *connSuite) TestConnectionCreateDisks(c *gc.C) {
 	c.Check(s.FakeConn.Calls[0].ComputeDisk.Name, gc.Equals, fakeVolName)
 }
 
+func (s *connSuite) TestConnectionCreateDisksFromSnapshot(c *gc.C) {
+	spec, _, err := fakeDiskAndSpec()
+	c.Check(err, jc.ErrorIsNil)
+	spec.SnapshotName = "snap-123"
+
+	_, err = s.Conn.CreateDisks("home-zone", []google.DiskSpec{spec})
+	c.Check(err, jc.ErrorIsNil)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "CreateDisk")
+	c.Check(s.FakeConn.Calls[0].ComputeDisk.SourceSnapshot, gc.Equals, "global/snapshots/snap-123")
+}
+
+func (s *connSuite) TestConnectionCreateSnapshot(c *gc.C) {
+	s.FakeConn.Snapshot = &compute.Snapshot{
+		Name:       "snap-123",
+		DiskSizeGb: 2,
+	}
+	snapshot, err := s.Conn.CreateSnapshot("home-zone", fakeVolName, "snap-123")
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(snapshot, jc.DeepEquals, &google.Snapshot{
+		Name: "snap-123",
+		Size: 2048,
+	})
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "CreateSnapshot")
+	c.Check(s.FakeConn.Calls[0].ProjectID, gc.Equals, "spam")
+	c.Check(s.FakeConn.Calls[0].ZoneName, gc.Equals, "home-zone")
+	c.Check(s.FakeConn.Calls[0].Name, gc.Equals, fakeVolName)
+	c.Check(s.FakeConn.Calls[0].Snapshot.Name, gc.Equals, "snap-123")
+}
+
+func (s *connSuite) TestConnectionRemoveSnapshot(c *gc.C) {
+	err := s.Conn.RemoveSnapshot("snap-123")
+	c.Check(err, jc.ErrorIsNil)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "RemoveSnapshot")
+	c.Check(s.FakeConn.Calls[0].ProjectID, gc.Equals, "spam")
+	c.Check(s.FakeConn.Calls[0].Name, gc.Equals, "snap-123")
+}
+
+func (s *connSuite) TestConnectionResizeDisk(c *gc.C) {
+	err := s.Conn.ResizeDisk("home-zone", fakeVolName, 4)
+	c.Check(err, jc.ErrorIsNil)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "ResizeDisk")
+	c.Check(s.FakeConn.Calls[0].ProjectID, gc.Equals, "spam")
+	c.Check(s.FakeConn.Calls[0].ZoneName, gc.Equals, "home-zone")
+	c.Check(s.FakeConn.Calls[0].Name, gc.Equals, fakeVolName)
+	c.Check(s.FakeConn.Calls[0].SizeGb, gc.Equals, int64(4))
+}
+
 func (s *connSuite) TestConnectionDisks(c *gc.C) {
 	_, fakeDisk, err := fakeDiskAndSpec()
 	c.Check(err, jc.ErrorIsNil)
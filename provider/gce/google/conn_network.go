@@ -4,10 +4,14 @@
 package google
 
 import (
+	"crypto/sha256"
+	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/juju/errors"
 	"github.com/juju/utils/set"
+	"google.golang.org/api/compute/v1"
 
 	"github.com/juju/juju/network"
 )
@@ -26,6 +30,9 @@ func (gce Connection) IngressRules(fwname string) ([]network.IngressRule, error)
 
 	var rules []network.IngressRule
 	for _, firewall := range firewalls {
+		if firewall.Direction == firewallDirectionEgress {
+			continue
+		}
 		sourceRanges := firewall.SourceRanges
 		if len(sourceRanges) == 0 {
 			sourceRanges = []string{"0.0.0.0/0"}
@@ -225,3 +232,204 @@ func (gce Connection) ClosePorts(target string, rules ...network.IngressRule) er
 	}
 	return nil
 }
+
+// egressFirewallPrefix returns the name prefix of the egress firewalls
+// of the target.
+func egressFirewallPrefix(target string) string {
+	return target + "-egress"
+}
+
+// egressFirewallName returns the name of the egress firewall of the
+// target for the port range.
+func egressFirewallName(target string, portRange network.PortRange) string {
+	hash := sha256.Sum256([]byte(portRange.String()))
+	return fmt.Sprintf("%s-%x", egressFirewallPrefix(target), hash[:3])
+}
+
+// egressDenyFirewallName returns the name of the firewall denying the
+// outgoing traffic of the target that its egress firewalls do not allow.
+func egressDenyFirewallName(target string) string {
+	return egressFirewallPrefix(target) + "-deny"
+}
+
+// egressFirewalls returns the egress firewalls of the target, keyed by
+// name.
+func (gce Connection) egressFirewalls(target string) (map[string]*compute.Firewall, error) {
+	prefix := egressFirewallPrefix(target)
+	firewalls, err := gce.raw.GetFirewalls(gce.projectID, prefix)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Annotate(err, "while getting egress rules from GCE")
+	}
+	result := make(map[string]*compute.Firewall)
+	for _, firewall := range firewalls {
+		if firewall.Direction != firewallDirectionEgress || !strings.HasPrefix(firewall.Name, prefix) {
+			continue
+		}
+		result[firewall.Name] = firewall
+	}
+	return result, nil
+}
+
+// egressFirewallRules returns the egress rules allowed by the firewalls.
+func egressFirewallRules(firewalls map[string]*compute.Firewall) ([]network.EgressRule, error) {
+	var rules []network.EgressRule
+	for _, firewall := range firewalls {
+		destinationRanges := firewall.DestinationRanges
+		if len(destinationRanges) == 0 {
+			destinationRanges = []string{"0.0.0.0/0"}
+		}
+		for _, allowed := range firewall.Allowed {
+			if len(allowed.Ports) == 0 {
+				rule, err := network.NewEgressRule(allowed.IPProtocol, -1, -1, destinationRanges...)
+				if err != nil {
+					return nil, errors.Annotate(err, "bad destinations from GCE")
+				}
+				rules = append(rules, rule)
+			}
+			for _, portRangeStr := range allowed.Ports {
+				portRange, err := network.ParsePortRange(portRangeStr)
+				if err != nil {
+					return nil, errors.Annotate(err, "bad ports from GCE")
+				}
+				rule, err := network.NewEgressRule(
+					allowed.IPProtocol, portRange.FromPort, portRange.ToPort, destinationRanges...,
+				)
+				if err != nil {
+					return nil, errors.Annotate(err, "bad destinations from GCE")
+				}
+				rules = append(rules, rule)
+			}
+		}
+	}
+	return network.MergeEgressRules(rules), nil
+}
+
+// EgressRules returns the egress rules of the target (within the
+// Connection's project). If the target has no egress firewalls then
+// the list will be empty and no error is returned.
+func (gce Connection) EgressRules(target string) ([]network.EgressRule, error) {
+	firewalls, err := gce.egressFirewalls(target)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return egressFirewallRules(firewalls)
+}
+
+// OpenEgressRules sends requests to the GCE API to allow the outgoing
+// traffic of the target matching the provided rules. Each port range
+// has its own egress firewall, created or updated to add the rule's
+// destinations. Once the target has egress firewalls, a firewall
+// denying all other outgoing traffic is added. The call blocks until
+// the rules are opened or a request fails.
+func (gce Connection) OpenEgressRules(target string, rules ...network.EgressRule) error {
+	if len(rules) == 0 {
+		return nil
+	}
+	firewalls, err := gce.egressFirewalls(target)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	currentRules, err := egressFirewallRules(firewalls)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	currentCIDRs := make(map[network.PortRange]string)
+	for _, rule := range currentRules {
+		currentCIDRs[rule.PortRange] = strings.Join(rule.DestinationCIDRs, ",")
+	}
+
+	for _, rule := range network.MergeEgressRules(append(currentRules, rules...)) {
+		name := egressFirewallName(target, rule.PortRange)
+		spec := egressFirewallSpec(name, target, rule)
+		cidrs, ok := currentCIDRs[rule.PortRange]
+		if !ok {
+			if err := gce.raw.AddFirewall(gce.projectID, spec); err != nil {
+				return errors.Annotatef(err, "opening egress rule %v", rule)
+			}
+			continue
+		}
+		if cidrs == strings.Join(rule.DestinationCIDRs, ",") {
+			continue
+		}
+		if err := gce.raw.UpdateFirewall(gce.projectID, name, spec); err != nil {
+			return errors.Annotatef(err, "opening egress rule %v", rule)
+		}
+	}
+
+	denyName := egressDenyFirewallName(target)
+	if _, ok := firewalls[denyName]; ok {
+		return nil
+	}
+	if err := gce.raw.AddFirewall(gce.projectID, egressDenyFirewallSpec(denyName, target)); err != nil {
+		return errors.Annotate(err, "restricting outgoing traffic")
+	}
+	return nil
+}
+
+// CloseEgressRules sends requests to the GCE API to remove the
+// provided rules from the target's egress firewalls. Firewalls left
+// with no destinations are removed; once the target has no egress
+// firewalls left, its outgoing traffic is no longer restricted. The
+// call blocks until the rules are closed or a request fails.
+func (gce Connection) CloseEgressRules(target string, rules ...network.EgressRule) error {
+	if len(rules) == 0 {
+		return nil
+	}
+	firewalls, err := gce.egressFirewalls(target)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	currentRules, err := egressFirewallRules(firewalls)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	remaining := 0
+	for _, current := range currentRules {
+		cidrs := set.NewStrings(current.DestinationCIDRs...)
+		for _, rule := range rules {
+			if rule.PortRange != current.PortRange {
+				continue
+			}
+			if len(rule.DestinationCIDRs) == 0 {
+				cidrs.Remove("0.0.0.0/0")
+			}
+			for _, cidr := range rule.DestinationCIDRs {
+				cidrs.Remove(cidr)
+			}
+		}
+		name := egressFirewallName(target, current.PortRange)
+		switch {
+		case cidrs.Size() == len(current.DestinationCIDRs):
+			remaining++
+		case cidrs.IsEmpty():
+			if err := gce.raw.RemoveFirewall(gce.projectID, name); err != nil {
+				return errors.Annotatef(err, "closing egress rule %v", current)
+			}
+		default:
+			remaining++
+			current.DestinationCIDRs = cidrs.SortedValues()
+			spec := egressFirewallSpec(name, target, current)
+			if err := gce.raw.UpdateFirewall(gce.projectID, name, spec); err != nil {
+				return errors.Annotatef(err, "closing egress rule %v", current)
+			}
+		}
+	}
+	if remaining > 0 {
+		return nil
+	}
+
+	// The last egress rule has gone, so outgoing traffic is no longer
+	// restricted.
+	denyName := egressDenyFirewallName(target)
+	if _, ok := firewalls[denyName]; !ok {
+		return nil
+	}
+	if err := gce.raw.RemoveFirewall(gce.projectID, denyName); err != nil {
+		return errors.Annotate(err, "unrestricting outgoing traffic")
+	}
+	return nil
+}
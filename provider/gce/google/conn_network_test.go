@@ -427,3 +427,231 @@ func (s *connSuite) TestConnectionCloseMoMatches(c *gc.C) {
 	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
 	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "GetFirewalls")
 }
+
+func (s *connSuite) TestConnectionIngressRulesSkipsEgress(c *gc.C) {
+	s.FakeConn.Firewalls = []*compute.Firewall{{
+		Name:       "spam",
+		TargetTags: []string{"spam"},
+		Allowed: []*compute.FirewallAllowed{{
+			IPProtocol: "tcp",
+			Ports:      []string{"80"},
+		}},
+	}, {
+		Name:              "spam-egress-bdf4d0",
+		Direction:         "EGRESS",
+		TargetTags:        []string{"spam"},
+		DestinationRanges: []string{"10.0.0.0/8"},
+		Allowed: []*compute.FirewallAllowed{{
+			IPProtocol: "tcp",
+			Ports:      []string{"443"},
+		}},
+	}}
+
+	rules, err := s.Conn.IngressRules("spam")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(rules, jc.DeepEquals, []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0"),
+	})
+}
+
+func (s *connSuite) TestConnectionEgressRules(c *gc.C) {
+	s.FakeConn.Firewalls = []*compute.Firewall{{
+		Name:         "spam",
+		TargetTags:   []string{"spam"},
+		SourceRanges: []string{"0.0.0.0/0"},
+		Allowed: []*compute.FirewallAllowed{{
+			IPProtocol: "tcp",
+			Ports:      []string{"80"},
+		}},
+	}, {
+		Name:              "spam-egress-bdf4d0",
+		Direction:         "EGRESS",
+		TargetTags:        []string{"spam"},
+		DestinationRanges: []string{"192.168.0.0/16", "10.0.0.0/8"},
+		Allowed: []*compute.FirewallAllowed{{
+			IPProtocol: "tcp",
+			Ports:      []string{"443"},
+		}},
+	}, {
+		Name:       "spam-egress-740c43",
+		Direction:  "EGRESS",
+		TargetTags: []string{"spam"},
+		Allowed: []*compute.FirewallAllowed{{
+			IPProtocol: "udp",
+			Ports:      []string{"53"},
+		}},
+	}, {
+		Name:              "spam-egress-deny",
+		Direction:         "EGRESS",
+		Priority:          65534,
+		TargetTags:        []string{"spam"},
+		DestinationRanges: []string{"0.0.0.0/0"},
+		Denied: []*compute.FirewallDenied{{
+			IPProtocol: "all",
+		}},
+	}}
+
+	rules, err := s.Conn.EgressRules("spam")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(rules, jc.DeepEquals, []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8", "192.168.0.0/16"),
+		network.MustNewEgressRule("udp", 53, 53, "0.0.0.0/0"),
+	})
+	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "GetFirewalls")
+	c.Check(s.FakeConn.Calls[0].Name, gc.Equals, "spam-egress")
+}
+
+func (s *connSuite) TestConnectionOpenEgressRulesAdd(c *gc.C) {
+	s.FakeConn.Err = errors.NotFoundf("spam")
+
+	err := s.Conn.OpenEgressRules("spam",
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+		network.MustNewEgressRule("udp", 53, 53),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 4)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "GetFirewalls")
+	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "AddFirewall")
+	c.Check(s.FakeConn.Calls[1].Firewall, jc.DeepEquals, &compute.Firewall{
+		Name:              "spam-egress-bdf4d0",
+		Direction:         "EGRESS",
+		TargetTags:        []string{"spam"},
+		DestinationRanges: []string{"10.0.0.0/8"},
+		Allowed: []*compute.FirewallAllowed{{
+			IPProtocol: "tcp",
+			Ports:      []string{"443"},
+		}},
+	})
+	c.Check(s.FakeConn.Calls[2].FuncName, gc.Equals, "AddFirewall")
+	c.Check(s.FakeConn.Calls[2].Firewall, jc.DeepEquals, &compute.Firewall{
+		Name:              "spam-egress-740c43",
+		Direction:         "EGRESS",
+		TargetTags:        []string{"spam"},
+		DestinationRanges: []string{"0.0.0.0/0"},
+		Allowed: []*compute.FirewallAllowed{{
+			IPProtocol: "udp",
+			Ports:      []string{"53"},
+		}},
+	})
+	// Outgoing traffic is restricted after the rules are in place.
+	c.Check(s.FakeConn.Calls[3].FuncName, gc.Equals, "AddFirewall")
+	c.Check(s.FakeConn.Calls[3].Firewall, jc.DeepEquals, &compute.Firewall{
+		Name:              "spam-egress-deny",
+		Direction:         "EGRESS",
+		Priority:          65534,
+		TargetTags:        []string{"spam"},
+		DestinationRanges: []string{"0.0.0.0/0"},
+		Denied: []*compute.FirewallDenied{{
+			IPProtocol: "all",
+		}},
+	})
+}
+
+func (s *connSuite) TestConnectionOpenEgressRulesUpdate(c *gc.C) {
+	s.FakeConn.Firewalls = []*compute.Firewall{{
+		Name:              "spam-egress-bdf4d0",
+		Direction:         "EGRESS",
+		TargetTags:        []string{"spam"},
+		DestinationRanges: []string{"10.0.0.0/8"},
+		Allowed: []*compute.FirewallAllowed{{
+			IPProtocol: "tcp",
+			Ports:      []string{"443"},
+		}},
+	}, {
+		Name:              "spam-egress-deny",
+		Direction:         "EGRESS",
+		Priority:          65534,
+		TargetTags:        []string{"spam"},
+		DestinationRanges: []string{"0.0.0.0/0"},
+		Denied: []*compute.FirewallDenied{{
+			IPProtocol: "all",
+		}},
+	}}
+
+	err := s.Conn.OpenEgressRules("spam",
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8", "192.168.0.0/16"),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 2)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "GetFirewalls")
+	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "UpdateFirewall")
+	c.Check(s.FakeConn.Calls[1].Name, gc.Equals, "spam-egress-bdf4d0")
+	c.Check(s.FakeConn.Calls[1].Firewall, jc.DeepEquals, &compute.Firewall{
+		Name:              "spam-egress-bdf4d0",
+		Direction:         "EGRESS",
+		TargetTags:        []string{"spam"},
+		DestinationRanges: []string{"10.0.0.0/8", "192.168.0.0/16"},
+		Allowed: []*compute.FirewallAllowed{{
+			IPProtocol: "tcp",
+			Ports:      []string{"443"},
+		}},
+	})
+}
+
+func (s *connSuite) TestConnectionCloseEgressRules(c *gc.C) {
+	s.FakeConn.Firewalls = []*compute.Firewall{{
+		Name:              "spam-egress-bdf4d0",
+		Direction:         "EGRESS",
+		TargetTags:        []string{"spam"},
+		DestinationRanges: []string{"10.0.0.0/8", "192.168.0.0/16"},
+		Allowed: []*compute.FirewallAllowed{{
+			IPProtocol: "tcp",
+			Ports:      []string{"443"},
+		}},
+	}, {
+		Name:       "spam-egress-deny",
+		Direction:  "EGRESS",
+		Priority:   65534,
+		TargetTags: []string{"spam"},
+		Denied: []*compute.FirewallDenied{{
+			IPProtocol: "all",
+		}},
+	}}
+
+	err := s.Conn.CloseEgressRules("spam",
+		network.MustNewEgressRule("tcp", 443, 443, "192.168.0.0/16"),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 2)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "GetFirewalls")
+	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "UpdateFirewall")
+	c.Check(s.FakeConn.Calls[1].Firewall.DestinationRanges, jc.DeepEquals, []string{"10.0.0.0/8"})
+}
+
+func (s *connSuite) TestConnectionCloseEgressRulesLast(c *gc.C) {
+	s.FakeConn.Firewalls = []*compute.Firewall{{
+		Name:              "spam-egress-bdf4d0",
+		Direction:         "EGRESS",
+		TargetTags:        []string{"spam"},
+		DestinationRanges: []string{"10.0.0.0/8"},
+		Allowed: []*compute.FirewallAllowed{{
+			IPProtocol: "tcp",
+			Ports:      []string{"443"},
+		}},
+	}, {
+		Name:       "spam-egress-deny",
+		Direction:  "EGRESS",
+		Priority:   65534,
+		TargetTags: []string{"spam"},
+		Denied: []*compute.FirewallDenied{{
+			IPProtocol: "all",
+		}},
+	}}
+
+	err := s.Conn.CloseEgressRules("spam",
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The last rule has gone, so outgoing traffic is no longer restricted.
+	c.Check(s.FakeConn.Calls, gc.HasLen, 3)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "GetFirewalls")
+	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "RemoveFirewall")
+	c.Check(s.FakeConn.Calls[1].Name, gc.Equals, "spam-egress-bdf4d0")
+	c.Check(s.FakeConn.Calls[2].FuncName, gc.Equals, "RemoveFirewall")
+	c.Check(s.FakeConn.Calls[2].Name, gc.Equals, "spam-egress-deny")
+}
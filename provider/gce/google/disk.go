@@ -84,6 +84,9 @@ type DiskSpec struct {
 	// ImageURL is the location of the image to which the disk should
 	// be initialized.
 	ImageURL string
+	// SnapshotName is the name of the snapshot from which the disk
+	// should be initialized, if any. (detached only)
+	SnapshotName string
 	// Boot indicates that this is a boot disk. An instance may only
 	// have one boot disk. (attached only)
 	Boot bool
@@ -175,13 +178,19 @@ func (ds *DiskSpec) newDetached() (*compute.Disk, error) {
 	if ds.PersistentDiskType == DiskLocalSSD {
 		return nil, errors.New("cannot create local ssd disks detached")
 	}
-	return &compute.Disk{
+	disk := &compute.Disk{
 		Name:        ds.Name,
 		SizeGb:      int64(ds.SizeGB()),
 		SourceImage: ds.ImageURL,
 		Type:        string(ds.PersistentDiskType),
 		Description: ds.Description,
-	}, nil
+	}
+	if ds.SnapshotName != "" {
+		// Snapshots are global resources, so a disk in any
+		// zone may be created from them.
+		disk.SourceSnapshot = "global/snapshots/" + ds.SnapshotName
+	}
+	return disk, nil
 }
 
 // AttachedDisk represents a disk that is attached to an instance.
@@ -216,6 +225,14 @@ type Disk struct {
 	Status DiskStatus
 }
 
+// Snapshot represents a snapshot of a gce disk.
+type Snapshot struct {
+	// Name is a unique identifier string for each snapshot.
+	Name string
+	// Size is the size of the disk the snapshot was taken of, in MiB.
+	Size uint64
+}
+
 func NewDisk(cd *compute.Disk) *Disk {
 	d := &Disk{
 		Id:          cd.Id,
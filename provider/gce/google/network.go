@@ -16,6 +16,16 @@ const (
 	networkPathRoot    = "global/networks/"
 )
 
+// firewallDirectionEgress is the direction of firewalls applying to
+// outgoing traffic.
+const firewallDirectionEgress = "EGRESS"
+
+// egressDenyPriority is the priority of the firewall denying outgoing
+// traffic from a target with egress rules. It takes precedence over
+// the implied GCE rule allowing all outgoing traffic, but not over the
+// egress firewalls, which have the default priority of 1000.
+const egressDenyPriority = 65534
+
 // The different kinds of network access.
 const (
 	NetworkAccessOneToOneNAT = "ONE_TO_ONE_NAT" // the default
@@ -89,6 +99,45 @@ func firewallSpec(name, target string, sourceCIDRs []string, ports protocolPorts
 	return &firewall
 }
 
+// egressFirewallSpec returns a compute.Firewall for the provided name,
+// allowing outgoing traffic from the target matching the rule.
+func egressFirewallSpec(name, target string, rule network.EgressRule) *compute.Firewall {
+	destinationCIDRs := rule.DestinationCIDRs
+	if len(destinationCIDRs) == 0 {
+		destinationCIDRs = []string{"0.0.0.0/0"}
+	}
+	allowed := compute.FirewallAllowed{
+		IPProtocol: rule.Protocol,
+	}
+	if rule.Protocol != "icmp" {
+		ports := protocolPorts{rule.Protocol: {rule.PortRange}}
+		allowed.Ports = ports.portStrings(rule.Protocol)
+	}
+	return &compute.Firewall{
+		Name:              name,
+		Direction:         firewallDirectionEgress,
+		TargetTags:        []string{target},
+		DestinationRanges: destinationCIDRs,
+		Allowed:           []*compute.FirewallAllowed{&allowed},
+	}
+}
+
+// egressDenyFirewallSpec returns a compute.Firewall for the provided
+// name, denying all outgoing traffic from the target that no egress
+// firewall allows.
+func egressDenyFirewallSpec(name, target string) *compute.Firewall {
+	return &compute.Firewall{
+		Name:              name,
+		Direction:         firewallDirectionEgress,
+		Priority:          egressDenyPriority,
+		TargetTags:        []string{target},
+		DestinationRanges: []string{"0.0.0.0/0"},
+		Denied: []*compute.FirewallDenied{{
+			IPProtocol: "all",
+		}},
+	}
+}
+
 func extractAddresses(interfaces ...*compute.NetworkInterface) []network.Address {
 	var addresses []network.Address
 
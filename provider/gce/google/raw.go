@@ -4,8 +4,11 @@
 package google
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"path"
 	"strings"
 	"time"
@@ -39,6 +42,10 @@ func convertRawAPIError(err error) error {
 
 type rawConn struct {
 	*compute.Service
+
+	// client is the authenticated client used by the service, for
+	// requests which the service does not support.
+	client *http.Client
 }
 
 func (rc *rawConn) GetProject(projectID string) (*compute.Project, error) {
@@ -272,6 +279,77 @@ func (rc *rawConn) DetachDisk(project, zone, instanceId, diskDeviceName string)
 	return nil
 }
 
+func (rc *rawConn) ResizeDisk(project, zone, disk string, sizeGb int64) error {
+	op, err := rc.resizeDisk(project, zone, disk, sizeGb)
+	if err != nil {
+		return errors.Annotatef(err, "could not resize disk %q", disk)
+	}
+	return errors.Trace(rc.waitOperation(project, op, attemptsLong))
+}
+
+// disksResizeRequest is the body of a disks.resize request.
+type disksResizeRequest struct {
+	SizeGb int64 `json:"sizeGb,string"`
+}
+
+// resizeDisk requests that the disk be resized, returning the
+// operation doing so. The revision of the compute API client in use
+// predates disks.resize, so the request is made directly.
+func (rc *rawConn) resizeDisk(project, zone, disk string, sizeGb int64) (*compute.Operation, error) {
+	body, err := json.Marshal(disksResizeRequest{SizeGb: sizeGb})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	resizeURL := rc.BasePath + path.Join(
+		url.QueryEscape(project),
+		"zones", url.QueryEscape(zone),
+		"disks", url.QueryEscape(disk),
+		"resize",
+	) + "?alt=json"
+	req, err := http.NewRequest("POST", resizeURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := rc.client.Do(req)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	if err := googleapi.CheckResponse(resp); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var op compute.Operation
+	if err := json.NewDecoder(resp.Body).Decode(&op); err != nil {
+		return nil, errors.Annotate(err, "cannot decode operation")
+	}
+	return &op, nil
+}
+
+func (rc *rawConn) CreateSnapshot(project, zone, disk string, spec *compute.Snapshot) (*compute.Snapshot, error) {
+	call := rc.Disks.CreateSnapshot(project, zone, disk, spec)
+	op, err := call.Do()
+	if err != nil {
+		return nil, errors.Annotatef(err, "could not create snapshot of disk %q", disk)
+	}
+	if err := rc.waitOperation(project, op, attemptsLong); err != nil {
+		return nil, errors.Trace(err)
+	}
+	snapshot, err := rc.Snapshots.Get(project, spec.Name).Do()
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get snapshot %q in project %q", spec.Name, project)
+	}
+	return snapshot, nil
+}
+
+func (rc *rawConn) RemoveSnapshot(project, name string) error {
+	op, err := rc.Snapshots.Delete(project, name).Do()
+	if err != nil {
+		return errors.Annotatef(convertRawAPIError(err), "could not delete snapshot %q", name)
+	}
+	return errors.Trace(rc.waitOperation(project, op, attemptsLong))
+}
+
 func (rc *rawConn) InstanceDisks(project, zone, instanceId string) ([]*compute.AttachedDisk, error) {
 	instance, err := rc.GetInstance(project, zone, instanceId)
 	if err != nil {
@@ -4,6 +4,11 @@
 package google
 
 import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
@@ -36,7 +41,7 @@ func (s *rawConnSuite) SetUpTest(c *gc.C) {
 	service.ZoneOperations = compute.NewZoneOperationsService(service)
 	service.RegionOperations = compute.NewRegionOperationsService(service)
 	service.GlobalOperations = compute.NewGlobalOperationsService(service)
-	s.rawConn = &rawConn{service}
+	s.rawConn = &rawConn{Service: service}
 	s.strategy.Min = 4
 
 	s.callCount = 0
@@ -149,3 +154,38 @@ func (s *rawConnSuite) TestConnectionWaitOperationError(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, `.* "testing-wait-operation-error" .*`)
 	c.Check(s.callCount, gc.Equals, 1)
 }
+
+func (s *rawConnSuite) TestResizeDisk(c *gc.C) {
+	var method, path, body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		method = req.Method
+		path = req.URL.Path
+		data, err := ioutil.ReadAll(req.Body)
+		c.Check(err, jc.ErrorIsNil)
+		body = string(data)
+		fmt.Fprint(w, `{"name": "resize-op", "status": "DONE"}`)
+	}))
+	defer server.Close()
+	s.rawConn.BasePath = server.URL + "/projects/"
+	s.rawConn.client = http.DefaultClient
+
+	err := s.rawConn.ResizeDisk("proj", "a-zone", "a-disk", 20)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(method, gc.Equals, "POST")
+	c.Check(path, gc.Equals, "/projects/proj/zones/a-zone/disks/a-disk/resize")
+	c.Check(body, gc.Equals, `{"sizeGb":"20"}`)
+	c.Check(s.callCount, gc.Equals, 0)
+}
+
+func (s *rawConnSuite) TestResizeDiskError(c *gc.C) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"error": {"code": 400, "message": "disk cannot shrink"}}`)
+	}))
+	defer server.Close()
+	s.rawConn.BasePath = server.URL + "/projects/"
+	s.rawConn.client = http.DefaultClient
+
+	err := s.rawConn.ResizeDisk("proj", "a-zone", "a-disk", 20)
+	c.Assert(err, gc.ErrorMatches, `could not resize disk "a-disk": .*disk cannot shrink.*`)
+}
@@ -161,6 +161,8 @@ type fakeCall struct {
 	AttachedDisk *compute.AttachedDisk
 	DeviceName   string
 	ComputeDisk  *compute.Disk
+	Snapshot     *compute.Snapshot
+	SizeGb       int64
 	Metadata     *compute.Metadata
 }
 
@@ -177,6 +179,7 @@ type fakeConn struct {
 	Disks         []*compute.Disk
 	Disk          *compute.Disk
 	AttachedDisks []*compute.AttachedDisk
+	Snapshot      *compute.Snapshot
 }
 
 func (rc *fakeConn) GetProject(projectID string) (*compute.Project, error) {
@@ -480,3 +483,52 @@ func (rc *fakeConn) SetMetadata(projectID, zone, instanceID string, metadata *co
 	}
 	return err
 }
+
+func (rc *fakeConn) ResizeDisk(project, zone, disk string, sizeGb int64) error {
+	call := fakeCall{
+		FuncName:  "ResizeDisk",
+		ProjectID: project,
+		ZoneName:  zone,
+		Name:      disk,
+		SizeGb:    sizeGb,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return err
+}
+
+func (rc *fakeConn) CreateSnapshot(project, zone, disk string, spec *compute.Snapshot) (*compute.Snapshot, error) {
+	call := fakeCall{
+		FuncName:  "CreateSnapshot",
+		ProjectID: project,
+		ZoneName:  zone,
+		Name:      disk,
+		Snapshot:  spec,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return rc.Snapshot, err
+}
+
+func (rc *fakeConn) RemoveSnapshot(project, name string) error {
+	call := fakeCall{
+		FuncName:  "RemoveSnapshot",
+		ProjectID: project,
+		Name:      name,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return err
+}
@@ -17,7 +17,10 @@ type environInstance struct {
 	env  *environ
 }
 
-var _ instance.Instance = (*environInstance)(nil)
+var (
+	_ instance.Instance         = (*environInstance)(nil)
+	_ instance.EgressFirewaller = (*environInstance)(nil)
+)
 
 func newInstance(base *google.Instance, env *environ) *environInstance {
 	return &environInstance{
@@ -101,3 +104,37 @@ func (inst *environInstance) IngressRules(machineID string) ([]network.IngressRu
 	ports, err := inst.env.gce.IngressRules(name)
 	return ports, errors.Trace(err)
 }
+
+// OpenEgressRules allows outgoing traffic matching the given rules from
+// the instance, which should have been started with the given machine id.
+func (inst *environInstance) OpenEgressRules(machineID string, rules []network.EgressRule) error {
+	name, err := inst.env.namespace.Hostname(machineID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = inst.env.gce.OpenEgressRules(name, rules...)
+	return errors.Trace(err)
+}
+
+// CloseEgressRules removes the given egress rules from the instance,
+// which should have been started with the given machine id.
+func (inst *environInstance) CloseEgressRules(machineID string, rules []network.EgressRule) error {
+	name, err := inst.env.namespace.Hostname(machineID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = inst.env.gce.CloseEgressRules(name, rules...)
+	return errors.Trace(err)
+}
+
+// EgressRules returns the set of egress rules applicable to the
+// instance, which should have been started with the given machine id.
+// The rules are returned as sorted by SortEgressRules.
+func (inst *environInstance) EgressRules(machineID string) ([]network.EgressRule, error) {
+	name, err := inst.env.namespace.Hostname(machineID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	rules, err := inst.env.gce.EgressRules(name)
+	return rules, errors.Trace(err)
+}
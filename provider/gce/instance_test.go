@@ -85,3 +85,35 @@ func (s *instanceSuite) TestPortsAPI(c *gc.C) {
 	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "Ports")
 	c.Check(s.FakeConn.Calls[0].FirewallName, gc.Equals, s.InstName)
 }
+
+func (s *instanceSuite) TestOpenEgressRulesAPI(c *gc.C) {
+	err := s.Instance.OpenEgressRules("42", egressRules)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "OpenEgressRules")
+	c.Check(s.FakeConn.Calls[0].FirewallName, gc.Equals, s.InstName)
+	c.Check(s.FakeConn.Calls[0].EgressRules, jc.DeepEquals, egressRules)
+}
+
+func (s *instanceSuite) TestCloseEgressRulesAPI(c *gc.C) {
+	err := s.Instance.CloseEgressRules("42", egressRules)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "CloseEgressRules")
+	c.Check(s.FakeConn.Calls[0].FirewallName, gc.Equals, s.InstName)
+	c.Check(s.FakeConn.Calls[0].EgressRules, jc.DeepEquals, egressRules)
+}
+
+func (s *instanceSuite) TestEgressRules(c *gc.C) {
+	s.FakeConn.Egress = egressRules
+
+	rules, err := s.Instance.EgressRules("42")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(rules, jc.DeepEquals, egressRules)
+	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "EgressRules")
+	c.Check(s.FakeConn.Calls[0].FirewallName, gc.Equals, s.InstName)
+}
@@ -456,6 +456,7 @@ type fakeConnCall struct {
 	InstanceSpec google.InstanceSpec
 	FirewallName string
 	Rules        []network.IngressRule
+	EgressRules  []network.EgressRule
 	Region       string
 	Disks        []google.DiskSpec
 	VolumeName   string
@@ -463,20 +464,23 @@ type fakeConnCall struct {
 	Mode         string
 	Key          string
 	Value        string
+	Size         uint64
 }
 
 type fakeConn struct {
 	Calls []fakeConnCall
 
-	Inst  *google.Instance
-	Insts []google.Instance
-	Rules []network.IngressRule
-	Zones []google.AvailabilityZone
+	Inst   *google.Instance
+	Insts  []google.Instance
+	Rules  []network.IngressRule
+	Egress []network.EgressRule
+	Zones  []google.AvailabilityZone
 
 	GoogleDisks   []*google.Disk
 	GoogleDisk    *google.Disk
 	AttachedDisk  *google.AttachedDisk
 	AttachedDisks []*google.AttachedDisk
+	Snapshot      *google.Snapshot
 
 	Err        error
 	FailOnCall int
@@ -568,6 +572,32 @@ func (fc *fakeConn) ClosePorts(fwname string, rules ...network.IngressRule) erro
 	return fc.err()
 }
 
+func (fc *fakeConn) EgressRules(target string) ([]network.EgressRule, error) {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName:     "EgressRules",
+		FirewallName: target,
+	})
+	return fc.Egress, fc.err()
+}
+
+func (fc *fakeConn) OpenEgressRules(target string, rules ...network.EgressRule) error {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName:     "OpenEgressRules",
+		FirewallName: target,
+		EgressRules:  rules,
+	})
+	return fc.err()
+}
+
+func (fc *fakeConn) CloseEgressRules(target string, rules ...network.EgressRule) error {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName:     "CloseEgressRules",
+		FirewallName: target,
+		EgressRules:  rules,
+	})
+	return fc.err()
+}
+
 func (fc *fakeConn) AvailabilityZones(region string) ([]google.AvailabilityZone, error) {
 	fc.Calls = append(fc.Calls, fakeConnCall{
 		FuncName: "AvailabilityZones",
@@ -585,6 +615,34 @@ func (fc *fakeConn) CreateDisks(zone string, disks []google.DiskSpec) ([]*google
 	return fc.GoogleDisks, fc.err()
 }
 
+func (fc *fakeConn) CreateSnapshot(zone, diskName, snapshotName string) (*google.Snapshot, error) {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName:   "CreateSnapshot",
+		ZoneName:   zone,
+		VolumeName: diskName,
+		ID:         snapshotName,
+	})
+	return fc.Snapshot, fc.err()
+}
+
+func (fc *fakeConn) RemoveSnapshot(name string) error {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName: "RemoveSnapshot",
+		ID:       name,
+	})
+	return fc.err()
+}
+
+func (fc *fakeConn) ResizeDisk(zone, diskName string, sizeGB uint64) error {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName:   "ResizeDisk",
+		ZoneName:   zone,
+		VolumeName: diskName,
+		Size:       sizeGB,
+	})
+	return fc.err()
+}
+
 func (fc *fakeConn) Disks(zone string) ([]*google.Disk, error) {
 	fc.Calls = append(fc.Calls, fakeConnCall{
 		FuncName: "Disks",
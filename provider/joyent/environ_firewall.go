@@ -6,6 +6,7 @@ package joyent
 import (
 	"fmt"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -17,14 +18,52 @@ import (
 )
 
 const (
-	firewallRuleAll = "FROM tag %s TO tag juju ALLOW %s %s"
+	firewallRuleAll = "FROM %s TO tag juju ALLOW %s %s"
 )
 
 var (
-	firewallSinglePortRule = regexp.MustCompile("FROM tag [a-z0-9 \\-]+ TO (?:tag|vm) [a-z0-9 \\-]+ ALLOW (?P<protocol>[a-z]+) PORT (?P<port>[0-9]+)")
-	firewallMultiPortRule  = regexp.MustCompile("FROM tag [a-z0-9 \\-]+ TO (?:tag|vm) [a-z0-9 \\-]+ ALLOW (?P<protocol>[a-z]+) \\(\\s*(?P<ports>PORT [0-9]+(?: AND PORT [0-9]+)*)\\s*\\)")
+	firewallSinglePortRule = regexp.MustCompile("FROM (?:tag [a-z0-9 \\-]+|\\(tag [a-z0-9\\-]+(?: OR subnet [0-9a-f.:/]+)+\\)) TO (?:tag|vm) [a-z0-9 \\-]+ ALLOW (?P<protocol>[a-z]+) PORT (?P<port>[0-9]+)")
+	firewallMultiPortRule  = regexp.MustCompile("FROM (?:tag [a-z0-9 \\-]+|\\(tag [a-z0-9\\-]+(?: OR subnet [0-9a-f.:/]+)+\\)) TO (?:tag|vm) [a-z0-9 \\-]+ ALLOW (?P<protocol>[a-z]+) \\(\\s*(?P<ports>PORT [0-9]+(?: AND PORT [0-9]+)*)\\s*\\)")
+	firewallRuleSubnet     = regexp.MustCompile("subnet ([0-9a-f.:/]+)")
 )
 
+// Helper method to create the source of a firewall rule string for the
+// given ingress rule. Rules restricted to source CIDRs also allow access
+// from the model's own machines.
+func firewallRuleSource(envName string, rule network.IngressRule) string {
+	var subnets []string
+	for _, cidr := range rule.SourceCIDRs {
+		if cidr == "0.0.0.0/0" {
+			return "tag " + envName
+		}
+		subnets = append(subnets, "subnet "+cidr)
+	}
+	if len(subnets) == 0 {
+		return "tag " + envName
+	}
+	sort.Strings(subnets)
+	return fmt.Sprintf("(tag %s OR %s)", envName, strings.Join(subnets, " OR "))
+}
+
+// Helper method to get the source CIDRs from the given firewall rule string
+func ruleSourceCIDRs(rule string) []string {
+	from := rule[:strings.Index(rule, " TO ")]
+	var cidrs []string
+	for _, parts := range firewallRuleSubnet.FindAllStringSubmatch(from, -1) {
+		cidrs = append(cidrs, parts[1])
+	}
+	if len(cidrs) == 0 {
+		return []string{"0.0.0.0/0"}
+	}
+	return cidrs
+}
+
+// Helper method to check if a firewall rule string belongs to the given model
+func isModelRule(envName string, rule string) bool {
+	return strings.HasPrefix(rule, "FROM tag "+envName+" ") ||
+		strings.HasPrefix(rule, "FROM (tag "+envName+" OR ")
+}
+
 // Helper method to create a firewall rule string for the given port
 func createFirewallRuleAll(envName string, portRange network.IngressRule) string {
 	ports := []string{}
@@ -37,7 +76,7 @@ func createFirewallRuleAll(envName string, portRange network.IngressRule) string
 	} else if len(ports) == 1 {
 		portList = ports[0]
 	}
-	return fmt.Sprintf(firewallRuleAll, envName, strings.ToLower(portRange.Protocol), portList)
+	return fmt.Sprintf(firewallRuleAll, firewallRuleSource(envName, portRange), strings.ToLower(portRange.Protocol), portList)
 }
 
 // Helper method to check if a firewall rule string already exist
@@ -56,7 +95,7 @@ func getRules(envName string, fwrules []cloudapi.FirewallRule) ([]network.Ingres
 	rules := []network.IngressRule{}
 	for _, r := range fwrules {
 		rule := r.Rule
-		if r.Enabled && strings.HasPrefix(rule, "FROM tag "+envName) && strings.Contains(rule, "PORT") {
+		if r.Enabled && isModelRule(envName, rule) && strings.Contains(rule, "PORT") {
 			if firewallSinglePortRule.MatchString(rule) {
 				parts := firewallSinglePortRule.FindStringSubmatch(rule)
 				if len(parts) != 3 {
@@ -64,7 +103,7 @@ func getRules(envName string, fwrules []cloudapi.FirewallRule) ([]network.Ingres
 				}
 				protocol := parts[1]
 				n, _ := strconv.Atoi(parts[2])
-				rule, err := network.NewIngressRule(protocol, n, n, "0.0.0.0/0")
+				rule, err := network.NewIngressRule(protocol, n, n, ruleSourceCIDRs(rule)...)
 				if err != nil {
 					return nil, errors.Trace(err)
 				}
@@ -82,9 +121,10 @@ func getRules(envName string, fwrules []cloudapi.FirewallRule) ([]network.Ingres
 					port, _ := strconv.Atoi(portString)
 					ports = append(ports, network.Port{protocol, port})
 				}
+				sourceCIDRs := ruleSourceCIDRs(rule)
 				portRange := network.CollapsePorts(ports)
 				for _, port := range portRange {
-					rule, _ := network.NewIngressRule(port.Protocol, port.FromPort, port.ToPort, "0.0.0.0/0")
+					rule, _ := network.NewIngressRule(port.Protocol, port.FromPort, port.ToPort, sourceCIDRs...)
 					rules = append(rules, rule)
 				}
 			}
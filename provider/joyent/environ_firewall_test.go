@@ -42,6 +42,26 @@ func (s *FirewallSuite) TestGetIngressRules(c *gc.C) {
 			}},
 			[]network.IngressRule{network.MustNewIngressRule("tcp", 80, 83, "0.0.0.0/0")},
 		},
+		{
+			"source subnets model rule",
+			"switch",
+			[]cloudapi.FirewallRule{{
+				"",
+				true,
+				"FROM (tag switch OR subnet 10.0.0.0/24 OR subnet 192.168.0.0/16) TO tag juju ALLOW tcp (PORT 80 AND PORT 81)",
+			}},
+			[]network.IngressRule{network.MustNewIngressRule("tcp", 80, 81, "10.0.0.0/24", "192.168.0.0/16")},
+		},
+		{
+			"other model rule",
+			"switch",
+			[]cloudapi.FirewallRule{{
+				"",
+				true,
+				"FROM (tag switchboard OR subnet 10.0.0.0/24) TO tag juju ALLOW tcp PORT 80",
+			}},
+			[]network.IngressRule{},
+		},
 	}
 	for i, t := range testCases {
 		c.Logf("test %d: %s", i, t.about)
@@ -65,6 +85,14 @@ func (s *FirewallSuite) TestRuleCreation(c *gc.C) {
 		"multiple port firewall rule",
 		network.MustNewIngressRule("tcp", 80, 81),
 		"FROM tag switch TO tag juju ALLOW tcp ( PORT 80 AND PORT 81 )",
+	}, {
+		"source subnets firewall rule",
+		network.MustNewIngressRule("tcp", 80, 80, "192.168.0.0/16", "10.0.0.0/24"),
+		"FROM (tag switch OR subnet 10.0.0.0/24 OR subnet 192.168.0.0/16) TO tag juju ALLOW tcp PORT 80",
+	}, {
+		"everywhere firewall rule",
+		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0"),
+		"FROM tag switch TO tag juju ALLOW tcp PORT 80",
 	}}
 
 	for i, t := range testCases {
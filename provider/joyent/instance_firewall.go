@@ -14,7 +14,7 @@ import (
 )
 
 const (
-	firewallRuleVm = "FROM tag %s TO vm %s ALLOW %s %s"
+	firewallRuleVm = "FROM %s TO vm %s ALLOW %s %s"
 )
 
 // Helper method to create a firewall rule string for the given machine Id and port
@@ -29,7 +29,7 @@ func createFirewallRuleVm(envName string, machineId string, portRange network.In
 	} else if len(ports) == 1 {
 		portList = ports[0]
 	}
-	return fmt.Sprintf(firewallRuleVm, envName, machineId, strings.ToLower(portRange.Protocol), portList)
+	return fmt.Sprintf(firewallRuleVm, firewallRuleSource(envName, portRange), machineId, strings.ToLower(portRange.Protocol), portList)
 }
 
 func (inst *joyentInstance) OpenPorts(machineId string, ports []network.IngressRule) error {
@@ -4,8 +4,14 @@
 package openstack
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
 	"math"
+	"net/http"
 	"net/url"
+	"strings"
 	"sync"
 	"time"
 
@@ -30,6 +36,9 @@ const (
 	volumeStatusDeleting  = "deleting"
 	volumeStatusError     = "error"
 	volumeStatusInUse     = "in-use"
+
+	volumeStatusExtending      = "extending"
+	volumeStatusErrorExtending = "error_extending"
 )
 
 // StorageProviderTypes implements storage.ProviderRegistry.
@@ -81,8 +90,13 @@ var newOpenstackStorage = func(env *Environ) (OpenstackStorage, error) {
 		logger.Debugf("volume URL: %v", url)
 	}
 
+	handleRequest := cinder.SetAuthHeaderFn(client.Token, http.DefaultClient.Do)
 	return &openstackStorageAdapter{
-		cinderClient{cinder.Basic(env.volumeURL, client.TenantId(), client.Token)},
+		cinderClient{
+			cinder.NewClient(client.TenantId(), env.volumeURL, handleRequest),
+			env.volumeURL,
+			handleRequest,
+		},
 		novaClient{env.novaUnlocked},
 	}, nil
 }
@@ -159,6 +173,8 @@ type cinderVolumeSource struct {
 }
 
 var _ storage.VolumeSource = (*cinderVolumeSource)(nil)
+var _ storage.VolumeSnapshotter = (*cinderVolumeSource)(nil)
+var _ storage.VolumeResizer = (*cinderVolumeSource)(nil)
 
 // CreateVolumes implements storage.VolumeSource.
 func (s *cinderVolumeSource) CreateVolumes(args []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
@@ -187,6 +203,7 @@ func (s *cinderVolumeSource) createVolume(arg storage.VolumeParams) (*storage.Vo
 		// TODO(axw) use the AZ of the initially attached machine.
 		AvailabilityZone: "",
 		Metadata:         metadata,
+		SnapshotId:       arg.SnapshotId,
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -209,6 +226,78 @@ func (s *cinderVolumeSource) createVolume(arg storage.VolumeParams) (*storage.Vo
 	return &storage.Volume{arg.Tag, cinderToJujuVolumeInfo(cinderVolume)}, nil
 }
 
+// CreateVolumeSnapshots implements storage.VolumeSnapshotter.
+func (s *cinderVolumeSource) CreateVolumeSnapshots(args []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error) {
+	results := make([]storage.CreateVolumeSnapshotsResult, len(args))
+	for i, arg := range args {
+		// Snapshots of in-use volumes must be forced; the user
+		// is responsible for quiescing the filesystem first.
+		snapshot, err := s.storageAdapter.CreateSnapshot(cinder.CreateSnapshotSnapshotParams{
+			VolumeId:    arg.VolumeId,
+			Force:       true,
+			Name:        resourceName(s.namespace, s.envName, arg.Volume.String()),
+			Description: "juju snapshot of " + arg.Volume.String(),
+		})
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "creating snapshot of %q", arg.VolumeId)
+			continue
+		}
+		logger.Debugf("created snapshot: %+v", snapshot)
+		results[i].VolumeSnapshot = &storage.VolumeSnapshot{
+			Volume:     arg.Volume,
+			SnapshotId: snapshot.ID,
+			Size:       uint64(snapshot.Size * 1024),
+		}
+	}
+	return results, nil
+}
+
+// ResizeVolumes implements storage.VolumeResizer.
+func (s *cinderVolumeSource) ResizeVolumes(args []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	results := make([]storage.ResizeVolumesResult, len(args))
+	for i, arg := range args {
+		size, err := s.resizeVolume(arg)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "resizing volume %q", arg.VolumeId)
+			continue
+		}
+		results[i].Size = size
+	}
+	return results, nil
+}
+
+func (s *cinderVolumeSource) resizeVolume(arg storage.VolumeResizeParams) (uint64, error) {
+	sizeGiB := int((arg.Size + 1023) / 1024)
+	if err := s.storageAdapter.ExtendVolume(arg.VolumeId, sizeGiB); err != nil {
+		return 0, errors.Trace(err)
+	}
+	volume, err := waitVolume(s.storageAdapter, arg.VolumeId, func(v *cinder.Volume) (bool, error) {
+		switch v.Status {
+		case volumeStatusExtending:
+			return false, nil
+		case volumeStatusErrorExtending:
+			return false, errors.New("volume failed to extend")
+		}
+		return v.Size >= sizeGiB, nil
+	})
+	if err != nil {
+		return 0, errors.Annotate(err, "waiting for volume to be extended")
+	}
+	return uint64(volume.Size * 1024), nil
+}
+
+// DestroyVolumeSnapshots implements storage.VolumeSnapshotter.
+func (s *cinderVolumeSource) DestroyVolumeSnapshots(snapshotIds []string) ([]error, error) {
+	results := make([]error, len(snapshotIds))
+	for i, snapshotId := range snapshotIds {
+		err := s.storageAdapter.DeleteSnapshot(snapshotId)
+		if err != nil && !errors.IsNotFound(err) {
+			results[i] = errors.Annotatef(err, "destroying snapshot %q", snapshotId)
+		}
+	}
+	return results, nil
+}
+
 // ListVolumes is specified on the storage.VolumeSource interface.
 func (s *cinderVolumeSource) ListVolumes() ([]string, error) {
 	volumes, err := listVolumes(s.storageAdapter, func(v *cinder.Volume) bool {
@@ -478,10 +567,13 @@ type OpenstackStorage interface {
 	GetVolumesDetail() ([]cinder.Volume, error)
 	DeleteVolume(volumeId string) error
 	CreateVolume(cinder.CreateVolumeVolumeParams) (*cinder.Volume, error)
+	CreateSnapshot(cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error)
+	DeleteSnapshot(snapshotId string) error
 	AttachVolume(serverId, volumeId, mountPoint string) (*nova.VolumeAttachment, error)
 	DetachVolume(serverId, attachmentId string) error
 	ListVolumeAttachments(serverId string) ([]nova.VolumeAttachment, error)
 	SetVolumeMetadata(volumeId string, metadata map[string]string) (map[string]string, error)
+	ExtendVolume(volumeId string, newSize int) error
 }
 
 type endpointResolver interface {
@@ -516,6 +608,44 @@ type openstackStorageAdapter struct {
 
 type cinderClient struct {
 	*cinder.Client
+	endpoint      *url.URL
+	handleRequest cinder.RequestHandlerFn
+}
+
+// extendVolume grows the volume to newSize GiB with the "os-extend"
+// volume action, which goose does not yet provide. Extending an
+// in-use volume requires Cinder API microversion 3.42 or later;
+// older clouds will reject the request unless the volume is detached.
+func (c cinderClient) extendVolume(volumeId string, newSize int) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"os-extend": map[string]int{"new_size": newSize},
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	endpoint := *c.endpoint
+	if !strings.HasSuffix(endpoint.Path, "/") {
+		endpoint.Path += "/"
+	}
+	actionURL := endpoint.ResolveReference(&url.URL{
+		Path: fmt.Sprintf("volumes/%s/action", volumeId),
+	})
+	req, err := http.NewRequest("POST", actionURL.String(), bytes.NewReader(body))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("OpenStack-API-Version", "volume 3.42")
+	resp, err := c.handleRequest(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		message, _ := ioutil.ReadAll(resp.Body)
+		return errors.Errorf("extending volume: %s: %s", resp.Status, bytes.TrimSpace(message))
+	}
+	return nil
 }
 
 type novaClient struct {
@@ -531,6 +661,26 @@ func (ga *openstackStorageAdapter) CreateVolume(args cinder.CreateVolumeVolumePa
 	return &resp.Volume, nil
 }
 
+// CreateSnapshot is part of the OpenstackStorage interface.
+func (ga *openstackStorageAdapter) CreateSnapshot(args cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error) {
+	resp, err := ga.cinderClient.CreateSnapshot(args)
+	if err != nil {
+		return nil, err
+	}
+	return &resp.Snapshot, nil
+}
+
+// DeleteSnapshot is part of the OpenstackStorage interface.
+func (ga *openstackStorageAdapter) DeleteSnapshot(snapshotId string) error {
+	err := ga.cinderClient.DeleteSnapshot(snapshotId)
+	// The cinder client does not classify errors, so a missing
+	// snapshot can only be identified by the response status.
+	if err != nil && strings.HasPrefix(err.Error(), "invalid status (404)") {
+		return errors.NewNotFound(err, "")
+	}
+	return err
+}
+
 // GetVolumesDetail is part of the OpenstackStorage interface.
 func (ga *openstackStorageAdapter) GetVolumesDetail() ([]cinder.Volume, error) {
 	resp, err := ga.cinderClient.GetVolumesDetail()
@@ -549,6 +699,11 @@ func (ga *openstackStorageAdapter) GetVolume(volumeId string) (*cinder.Volume, e
 	return &resp.Volume, nil
 }
 
+// ExtendVolume is part of the OpenstackStorage interface.
+func (ga *openstackStorageAdapter) ExtendVolume(volumeId string, newSize int) error {
+	return ga.cinderClient.extendVolume(volumeId, newSize)
+}
+
 // SetVolumeMetadata is part of the OpenstackStorage interface.
 func (ga *openstackStorageAdapter) SetVolumeMetadata(volumeId string, metadata map[string]string) (map[string]string, error) {
 	return ga.cinderClient.SetVolumeMetadata(volumeId, metadata)
@@ -5,6 +5,11 @@ package openstack_test
 
 import (
 	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/juju/errors"
@@ -189,6 +194,194 @@ func (s *cinderVolumeSourceSuite) TestCreateVolume(c *gc.C) {
 	c.Check(getVolumeCalls, gc.Equals, 2)
 }
 
+func (s *cinderVolumeSourceSuite) TestCreateVolumeFromSnapshot(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		createVolume: func(args cinder.CreateVolumeVolumeParams) (*cinder.Volume, error) {
+			c.Assert(args, jc.DeepEquals, cinder.CreateVolumeVolumeParams{
+				Size:       2,
+				Name:       "juju-testenv-volume-123",
+				SnapshotId: "snap-123",
+			})
+			return &cinder.Volume{
+				ID: mockVolId,
+			}, nil
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	results, err := volSource.CreateVolumes([]storage.VolumeParams{{
+		Provider:   openstack.CinderProviderType,
+		Tag:        mockVolumeTag,
+		Size:       2048,
+		SnapshotId: "snap-123",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+}
+
+func (s *cinderVolumeSourceSuite) TestCreateVolumeSnapshots(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		createSnapshot: func(args cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error) {
+			if args.VolumeId == "vol-bad" {
+				return nil, errors.New("no snapshot for you")
+			}
+			return &cinder.Snapshot{
+				ID:       "snap-123",
+				VolumeID: args.VolumeId,
+				Size:     2,
+			}, nil
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	snapshotter, ok := volSource.(storage.VolumeSnapshotter)
+	c.Assert(ok, jc.IsTrue)
+	results, err := snapshotter.CreateVolumeSnapshots([]storage.VolumeSnapshotParams{{
+		Volume:   mockVolumeTag,
+		VolumeId: mockVolId,
+		Provider: openstack.CinderProviderType,
+	}, {
+		Volume:   mockVolumeTag,
+		VolumeId: "vol-bad",
+		Provider: openstack.CinderProviderType,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].VolumeSnapshot, jc.DeepEquals, &storage.VolumeSnapshot{
+		Volume:     mockVolumeTag,
+		SnapshotId: "snap-123",
+		Size:       2048,
+	})
+	c.Assert(results[1].Error, gc.ErrorMatches, `creating snapshot of "vol-bad": no snapshot for you`)
+	mockAdapter.CheckCall(c, 0, "CreateSnapshot", cinder.CreateSnapshotSnapshotParams{
+		VolumeId:    mockVolId,
+		Force:       true,
+		Name:        "juju-testenv-volume-123",
+		Description: "juju snapshot of volume-123",
+	})
+}
+
+func (s *cinderVolumeSourceSuite) TestDestroyVolumeSnapshots(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		deleteSnapshot: func(snapshotId string) error {
+			switch snapshotId {
+			case "snap-gone":
+				return errors.NotFoundf("snapshot %q", snapshotId)
+			case "snap-bad":
+				return errors.New("no deleting for you")
+			}
+			return nil
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	errs, err := volSource.(storage.VolumeSnapshotter).DestroyVolumeSnapshots([]string{
+		"snap-123", "snap-gone", "snap-bad",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, gc.HasLen, 3)
+	c.Assert(errs[0], jc.ErrorIsNil)
+	c.Assert(errs[1], jc.ErrorIsNil)
+	c.Assert(errs[2], gc.ErrorMatches, `destroying snapshot "snap-bad": no deleting for you`)
+	mockAdapter.CheckCallNames(c, "DeleteSnapshot", "DeleteSnapshot", "DeleteSnapshot")
+}
+
+func (s *cinderVolumeSourceSuite) TestResizeVolumes(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		extendVolume: func(volumeId string, newSize int) error {
+			if volumeId == "vol-bad" {
+				return errors.New("no resize for you")
+			}
+			return nil
+		},
+		getVolume: func(volumeId string) (*cinder.Volume, error) {
+			return &cinder.Volume{
+				ID:     volumeId,
+				Size:   3,
+				Status: "in-use",
+			}, nil
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	resizer, ok := volSource.(storage.VolumeResizer)
+	c.Assert(ok, jc.IsTrue)
+	results, err := resizer.ResizeVolumes([]storage.VolumeResizeParams{{
+		Volume:   mockVolumeTag,
+		VolumeId: mockVolId,
+		Size:     2500,
+	}, {
+		Volume:   mockVolumeTag,
+		VolumeId: "vol-bad",
+		Size:     2500,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Size, gc.Equals, uint64(3072))
+	c.Assert(results[1].Error, gc.ErrorMatches, `resizing volume "vol-bad": no resize for you`)
+	mockAdapter.CheckCalls(c, []gitjujutesting.StubCall{
+		{"ExtendVolume", []interface{}{mockVolId, 3}},
+		{"GetVolume", []interface{}{mockVolId}},
+		{"ExtendVolume", []interface{}{"vol-bad", 3}},
+	})
+}
+
+func (s *cinderVolumeSourceSuite) TestResizeVolumesErrorExtending(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		extendVolume: func(volumeId string, newSize int) error {
+			return nil
+		},
+		getVolume: func(volumeId string) (*cinder.Volume, error) {
+			return &cinder.Volume{
+				ID:     volumeId,
+				Size:   1,
+				Status: "error_extending",
+			}, nil
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	results, err := volSource.(storage.VolumeResizer).ResizeVolumes([]storage.VolumeResizeParams{{
+		Volume:   mockVolumeTag,
+		VolumeId: mockVolId,
+		Size:     2048,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, `resizing volume "0": waiting for volume to be extended: volume failed to extend`)
+}
+
+func (s *cinderVolumeSourceSuite) TestExtendVolume(c *gc.C) {
+	var requests []*http.Request
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, err := ioutil.ReadAll(req.Body)
+		c.Check(err, jc.ErrorIsNil)
+		requests = append(requests, req)
+		bodies = append(bodies, string(body))
+		if strings.Contains(req.URL.Path, "vol-bad") {
+			http.Error(w, "volume is busy", http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	endpoint, err := url.Parse(server.URL + "/v2/tenant")
+	c.Assert(err, jc.ErrorIsNil)
+	err = openstack.ExtendVolume(endpoint, mockVolId, 3)
+	c.Assert(err, jc.ErrorIsNil)
+	err = openstack.ExtendVolume(endpoint, "vol-bad", 3)
+	c.Assert(err, gc.ErrorMatches, `extending volume: 400 Bad Request: volume is busy`)
+
+	c.Assert(requests, gc.HasLen, 2)
+	c.Assert(requests[0].Method, gc.Equals, "POST")
+	c.Assert(requests[0].URL.Path, gc.Equals, "/v2/tenant/volumes/0/action")
+	c.Assert(requests[0].Header.Get("Content-Type"), gc.Equals, "application/json")
+	c.Assert(requests[0].Header.Get("OpenStack-API-Version"), gc.Equals, "volume 3.42")
+	c.Assert(bodies[0], jc.JSONEquals, map[string]interface{}{
+		"os-extend": map[string]interface{}{"new_size": 3},
+	})
+}
+
 func (s *cinderVolumeSourceSuite) TestResourceTags(c *gc.C) {
 	var created bool
 	mockAdapter := &mockAdapter{
@@ -469,11 +662,14 @@ type mockAdapter struct {
 	getVolumesDetail      func() ([]cinder.Volume, error)
 	deleteVolume          func(string) error
 	createVolume          func(cinder.CreateVolumeVolumeParams) (*cinder.Volume, error)
+	createSnapshot        func(cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error)
+	deleteSnapshot        func(string) error
 	attachVolume          func(string, string, string) (*nova.VolumeAttachment, error)
 	volumeStatusNotifier  func(string, string, int, time.Duration) <-chan error
 	detachVolume          func(string, string) error
 	listVolumeAttachments func(string) ([]nova.VolumeAttachment, error)
 	setVolumeMetadata     func(string, map[string]string) (map[string]string, error)
+	extendVolume          func(string, int) error
 }
 
 func (ma *mockAdapter) GetVolume(volumeId string) (*cinder.Volume, error) {
@@ -511,6 +707,22 @@ func (ma *mockAdapter) CreateVolume(args cinder.CreateVolumeVolumeParams) (*cind
 	return nil, errors.NotImplementedf("CreateVolume")
 }
 
+func (ma *mockAdapter) CreateSnapshot(args cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error) {
+	ma.MethodCall(ma, "CreateSnapshot", args)
+	if ma.createSnapshot != nil {
+		return ma.createSnapshot(args)
+	}
+	return nil, errors.NotImplementedf("CreateSnapshot")
+}
+
+func (ma *mockAdapter) DeleteSnapshot(snapshotId string) error {
+	ma.MethodCall(ma, "DeleteSnapshot", snapshotId)
+	if ma.deleteSnapshot != nil {
+		return ma.deleteSnapshot(snapshotId)
+	}
+	return nil
+}
+
 func (ma *mockAdapter) AttachVolume(serverId, volumeId, mountPoint string) (*nova.VolumeAttachment, error) {
 	ma.MethodCall(ma, "AttachVolume", serverId, volumeId, mountPoint)
 	if ma.attachVolume != nil {
@@ -543,6 +755,14 @@ func (ma *mockAdapter) SetVolumeMetadata(volumeId string, metadata map[string]st
 	return nil, nil
 }
 
+func (ma *mockAdapter) ExtendVolume(volumeId string, newSize int) error {
+	ma.MethodCall(ma, "ExtendVolume", volumeId, newSize)
+	if ma.extendVolume != nil {
+		return ma.extendVolume(volumeId, newSize)
+	}
+	return errors.NotImplementedf("ExtendVolume")
+}
+
 type testEndpointResolver struct {
 	authenticated   bool
 	regionEndpoints map[string]identity.ServiceURLs
@@ -6,6 +6,8 @@ package openstack
 import (
 	"bytes"
 	"fmt"
+	"net/http"
+	"net/url"
 	"regexp"
 	"strings"
 	"text/template"
@@ -90,6 +92,13 @@ func NewCinderVolumeSourceForModel(s OpenstackStorage, modelUUID string) storage
 	}
 }
 
+// ExtendVolume sends an "os-extend" volume action to the Cinder
+// endpoint, without authentication.
+func ExtendVolume(endpoint *url.URL, volumeId string, newSize int) error {
+	client := cinderClient{endpoint: endpoint, handleRequest: http.DefaultClient.Do}
+	return client.extendVolume(volumeId, newSize)
+}
+
 type fakeNamespace struct {
 	instance.Namespace
 }
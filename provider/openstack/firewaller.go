@@ -13,6 +13,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/retry"
 	"github.com/juju/utils/clock"
+	"github.com/juju/utils/set"
 	"gopkg.in/goose.v1/neutron"
 
 	"github.com/juju/juju/environs"
@@ -81,6 +82,28 @@ type Firewaller interface {
 
 	// InstanceIngressRules returns the ingress rules applied to the specified  instance.
 	InstanceIngressRules(inst instance.Instance, machineId string) ([]network.IngressRule, error)
+
+	// OpenEgressRules allows outgoing traffic matching the given rules
+	// for the whole environment.
+	OpenEgressRules(rules []network.EgressRule) error
+
+	// CloseEgressRules removes the given egress rules from the whole
+	// environment.
+	CloseEgressRules(rules []network.EgressRule) error
+
+	// EgressRules returns the egress rules applied to the whole environment.
+	EgressRules() ([]network.EgressRule, error)
+
+	// OpenInstanceEgressRules allows outgoing traffic matching the given
+	// rules for the specified instance.
+	OpenInstanceEgressRules(inst instance.Instance, machineId string, rules []network.EgressRule) error
+
+	// CloseInstanceEgressRules removes the given egress rules from the
+	// specified instance.
+	CloseInstanceEgressRules(inst instance.Instance, machineId string, rules []network.EgressRule) error
+
+	// InstanceEgressRules returns the egress rules applied to the specified instance.
+	InstanceEgressRules(inst instance.Instance, machineId string) ([]network.EgressRule, error)
 }
 
 type firewallerFactory struct {
@@ -205,6 +228,48 @@ func (f *switchingFirewaller) InstanceIngressRules(inst instance.Instance, machi
 	return f.fw.InstanceIngressRules(inst, machineId)
 }
 
+func (f *switchingFirewaller) OpenEgressRules(rules []network.EgressRule) error {
+	if err := f.initFirewaller(); err != nil {
+		return errors.Trace(err)
+	}
+	return f.fw.OpenEgressRules(rules)
+}
+
+func (f *switchingFirewaller) CloseEgressRules(rules []network.EgressRule) error {
+	if err := f.initFirewaller(); err != nil {
+		return errors.Trace(err)
+	}
+	return f.fw.CloseEgressRules(rules)
+}
+
+func (f *switchingFirewaller) EgressRules() ([]network.EgressRule, error) {
+	if err := f.initFirewaller(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return f.fw.EgressRules()
+}
+
+func (f *switchingFirewaller) OpenInstanceEgressRules(inst instance.Instance, machineId string, rules []network.EgressRule) error {
+	if err := f.initFirewaller(); err != nil {
+		return errors.Trace(err)
+	}
+	return f.fw.OpenInstanceEgressRules(inst, machineId, rules)
+}
+
+func (f *switchingFirewaller) CloseInstanceEgressRules(inst instance.Instance, machineId string, rules []network.EgressRule) error {
+	if err := f.initFirewaller(); err != nil {
+		return errors.Trace(err)
+	}
+	return f.fw.CloseInstanceEgressRules(inst, machineId, rules)
+}
+
+func (f *switchingFirewaller) InstanceEgressRules(inst instance.Instance, machineId string) ([]network.EgressRule, error) {
+	if err := f.initFirewaller(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return f.fw.InstanceEgressRules(inst, machineId)
+}
+
 type firewallerBase struct {
 	environ *Environ
 }
@@ -407,6 +472,95 @@ func (c *firewallerBase) instanceIngressRules(
 	return portRanges, nil
 }
 
+func (c *firewallerBase) openEgressRules(
+	openEgressRulesInGroup func(string, []network.EgressRule) error,
+	rules []network.EgressRule,
+) error {
+	if c.environ.Config().FirewallMode() != config.FwGlobal {
+		return errors.Errorf("invalid firewall mode %q for opening egress rules on model",
+			c.environ.Config().FirewallMode())
+	}
+	if err := openEgressRulesInGroup(c.globalGroupRegexp(), rules); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("opened egress rules in global group: %v", rules)
+	return nil
+}
+
+func (c *firewallerBase) closeEgressRules(
+	closeEgressRulesInGroup func(string, []network.EgressRule) error,
+	rules []network.EgressRule,
+) error {
+	if c.environ.Config().FirewallMode() != config.FwGlobal {
+		return errors.Errorf("invalid firewall mode %q for closing egress rules on model",
+			c.environ.Config().FirewallMode())
+	}
+	if err := closeEgressRulesInGroup(c.globalGroupRegexp(), rules); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("closed egress rules in global group: %v", rules)
+	return nil
+}
+
+func (c *firewallerBase) egressRules(
+	egressRulesInGroup func(string) ([]network.EgressRule, error),
+) ([]network.EgressRule, error) {
+	if c.environ.Config().FirewallMode() != config.FwGlobal {
+		return nil, errors.Errorf("invalid firewall mode %q for retrieving egress rules from model",
+			c.environ.Config().FirewallMode())
+	}
+	return egressRulesInGroup(c.globalGroupRegexp())
+}
+
+func (c *firewallerBase) openInstanceEgressRules(
+	openEgressRulesInGroup func(string, []network.EgressRule) error,
+	machineId string,
+	rules []network.EgressRule,
+) error {
+	if c.environ.Config().FirewallMode() != config.FwInstance {
+		return errors.Errorf("invalid firewall mode %q for opening egress rules on instance",
+			c.environ.Config().FirewallMode())
+	}
+	nameRegexp := c.machineGroupRegexp(machineId)
+	if err := openEgressRulesInGroup(nameRegexp, rules); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("opened egress rules in security group %s-%s: %v", c.environ.Config().UUID(), machineId, rules)
+	return nil
+}
+
+func (c *firewallerBase) closeInstanceEgressRules(
+	closeEgressRulesInGroup func(string, []network.EgressRule) error,
+	machineId string,
+	rules []network.EgressRule,
+) error {
+	if c.environ.Config().FirewallMode() != config.FwInstance {
+		return errors.Errorf("invalid firewall mode %q for closing egress rules on instance",
+			c.environ.Config().FirewallMode())
+	}
+	nameRegexp := c.machineGroupRegexp(machineId)
+	if err := closeEgressRulesInGroup(nameRegexp, rules); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("closed egress rules in security group %s-%s: %v", c.environ.Config().UUID(), machineId, rules)
+	return nil
+}
+
+func (c *firewallerBase) instanceEgressRules(
+	egressRulesInGroup func(string) ([]network.EgressRule, error),
+	machineId string,
+) ([]network.EgressRule, error) {
+	if c.environ.Config().FirewallMode() != config.FwInstance {
+		return nil, errors.Errorf("invalid firewall mode %q for retrieving egress rules from instance",
+			c.environ.Config().FirewallMode())
+	}
+	rules, err := egressRulesInGroup(c.machineGroupRegexp(machineId))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return rules, nil
+}
+
 func (c *firewallerBase) globalGroupName(controllerUUID string) string {
 	return fmt.Sprintf("%s-global", c.jujuGroupName(controllerUUID))
 }
@@ -739,6 +893,36 @@ func (c *neutronFirewaller) InstanceIngressRules(inst instance.Instance, machine
 	return c.instanceIngressRules(c.ingressRulesInGroup, machineId)
 }
 
+// OpenEgressRules implements Firewaller interface.
+func (c *neutronFirewaller) OpenEgressRules(rules []network.EgressRule) error {
+	return c.openEgressRules(c.openEgressRulesInGroup, rules)
+}
+
+// CloseEgressRules implements Firewaller interface.
+func (c *neutronFirewaller) CloseEgressRules(rules []network.EgressRule) error {
+	return c.closeEgressRules(c.closeEgressRulesInGroup, rules)
+}
+
+// EgressRules implements Firewaller interface.
+func (c *neutronFirewaller) EgressRules() ([]network.EgressRule, error) {
+	return c.egressRules(c.egressRulesInGroup)
+}
+
+// OpenInstanceEgressRules implements Firewaller interface.
+func (c *neutronFirewaller) OpenInstanceEgressRules(inst instance.Instance, machineId string, rules []network.EgressRule) error {
+	return c.openInstanceEgressRules(c.openEgressRulesInGroup, machineId, rules)
+}
+
+// CloseInstanceEgressRules implements Firewaller interface.
+func (c *neutronFirewaller) CloseInstanceEgressRules(inst instance.Instance, machineId string, rules []network.EgressRule) error {
+	return c.closeInstanceEgressRules(c.closeEgressRulesInGroup, machineId, rules)
+}
+
+// InstanceEgressRules implements Firewaller interface.
+func (c *neutronFirewaller) InstanceEgressRules(inst instance.Instance, machineId string) ([]network.EgressRule, error) {
+	return c.instanceEgressRules(c.egressRulesInGroup, machineId)
+}
+
 // Matching a security group by name only works if each name is unqiue.  Neutron
 // security groups are not required to have unique names.  Juju constructs unique
 // names, but there are frequently multiple matches to 'default'
@@ -879,6 +1063,209 @@ func (c *neutronFirewaller) ingressRulesInGroup(nameRegexp string) (rules []netw
 	return rules, nil
 }
 
+// allowAllEgressRules are the rules Neutron adds to every new security
+// group, allowing all outgoing traffic.
+var allowAllEgressRules = []neutron.RuleInfoV2{
+	{
+		Direction:    "egress",
+		EthernetType: "IPv4",
+	},
+	{
+		Direction:    "egress",
+		EthernetType: "IPv6",
+	},
+}
+
+// isAllowAllEgressRule reports whether the security group rule is one
+// of the rules allowing all outgoing traffic.
+func isAllowAllEgressRule(rule neutron.SecurityGroupRuleV2) bool {
+	if rule.Direction != "egress" {
+		return false
+	}
+	if rule.IPProtocol != nil && *rule.IPProtocol != "" {
+		return false
+	}
+	switch rule.RemoteIPPrefix {
+	case "", "0.0.0.0/0", "::/0":
+		return true
+	}
+	return false
+}
+
+// egressRulesToRuleInfo maps egress rules to neutron rules.
+func egressRulesToRuleInfo(groupId string, rules []network.EgressRule) []neutron.RuleInfoV2 {
+	var result []neutron.RuleInfoV2
+	for _, r := range rules {
+		destinationCIDRs := r.DestinationCIDRs
+		if len(destinationCIDRs) == 0 {
+			destinationCIDRs = []string{"0.0.0.0/0"}
+		}
+		for _, cidr := range destinationCIDRs {
+			ruleInfo := neutron.RuleInfoV2{
+				Direction:      "egress",
+				ParentGroupId:  groupId,
+				PortRangeMin:   r.FromPort,
+				PortRangeMax:   r.ToPort,
+				IPProtocol:     r.Protocol,
+				RemoteIPPrefix: cidr,
+			}
+			if strings.Contains(cidr, ":") {
+				ruleInfo.EthernetType = "IPv6"
+			}
+			result = append(result, ruleInfo)
+		}
+	}
+	return result
+}
+
+// secGroupMatchesEgressRule checks if the supplied neutron security group
+// rule allows outgoing traffic for the port range to the destination.
+func secGroupMatchesEgressRule(secGroupRule neutron.SecurityGroupRuleV2, portRange network.PortRange, destinationCIDR string) bool {
+	if secGroupRule.Direction != "egress" || secGroupRule.IPProtocol == nil ||
+		secGroupRule.PortRangeMin == nil || secGroupRule.PortRangeMax == nil {
+		return false
+	}
+	if *secGroupRule.IPProtocol != portRange.Protocol ||
+		*secGroupRule.PortRangeMin != portRange.FromPort ||
+		*secGroupRule.PortRangeMax != portRange.ToPort {
+		return false
+	}
+	if secGroupRule.RemoteIPPrefix == "" {
+		return destinationCIDR == "0.0.0.0/0"
+	}
+	return secGroupRule.RemoteIPPrefix == destinationCIDR
+}
+
+// openEgressRulesInGroup adds the egress rules to the group, and removes
+// the rules allowing all outgoing traffic from it and from the model's
+// juju group, so that only traffic matching egress rules is allowed.
+func (c *neutronFirewaller) openEgressRulesInGroup(nameRegExp string, rules []network.EgressRule) error {
+	group, err := c.matchingGroup(nameRegExp)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	neutronClient := c.environ.neutron()
+	for _, rule := range egressRulesToRuleInfo(group.Id, rules) {
+		if _, err := neutronClient.CreateSecurityGroupRuleV2(rule); err != nil {
+			// TODO: if err is not rule already exists, raise?
+			logger.Debugf("error creating security group rule: %v", err.Error())
+		}
+	}
+	jujuGroup, err := c.matchingGroup("^" + c.jujuGroupRegexp() + "$")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, g := range []neutron.SecurityGroupV2{group, jujuGroup} {
+		for _, p := range g.Rules {
+			if !isAllowAllEgressRule(p) {
+				continue
+			}
+			if err := neutronClient.DeleteSecurityGroupRuleV2(p.Id); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+	if c.environ.ecfg().useDefaultSecurityGroup() {
+		logger.Warningf("egress rules do not restrict outgoing traffic allowed by the default security group")
+	}
+	return nil
+}
+
+// closeEgressRulesInGroup removes the egress rules from the group. Once
+// the group has no egress rules left, all outgoing traffic is allowed
+// again.
+func (c *neutronFirewaller) closeEgressRulesInGroup(nameRegExp string, rules []network.EgressRule) error {
+	if len(rules) == 0 {
+		return nil
+	}
+	group, err := c.matchingGroup(nameRegExp)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	neutronClient := c.environ.neutron()
+	deleted := set.NewStrings()
+	for _, rule := range rules {
+		destinationCIDRs := rule.DestinationCIDRs
+		if len(destinationCIDRs) == 0 {
+			destinationCIDRs = []string{"0.0.0.0/0"}
+		}
+		for _, cidr := range destinationCIDRs {
+			for _, p := range group.Rules {
+				if deleted.Contains(p.Id) || !secGroupMatchesEgressRule(p, rule.PortRange, cidr) {
+					continue
+				}
+				if err := neutronClient.DeleteSecurityGroupRuleV2(p.Id); err != nil {
+					return errors.Trace(err)
+				}
+				deleted.Add(p.Id)
+				break
+			}
+		}
+	}
+	for _, p := range group.Rules {
+		if p.Direction == "egress" && !deleted.Contains(p.Id) {
+			return nil
+		}
+	}
+	// The last egress rule has gone, so outgoing traffic is no longer
+	// restricted.
+	for _, rule := range allowAllEgressRules {
+		rule.ParentGroupId = group.Id
+		if _, err := neutronClient.CreateSecurityGroupRuleV2(rule); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+func (c *neutronFirewaller) egressRulesInGroup(nameRegexp string) (rules []network.EgressRule, err error) {
+	group, err := c.matchingGroup(nameRegexp)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// Keep track of all the RemoteIPPrefixes for each port range.
+	portDestinationCIDRs := make(map[network.PortRange]*[]string)
+	for _, p := range group.Rules {
+		// Skip the ingress rules, and the rules allowing all
+		// outgoing traffic created by Neutron.
+		if p.Direction != "egress" || isAllowAllEgressRule(p) || p.IPProtocol == nil {
+			continue
+		}
+		portRange := network.PortRange{
+			Protocol: *p.IPProtocol,
+		}
+		if p.PortRangeMin != nil {
+			portRange.FromPort = *p.PortRangeMin
+		}
+		if p.PortRangeMax != nil {
+			portRange.ToPort = *p.PortRangeMax
+		}
+		remotePrefix := p.RemoteIPPrefix
+		if remotePrefix == "" {
+			remotePrefix = "0.0.0.0/0"
+		}
+		destinationCIDRs, ok := portDestinationCIDRs[portRange]
+		if !ok {
+			destinationCIDRs = &[]string{}
+			portDestinationCIDRs[portRange] = destinationCIDRs
+		}
+		*destinationCIDRs = append(*destinationCIDRs, remotePrefix)
+	}
+	for portRange, destinationCIDRs := range portDestinationCIDRs {
+		rule, err := network.NewEgressRule(
+			portRange.Protocol,
+			portRange.FromPort,
+			portRange.ToPort,
+			*destinationCIDRs...)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		rules = append(rules, rule)
+	}
+	network.SortEgressRules(rules)
+	return rules, nil
+}
+
 func replaceControllerUUID(oldName, controllerUUID string) (string, error) {
 	if !extractControllerRe.MatchString(oldName) {
 		return "", errors.Errorf("unexpected security group name format for %q", oldName)
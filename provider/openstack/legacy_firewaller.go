@@ -230,6 +230,36 @@ func (c *legacyNovaFirewaller) InstanceIngressRules(inst instance.Instance, mach
 	return c.instanceIngressRules(c.ingressRulesInGroup, machineId)
 }
 
+// OpenEgressRules is not supported by nova security groups.
+func (c *legacyNovaFirewaller) OpenEgressRules(rules []network.EgressRule) error {
+	return errors.NotSupportedf("egress rules without neutron")
+}
+
+// CloseEgressRules is not supported by nova security groups.
+func (c *legacyNovaFirewaller) CloseEgressRules(rules []network.EgressRule) error {
+	return errors.NotSupportedf("egress rules without neutron")
+}
+
+// EgressRules is not supported by nova security groups.
+func (c *legacyNovaFirewaller) EgressRules() ([]network.EgressRule, error) {
+	return nil, errors.NotSupportedf("egress rules without neutron")
+}
+
+// OpenInstanceEgressRules is not supported by nova security groups.
+func (c *legacyNovaFirewaller) OpenInstanceEgressRules(inst instance.Instance, machineId string, rules []network.EgressRule) error {
+	return errors.NotSupportedf("egress rules without neutron")
+}
+
+// CloseInstanceEgressRules is not supported by nova security groups.
+func (c *legacyNovaFirewaller) CloseInstanceEgressRules(inst instance.Instance, machineId string, rules []network.EgressRule) error {
+	return errors.NotSupportedf("egress rules without neutron")
+}
+
+// InstanceEgressRules is not supported by nova security groups.
+func (c *legacyNovaFirewaller) InstanceEgressRules(inst instance.Instance, machineId string) ([]network.EgressRule, error) {
+	return nil, errors.NotSupportedf("egress rules without neutron")
+}
+
 func (c *legacyNovaFirewaller) matchingGroup(nameRegExp string) (nova.SecurityGroup, error) {
 	re, err := regexp.Compile(nameRegExp)
 	if err != nil {
@@ -673,6 +673,92 @@ func (s *localServerSuite) TestDestroyEnvironmentDeletesSecurityGroupsFWModeGlob
 	assertSecurityGroups(c, env, []string{"default"})
 }
 
+func countAllowAllEgressRules(c *gc.C, env environs.Environ, groupName string) int {
+	neutronClient := openstack.GetNeutronClient(env)
+	groups, err := neutronClient.SecurityGroupByNameV2(groupName)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(groups, gc.HasLen, 1)
+	count := 0
+	for _, rule := range groups[0].Rules {
+		if rule.Direction == "egress" && (rule.IPProtocol == nil || *rule.IPProtocol == "") {
+			count++
+		}
+	}
+	return count
+}
+
+func (s *localServerSuite) TestInstanceEgressRules(c *gc.C) {
+	env := s.openEnviron(c, coretesting.Attrs{"firewall-mode": config.FwInstance})
+	instanceName := "100"
+	inst, _ := testing.AssertStartInstance(c, env, s.ControllerUUID, instanceName)
+	jujuGroup := fmt.Sprintf("juju-%v-%v", s.ControllerUUID, env.Config().UUID())
+	machineGroup := fmt.Sprintf("%v-%v", jujuGroup, instanceName)
+	egressFirewaller, ok := inst.(instance.EgressFirewaller)
+	c.Assert(ok, jc.IsTrue)
+
+	rules, err := egressFirewaller.EgressRules(instanceName)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, gc.HasLen, 0)
+	c.Assert(countAllowAllEgressRules(c, env, jujuGroup), gc.Equals, 2)
+	c.Assert(countAllowAllEgressRules(c, env, machineGroup), gc.Equals, 2)
+
+	httpsRule := network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8")
+	dnsRule := network.MustNewEgressRule("udp", 53, 53, "10.0.0.2/32", "10.0.0.3/32")
+	err = egressFirewaller.OpenEgressRules(instanceName, []network.EgressRule{httpsRule, dnsRule})
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err = egressFirewaller.EgressRules(instanceName)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.EgressRule{httpsRule, dnsRule})
+	// Outgoing traffic is now restricted to the egress rules.
+	c.Assert(countAllowAllEgressRules(c, env, jujuGroup), gc.Equals, 0)
+	c.Assert(countAllowAllEgressRules(c, env, machineGroup), gc.Equals, 0)
+
+	err = egressFirewaller.CloseEgressRules(instanceName, []network.EgressRule{dnsRule})
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err = egressFirewaller.EgressRules(instanceName)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.EgressRule{httpsRule})
+	c.Assert(countAllowAllEgressRules(c, env, machineGroup), gc.Equals, 0)
+
+	// Closing the last egress rule lifts the restriction.
+	err = egressFirewaller.CloseEgressRules(instanceName, []network.EgressRule{httpsRule})
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err = egressFirewaller.EgressRules(instanceName)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, gc.HasLen, 0)
+	c.Assert(countAllowAllEgressRules(c, env, machineGroup), gc.Equals, 2)
+}
+
+func (s *localServerSuite) TestGlobalEgressRules(c *gc.C) {
+	env := s.openEnviron(c, coretesting.Attrs{"firewall-mode": config.FwGlobal})
+	testing.AssertStartInstance(c, env, s.ControllerUUID, "100")
+	globalGroup := fmt.Sprintf("juju-%v-%v-global", s.ControllerUUID, env.Config().UUID())
+	egressFirewaller, ok := env.(environs.EgressFirewaller)
+	c.Assert(ok, jc.IsTrue)
+
+	rule := network.MustNewEgressRule("tcp", 80, 443, "192.168.0.0/16")
+	err := egressFirewaller.OpenEgressRules([]network.EgressRule{rule})
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err := egressFirewaller.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.EgressRule{rule})
+	c.Assert(countAllowAllEgressRules(c, env, globalGroup), gc.Equals, 0)
+
+	err = egressFirewaller.CloseEgressRules([]network.EgressRule{rule})
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err = egressFirewaller.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, gc.HasLen, 0)
+	c.Assert(countAllowAllEgressRules(c, env, globalGroup), gc.Equals, 2)
+}
+
+func (s *localServerSuite) TestInstanceEgressRulesWrongFirewallMode(c *gc.C) {
+	env := s.openEnviron(c, coretesting.Attrs{"firewall-mode": config.FwInstance})
+	rule := network.MustNewEgressRule("tcp", 443, 443)
+	err := env.(environs.EgressFirewaller).OpenEgressRules([]network.EgressRule{rule})
+	c.Assert(err, gc.ErrorMatches, `invalid firewall mode "instance" for opening egress rules on model`)
+}
+
 func (s *localServerSuite) TestDestroyController(c *gc.C) {
 	env := s.openEnviron(c, coretesting.Attrs{"uuid": utils.MustNewUUID().String()})
 	controllerEnv := s.env
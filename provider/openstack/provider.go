@@ -282,6 +282,7 @@ var _ simplestreams.HasRegion = (*Environ)(nil)
 var _ state.Prechecker = (*Environ)(nil)
 var _ instance.Distributor = (*Environ)(nil)
 var _ environs.InstanceTagger = (*Environ)(nil)
+var _ environs.EgressFirewaller = (*Environ)(nil)
 
 type openstackInstance struct {
 	e        *Environ
@@ -299,6 +300,7 @@ func (inst *openstackInstance) String() string {
 }
 
 var _ instance.Instance = (*openstackInstance)(nil)
+var _ instance.EgressFirewaller = (*openstackInstance)(nil)
 
 func (inst *openstackInstance) Refresh() error {
 	inst.mu.Lock()
@@ -445,6 +447,18 @@ func (inst *openstackInstance) IngressRules(machineId string) ([]network.Ingress
 	return inst.e.firewaller.InstanceIngressRules(inst, machineId)
 }
 
+func (inst *openstackInstance) OpenEgressRules(machineId string, rules []network.EgressRule) error {
+	return inst.e.firewaller.OpenInstanceEgressRules(inst, machineId, rules)
+}
+
+func (inst *openstackInstance) CloseEgressRules(machineId string, rules []network.EgressRule) error {
+	return inst.e.firewaller.CloseInstanceEgressRules(inst, machineId, rules)
+}
+
+func (inst *openstackInstance) EgressRules(machineId string) ([]network.EgressRule, error) {
+	return inst.e.firewaller.InstanceEgressRules(inst, machineId)
+}
+
 func (e *Environ) ecfg() *environConfig {
 	e.ecfgMutex.Lock()
 	ecfg := e.ecfgUnlocked
@@ -1496,6 +1510,18 @@ func (e *Environ) IngressRules() ([]network.IngressRule, error) {
 	return e.firewaller.IngressRules()
 }
 
+func (e *Environ) OpenEgressRules(rules []network.EgressRule) error {
+	return e.firewaller.OpenEgressRules(rules)
+}
+
+func (e *Environ) CloseEgressRules(rules []network.EgressRule) error {
+	return e.firewaller.CloseEgressRules(rules)
+}
+
+func (e *Environ) EgressRules() ([]network.EgressRule, error) {
+	return e.firewaller.EgressRules()
+}
+
 func (e *Environ) Provider() environs.EnvironProvider {
 	return providerInstance
 }
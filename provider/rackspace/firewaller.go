@@ -92,6 +92,36 @@ func (c *rackspaceFirewaller) InstanceIngressRules(inst instance.Instance, machi
 	return configurator.FindIngressRules()
 }
 
+// OpenEgressRules is not supported.
+func (c *rackspaceFirewaller) OpenEgressRules(rules []network.EgressRule) error {
+	return errors.NotSupportedf("OpenEgressRules")
+}
+
+// CloseEgressRules is not supported.
+func (c *rackspaceFirewaller) CloseEgressRules(rules []network.EgressRule) error {
+	return errors.NotSupportedf("CloseEgressRules")
+}
+
+// EgressRules is not supported.
+func (c *rackspaceFirewaller) EgressRules() ([]network.EgressRule, error) {
+	return nil, errors.NotSupportedf("EgressRules")
+}
+
+// OpenInstanceEgressRules is not supported.
+func (c *rackspaceFirewaller) OpenInstanceEgressRules(inst instance.Instance, machineId string, rules []network.EgressRule) error {
+	return errors.NotSupportedf("OpenInstanceEgressRules")
+}
+
+// CloseInstanceEgressRules is not supported.
+func (c *rackspaceFirewaller) CloseInstanceEgressRules(inst instance.Instance, machineId string, rules []network.EgressRule) error {
+	return errors.NotSupportedf("CloseInstanceEgressRules")
+}
+
+// InstanceEgressRules is not supported.
+func (c *rackspaceFirewaller) InstanceEgressRules(inst instance.Instance, machineId string) ([]network.EgressRule, error) {
+	return nil, errors.NotSupportedf("InstanceEgressRules")
+}
+
 func (c *rackspaceFirewaller) changeIngressRules(inst instance.Instance, insert bool, rules []network.IngressRule) error {
 	addresses, sshClient, err := c.getInstanceConfigurator(inst)
 	if err != nil {
@@ -5,9 +5,11 @@ package state
 
 import (
 	"time"
+	"unicode/utf8"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	jujutxn "github.com/juju/txn"
 	"github.com/juju/utils"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/mgo.v2"
@@ -45,6 +47,10 @@ const (
 
 	// ActionRunning indicates that the Action is currently running.
 	ActionRunning ActionStatus = "running"
+
+	// ActionAborting indicates that the Action is running but has been
+	// asked to stop, and the receiver has yet to acknowledge it.
+	ActionAborting ActionStatus = "aborting"
 )
 
 type actionNotificationDoc struct {
@@ -64,6 +70,11 @@ type actionNotificationDoc struct {
 	// ActionID is the unique identifier for the Action this notification
 	// represents.
 	ActionID string `bson:"actionid"`
+
+	// Aborting is set when a running Action has been cancelled; setting
+	// it causes the notification to be delivered again, so that the
+	// receiver can stop the running Action.
+	Aborting bool `bson:"aborting,omitempty"`
 }
 
 type actionDoc struct {
@@ -85,6 +96,10 @@ type actionDoc struct {
 	// against the schema defined by the named action in the unit's charm.
 	Parameters map[string]interface{} `bson:"parameters"`
 
+	// Timeout is the maximum time the action may run for before it is
+	// stopped by its receiver; zero means no limit.
+	Timeout time.Duration `bson:"timeout,omitempty"`
+
 	// Enqueued is the time the action was added.
 	Enqueued time.Time `bson:"enqueued"`
 
@@ -104,6 +119,35 @@ type actionDoc struct {
 
 	// Results are the structured results from the action.
 	Results map[string]interface{} `bson:"results"`
+
+	// Messages holds the progress messages logged by the action
+	// while it was running, oldest first.
+	Messages []ActionMessage `bson:"messages,omitempty"`
+
+	// TxnRevno is used to assert that the messages have not changed
+	// while a new one is being logged.
+	TxnRevno int64 `bson:"txn-revno"`
+}
+
+const (
+	// maxActionMessages is the maximum number of progress messages
+	// kept for an action; older messages are discarded.
+	maxActionMessages = 1000
+
+	// maxActionMessageBytes is the maximum length of a single progress
+	// message; longer messages are truncated.
+	maxActionMessageBytes = 4 * 1024
+
+	// maxActionMessagesBytes is the maximum total length of the
+	// progress messages kept for an action; older messages are
+	// discarded to keep within it.
+	maxActionMessagesBytes = 256 * 1024
+)
+
+// ActionMessage is a timestamped progress message logged by an action.
+type ActionMessage struct {
+	Timestamp time.Time `bson:"timestamp"`
+	Message   string    `bson:"message"`
 }
 
 // action represents an instruction to do some "action" and is expected
@@ -136,6 +180,12 @@ func (a *action) Parameters() map[string]interface{} {
 	return a.doc.Parameters
 }
 
+// Timeout returns the maximum time the action may run for; zero
+// means no limit.
+func (a *action) Timeout() time.Duration {
+	return a.doc.Timeout
+}
+
 // Enqueued returns the time the action was added to state as a pending
 // Action.
 func (a *action) Enqueued() time.Time {
@@ -162,6 +212,12 @@ func (a *action) Results() (map[string]interface{}, string) {
 	return a.doc.Results, a.doc.Message
 }
 
+// Messages returns the progress messages logged by the action,
+// oldest first.
+func (a *action) Messages() []ActionMessage {
+	return a.doc.Messages
+}
+
 // Tag implements the Entity interface and returns a names.Tag that
 // is a names.ActionTag.
 func (a *action) Tag() names.Tag {
@@ -201,26 +257,149 @@ func (a *action) Begin() (Action, error) {
 	return a.st.Action(a.Id())
 }
 
+// Log adds a timestamped progress message to the action. It asserts
+// that the action is running. Messages longer than
+// maxActionMessageBytes are truncated, and the oldest messages are
+// discarded to keep within maxActionMessages and
+// maxActionMessagesBytes.
+func (a *action) Log(message string) error {
+	msg := ActionMessage{
+		Timestamp: a.st.clock.Now().UTC(),
+		Message:   truncateActionMessage(message),
+	}
+	buildTxn := func(int) ([]txn.Op, error) {
+		current, err := a.st.Action(a.Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		doc := current.(*action).doc
+		if doc.Status != ActionRunning && doc.Status != ActionAborting {
+			return nil, errors.New("action is not running")
+		}
+		return []txn.Op{{
+			C:      actionsC,
+			Id:     a.doc.DocId,
+			Assert: bson.D{{"txn-revno", doc.TxnRevno}},
+			Update: bson.D{{"$set", bson.D{
+				{"messages", appendActionMessage(doc.Messages, msg)},
+			}}},
+		}}, nil
+	}
+	if err := a.st.run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot log message for action %q", a.Id())
+	}
+	return nil
+}
+
+// truncateActionMessage shortens the message to maxActionMessageBytes,
+// without splitting a multi-byte character.
+func truncateActionMessage(message string) string {
+	if len(message) <= maxActionMessageBytes {
+		return message
+	}
+	n := maxActionMessageBytes
+	for n > 0 && !utf8.RuneStart(message[n]) {
+		n--
+	}
+	return message[:n]
+}
+
+// appendActionMessage returns the messages with msg added, after
+// discarding as many of the oldest as are needed to keep within
+// maxActionMessages and maxActionMessagesBytes.
+func appendActionMessage(messages []ActionMessage, msg ActionMessage) []ActionMessage {
+	size := len(msg.Message)
+	keep := 0
+	for i := len(messages) - 1; i >= 0; i-- {
+		if keep+1 >= maxActionMessages || size+len(messages[i].Message) > maxActionMessagesBytes {
+			break
+		}
+		size += len(messages[i].Message)
+		keep++
+	}
+	result := make([]ActionMessage, 0, keep+1)
+	result = append(result, messages[len(messages)-keep:]...)
+	return append(result, msg)
+}
+
 // Finish removes action from the pending queue and captures the output
 // and end state of the action.
 func (a *action) Finish(results ActionResults) (Action, error) {
 	return a.removeAndLog(results.Status, results.Results, results.Message)
 }
 
+// Cancel stops the action. A pending action is removed from the queue
+// and marked as cancelled; a running action is marked as aborting, and
+// the receiver is notified so that it may stop it. It is an error to
+// cancel an action that has already finished.
+func (a *action) Cancel() (Action, error) {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		doc := a.doc
+		if attempt > 0 {
+			current, err := a.st.Action(a.Id())
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			doc = current.(*action).doc
+		}
+		switch doc.Status {
+		case ActionPending:
+			return a.finishOps(
+				bson.D{{"status", ActionPending}},
+				ActionCancelled, nil, "action cancelled",
+			), nil
+		case ActionRunning:
+			return []txn.Op{{
+				C:      actionsC,
+				Id:     a.doc.DocId,
+				Assert: bson.D{{"status", ActionRunning}},
+				Update: bson.D{{"$set", bson.D{
+					{"status", ActionAborting},
+				}}},
+			}, {
+				C:      actionNotificationsC,
+				Id:     a.st.docID(ensureActionMarker(a.Receiver()) + a.Id()),
+				Assert: txn.DocExists,
+				Update: bson.D{{"$set", bson.D{
+					{"aborting", true},
+				}}},
+			}}, nil
+		case ActionAborting:
+			return nil, jujutxn.ErrNoOperations
+		}
+		return nil, errors.Errorf("action is %s", doc.Status)
+	}
+	if err := a.st.run(buildTxn); err != nil {
+		return nil, errors.Annotatef(err, "cannot cancel action %q", a.Id())
+	}
+	return a.st.Action(a.Id())
+}
+
 // removeAndLog takes the action off of the pending queue, and creates
 // an actionresult to capture the outcome of the action. It asserts that
 // the action is not already completed.
 func (a *action) removeAndLog(finalStatus ActionStatus, results map[string]interface{}, message string) (Action, error) {
-	err := a.st.runTransaction([]txn.Op{
+	assert := bson.D{{"status", bson.D{
+		{"$nin", []interface{}{
+			ActionCompleted,
+			ActionCancelled,
+			ActionFailed,
+		}}}}}
+	err := a.st.runTransaction(a.finishOps(assert, finalStatus, results, message))
+	if err != nil {
+		return nil, err
+	}
+	return a.st.Action(a.Id())
+}
+
+// finishOps returns the operations that record the outcome of the action
+// and remove its notification, provided the action satisfies assert.
+func (a *action) finishOps(assert bson.D, finalStatus ActionStatus, results map[string]interface{}, message string) []txn.Op {
+	return []txn.Op{
 		{
-			C:  actionsC,
-			Id: a.doc.DocId,
-			Assert: bson.D{{"status", bson.D{
-				{"$nin", []interface{}{
-					ActionCompleted,
-					ActionCancelled,
-					ActionFailed,
-				}}}}},
+			C:      actionsC,
+			Id:     a.doc.DocId,
+			Assert: assert,
 			Update: bson.D{{"$set", bson.D{
 				{"status", finalStatus},
 				{"message", message},
@@ -231,11 +410,7 @@ func (a *action) removeAndLog(finalStatus ActionStatus, results map[string]inter
 			C:      actionNotificationsC,
 			Id:     a.st.docID(ensureActionMarker(a.Receiver()) + a.Id()),
 			Remove: true,
-		}})
-	if err != nil {
-		return nil, err
-	}
-	return a.st.Action(a.Id())
+		}}
 }
 
 // newAction builds an Action for the given State and actionDoc.
@@ -356,6 +531,13 @@ func (st *State) FindActionsByName(name string) ([]Action, error) {
 
 // EnqueueAction
 func (st *State) EnqueueAction(receiver names.Tag, actionName string, payload map[string]interface{}) (Action, error) {
+	return st.enqueueAction(receiver, actionName, payload, 0)
+}
+
+// enqueueAction adds a pending action for the receiver, that will be
+// stopped if it runs for longer than timeout; a zero timeout means no
+// limit.
+func (st *State) enqueueAction(receiver names.Tag, actionName string, payload map[string]interface{}, timeout time.Duration) (Action, error) {
 	if len(actionName) == 0 {
 		return nil, errors.New("action name required")
 	}
@@ -365,10 +547,14 @@ func (st *State) EnqueueAction(receiver names.Tag, actionName string, payload ma
 		return nil, errors.Trace(err)
 	}
 
+	if timeout < 0 {
+		return nil, errors.NotValidf("negative action timeout %v", timeout)
+	}
 	doc, ndoc, err := newActionDoc(st, receiver, actionName, payload)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	doc.Timeout = timeout
 
 	ops := []txn.Op{{
 		C:      receiverCollectionName,
@@ -432,7 +618,10 @@ func (st *State) matchingActionsPending(ar ActionReceiver) ([]Action, error) {
 // matchingActionsRunning finds actions that match ActionReceiver and
 // that are running.
 func (st *State) matchingActionsRunning(ar ActionReceiver) ([]Action, error) {
-	completed := bson.D{{"status", ActionRunning}}
+	completed := bson.D{{"$or", []bson.D{
+		{{"status", ActionRunning}},
+		{{"status", ActionAborting}},
+	}}}
 	return st.matchingActionsByReceiverAndStatus(ar.Tag(), completed)
 }
 
@@ -7,6 +7,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -463,6 +464,186 @@ func (s *ActionSuite) TestComplete(c *gc.C) {
 	c.Assert(len(actions), gc.Equals, 0)
 }
 
+func (s *ActionSuite) TestAddActionWithTimeout(c *gc.C) {
+	unit, err := s.State.Unit(s.unit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+
+	a, err := unit.AddActionWithTimeout("snapshot", nil, 5*time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(a.Timeout(), gc.Equals, 5*time.Minute)
+
+	action, err := s.State.Action(a.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(action.Timeout(), gc.Equals, 5*time.Minute)
+
+	a, err = unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(a.Timeout(), gc.Equals, time.Duration(0))
+}
+
+func (s *ActionSuite) TestAddActionWithNegativeTimeout(c *gc.C) {
+	unit, err := s.State.Unit(s.unit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = unit.AddActionWithTimeout("snapshot", nil, -time.Second)
+	c.Assert(err, gc.ErrorMatches, "negative action timeout -1s not valid")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *ActionSuite) TestCancelPending(c *gc.C) {
+	unit, err := s.State.Unit(s.unit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	preventUnitDestroyRemove(c, unit)
+
+	a, err := unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := a.Cancel()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Status(), gc.Equals, state.ActionCancelled)
+	_, message := result.Results()
+	c.Assert(message, gc.Equals, "action cancelled")
+
+	actions, err := unit.PendingActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actions, gc.HasLen, 0)
+	actions, err = unit.CompletedActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actions, gc.HasLen, 1)
+}
+
+func (s *ActionSuite) TestCancelRunning(c *gc.C) {
+	unit, err := s.State.Unit(s.unit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	preventUnitDestroyRemove(c, unit)
+
+	a, err := unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	a, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	w := unit.WatchActionNotifications()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChange(a.Id())
+	wc.AssertNoChange()
+
+	result, err := a.Cancel()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Status(), gc.Equals, state.ActionAborting)
+
+	// The receiver is told about the action again, so that it can
+	// stop it.
+	wc.AssertChange(a.Id())
+	wc.AssertNoChange()
+
+	actions, err := unit.RunningActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actions, gc.HasLen, 1)
+
+	// Cancelling again is a no-op.
+	result, err = result.Cancel()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Status(), gc.Equals, state.ActionAborting)
+
+	// The receiver can then record the outcome.
+	result, err = result.Finish(state.ActionResults{Status: state.ActionCancelled, Message: "action cancelled"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Status(), gc.Equals, state.ActionCancelled)
+}
+
+func (s *ActionSuite) TestLog(c *gc.C) {
+	unit, err := s.State.Unit(s.unit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	preventUnitDestroyRemove(c, unit)
+
+	a, err := unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Messages may only be logged while the action is running.
+	err = a.Log("too soon")
+	c.Assert(err, gc.ErrorMatches, `cannot log message for action ".*": action is not running`)
+
+	a, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(a.Log("dumping database"), jc.ErrorIsNil)
+	c.Assert(a.Log("compressing dump"), jc.ErrorIsNil)
+
+	a, err = s.State.Action(a.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	messages := a.Messages()
+	c.Assert(messages, gc.HasLen, 2)
+	c.Check(messages[0].Message, gc.Equals, "dumping database")
+	c.Check(messages[1].Message, gc.Equals, "compressing dump")
+	c.Check(messages[0].Timestamp.IsZero(), jc.IsFalse)
+
+	a, err = a.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(a.Messages(), gc.HasLen, 2)
+	err = a.Log("too late")
+	c.Assert(err, gc.ErrorMatches, `cannot log message for action ".*": action is not running`)
+}
+
+func (s *ActionSuite) TestLogTruncatesLongMessages(c *gc.C) {
+	unit, err := s.State.Unit(s.unit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	preventUnitDestroyRemove(c, unit)
+
+	a, err := unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	a, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The message is cut short without splitting the final character.
+	message := strings.Repeat("x", state.MaxActionMessageBytes-1) + "é"
+	c.Assert(a.Log(message), jc.ErrorIsNil)
+
+	a, err = s.State.Action(a.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	messages := a.Messages()
+	c.Assert(messages, gc.HasLen, 1)
+	c.Check(messages[0].Message, gc.Equals, message[:state.MaxActionMessageBytes-1])
+}
+
+func (s *ActionSuite) TestLogDiscardsOldestMessagesOverSize(c *gc.C) {
+	unit, err := s.State.Unit(s.unit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	preventUnitDestroyRemove(c, unit)
+
+	a, err := unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	a, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	kept := state.MaxActionMessagesBytes / state.MaxActionMessageBytes
+	for i := 0; i < kept+2; i++ {
+		message := fmt.Sprintf("%04d", i)
+		message += strings.Repeat("x", state.MaxActionMessageBytes-len(message))
+		c.Assert(a.Log(message), jc.ErrorIsNil)
+	}
+
+	a, err = s.State.Action(a.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	messages := a.Messages()
+	c.Assert(messages, gc.HasLen, kept)
+	c.Check(messages[0].Message[:4], gc.Equals, "0002")
+	c.Check(messages[kept-1].Message[:4], gc.Equals, fmt.Sprintf("%04d", kept+1))
+}
+
+func (s *ActionSuite) TestCancelCompleted(c *gc.C) {
+	unit, err := s.State.Unit(s.unit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	preventUnitDestroyRemove(c, unit)
+
+	a, err := unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	a, err = a.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = a.Cancel()
+	c.Assert(err, gc.ErrorMatches, `cannot cancel action ".*": action is completed`)
+}
+
 func (s *ActionSuite) TestFindActionTagsByPrefix(c *gc.C) {
 	prefix := "feedbeef"
 	uuidMock := uuidMockHelper{}
@@ -890,6 +1071,9 @@ var _ state.ActionReceiver = (*mockAR)(nil)
 func (r mockAR) AddAction(name string, payload map[string]interface{}) (state.Action, error) {
 	return nil, nil
 }
+func (r mockAR) AddActionWithTimeout(string, map[string]interface{}, time.Duration) (state.Action, error) {
+	return nil, nil
+}
 func (r mockAR) CancelAction(state.Action) (state.Action, error) { return nil, nil }
 func (r mockAR) WatchActionNotifications() state.StringsWatcher  { return nil }
 func (r mockAR) Actions() ([]state.Action, error)                { return nil, nil }
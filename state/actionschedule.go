@@ -0,0 +1,463 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/actions"
+)
+
+// ActionScheduleRunStatus describes the outcome of the last run of an
+// action schedule.
+type ActionScheduleRunStatus string
+
+const (
+	// ActionScheduleEnqueued indicates that actions were enqueued on
+	// every receiver targeted by the schedule.
+	ActionScheduleEnqueued ActionScheduleRunStatus = "enqueued"
+
+	// ActionScheduleFailed indicates that actions could not be
+	// enqueued on some or all of the receivers targeted by the
+	// schedule.
+	ActionScheduleFailed ActionScheduleRunStatus = "failed"
+)
+
+var validActionScheduleName = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// IsValidActionScheduleName reports whether name is a valid action
+// schedule name.
+func IsValidActionScheduleName(name string) bool {
+	return validActionScheduleName.MatchString(name)
+}
+
+// ActionScheduleArgs contains the parameters for adding an action
+// schedule to a model.
+type ActionScheduleArgs struct {
+	// Name uniquely identifies the schedule within the model.
+	Name string
+
+	// Receiver is the unit or application on which the action is to
+	// be enqueued. If it is an application, the action is enqueued
+	// on all of the application's units each time the schedule fires.
+	Receiver names.Tag
+
+	// ActionName is the name of the action to run.
+	ActionName string
+
+	// Parameters holds the parameters passed to each action.
+	Parameters map[string]interface{}
+
+	// Schedule is the schedule expression, as accepted by
+	// actions.ParseSchedule.
+	Schedule string
+
+	// Timeout is the timeout of each enqueued action. Zero means that
+	// the charm's default timeout, if any, is used.
+	Timeout time.Duration
+}
+
+// Validate returns an error if the arguments are not valid.
+func (args ActionScheduleArgs) Validate() error {
+	if !IsValidActionScheduleName(args.Name) {
+		return errors.NotValidf("action schedule name %q", args.Name)
+	}
+	switch args.Receiver.(type) {
+	case names.UnitTag, names.ApplicationTag:
+	default:
+		return errors.NotValidf("action schedule receiver %v", args.Receiver)
+	}
+	if args.ActionName == "" {
+		return errors.NotValidf("empty action name")
+	}
+	if _, err := actions.ParseSchedule(args.Schedule); err != nil {
+		return errors.Trace(err)
+	}
+	if args.Timeout < 0 {
+		return errors.NotValidf("negative action timeout %v", args.Timeout)
+	}
+	return nil
+}
+
+// actionScheduleDoc records a schedule on which an action is to be
+// enqueued repeatedly.
+type actionScheduleDoc struct {
+	DocId     string `bson:"_id"`
+	Name      string `bson:"name"`
+	ModelUUID string `bson:"model-uuid"`
+
+	// Receiver is the tag of the unit or application targeted by
+	// the schedule.
+	Receiver   string                 `bson:"receiver"`
+	ActionName string                 `bson:"action-name"`
+	Parameters map[string]interface{} `bson:"parameters"`
+	Schedule   string                 `bson:"schedule"`
+	Timeout    time.Duration          `bson:"timeout,omitempty"`
+	Enabled    bool                   `bson:"enabled"`
+
+	// Updated is the time the schedule was added or last enabled;
+	// the schedule next fires after the later of Updated and LastRun.
+	Updated time.Time `bson:"updated"`
+
+	LastRun     time.Time               `bson:"last-run,omitempty"`
+	LastStatus  ActionScheduleRunStatus `bson:"last-status,omitempty"`
+	LastMessage string                  `bson:"last-message,omitempty"`
+	LastActions []string                `bson:"last-actions,omitempty"`
+}
+
+// ActionSchedule represents a schedule on which an action is enqueued
+// on a unit or on all units of an application.
+type ActionSchedule struct {
+	st  *State
+	doc actionScheduleDoc
+}
+
+// Name returns the name of the schedule.
+func (s *ActionSchedule) Name() string {
+	return s.doc.Name
+}
+
+// Receiver returns the tag of the unit or application targeted by the
+// schedule.
+func (s *ActionSchedule) Receiver() names.Tag {
+	// The tag was validated when the schedule was added.
+	tag, _ := names.ParseTag(s.doc.Receiver)
+	return tag
+}
+
+// ActionName returns the name of the action to run.
+func (s *ActionSchedule) ActionName() string {
+	return s.doc.ActionName
+}
+
+// Parameters returns the parameters passed to each enqueued action.
+func (s *ActionSchedule) Parameters() map[string]interface{} {
+	return s.doc.Parameters
+}
+
+// Schedule returns the schedule expression.
+func (s *ActionSchedule) Schedule() string {
+	return s.doc.Schedule
+}
+
+// Timeout returns the timeout of each enqueued action.
+func (s *ActionSchedule) Timeout() time.Duration {
+	return s.doc.Timeout
+}
+
+// Enabled reports whether the schedule is enabled.
+func (s *ActionSchedule) Enabled() bool {
+	return s.doc.Enabled
+}
+
+// Updated returns the time the schedule was added or last enabled.
+func (s *ActionSchedule) Updated() time.Time {
+	return s.doc.Updated
+}
+
+// LastRun returns the time the schedule last fired, or the zero time
+// if it has never fired.
+func (s *ActionSchedule) LastRun() time.Time {
+	return s.doc.LastRun
+}
+
+// LastStatus returns the outcome of the last run of the schedule, and
+// a message describing any failures.
+func (s *ActionSchedule) LastStatus() (ActionScheduleRunStatus, string) {
+	return s.doc.LastStatus, s.doc.LastMessage
+}
+
+// LastActions returns the ids of the actions enqueued by the last run
+// of the schedule.
+func (s *ActionSchedule) LastActions() []string {
+	return s.doc.LastActions
+}
+
+// Next returns the time at which the schedule next fires. It returns
+// the zero time if the schedule can never fire.
+func (s *ActionSchedule) Next() (time.Time, error) {
+	schedule, err := actions.ParseSchedule(s.doc.Schedule)
+	if err != nil {
+		return time.Time{}, errors.Trace(err)
+	}
+	after := s.doc.Updated
+	if s.doc.LastRun.After(after) {
+		after = s.doc.LastRun
+	}
+	return schedule.Next(after), nil
+}
+
+// Refresh refreshes the contents of the schedule from the underlying
+// state.
+func (s *ActionSchedule) Refresh() error {
+	schedule, err := s.st.ActionSchedule(s.doc.Name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	s.doc = schedule.doc
+	return nil
+}
+
+// SetEnabled enables or disables the schedule. Enabling a disabled
+// schedule does not cause runs missed while it was disabled to be
+// made up.
+func (s *ActionSchedule) SetEnabled(enabled bool) error {
+	update := bson.D{{"enabled", enabled}}
+	if enabled {
+		update = append(update, bson.DocElem{"updated", s.st.NowToTheSecond()})
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := s.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if s.doc.Enabled == enabled {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:      actionSchedulesC,
+			Id:     s.doc.DocId,
+			Assert: bson.D{{"enabled", s.doc.Enabled}},
+			Update: bson.D{{"$set", update}},
+		}}, nil
+	}
+	if err := s.st.run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot update action schedule %q", s.doc.Name)
+	}
+	return s.Refresh()
+}
+
+// Run enqueues the schedule's action on each receiver targeted by the
+// schedule, and records the outcome as the schedule's last run. Errors
+// enqueueing individual actions are recorded rather than returned. If
+// the schedule has been run since it was read, Run returns an error
+// without enqueueing anything.
+func (s *ActionSchedule) Run() error {
+	now := s.st.NowToTheSecond()
+
+	// Claim the run before enqueueing any actions, so that concurrent
+	// runs of the same schedule cannot both enqueue them.
+	lastRun := bson.D{{"last-run", bson.D{{"$exists", false}}}}
+	if !s.doc.LastRun.IsZero() {
+		lastRun = bson.D{{"last-run", s.doc.LastRun}}
+	}
+	claimOps := []txn.Op{{
+		C:      actionSchedulesC,
+		Id:     s.doc.DocId,
+		Assert: lastRun,
+		Update: bson.D{{"$set", bson.D{{"last-run", now}}}},
+	}}
+	if err := s.st.runTransaction(claimOps); err == txn.ErrAborted {
+		if err := s.Refresh(); err != nil {
+			return errors.Trace(err)
+		}
+		return errors.Errorf("action schedule %q has already been run", s.doc.Name)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot record run of action schedule %q", s.doc.Name)
+	}
+
+	var actionIds, failures []string
+	receivers, err := s.receivers()
+	if err != nil {
+		failures = append(failures, err.Error())
+	}
+	for _, receiver := range receivers {
+		action, err := receiver.AddActionWithTimeout(s.doc.ActionName, s.doc.Parameters, s.doc.Timeout)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", receiver.Name(), err))
+			continue
+		}
+		actionIds = append(actionIds, action.Id())
+	}
+	status := ActionScheduleEnqueued
+	if len(failures) > 0 {
+		status = ActionScheduleFailed
+	}
+	ops := []txn.Op{{
+		C:      actionSchedulesC,
+		Id:     s.doc.DocId,
+		Assert: bson.D{{"last-run", now}},
+		Update: bson.D{{"$set", bson.D{
+			{"last-status", status},
+			{"last-message", strings.Join(failures, "; ")},
+			{"last-actions", actionIds},
+		}}},
+	}}
+	if err := s.st.runTransaction(ops); err == txn.ErrAborted {
+		if err := s.Refresh(); err != nil {
+			return errors.Trace(err)
+		}
+		return errors.Errorf("action schedule %q was run again before its outcome was recorded", s.doc.Name)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot record run of action schedule %q", s.doc.Name)
+	}
+	return s.Refresh()
+}
+
+// receivers returns the units targeted by the schedule.
+func (s *ActionSchedule) receivers() ([]*Unit, error) {
+	switch tag := s.Receiver().(type) {
+	case names.UnitTag:
+		unit, err := s.st.Unit(tag.Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []*Unit{unit}, nil
+	case names.ApplicationTag:
+		application, err := s.st.Application(tag.Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		units, err := application.AllUnits()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if len(units) == 0 {
+			return nil, errors.Errorf("application %q has no units", tag.Id())
+		}
+		return units, nil
+	}
+	return nil, errors.NotValidf("action schedule receiver %q", s.doc.Receiver)
+}
+
+// AddActionSchedule adds a new, enabled, action schedule to the model.
+// The schedule is removed along with the unit or application it targets.
+func (st *State) AddActionSchedule(args ActionScheduleArgs) (*ActionSchedule, error) {
+	if err := args.Validate(); err != nil {
+		return nil, errors.Annotate(err, "cannot add action schedule")
+	}
+	receiverCollection, receiverId, err := st.tagToCollectionAndId(args.Receiver)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	doc := actionScheduleDoc{
+		DocId:      st.docID(args.Name),
+		Name:       args.Name,
+		ModelUUID:  st.ModelUUID(),
+		Receiver:   args.Receiver.String(),
+		ActionName: args.ActionName,
+		Parameters: args.Parameters,
+		Schedule:   args.Schedule,
+		Timeout:    args.Timeout,
+		Enabled:    true,
+		Updated:    st.NowToTheSecond(),
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if _, err := st.ActionSchedule(args.Name); err == nil {
+			return nil, errors.AlreadyExistsf("action schedule %q", args.Name)
+		} else if !errors.IsNotFound(err) {
+			return nil, errors.Trace(err)
+		}
+		if alive, err := isAlive(st, receiverCollection, receiverId); err != nil {
+			return nil, errors.Trace(err)
+		} else if !alive {
+			return nil, errors.Errorf("%s not found or not alive", names.ReadableString(args.Receiver))
+		}
+		return []txn.Op{{
+			C:      receiverCollection,
+			Id:     receiverId,
+			Assert: isAliveDoc,
+		}, {
+			C:      actionSchedulesC,
+			Id:     doc.DocId,
+			Assert: txn.DocMissing,
+			Insert: &doc,
+		}}, nil
+	}
+	if err := st.run(buildTxn); err != nil {
+		return nil, errors.Annotate(err, "cannot add action schedule")
+	}
+	return &ActionSchedule{st: st, doc: doc}, nil
+}
+
+// ActionSchedule returns the action schedule with the given name.
+func (st *State) ActionSchedule(name string) (*ActionSchedule, error) {
+	schedules, closer := st.getCollection(actionSchedulesC)
+	defer closer()
+
+	var doc actionScheduleDoc
+	err := schedules.FindId(name).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("action schedule %q", name)
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get action schedule %q", name)
+	}
+	return &ActionSchedule{st: st, doc: doc}, nil
+}
+
+// AllActionSchedules returns all action schedules in the model,
+// ordered by name.
+func (st *State) AllActionSchedules() ([]*ActionSchedule, error) {
+	schedules, closer := st.getCollection(actionSchedulesC)
+	defer closer()
+
+	var docs []actionScheduleDoc
+	if err := schedules.Find(nil).Sort("name").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get action schedules")
+	}
+	result := make([]*ActionSchedule, len(docs))
+	for i, doc := range docs {
+		result[i] = &ActionSchedule{st: st, doc: doc}
+	}
+	return result, nil
+}
+
+// RemoveActionSchedule removes the action schedule with the given
+// name. Actions already enqueued by the schedule are unaffected.
+func (st *State) RemoveActionSchedule(name string) error {
+	ops := []txn.Op{{
+		C:      actionSchedulesC,
+		Id:     st.docID(name),
+		Assert: txn.DocExists,
+		Remove: true,
+	}}
+	if err := st.runTransaction(ops); err == txn.ErrAborted {
+		return errors.NotFoundf("action schedule %q", name)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot remove action schedule %q", name)
+	}
+	return nil
+}
+
+// removeActionSchedulesOps returns the operations necessary to remove
+// the action schedules targeting the given unit or application.
+func removeActionSchedulesOps(st *State, receiver names.Tag) ([]txn.Op, error) {
+	schedules, closer := st.getCollection(actionSchedulesC)
+	defer closer()
+
+	var docs []struct {
+		DocId string `bson:"_id"`
+	}
+	query := bson.D{{"receiver", receiver.String()}}
+	if err := schedules.Find(query).Select(bson.D{{"_id", 1}}).All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "cannot get action schedules for %s", names.ReadableString(receiver))
+	}
+	ops := make([]txn.Op, len(docs))
+	for i, doc := range docs {
+		ops[i] = txn.Op{
+			C:      actionSchedulesC,
+			Id:     doc.DocId,
+			Remove: true,
+		}
+	}
+	return ops, nil
+}
+
+// WatchActionSchedules returns a NotifyWatcher that notifies when
+// action schedules in the model are added, changed or removed.
+func (st *State) WatchActionSchedules() NotifyWatcher {
+	return newNotifyCollWatcher(st, actionSchedulesC, isLocalID(st))
+}
@@ -0,0 +1,300 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type ActionScheduleSuite struct {
+	ConnSuite
+	application *state.Application
+	unit        *state.Unit
+	unit2       *state.Unit
+}
+
+var _ = gc.Suite(&ActionScheduleSuite{})
+
+func (s *ActionScheduleSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+
+	ch := s.AddTestingCharm(c, "dummy")
+	s.application = s.AddTestingService(c, "dummy", ch)
+	curl, _ := s.application.CharmURL()
+
+	var err error
+	s.unit, err = s.application.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.SetCharmURL(curl)
+	c.Assert(err, jc.ErrorIsNil)
+	s.unit2, err = s.application.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit2.SetCharmURL(curl)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ActionScheduleSuite) args(receiver names.Tag) state.ActionScheduleArgs {
+	return state.ActionScheduleArgs{
+		Name:       "nightly-snapshot",
+		Receiver:   receiver,
+		ActionName: "snapshot",
+		Parameters: map[string]interface{}{"outfile": "snapshot.tar.gz"},
+		Schedule:   "0 2 * * *",
+		Timeout:    time.Hour,
+	}
+}
+
+func (s *ActionScheduleSuite) TestAddActionSchedule(c *gc.C) {
+	schedule, err := s.State.AddActionSchedule(s.args(s.application.Tag()))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(schedule.Name(), gc.Equals, "nightly-snapshot")
+	c.Check(schedule.Receiver(), gc.Equals, s.application.Tag())
+	c.Check(schedule.ActionName(), gc.Equals, "snapshot")
+	c.Check(schedule.Parameters(), jc.DeepEquals, map[string]interface{}{"outfile": "snapshot.tar.gz"})
+	c.Check(schedule.Schedule(), gc.Equals, "0 2 * * *")
+	c.Check(schedule.Timeout(), gc.Equals, time.Hour)
+	c.Check(schedule.Enabled(), jc.IsTrue)
+	c.Check(schedule.LastRun().IsZero(), jc.IsTrue)
+
+	next, err := schedule.Next()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(next.After(schedule.Updated()), jc.IsTrue)
+	c.Check(next.Hour(), gc.Equals, 2)
+	c.Check(next.Minute(), gc.Equals, 0)
+
+	all, err := s.State.AllActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 1)
+	c.Check(all[0].Name(), gc.Equals, "nightly-snapshot")
+}
+
+func (s *ActionScheduleSuite) TestAddActionScheduleAlreadyExists(c *gc.C) {
+	_, err := s.State.AddActionSchedule(s.args(s.application.Tag()))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddActionSchedule(s.args(s.unit.Tag()))
+	c.Assert(err, gc.ErrorMatches, `cannot add action schedule: action schedule "nightly-snapshot" already exists`)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *ActionScheduleSuite) TestAddActionScheduleInvalid(c *gc.C) {
+	for i, test := range []struct {
+		about  string
+		modify func(*state.ActionScheduleArgs)
+		err    string
+	}{{
+		about:  "bad name",
+		modify: func(args *state.ActionScheduleArgs) { args.Name = "Nightly Snapshot" },
+		err:    `cannot add action schedule: action schedule name "Nightly Snapshot" not valid`,
+	}, {
+		about:  "bad receiver",
+		modify: func(args *state.ActionScheduleArgs) { args.Receiver = names.NewMachineTag("0") },
+		err:    `cannot add action schedule: action schedule receiver machine-0 not valid`,
+	}, {
+		about:  "missing action",
+		modify: func(args *state.ActionScheduleArgs) { args.ActionName = "" },
+		err:    `cannot add action schedule: empty action name not valid`,
+	}, {
+		about:  "bad schedule",
+		modify: func(args *state.ActionScheduleArgs) { args.Schedule = "every night" },
+		err:    `cannot add action schedule: schedule "every night" \(expected 5 fields, got 2\) not valid`,
+	}, {
+		about:  "negative timeout",
+		modify: func(args *state.ActionScheduleArgs) { args.Timeout = -time.Second },
+		err:    `cannot add action schedule: negative action timeout -1s not valid`,
+	}, {
+		about:  "unknown receiver",
+		modify: func(args *state.ActionScheduleArgs) { args.Receiver = names.NewApplicationTag("nope") },
+		err:    `cannot add action schedule: application nope not found or not alive`,
+	}} {
+		c.Logf("test %d: %s", i, test.about)
+		args := s.args(s.unit.Tag())
+		test.modify(&args)
+		_, err := s.State.AddActionSchedule(args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ActionScheduleSuite) TestRunApplicationSchedule(c *gc.C) {
+	schedule, err := s.State.AddActionSchedule(s.args(s.application.Tag()))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = schedule.Run()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(schedule.LastRun().IsZero(), jc.IsFalse)
+	status, message := schedule.LastStatus()
+	c.Check(status, gc.Equals, state.ActionScheduleEnqueued)
+	c.Check(message, gc.Equals, "")
+	c.Assert(schedule.LastActions(), gc.HasLen, 2)
+
+	for _, unit := range []*state.Unit{s.unit, s.unit2} {
+		actions, err := unit.PendingActions()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(actions, gc.HasLen, 1)
+		c.Check(actions[0].Name(), gc.Equals, "snapshot")
+		c.Check(actions[0].Timeout(), gc.Equals, time.Hour)
+		c.Check(schedule.LastActions(), jc.Contains, actions[0].Id())
+	}
+}
+
+func (s *ActionScheduleSuite) TestRunUnitSchedule(c *gc.C) {
+	schedule, err := s.State.AddActionSchedule(s.args(s.unit.Tag()))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = schedule.Run()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedule.LastActions(), gc.HasLen, 1)
+
+	actions, err := s.unit.PendingActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actions, gc.HasLen, 1)
+	c.Check(actions[0].Id(), gc.Equals, schedule.LastActions()[0])
+
+	actions, err = s.unit2.PendingActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actions, gc.HasLen, 0)
+}
+
+func (s *ActionScheduleSuite) TestRunRecordsFailure(c *gc.C) {
+	args := s.args(s.unit.Tag())
+	args.ActionName = "no-such-action"
+	schedule, err := s.State.AddActionSchedule(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = schedule.Run()
+	c.Assert(err, jc.ErrorIsNil)
+	status, message := schedule.LastStatus()
+	c.Check(status, gc.Equals, state.ActionScheduleFailed)
+	c.Check(message, gc.Equals, `dummy/0: action "no-such-action" not defined on unit "dummy/0"`)
+	c.Check(schedule.LastActions(), gc.HasLen, 0)
+
+	next, err := schedule.Next()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(next.After(schedule.LastRun()), jc.IsTrue)
+}
+
+func (s *ActionScheduleSuite) TestRunConcurrently(c *gc.C) {
+	schedule, err := s.State.AddActionSchedule(s.args(s.unit.Tag()))
+	c.Assert(err, jc.ErrorIsNil)
+
+	defer state.SetBeforeHooks(c, s.State, func() {
+		other, err := s.State.ActionSchedule(schedule.Name())
+		c.Assert(err, jc.ErrorIsNil)
+		err = other.Run()
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+
+	err = schedule.Run()
+	c.Assert(err, gc.ErrorMatches, `action schedule "nightly-snapshot" has already been run`)
+	c.Assert(schedule.LastActions(), gc.HasLen, 1)
+
+	actions, err := s.unit.PendingActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actions, gc.HasLen, 1)
+	c.Check(actions[0].Id(), gc.Equals, schedule.LastActions()[0])
+}
+
+func (s *ActionScheduleSuite) TestSetEnabled(c *gc.C) {
+	schedule, err := s.State.AddActionSchedule(s.args(s.unit.Tag()))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = schedule.SetEnabled(false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(schedule.Enabled(), jc.IsFalse)
+
+	// Disabling twice is not an error.
+	err = schedule.SetEnabled(false)
+	c.Assert(err, jc.ErrorIsNil)
+
+	schedule, err = s.State.ActionSchedule("nightly-snapshot")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(schedule.Enabled(), jc.IsFalse)
+
+	err = schedule.SetEnabled(true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(schedule.Enabled(), jc.IsTrue)
+}
+
+func (s *ActionScheduleSuite) TestRemoveActionSchedule(c *gc.C) {
+	_, err := s.State.AddActionSchedule(s.args(s.unit.Tag()))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RemoveActionSchedule("nightly-snapshot")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.ActionSchedule("nightly-snapshot")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	err = s.State.RemoveActionSchedule("nightly-snapshot")
+	c.Assert(err, gc.ErrorMatches, `action schedule "nightly-snapshot" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ActionScheduleSuite) TestRemoveUnitRemovesSchedules(c *gc.C) {
+	_, err := s.State.AddActionSchedule(s.args(s.unit.Tag()))
+	c.Assert(err, jc.ErrorIsNil)
+	args := s.args(s.unit2.Tag())
+	args.Name = "other-unit"
+	_, err = s.State.AddActionSchedule(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.ActionSchedule("nightly-snapshot")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	_, err = s.State.ActionSchedule("other-unit")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ActionScheduleSuite) TestRemoveApplicationRemovesSchedules(c *gc.C) {
+	_, err := s.State.AddActionSchedule(s.args(s.application.Tag()))
+	c.Assert(err, jc.ErrorIsNil)
+	args := s.args(s.unit.Tag())
+	args.Name = "unit"
+	_, err = s.State.AddActionSchedule(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.application.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	for _, unit := range []*state.Unit{s.unit, s.unit2} {
+		err = unit.EnsureDead()
+		c.Assert(err, jc.ErrorIsNil)
+		err = unit.Remove()
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	err = s.application.Refresh()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	schedules, err := s.State.AllActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedules, gc.HasLen, 0)
+}
+
+func (s *ActionScheduleSuite) TestWatchActionSchedules(c *gc.C) {
+	w := s.State.WatchActionSchedules()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	schedule, err := s.State.AddActionSchedule(s.args(s.unit.Tag()))
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = schedule.SetEnabled(false)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = s.State.RemoveActionSchedule(schedule.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
@@ -601,8 +601,38 @@ func (st *State) machineStorageOps(
 		})
 	}
 
-	// TODO(axw) handle args.filesystemAttachments, args.volumeAttachments
-	// when we handle attaching to existing (e.g. shared) storage.
+	// Create attachments to existing filesystems and volumes,
+	// e.g. those imported into the model.
+	for tag, params := range args.filesystemAttachments {
+		f, err := st.filesystemByTag(tag)
+		if err != nil {
+			return nil, nil, nil, errors.Trace(err)
+		}
+		filesystemOps = append(filesystemOps, txn.Op{
+			C:      filesystemsC,
+			Id:     f.doc.FilesystemId,
+			Assert: isAliveDoc,
+			Update: bson.D{{"$inc", bson.D{{"attachmentcount", 1}}}},
+		})
+		var storageTag names.StorageTag
+		if f.doc.StorageId != "" {
+			storageTag = names.NewStorageTag(f.doc.StorageId)
+		}
+		fsAttachments = append(fsAttachments, filesystemAttachmentTemplate{
+			tag, storageTag, params,
+		})
+	}
+	for tag, params := range args.volumeAttachments {
+		volumeOps = append(volumeOps, txn.Op{
+			C:      volumesC,
+			Id:     tag.Id(),
+			Assert: isAliveDoc,
+			Update: bson.D{{"$inc", bson.D{{"attachmentcount", 1}}}},
+		})
+		volumeAttachments = append(volumeAttachments, volumeAttachmentTemplate{
+			tag, params,
+		})
+	}
 
 	ops := make([]txn.Op, 0, len(filesystemOps)+len(volumeOps)+len(fsAttachments)+len(volumeAttachments))
 	if len(fsAttachments) > 0 {
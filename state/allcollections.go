@@ -325,6 +325,7 @@ func allCollections() collectionSchema {
 			}},
 		},
 		volumeAttachmentsC: {},
+		volumeSnapshotsC:   {},
 
 		// -----
 
@@ -346,6 +347,7 @@ func allCollections() collectionSchema {
 			}},
 		},
 		actionNotificationsC: {},
+		actionSchedulesC:     {},
 
 		// -----
 
@@ -380,6 +382,15 @@ func allCollections() collectionSchema {
 		// unit relation settings, model config, etc etc etc.
 		settingsC: {},
 
+		// This collection holds secrets, with their values encrypted
+		// by a controller-wide key, and the applications granted
+		// access to them.
+		secretsC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "applications"},
+			}},
+		},
+
 		constraintsC:        {},
 		storageConstraintsC: {},
 		statusesC:           {},
@@ -396,6 +407,14 @@ func allCollections() collectionSchema {
 			}},
 		},
 
+		// This collection holds a bounded history of the hook and action
+		// executions run by each unit's agent.
+		hookExecutionsC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "unit", "started"},
+			}},
+		},
+
 		// This collection holds information about cloud image metadata.
 		cloudimagemetadataC: {
 			global: true,
@@ -444,6 +463,7 @@ func allCollections() collectionSchema {
 const (
 	actionNotificationsC     = "actionnotifications"
 	actionresultsC           = "actionresults"
+	actionSchedulesC         = "actionschedules"
 	actionsC                 = "actions"
 	annotationsC             = "annotations"
 	autocertCacheC           = "autocertCache"
@@ -466,6 +486,7 @@ const (
 	globalSettingsC          = "globalSettings"
 	guimetadataC             = "guimetadata"
 	guisettingsC             = "guisettings"
+	hookExecutionsC          = "hookexecutions"
 	instanceDataC            = "instanceData"
 	leasesC                  = "leases"
 	machinesC                = "machines"
@@ -490,6 +511,7 @@ const (
 	relationScopesC          = "relationscopes"
 	relationsC               = "relations"
 	restoreInfoC             = "restoreInfo"
+	secretsC                 = "secrets"
 	sequenceC                = "sequence"
 	applicationsC            = "applications"
 	endpointBindingsC        = "endpointbindings"
@@ -515,6 +537,7 @@ const (
 	usermodelnameC           = "usermodelname"
 	usersC                   = "users"
 	volumeAttachmentsC       = "volumeattachments"
+	volumeSnapshotsC         = "volumesnapshots"
 	volumesC                 = "volumes"
 	// "resources" (see resource/persistence/mongo.go)
 
@@ -49,9 +49,19 @@ type applicationDoc struct {
 	UnitCount            int        `bson:"unitcount"`
 	RelationCount        int        `bson:"relationcount"`
 	Exposed              bool       `bson:"exposed"`
+	Trusted              bool       `bson:"trusted"`
 	MinUnits             int        `bson:"minunits"`
 	TxnRevno             int64      `bson:"txn-revno"`
 	MetricCredentials    []byte     `bson:"metric-credentials"`
+
+	// ExposedEndpoints holds the expose settings of an exposed
+	// application, keyed on endpoint name. See ExposedEndpoint.
+	ExposedEndpoints map[string]ExposedEndpoint `bson:"exposed-endpoints,omitempty"`
+
+	// EgressRules holds the egress rules set for the application by
+	// operators, and CharmEgressRules those set by its charm.
+	EgressRules      []egressRuleDoc `bson:"egress-rules,omitempty"`
+	CharmEgressRules []egressRuleDoc `bson:"charm-egress-rules,omitempty"`
 }
 
 func newApplication(st *State, doc *applicationDoc) *Application {
@@ -301,6 +311,16 @@ func (a *Application) removeOps(asserts bson.D) ([]txn.Op, error) {
 		removeStatusOp(a.st, globalKey),
 		removeModelApplicationRefOp(a.st, name),
 	)
+	secretOps, err := removeSecretGrantsOps(a.st, name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, secretOps...)
+	scheduleOps, err := removeActionSchedulesOps(a.st, a.Tag())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, scheduleOps...)
 	return ops, nil
 }
 
@@ -311,29 +331,73 @@ func (a *Application) IsExposed() bool {
 	return a.doc.Exposed
 }
 
-// SetExposed marks the application as exposed.
-// See ClearExposed and IsExposed.
+// SetExposed marks the application as exposed. Any existing expose
+// settings are left unchanged; an exposed application with no expose
+// settings may be accessed from anywhere.
+// See ClearExposed, IsExposed and MergeExposeSettings.
 func (a *Application) SetExposed() error {
 	return a.setExposed(true)
 }
 
-// ClearExposed removes the exposed flag from the application.
-// See SetExposed and IsExposed.
+// ClearExposed removes the exposed flag and any expose settings from
+// the application. See SetExposed and IsExposed.
 func (a *Application) ClearExposed() error {
 	return a.setExposed(false)
 }
 
 func (a *Application) setExposed(exposed bool) (err error) {
+	update := bson.D{{"$set", bson.D{{"exposed", exposed}}}}
+	if !exposed {
+		update = append(update, bson.DocElem{
+			"$unset", bson.D{{"exposed-endpoints", nil}},
+		})
+	}
 	ops := []txn.Op{{
 		C:      applicationsC,
 		Id:     a.doc.DocID,
 		Assert: isAliveDoc,
-		Update: bson.D{{"$set", bson.D{{"exposed", exposed}}}},
+		Update: update,
 	}}
 	if err := a.st.runTransaction(ops); err != nil {
 		return errors.Errorf("cannot set exposed flag for application %q to %v: %v", a, exposed, onAbort(err, errNotAlive))
 	}
 	a.doc.Exposed = exposed
+	if !exposed {
+		a.doc.ExposedEndpoints = nil
+	}
+	return nil
+}
+
+// IsTrusted returns whether this application is trusted. The units of a
+// trusted application may access the cloud credential of the model.
+// See SetTrusted and ClearTrusted.
+func (a *Application) IsTrusted() bool {
+	return a.doc.Trusted
+}
+
+// SetTrusted marks the application as trusted.
+// See ClearTrusted and IsTrusted.
+func (a *Application) SetTrusted() error {
+	return a.setTrusted(true)
+}
+
+// ClearTrusted removes the trusted flag from the application.
+// See SetTrusted and IsTrusted.
+func (a *Application) ClearTrusted() error {
+	return a.setTrusted(false)
+}
+
+func (a *Application) setTrusted(trusted bool) (err error) {
+	ops := []txn.Op{{
+		C:      applicationsC,
+		Id:     a.doc.DocID,
+		Assert: isAliveDoc,
+		Update: bson.D{{"$set", bson.D{{"trusted", trusted}}}},
+	}}
+	if err := a.st.runTransaction(ops); err != nil {
+		return errors.Errorf("cannot set trusted flag for application %q to %v: %v", a, trusted, onAbort(err, errNotAlive))
+	}
+	a.doc.Trusted = trusted
 	return nil
 }
 
@@ -1067,6 +1131,10 @@ type applicationAddUnitOpsArgs struct {
 	principalName string
 	cons          constraints.Value
 	storageCons   map[string]StorageConstraints
+
+	// attachStorage contains existing storage instances
+	// to attach to the unit.
+	attachStorage []*storageInstance
 }
 
 // addApplicationUnitOps is just like addUnitOps but explicitly takes a
@@ -1115,6 +1183,7 @@ func (a *Application) addUnitOpsWithCons(args applicationAddUnitOpsArgs) (string
 		unitTag,
 		charm.Meta(),
 		args.storageCons,
+		args.attachStorage,
 		a.doc.Series,
 		machineAssignable,
 	)
@@ -1239,6 +1308,11 @@ func (a *Application) removeUnitOps(u *Unit, asserts bson.D) ([]txn.Op, error) {
 		return nil, errors.Trace(err)
 	}
 	ops = append(ops, resOps...)
+	scheduleOps, err := removeActionSchedulesOps(a.st, u.Tag())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, scheduleOps...)
 
 	observedFieldsMatch := bson.D{
 		{"charmurl", u.doc.CharmURL},
@@ -1256,6 +1330,7 @@ func (a *Application) removeUnitOps(u *Unit, asserts bson.D) ([]txn.Op, error) {
 		removeStatusOp(a.st, u.globalKey()),
 		removeConstraintsOp(a.st, u.globalAgentKey()),
 		annotationRemoveOp(a.st, u.globalKey()),
+		removeCharmStateOp(u.doc.Name),
 		newCleanupOp(cleanupRemovedUnit, u.doc.Name),
 	)
 	ops = append(ops, portsOps...)
@@ -1393,6 +1393,32 @@ func (s *ApplicationSuite) TestServiceExposed(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, notAliveErr)
 }
 
+func (s *ApplicationSuite) TestServiceTrusted(c *gc.C) {
+	c.Assert(s.mysql.IsTrusted(), jc.IsFalse)
+
+	err := s.mysql.SetTrusted()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsTrusted(), jc.IsTrue)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsTrusted(), jc.IsTrue)
+
+	err = s.mysql.ClearTrusted()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsTrusted(), jc.IsFalse)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsTrusted(), jc.IsFalse)
+
+	// Make the application Dying and check that the flag cannot be changed.
+	_, err = s.mysql.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.SetTrusted()
+	c.Assert(err, gc.ErrorMatches, notAliveErr)
+}
+
 func (s *ApplicationSuite) TestAddUnit(c *gc.C) {
 	// Check that principal units can be added on their own.
 	unitZero, err := s.mysql.AddUnit()
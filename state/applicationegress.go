@@ -0,0 +1,141 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"net"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/network"
+)
+
+// egressRuleDoc is the persistent representation of a network.EgressRule.
+type egressRuleDoc struct {
+	Protocol         string   `bson:"protocol"`
+	FromPort         int      `bson:"from-port"`
+	ToPort           int      `bson:"to-port"`
+	DestinationCIDRs []string `bson:"destination-cidrs,omitempty"`
+}
+
+// EgressRules returns the egress rules set for the application by
+// operators. See SetEgressRules.
+func (a *Application) EgressRules() []network.EgressRule {
+	return egressRulesFromDocs(a.doc.EgressRules)
+}
+
+// CharmEgressRules returns the egress rules set for the application by
+// its charm. See SetCharmEgressRules.
+func (a *Application) CharmEgressRules() []network.EgressRule {
+	return egressRulesFromDocs(a.doc.CharmEgressRules)
+}
+
+// SetEgressRules replaces the egress rules set for the application by
+// operators. Once the application has any egress rules, outgoing
+// traffic from the machines hosting its units is limited to the
+// destinations allowed by the egress rules of the applications on
+// those machines. An empty set of rules removes the restriction.
+func (a *Application) SetEgressRules(rules []network.EgressRule) error {
+	docs, err := a.setEgressRules("egress-rules", rules)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	a.doc.EgressRules = docs
+	return nil
+}
+
+// SetCharmEgressRules replaces the egress rules set for the application
+// by its charm. Charm egress rules are combined with those set by
+// operators; see SetEgressRules.
+func (a *Application) SetCharmEgressRules(rules []network.EgressRule) error {
+	docs, err := a.setEgressRules("charm-egress-rules", rules)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	a.doc.CharmEgressRules = docs
+	return nil
+}
+
+func (a *Application) setEgressRules(field string, rules []network.EgressRule) ([]egressRuleDoc, error) {
+	docs, err := egressRuleDocs(rules)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot set egress rules for application %q", a)
+	}
+	var update bson.D
+	if len(docs) > 0 {
+		update = bson.D{{"$set", bson.D{{field, docs}}}}
+	} else {
+		update = bson.D{{"$unset", bson.D{{field, nil}}}}
+	}
+	ops := []txn.Op{{
+		C:      applicationsC,
+		Id:     a.doc.DocID,
+		Assert: isAliveDoc,
+		Update: update,
+	}}
+	if err := a.st.runTransaction(ops); err != nil {
+		return nil, errors.Errorf("cannot set egress rules for application %q: %v", a, onAbort(err, errNotAlive))
+	}
+	return docs, nil
+}
+
+// egressRuleDocs validates the given rules, and returns them as sorted
+// docs with sorted, de-duplicated destinations. Rules with no
+// destinations allow traffic to 0.0.0.0/0.
+func egressRuleDocs(rules []network.EgressRule) ([]egressRuleDoc, error) {
+	if len(rules) == 0 {
+		return nil, nil
+	}
+	normalised := make([]network.EgressRule, len(rules))
+	for i, rule := range rules {
+		if err := rule.PortRange.Validate(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		cidrs := set.NewStrings(rule.DestinationCIDRs...)
+		if cidrs.IsEmpty() {
+			cidrs.Add("0.0.0.0/0")
+		}
+		for _, cidr := range cidrs.Values() {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				return nil, errors.NotValidf("CIDR %q", cidr)
+			}
+		}
+		rule.Protocol = strings.ToLower(rule.Protocol)
+		rule.DestinationCIDRs = cidrs.SortedValues()
+		normalised[i] = rule
+	}
+	network.SortEgressRules(normalised)
+	docs := make([]egressRuleDoc, len(normalised))
+	for i, rule := range normalised {
+		docs[i] = egressRuleDoc{
+			Protocol:         rule.Protocol,
+			FromPort:         rule.FromPort,
+			ToPort:           rule.ToPort,
+			DestinationCIDRs: rule.DestinationCIDRs,
+		}
+	}
+	return docs, nil
+}
+
+func egressRulesFromDocs(docs []egressRuleDoc) []network.EgressRule {
+	if len(docs) == 0 {
+		return nil
+	}
+	rules := make([]network.EgressRule, len(docs))
+	for i, doc := range docs {
+		rules[i] = network.EgressRule{
+			PortRange: network.PortRange{
+				Protocol: doc.Protocol,
+				FromPort: doc.FromPort,
+				ToPort:   doc.ToPort,
+			},
+			DestinationCIDRs: copyStrings(doc.DestinationCIDRs),
+		}
+	}
+	return rules
+}
@@ -0,0 +1,110 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+)
+
+type ApplicationEgressSuite struct {
+	ConnSuite
+	mysql *state.Application
+}
+
+var _ = gc.Suite(&ApplicationEgressSuite{})
+
+func (s *ApplicationEgressSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.mysql = s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+}
+
+func (s *ApplicationEgressSuite) TestSetEgressRules(c *gc.C) {
+	c.Assert(s.mysql.EgressRules(), gc.IsNil)
+
+	err := s.mysql.SetEgressRules([]network.EgressRule{
+		network.MustNewEgressRule("UDP", 53, 53, "10.0.0.3/32", "10.0.0.2/32", "10.0.0.2/32"),
+		network.MustNewEgressRule("tcp", 443, 443),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	expected := []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "0.0.0.0/0"),
+		network.MustNewEgressRule("udp", 53, 53, "10.0.0.2/32", "10.0.0.3/32"),
+	}
+	c.Assert(s.mysql.EgressRules(), jc.DeepEquals, expected)
+	c.Assert(s.mysql.CharmEgressRules(), gc.IsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.EgressRules(), jc.DeepEquals, expected)
+	c.Assert(s.mysql.CharmEgressRules(), gc.IsNil)
+
+	// Setting no rules removes them.
+	err = s.mysql.SetEgressRules(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.EgressRules(), gc.IsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.EgressRules(), gc.IsNil)
+}
+
+func (s *ApplicationEgressSuite) TestSetCharmEgressRules(c *gc.C) {
+	err := s.mysql.SetEgressRules([]network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.SetCharmEgressRules([]network.EgressRule{
+		network.MustNewEgressRule("tcp", 80, 80, "192.168.0.0/16"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.EgressRules(), jc.DeepEquals, []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+	})
+	c.Assert(s.mysql.CharmEgressRules(), jc.DeepEquals, []network.EgressRule{
+		network.MustNewEgressRule("tcp", 80, 80, "192.168.0.0/16"),
+	})
+}
+
+func (s *ApplicationEgressSuite) TestSetEgressRulesInvalid(c *gc.C) {
+	for i, t := range []struct {
+		rule network.EgressRule
+		err  string
+	}{{
+		rule: network.EgressRule{PortRange: network.PortRange{Protocol: "icmp", FromPort: 1, ToPort: 1}},
+		err:  `cannot set egress rules for application "mysql": invalid protocol "icmp", expected "tcp" or "udp"`,
+	}, {
+		rule: network.EgressRule{PortRange: network.PortRange{Protocol: "tcp", FromPort: 90, ToPort: 80}},
+		err:  `cannot set egress rules for application "mysql": invalid port range 90-80/tcp`,
+	}, {
+		rule: network.EgressRule{
+			PortRange:        network.PortRange{Protocol: "tcp", FromPort: 80, ToPort: 80},
+			DestinationCIDRs: []string{"10.0.0.0"},
+		},
+		err: `cannot set egress rules for application "mysql": CIDR "10.0.0.0" not valid`,
+	}} {
+		c.Logf("test %d", i)
+		err := s.mysql.SetEgressRules([]network.EgressRule{t.rule})
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+	err := s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.EgressRules(), gc.IsNil)
+}
+
+func (s *ApplicationEgressSuite) TestSetEgressRulesNotAlive(c *gc.C) {
+	_, err := s.mysql.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.SetEgressRules([]network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443),
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot set egress rules for application "mysql": not found or not alive`)
+}
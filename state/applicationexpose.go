@@ -0,0 +1,225 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"net"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// ExposedEndpoint describes the sources from which the ports opened by
+// an exposed application endpoint may be accessed.
+type ExposedEndpoint struct {
+	// ExposeToSpaces contains the names of the spaces whose subnets
+	// may access the ports opened for the endpoint.
+	ExposeToSpaces []string `bson:"to-spaces,omitempty"`
+
+	// ExposeToCIDRs contains the CIDRs that may access the ports
+	// opened for the endpoint.
+	ExposeToCIDRs []string `bson:"to-cidrs,omitempty"`
+}
+
+// AllEndpoints is the endpoint name used as a key in expose settings
+// to indicate that the settings apply to all of an application's
+// endpoints.
+//
+// The ports a unit opens for a particular endpoint may be accessed
+// from the sources in that endpoint's settings, or in the AllEndpoints
+// settings if the endpoint has none; the ports it opens for all
+// endpoints may be accessed from the sources in any of the settings.
+const AllEndpoints = ""
+
+// ExposedEndpoints returns the expose settings of the application,
+// keyed on endpoint name. The AllEndpoints key holds settings that
+// apply to every endpoint of the application.
+//
+// An exposed application with no expose settings may be accessed from
+// anywhere. See MergeExposeSettings and UnsetExposeSettings.
+func (a *Application) ExposedEndpoints() map[string]ExposedEndpoint {
+	if len(a.doc.ExposedEndpoints) == 0 {
+		return nil
+	}
+	result := make(map[string]ExposedEndpoint, len(a.doc.ExposedEndpoints))
+	for name, exposed := range a.doc.ExposedEndpoints {
+		result[name] = ExposedEndpoint{
+			ExposeToSpaces: copyStrings(exposed.ExposeToSpaces),
+			ExposeToCIDRs:  copyStrings(exposed.ExposeToCIDRs),
+		}
+	}
+	return result
+}
+
+// MergeExposeSettings marks the application as exposed, and merges the
+// given expose settings with the application's existing settings. The
+// settings for each endpoint named in exposed replace any existing
+// settings for that endpoint. An endpoint with neither spaces nor CIDRs
+// is exposed to 0.0.0.0/0.
+func (a *Application) MergeExposeSettings(exposed map[string]ExposedEndpoint) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot expose application %q", a)
+	if len(exposed) == 0 {
+		return errors.NotValidf("empty expose settings")
+	}
+	acopy := &Application{a.st, a.doc}
+	var merged map[string]ExposedEndpoint
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		a := acopy
+		if attempt > 0 {
+			if err := a.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if a.Life() != Alive {
+			return nil, errNotAlive
+		}
+		if err := a.validateExposeSettings(exposed); err != nil {
+			return nil, errors.Trace(err)
+		}
+		merged = a.ExposedEndpoints()
+		if merged == nil {
+			merged = make(map[string]ExposedEndpoint)
+		}
+		for name, settings := range exposed {
+			merged[name] = normaliseExposedEndpoint(settings)
+		}
+		return a.setExposeSettingsOps(true, merged), nil
+	}
+	if err := a.st.run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	a.doc.Exposed = true
+	a.doc.ExposedEndpoints = merged
+	return nil
+}
+
+// UnsetExposeSettings removes the expose settings for the named
+// endpoints. If no expose settings remain, the application is
+// unexposed. It is an error to name an endpoint that has no expose
+// settings.
+func (a *Application) UnsetExposeSettings(endpoints []string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot unexpose application %q", a)
+	if len(endpoints) == 0 {
+		return errors.NotValidf("empty endpoint list")
+	}
+	acopy := &Application{a.st, a.doc}
+	var remaining map[string]ExposedEndpoint
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		a := acopy
+		if attempt > 0 {
+			if err := a.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if a.Life() != Alive {
+			return nil, errNotAlive
+		}
+		remaining = a.ExposedEndpoints()
+		for _, name := range endpoints {
+			if _, ok := remaining[name]; !ok {
+				if name == AllEndpoints {
+					return nil, errors.NotFoundf("expose settings for all endpoints")
+				}
+				return nil, errors.NotFoundf("expose settings for endpoint %q", name)
+			}
+			delete(remaining, name)
+		}
+		if len(remaining) == 0 {
+			remaining = nil
+		}
+		return a.setExposeSettingsOps(remaining != nil, remaining), nil
+	}
+	if err := a.st.run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	a.doc.Exposed = remaining != nil
+	a.doc.ExposedEndpoints = remaining
+	return nil
+}
+
+// setExposeSettingsOps returns the operations required to set the
+// application's exposed flag and expose settings. The operations
+// assert that the application has not changed since it was read.
+func (a *Application) setExposeSettingsOps(exposed bool, settings map[string]ExposedEndpoint) []txn.Op {
+	var update bson.D
+	if len(settings) > 0 {
+		update = bson.D{{"$set", bson.D{
+			{"exposed", exposed},
+			{"exposed-endpoints", settings},
+		}}}
+	} else {
+		update = bson.D{
+			{"$set", bson.D{{"exposed", exposed}}},
+			{"$unset", bson.D{{"exposed-endpoints", nil}}},
+		}
+	}
+	return []txn.Op{{
+		C:  applicationsC,
+		Id: a.doc.DocID,
+		Assert: bson.D{
+			{"life", Alive},
+			{"txn-revno", a.doc.TxnRevno},
+		},
+		Update: update,
+	}}
+}
+
+// validateExposeSettings checks that the endpoints, spaces and CIDRs
+// named in the given expose settings are valid for the application.
+func (a *Application) validateExposeSettings(exposed map[string]ExposedEndpoint) error {
+	eps, err := a.Endpoints()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	knownEndpoints := set.NewStrings(AllEndpoints)
+	for _, ep := range eps {
+		knownEndpoints.Add(ep.Name)
+	}
+	for name, settings := range exposed {
+		if !knownEndpoints.Contains(name) {
+			return errors.NotFoundf("endpoint %q", name)
+		}
+		for _, spaceName := range settings.ExposeToSpaces {
+			if _, err := a.st.Space(spaceName); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		for _, cidr := range settings.ExposeToCIDRs {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				return errors.NotValidf("CIDR %q", cidr)
+			}
+		}
+	}
+	return nil
+}
+
+// normaliseExposedEndpoint returns a copy of the given settings with
+// sorted, de-duplicated spaces and CIDRs. Settings with no spaces or
+// CIDRs are exposed to 0.0.0.0/0.
+func normaliseExposedEndpoint(settings ExposedEndpoint) ExposedEndpoint {
+	spaces := set.NewStrings(settings.ExposeToSpaces...)
+	cidrs := set.NewStrings(settings.ExposeToCIDRs...)
+	if spaces.IsEmpty() && cidrs.IsEmpty() {
+		cidrs.Add("0.0.0.0/0")
+	}
+	var result ExposedEndpoint
+	if !spaces.IsEmpty() {
+		result.ExposeToSpaces = spaces.SortedValues()
+	}
+	if !cidrs.IsEmpty() {
+		result.ExposeToCIDRs = cidrs.SortedValues()
+	}
+	return result
+}
+
+func copyStrings(in []string) []string {
+	if in == nil {
+		return nil
+	}
+	out := make([]string, len(in))
+	copy(out, in)
+	return out
+}
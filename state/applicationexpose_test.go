@@ -0,0 +1,167 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type ApplicationExposeSuite struct {
+	ConnSuite
+	mysql *state.Application
+}
+
+var _ = gc.Suite(&ApplicationExposeSuite{})
+
+func (s *ApplicationExposeSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.mysql = s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	_, err := s.State.AddSpace("dmz", "", nil, true)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ApplicationExposeSuite) TestMergeExposeSettings(c *gc.C) {
+	err := s.mysql.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"server": {
+			ExposeToSpaces: []string{"dmz"},
+			ExposeToCIDRs:  []string{"10.0.0.0/24", "10.0.0.0/24", "10.0.1.0/24"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsTrue)
+
+	// Settings for other endpoints are merged; an endpoint with
+	// no spaces or CIDRs is exposed to everyone.
+	err = s.mysql.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		state.AllEndpoints: {},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	expected := map[string]state.ExposedEndpoint{
+		state.AllEndpoints: {
+			ExposeToCIDRs: []string{"0.0.0.0/0"},
+		},
+		"server": {
+			ExposeToSpaces: []string{"dmz"},
+			ExposeToCIDRs:  []string{"10.0.0.0/24", "10.0.1.0/24"},
+		},
+	}
+	c.Assert(s.mysql.ExposedEndpoints(), jc.DeepEquals, expected)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsTrue)
+	c.Assert(s.mysql.ExposedEndpoints(), jc.DeepEquals, expected)
+}
+
+func (s *ApplicationExposeSuite) TestMergeExposeSettingsReplacesEndpoint(c *gc.C) {
+	err := s.mysql.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"server": {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"server": {ExposeToSpaces: []string{"dmz"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.ExposedEndpoints(), jc.DeepEquals, map[string]state.ExposedEndpoint{
+		"server": {ExposeToSpaces: []string{"dmz"}},
+	})
+}
+
+func (s *ApplicationExposeSuite) TestMergeExposeSettingsInvalid(c *gc.C) {
+	for i, t := range []struct {
+		exposed map[string]state.ExposedEndpoint
+		err     string
+	}{{
+		exposed: nil,
+		err:     `cannot expose application "mysql": empty expose settings not valid`,
+	}, {
+		exposed: map[string]state.ExposedEndpoint{"foo": {}},
+		err:     `cannot expose application "mysql": endpoint "foo" not found`,
+	}, {
+		exposed: map[string]state.ExposedEndpoint{
+			"server": {ExposeToSpaces: []string{"nowhere"}},
+		},
+		err: `cannot expose application "mysql": space "nowhere" not found`,
+	}, {
+		exposed: map[string]state.ExposedEndpoint{
+			"server": {ExposeToCIDRs: []string{"10.0.0.0"}},
+		},
+		err: `cannot expose application "mysql": CIDR "10.0.0.0" not valid`,
+	}} {
+		c.Logf("test %d", i)
+		err := s.mysql.MergeExposeSettings(t.exposed)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+	err := s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsFalse)
+}
+
+func (s *ApplicationExposeSuite) TestMergeExposeSettingsNotAlive(c *gc.C) {
+	_, err := s.mysql.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"server": {},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot expose application "mysql": not found or not alive`)
+}
+
+func (s *ApplicationExposeSuite) TestUnsetExposeSettings(c *gc.C) {
+	err := s.mysql.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		state.AllEndpoints: {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+		"server":           {ExposeToSpaces: []string{"dmz"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.mysql.UnsetExposeSettings([]string{"server"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsTrue)
+	c.Assert(s.mysql.ExposedEndpoints(), jc.DeepEquals, map[string]state.ExposedEndpoint{
+		state.AllEndpoints: {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+	})
+
+	err = s.mysql.UnsetExposeSettings([]string{"server"})
+	c.Assert(err, gc.ErrorMatches, `cannot unexpose application "mysql": expose settings for endpoint "server" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	// Removing the last expose settings unexposes the application.
+	err = s.mysql.UnsetExposeSettings([]string{state.AllEndpoints})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsFalse)
+	c.Assert(s.mysql.ExposedEndpoints(), gc.IsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsFalse)
+	c.Assert(s.mysql.ExposedEndpoints(), gc.IsNil)
+}
+
+func (s *ApplicationExposeSuite) TestClearExposedRemovesSettings(c *gc.C) {
+	err := s.mysql.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"server": {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.ClearExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.ExposedEndpoints(), gc.IsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsFalse)
+	c.Assert(s.mysql.ExposedEndpoints(), gc.IsNil)
+
+	// Exposing the application again exposes it to everyone.
+	err = s.mysql.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsTrue)
+	c.Assert(s.mysql.ExposedEndpoints(), gc.IsNil)
+}
@@ -0,0 +1,89 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// unitCharmStateKey returns the key of the settings document holding
+// the charm state of the named unit.
+func unitCharmStateKey(name string) string {
+	return unitGlobalKey(name) + "#state"
+}
+
+// CharmState returns the key/value state that the unit's charm has
+// stored. A unit whose charm has stored nothing has an empty state.
+func (u *Unit) CharmState() (map[string]string, error) {
+	doc, err := readSettingsDoc(u.st, settingsC, unitCharmStateKey(u.doc.Name))
+	if errors.IsNotFound(err) {
+		return map[string]string{}, nil
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot read charm state for unit %q", u)
+	}
+	result := make(map[string]string, len(doc.Settings))
+	for key, value := range copyMap(doc.Settings, unescapeReplacer.Replace) {
+		result[key], _ = value.(string)
+	}
+	return result, nil
+}
+
+// SetCharmState replaces the key/value state that the unit's charm has
+// stored. If maxSize is positive, the total size of the keys and values
+// must not exceed it.
+func (u *Unit) SetCharmState(charmState map[string]string, maxSize int) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set charm state for unit %q", u)
+	if size := charmStateSize(charmState); maxSize > 0 && size > maxSize {
+		return errors.Errorf("state is %d bytes, exceeding the limit of %d bytes", size, maxSize)
+	}
+	values := make(map[string]interface{}, len(charmState))
+	for key, value := range charmState {
+		values[key] = value
+	}
+	key := unitCharmStateKey(u.doc.Name)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := u.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if u.doc.Life == Dead {
+			return nil, errors.Errorf("unit is dead")
+		}
+		ops := []txn.Op{{
+			C:      unitsC,
+			Id:     u.doc.DocID,
+			Assert: notDeadDoc,
+		}}
+		op, _, err := replaceSettingsOp(u.st, settingsC, key, values)
+		if errors.IsNotFound(err) {
+			return append(ops, createSettingsOp(settingsC, key, values)), nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append(ops, op), nil
+	}
+	return u.st.run(buildTxn)
+}
+
+// removeCharmStateOp returns an operation that removes the charm
+// state of the named unit, if any has been stored.
+func removeCharmStateOp(name string) txn.Op {
+	return txn.Op{
+		C:      settingsC,
+		Id:     unitCharmStateKey(name),
+		Remove: true,
+	}
+}
+
+// charmStateSize returns the number of bytes taken by the keys and
+// values of the supplied charm state.
+func charmStateSize(charmState map[string]string) int {
+	var size int
+	for key, value := range charmState {
+		size += len(key) + len(value)
+	}
+	return size
+}
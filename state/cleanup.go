@@ -33,6 +33,7 @@ const (
 	cleanupMachinesForDyingModel         cleanupKind = "modelMachines"
 	cleanupVolumesForDyingModel          cleanupKind = "modelVolumes"
 	cleanupFilesystemsForDyingModel      cleanupKind = "modelFilesystems"
+	cleanupVolumeSnapshotsForDyingModel  cleanupKind = "modelVolumeSnapshots"
 )
 
 // cleanupDoc originally represented a set of documents that should be
@@ -61,15 +62,21 @@ func newCleanupOp(kind cleanupKind, prefix string) txn.Op {
 
 // NeedsCleanup returns true if documents previously marked for removal exist.
 func (st *State) NeedsCleanup() (bool, error) {
-	cleanups, closer := st.getCollection(cleanupsC)
-	defer closer()
-	count, err := cleanups.Count()
+	count, err := st.PendingCleanups()
 	if err != nil {
 		return false, err
 	}
 	return count > 0, nil
 }
 
+// PendingCleanups returns the number of documents previously marked
+// for removal that have not yet been cleaned up.
+func (st *State) PendingCleanups() (int, error) {
+	cleanups, closer := st.getCollection(cleanupsC)
+	defer closer()
+	return cleanups.Count()
+}
+
 // Cleanup removes all documents that were previously marked for removal, if
 // any such exist. It should be called periodically by at least one element
 // of the system.
@@ -113,6 +120,8 @@ func (st *State) Cleanup() (err error) {
 			err = st.cleanupVolumesForDyingModel()
 		case cleanupFilesystemsForDyingModel:
 			err = st.cleanupFilesystemsForDyingModel()
+		case cleanupVolumeSnapshotsForDyingModel:
+			err = st.cleanupVolumeSnapshotsForDyingModel()
 		default:
 			handler, ok := cleanupHandlers[doc.Kind]
 			if !ok {
@@ -258,6 +267,22 @@ func (st *State) cleanupFilesystemsForDyingModel() (err error) {
 	return nil
 }
 
+// cleanupVolumeSnapshotsForDyingModel destroys all volume snapshots,
+// if they are not already Dying or Dead. It's expected to be used when
+// a model is destroyed.
+func (st *State) cleanupVolumeSnapshotsForDyingModel() (err error) {
+	snapshots, err := st.AllVolumeSnapshots()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, s := range snapshots {
+		if err := st.DestroyVolumeSnapshot(s.Id()); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
 // cleanupApplicationsForDyingModel sets all applications to Dying, if they are
 // not already Dying or Dead. It's expected to be used when a model is
 // destroyed.
@@ -29,6 +29,21 @@ func (s *CleanupSuite) SetUpTest(c *gc.C) {
 	s.assertDoesNotNeedCleanup(c)
 }
 
+func (s *CleanupSuite) TestPendingCleanups(c *gc.C) {
+	count, err := s.State.PendingCleanups()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, 0)
+
+	mysql := s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	_, err = mysql.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	count, err = s.State.PendingCleanups()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Not(gc.Equals), 0)
+}
+
 func (s *CleanupSuite) TestCleanupDyingApplicationUnits(c *gc.C) {
 	// Create a application with some units.
 	mysql := s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
@@ -30,6 +30,23 @@ func (s *ControllerConfigSuite) TestControllerAndModelConfigInitialisation(c *gc
 		controller.AutocertDNSNameKey:  true,
 		controller.AllowModelAccessKey: true,
 		controller.MongoMemoryProfile:  true,
+
+		controller.APIUserRequestRateKey:     true,
+		controller.APIModelRequestRateKey:    true,
+		controller.APIUserMaxConnectionsKey:  true,
+		controller.APIModelMaxConnectionsKey: true,
+		controller.MaxCharmStateSizeKey:      true,
+
+		controller.AuditLogMaxSizeKey:        true,
+		controller.AuditLogMaxBackupsKey:     true,
+		controller.AuditSyslogHostKey:        true,
+		controller.AuditSyslogCACertKey:      true,
+		controller.AuditSyslogClientCertKey:  true,
+		controller.AuditSyslogClientKeyKey:   true,
+		controller.AuditWebhookURLKey:        true,
+		controller.AuditFilterOperationsKey:  true,
+		controller.AuditFilterOriginTypesKey: true,
+		controller.AuditFilterModelsKey:      true,
 	}
 	for _, controllerAttr := range controller.ControllerOnlyConfigAttributes {
 		v, ok := controllerSettings.Get(controllerAttr)
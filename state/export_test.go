@@ -46,6 +46,11 @@ const (
 	GUISettingsC      = guisettingsC
 	GlobalSettingsC   = globalSettingsC
 	SettingsC         = settingsC
+
+	MaxHookExecutionsPerUnit     = maxHookExecutionsPerUnit
+	MaxToolCallsPerHookExecution = maxToolCallsPerHookExecution
+	MaxActionMessageBytes        = maxActionMessageBytes
+	MaxActionMessagesBytes       = maxActionMessagesBytes
 )
 
 var (
@@ -60,6 +60,11 @@ type Filesystem interface {
 	// if it needs to be provisioned. Params returns true if the returned
 	// parameters are usable for provisioning, otherwise false.
 	Params() (FilesystemParams, bool)
+
+	// PendingSize returns the size, in MiB, that the filesystem has
+	// been requested to grow to. PendingSize returns true if there is
+	// a resize pending, otherwise false.
+	PendingSize() (uint64, bool)
 }
 
 // FilesystemAttachment describes an attachment of a filesystem to a machine.
@@ -108,6 +113,10 @@ type filesystemDoc struct {
 	Info            *FilesystemInfo   `bson:"info,omitempty"`
 	Params          *FilesystemParams `bson:"params,omitempty"`
 
+	// PendingSize, if non-zero, is the size in MiB that the
+	// provisioned filesystem has been requested to grow to.
+	PendingSize uint64 `bson:"pendingsize,omitempty"`
+
 	// MachineId is the ID of the machine that a non-detachable
 	// volume is initially attached to. We use this to identify
 	// the volume as being non-detachable, and to determine
@@ -227,6 +236,11 @@ func (f *filesystem) Params() (FilesystemParams, bool) {
 	return *f.doc.Params, true
 }
 
+// PendingSize is required to implement Filesystem.
+func (f *filesystem) PendingSize() (uint64, bool) {
+	return f.doc.PendingSize, f.doc.PendingSize != 0
+}
+
 // Status is required to implement StatusGetter.
 func (f *filesystem) Status() (status.StatusInfo, error) {
 	return f.st.FilesystemStatus(f.FilesystemTag())
@@ -818,9 +832,9 @@ func (st *State) addFilesystemOps(params FilesystemParams, machineId string) ([]
 	if !provider.Supports(storage.StorageKindFilesystem) {
 		var volumeOps []txn.Op
 		volumeParams := VolumeParams{
-			params.storage,
-			params.Pool,
-			params.Size,
+			storage: params.storage,
+			Pool:    params.Pool,
+			Size:    params.Size,
 		}
 		volumeOps, volumeTag, err = st.addVolumeOps(volumeParams, machineId)
 		if err != nil {
@@ -0,0 +1,172 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// maxHookExecutionsPerUnit is the number of hook executions recorded
+// for each unit; older records are discarded as new ones arrive.
+const maxHookExecutionsPerUnit = 50
+
+// maxToolCallsPerHookExecution is the number of hook tool calls
+// recorded for each hook execution; later calls are only counted.
+const maxToolCallsPerHookExecution = 100
+
+// HookExecution records a single execution of a hook or action by a
+// unit's agent.
+type HookExecution struct {
+	// Kind is either "hook" or "action".
+	Kind string
+
+	// Name is the name of the hook or action.
+	Name string
+
+	// Started and Finished record when the execution started and
+	// finished.
+	Started  time.Time
+	Finished time.Time
+
+	// ExitCode is the exit code of the hook or action process.
+	ExitCode int
+
+	// Error holds the reason the execution failed, if it did.
+	Error string
+
+	// ToolCalls records the hook tools invoked during the execution,
+	// in the order they were called.
+	ToolCalls []HookToolCall
+
+	// DroppedToolCalls is the number of hook tool calls made after
+	// those in ToolCalls, which were not recorded.
+	DroppedToolCalls int
+}
+
+// HookToolCall records a single invocation of a hook tool.
+type HookToolCall struct {
+	Name     string
+	Started  time.Time
+	Duration time.Duration
+	ExitCode int
+}
+
+type hookExecutionDoc struct {
+	ModelUUID string               `bson:"model-uuid"`
+	Unit      string               `bson:"unit"`
+	Kind      string               `bson:"kind"`
+	Name      string               `bson:"name"`
+	Started   int64                `bson:"started"`
+	Finished  int64                `bson:"finished"`
+	ExitCode  int                  `bson:"exit-code"`
+	Error     string               `bson:"error,omitempty"`
+	ToolCalls []hookToolCallSubdoc `bson:"tool-calls,omitempty"`
+
+	DroppedToolCalls int `bson:"dropped-tool-calls,omitempty"`
+}
+
+type hookToolCallSubdoc struct {
+	Name     string `bson:"name"`
+	Started  int64  `bson:"started"`
+	Duration int64  `bson:"duration"`
+	ExitCode int    `bson:"exit-code"`
+}
+
+// RecordHookExecution adds the execution to the unit's hook history,
+// discarding the oldest records once the history is full. Only the
+// first tool calls of the execution are kept; the others are counted
+// as dropped.
+func (u *Unit) RecordHookExecution(execution HookExecution) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot record hook execution for unit %q", u)
+	doc := &hookExecutionDoc{
+		Unit:             u.doc.Name,
+		Kind:             execution.Kind,
+		Name:             execution.Name,
+		Started:          execution.Started.UnixNano(),
+		Finished:         execution.Finished.UnixNano(),
+		ExitCode:         execution.ExitCode,
+		Error:            execution.Error,
+		DroppedToolCalls: execution.DroppedToolCalls,
+	}
+	toolCalls := execution.ToolCalls
+	if len(toolCalls) > maxToolCallsPerHookExecution {
+		doc.DroppedToolCalls += len(toolCalls) - maxToolCallsPerHookExecution
+		toolCalls = toolCalls[:maxToolCallsPerHookExecution]
+	}
+	for _, call := range toolCalls {
+		doc.ToolCalls = append(doc.ToolCalls, hookToolCallSubdoc{
+			Name:     call.Name,
+			Started:  call.Started.UnixNano(),
+			Duration: int64(call.Duration),
+			ExitCode: call.ExitCode,
+		})
+	}
+	executions, closer := u.st.getCollection(hookExecutionsC)
+	defer closer()
+	executionsW := executions.Writeable()
+	if err := executionsW.Insert(doc); err != nil {
+		return errors.Trace(err)
+	}
+
+	// Find the oldest record to be kept, and discard anything older.
+	var oldest hookExecutionDoc
+	err = executions.Find(bson.D{{"unit", u.doc.Name}}).
+		Sort("-started").Skip(maxHookExecutionsPerUnit - 1).One(&oldest)
+	if err == mgo.ErrNotFound {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	_, err = executionsW.RemoveAll(bson.D{
+		{"unit", u.doc.Name},
+		{"started", bson.M{"$lt": oldest.Started}},
+	})
+	return errors.Trace(err)
+}
+
+// HookExecutions returns the unit's recorded hook and action
+// executions, oldest first.
+func (u *Unit) HookExecutions() ([]HookExecution, error) {
+	executions, closer := u.st.getCollection(hookExecutionsC)
+	defer closer()
+
+	var docs []hookExecutionDoc
+	err := executions.Find(bson.D{{"unit", u.doc.Name}}).Sort("started").All(&docs)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get hook executions for unit %q", u)
+	}
+	result := make([]HookExecution, len(docs))
+	for i, doc := range docs {
+		result[i] = HookExecution{
+			Kind:             doc.Kind,
+			Name:             doc.Name,
+			Started:          time.Unix(0, doc.Started).UTC(),
+			Finished:         time.Unix(0, doc.Finished).UTC(),
+			ExitCode:         doc.ExitCode,
+			Error:            doc.Error,
+			DroppedToolCalls: doc.DroppedToolCalls,
+		}
+		for _, call := range doc.ToolCalls {
+			result[i].ToolCalls = append(result[i].ToolCalls, HookToolCall{
+				Name:     call.Name,
+				Started:  time.Unix(0, call.Started).UTC(),
+				Duration: time.Duration(call.Duration),
+				ExitCode: call.ExitCode,
+			})
+		}
+	}
+	return result, nil
+}
+
+// eraseHookExecutions removes the unit's hook history.
+func (u *Unit) eraseHookExecutions() error {
+	executions, closer := u.st.getCollection(hookExecutionsC)
+	defer closer()
+	_, err := executions.Writeable().RemoveAll(bson.D{{"unit", u.doc.Name}})
+	return errors.Trace(err)
+}
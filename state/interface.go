@@ -142,8 +142,14 @@ type ActionReceiver interface {
 	// ActionReceiver.
 	AddAction(name string, payload map[string]interface{}) (Action, error)
 
-	// CancelAction removes a pending Action from the queue for this
-	// ActionReceiver and marks it as cancelled.
+	// AddActionWithTimeout queues an action as AddAction does, which
+	// will be stopped if it runs for longer than timeout. A zero timeout
+	// means the action's default timeout is used, if it has one.
+	AddActionWithTimeout(name string, payload map[string]interface{}, timeout time.Duration) (Action, error)
+
+	// CancelAction cancels an Action queued for this ActionReceiver.
+	// Pending actions are removed from the queue and marked as
+	// cancelled; running actions are asked to stop.
 	CancelAction(action Action) (Action, error)
 
 	// WatchActionNotifications returns a StringsWatcher that will notify
@@ -193,6 +199,10 @@ type Action interface {
 	// definition of the Action.
 	Parameters() map[string]interface{}
 
+	// Timeout returns the maximum time the action may run for; zero
+	// means no limit.
+	Timeout() time.Duration
+
 	// Enqueued returns the time the action was added to state as a pending
 	// Action.
 	Enqueued() time.Time
@@ -209,6 +219,14 @@ type Action interface {
 	// Results returns the structured output of the action and any error.
 	Results() (map[string]interface{}, string)
 
+	// Messages returns the progress messages logged by the action,
+	// oldest first.
+	Messages() []ActionMessage
+
+	// Log adds a timestamped progress message to the action. It
+	// asserts that the action is running.
+	Log(message string) error
+
 	// ActionTag returns an ActionTag constructed from this action's
 	// Prefix and Sequence.
 	ActionTag() names.ActionTag
@@ -220,6 +238,11 @@ type Action interface {
 	// Finish removes action from the pending queue and captures the output
 	// and end state of the action.
 	Finish(results ActionResults) (Action, error)
+
+	// Cancel removes a pending action from the queue and marks it as
+	// cancelled, or marks a running action as aborting so that its
+	// receiver will stop it.
+	Cancel() (Action, error)
 }
 
 // ApplicationEntity represents a local or remote application.
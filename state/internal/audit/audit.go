@@ -4,8 +4,12 @@
 package audit
 
 import (
+	"regexp"
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/version"
+	"gopkg.in/mgo.v2/bson"
 
 	"github.com/juju/juju/audit"
 	"github.com/juju/juju/mongo/utils"
@@ -82,3 +86,104 @@ func auditEntryDocFromAuditEntry(auditEntry audit.AuditEntry) (auditEntryDoc, er
 		Data:              utils.EscapeKeys(auditEntry.Data),
 	}, nil
 }
+
+// GetAuditEntriesFn creates a closure which when passed an audit.Query
+// will return the matching entries from the audit collection, oldest
+// first. The find function is expected to return at most limit docs
+// matching the selector, sorted by the given field, with a limit of
+// zero meaning no limit.
+func GetAuditEntriesFn(
+	collectionName string,
+	find func(collectionName string, selector bson.D, sort string, limit int, docs interface{}) error,
+) func(audit.Query) ([]audit.AuditEntry, error) {
+	return func(query audit.Query) ([]audit.AuditEntry, error) {
+		// Entries are inserted in time order, so the _id reflects
+		// the order in which they were recorded. When limited we
+		// want the most recent entries, so read them newest first.
+		sort := "_id"
+		if query.Limit > 0 {
+			sort = "-_id"
+		}
+		var docs []auditEntryDoc
+		if err := find(collectionName, querySelector(query), sort, query.Limit, &docs); err != nil {
+			return nil, errors.Trace(err)
+		}
+		entries := make([]audit.AuditEntry, 0, len(docs))
+		for _, doc := range docs {
+			entry, err := auditEntryFromAuditEntryDoc(doc)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			// The selector's time bounds are only accurate to the
+			// second, so check them again here.
+			if !query.Match(entry) {
+				continue
+			}
+			entries = append(entries, entry)
+		}
+		if query.Limit > 0 {
+			for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+				entries[i], entries[j] = entries[j], entries[i]
+			}
+		}
+		return entries, nil
+	}
+}
+
+// querySelector returns a selector for the docs matched by query.
+func querySelector(query audit.Query) bson.D {
+	var selector bson.D
+	if query.OriginName != "" {
+		selector = append(selector, bson.DocElem{"origin-name", query.OriginName})
+	}
+	if query.ModelUUID != "" {
+		selector = append(selector, bson.DocElem{"model-uuid", query.ModelUUID})
+	}
+	if query.Operation != "" {
+		selector = append(selector, bson.DocElem{
+			"operation", bson.RegEx{Pattern: "^" + regexp.QuoteMeta(query.Operation)},
+		})
+	}
+	if query.RemoteAddress != "" {
+		// Addresses are recorded with their port.
+		selector = append(selector, bson.DocElem{
+			"remote-address", bson.RegEx{Pattern: "^" + regexp.QuoteMeta(query.RemoteAddress) + "(:[0-9]+)?$"},
+		})
+	}
+	// Timestamps are stored in RFC3339 format with a variable
+	// number of fractional digits, so they only sort correctly
+	// when compared to the second. A bound with the zone and
+	// fraction omitted sorts before every timestamp in that second.
+	timestamp := bson.D{}
+	if !query.From.IsZero() {
+		timestamp = append(timestamp, bson.DocElem{"$gte", secondPrefix(query.From)})
+	}
+	if !query.To.IsZero() {
+		timestamp = append(timestamp, bson.DocElem{"$lt", secondPrefix(query.To.Add(time.Second))})
+	}
+	if len(timestamp) > 0 {
+		selector = append(selector, bson.DocElem{"timestamp", timestamp})
+	}
+	return selector
+}
+
+func secondPrefix(t time.Time) string {
+	return t.UTC().Format("2006-01-02T15:04:05")
+}
+
+func auditEntryFromAuditEntryDoc(doc auditEntryDoc) (audit.AuditEntry, error) {
+	var timestamp time.Time
+	if err := timestamp.UnmarshalText([]byte(doc.Timestamp)); err != nil {
+		return audit.AuditEntry{}, errors.Annotatef(err, "parsing timestamp %q", doc.Timestamp)
+	}
+	return audit.AuditEntry{
+		JujuServerVersion: doc.JujuServerVersion,
+		ModelUUID:         doc.ModelUUID,
+		Timestamp:         timestamp.UTC(),
+		RemoteAddress:     doc.RemoteAddress,
+		OriginType:        doc.OriginType,
+		OriginName:        doc.OriginName,
+		Operation:         doc.Operation,
+		Data:              utils.UnescapeKeys(doc.Data),
+	}, nil
+}
@@ -4,6 +4,8 @@
 package audit_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -111,3 +113,96 @@ func (*AuditSuite) TestPutAuditEntry_ValidateAuditEntry(c *gc.C) {
 	err := putAuditEntry(auditEntry)
 	c.Check(err, gc.ErrorMatches, validationErr.Error())
 }
+
+func (*AuditSuite) TestGetAuditEntries_QueriesCollection(c *gc.C) {
+	modelUUID := utils.MustNewUUID().String()
+	t0 := time.Date(2017, time.June, 1, 12, 0, 0, 0, time.UTC)
+	query := audit.Query{
+		OriginName:    "user-bob",
+		ModelUUID:     modelUUID,
+		Operation:     "Application:",
+		RemoteAddress: "8.8.8.8",
+		From:          t0,
+		To:            t0.Add(time.Hour),
+	}
+
+	var findCalled bool
+	find := func(collectionName string, selector bson.D, sort string, limit int, docs interface{}) error {
+		findCalled = true
+		c.Check(collectionName, gc.Equals, "audit.log")
+		c.Check(selector, jc.DeepEquals, bson.D{
+			{"origin-name", "user-bob"},
+			{"model-uuid", modelUUID},
+			{"operation", bson.RegEx{Pattern: "^Application:"}},
+			{"remote-address", bson.RegEx{Pattern: `^8\.8\.8\.8(:[0-9]+)?$`}},
+			{"timestamp", bson.D{
+				{"$gte", "2017-06-01T12:00:00"},
+				{"$lt", "2017-06-01T13:00:01"},
+			}},
+		})
+		c.Check(sort, gc.Equals, "_id")
+		c.Check(limit, gc.Equals, 0)
+		return nil
+	}
+
+	getAuditEntries := stateaudit.GetAuditEntriesFn("audit.log", find)
+	entries, err := getAuditEntries(query)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 0)
+	c.Assert(findCalled, jc.IsTrue)
+}
+
+func (*AuditSuite) TestGetAuditEntries_RoundTrip(c *gc.C) {
+	t0 := time.Date(2017, time.June, 1, 12, 0, 0, 0, time.UTC)
+	var stored []bson.Raw
+	insertDocs := func(_ string, docs ...interface{}) error {
+		for _, doc := range docs {
+			data, err := bson.Marshal(doc)
+			c.Assert(err, jc.ErrorIsNil)
+			stored = append(stored, bson.Raw{Kind: 3, Data: data})
+		}
+		return nil
+	}
+	putAuditEntry := stateaudit.PutAuditEntryFn("audit.log", insertDocs)
+
+	modelUUID := utils.MustNewUUID().String()
+	var expected []audit.AuditEntry
+	for i := 0; i < 3; i++ {
+		entry := audit.AuditEntry{
+			JujuServerVersion: version.MustParse("1.0.0"),
+			ModelUUID:         modelUUID,
+			Timestamp:         t0.Add(time.Duration(i) * 500 * time.Millisecond),
+			RemoteAddress:     "8.8.8.8:1234",
+			OriginType:        "user",
+			OriginName:        "bob",
+			Operation:         "status",
+			Data:              map[string]interface{}{"$a": "b"},
+		}
+		c.Assert(putAuditEntry(entry), jc.ErrorIsNil)
+		expected = append(expected, entry)
+	}
+
+	find := func(_ string, _ bson.D, sort string, limit int, docs interface{}) error {
+		c.Check(sort, gc.Equals, "-_id")
+		c.Check(limit, gc.Equals, 2)
+		// Return the docs newest first, as mongo would.
+		raw := []bson.Raw{stored[2], stored[1], stored[0]}
+		data, err := bson.Marshal(bson.M{"docs": raw})
+		c.Assert(err, jc.ErrorIsNil)
+		var wrapper struct {
+			Docs bson.Raw `bson:"docs"`
+		}
+		c.Assert(bson.Unmarshal(data, &wrapper), jc.ErrorIsNil)
+		return wrapper.Docs.Unmarshal(docs)
+	}
+
+	// Only the first entry is excluded by the sub-second bound;
+	// the limit is applied by the find function.
+	getAuditEntries := stateaudit.GetAuditEntriesFn("audit.log", find)
+	entries, err := getAuditEntries(audit.Query{
+		From:  t0.Add(time.Millisecond),
+		Limit: 2,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, jc.DeepEquals, expected[1:])
+}
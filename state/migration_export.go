@@ -413,13 +413,21 @@ func (e *exporter) openedPortsArgsForMachine(machineId string, portsData []ports
 		// Don't bother including a subnet if there are no ports open on it.
 		if doc.MachineID == machineId && len(doc.Ports) > 0 {
 			args := description.OpenedPortsArgs{SubnetID: doc.SubnetID}
+			seen := make(map[description.PortRangeArgs]bool)
 			for _, p := range doc.Ports {
-				args.OpenedPorts = append(args.OpenedPorts, description.PortRangeArgs{
+				// The endpoints ports are opened for cannot yet be
+				// represented, so a range opened for several endpoints
+				// is exported once.
+				portRange := description.PortRangeArgs{
 					UnitName: p.UnitName,
 					FromPort: p.FromPort,
 					ToPort:   p.ToPort,
 					Protocol: p.Protocol,
-				})
+				}
+				if !seen[portRange] {
+					seen[portRange] = true
+					args.OpenedPorts = append(args.OpenedPorts, portRange)
+				}
 			}
 			result = append(result, args)
 		}
@@ -333,6 +333,62 @@ func (s *MigrationExportSuite) TestApplicationsWithVirtConstraint(c *gc.C) {
 	s.assertMigrateApplications(c, constraints.MustParse("arch=amd64 mem=8G virt-type=kvm"))
 }
 
+func (s *MigrationExportSuite) TestApplicationsWithExposeSettings(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	err := application.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		state.AllEndpoints: {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The settings cannot yet be represented, so they are dropped;
+	// the migration prechecks refuse to migrate the model.
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+	applications := model.Applications()
+	c.Assert(applications, gc.HasLen, 1)
+	c.Assert(applications[0].Exposed(), jc.IsTrue)
+}
+
+func (s *MigrationExportSuite) TestApplicationsWithUnrestrictedExposeSettings(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	err := application.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		state.AllEndpoints: {},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+	applications := model.Applications()
+	c.Assert(applications, gc.HasLen, 1)
+	c.Assert(applications[0].Exposed(), jc.IsTrue)
+}
+
+func (s *MigrationExportSuite) TestApplicationsWithEgressRules(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	err := application.SetCharmEgressRules([]network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The rules cannot yet be represented, so they are dropped; the
+	// migration prechecks refuse to migrate the model.
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Applications(), gc.HasLen, 1)
+}
+
+func (s *MigrationExportSuite) TestApplicationsWithOperatorEgressRules(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	err := application.SetEgressRules([]network.EgressRule{
+		network.MustNewEgressRule("udp", 53, 53),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Applications(), gc.HasLen, 1)
+}
+
 func (s *MigrationExportSuite) assertMigrateApplications(c *gc.C, cons constraints.Value) {
 	application := s.Factory.MakeApplication(c, &factory.ApplicationParams{
 		Settings: map[string]interface{}{
@@ -25,6 +25,11 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		modelUsersC,
 		modelUserLastConnectionC,
 		permissionsC,
+		// The application settings of relations, with keys of the
+		// form "r#<relation id>#<application>", and the charm state
+		// of units, with keys of the form "u#<unit>#state", are not
+		// yet supported by the description package. The migration
+		// prechecks refuse models that have any.
 		settingsC,
 		sequenceC,
 		sshHostKeysC,
@@ -179,6 +184,10 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// Metrics manager maintains controller specific state relating to
 		// the store and forward of charm metrics. Nothing to migrate here.
 		metricsManagerC,
+
+		// Hook execution history is diagnostic only, and is rebuilt as
+		// the migrated units run hooks.
+		hookExecutionsC,
 	)
 
 	// THIS SET WILL BE REMOVED WHEN MIGRATIONS ARE COMPLETE
@@ -189,6 +198,19 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		applicationOffersC,
 		tokensC,
 		remoteEntitiesC,
+
+		// Action schedules are not yet supported by the
+		// description package.
+		actionSchedulesC,
+
+		// Volume snapshots are not yet supported by the
+		// description package.
+		volumeSnapshotsC,
+
+		// Secrets are encrypted with a key specific to the source
+		// controller, and are not yet supported by the description
+		// package.
+		secretsC,
 	)
 
 	envCollections := set.NewStrings()
@@ -349,6 +371,18 @@ func (s *MigrationSuite) TestApplicationDocFields(c *gc.C) {
 		// RelationCount is handled by the number of times the application name
 		// appears in relation endpoints.
 		"RelationCount",
+		// Trusted is not yet supported by the description package.
+		// The migration prechecks refuse models with trusted
+		// applications.
+		"Trusted",
+		// ExposedEndpoints cannot yet be migrated; the migration
+		// prechecks refuse applications with expose settings that
+		// restrict access, and unrestricted settings are dropped.
+		"ExposedEndpoints",
+		// Egress rules cannot yet be migrated; the migration
+		// prechecks refuse applications with egress rules.
+		"EgressRules",
+		"CharmEgressRules",
 	)
 	migrated := set.NewStrings(
 		"Name",
@@ -655,6 +689,12 @@ func (s *MigrationSuite) TestSSHHostKeyDocFields(c *gc.C) {
 func (s *MigrationSuite) TestActionDocFields(c *gc.C) {
 	ignored := set.NewStrings(
 		"ModelUUID",
+		// Timeout and Messages are not yet supported by the
+		// description package.
+		"Timeout",
+		"Messages",
+		// TxnRevno is mgo internals and should not be migrated.
+		"TxnRevno",
 	)
 	migrated := set.NewStrings(
 		"DocId",
@@ -676,7 +716,8 @@ func (s *MigrationSuite) TestVolumeDocFields(c *gc.C) {
 		"ModelUUID",
 		"DocID",
 		"Life",
-		"MachineId", // recreated from pool properties
+		"MachineId",   // recreated from pool properties
+		"PendingSize", // pending resizes are not migrated
 	)
 	migrated := set.NewStrings(
 		"Name",
@@ -689,8 +730,9 @@ func (s *MigrationSuite) TestVolumeDocFields(c *gc.C) {
 	// The info and params fields ar structs.
 	s.AssertExportedFields(c, VolumeInfo{}, set.NewStrings(
 		"HardwareId", "Size", "Pool", "VolumeId", "Persistent"))
+	// SnapshotId is not yet supported by the description package.
 	s.AssertExportedFields(c, VolumeParams{}, set.NewStrings(
-		"Size", "Pool"))
+		"Size", "Pool", "SnapshotId"))
 }
 
 func (s *MigrationSuite) TestVolumeAttachmentDocFields(c *gc.C) {
@@ -718,7 +760,8 @@ func (s *MigrationSuite) TestFilesystemDocFields(c *gc.C) {
 		"ModelUUID",
 		"DocID",
 		"Life",
-		"MachineId", // recreated from pool properties
+		"MachineId",   // recreated from pool properties
+		"PendingSize", // pending resizes are not migrated
 	)
 	migrated := set.NewStrings(
 		"FilesystemId",
@@ -289,6 +289,7 @@ func (st *State) NewModel(args ModelArgs) (_ *Model, _ *State, err error) {
 		}
 	}()
 	newSt.controllerModelTag = st.controllerModelTag
+	newSt.secretsKey = st.secretsKey
 
 	modelOps, err := newSt.modelSetupOps(st.controllerTag.Id(), args, nil)
 	if err != nil {
@@ -875,11 +876,13 @@ func (m *Model) destroyOps(ensureNoHostedModels, ensureEmpty bool) ([]txn.Op, er
 		cleanupApplicationsOp := newCleanupOp(cleanupApplicationsForDyingModel, modelUUID)
 		cleanupVolumesOp := newCleanupOp(cleanupVolumesForDyingModel, modelUUID)
 		cleanupFilesystemsOp := newCleanupOp(cleanupFilesystemsForDyingModel, modelUUID)
+		cleanupVolumeSnapshotsOp := newCleanupOp(cleanupVolumeSnapshotsForDyingModel, modelUUID)
 		ops = append(ops,
 			cleanupMachinesOp,
 			cleanupApplicationsOp,
 			cleanupVolumesOp,
 			cleanupFilesystemsOp,
+			cleanupVolumeSnapshotsOp,
 		)
 	}
 	return append(prereqOps, ops...), nil
@@ -917,6 +920,17 @@ func (m *Model) checkEmpty() error {
 	if n := len(doc.Filesystems); n > 0 {
 		return errors.Errorf("model not empty, found %d filesystem(s)", n)
 	}
+	// Volume snapshots outlive the volumes they were taken of, so
+	// they are not recorded in the model's entity references.
+	volumeSnapshots, closer := st.getCollection(volumeSnapshotsC)
+	defer closer()
+	n, err := volumeSnapshots.Count()
+	if err != nil {
+		return errors.Annotate(err, "counting volume snapshots")
+	}
+	if n > 0 {
+		return errors.Errorf("model not empty, found %d volume snapshot(s)", n)
+	}
 	return nil
 }
 
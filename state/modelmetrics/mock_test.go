@@ -0,0 +1,199 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelmetrics_test
+
+import (
+	"github.com/juju/testing"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/modelmetrics"
+	"github.com/juju/juju/status"
+)
+
+type mockState struct {
+	testing.Stub
+	models []*mockModel
+}
+
+func (m *mockState) AllModels() ([]modelmetrics.Model, error) {
+	m.MethodCall(m, "AllModels")
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	out := make([]modelmetrics.Model, len(m.models))
+	for i, m := range m.models {
+		out[i] = m
+	}
+	return out, nil
+}
+
+func (m *mockState) ForModel(tag names.ModelTag) (modelmetrics.ModelState, error) {
+	m.MethodCall(m, "ForModel", tag)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	for _, m := range m.models {
+		if m.tag == tag {
+			return m, nil
+		}
+	}
+	panic("model not found")
+}
+
+type mockModel struct {
+	testing.Stub
+	tag          names.ModelTag
+	name         string
+	cleanups     int
+	applications []*mockApplication
+	machines     []*mockMachine
+}
+
+func (m *mockModel) ModelTag() names.ModelTag {
+	m.MethodCall(m, "ModelTag")
+	return m.tag
+}
+
+func (m *mockModel) Name() string {
+	m.MethodCall(m, "Name")
+	return m.name
+}
+
+func (m *mockModel) AllApplications() ([]modelmetrics.Application, error) {
+	m.MethodCall(m, "AllApplications")
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	out := make([]modelmetrics.Application, len(m.applications))
+	for i, a := range m.applications {
+		out[i] = a
+	}
+	return out, nil
+}
+
+func (m *mockModel) AllMachines() ([]modelmetrics.Machine, error) {
+	m.MethodCall(m, "AllMachines")
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	out := make([]modelmetrics.Machine, len(m.machines))
+	for i, machine := range m.machines {
+		out[i] = machine
+	}
+	return out, nil
+}
+
+func (m *mockModel) PendingCleanups() (int, error) {
+	m.MethodCall(m, "PendingCleanups")
+	if err := m.NextErr(); err != nil {
+		return 0, err
+	}
+	return m.cleanups, nil
+}
+
+func (m *mockModel) Close() error {
+	m.MethodCall(m, "Close")
+	return m.NextErr()
+}
+
+type mockApplication struct {
+	testing.Stub
+	name  string
+	units []*mockUnit
+}
+
+func (a *mockApplication) Name() string {
+	a.MethodCall(a, "Name")
+	return a.name
+}
+
+func (a *mockApplication) AllUnits() ([]modelmetrics.Unit, error) {
+	a.MethodCall(a, "AllUnits")
+	if err := a.NextErr(); err != nil {
+		return nil, err
+	}
+	out := make([]modelmetrics.Unit, len(a.units))
+	for i, u := range a.units {
+		out[i] = u
+	}
+	return out, nil
+}
+
+type mockUnit struct {
+	testing.Stub
+	name           string
+	agentStatus    status.StatusInfo
+	workloadStatus status.StatusInfo
+	actions        []state.Action
+	history        []status.StatusInfo
+}
+
+func (u *mockUnit) Name() string {
+	u.MethodCall(u, "Name")
+	return u.name
+}
+
+func (u *mockUnit) AgentHistory() status.StatusHistoryGetter {
+	u.MethodCall(u, "AgentHistory")
+	return u
+}
+
+func (u *mockUnit) StatusHistory(filter status.StatusHistoryFilter) ([]status.StatusInfo, error) {
+	u.MethodCall(u, "StatusHistory", filter)
+	if err := u.NextErr(); err != nil {
+		return nil, err
+	}
+	return u.history, nil
+}
+
+func (u *mockUnit) AgentStatus() (status.StatusInfo, error) {
+	u.MethodCall(u, "AgentStatus")
+	if err := u.NextErr(); err != nil {
+		return status.StatusInfo{}, err
+	}
+	return u.agentStatus, nil
+}
+
+func (u *mockUnit) Status() (status.StatusInfo, error) {
+	u.MethodCall(u, "Status")
+	if err := u.NextErr(); err != nil {
+		return status.StatusInfo{}, err
+	}
+	return u.workloadStatus, nil
+}
+
+func (u *mockUnit) PendingActions() ([]state.Action, error) {
+	u.MethodCall(u, "PendingActions")
+	if err := u.NextErr(); err != nil {
+		return nil, err
+	}
+	return u.actions, nil
+}
+
+type mockMachine struct {
+	testing.Stub
+	instanceStatus status.StatusInfo
+	agentStatus    status.StatusInfo
+}
+
+func (m *mockMachine) InstanceStatus() (status.StatusInfo, error) {
+	m.MethodCall(m, "InstanceStatus")
+	if err := m.NextErr(); err != nil {
+		return status.StatusInfo{}, err
+	}
+	return m.instanceStatus, nil
+}
+
+func (m *mockMachine) Status() (status.StatusInfo, error) {
+	m.MethodCall(m, "Status")
+	if err := m.NextErr(); err != nil {
+		return status.StatusInfo{}, err
+	}
+	return m.agentStatus, nil
+}
+
+type mockAction struct {
+	state.Action
+}
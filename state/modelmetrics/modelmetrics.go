@@ -0,0 +1,445 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package modelmetrics provides a prometheus.Collector that exposes
+// the operational state of each model hosted by a Juju controller.
+package modelmetrics
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/juju/juju/status"
+)
+
+const (
+	metricsNamespace = "juju_model"
+
+	modelLabel          = "model"
+	modelUUIDLabel      = "model_uuid"
+	applicationLabel    = "application"
+	hookLabel           = "hook"
+	agentStatusLabel    = "agent_status"
+	workloadStatusLabel = "workload_status"
+	machineStatusLabel  = "machine_status"
+
+	// agentHistorySize is the number of unit agent status history
+	// entries inspected on each scrape for completed and failed hooks.
+	agentHistorySize = 50
+)
+
+var (
+	modelLabelNames = []string{
+		modelLabel,
+		modelUUIDLabel,
+	}
+
+	applicationLabelNames = []string{
+		modelLabel,
+		modelUUIDLabel,
+		applicationLabel,
+	}
+
+	hookLabelNames = []string{
+		modelLabel,
+		modelUUIDLabel,
+		applicationLabel,
+		hookLabel,
+	}
+
+	unitLabelNames = []string{
+		modelLabel,
+		modelUUIDLabel,
+		applicationLabel,
+		agentStatusLabel,
+		workloadStatusLabel,
+	}
+
+	machineLabelNames = []string{
+		modelLabel,
+		modelUUIDLabel,
+		agentStatusLabel,
+		machineStatusLabel,
+	}
+
+	logger = loggo.GetLogger("juju.state.modelmetrics")
+)
+
+// Collector is a prometheus.Collector that collects metrics about
+// the models hosted by a Juju controller.
+type Collector struct {
+	st State
+
+	// mu serialises scrapes, and guards lastSeen.
+	mu sync.Mutex
+
+	// lastSeen records, for each unit, the time of the most recent
+	// agent status history entry accounted for in the hook metrics.
+	lastSeen map[string]time.Time
+
+	scrapeDuration prometheus.Gauge
+	scrapeErrors   prometheus.Gauge
+
+	units              *prometheus.GaugeVec
+	machines           *prometheus.GaugeVec
+	pendingActions     *prometheus.GaugeVec
+	pendingCleanups    *prometheus.GaugeVec
+	provisioningErrors *prometheus.GaugeVec
+	hookDuration       *prometheus.HistogramVec
+	hookFailures       *prometheus.CounterVec
+}
+
+// New returns a new Collector.
+func New(st State) *Collector {
+	return &Collector{
+		st:       st,
+		lastSeen: make(map[string]time.Time),
+		scrapeDuration: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "scrape_duration_seconds",
+				Help:      "Amount of time taken to collect model metrics.",
+			},
+		),
+		scrapeErrors: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "scrape_errors",
+				Help:      "Number of errors observed while collecting model metrics.",
+			},
+		),
+
+		units: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "units",
+				Help:      "Number of units in the model.",
+			},
+			unitLabelNames,
+		),
+		machines: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "machines",
+				Help:      "Number of machines in the model.",
+			},
+			machineLabelNames,
+		),
+		pendingActions: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "pending_actions",
+				Help:      "Number of actions queued for the units of an application.",
+			},
+			applicationLabelNames,
+		),
+		pendingCleanups: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "pending_cleanups",
+				Help:      "Number of cleanups waiting to be run in the model.",
+			},
+			modelLabelNames,
+		),
+		provisioningErrors: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "provisioning_errors",
+				Help:      "Number of machines in the model that failed to provision.",
+			},
+			modelLabelNames,
+		),
+		hookDuration: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: metricsNamespace,
+				Name:      "hook_duration_seconds",
+				Help:      "Time taken by units of an application to run hooks.",
+				Buckets:   []float64{0.1, 0.5, 1, 5, 10, 30, 60, 300, 600, 1800},
+			},
+			hookLabelNames,
+		),
+		hookFailures: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: metricsNamespace,
+				Name:      "hook_failures_total",
+				Help:      "Number of hooks that failed on units of an application.",
+			},
+			hookLabelNames,
+		),
+	}
+}
+
+// Describe is part of the prometheus.Collector interface.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.units.Describe(ch)
+	c.machines.Describe(ch)
+	c.pendingActions.Describe(ch)
+	c.pendingCleanups.Describe(ch)
+	c.provisioningErrors.Describe(ch)
+	c.hookDuration.Describe(ch)
+	c.hookFailures.Describe(ch)
+
+	c.scrapeErrors.Describe(ch)
+	c.scrapeDuration.Describe(ch)
+}
+
+// Collect is part of the prometheus.Collector interface.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	timer := prometheus.NewTimer(prometheus.ObserverFunc(c.scrapeDuration.Set))
+	defer c.scrapeDuration.Collect(ch)
+	defer timer.ObserveDuration()
+	c.scrapeErrors.Set(0)
+	defer c.scrapeErrors.Collect(ch)
+
+	// The hook metrics are cumulative, so they are not reset
+	// between scrapes.
+	c.units.Reset()
+	c.machines.Reset()
+	c.pendingActions.Reset()
+	c.pendingCleanups.Reset()
+	c.provisioningErrors.Reset()
+
+	c.updateMetrics()
+
+	c.units.Collect(ch)
+	c.machines.Collect(ch)
+	c.pendingActions.Collect(ch)
+	c.pendingCleanups.Collect(ch)
+	c.provisioningErrors.Collect(ch)
+	c.hookDuration.Collect(ch)
+	c.hookFailures.Collect(ch)
+}
+
+func (c *Collector) updateMetrics() {
+	logger.Tracef("updating model metrics")
+	defer logger.Tracef("updated model metrics")
+
+	models, err := c.st.AllModels()
+	if err != nil {
+		logger.Debugf("error getting models: %v", err)
+		c.scrapeErrors.Inc()
+		return
+	}
+	seen := make(map[string]bool)
+	for _, m := range models {
+		c.updateModelMetrics(m, seen)
+	}
+	// Forget the units that have gone away, so that lastSeen
+	// does not grow without bound.
+	for name := range c.lastSeen {
+		if !seen[name] {
+			delete(c.lastSeen, name)
+		}
+	}
+}
+
+func (c *Collector) updateModelMetrics(model Model, seen map[string]bool) {
+	modelTag := model.ModelTag()
+	st, err := c.st.ForModel(modelTag)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return // Model removed
+		}
+		c.scrapeErrors.Inc()
+		logger.Debugf("error getting model state: %v", err)
+		return
+	}
+	defer st.Close()
+
+	modelLabels := prometheus.Labels{
+		modelLabel:     model.Name(),
+		modelUUIDLabel: modelTag.Id(),
+	}
+
+	cleanups, err := st.PendingCleanups()
+	if err != nil {
+		c.scrapeErrors.Inc()
+		logger.Debugf("error getting pending cleanups: %v", err)
+	} else {
+		c.pendingCleanups.With(modelLabels).Set(float64(cleanups))
+	}
+
+	c.provisioningErrors.With(modelLabels).Set(0)
+	machines, err := st.AllMachines()
+	if err != nil {
+		c.scrapeErrors.Inc()
+		logger.Debugf("error getting machines: %v", err)
+		machines = nil
+	}
+	for _, m := range machines {
+		c.updateMachineMetrics(m, modelLabels)
+	}
+
+	applications, err := st.AllApplications()
+	if err != nil {
+		c.scrapeErrors.Inc()
+		logger.Debugf("error getting applications: %v", err)
+		applications = nil
+	}
+	for _, app := range applications {
+		appLabels := withLabel(modelLabels, applicationLabel, app.Name())
+		units, err := app.AllUnits()
+		if err != nil {
+			c.scrapeErrors.Inc()
+			logger.Debugf("error getting units: %v", err)
+			continue
+		}
+		c.pendingActions.With(appLabels).Set(0)
+		for _, u := range units {
+			seen[modelTag.Id()+"/"+u.Name()] = true
+			c.updateUnitMetrics(modelTag.Id(), u, appLabels)
+		}
+	}
+}
+
+func (c *Collector) updateMachineMetrics(m Machine, modelLabels prometheus.Labels) {
+	agentStatus, err := m.Status()
+	if errors.IsNotFound(err) {
+		return // Machine removed
+	} else if err != nil {
+		c.scrapeErrors.Inc()
+		logger.Debugf("error getting machine status: %v", err)
+		return
+	}
+
+	machineStatus, err := m.InstanceStatus()
+	if errors.IsNotFound(err) {
+		return // Machine removed
+	} else if errors.IsNotProvisioned(err) {
+		machineStatus.Status = ""
+	} else if err != nil {
+		c.scrapeErrors.Inc()
+		logger.Debugf("error getting machine status: %v", err)
+		return
+	}
+
+	labels := withLabel(modelLabels, agentStatusLabel, string(agentStatus.Status))
+	labels[machineStatusLabel] = string(machineStatus.Status)
+	c.machines.With(labels).Inc()
+	if machineStatus.Status == status.ProvisioningError {
+		c.provisioningErrors.With(modelLabels).Inc()
+	}
+}
+
+func (c *Collector) updateUnitMetrics(modelUUID string, u Unit, appLabels prometheus.Labels) {
+	agentStatus, err := u.AgentStatus()
+	if errors.IsNotFound(err) {
+		return // Unit removed
+	} else if err != nil {
+		c.scrapeErrors.Inc()
+		logger.Debugf("error getting unit agent status: %v", err)
+		return
+	}
+	workloadStatus, err := u.Status()
+	if errors.IsNotFound(err) {
+		return // Unit removed
+	} else if err != nil {
+		c.scrapeErrors.Inc()
+		logger.Debugf("error getting unit status: %v", err)
+		return
+	}
+	labels := withLabel(appLabels, agentStatusLabel, string(agentStatus.Status))
+	labels[workloadStatusLabel] = string(workloadStatus.Status)
+	c.units.With(labels).Inc()
+
+	actions, err := u.PendingActions()
+	if err != nil {
+		c.scrapeErrors.Inc()
+		logger.Debugf("error getting pending actions: %v", err)
+	} else {
+		c.pendingActions.With(appLabels).Add(float64(len(actions)))
+	}
+
+	history, err := u.AgentHistory().StatusHistory(status.StatusHistoryFilter{
+		Size: agentHistorySize,
+	})
+	if err != nil {
+		c.scrapeErrors.Inc()
+		logger.Debugf("error getting unit agent status history: %v", err)
+		return
+	}
+	key := modelUUID + "/" + u.Name()
+	if latest, ok := c.updateHookMetrics(history, c.lastSeen[key], appLabels); ok {
+		c.lastSeen[key] = latest
+	}
+}
+
+// updateHookMetrics records the hooks completed and failed after the
+// given time in the unit agent status history, which is ordered from
+// newest to oldest. It returns the time of the newest entry, if any.
+func (c *Collector) updateHookMetrics(
+	history []status.StatusInfo,
+	after time.Time,
+	appLabels prometheus.Labels,
+) (time.Time, bool) {
+	if len(history) == 0 || history[0].Since == nil {
+		return time.Time{}, false
+	}
+	for i, entry := range history {
+		if entry.Since == nil || !entry.Since.After(after) {
+			break
+		}
+		if entry.Status == status.Error {
+			if hook, ok := failedHookName(entry); ok {
+				c.hookFailures.With(withLabel(appLabels, hookLabel, hook)).Inc()
+			}
+		}
+		// A hook finishes when the agent moves on from the
+		// entry that reports it running.
+		if i+1 == len(history) {
+			continue
+		}
+		previous := history[i+1]
+		hook, ok := runningHookName(previous)
+		if !ok || previous.Since == nil {
+			continue
+		}
+		duration := entry.Since.Sub(*previous.Since)
+		c.hookDuration.With(withLabel(appLabels, hookLabel, hook)).Observe(duration.Seconds())
+	}
+	return *history[0].Since, true
+}
+
+// runningHookName returns the name of the hook that a unit agent
+// status history entry reports as running.
+func runningHookName(entry status.StatusInfo) (string, bool) {
+	const prefix, suffix = "running ", " hook"
+	if entry.Status != status.Executing {
+		return "", false
+	}
+	if !strings.HasPrefix(entry.Message, prefix) || !strings.HasSuffix(entry.Message, suffix) {
+		return "", false
+	}
+	return entry.Message[len(prefix) : len(entry.Message)-len(suffix)], true
+}
+
+// failedHookName returns the name of the hook that a unit agent
+// status history entry reports as failed.
+func failedHookName(entry status.StatusInfo) (string, bool) {
+	if hook, ok := entry.Data["hook"].(string); ok && hook != "" {
+		return hook, true
+	}
+	const prefix = "hook failed: "
+	if !strings.HasPrefix(entry.Message, prefix) {
+		return "", false
+	}
+	return strings.Trim(entry.Message[len(prefix):], `"`), true
+}
+
+// withLabel returns a copy of labels with the given label added.
+func withLabel(labels prometheus.Labels, name, value string) prometheus.Labels {
+	out := make(prometheus.Labels, len(labels)+1)
+	for k, v := range labels {
+		out[k] = v
+	}
+	out[name] = value
+	return out
+}
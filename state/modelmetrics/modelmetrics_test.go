@@ -0,0 +1,239 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelmetrics_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/modelmetrics"
+	"github.com/juju/juju/status"
+)
+
+const modelUUID = "b266dff7-eee8-4297-b03a-4692796ec193"
+
+type collectorSuite struct {
+	testing.IsolationSuite
+	st        mockState
+	collector *modelmetrics.Collector
+}
+
+var _ = gc.Suite(&collectorSuite{})
+
+func statusInfo(s status.Status, message string, since time.Time, data map[string]interface{}) status.StatusInfo {
+	return status.StatusInfo{Status: s, Message: message, Since: &since, Data: data}
+}
+
+func (s *collectorSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	t0 := time.Date(2017, 5, 1, 12, 0, 0, 0, time.UTC)
+	mysql := &mockApplication{
+		name: "mysql",
+		units: []*mockUnit{{
+			name:           "mysql/0",
+			agentStatus:    status.StatusInfo{Status: status.Idle},
+			workloadStatus: status.StatusInfo{Status: status.Active},
+			actions:        []state.Action{mockAction{}, mockAction{}},
+			history: []status.StatusInfo{
+				statusInfo(status.Idle, "", t0.Add(30*time.Second), nil),
+				statusInfo(status.Executing, "running config-changed hook", t0.Add(10*time.Second), nil),
+				statusInfo(status.Idle, "", t0, nil),
+			},
+		}, {
+			name:           "mysql/1",
+			agentStatus:    status.StatusInfo{Status: status.Error},
+			workloadStatus: status.StatusInfo{Status: status.Error},
+			history: []status.StatusInfo{
+				statusInfo(status.Error, `hook failed: "install"`, t0.Add(5*time.Second), map[string]interface{}{
+					"hook": "install",
+				}),
+				statusInfo(status.Executing, "running install hook", t0, nil),
+			},
+		}},
+	}
+	s.st = mockState{
+		models: []*mockModel{{
+			tag:          names.NewModelTag(modelUUID),
+			name:         "mymodel",
+			cleanups:     2,
+			applications: []*mockApplication{mysql},
+			machines: []*mockMachine{{
+				agentStatus:    status.StatusInfo{Status: status.Started},
+				instanceStatus: status.StatusInfo{Status: status.Running},
+			}, {
+				agentStatus:    status.StatusInfo{Status: status.Pending},
+				instanceStatus: status.StatusInfo{Status: status.ProvisioningError},
+			}},
+		}},
+	}
+	s.collector = modelmetrics.New(&s.st)
+}
+
+func (s *collectorSuite) TestDescribe(c *gc.C) {
+	ch := make(chan *prometheus.Desc)
+	go func() {
+		defer close(ch)
+		s.collector.Describe(ch)
+	}()
+	var descStrings []string
+	for desc := range ch {
+		descStrings = append(descStrings, desc.String())
+	}
+	expect := []string{
+		`.*fqName: "juju_model_units".*`,
+		`.*fqName: "juju_model_machines".*`,
+		`.*fqName: "juju_model_pending_actions".*`,
+		`.*fqName: "juju_model_pending_cleanups".*`,
+		`.*fqName: "juju_model_provisioning_errors".*`,
+		`.*fqName: "juju_model_hook_duration_seconds".*`,
+		`.*fqName: "juju_model_hook_failures_total".*`,
+		`.*fqName: "juju_model_scrape_errors".*`,
+		`.*fqName: "juju_model_scrape_duration_seconds".*`,
+	}
+	c.Assert(descStrings, gc.HasLen, len(expect))
+	for i, expect := range expect {
+		c.Assert(descStrings[i], gc.Matches, expect)
+	}
+}
+
+// gather collects the metrics, and returns them keyed by metric name.
+func (s *collectorSuite) gather(c *gc.C) map[string][]*dto.Metric {
+	registry := prometheus.NewPedanticRegistry()
+	err := registry.Register(s.collector)
+	c.Assert(err, jc.ErrorIsNil)
+	families, err := registry.Gather()
+	c.Assert(err, jc.ErrorIsNil)
+	out := make(map[string][]*dto.Metric)
+	for _, family := range families {
+		out[family.GetName()] = family.GetMetric()
+	}
+	return out
+}
+
+// labels returns the label pairs of a metric as a map.
+func labels(m *dto.Metric) map[string]string {
+	out := make(map[string]string)
+	for _, pair := range m.GetLabel() {
+		out[pair.GetName()] = pair.GetValue()
+	}
+	return out
+}
+
+// gaugeValues returns the values of the gauges in metrics, keyed by
+// the values of the given labels joined by spaces.
+func gaugeValues(metrics []*dto.Metric, labelNames ...string) map[string]float64 {
+	out := make(map[string]float64)
+	for _, m := range metrics {
+		out[labelKey(m, labelNames...)] = m.GetGauge().GetValue()
+	}
+	return out
+}
+
+func labelKey(m *dto.Metric, labelNames ...string) string {
+	l := labels(m)
+	var key string
+	for i, name := range labelNames {
+		if i > 0 {
+			key += " "
+		}
+		key += l[name]
+	}
+	return key
+}
+
+func (s *collectorSuite) TestCollect(c *gc.C) {
+	metrics := s.gather(c)
+
+	c.Assert(metrics["juju_model_units"], gc.HasLen, 2)
+	for _, m := range metrics["juju_model_units"] {
+		l := labels(m)
+		c.Check(l["model"], gc.Equals, "mymodel")
+		c.Check(l["model_uuid"], gc.Equals, modelUUID)
+		c.Check(l["application"], gc.Equals, "mysql")
+	}
+	c.Check(gaugeValues(metrics["juju_model_units"], "agent_status", "workload_status"), jc.DeepEquals, map[string]float64{
+		"idle active": 1,
+		"error error": 1,
+	})
+	c.Check(gaugeValues(metrics["juju_model_machines"], "agent_status", "machine_status"), jc.DeepEquals, map[string]float64{
+		"started running":            1,
+		"pending provisioning error": 1,
+	})
+	c.Check(gaugeValues(metrics["juju_model_pending_actions"], "model", "application"), jc.DeepEquals, map[string]float64{
+		"mymodel mysql": 2,
+	})
+	c.Check(gaugeValues(metrics["juju_model_pending_cleanups"], "model"), jc.DeepEquals, map[string]float64{
+		"mymodel": 2,
+	})
+	c.Check(gaugeValues(metrics["juju_model_provisioning_errors"], "model"), jc.DeepEquals, map[string]float64{
+		"mymodel": 1,
+	})
+	c.Check(gaugeValues(metrics["juju_model_scrape_errors"]), jc.DeepEquals, map[string]float64{
+		"": 0,
+	})
+	s.checkHookMetrics(c, metrics)
+}
+
+func (s *collectorSuite) checkHookMetrics(c *gc.C, metrics map[string][]*dto.Metric) {
+	durations := make(map[string][2]float64)
+	for _, m := range metrics["juju_model_hook_duration_seconds"] {
+		h := m.GetHistogram()
+		durations[labelKey(m, "application", "hook")] = [2]float64{
+			float64(h.GetSampleCount()), h.GetSampleSum(),
+		}
+	}
+	c.Check(durations, jc.DeepEquals, map[string][2]float64{
+		"mysql config-changed": {1, 20},
+		"mysql install":        {1, 5},
+	})
+	failures := make(map[string]float64)
+	for _, m := range metrics["juju_model_hook_failures_total"] {
+		failures[labelKey(m, "application", "hook")] = m.GetCounter().GetValue()
+	}
+	c.Check(failures, jc.DeepEquals, map[string]float64{
+		"mysql install": 1,
+	})
+}
+
+func (s *collectorSuite) TestCollectHooksOnce(c *gc.C) {
+	s.gather(c)
+	// The status history has not changed, so the hooks
+	// must not be counted again.
+	s.checkHookMetrics(c, s.gather(c))
+}
+
+func (s *collectorSuite) TestCollectErrors(c *gc.C) {
+	s.st.SetErrors(errors.New("no models for you"))
+	metrics := s.gather(c)
+	c.Check(gaugeValues(metrics["juju_model_scrape_errors"]), jc.DeepEquals, map[string]float64{
+		"": 1,
+	})
+	c.Check(metrics["juju_model_units"], gc.HasLen, 0)
+}
+
+func (s *collectorSuite) TestCollectModelErrors(c *gc.C) {
+	s.st.models[0].SetErrors(
+		errors.New("no cleanups for you"),
+		errors.New("no machines for you"),
+	)
+	metrics := s.gather(c)
+	c.Check(gaugeValues(metrics["juju_model_scrape_errors"]), jc.DeepEquals, map[string]float64{
+		"": 2,
+	})
+	c.Check(metrics["juju_model_pending_cleanups"], gc.HasLen, 0)
+	c.Check(metrics["juju_model_machines"], gc.HasLen, 0)
+	c.Check(metrics["juju_model_units"], gc.HasLen, 2)
+	s.st.models[0].CheckCallNames(c,
+		"ModelTag", "Name", "PendingCleanups", "AllMachines", "AllApplications", "Close",
+	)
+}
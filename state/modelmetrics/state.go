@@ -0,0 +1,134 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelmetrics
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+)
+
+// State represents the global state managed by the Juju controller.
+type State interface {
+	AllModels() ([]Model, error)
+	ForModel(names.ModelTag) (ModelState, error)
+}
+
+// ModelState represents the state of a single Juju model.
+type ModelState interface {
+	AllApplications() ([]Application, error)
+	AllMachines() ([]Machine, error)
+	PendingCleanups() (int, error)
+	Close() error
+}
+
+// Model represents a Juju model.
+type Model interface {
+	ModelTag() names.ModelTag
+	Name() string
+}
+
+// Application represents an application in a Juju model.
+type Application interface {
+	Name() string
+	AllUnits() ([]Unit, error)
+}
+
+// Unit represents a unit of an application in a Juju model.
+type Unit interface {
+	Name() string
+	AgentHistory() status.StatusHistoryGetter
+	AgentStatus() (status.StatusInfo, error)
+	Status() (status.StatusInfo, error)
+	PendingActions() ([]state.Action, error)
+}
+
+// Machine represents a machine in a Juju model.
+type Machine interface {
+	InstanceStatus() (status.StatusInfo, error)
+	Status() (status.StatusInfo, error)
+}
+
+// NewState takes a *state.State, and returns a State value backed by it.
+func NewState(st *state.State) State {
+	return stateShim{st}
+}
+
+type stateShim struct {
+	*state.State
+}
+
+func (s stateShim) AllModels() ([]Model, error) {
+	models, err := s.State.AllModels()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	out := make([]Model, len(models))
+	for i, m := range models {
+		if m != nil {
+			out[i] = m
+		}
+	}
+	return out, nil
+}
+
+func (s stateShim) ForModel(tag names.ModelTag) (ModelState, error) {
+	st, err := s.State.ForModel(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return modelStateShim{st}, nil
+}
+
+type modelStateShim struct {
+	*state.State
+}
+
+func (s modelStateShim) AllApplications() ([]Application, error) {
+	applications, err := s.State.AllApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	out := make([]Application, len(applications))
+	for i, a := range applications {
+		if a != nil {
+			out[i] = applicationShim{a}
+		}
+	}
+	return out, nil
+}
+
+func (s modelStateShim) AllMachines() ([]Machine, error) {
+	machines, err := s.State.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	out := make([]Machine, len(machines))
+	for i, m := range machines {
+		if m != nil {
+			out[i] = m
+		}
+	}
+	return out, nil
+}
+
+type applicationShim struct {
+	*state.Application
+}
+
+func (a applicationShim) AllUnits() ([]Unit, error) {
+	units, err := a.Application.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	out := make([]Unit, len(units))
+	for i, u := range units {
+		if u != nil {
+			out[i] = u
+		}
+	}
+	return out, nil
+}
@@ -57,6 +57,11 @@ type OpenParams struct {
 	// be called after mgo/txn transactions are run, successfully
 	// or not.
 	RunTransactionObserver RunTransactionObserverFunc
+
+	// SecretsKey is the key used to encrypt the values of secrets,
+	// as held in the controller agent's config. If it is empty,
+	// secrets cannot be added or read.
+	SecretsKey []byte
 }
 
 // Validate validates the OpenParams.
@@ -73,6 +78,9 @@ func (p OpenParams) Validate() error {
 	if p.MongoInfo == nil {
 		return errors.NotValidf("nil MongoInfo")
 	}
+	if err := validateSecretsKey(p.SecretsKey); err != nil {
+		return errors.Trace(err)
+	}
 	return nil
 }
 
@@ -96,6 +104,7 @@ func Open(args OpenParams) (*State, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	st.secretsKey = args.SecretsKey
 	if _, err := st.Model(); err != nil {
 		if err := st.Close(); err != nil {
 			logger.Errorf("closing State for %s: %v", args.ControllerModelTag, err)
@@ -196,6 +205,10 @@ type InitializeParams struct {
 	// MongoDialOpts contains the dial options for connecting to
 	// Mongo.
 	MongoDialOpts mongo.DialOpts
+
+	// SecretsKey is the key used to encrypt the values of secrets,
+	// as held in the controller agent's config.
+	SecretsKey []byte
 }
 
 // Validate checks that the state initialization parameters are valid.
@@ -237,6 +250,9 @@ func (p InitializeParams) Validate() error {
 	); err != nil {
 		return errors.Annotate(err, "validating controller model cloud credential")
 	}
+	if err := validateSecretsKey(p.SecretsKey); err != nil {
+		return errors.Trace(err)
+	}
 	return nil
 }
 
@@ -263,6 +279,7 @@ func Initialize(args InitializeParams) (_ *State, err error) {
 		}
 	}()
 	st.controllerModelTag = modelTag
+	st.secretsKey = args.SecretsKey
 
 	// A valid model is used as a signal that the
 	// state has already been initalized. If this is the case
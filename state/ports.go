@@ -6,6 +6,7 @@ package state
 import (
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/juju/errors"
@@ -37,6 +38,10 @@ type PortRange struct {
 	FromPort int
 	ToPort   int
 	Protocol string
+
+	// Endpoint holds the name of the unit's endpoint for which the
+	// range is opened, or AllEndpoints.
+	Endpoint string `bson:"endpoint,omitempty"`
 }
 
 // NewPortRange create a new port range and validate it.
@@ -118,8 +123,10 @@ func (prA PortRange) CheckConflicts(prB PortRange) error {
 
 	// An exact port range match (including the associated unit name) is not
 	// considered a conflict due to the fact that many charms issue commands
-	// to open the same port multiple times.
-	if prA == prB {
+	// to open the same port multiple times. A unit may also open the same
+	// range for several endpoints.
+	if prA.UnitName == prB.UnitName && prA.FromPort == prB.FromPort &&
+		prA.ToPort == prB.ToPort && prA.Protocol == prB.Protocol {
 		return nil
 	}
 	if prA.Protocol != prB.Protocol {
@@ -133,6 +140,9 @@ func (prA PortRange) CheckConflicts(prB PortRange) error {
 
 // Strings returns the port range as a string.
 func (p PortRange) String() string {
+	if p.Endpoint != AllEndpoints {
+		return fmt.Sprintf("%d-%d/%s (%q, endpoint %q)", p.FromPort, p.ToPort, strings.ToLower(p.Protocol), p.UnitName, p.Endpoint)
+	}
 	return fmt.Sprintf("%d-%d/%s (%q)", p.FromPort, p.ToPort, strings.ToLower(p.Protocol), p.UnitName)
 }
 
@@ -348,7 +358,8 @@ func (p *Ports) Refresh() error {
 }
 
 // AllPortRanges returns a map with network.PortRange as keys and unit
-// names as values.
+// names as values. A range a unit opened for several endpoints appears
+// once; see PortRanges.
 func (p *Ports) AllPortRanges() map[network.PortRange]string {
 	result := make(map[network.PortRange]string)
 	for _, portRange := range p.doc.Ports {
@@ -362,6 +373,36 @@ func (p *Ports) AllPortRanges() map[network.PortRange]string {
 	return result
 }
 
+// PortRanges returns all the port ranges maintained by this document,
+// sorted by protocol and port number, then by unit name and endpoint.
+func (p *Ports) PortRanges() []PortRange {
+	result := make([]PortRange, len(p.doc.Ports))
+	copy(result, p.doc.Ports)
+	sort.Sort(portRangeSlice(result))
+	return result
+}
+
+type portRangeSlice []PortRange
+
+func (p portRangeSlice) Len() int      { return len(p) }
+func (p portRangeSlice) Swap(i, j int) { p[i], p[j] = p[j], p[i] }
+func (p portRangeSlice) Less(i, j int) bool {
+	p1, p2 := p[i], p[j]
+	if p1.Protocol != p2.Protocol {
+		return p1.Protocol < p2.Protocol
+	}
+	if p1.FromPort != p2.FromPort {
+		return p1.FromPort < p2.FromPort
+	}
+	if p1.ToPort != p2.ToPort {
+		return p1.ToPort < p2.ToPort
+	}
+	if p1.UnitName != p2.UnitName {
+		return p1.UnitName < p2.UnitName
+	}
+	return p1.Endpoint < p2.Endpoint
+}
+
 // Remove removes the ports document from state.
 func (p *Ports) Remove() error {
 	ports := &Ports{st: p.st, doc: p.doc}
@@ -505,17 +546,10 @@ func removePortsForUnitOps(st *State, unit *Unit) ([]txn.Op, error) {
 	}
 	var ops []txn.Op
 	for _, ports := range allPorts {
-		allRanges := ports.AllPortRanges()
 		var keepPorts []PortRange
-		for portRange, unitName := range allRanges {
-			if unitName != unit.Name() {
-				unitRange := PortRange{
-					UnitName: unitName,
-					FromPort: portRange.FromPort,
-					ToPort:   portRange.ToPort,
-					Protocol: portRange.Protocol,
-				}
-				keepPorts = append(keepPorts, unitRange)
+		for _, portRange := range ports.doc.Ports {
+			if portRange.UnitName != unit.Name() {
+				keepPorts = append(keepPorts, portRange)
 			}
 		}
 		if len(keepPorts) > 0 {
@@ -15,6 +15,8 @@ import (
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/leadership"
 )
 
 // relationKey returns a string describing the relation defined by
@@ -327,6 +329,117 @@ func (r *Relation) RelatedEndpoints(applicationname string) ([]Endpoint, error)
 	return eps, nil
 }
 
+// relationApplicationSettingsKey returns the settings key of the
+// application-level settings of the named application in the relation
+// with the given id. It shares the "r#<id>#" prefix of the relation's
+// unit settings, so it is removed along with them.
+func relationApplicationSettingsKey(id int, applicationName string) string {
+	return fmt.Sprintf("r#%d#%s", id, applicationName)
+}
+
+// ApplicationSettings returns the application-level settings of the
+// named application in the relation. The application must be a member
+// of the relation.
+func (r *Relation) ApplicationSettings(applicationName string) (map[string]interface{}, error) {
+	if _, err := r.Endpoint(applicationName); err != nil {
+		return nil, errors.Trace(err)
+	}
+	key := relationApplicationSettingsKey(r.doc.Id, applicationName)
+	doc, err := readSettingsDoc(r.st, settingsC, key)
+	if errors.IsNotFound(err) {
+		// Relations added before application settings existed
+		// have none until the leader first writes some.
+		return map[string]interface{}{}, nil
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot read settings for application %q in relation %q", applicationName, r)
+	}
+	return copyMap(doc.Settings, unescapeReplacer.Replace), nil
+}
+
+// UpdateApplicationSettings updates the application-level settings of
+// the named application in the relation with the supplied values, but
+// will fail if the supplied Token, which should represent the
+// leadership of the application, loses validity. Empty values in the
+// supplied map are cleared in the database.
+func (r *Relation) UpdateApplicationSettings(applicationName string, token leadership.Token, updates map[string]string) error {
+	if _, err := r.Endpoint(applicationName); err != nil {
+		return errors.Trace(err)
+	}
+	key := relationApplicationSettingsKey(r.doc.Id, applicationName)
+	sets := bson.M{}
+	unsets := bson.M{}
+	for unescapedKey, value := range updates {
+		key := escapeReplacer.Replace(unescapedKey)
+		if value == "" {
+			unsets[key] = 1
+		} else {
+			sets[key] = value
+		}
+	}
+
+	buildTxn := func(_ int) ([]txn.Op, error) {
+		if err := r.Refresh(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if r.doc.Life != Alive {
+			return nil, errors.Errorf("relation is no longer alive")
+		}
+		aliveOp := txn.Op{
+			C:      relationsC,
+			Id:     r.doc.DocID,
+			Assert: isAliveDoc,
+		}
+		doc, err := readSettingsDoc(r.st, settingsC, key)
+		if errors.IsNotFound(err) {
+			if len(sets) == 0 {
+				return nil, jujutxn.ErrNoOperations
+			}
+			settings := make(map[string]interface{})
+			for k, v := range sets {
+				settings[k] = v
+			}
+			return []txn.Op{aliveOp, {
+				C:      settingsC,
+				Id:     key,
+				Assert: txn.DocMissing,
+				Insert: &settingsDoc{
+					Settings: settings,
+					Version:  1,
+				},
+			}}, nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if isNullSettingsChange(doc.Settings, sets, unsets) {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{aliveOp, {
+			C:      settingsC,
+			Id:     key,
+			Assert: bson.D{{"version", doc.Version}},
+			Update: setUnsetUpdateSettings(sets, unsets),
+		}}, nil
+	}
+	err := r.st.run(buildTxnWithLeadership(buildTxn, token))
+	return errors.Annotatef(err, "cannot update settings for application %q in relation %q", applicationName, r)
+}
+
+// isNullSettingsChange reports whether applying the supplied sets and
+// unsets to the raw settings map would leave it unchanged.
+func isNullSettingsChange(rawMap map[string]interface{}, sets, unsets bson.M) bool {
+	for key := range unsets {
+		if _, found := rawMap[key]; found {
+			return false
+		}
+	}
+	for key, value := range sets {
+		if current := rawMap[key]; current != value {
+			return false
+		}
+	}
+	return true
+}
+
 // Unit returns a RelationUnit for the supplied unit.
 func (r *Relation) Unit(u *Unit) (*RelationUnit, error) {
 	const checkUnitLife = true
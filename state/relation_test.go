@@ -150,6 +150,70 @@ func (s *RelationSuite) TestAddRelation(c *gc.C) {
 	assertOneRelation(c, wordpress, 0, wordpressEP, mysqlEP)
 }
 
+func (s *RelationSuite) addWordpressMySQLRelation(c *gc.C) *state.Relation {
+	wordpress := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	wordpressEP, err := wordpress.Endpoint("db")
+	c.Assert(err, jc.ErrorIsNil)
+	mysql := s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	mysqlEP, err := mysql.Endpoint("server")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(wordpressEP, mysqlEP)
+	c.Assert(err, jc.ErrorIsNil)
+	return rel
+}
+
+func (s *RelationSuite) TestApplicationSettings(c *gc.C) {
+	rel := s.addWordpressMySQLRelation(c)
+	settings, err := rel.ApplicationSettings("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, gc.HasLen, 0)
+
+	err = rel.UpdateApplicationSettings("mysql", &fakeToken{}, map[string]string{
+		"host":    "10.0.0.1",
+		"db.name": "wordpress",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	settings, err = rel.ApplicationSettings("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, jc.DeepEquals, map[string]interface{}{
+		"host":    "10.0.0.1",
+		"db.name": "wordpress",
+	})
+
+	// Empty values are cleared.
+	err = rel.UpdateApplicationSettings("mysql", &fakeToken{}, map[string]string{
+		"host": "",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	settings, err = rel.ApplicationSettings("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, jc.DeepEquals, map[string]interface{}{
+		"db.name": "wordpress",
+	})
+
+	// The other application's settings are untouched.
+	settings, err = rel.ApplicationSettings("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, gc.HasLen, 0)
+}
+
+func (s *RelationSuite) TestApplicationSettingsNotMember(c *gc.C) {
+	rel := s.addWordpressMySQLRelation(c)
+	_, err := rel.ApplicationSettings("riak")
+	c.Assert(err, gc.ErrorMatches, `application "riak" is not a member of "wordpress:db mysql:server"`)
+	err = rel.UpdateApplicationSettings("riak", &fakeToken{}, map[string]string{"foo": "bar"})
+	c.Assert(err, gc.ErrorMatches, `application "riak" is not a member of "wordpress:db mysql:server"`)
+}
+
+func (s *RelationSuite) TestUpdateApplicationSettingsNotLeader(c *gc.C) {
+	rel := s.addWordpressMySQLRelation(c)
+	err := rel.UpdateApplicationSettings("mysql", &failToken{}, map[string]string{"foo": "bar"})
+	c.Assert(err, gc.ErrorMatches, `cannot update settings for application "mysql" in relation "wordpress:db mysql:server": prerequisites failed: something bad happened`)
+	settings, err := rel.ApplicationSettings("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, gc.HasLen, 0)
+}
+
 func (s *RelationSuite) TestAddRelationSeriesNeedNotMatch(c *gc.C) {
 	wordpress := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
 	wordpressEP, err := wordpress.Endpoint("db")
@@ -14,6 +14,7 @@ import (
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/testing"
@@ -839,6 +840,40 @@ func newPeerRelation(c *gc.C, st *state.State) *PeerRelation {
 	return pr
 }
 
+func (s *RelationUnitSuite) TestWatchApplicationSettings(c *gc.C) {
+	prr := newProReqRelation(c, &s.ConnSuite, charm.ScopeGlobal)
+	w := prr.rru0.Watch()
+	defer testing.AssertStop(c, w)
+
+	nextChange := func() (change params.RelationUnitsChange) {
+		s.State.StartSync()
+		select {
+		case ch, ok := <-w.Changes():
+			c.Assert(ok, jc.IsTrue)
+			change = ch
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("watcher did not send change")
+		}
+		return change
+	}
+
+	// The initial event holds the counterpart application's settings
+	// version, but not the watching unit's own application's.
+	change := nextChange()
+	c.Assert(change.AppChanged, jc.DeepEquals, map[string]int64{"mysql": 0})
+
+	err := prr.rel.UpdateApplicationSettings("mysql", &fakeToken{}, map[string]string{"foo": "bar"})
+	c.Assert(err, jc.ErrorIsNil)
+	change = nextChange()
+	c.Assert(change.Changed, gc.HasLen, 0)
+	c.Assert(change.AppChanged, jc.DeepEquals, map[string]int64{"mysql": 1})
+
+	err = prr.rel.UpdateApplicationSettings("wordpress", &fakeToken{}, map[string]string{"foo": "bar"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc := testing.NewRelationUnitsWatcherC(c, s.State, w)
+	wc.AssertNoChange()
+}
+
 type ProReqRelation struct {
 	rel                    *state.Relation
 	psvc, rsvc             *state.Application
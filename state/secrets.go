@@ -0,0 +1,450 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"io"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+var (
+	validSecretName = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+	validSecretKey  = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+)
+
+// IsValidSecretName reports whether name is a valid secret name.
+func IsValidSecretName(name string) bool {
+	return validSecretName.MatchString(name)
+}
+
+// validateSecretValues returns an error if the supplied secret values
+// are empty or contain an invalid key.
+func validateSecretValues(values map[string]string) error {
+	if len(values) == 0 {
+		return errors.NotValidf("empty secret values")
+	}
+	for key := range values {
+		if !validSecretKey.MatchString(key) {
+			return errors.NotValidf("secret key %q", key)
+		}
+	}
+	return nil
+}
+
+// SecretArgs contains the parameters for adding a secret to a model.
+type SecretArgs struct {
+	// Name uniquely identifies the secret within the model.
+	Name string
+
+	// Description describes the purpose of the secret.
+	Description string
+
+	// Values holds the key/value pairs making up the secret.
+	Values map[string]string
+}
+
+// Validate returns an error if the arguments are not valid.
+func (args SecretArgs) Validate() error {
+	if !IsValidSecretName(args.Name) {
+		return errors.NotValidf("secret name %q", args.Name)
+	}
+	return validateSecretValues(args.Values)
+}
+
+// secretDoc records a secret and the applications that have been
+// granted access to it. The secret's values are encrypted with the
+// controller's secrets key.
+type secretDoc struct {
+	DocId        string    `bson:"_id"`
+	Name         string    `bson:"name"`
+	ModelUUID    string    `bson:"model-uuid"`
+	Description  string    `bson:"description,omitempty"`
+	Revision     int       `bson:"revision"`
+	Data         []byte    `bson:"data"`
+	Applications []string  `bson:"applications"`
+	Created      time.Time `bson:"created"`
+	Updated      time.Time `bson:"updated"`
+}
+
+// Secret represents a set of key/value pairs, such as passwords or
+// keys, that may be handed to the units of selected applications.
+type Secret struct {
+	st  *State
+	doc secretDoc
+}
+
+// Name returns the name of the secret.
+func (s *Secret) Name() string {
+	return s.doc.Name
+}
+
+// Description returns the description of the secret.
+func (s *Secret) Description() string {
+	return s.doc.Description
+}
+
+// Revision returns the revision of the secret's values. It starts at 1
+// and increases each time the secret is rotated.
+func (s *Secret) Revision() int {
+	return s.doc.Revision
+}
+
+// Applications returns the names of the applications that have been
+// granted access to the secret, in sorted order.
+func (s *Secret) Applications() []string {
+	result := append([]string(nil), s.doc.Applications...)
+	sort.Strings(result)
+	return result
+}
+
+// IsGranted reports whether the named application has been granted
+// access to the secret.
+func (s *Secret) IsGranted(application string) bool {
+	for _, name := range s.doc.Applications {
+		if name == application {
+			return true
+		}
+	}
+	return false
+}
+
+// Created returns the time the secret was added.
+func (s *Secret) Created() time.Time {
+	return s.doc.Created
+}
+
+// Updated returns the time the secret was last rotated, or added if it
+// has never been rotated.
+func (s *Secret) Updated() time.Time {
+	return s.doc.Updated
+}
+
+// Values returns the decrypted key/value pairs making up the secret.
+func (s *Secret) Values() (map[string]string, error) {
+	values, err := decryptSecretValues(s.st.secretsKey, s.doc.Data)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot read secret %q", s.doc.Name)
+	}
+	return values, nil
+}
+
+// Refresh refreshes the contents of the secret from the underlying
+// state.
+func (s *Secret) Refresh() error {
+	secret, err := s.st.Secret(s.doc.Name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	s.doc = secret.doc
+	return nil
+}
+
+// Rotate replaces the values of the secret and increments its
+// revision, so that the units of the applications granted access to
+// it are notified of the change.
+func (s *Secret) Rotate(values map[string]string) error {
+	if err := validateSecretValues(values); err != nil {
+		return errors.Annotatef(err, "cannot rotate secret %q", s.doc.Name)
+	}
+	data, err := encryptSecretValues(s.st.secretsKey, values)
+	if err != nil {
+		return errors.Annotatef(err, "cannot rotate secret %q", s.doc.Name)
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := s.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		return []txn.Op{{
+			C:      secretsC,
+			Id:     s.doc.DocId,
+			Assert: bson.D{{"revision", s.doc.Revision}},
+			Update: bson.D{{"$set", bson.D{
+				{"revision", s.doc.Revision + 1},
+				{"data", data},
+				{"updated", s.st.NowToTheSecond()},
+			}}},
+		}}, nil
+	}
+	if err := s.st.run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot rotate secret %q", s.doc.Name)
+	}
+	return s.Refresh()
+}
+
+// Grant gives the units of the named application access to the secret.
+func (s *Secret) Grant(application string) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := s.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if s.IsGranted(application) {
+			return nil, jujutxn.ErrNoOperations
+		}
+		if alive, err := isAlive(s.st, applicationsC, application); err != nil {
+			return nil, errors.Trace(err)
+		} else if !alive {
+			return nil, errors.Errorf("application %q not found or not alive", application)
+		}
+		return []txn.Op{{
+			C:      applicationsC,
+			Id:     application,
+			Assert: isAliveDoc,
+		}, {
+			C:      secretsC,
+			Id:     s.doc.DocId,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$addToSet", bson.D{{"applications", application}}}},
+		}}, nil
+	}
+	if err := s.st.run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot grant secret %q to application %q", s.doc.Name, application)
+	}
+	return s.Refresh()
+}
+
+// Revoke removes the named application's access to the secret.
+func (s *Secret) Revoke(application string) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := s.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if !s.IsGranted(application) {
+			return nil, errors.NotFoundf("grant to application %q", application)
+		}
+		return []txn.Op{{
+			C:      secretsC,
+			Id:     s.doc.DocId,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$pull", bson.D{{"applications", application}}}},
+		}}, nil
+	}
+	if err := s.st.run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot revoke secret %q from application %q", s.doc.Name, application)
+	}
+	return s.Refresh()
+}
+
+// AddSecret adds a new secret to the model. No application has access
+// to it until it is granted.
+func (st *State) AddSecret(args SecretArgs) (*Secret, error) {
+	if err := args.Validate(); err != nil {
+		return nil, errors.Annotate(err, "cannot add secret")
+	}
+	data, err := encryptSecretValues(st.secretsKey, args.Values)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot add secret")
+	}
+	now := st.NowToTheSecond()
+	doc := secretDoc{
+		DocId:        st.docID(args.Name),
+		Name:         args.Name,
+		ModelUUID:    st.ModelUUID(),
+		Description:  args.Description,
+		Revision:     1,
+		Data:         data,
+		Applications: []string{},
+		Created:      now,
+		Updated:      now,
+	}
+	ops := []txn.Op{{
+		C:      secretsC,
+		Id:     doc.DocId,
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	}}
+	if err := st.runTransaction(ops); err == txn.ErrAborted {
+		return nil, errors.AlreadyExistsf("secret %q", args.Name)
+	} else if err != nil {
+		return nil, errors.Annotate(err, "cannot add secret")
+	}
+	return &Secret{st: st, doc: doc}, nil
+}
+
+// Secret returns the secret with the given name.
+func (st *State) Secret(name string) (*Secret, error) {
+	secrets, closer := st.getCollection(secretsC)
+	defer closer()
+
+	var doc secretDoc
+	err := secrets.FindId(name).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("secret %q", name)
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get secret %q", name)
+	}
+	return &Secret{st: st, doc: doc}, nil
+}
+
+// AllSecrets returns all secrets in the model, ordered by name.
+func (st *State) AllSecrets() ([]*Secret, error) {
+	return st.findSecrets(nil)
+}
+
+// SecretsGrantedTo returns the secrets that the named application has
+// been granted access to, ordered by name.
+func (st *State) SecretsGrantedTo(application string) ([]*Secret, error) {
+	return st.findSecrets(bson.D{{"applications", application}})
+}
+
+func (st *State) findSecrets(query bson.D) ([]*Secret, error) {
+	secrets, closer := st.getCollection(secretsC)
+	defer closer()
+
+	var docs []secretDoc
+	if err := secrets.Find(query).Sort("name").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get secrets")
+	}
+	result := make([]*Secret, len(docs))
+	for i, doc := range docs {
+		result[i] = &Secret{st: st, doc: doc}
+	}
+	return result, nil
+}
+
+// RemoveSecret removes the secret with the given name.
+func (st *State) RemoveSecret(name string) error {
+	ops := []txn.Op{{
+		C:      secretsC,
+		Id:     st.docID(name),
+		Assert: txn.DocExists,
+		Remove: true,
+	}}
+	if err := st.runTransaction(ops); err == txn.ErrAborted {
+		return errors.NotFoundf("secret %q", name)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot remove secret %q", name)
+	}
+	return nil
+}
+
+// WatchSecrets returns a NotifyWatcher that notifies when secrets in
+// the model are added, rotated, granted, revoked or removed.
+func (st *State) WatchSecrets() NotifyWatcher {
+	return newNotifyCollWatcher(st, secretsC, isLocalID(st))
+}
+
+// removeSecretGrantsOps returns the operations required to revoke the
+// named application's access to all secrets.
+func removeSecretGrantsOps(st *State, application string) ([]txn.Op, error) {
+	secrets, err := st.SecretsGrantedTo(application)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops := make([]txn.Op, len(secrets))
+	for i, secret := range secrets {
+		ops[i] = txn.Op{
+			C:      secretsC,
+			Id:     secret.doc.DocId,
+			Update: bson.D{{"$pull", bson.D{{"applications", application}}}},
+		}
+	}
+	return ops, nil
+}
+
+// secretsKeySize is the size, in bytes, of the AES-256 key used to
+// encrypt the values of secrets.
+const secretsKeySize = 32
+
+// GenerateSecretsKey generates a new key for encrypting the values of
+// secrets. The key is held in the controller agents' configuration and
+// passed to Open; it is never written to the database.
+func GenerateSecretsKey() ([]byte, error) {
+	key := make([]byte, secretsKeySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, errors.Annotate(err, "cannot generate secrets key")
+	}
+	return key, nil
+}
+
+// validateSecretsKey checks that the key, if supplied, is of the size
+// required for AES-256.
+func validateSecretsKey(key []byte) error {
+	if len(key) != 0 && len(key) != secretsKeySize {
+		return errors.NotValidf("SecretsKey of %d bytes", len(key))
+	}
+	return nil
+}
+
+// SecretsKey returns the key used to encrypt the values of secrets, so
+// that it may be handed on to new controller agents.
+func (st *State) SecretsKey() []byte {
+	return st.secretsKey
+}
+
+// encryptSecretValues encrypts the supplied values with AES-GCM. The
+// result holds the nonce followed by the sealed values.
+func encryptSecretValues(key []byte, values map[string]string) ([]byte, error) {
+	plaintext, err := json.Marshal(values)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	gcm, err := newSecretsCipher(key)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// decryptSecretValues reverses encryptSecretValues.
+func decryptSecretValues(key, data []byte) (map[string]string, error) {
+	gcm, err := newSecretsCipher(key)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(data) < gcm.NonceSize() {
+		return nil, errors.New("encrypted secret too short")
+	}
+	nonce, sealed := data[:gcm.NonceSize()], data[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var values map[string]string
+	if err := json.Unmarshal(plaintext, &values); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return values, nil
+}
+
+// errSecretsKeyNotConfigured is returned when secrets are used on a
+// controller without a secrets key. Controllers bootstrapped before
+// secrets were supported have none until one is added by hand; see
+// doc/secrets-key.md.
+var errSecretsKeyNotConfigured = errors.New("secrets key not configured; " +
+	`add a key generated with "openssl rand -base64 32" as secretskey ` +
+	"in the agent.conf of every controller machine, then restart their agents")
+
+func newSecretsCipher(key []byte) (cipher.AEAD, error) {
+	if len(key) == 0 {
+		return nil, errSecretsKeyNotConfigured
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return cipher.NewGCM(block)
+}
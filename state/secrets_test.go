@@ -0,0 +1,238 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"bytes"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/clock"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/mongo/mongotest"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type SecretsSuite struct {
+	ConnSuite
+	application *state.Application
+}
+
+var _ = gc.Suite(&SecretsSuite{})
+
+func (s *SecretsSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	ch := s.AddTestingCharm(c, "dummy")
+	s.application = s.AddTestingService(c, "dummy", ch)
+}
+
+func (s *SecretsSuite) addSecret(c *gc.C) *state.Secret {
+	secret, err := s.State.AddSecret(state.SecretArgs{
+		Name:        "db-password",
+		Description: "database credentials",
+		Values:      map[string]string{"username": "admin", "password": "sekrit"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return secret
+}
+
+func (s *SecretsSuite) TestAddSecret(c *gc.C) {
+	secret := s.addSecret(c)
+	c.Check(secret.Name(), gc.Equals, "db-password")
+	c.Check(secret.Description(), gc.Equals, "database credentials")
+	c.Check(secret.Revision(), gc.Equals, 1)
+	c.Check(secret.Applications(), gc.HasLen, 0)
+	c.Check(secret.Created().IsZero(), jc.IsFalse)
+
+	secret, err := s.State.Secret("db-password")
+	c.Assert(err, jc.ErrorIsNil)
+	values, err := secret.Values()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(values, jc.DeepEquals, map[string]string{"username": "admin", "password": "sekrit"})
+}
+
+func (s *SecretsSuite) TestAddSecretAlreadyExists(c *gc.C) {
+	s.addSecret(c)
+	_, err := s.State.AddSecret(state.SecretArgs{
+		Name:   "db-password",
+		Values: map[string]string{"password": "other"},
+	})
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *SecretsSuite) TestAddSecretInvalid(c *gc.C) {
+	_, err := s.State.AddSecret(state.SecretArgs{
+		Name:   "DB password",
+		Values: map[string]string{"password": "sekrit"},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot add secret: secret name "DB password" not valid`)
+
+	_, err = s.State.AddSecret(state.SecretArgs{Name: "db-password"})
+	c.Assert(err, gc.ErrorMatches, `cannot add secret: empty secret values not valid`)
+
+	_, err = s.State.AddSecret(state.SecretArgs{
+		Name:   "db-password",
+		Values: map[string]string{"-password": "sekrit"},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot add secret: secret key "-password" not valid`)
+}
+
+func (s *SecretsSuite) TestSecretEncryptedAtRest(c *gc.C) {
+	s.addSecret(c)
+	coll := s.MgoSuite.Session.DB("juju").C("secrets")
+	var doc bson.M
+	err := coll.Find(bson.D{{"name", "db-password"}}).One(&doc)
+	c.Assert(err, jc.ErrorIsNil)
+	data, ok := doc["data"].([]byte)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(bytes.Contains(data, []byte("sekrit")), jc.IsFalse)
+}
+
+func (s *SecretsSuite) TestRotate(c *gc.C) {
+	secret := s.addSecret(c)
+	err := secret.Rotate(map[string]string{"username": "admin", "password": "new-sekrit"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(secret.Revision(), gc.Equals, 2)
+
+	secret, err = s.State.Secret("db-password")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(secret.Revision(), gc.Equals, 2)
+	values, err := secret.Values()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(values, jc.DeepEquals, map[string]string{"username": "admin", "password": "new-sekrit"})
+}
+
+func (s *SecretsSuite) TestGrantRevoke(c *gc.C) {
+	secret := s.addSecret(c)
+	err := secret.Grant("dummy")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(secret.Applications(), jc.DeepEquals, []string{"dummy"})
+	c.Assert(secret.IsGranted("dummy"), jc.IsTrue)
+
+	// Granting again is a no-op.
+	err = secret.Grant("dummy")
+	c.Assert(err, jc.ErrorIsNil)
+
+	granted, err := s.State.SecretsGrantedTo("dummy")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(granted, gc.HasLen, 1)
+	c.Assert(granted[0].Name(), gc.Equals, "db-password")
+
+	err = secret.Revoke("dummy")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(secret.Applications(), gc.HasLen, 0)
+
+	err = secret.Revoke("dummy")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	granted, err = s.State.SecretsGrantedTo("dummy")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(granted, gc.HasLen, 0)
+}
+
+func (s *SecretsSuite) TestGrantUnknownApplication(c *gc.C) {
+	secret := s.addSecret(c)
+	err := secret.Grant("wordpress")
+	c.Assert(err, gc.ErrorMatches, `cannot grant secret "db-password" to application "wordpress": application "wordpress" not found or not alive`)
+}
+
+func (s *SecretsSuite) TestRemoveApplicationRevokesGrants(c *gc.C) {
+	secret := s.addSecret(c)
+	err := secret.Grant("dummy")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.application.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = secret.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(secret.Applications(), gc.HasLen, 0)
+}
+
+func (s *SecretsSuite) TestAllSecretsAndRemove(c *gc.C) {
+	s.addSecret(c)
+	_, err := s.State.AddSecret(state.SecretArgs{
+		Name:   "api-token",
+		Values: map[string]string{"token": "abc"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	secrets, err := s.State.AllSecrets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(secrets, gc.HasLen, 2)
+	c.Assert(secrets[0].Name(), gc.Equals, "api-token")
+	c.Assert(secrets[1].Name(), gc.Equals, "db-password")
+
+	err = s.State.RemoveSecret("api-token")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.Secret("api-token")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	err = s.State.RemoveSecret("api-token")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *SecretsSuite) TestWatchSecrets(c *gc.C) {
+	w := s.State.WatchSecrets()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	secret := s.addSecret(c)
+	wc.AssertOneChange()
+
+	err := secret.Rotate(map[string]string{"password": "new-sekrit"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = secret.Grant("dummy")
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
+func (s *SecretsSuite) openStateWithSecretsKey(c *gc.C, key []byte) (*state.State, error) {
+	return state.Open(state.OpenParams{
+		Clock:              clock.WallClock,
+		ControllerTag:      s.State.ControllerTag(),
+		ControllerModelTag: s.modelTag,
+		MongoInfo:          statetesting.NewMongoInfo(),
+		MongoDialOpts:      mongotest.DialOpts(),
+		SecretsKey:         key,
+	})
+}
+
+func (s *SecretsSuite) TestSecretsKeyNotConfigured(c *gc.C) {
+	s.addSecret(c)
+	st, err := s.openStateWithSecretsKey(c, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	defer st.Close()
+
+	_, err = st.AddSecret(state.SecretArgs{
+		Name:   "other",
+		Values: map[string]string{"password": "sekrit"},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot add secret: secrets key not configured; add a key generated with .*`)
+
+	secret, err := st.Secret("db-password")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = secret.Values()
+	c.Assert(err, gc.ErrorMatches, `cannot read secret "db-password": secrets key not configured; .*`)
+}
+
+func (s *SecretsSuite) TestSecretsKeyMismatch(c *gc.C) {
+	s.addSecret(c)
+	st, err := s.openStateWithSecretsKey(c, bytes.Repeat([]byte("x"), 32))
+	c.Assert(err, jc.ErrorIsNil)
+	defer st.Close()
+
+	secret, err := st.Secret("db-password")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = secret.Values()
+	c.Assert(err, gc.ErrorMatches, `cannot read secret "db-password": .*message authentication failed`)
+}
+
+func (s *SecretsSuite) TestOpenInvalidSecretsKey(c *gc.C) {
+	_, err := s.openStateWithSecretsKey(c, []byte("short"))
+	c.Assert(err, gc.ErrorMatches, `validating args: SecretsKey of 5 bytes not valid`)
+}
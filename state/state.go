@@ -88,6 +88,10 @@ type State struct {
 	newPolicy              NewPolicyFunc
 	runTransactionObserver RunTransactionObserverFunc
 
+	// secretsKey is the key used to encrypt the values of secrets.
+	// It is held in the controller agent's config, not the database.
+	secretsKey []byte
+
 	// cloudName is the name of the cloud on which the model
 	// represented by this state runs.
 	cloudName string
@@ -305,6 +309,7 @@ func (st *State) ForModel(modelTag names.ModelTag) (*State, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	newSt.secretsKey = st.secretsKey
 	if err := newSt.start(st.controllerTag); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -967,6 +972,11 @@ type AddApplicationArgs struct {
 	Placement        []*instance.Placement
 	Constraints      constraints.Value
 	Resources        map[string]string
+
+	// AttachStorage contains the tags of existing, detached
+	// storage instances to attach to the application's first
+	// unit. If AttachStorage is non-empty, NumUnits must be 1.
+	AttachStorage []names.StorageTag
 }
 
 // AddApplication creates a new application, running the supplied charm, with the
@@ -994,6 +1004,9 @@ func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err er
 	if err := checkModelActive(st); err != nil {
 		return nil, errors.Trace(err)
 	}
+	if len(args.AttachStorage) > 0 && args.NumUnits != 1 {
+		return nil, errors.Errorf("AttachStorage is non-empty, but NumUnits is %d", args.NumUnits)
+	}
 	if args.Storage == nil {
 		args.Storage = make(map[string]StorageConstraints)
 	}
@@ -1197,7 +1210,18 @@ func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err er
 
 		// Collect unit-adding operations.
 		for x := 0; x < args.NumUnits; x++ {
-			unitName, unitOps, err := app.addApplicationUnitOps(applicationAddUnitOpsArgs{cons: args.Constraints, storageCons: args.Storage})
+			unitArgs := applicationAddUnitOpsArgs{cons: args.Constraints, storageCons: args.Storage}
+			if x == 0 {
+				// Existing storage is attached to the first unit only.
+				for _, tag := range args.AttachStorage {
+					si, err := st.storageInstance(tag)
+					if err != nil {
+						return nil, errors.Annotatef(err, "attaching %s", names.ReadableString(tag))
+					}
+					unitArgs.attachStorage = append(unitArgs.attachStorage, si)
+				}
+			}
+			unitName, unitOps, err := app.addApplicationUnitOps(unitArgs)
 			if err != nil {
 				return nil, errors.Trace(err)
 			}
@@ -1724,6 +1748,13 @@ func (st *State) AddRelation(eps ...Endpoint) (r *Relation, err error) {
 			Assert: txn.DocMissing,
 			Insert: doc,
 		})
+		for _, ep := range eps {
+			ops = append(ops, createSettingsOp(
+				settingsC,
+				relationApplicationSettingsKey(id, ep.ApplicationName),
+				map[string]interface{}{},
+			))
+		}
 		return ops, nil
 	}
 	if err = st.run(buildTxn); err == nil {
@@ -2132,6 +2163,22 @@ func (st *State) PutAuditEntryFn() func(audit.AuditEntry) error {
 	return stateaudit.PutAuditEntryFn(auditingC, insert)
 }
 
+// GetAuditEntriesFn returns a function which will retrieve the
+// persisted audit.AuditEntry instances matching an audit.Query.
+func (st *State) GetAuditEntriesFn() func(audit.Query) ([]audit.AuditEntry, error) {
+	find := func(collectionName string, selector bson.D, sort string, limit int, docs interface{}) error {
+		collection, closeCollection := st.getCollection(collectionName)
+		defer closeCollection()
+
+		query := collection.Find(selector).Sort(sort)
+		if limit > 0 {
+			query = query.Limit(limit)
+		}
+		return errors.Trace(query.All(docs))
+	}
+	return stateaudit.GetAuditEntriesFn(auditingC, find)
+}
+
 var tagPrefix = map[byte]string{
 	'm': names.MachineTagKind + "-",
 	'a': names.ApplicationTagKind + "-",
@@ -13,6 +13,7 @@ import (
 	"gopkg.in/mgo.v2/txn"
 
 	"github.com/juju/juju/core/leadership"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/mongo/utils"
 	"github.com/juju/juju/status"
@@ -204,14 +205,20 @@ func fetchNStatusResults(col mongo.Collection, key string,
 		query mongo.Query
 	)
 	baseQuery := bson.M{"globalkey": key}
+	updated := bson.M{}
 	if filter.Delta != nil {
 		delta := *filter.Delta
 		// TODO(perrito666) 2016-10-06 lp:1558657
-		updated := time.Now().Add(-delta)
-		baseQuery["updated"] = bson.M{"$gt": updated.UnixNano()}
+		updated["$gt"] = time.Now().Add(-delta).UnixNano()
 	}
 	if filter.FromDate != nil {
-		baseQuery["updated"] = bson.M{"$gt": filter.FromDate.UnixNano()}
+		updated["$gt"] = filter.FromDate.UnixNano()
+	}
+	if filter.ToDate != nil {
+		updated["$lt"] = filter.ToDate.UnixNano()
+	}
+	if len(updated) > 0 {
+		baseQuery["updated"] = updated
 	}
 	excludes := []string{}
 	excludes = append(excludes, filter.Exclude.Values()...)
@@ -262,8 +269,23 @@ func statusHistory(args *statusHistoryArgs) ([]status.StatusInfo, error) {
 // PruneStatusHistory removes status history entries until
 // only logs newer than <maxLogTime> remain and also ensures
 // that the collection is smaller than <maxLogsMB> after the
-// deletion.
+// deletion. Entries belonging to models which set their own
+// max-status-history-size are not removed to meet the size
+// limit; see PruneModelStatusHistory.
 func PruneStatusHistory(st *State, maxHistoryTime time.Duration, maxHistoryMB int) error {
+	return pruneStatusHistory(st, maxHistoryTime, maxHistoryMB, false)
+}
+
+// PruneModelStatusHistory removes the model's status history entries
+// until only logs newer than <maxHistoryTime> remain and also ensures
+// that the model's share of the collection is smaller than
+// <maxHistoryMB> after the deletion. Entries belonging to other models
+// are never removed.
+func PruneModelStatusHistory(st *State, maxHistoryTime time.Duration, maxHistoryMB int) error {
+	return pruneStatusHistory(st, maxHistoryTime, maxHistoryMB, true)
+}
+
+func pruneStatusHistory(st *State, maxHistoryTime time.Duration, maxHistoryMB int, modelOnly bool) error {
 	if maxHistoryMB < 0 {
 		return errors.NotValidf("non-positive maxHistoryMB")
 	}
@@ -299,11 +321,10 @@ func PruneStatusHistory(st *State, maxHistoryTime time.Duration, maxHistoryMB in
 	if err != nil {
 		return errors.Annotate(err, "retrieving status history collection size")
 	}
+	// The model's share can be no larger than the whole collection.
 	if collMB <= maxHistoryMB {
 		return nil
 	}
-	// TODO(perrito666) explore if there would be any beneffit from having the
-	// size limit be per model
 	count, err := history.Count()
 	if err == mgo.ErrNotFound || count <= 0 {
 		return nil
@@ -320,13 +341,65 @@ func PruneStatusHistory(st *State, maxHistoryTime time.Duration, maxHistoryMB in
 	if sizePerStatus == 0 {
 		return errors.New("unexpected result calculating status history entry size")
 	}
-	deleteStatuses := count - int(float64(collMB-maxHistoryMB)/sizePerStatus)
+	if !modelOnly {
+		// Models with their own size limit are pruned against it
+		// alone, so their entries are left out of the global pass.
+		excluded, err := modelsWithStatusHistorySize(st)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		candidates := bson.D{{"model-uuid", bson.M{"$nin": excluded}}}
+		candidateCount, err := history.Find(candidates).Count()
+		if err != nil {
+			return errors.Annotate(err, "counting status history records")
+		}
+		keepStatuses := candidateCount - int(float64(collMB-maxHistoryMB)/sizePerStatus)
+		if keepStatuses <= 0 {
+			_, err := history.RemoveAll(candidates)
+			return errors.Trace(err)
+		}
+		result := historicalStatusDoc{}
+		err = history.Find(candidates).Sort("-updated").Skip(keepStatuses).One(&result)
+		if err == mgo.ErrNotFound {
+			return nil
+		}
+		if err != nil {
+			return errors.Trace(err)
+		}
+		_, err = history.RemoveAll(bson.D{
+			{"model-uuid", bson.M{"$nin": excluded}},
+			{"updated", bson.M{"$lt": result.Updated}},
+		})
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return nil
+	}
+
+	modelHistory := bson.D{{"model-uuid", st.ModelUUID()}}
+	modelCount, err := history.Find(modelHistory).Count()
+	if err != nil {
+		return errors.Annotate(err, "counting model status history records")
+	}
+	modelMB := float64(modelCount) * sizePerStatus
+	if modelMB <= float64(maxHistoryMB) {
+		return nil
+	}
+	keepStatuses := modelCount - int((modelMB-float64(maxHistoryMB))/sizePerStatus)
+	if keepStatuses <= 0 {
+		_, err := history.RemoveAll(modelHistory)
+		return errors.Trace(err)
+	}
 	result := historicalStatusDoc{}
-	err = history.Find(nil).Sort("-updated").Skip(deleteStatuses).One(&result)
+	err = history.Find(modelHistory).Sort("-updated").Skip(keepStatuses).One(&result)
+	if err == mgo.ErrNotFound {
+		return nil
+	}
 	if err != nil {
 		return errors.Trace(err)
 	}
 	_, err = history.RemoveAll(bson.D{
+		{"model-uuid", st.ModelUUID()},
 		{"updated", bson.M{"$lt": result.Updated}},
 	})
 	if err != nil {
@@ -334,3 +407,26 @@ func PruneStatusHistory(st *State, maxHistoryTime time.Duration, maxHistoryMB in
 	}
 	return nil
 }
+
+// modelsWithStatusHistorySize returns the UUIDs of the models whose
+// config sets max-status-history-size.
+func modelsWithStatusHistorySize(st *State) ([]string, error) {
+	settings, closer := st.getRawCollection(settingsC)
+	defer closer()
+
+	var docs []struct {
+		ModelUUID string `bson:"model-uuid"`
+	}
+	err := settings.Find(bson.D{
+		{"_id", bson.RegEx{Pattern: "^[^:]+:" + modelGlobalKey + "$"}},
+		{"settings." + config.MaxStatusHistorySizeKey, bson.M{"$nin": []interface{}{nil, ""}}},
+	}).Select(bson.M{"model-uuid": 1}).All(&docs)
+	if err != nil {
+		return nil, errors.Annotate(err, "reading model status history sizes")
+	}
+	uuids := make([]string, len(docs))
+	for i, doc := range docs {
+		uuids[i] = doc.ModelUUID
+	}
+	return uuids, nil
+}
@@ -52,6 +52,85 @@ func (s *StatusHistorySuite) TestPruneStatusHistoryBySize(c *gc.C) {
 	c.Assert(historyLen, jc.LessThan, 10000)
 }
 
+func (s *StatusHistorySuite) TestPruneStatusHistoryBySizeSkipsModelsWithOwnSize(c *gc.C) {
+	clock := testing.NewClock(coretesting.NonZeroTime())
+	err := s.State.SetClockForTesting(clock)
+	c.Assert(err, jc.ErrorIsNil)
+	service := s.Factory.MakeApplication(c, nil)
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Application: service})
+	state.PrimeUnitStatusHistory(c, clock, unit, status.Active, 20000, 1000, nil)
+
+	otherSt := s.Factory.MakeModel(c, &factory.ModelParams{
+		ConfigAttrs: coretesting.Attrs{"max-status-history-size": "1G"},
+	})
+	defer otherSt.Close()
+	otherFactory := factory.NewFactory(otherSt)
+	otherUnit := otherFactory.MakeUnit(c, nil)
+	state.PrimeUnitStatusHistory(c, clock, otherUnit, status.Active, 10000, 1000, nil)
+
+	err = state.PruneStatusHistory(s.State, 0, 1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := unit.StatusHistory(status.StatusHistoryFilter{Size: 25000})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(len(history), jc.LessThan, 10000)
+
+	// The other model is pruned against its own limit only.
+	history, err = otherUnit.StatusHistory(status.StatusHistoryFilter{Size: 25000})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 10001)
+}
+
+func (s *StatusHistorySuite) TestPruneModelStatusHistoryBySizeOnlyAffectsModel(c *gc.C) {
+	clock := testing.NewClock(coretesting.NonZeroTime())
+	err := s.State.SetClockForTesting(clock)
+	c.Assert(err, jc.ErrorIsNil)
+	service := s.Factory.MakeApplication(c, nil)
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Application: service})
+	state.PrimeUnitStatusHistory(c, clock, unit, status.Active, 20000, 1000, nil)
+
+	otherSt := s.Factory.MakeModel(c, nil)
+	defer otherSt.Close()
+	otherFactory := factory.NewFactory(otherSt)
+	otherUnit := otherFactory.MakeUnit(c, nil)
+	state.PrimeUnitStatusHistory(c, clock, otherUnit, status.Active, 100, 1000, nil)
+
+	err = state.PruneModelStatusHistory(s.State, 0, 1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := unit.StatusHistory(status.StatusHistoryFilter{Size: 25000})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(len(history), jc.LessThan, 10000)
+
+	history, err = otherUnit.StatusHistory(status.StatusHistoryFilter{Size: 200})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 101)
+}
+
+func (s *StatusHistorySuite) TestPruneModelStatusHistoryBySizeUsesModelShare(c *gc.C) {
+	clock := testing.NewClock(coretesting.NonZeroTime())
+	err := s.State.SetClockForTesting(clock)
+	c.Assert(err, jc.ErrorIsNil)
+	service := s.Factory.MakeApplication(c, nil)
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Application: service})
+	state.PrimeUnitStatusHistory(c, clock, unit, status.Active, 100, 1000, nil)
+
+	otherSt := s.Factory.MakeModel(c, nil)
+	defer otherSt.Close()
+	otherFactory := factory.NewFactory(otherSt)
+	otherUnit := otherFactory.MakeUnit(c, nil)
+	state.PrimeUnitStatusHistory(c, clock, otherUnit, status.Active, 20000, 1000, nil)
+
+	// The collection is over the limit, but this model's share of it
+	// is not, so nothing is removed.
+	err = state.PruneModelStatusHistory(s.State, 0, 1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := unit.StatusHistory(status.StatusHistoryFilter{Size: 200})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 101)
+}
+
 func (s *StatusHistorySuite) TestPruneStatusHistoryByDate(c *gc.C) {
 
 	// NOTE: the behaviour is bad, and the test is ugly. I'm just verifying
@@ -265,4 +344,16 @@ func (s *StatusHistorySuite) TestStatusHistoryFiltersByDateAndDelta(c *gc.C) {
 	c.Assert(history[0].Message, gc.Equals, "current status")
 	c.Assert(history[1].Message, gc.Equals, "waiting for machine")
 	c.Assert(history[2].Message, gc.Equals, "2 days ago")
+
+	// Logs between three days ago and yesterday.
+	history, err = unit.StatusHistory(status.StatusHistoryFilter{FromDate: &threeDaysAgo, ToDate: &yesterday})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 1)
+	c.Assert(history[0].Message, gc.Equals, "2 days ago")
+
+	// The most recent log before yesterday.
+	history, err = unit.StatusHistory(status.StatusHistoryFilter{Size: 1, ToDate: &yesterday})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 1)
+	c.Assert(history[0].Message, gc.Equals, "2 days ago")
 }
@@ -5,6 +5,7 @@ package state
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/dustin/go-humanize"
 	"github.com/juju/errors"
@@ -540,6 +541,11 @@ type machineAssignable interface {
 // will be correlated with the charm storage metadata for validation
 // and supplementing.
 //
+// The supplied existing storage instances, which must be unowned and
+// detached, will be attached to the newly created unit and owned by it.
+// They count towards the storage constraints, so that fewer new storage
+// instances of the same name are created.
+//
 // maybeMachineAssignable may be nil, or an machineAssignable which
 // describes the entity's machine assignment. If the entity is assigned
 // to a machine, then machine storage will be created.
@@ -548,6 +554,7 @@ func createStorageOps(
 	entityTag names.Tag,
 	charmMeta *charm.Meta,
 	cons map[string]StorageConstraints,
+	attachStorage []*storageInstance,
 	series string,
 	maybeMachineAssignable machineAssignable,
 ) (ops []txn.Op, numStorageAttachments int, err error) {
@@ -556,12 +563,16 @@ func createStorageOps(
 		storageName string
 		meta        charm.Storage
 		cons        StorageConstraints
+		attach      []*storageInstance
 	}
 
 	createdShared := false
 	switch entityTag := entityTag.(type) {
 	case names.ApplicationTag:
 		createdShared = true
+		if len(attachStorage) > 0 {
+			return nil, -1, errors.New("cannot attach existing storage to an application")
+		}
 	case names.UnitTag:
 	default:
 		return nil, -1, errors.Errorf("expected application or unit tag, got %T", entityTag)
@@ -572,15 +583,39 @@ func createStorageOps(
 	for name := range cons {
 		storageNames.Add(name)
 	}
+	attachByName := make(map[string][]*storageInstance)
+	for _, s := range attachStorage {
+		storageNames.Add(s.StorageName())
+		attachByName[s.StorageName()] = append(attachByName[s.StorageName()], s)
+	}
 
-	templates := make([]template, 0, len(cons))
+	templates := make([]template, 0, len(storageNames))
 	for _, store := range storageNames.SortedValues() {
 		cons := cons[store]
+		attach := attachByName[store]
 		charmStorage, ok := charmMeta.Storage[store]
 		if !ok {
 			return nil, -1, errors.NotFoundf("charm storage %q", store)
 		}
-		if cons.Count == 0 {
+		if len(attach) > 0 {
+			if err := validateAttachStorage(charmStorage, attach); err != nil {
+				return nil, -1, errors.Trace(err)
+			}
+			// Existing storage counts towards the constraints;
+			// only create as many new instances as are needed
+			// to make up the difference.
+			total := cons.Count
+			if n := uint64(len(attach)); n >= total {
+				total = n
+				cons.Count = 0
+			} else {
+				cons.Count -= n
+			}
+			if err := validateCharmStorageCount(charmStorage, total); err != nil {
+				return nil, -1, errors.Annotatef(err, "attaching storage %q", store)
+			}
+		}
+		if cons.Count == 0 && len(attach) == 0 {
 			continue
 		}
 		if createdShared != charmStorage.Shared {
@@ -592,6 +627,7 @@ func createStorageOps(
 			storageName: store,
 			meta:        charmStorage,
 			cons:        cons,
+			attach:      attach,
 		})
 	}
 
@@ -612,12 +648,51 @@ func createStorageOps(
 		// instance we create. We'll use the reference counts to ensure
 		// we don't exceed limits when adding storage, and for
 		// maintaining model integrity during charm upgrades.
-		incRefOp, err := increfEntityStorageOp(st, entityTag, t.storageName, int(t.cons.Count))
+		incRefOp, err := increfEntityStorageOp(
+			st, entityTag, t.storageName, int(t.cons.Count)+len(t.attach),
+		)
 		if err != nil {
 			return nil, -1, errors.Trace(err)
 		}
 		ops = append(ops, incRefOp)
 
+		for _, s := range t.attach {
+			// The storage instance is taken over by the unit,
+			// which will be responsible for its lifecycle.
+			unitTag := entityTag.(names.UnitTag)
+			doc := s.doc
+			doc.Owner = owner
+			doc.AttachmentCount = 1
+			ops = append(ops, txn.Op{
+				C:  storageInstancesC,
+				Id: s.doc.Id,
+				Assert: append(bson.D{
+					{"owner", bson.D{{"$exists", false}}},
+					{"attachmentcount", 0},
+				}, isAliveDoc...),
+				Update: bson.D{
+					{"$set", bson.D{{"owner", owner}}},
+					{"$inc", bson.D{{"attachmentcount", 1}}},
+				},
+			})
+			ops = append(ops, createStorageAttachmentOp(s.StorageTag(), unitTag))
+			numStorageAttachments++
+
+			if maybeMachineAssignable != nil {
+				machineOps, err := unitAssignedMachineStorageOps(
+					st, unitTag, charmMeta, cons, series,
+					&storageInstance{st, doc},
+					maybeMachineAssignable,
+				)
+				if err != nil {
+					return nil, -1, errors.Annotatef(
+						err, "attaching machine storage for storage %s", s.doc.Id,
+					)
+				}
+				ops = append(ops, machineOps...)
+			}
+		}
+
 		for i := uint64(0); i < t.cons.Count; i++ {
 			id, err := newStorageInstanceId(st, t.storageName)
 			if err != nil {
@@ -670,6 +745,42 @@ func createStorageOps(
 	return ops, numStorageAttachments, nil
 }
 
+// validateAttachStorage validates that the given existing storage
+// instances may be attached to a new unit with the given charm storage.
+func validateAttachStorage(charmStorage charm.Storage, attach []*storageInstance) error {
+	for _, s := range attach {
+		var kind StorageKind
+		switch charmStorage.Type {
+		case charm.StorageBlock:
+			kind = StorageKindBlock
+		case charm.StorageFilesystem:
+			kind = StorageKindFilesystem
+		}
+		if s.Kind() != kind {
+			return errors.Errorf(
+				"cannot attach %s: storage kind %q does not match charm storage type %q",
+				names.ReadableString(s.Tag()), s.Kind(), charmStorage.Type,
+			)
+		}
+		if charmStorage.Shared {
+			return errors.NotSupportedf("attaching existing storage to shared charm storage %q", charmStorage.Name)
+		}
+		if s.Life() != Alive {
+			return errors.Errorf("cannot attach %s: storage is not alive", names.ReadableString(s.Tag()))
+		}
+		if owner, ok := s.Owner(); ok {
+			return errors.Errorf(
+				"cannot attach %s: storage is owned by %s",
+				names.ReadableString(s.Tag()), names.ReadableString(owner),
+			)
+		}
+		if s.doc.AttachmentCount > 0 {
+			return errors.Errorf("cannot attach %s: storage is attached", names.ReadableString(s.Tag()))
+		}
+	}
+	return nil
+}
+
 // unitAssignedMachineStorageOps returns ops for creating volumes, filesystems
 // and their attachments to the machine that the specified unit is assigned to,
 // corresponding to the specified storage instance.
@@ -1155,6 +1266,12 @@ type StorageConstraints struct {
 
 	// Count is the required number of storage instances.
 	Count uint64 `bson:"count"`
+
+	// snapshotId, if non-empty, is the ID of the volume snapshot
+	// from which to create the storage instances' volumes. It is
+	// only ever set when adding storage to a unit, and so is never
+	// recorded with an application's storage constraints.
+	snapshotId string
 }
 
 func createStorageConstraintsOp(key string, cons map[string]StorageConstraints) txn.Op {
@@ -1531,6 +1648,114 @@ func (st *State) AddStorageForUnit(
 	return nil
 }
 
+// AddStorageForUnitFromSnapshot adds a storage instance to the given
+// unit, whose volume is created from the specified volume snapshot.
+//
+// The snapshot must be alive and have been taken, and the unit must be
+// assigned to a machine. Only block storage may be created from a
+// snapshot. If no pool is specified, the pool of the snapshotted volume
+// is used; the size defaults to, and may not be less than, the size of
+// the snapshot.
+func (st *State) AddStorageForUnitFromSnapshot(
+	tag names.UnitTag, name string, snapshotId string, cons StorageConstraints,
+) error {
+	u, err := st.Unit(tag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := u.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		snapshotCons, err := st.snapshotStorageConstraints(u, name, snapshotId, cons)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops, err := st.addStorageForUnitOps(u, name, snapshotCons)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append(ops, txn.Op{
+			C:      volumeSnapshotsC,
+			Id:     snapshotId,
+			Assert: isAliveDoc,
+		}), nil
+	}
+	if err := st.run(buildTxn); err != nil {
+		return errors.Annotatef(err, "adding %q storage to %s from snapshot %s", name, u, snapshotId)
+	}
+	return nil
+}
+
+// snapshotStorageConstraints validates the constraints for adding
+// storage to a unit from a volume snapshot, and returns them with the
+// snapshot's pool and size filled in.
+func (st *State) snapshotStorageConstraints(
+	u *Unit, name string, snapshotId string, cons StorageConstraints,
+) (StorageConstraints, error) {
+	snapshot, err := st.volumeSnapshot(snapshotId)
+	if err != nil {
+		return StorageConstraints{}, errors.Trace(err)
+	}
+	if snapshot.Life() != Alive {
+		return StorageConstraints{}, errors.New("snapshot is not alive")
+	}
+	info, err := snapshot.Info()
+	if err != nil {
+		return StorageConstraints{}, errors.Trace(err)
+	}
+	ch, err := u.charm()
+	if err != nil {
+		return StorageConstraints{}, errors.Trace(err)
+	}
+	charmStorage, ok := ch.Meta().Storage[name]
+	if !ok {
+		return StorageConstraints{}, errors.NotFoundf("charm storage %q", name)
+	}
+	if charmStorage.Type != charm.StorageBlock {
+		return StorageConstraints{}, errors.NotSupportedf(
+			"creating %s storage from a volume snapshot", charmStorage.Type,
+		)
+	}
+	if cons.Count > 1 {
+		return StorageConstraints{}, errors.NotValidf(
+			"adding %d storage instances from one snapshot", cons.Count,
+		)
+	}
+	machineId, err := u.AssignedMachineId()
+	if err != nil {
+		return StorageConstraints{}, errors.Trace(err)
+	}
+	if i := strings.LastIndex(snapshotId, "/"); i >= 0 && snapshotId[:i] != machineId {
+		return StorageConstraints{}, errors.Errorf(
+			"snapshot %s is scoped to machine %s, but unit is assigned to machine %s",
+			snapshotId, snapshotId[:i], machineId,
+		)
+	}
+	switch {
+	case cons.Pool == "":
+		cons.Pool = snapshot.Pool()
+	case cons.Pool != snapshot.Pool():
+		return StorageConstraints{}, errors.Errorf(
+			"cannot create storage in pool %q from snapshot of pool %q",
+			cons.Pool, snapshot.Pool(),
+		)
+	}
+	switch {
+	case cons.Size == 0:
+		cons.Size = info.Size
+	case cons.Size < info.Size:
+		return StorageConstraints{}, errors.Errorf(
+			"size %dM is smaller than snapshot size %dM", cons.Size, info.Size,
+		)
+	}
+	cons.Count = 1
+	cons.snapshotId = snapshotId
+	return cons, nil
+}
+
 // addStorage adds storage instances to given unit as specified.
 func (st *State) addStorageForUnitOps(
 	u *Unit,
@@ -1635,6 +1860,7 @@ func (st *State) addUnitStorageOps(
 		u.Tag(),
 		charmMeta,
 		map[string]StorageConstraints{storageName: cons},
+		nil,
 		u.Series(),
 		u,
 	)
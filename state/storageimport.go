@@ -0,0 +1,168 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"regexp"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/status"
+	"github.com/juju/juju/storage"
+)
+
+var validStorageName = regexp.MustCompile("^" + names.StorageNameSnippet + "$")
+
+// AddExistingFilesystem imports an existing, already-provisioned
+// filesystem into the model. The filesystem, and its backing volume
+// if one is specified, will be associated with a new storage instance
+// with the given storage name. The storage instance is not owned by
+// any entity, and the filesystem starts out in the Detached state; the
+// storage may later be attached to a new unit of an application whose
+// charm defines storage of the same name.
+//
+// If backingVolume is non-nil, the filesystem is expected to be managed
+// by Juju on the specified volume, which must belong to a pool whose
+// provider supports block devices. Otherwise the filesystem's pool must
+// support filesystems directly. In both cases, the storage provider must
+// be dynamic and model-scoped, so that the storage can be moved between
+// machines.
+func (st *State) AddExistingFilesystem(
+	info FilesystemInfo,
+	backingVolume *VolumeInfo,
+	storageName string,
+) (_ names.StorageTag, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot add existing filesystem")
+	if !validStorageName.MatchString(storageName) {
+		return names.StorageTag{}, errors.NotValidf("storage name %q", storageName)
+	}
+	if backingVolume != nil {
+		if backingVolume.Pool == "" {
+			backingVolume.Pool = info.Pool
+		}
+		if backingVolume.VolumeId == "" {
+			return names.StorageTag{}, errors.NotValidf("empty backing volume ID")
+		}
+		if info.Size == 0 {
+			info.Size = backingVolume.Size
+		}
+		if err := validateImportPool(st, backingVolume.Pool, storage.StorageKindBlock); err != nil {
+			return names.StorageTag{}, errors.Trace(err)
+		}
+		if err := st.checkVolumeNotImported(*backingVolume); err != nil {
+			return names.StorageTag{}, errors.Trace(err)
+		}
+	} else {
+		if info.FilesystemId == "" {
+			return names.StorageTag{}, errors.NotValidf("empty filesystem ID")
+		}
+		if err := validateImportPool(st, info.Pool, storage.StorageKindFilesystem); err != nil {
+			return names.StorageTag{}, errors.Trace(err)
+		}
+	}
+	if info.Size == 0 {
+		return names.StorageTag{}, errors.NotValidf("filesystem size 0")
+	}
+
+	storageId, err := newStorageInstanceId(st, storageName)
+	if err != nil {
+		return names.StorageTag{}, errors.Annotate(err, "cannot generate storage instance name")
+	}
+	ops := []txn.Op{{
+		C:      storageInstancesC,
+		Id:     storageId,
+		Assert: txn.DocMissing,
+		Insert: &storageInstanceDoc{
+			Id:          storageId,
+			Kind:        StorageKindFilesystem,
+			StorageName: storageName,
+		},
+	}}
+
+	now := st.clock.Now().UnixNano()
+	detached := statusDoc{
+		Status:  status.Detached,
+		Updated: now,
+	}
+
+	var volumeId string
+	if backingVolume != nil {
+		volumeId, err = newVolumeName(st, "")
+		if err != nil {
+			return names.StorageTag{}, errors.Annotate(err, "cannot generate volume name")
+		}
+		volumeInfo := *backingVolume
+		ops = append(ops, st.newVolumeOps(volumeDoc{
+			Name:      volumeId,
+			StorageId: storageId,
+			Info:      &volumeInfo,
+		}, detached)...)
+	}
+
+	filesystemId, err := newFilesystemId(st, "")
+	if err != nil {
+		return names.StorageTag{}, errors.Annotate(err, "cannot generate filesystem name")
+	}
+	if backingVolume != nil && info.FilesystemId == "" {
+		// Filesystems managed by Juju on volumes are identified
+		// by their tags; see storage/provider/managedfs.go.
+		info.FilesystemId = names.NewFilesystemTag(filesystemId).String()
+	}
+	ops = append(ops, st.newFilesystemOps(filesystemDoc{
+		FilesystemId: filesystemId,
+		StorageId:    storageId,
+		VolumeId:     volumeId,
+		Info:         &info,
+	}, detached)...)
+
+	if err := st.runTransaction(ops); err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	return names.NewStorageTag(storageId), nil
+}
+
+// validateImportPool checks that storage of the given kind may be
+// imported from the named pool.
+func validateImportPool(st *State, poolName string, kind storage.StorageKind) error {
+	if poolName == "" {
+		return errors.NotValidf("empty pool name")
+	}
+	_, provider, err := poolStorageProvider(st, poolName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !provider.Supports(kind) {
+		return errors.NotSupportedf("importing %s storage from pool %q", kind, poolName)
+	}
+	if kind == storage.StorageKindBlock && provider.Supports(storage.StorageKindFilesystem) {
+		return errors.NotSupportedf(
+			"importing volume-backed filesystem from pool %q, which supports filesystems", poolName,
+		)
+	}
+	if !provider.Dynamic() || provider.Scope() != storage.ScopeEnviron {
+		return errors.NotSupportedf("importing storage from non-detachable pool %q", poolName)
+	}
+	return nil
+}
+
+// checkVolumeNotImported returns an error if a volume with the same
+// provider ID as the given volume info is already known to the model.
+func (st *State) checkVolumeNotImported(info VolumeInfo) error {
+	coll, closer := st.getCollection(volumesC)
+	defer closer()
+	n, err := coll.Find(bson.D{
+		{"info.pool", info.Pool},
+		{"info.volumeid", info.VolumeId},
+	}).Count()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if n > 0 {
+		return errors.AlreadyExistsf("volume %q in pool %q", info.VolumeId, info.Pool)
+	}
+	return nil
+}
@@ -0,0 +1,249 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/testing"
+	"github.com/juju/juju/status"
+)
+
+type StorageImportSuite struct {
+	StorageStateSuiteBase
+}
+
+var _ = gc.Suite(&StorageImportSuite{})
+
+func (s *StorageImportSuite) importVolumeBackedFilesystem(c *gc.C) names.StorageTag {
+	storageTag, err := s.State.AddExistingFilesystem(state.FilesystemInfo{
+		Pool: "modelscoped-block",
+	}, &state.VolumeInfo{
+		VolumeId:   "vol-123",
+		Size:       1024,
+		Persistent: true,
+	}, "data")
+	c.Assert(err, jc.ErrorIsNil)
+	return storageTag
+}
+
+func (s *StorageImportSuite) TestAddExistingFilesystem(c *gc.C) {
+	storageTag, err := s.State.AddExistingFilesystem(state.FilesystemInfo{
+		Pool:         "modelscoped",
+		Size:         1024,
+		FilesystemId: "fs-123",
+	}, nil, "data")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(storageTag, gc.Equals, names.NewStorageTag("data/0"))
+
+	storageInstance, err := s.State.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(storageInstance.Kind(), gc.Equals, state.StorageKindFilesystem)
+	_, hasOwner := storageInstance.Owner()
+	c.Assert(hasOwner, jc.IsFalse)
+
+	filesystem := s.storageInstanceFilesystem(c, storageTag)
+	c.Assert(filesystem.FilesystemTag(), gc.Equals, names.NewFilesystemTag("0"))
+	_, err = filesystem.Volume()
+	c.Assert(err, gc.Equals, state.ErrNoBackingVolume)
+	s.assertFilesystemInfo(c, filesystem.FilesystemTag(), state.FilesystemInfo{
+		Pool:         "modelscoped",
+		Size:         1024,
+		FilesystemId: "fs-123",
+	})
+
+	filesystemStatus, err := filesystem.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(filesystemStatus.Status, gc.Equals, status.Detached)
+}
+
+func (s *StorageImportSuite) TestAddExistingFilesystemVolumeBacked(c *gc.C) {
+	storageTag := s.importVolumeBackedFilesystem(c)
+
+	filesystem := s.storageInstanceFilesystem(c, storageTag)
+	s.assertFilesystemInfo(c, filesystem.FilesystemTag(), state.FilesystemInfo{
+		Pool:         "modelscoped-block",
+		Size:         1024,
+		FilesystemId: "filesystem-0",
+	})
+	volumeTag, err := filesystem.Volume()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volumeTag, gc.Equals, names.NewVolumeTag("0"))
+	s.assertVolumeInfo(c, volumeTag, state.VolumeInfo{
+		Pool:       "modelscoped-block",
+		VolumeId:   "vol-123",
+		Size:       1024,
+		Persistent: true,
+	})
+
+	volumeStatus, err := s.volume(c, volumeTag).Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volumeStatus.Status, gc.Equals, status.Detached)
+}
+
+func (s *StorageImportSuite) TestAddExistingFilesystemAlreadyImported(c *gc.C) {
+	s.importVolumeBackedFilesystem(c)
+	_, err := s.State.AddExistingFilesystem(state.FilesystemInfo{
+		Pool: "modelscoped-block",
+	}, &state.VolumeInfo{
+		VolumeId: "vol-123",
+		Size:     1024,
+	}, "data")
+	c.Assert(err, gc.ErrorMatches, `cannot add existing filesystem: volume "vol-123" in pool "modelscoped-block" already exists`)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *StorageImportSuite) TestAddExistingFilesystemMachineScopedPool(c *gc.C) {
+	_, err := s.State.AddExistingFilesystem(state.FilesystemInfo{
+		Pool:         "machinescoped",
+		Size:         1024,
+		FilesystemId: "fs-123",
+	}, nil, "data")
+	c.Assert(err, gc.ErrorMatches, `cannot add existing filesystem: importing storage from non-detachable pool "machinescoped" not supported`)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *StorageImportSuite) TestAddExistingFilesystemUnsupportedKind(c *gc.C) {
+	_, err := s.State.AddExistingFilesystem(state.FilesystemInfo{
+		Pool:         "modelscoped-block",
+		Size:         1024,
+		FilesystemId: "fs-123",
+	}, nil, "data")
+	c.Assert(err, gc.ErrorMatches, `cannot add existing filesystem: importing filesystem storage from pool "modelscoped-block" not supported`)
+}
+
+func (s *StorageImportSuite) TestAddExistingFilesystemInvalidStorageName(c *gc.C) {
+	_, err := s.State.AddExistingFilesystem(state.FilesystemInfo{
+		Pool:         "modelscoped",
+		Size:         1024,
+		FilesystemId: "fs-123",
+	}, nil, "0-data")
+	c.Assert(err, gc.ErrorMatches, `cannot add existing filesystem: storage name "0-data" not valid`)
+}
+
+func (s *StorageImportSuite) addApplicationAttachStorage(c *gc.C, numUnits int, storageTag names.StorageTag) (*state.Application, error) {
+	ch := s.AddTestingCharm(c, "storage-filesystem")
+	return s.State.AddApplication(state.AddApplicationArgs{
+		Name:          "storage-filesystem",
+		Charm:         ch,
+		NumUnits:      numUnits,
+		AttachStorage: []names.StorageTag{storageTag},
+		Storage: map[string]state.StorageConstraints{
+			"data": makeStorageCons("modelscoped-block", 1024, 1),
+		},
+	})
+}
+
+func (s *StorageImportSuite) TestAddApplicationAttachStorage(c *gc.C) {
+	storageTag := s.importVolumeBackedFilesystem(c)
+	app, err := s.addApplicationAttachStorage(c, 1, storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	units, err := app.AllUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 1)
+	u := units[0]
+
+	// The imported storage counts towards the storage constraints,
+	// so no new storage instances are created.
+	attachments, err := s.State.UnitStorageAttachments(u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachments, gc.HasLen, 1)
+	c.Assert(attachments[0].StorageInstance(), gc.Equals, storageTag)
+
+	storageInstance, err := s.State.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	owner, hasOwner := storageInstance.Owner()
+	c.Assert(hasOwner, jc.IsTrue)
+	c.Assert(owner, gc.Equals, u.Tag())
+
+	// Assigning the unit to a machine attaches the existing
+	// filesystem and its backing volume to the machine.
+	err = s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	machineTag := names.NewMachineTag("0")
+	s.filesystemAttachment(c, machineTag, names.NewFilesystemTag("0"))
+	s.volumeAttachment(c, machineTag, names.NewVolumeTag("0"))
+
+	filesystems, err := s.State.AllFilesystems()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(filesystems, gc.HasLen, 1)
+}
+
+func (s *StorageImportSuite) TestAddApplicationAttachStorageWatchMachineFilesystemAttachments(c *gc.C) {
+	storageTag := s.importVolumeBackedFilesystem(c)
+	app, err := s.addApplicationAttachStorage(c, 1, storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	units, err := app.AllUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AssignUnit(units[0], state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The model-scoped, volume-backed filesystem is managed
+	// by the machine it is attached to.
+	w := s.State.WatchMachineFilesystemAttachments(names.NewMachineTag("0"))
+	defer testing.AssertStop(c, w)
+	wc := testing.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChangeInSingleEvent("0:0") // initial
+	wc.AssertNoChange()
+
+	w2 := s.State.WatchEnvironFilesystemAttachments()
+	defer testing.AssertStop(c, w2)
+	wc2 := testing.NewStringsWatcherC(c, s.State, w2)
+	wc2.AssertChangeInSingleEvent() // initial
+	wc2.AssertNoChange()
+}
+
+func (s *StorageImportSuite) TestAddApplicationAttachStorageNumUnits(c *gc.C) {
+	storageTag := s.importVolumeBackedFilesystem(c)
+	_, err := s.addApplicationAttachStorage(c, 2, storageTag)
+	c.Assert(err, gc.ErrorMatches, `cannot add application "storage-filesystem": AttachStorage is non-empty, but NumUnits is 2`)
+}
+
+func (s *StorageImportSuite) TestAddApplicationAttachStorageKindMismatch(c *gc.C) {
+	storageTag, err := s.State.AddExistingFilesystem(state.FilesystemInfo{
+		Pool:         "modelscoped",
+		Size:         1024,
+		FilesystemId: "fs-123",
+	}, nil, "data")
+	c.Assert(err, jc.ErrorIsNil)
+
+	ch := s.AddTestingCharm(c, "storage-block")
+	_, err = s.State.AddApplication(state.AddApplicationArgs{
+		Name:          "storage-block",
+		Charm:         ch,
+		NumUnits:      1,
+		AttachStorage: []names.StorageTag{storageTag},
+		Storage: map[string]state.StorageConstraints{
+			"data": makeStorageCons("modelscoped", 1024, 1),
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot add application "storage-block": cannot attach storage data/0: storage kind "filesystem" does not match charm storage type "block"`)
+}
+
+func (s *StorageImportSuite) TestAddApplicationAttachStorageAlreadyAttached(c *gc.C) {
+	storageTag := s.importVolumeBackedFilesystem(c)
+	_, err := s.addApplicationAttachStorage(c, 1, storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ch := s.AddTestingCharm(c, "storage-filesystem")
+	_, err = s.State.AddApplication(state.AddApplicationArgs{
+		Name:          "another",
+		Charm:         ch,
+		NumUnits:      1,
+		AttachStorage: []names.StorageTag{storageTag},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot add application "another": cannot attach storage data/0: storage is owned by unit storage-filesystem/0`)
+}
+
+func (s *StorageImportSuite) TestResizeImportedVolumeBackedFilesystem(c *gc.C) {
+	storageTag := s.importVolumeBackedFilesystem(c)
+	err := s.State.ResizeStorageInstance(storageTag, 2048)
+	c.Assert(err, gc.ErrorMatches, `cannot resize storage data/0: resizing imported filesystem not supported`)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
@@ -0,0 +1,291 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// ResizeStorageInstance records a request to grow the storage assigned
+// to the specified storage instance to the given size, in MiB. The
+// storage is resized asynchronously by the storage provisioner.
+//
+// Block-kind storage is resized by growing its volume. Filesystem-kind
+// storage that is backed by a volume is resized by first growing the
+// volume, and then growing the filesystem to fill it; other filesystems
+// are grown directly.
+func (st *State) ResizeStorageInstance(tag names.StorageTag, size uint64) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot resize storage %s", tag.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		s, err := st.storageInstance(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if s.Life() != Alive {
+			return nil, errors.New("storage is not alive")
+		}
+		ops := []txn.Op{{
+			C:      storageInstancesC,
+			Id:     s.doc.Id,
+			Assert: isAliveDoc,
+		}}
+		switch s.Kind() {
+		case StorageKindBlock:
+			v, err := st.storageInstanceVolume(tag)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			volumeOps, err := resizeVolumeOps(v, size)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			return append(ops, volumeOps...), nil
+		case StorageKindFilesystem:
+			f, err := st.storageInstanceFilesystem(tag)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if _, err := f.Info(); err != nil {
+				return nil, errors.Trace(err)
+			}
+			volumeTag, err := f.Volume()
+			if err == ErrNoBackingVolume {
+				filesystemOps, err := resizeFilesystemOps(f, size)
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				return append(ops, filesystemOps...), nil
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if _, ok := names.FilesystemMachine(f.FilesystemTag()); !ok {
+				// Model-scoped, volume-backed filesystems are
+				// only created by importing existing storage.
+				return nil, errors.NotSupportedf("resizing imported filesystem")
+			}
+			// The filesystem will be resized once its volume
+			// has been; see SetVolumeResized.
+			if _, ok := f.PendingSize(); ok {
+				return nil, errors.New("filesystem is already being resized")
+			}
+			v, err := st.volumeByTag(volumeTag)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			volumeOps, err := resizeVolumeOps(v, size)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, txn.Op{
+				C:  filesystemsC,
+				Id: f.doc.FilesystemId,
+				Assert: append(isAliveDoc, bson.DocElem{
+					"pendingsize", bson.D{{"$exists", false}},
+				}),
+			})
+			return append(ops, volumeOps...), nil
+		}
+		return nil, errors.Errorf("invalid storage kind %v", s.Kind())
+	}
+	return st.run(buildTxn)
+}
+
+// resizeVolumeOps returns the txn.Ops to record a request to grow the
+// specified volume to the given size, in MiB.
+func resizeVolumeOps(v *volume, size uint64) ([]txn.Op, error) {
+	if v.Life() != Alive {
+		return nil, errors.New("volume is not alive")
+	}
+	info, err := v.Info()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if _, ok := v.PendingSize(); ok {
+		return nil, errors.New("volume is already being resized")
+	}
+	if size <= info.Size {
+		return nil, errors.Errorf(
+			"new size %dM is not larger than current size %dM",
+			size, info.Size,
+		)
+	}
+	return []txn.Op{{
+		C:  volumesC,
+		Id: v.doc.Name,
+		Assert: append(isAliveDoc,
+			bson.DocElem{"info.size", info.Size},
+			bson.DocElem{"pendingsize", bson.D{{"$exists", false}}},
+		),
+		Update: bson.D{{"$set", bson.D{{"pendingsize", size}}}},
+	}}, nil
+}
+
+// resizeFilesystemOps returns the txn.Ops to record a request to grow
+// the specified filesystem to the given size, in MiB.
+func resizeFilesystemOps(f *filesystem, size uint64) ([]txn.Op, error) {
+	if f.Life() != Alive {
+		return nil, errors.New("filesystem is not alive")
+	}
+	info, err := f.Info()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if _, ok := f.PendingSize(); ok {
+		return nil, errors.New("filesystem is already being resized")
+	}
+	if size <= info.Size {
+		return nil, errors.Errorf(
+			"new size %dM is not larger than current size %dM",
+			size, info.Size,
+		)
+	}
+	return []txn.Op{{
+		C:  filesystemsC,
+		Id: f.doc.FilesystemId,
+		Assert: append(isAliveDoc,
+			bson.DocElem{"info.size", info.Size},
+			bson.DocElem{"pendingsize", bson.D{{"$exists", false}}},
+		),
+		Update: bson.D{{"$set", bson.D{{"pendingsize", size}}}},
+	}}, nil
+}
+
+// SetVolumeResized records that the specified volume has been grown to
+// the given size, in MiB, completing a pending resize. If the volume
+// backs a filesystem, the filesystem is then scheduled to be grown to
+// fill the volume.
+func (st *State) SetVolumeResized(tag names.VolumeTag, size uint64) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set volume %s resized", tag.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		v, err := st.volumeByTag(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		pendingSize, ok := v.PendingSize()
+		if !ok {
+			return nil, jujutxn.ErrNoOperations
+		}
+		if size < pendingSize {
+			return nil, errors.Errorf(
+				"size %dM is smaller than requested size %dM",
+				size, pendingSize,
+			)
+		}
+		ops := []txn.Op{{
+			C:      volumesC,
+			Id:     v.doc.Name,
+			Assert: bson.D{{"pendingsize", pendingSize}},
+			Update: bson.D{
+				{"$set", bson.D{{"info.size", size}}},
+				{"$unset", bson.D{{"pendingsize", nil}}},
+			},
+		}}
+		f, err := st.volumeFilesystem(tag)
+		if errors.IsNotFound(err) {
+			return ops, nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if f.Life() != Alive {
+			return ops, nil
+		}
+		if _, err := f.Info(); errors.IsNotProvisioned(err) {
+			// The filesystem will be created to fill the
+			// volume when it is provisioned.
+			return ops, nil
+		}
+		return append(ops, txn.Op{
+			C:  filesystemsC,
+			Id: f.doc.FilesystemId,
+			Assert: append(isAliveDoc, bson.DocElem{
+				"pendingsize", bson.D{{"$exists", false}},
+			}),
+			Update: bson.D{{"$set", bson.D{{"pendingsize", size}}}},
+		}), nil
+	}
+	return st.run(buildTxn)
+}
+
+// SetVolumeResizeFailed records that the pending resize of the specified
+// volume could not be completed. The volume keeps its current size.
+func (st *State) SetVolumeResizeFailed(tag names.VolumeTag) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set volume %s resize failed", tag.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		v, err := st.volumeByTag(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		pendingSize, ok := v.PendingSize()
+		if !ok {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:      volumesC,
+			Id:     v.doc.Name,
+			Assert: bson.D{{"pendingsize", pendingSize}},
+			Update: bson.D{{"$unset", bson.D{{"pendingsize", nil}}}},
+		}}, nil
+	}
+	return st.run(buildTxn)
+}
+
+// SetFilesystemResized records that the specified filesystem has been
+// grown to the given size, in MiB, completing a pending resize.
+func (st *State) SetFilesystemResized(tag names.FilesystemTag, size uint64) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set filesystem %s resized", tag.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		f, err := st.filesystemByTag(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		pendingSize, ok := f.PendingSize()
+		if !ok {
+			return nil, jujutxn.ErrNoOperations
+		}
+		if size < pendingSize {
+			return nil, errors.Errorf(
+				"size %dM is smaller than requested size %dM",
+				size, pendingSize,
+			)
+		}
+		return []txn.Op{{
+			C:      filesystemsC,
+			Id:     f.doc.FilesystemId,
+			Assert: bson.D{{"pendingsize", pendingSize}},
+			Update: bson.D{
+				{"$set", bson.D{{"info.size", size}}},
+				{"$unset", bson.D{{"pendingsize", nil}}},
+			},
+		}}, nil
+	}
+	return st.run(buildTxn)
+}
+
+// SetFilesystemResizeFailed records that the pending resize of the
+// specified filesystem could not be completed. The filesystem keeps
+// its current size.
+func (st *State) SetFilesystemResizeFailed(tag names.FilesystemTag) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set filesystem %s resize failed", tag.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		f, err := st.filesystemByTag(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		pendingSize, ok := f.PendingSize()
+		if !ok {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:      filesystemsC,
+			Id:     f.doc.FilesystemId,
+			Assert: bson.D{{"pendingsize", pendingSize}},
+			Update: bson.D{{"$unset", bson.D{{"pendingsize", nil}}}},
+		}}, nil
+	}
+	return st.run(buildTxn)
+}
@@ -0,0 +1,226 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/testing"
+)
+
+type StorageResizeSuite struct {
+	StorageStateSuiteBase
+}
+
+var _ = gc.Suite(&StorageResizeSuite{})
+
+// setupProvisionedBlockStorage adds a unit with provisioned block
+// storage, and returns the storage and volume tags.
+func (s *StorageResizeSuite) setupProvisionedBlockStorage(c *gc.C) (names.StorageTag, names.VolumeTag) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "modelscoped")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	volumeTag := s.storageInstanceVolume(c, storageTag).VolumeTag()
+	err = s.State.SetVolumeInfo(volumeTag, state.VolumeInfo{
+		VolumeId: "vol-123",
+		Size:     1024,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return storageTag, volumeTag
+}
+
+// setupProvisionedVolumeBackedFilesystem adds a unit with a provisioned
+// volume-backed filesystem, and returns the storage, filesystem and
+// volume tags.
+func (s *StorageResizeSuite) setupProvisionedVolumeBackedFilesystem(c *gc.C) (
+	names.StorageTag, names.FilesystemTag, names.VolumeTag,
+) {
+	_, u, storageTag := s.setupSingleStorage(c, "filesystem", "modelscoped-block")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	machine := s.machine(c, "0")
+	err = machine.SetProvisioned("inst-id", "fake_nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	filesystem := s.storageInstanceFilesystem(c, storageTag)
+	volumeTag, err := filesystem.Volume()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetVolumeInfo(volumeTag, state.VolumeInfo{
+		VolumeId: "vol-123",
+		Size:     1024,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetVolumeAttachmentInfo(machine.MachineTag(), volumeTag, state.VolumeAttachmentInfo{
+		DeviceName: "sdb",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetFilesystemInfo(filesystem.FilesystemTag(), state.FilesystemInfo{
+		FilesystemId: "fs-123",
+		Size:         1000,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return storageTag, filesystem.FilesystemTag(), volumeTag
+}
+
+func (s *StorageResizeSuite) TestResizeBlockStorage(c *gc.C) {
+	storageTag, volumeTag := s.setupProvisionedBlockStorage(c)
+	err := s.State.ResizeStorageInstance(storageTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+
+	size, ok := s.volume(c, volumeTag).PendingSize()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(size, gc.Equals, uint64(2048))
+
+	err = s.State.ResizeStorageInstance(storageTag, 4096)
+	c.Assert(err, gc.ErrorMatches, `cannot resize storage data/0: volume is already being resized`)
+
+	err = s.State.SetVolumeResized(volumeTag, 3072)
+	c.Assert(err, jc.ErrorIsNil)
+	volume := s.volume(c, volumeTag)
+	_, ok = volume.PendingSize()
+	c.Assert(ok, jc.IsFalse)
+	info, err := volume.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.Size, gc.Equals, uint64(3072))
+	c.Assert(info.VolumeId, gc.Equals, "vol-123")
+}
+
+func (s *StorageResizeSuite) TestResizeStorageNotLarger(c *gc.C) {
+	storageTag, _ := s.setupProvisionedBlockStorage(c)
+	err := s.State.ResizeStorageInstance(storageTag, 1024)
+	c.Assert(err, gc.ErrorMatches, `cannot resize storage data/0: new size 1024M is not larger than current size 1024M`)
+}
+
+func (s *StorageResizeSuite) TestResizeStorageNotProvisioned(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "modelscoped")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.ResizeStorageInstance(storageTag, 2048)
+	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
+}
+
+func (s *StorageResizeSuite) TestResizeStorageNotFound(c *gc.C) {
+	err := s.State.ResizeStorageInstance(names.NewStorageTag("data/42"), 2048)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *StorageResizeSuite) TestSetVolumeResizedSmaller(c *gc.C) {
+	storageTag, volumeTag := s.setupProvisionedBlockStorage(c)
+	err := s.State.ResizeStorageInstance(storageTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetVolumeResized(volumeTag, 1536)
+	c.Assert(err, gc.ErrorMatches, `cannot set volume 0 resized: size 1536M is smaller than requested size 2048M`)
+}
+
+func (s *StorageResizeSuite) TestSetVolumeResizeFailed(c *gc.C) {
+	storageTag, volumeTag := s.setupProvisionedBlockStorage(c)
+	err := s.State.ResizeStorageInstance(storageTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetVolumeResizeFailed(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	volume := s.volume(c, volumeTag)
+	_, ok := volume.PendingSize()
+	c.Assert(ok, jc.IsFalse)
+	info, err := volume.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.Size, gc.Equals, uint64(1024))
+
+	// With the failed resize cleared, the storage may be resized again.
+	err = s.State.ResizeStorageInstance(storageTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *StorageResizeSuite) TestResizeVolumeBackedFilesystem(c *gc.C) {
+	storageTag, filesystemTag, volumeTag := s.setupProvisionedVolumeBackedFilesystem(c)
+	err := s.State.ResizeStorageInstance(storageTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The volume is resized first; the filesystem is not
+	// scheduled to be resized until the volume has been.
+	size, ok := s.volume(c, volumeTag).PendingSize()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(size, gc.Equals, uint64(2048))
+	_, ok = s.filesystem(c, filesystemTag).PendingSize()
+	c.Assert(ok, jc.IsFalse)
+
+	err = s.State.SetVolumeResized(volumeTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	size, ok = s.filesystem(c, filesystemTag).PendingSize()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(size, gc.Equals, uint64(2048))
+
+	err = s.State.ResizeStorageInstance(storageTag, 4096)
+	c.Assert(err, gc.ErrorMatches, `cannot resize storage data/0: filesystem is already being resized`)
+
+	err = s.State.SetFilesystemResized(filesystemTag, 2040)
+	c.Assert(err, gc.ErrorMatches, `cannot set filesystem 0/0 resized: size 2040M is smaller than requested size 2048M`)
+	err = s.State.SetFilesystemResized(filesystemTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	filesystem := s.filesystem(c, filesystemTag)
+	_, ok = filesystem.PendingSize()
+	c.Assert(ok, jc.IsFalse)
+	info, err := filesystem.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.Size, gc.Equals, uint64(2048))
+}
+
+func (s *StorageResizeSuite) TestResizeFilesystem(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "filesystem", "modelscoped")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	filesystemTag := s.storageInstanceFilesystem(c, storageTag).FilesystemTag()
+	err = s.State.SetFilesystemInfo(filesystemTag, state.FilesystemInfo{
+		FilesystemId: "fs-123",
+		Size:         1024,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.ResizeStorageInstance(storageTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	size, ok := s.filesystem(c, filesystemTag).PendingSize()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(size, gc.Equals, uint64(2048))
+
+	err = s.State.SetFilesystemResizeFailed(filesystemTag)
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok = s.filesystem(c, filesystemTag).PendingSize()
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *StorageResizeSuite) TestWatchModelVolumeResizes(c *gc.C) {
+	storageTag, _ := s.setupProvisionedBlockStorage(c)
+
+	w := s.State.WatchModelVolumeResizes()
+	defer testing.AssertStop(c, w)
+	wc := testing.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChangeInSingleEvent("0") // initial
+	wc.AssertNoChange()
+
+	err := s.State.ResizeStorageInstance(storageTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent("0")
+	wc.AssertNoChange()
+}
+
+func (s *StorageResizeSuite) TestWatchMachineFilesystemResizes(c *gc.C) {
+	_, filesystemTag, volumeTag := s.setupProvisionedVolumeBackedFilesystem(c)
+	err := s.State.ResizeStorageInstance(names.NewStorageTag("data/0"), 2048)
+	c.Assert(err, jc.ErrorIsNil)
+
+	w := s.State.WatchMachineFilesystemResizes(names.NewMachineTag("0"))
+	defer testing.AssertStop(c, w)
+	wc := testing.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChangeInSingleEvent("0/0") // initial
+	wc.AssertNoChange()
+
+	err = s.State.SetVolumeResized(volumeTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent(filesystemTag.Id())
+	wc.AssertNoChange()
+}
@@ -95,6 +95,7 @@ func InitializeWithArgs(c *gc.C, args InitializeArgs) *state.State {
 		MongoInfo:     mgoInfo,
 		MongoDialOpts: dialOpts,
 		NewPolicy:     args.NewPolicy,
+		SecretsKey:    testing.SecretsKey,
 	})
 	c.Assert(err, jc.ErrorIsNil)
 	return st
@@ -339,6 +339,9 @@ func (u *Unit) Destroy() (err error) {
 			logger.Errorf("cannot delete history for unit %q: %v", unit.globalKey(), err)
 		}
 		if err = unit.Refresh(); errors.IsNotFound(err) {
+			if historyErr := unit.eraseHookExecutions(); historyErr != nil {
+				logger.Errorf("cannot delete hook history for unit %q: %v", unit, historyErr)
+			}
 			return nil
 		}
 	}
@@ -668,7 +671,15 @@ func (u *Unit) Remove() (err error) {
 		}
 		return nil, jujutxn.ErrNoOperations
 	}
-	return unit.st.run(buildTxn)
+	if err := unit.st.run(buildTxn); err != nil {
+		return err
+	}
+	// The hook history is not transactional, so it is removed only
+	// once the unit itself has gone.
+	if err := unit.eraseHookExecutions(); err != nil {
+		logger.Errorf("cannot delete hook history for unit %q: %v", unit, err)
+	}
+	return nil
 }
 
 // Resolved returns the resolved mode for the unit.
@@ -918,7 +929,43 @@ func (u *Unit) OpenPortsOnSubnet(subnetID, protocol string, fromPort, toPort int
 		return errors.Annotatef(err, "invalid port range %v-%v/%v", fromPort, toPort, protocol)
 	}
 	defer errors.DeferredAnnotatef(&err, "cannot open ports %v for unit %q on subnet %q", ports, u, subnetID)
+	return u.openPorts(subnetID, ports)
+}
 
+// OpenPortsOnEndpoint opens the given port range and protocol for the
+// named endpoint of the unit, or for all of its endpoints if the name
+// is AllEndpoints. The application's expose settings for the endpoint
+// determine from where the range may be accessed.
+func (u *Unit) OpenPortsOnEndpoint(endpoint, protocol string, fromPort, toPort int) (err error) {
+	ports, err := NewPortRange(u.Name(), fromPort, toPort, protocol)
+	if err != nil {
+		return errors.Annotatef(err, "invalid port range %v-%v/%v", fromPort, toPort, protocol)
+	}
+	ports.Endpoint = endpoint
+	defer errors.DeferredAnnotatef(&err, "cannot open ports %v for unit %q", ports, u)
+	if err := u.checkPortsEndpoint(endpoint); err != nil {
+		return errors.Trace(err)
+	}
+	return u.openPorts("", ports)
+}
+
+// checkPortsEndpoint returns an error if ports cannot be opened or
+// closed for the named endpoint of the unit.
+func (u *Unit) checkPortsEndpoint(endpoint string) error {
+	if endpoint == AllEndpoints {
+		return nil
+	}
+	app, err := u.Application()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := app.Endpoint(endpoint); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+func (u *Unit) openPorts(subnetID string, ports PortRange) error {
 	machineID, err := u.AssignedMachineId()
 	if err != nil {
 		return errors.Annotatef(err, "unit %q has no assigned machine", u)
@@ -961,7 +1008,27 @@ func (u *Unit) ClosePortsOnSubnet(subnetID, protocol string, fromPort, toPort in
 		return errors.Annotatef(err, "invalid port range %v-%v/%v", fromPort, toPort, protocol)
 	}
 	defer errors.DeferredAnnotatef(&err, "cannot close ports %v for unit %q on subnet %q", ports, u, subnetID)
+	return u.closePorts(subnetID, ports)
+}
+
+// ClosePortsOnEndpoint closes the given port range and protocol for the
+// named endpoint of the unit, or for all of its endpoints if the name
+// is AllEndpoints. A range opened for all endpoints is not closed for
+// a particular endpoint, nor the other way round.
+func (u *Unit) ClosePortsOnEndpoint(endpoint, protocol string, fromPort, toPort int) (err error) {
+	ports, err := NewPortRange(u.Name(), fromPort, toPort, protocol)
+	if err != nil {
+		return errors.Annotatef(err, "invalid port range %v-%v/%v", fromPort, toPort, protocol)
+	}
+	ports.Endpoint = endpoint
+	defer errors.DeferredAnnotatef(&err, "cannot close ports %v for unit %q", ports, u)
+	if err := u.checkPortsEndpoint(endpoint); err != nil {
+		return errors.Trace(err)
+	}
+	return u.closePorts("", ports)
+}
 
+func (u *Unit) closePorts(subnetID string, ports PortRange) error {
 	machineID, err := u.AssignedMachineId()
 	if err != nil {
 		return errors.Annotatef(err, "unit %q has no assigned machine", u)
@@ -1050,12 +1117,18 @@ func (u *Unit) OpenedPortsOnSubnet(subnetID string) ([]network.PortRange, error)
 		return nil, errors.Annotatef(err, "failed getting ports for unit %q, subnet %q", u, subnetID)
 	}
 	ports := machinePorts.PortsForUnit(u.Name())
+	seen := make(map[network.PortRange]bool)
 	for _, port := range ports {
-		result = append(result, network.PortRange{
+		// A range opened for several endpoints is reported once.
+		portRange := network.PortRange{
 			Protocol: port.Protocol,
 			FromPort: port.FromPort,
 			ToPort:   port.ToPort,
-		})
+		}
+		if !seen[portRange] {
+			seen[portRange] = true
+			result = append(result, portRange)
+		}
 	}
 	network.SortPortRanges(result)
 	return result, nil
@@ -1070,6 +1143,22 @@ func (u *Unit) OpenedPorts() ([]network.PortRange, error) {
 	return u.OpenedPortsOnSubnet("")
 }
 
+// OpenedPortRanges returns the port ranges opened by the unit on its
+// assigned machine, including the endpoints they are opened for.
+func (u *Unit) OpenedPortRanges() ([]PortRange, error) {
+	machineID, err := u.AssignedMachineId()
+	if err != nil {
+		return nil, errors.Annotatef(err, "unit %q has no assigned machine", u)
+	}
+	machinePorts, err := getPorts(u.st, machineID, "")
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "failed getting ports for unit %q", u)
+	}
+	return machinePorts.PortsForUnit(u.Name()), nil
+}
+
 // CharmURL returns the charm URL this unit is currently using.
 func (u *Unit) CharmURL() (*charm.URL, bool) {
 	if u.doc.CharmURL == nil {
@@ -1867,13 +1956,22 @@ func machineStorageParamsForStorageInstance(
 			charmStorage.ReadOnly,
 		}
 		if unit == storage.maybeOwner() {
-			// The storage instance is owned by the unit, so we'll need
-			// to create a volume.
+			// The storage instance is owned by the unit. If the
+			// storage was imported, there will be a volume already
+			// to attach; otherwise we'll need to create a volume.
+			volume, err := st.storageInstanceVolume(storage.StorageTag())
+			if err == nil {
+				volumeAttachments[volume.VolumeTag()] = volumeAttachmentParams
+				break
+			} else if !errors.IsNotFound(err) {
+				return nil, errors.Annotatef(err, "getting volume for storage %q", storage.Tag().Id())
+			}
 			cons := allCons[storage.StorageName()]
 			volumeParams := VolumeParams{
-				storage: storage.StorageTag(),
-				Pool:    cons.Pool,
-				Size:    cons.Size,
+				storage:    storage.StorageTag(),
+				Pool:       cons.Pool,
+				Size:       cons.Size,
+				SnapshotId: cons.snapshotId,
 			}
 			volumes = append(volumes, MachineVolumeParams{
 				volumeParams, volumeAttachmentParams,
@@ -1902,8 +2000,22 @@ func machineStorageParamsForStorageInstance(
 			charmStorage.ReadOnly,
 		}
 		if unit == storage.maybeOwner() {
-			// The storage instance is owned by the unit, so we'll need
-			// to create a filesystem.
+			// The storage instance is owned by the unit. If the
+			// storage was imported, there will be a filesystem
+			// already to attach, along with its backing volume;
+			// otherwise we'll need to create a filesystem.
+			filesystem, err := st.storageInstanceFilesystem(storage.StorageTag())
+			if err == nil {
+				filesystemAttachments[filesystem.FilesystemTag()] = filesystemAttachmentParams
+				if volumeTag, err := filesystem.Volume(); err == nil {
+					volumeAttachments[volumeTag] = VolumeAttachmentParams{}
+				} else if err != ErrNoBackingVolume {
+					return nil, errors.Trace(err)
+				}
+				break
+			} else if !errors.IsNotFound(err) {
+				return nil, errors.Annotatef(err, "getting filesystem for storage %q", storage.Tag().Id())
+			}
 			cons := allCons[storage.StorageName()]
 			filesystemParams := FilesystemParams{
 				storage: storage.StorageTag(),
@@ -2242,6 +2354,14 @@ type ActionSpecsByName map[string]charm.ActionSpec
 // this Unit, and returns its ID.  Note that the use of spec.InsertDefaults
 // mutates payload.
 func (u *Unit) AddAction(name string, payload map[string]interface{}) (Action, error) {
+	return u.AddActionWithTimeout(name, payload, 0)
+}
+
+// AddActionWithTimeout adds a new Action as AddAction does, which will be
+// stopped if it runs for longer than timeout. If timeout is zero, the
+// default timeout defined for the action in the charm's actions.yaml is
+// used, if any.
+func (u *Unit) AddActionWithTimeout(name string, payload map[string]interface{}, timeout time.Duration) (Action, error) {
 	if len(name) == 0 {
 		return nil, errors.New("no action name given")
 	}
@@ -2267,7 +2387,13 @@ func (u *Unit) AddAction(name string, payload map[string]interface{}) (Action, e
 	if err != nil {
 		return nil, err
 	}
-	return u.st.EnqueueAction(u.Tag(), name, payloadWithDefaults)
+	if timeout == 0 {
+		timeout, err = actions.DefaultTimeout(spec)
+		if err != nil {
+			return nil, errors.Annotatef(err, "action %q", name)
+		}
+	}
+	return u.st.enqueueAction(u.Tag(), name, payloadWithDefaults, timeout)
 }
 
 // ActionSpecs gets the ActionSpec map for the Unit's charm.
@@ -2284,10 +2410,11 @@ func (u *Unit) ActionSpecs() (ActionSpecsByName, error) {
 	return chActions.ActionSpecs, nil
 }
 
-// CancelAction removes a pending Action from the queue for this
-// ActionReceiver and marks it as cancelled.
+// CancelAction cancels an Action queued for this ActionReceiver.
+// Pending actions are removed from the queue and marked as
+// cancelled; running actions are asked to stop.
 func (u *Unit) CancelAction(action Action) (Action, error) {
-	return action.Finish(ActionResults{Status: ActionCancelled})
+	return action.Cancel()
 }
 
 // WatchActionNotifications starts and returns a StringsWatcher that
@@ -1247,7 +1247,7 @@ func (s *UnitSuite) TestRemoveLastUnitOnMachineRemovesAllPorts(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(ports, gc.HasLen, 1)
 	c.Assert(ports[0].PortsForUnit(s.unit.Name()), jc.DeepEquals, []state.PortRange{
-		{s.unit.Name(), 100, 200, "tcp"},
+		{s.unit.Name(), 100, 200, "tcp", ""},
 	})
 
 	// Now remove the unit and check again.
@@ -1289,10 +1289,10 @@ func (s *UnitSuite) TestRemoveUnitRemovesItsPortsOnly(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(ports, gc.HasLen, 1)
 	c.Assert(ports[0].PortsForUnit(s.unit.Name()), jc.DeepEquals, []state.PortRange{
-		{s.unit.Name(), 100, 200, "tcp"},
+		{s.unit.Name(), 100, 200, "tcp", ""},
 	})
 	c.Assert(ports[0].PortsForUnit(otherUnit.Name()), jc.DeepEquals, []state.PortRange{
-		{otherUnit.Name(), 300, 400, "udp"},
+		{otherUnit.Name(), 300, 400, "udp", ""},
 	})
 
 	// Now remove the first unit and check again.
@@ -1309,7 +1309,50 @@ func (s *UnitSuite) TestRemoveUnitRemovesItsPortsOnly(c *gc.C) {
 	c.Assert(ports, gc.HasLen, 1)
 	c.Assert(ports[0].PortsForUnit(s.unit.Name()), gc.HasLen, 0)
 	c.Assert(ports[0].PortsForUnit(otherUnit.Name()), jc.DeepEquals, []state.PortRange{
-		{otherUnit.Name(), 300, 400, "udp"},
+		{otherUnit.Name(), 300, 400, "udp", ""},
+	})
+}
+
+func (s *UnitSuite) TestOpenClosePortsOnEndpoint(c *gc.C) {
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The same range may be opened for several endpoints.
+	err = s.unit.OpenPortsOnEndpoint("url", "tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.OpenPortsOnEndpoint("", "tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.OpenPortsOnEndpoint("unknown", "tcp", 80, 80)
+	c.Assert(err, gc.ErrorMatches, `cannot open ports 80-80/tcp \("wordpress/0", endpoint "unknown"\) for unit "wordpress/0": .*`)
+
+	ports, err := machine.AllPorts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports, gc.HasLen, 1)
+	c.Assert(ports[0].PortRanges(), jc.DeepEquals, []state.PortRange{
+		{s.unit.Name(), 80, 80, "tcp", ""},
+		{s.unit.Name(), 80, 80, "tcp", "url"},
+	})
+	open, err := s.unit.OpenedPorts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(open, jc.DeepEquals, []network.PortRange{
+		{FromPort: 80, ToPort: 80, Protocol: "tcp"},
+	})
+	portRanges, err := s.unit.OpenedPortRanges()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(portRanges, jc.SameContents, []state.PortRange{
+		{s.unit.Name(), 80, 80, "tcp", ""},
+		{s.unit.Name(), 80, 80, "tcp", "url"},
+	})
+
+	// Closing the range for one endpoint leaves it open for the other.
+	err = s.unit.ClosePortsOnEndpoint("url", "tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ports[0].Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports[0].PortRanges(), jc.DeepEquals, []state.PortRange{
+		{s.unit.Name(), 80, 80, "tcp", ""},
 	})
 }
 
@@ -1870,3 +1913,174 @@ func (s *UnitSuite) TestWorkloadVersion(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Check(version, gc.Equals, "3.combined")
 }
+
+func (s *UnitSuite) TestCharmState(c *gc.C) {
+	charmState, err := s.unit.CharmState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, gc.HasLen, 0)
+
+	err = s.unit.SetCharmState(map[string]string{"foo": "bar", "baz": "qux"}, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	charmState, err = s.unit.CharmState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, jc.DeepEquals, map[string]string{"foo": "bar", "baz": "qux"})
+
+	err = s.unit.SetCharmState(map[string]string{"foo": "quux"}, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	unit, err := s.State.Unit(s.unit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	charmState, err = unit.CharmState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, jc.DeepEquals, map[string]string{"foo": "quux"})
+}
+
+func (s *UnitSuite) TestCharmStateEscapedKeys(c *gc.C) {
+	charmState := map[string]string{"a.b": "1", "$c": "2"}
+	err := s.unit.SetCharmState(charmState, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	unit, err := s.State.Unit(s.unit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	stored, err := unit.CharmState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stored, jc.DeepEquals, charmState)
+
+	// Replacing the state removes the old escaped keys.
+	err = unit.SetCharmState(map[string]string{"a.b": "3"}, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	stored, err = unit.CharmState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stored, jc.DeepEquals, map[string]string{"a.b": "3"})
+}
+
+func (s *UnitSuite) TestSetCharmStateQuota(c *gc.C) {
+	err := s.unit.SetCharmState(map[string]string{"foo": "bar"}, 6)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.SetCharmState(map[string]string{"foo": "barbaz"}, 6)
+	c.Assert(err, gc.ErrorMatches, `cannot set charm state for unit "wordpress/0": state is 9 bytes, exceeding the limit of 6 bytes`)
+
+	charmState, err := s.unit.CharmState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, jc.DeepEquals, map[string]string{"foo": "bar"})
+}
+
+func (s *UnitSuite) TestSetCharmStateDeadUnit(c *gc.C) {
+	err := s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.SetCharmState(map[string]string{"foo": "bar"}, 0)
+	c.Assert(err, gc.ErrorMatches, `cannot set charm state for unit "wordpress/0": unit is dead`)
+}
+
+func (s *UnitSuite) TestRemoveUnitRemovesCharmState(c *gc.C) {
+	err := s.unit.SetCharmState(map[string]string{"foo": "bar"}, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.ReadSettings(state.SettingsC, "u#wordpress/0#charm#state")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *UnitSuite) TestHookExecutions(c *gc.C) {
+	executions, err := s.unit.HookExecutions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(executions, gc.HasLen, 0)
+
+	started := time.Date(2017, time.June, 1, 10, 0, 0, 0, time.UTC)
+	install := state.HookExecution{
+		Kind:     "hook",
+		Name:     "install",
+		Started:  started,
+		Finished: started.Add(time.Minute),
+		ToolCalls: []state.HookToolCall{{
+			Name:     "status-set",
+			Started:  started.Add(time.Second),
+			Duration: 50 * time.Millisecond,
+		}, {
+			Name:     "config-get",
+			Started:  started.Add(2 * time.Second),
+			Duration: 20 * time.Millisecond,
+			ExitCode: 1,
+		}},
+	}
+	start := state.HookExecution{
+		Kind:     "hook",
+		Name:     "start",
+		Started:  started.Add(2 * time.Minute),
+		Finished: started.Add(3 * time.Minute),
+		ExitCode: 1,
+		Error:    "exit status 1",
+	}
+	err = s.unit.RecordHookExecution(start)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.RecordHookExecution(install)
+	c.Assert(err, jc.ErrorIsNil)
+
+	executions, err = s.unit.HookExecutions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(executions, jc.DeepEquals, []state.HookExecution{install, start})
+}
+
+func (s *UnitSuite) TestHookExecutionsBounded(c *gc.C) {
+	started := time.Date(2017, time.June, 1, 10, 0, 0, 0, time.UTC)
+	total := state.MaxHookExecutionsPerUnit + 5
+	for i := 0; i < total; i++ {
+		err := s.unit.RecordHookExecution(state.HookExecution{
+			Kind:     "hook",
+			Name:     "update-status",
+			Started:  started.Add(time.Duration(i) * time.Minute),
+			Finished: started.Add(time.Duration(i)*time.Minute + time.Second),
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	executions, err := s.unit.HookExecutions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(executions, gc.HasLen, state.MaxHookExecutionsPerUnit)
+	c.Assert(executions[0].Started, gc.Equals, started.Add(5*time.Minute))
+	c.Assert(executions[len(executions)-1].Started, gc.Equals, started.Add(time.Duration(total-1)*time.Minute))
+}
+
+func (s *UnitSuite) TestHookExecutionToolCallsBounded(c *gc.C) {
+	started := time.Date(2017, time.June, 1, 10, 0, 0, 0, time.UTC)
+	execution := state.HookExecution{
+		Kind:             "hook",
+		Name:             "update-status",
+		Started:          started,
+		Finished:         started.Add(time.Minute),
+		DroppedToolCalls: 3,
+	}
+	for i := 0; i < state.MaxToolCallsPerHookExecution+2; i++ {
+		execution.ToolCalls = append(execution.ToolCalls, state.HookToolCall{
+			Name:    "status-get",
+			Started: started.Add(time.Duration(i) * time.Millisecond),
+		})
+	}
+	err := s.unit.RecordHookExecution(execution)
+	c.Assert(err, jc.ErrorIsNil)
+
+	executions, err := s.unit.HookExecutions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(executions, gc.HasLen, 1)
+	c.Assert(executions[0].ToolCalls, jc.DeepEquals, execution.ToolCalls[:state.MaxToolCallsPerHookExecution])
+	c.Assert(executions[0].DroppedToolCalls, gc.Equals, 5)
+}
+
+func (s *UnitSuite) TestRemoveUnitRemovesHookExecutions(c *gc.C) {
+	started := time.Date(2017, time.June, 1, 10, 0, 0, 0, time.UTC)
+	err := s.unit.RecordHookExecution(state.HookExecution{
+		Kind:     "action",
+		Name:     "backup",
+		Started:  started,
+		Finished: started.Add(time.Second),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	executions, err := s.unit.HookExecutions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(executions, gc.HasLen, 0)
+}
@@ -46,6 +46,11 @@ type Volume interface {
 	// if it has not already been provisioned. Params returns true if the
 	// returned parameters are usable for provisioning, otherwise false.
 	Params() (VolumeParams, bool)
+
+	// PendingSize returns the size, in MiB, that the volume has been
+	// requested to grow to. PendingSize returns true if there is a
+	// resize pending, otherwise false.
+	PendingSize() (uint64, bool)
 }
 
 // VolumeAttachment describes an attachment of a volume to a machine.
@@ -91,6 +96,10 @@ type volumeDoc struct {
 	Info            *VolumeInfo   `bson:"info,omitempty"`
 	Params          *VolumeParams `bson:"params,omitempty"`
 
+	// PendingSize, if non-zero, is the size in MiB that the
+	// provisioned volume has been requested to grow to.
+	PendingSize uint64 `bson:"pendingsize,omitempty"`
+
 	// MachineId is the ID of the machine that a non-detachable
 	// volume is initially attached to. We use this to identify
 	// the volume as being non-detachable, and to determine
@@ -118,6 +127,10 @@ type VolumeParams struct {
 
 	Pool string `bson:"pool"`
 	Size uint64 `bson:"size"`
+
+	// SnapshotId, if non-empty, is the ID of the volume snapshot
+	// from which the volume is to be created.
+	SnapshotId string `bson:"snapshotid,omitempty"`
 }
 
 // VolumeInfo describes information about a volume.
@@ -193,6 +206,11 @@ func (v *volume) Params() (VolumeParams, bool) {
 	return *v.doc.Params, true
 }
 
+// PendingSize is required to implement Volume.
+func (v *volume) PendingSize() (uint64, bool) {
+	return v.doc.PendingSize, v.doc.PendingSize != 0
+}
+
 // Status is required to implement StatusGetter.
 func (v *volume) Status() (status.StatusInfo, error) {
 	return v.st.VolumeStatus(v.VolumeTag())
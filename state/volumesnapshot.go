@@ -0,0 +1,379 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// VolumeSnapshot describes a point-in-time snapshot of a volume in
+// the model.
+type VolumeSnapshot interface {
+	Lifer
+
+	// Id returns the ID of the snapshot. Snapshots of machine-scoped
+	// volumes are scoped to the same machine, and their IDs are
+	// prefixed with the machine ID, as for volumes.
+	Id() string
+
+	// Volume returns the tag of the volume that the snapshot was
+	// taken of. The volume may since have been removed.
+	Volume() names.VolumeTag
+
+	// Pool returns the name of the storage pool that the snapshotted
+	// volume was created from. Volumes restored from the snapshot are
+	// created in the same pool.
+	Pool() string
+
+	// Created returns the time at which the snapshot was requested.
+	Created() time.Time
+
+	// Info returns the snapshot's VolumeSnapshotInfo, or a
+	// NotProvisioned error if the snapshot has not yet been taken.
+	Info() (VolumeSnapshotInfo, error)
+
+	// FailureReason returns the reason that the snapshot could not be
+	// taken, or the empty string if it has not failed.
+	FailureReason() string
+}
+
+// VolumeSnapshotInfo describes information about a volume snapshot.
+type VolumeSnapshotInfo struct {
+	SnapshotId string `bson:"snapshotid"`
+	Size       uint64 `bson:"size"`
+}
+
+type volumeSnapshot struct {
+	doc volumeSnapshotDoc
+}
+
+// volumeSnapshotDoc records information about a volume snapshot.
+type volumeSnapshotDoc struct {
+	DocID     string              `bson:"_id"`
+	Id        string              `bson:"id"`
+	ModelUUID string              `bson:"model-uuid"`
+	Life      Life                `bson:"life"`
+	VolumeId  string              `bson:"volumeid"`
+	Pool      string              `bson:"pool"`
+	Created   int64               `bson:"created"`
+	Info      *VolumeSnapshotInfo `bson:"info,omitempty"`
+	Failure   string              `bson:"failure,omitempty"`
+}
+
+// Id is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Id() string {
+	return s.doc.Id
+}
+
+// Life is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Life() Life {
+	return s.doc.Life
+}
+
+// Volume is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Volume() names.VolumeTag {
+	return names.NewVolumeTag(s.doc.VolumeId)
+}
+
+// Pool is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Pool() string {
+	return s.doc.Pool
+}
+
+// Created is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Created() time.Time {
+	return time.Unix(0, s.doc.Created).UTC()
+}
+
+// Info is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Info() (VolumeSnapshotInfo, error) {
+	if s.doc.Info == nil {
+		return VolumeSnapshotInfo{}, errors.NotProvisionedf("volume snapshot %q", s.doc.Id)
+	}
+	return *s.doc.Info, nil
+}
+
+// FailureReason is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) FailureReason() string {
+	return s.doc.Failure
+}
+
+// newVolumeSnapshotId returns a unique volume snapshot ID. If the
+// supplied volume is machine-scoped, the snapshot ID incorporates
+// the same machine scope.
+func newVolumeSnapshotId(st *State, volume names.VolumeTag) (string, error) {
+	seq, err := st.sequence("volumesnapshot")
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	id := fmt.Sprint(seq)
+	if i := strings.LastIndex(volume.Id(), "/"); i >= 0 {
+		id = volume.Id()[:i+1] + id
+	}
+	return id, nil
+}
+
+// AddVolumeSnapshot records a request to take a snapshot of the
+// specified volume, which must be alive and provisioned. The snapshot
+// is taken asynchronously by the storage provisioner.
+func (st *State) AddVolumeSnapshot(tag names.VolumeTag) (_ VolumeSnapshot, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot add snapshot of volume %s", tag.Id())
+	id, err := newVolumeSnapshotId(st, tag)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot generate volume snapshot ID")
+	}
+	var doc volumeSnapshotDoc
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		v, err := st.Volume(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if v.Life() != Alive {
+			return nil, errors.New("volume is not alive")
+		}
+		// A volume cannot go from being provisioned to unprovisioned,
+		// so there is no txn.Op for this below.
+		info, err := v.Info()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		doc = volumeSnapshotDoc{
+			Id:       id,
+			VolumeId: tag.Id(),
+			Pool:     info.Pool,
+			Created:  st.clock.Now().UnixNano(),
+		}
+		return []txn.Op{{
+			C:      volumesC,
+			Id:     tag.Id(),
+			Assert: isAliveDoc,
+		}, {
+			C:      volumeSnapshotsC,
+			Id:     id,
+			Assert: txn.DocMissing,
+			Insert: &doc,
+		}}, nil
+	}
+	if err := st.run(buildTxn); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &volumeSnapshot{doc}, nil
+}
+
+// VolumeSnapshot returns the VolumeSnapshot with the specified ID.
+func (st *State) VolumeSnapshot(id string) (VolumeSnapshot, error) {
+	return st.volumeSnapshot(id)
+}
+
+func (st *State) volumeSnapshot(id string) (*volumeSnapshot, error) {
+	coll, cleanup := st.getCollection(volumeSnapshotsC)
+	defer cleanup()
+
+	var s volumeSnapshot
+	err := coll.FindId(id).One(&s.doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("volume snapshot %q", id)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "getting volume snapshot %q", id)
+	}
+	return &s, nil
+}
+
+// AllVolumeSnapshots returns all VolumeSnapshots in the model.
+func (st *State) AllVolumeSnapshots() ([]VolumeSnapshot, error) {
+	coll, cleanup := st.getCollection(volumeSnapshotsC)
+	defer cleanup()
+
+	var docs []volumeSnapshotDoc
+	if err := coll.Find(nil).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get volume snapshots")
+	}
+	snapshots := make([]VolumeSnapshot, len(docs))
+	for i, doc := range docs {
+		snapshots[i] = &volumeSnapshot{doc}
+	}
+	return snapshots, nil
+}
+
+// SetVolumeSnapshotInfo sets the VolumeSnapshotInfo for the specified
+// volume snapshot, recording that the snapshot has been taken. If the
+// snapshot was destroyed while it was being taken, it becomes Dead so
+// that the storage provisioner will destroy it.
+func (st *State) SetVolumeSnapshotInfo(id string, info VolumeSnapshotInfo) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set info for volume snapshot %q", id)
+	if info.SnapshotId == "" {
+		return errors.New("snapshot ID not set")
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		s, err := st.volumeSnapshot(id)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if oldInfo, err := s.Info(); err == nil {
+			if oldInfo != info {
+				return nil, errors.New("snapshot info already set")
+			}
+			return nil, jujutxn.ErrNoOperations
+		}
+		set := bson.D{{"info", &info}}
+		if s.doc.Life == Dying {
+			set = append(set, bson.DocElem{"life", Dead})
+		}
+		return []txn.Op{{
+			C:  volumeSnapshotsC,
+			Id: id,
+			Assert: bson.D{
+				{"life", s.doc.Life},
+				{"info", bson.D{{"$exists", false}}},
+			},
+			Update: bson.D{
+				{"$set", set},
+				{"$unset", bson.D{{"failure", nil}}},
+			},
+		}}, nil
+	}
+	return st.run(buildTxn)
+}
+
+// SetVolumeSnapshotFailed records that the specified volume snapshot
+// could not be taken, and the reason why. If the snapshot was destroyed
+// while it was being taken, there is nothing left to destroy, and it is
+// removed.
+func (st *State) SetVolumeSnapshotFailed(id string, reason string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set failure for volume snapshot %q", id)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		s, err := st.volumeSnapshot(id)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if _, err := s.Info(); err == nil {
+			return nil, errors.New("snapshot already taken")
+		}
+		op := txn.Op{
+			C:  volumeSnapshotsC,
+			Id: id,
+			Assert: bson.D{
+				{"life", s.doc.Life},
+				{"info", bson.D{{"$exists", false}}},
+			},
+		}
+		if s.doc.Life == Dying {
+			op.Remove = true
+		} else {
+			op.Update = bson.D{{"$set", bson.D{{"failure", reason}}}}
+		}
+		return []txn.Op{op}, nil
+	}
+	return st.run(buildTxn)
+}
+
+// DestroyVolumeSnapshot ensures that the volume snapshot with the
+// specified ID will be removed. A snapshot that has been taken becomes
+// Dead, to be destroyed by the storage provisioner and then removed; a
+// snapshot that could not be taken is removed immediately; and a
+// snapshot that is still being taken becomes Dying, and then Dead once
+// it has been taken. A snapshot cannot be destroyed while volumes are
+// waiting to be restored from it.
+func (st *State) DestroyVolumeSnapshot(id string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot destroy volume snapshot %q", id)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		s, err := st.volumeSnapshot(id)
+		if errors.IsNotFound(err) {
+			return nil, jujutxn.ErrNoOperations
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if s.doc.Life != Alive {
+			return nil, jujutxn.ErrNoOperations
+		}
+		n, err := st.countVolumesRestoringSnapshot(id)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if n > 0 {
+			return nil, errors.Errorf("snapshot is being restored to %d volume(s)", n)
+		}
+		return destroyVolumeSnapshotOps(s), nil
+	}
+	return st.run(buildTxn)
+}
+
+func destroyVolumeSnapshotOps(s *volumeSnapshot) []txn.Op {
+	switch {
+	case s.doc.Info != nil:
+		return []txn.Op{{
+			C:  volumeSnapshotsC,
+			Id: s.doc.Id,
+			Assert: append(isAliveDoc, bson.DocElem{
+				"info", bson.D{{"$exists", true}},
+			}),
+			Update: bson.D{{"$set", bson.D{{"life", Dead}}}},
+		}}
+	case s.doc.Failure != "":
+		return []txn.Op{{
+			C:  volumeSnapshotsC,
+			Id: s.doc.Id,
+			Assert: append(isAliveDoc, bson.DocElem{
+				"failure", bson.D{{"$exists", true}},
+			}),
+			Remove: true,
+		}}
+	}
+	return []txn.Op{{
+		C:  volumeSnapshotsC,
+		Id: s.doc.Id,
+		Assert: append(isAliveDoc,
+			bson.DocElem{"info", bson.D{{"$exists", false}}},
+			bson.DocElem{"failure", bson.D{{"$exists", false}}},
+		),
+		Update: bson.D{{"$set", bson.D{{"life", Dying}}}},
+	}}
+}
+
+// countVolumesRestoringSnapshot returns the number of volumes that are
+// waiting to be provisioned from the volume snapshot with the specified
+// ID. A volume's params are removed once it is provisioned.
+func (st *State) countVolumesRestoringSnapshot(id string) (int, error) {
+	coll, cleanup := st.getCollection(volumesC)
+	defer cleanup()
+	n, err := coll.Find(bson.D{{"params.snapshotid", id}}).Count()
+	if err != nil {
+		return -1, errors.Annotate(err, "counting volumes restoring snapshot")
+	}
+	return n, nil
+}
+
+// RemoveVolumeSnapshot removes the volume snapshot with the specified
+// ID, which must be Dead. If the snapshot does not exist, this is a
+// no-op.
+func (st *State) RemoveVolumeSnapshot(id string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot remove volume snapshot %q", id)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		s, err := st.volumeSnapshot(id)
+		if errors.IsNotFound(err) {
+			return nil, jujutxn.ErrNoOperations
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if s.doc.Life != Dead {
+			return nil, errors.New("volume snapshot is not dead")
+		}
+		return []txn.Op{{
+			C:      volumeSnapshotsC,
+			Id:     id,
+			Assert: isDeadDoc,
+			Remove: true,
+		}}, nil
+	}
+	return st.run(buildTxn)
+}
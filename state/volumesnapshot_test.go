@@ -0,0 +1,348 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/testing"
+)
+
+type VolumeSnapshotStateSuite struct {
+	StorageStateSuiteBase
+}
+
+var _ = gc.Suite(&VolumeSnapshotStateSuite{})
+
+// setupProvisionedVolume adds a unit with a provisioned volume from
+// the specified pool, and returns the unit and volume tag.
+func (s *VolumeSnapshotStateSuite) setupProvisionedVolume(c *gc.C, pool string) (*state.Unit, names.VolumeTag) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", pool)
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	volumeTag := s.storageInstanceVolume(c, storageTag).VolumeTag()
+	err = s.State.SetVolumeInfo(volumeTag, state.VolumeInfo{
+		VolumeId: "vol-123",
+		Size:     1024,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return u, volumeTag
+}
+
+func (s *VolumeSnapshotStateSuite) TestAddVolumeSnapshot(c *gc.C) {
+	_, volumeTag := s.setupProvisionedVolume(c, "modelscoped")
+	snapshot, err := s.State.AddVolumeSnapshot(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshot.Id(), gc.Equals, "0")
+	c.Assert(snapshot.Volume(), gc.Equals, volumeTag)
+	c.Assert(snapshot.Pool(), gc.Equals, "modelscoped")
+	c.Assert(snapshot.Life(), gc.Equals, state.Alive)
+	c.Assert(snapshot.FailureReason(), gc.Equals, "")
+	_, err = snapshot.Info()
+	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
+
+	snapshot, err = s.State.VolumeSnapshot("0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshot.Volume(), gc.Equals, volumeTag)
+
+	all, err := s.State.AllVolumeSnapshots()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 1)
+	c.Assert(all[0].Id(), gc.Equals, "0")
+}
+
+func (s *VolumeSnapshotStateSuite) TestAddVolumeSnapshotMachineScoped(c *gc.C) {
+	_, volumeTag := s.setupProvisionedVolume(c, "loop-pool")
+	c.Assert(volumeTag.Id(), gc.Equals, "0/0")
+	snapshot, err := s.State.AddVolumeSnapshot(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshot.Id(), gc.Equals, "0/0")
+	c.Assert(snapshot.Pool(), gc.Equals, "loop-pool")
+}
+
+func (s *VolumeSnapshotStateSuite) TestAddVolumeSnapshotNotProvisioned(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "modelscoped")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	volumeTag := s.storageInstanceVolume(c, storageTag).VolumeTag()
+	_, err = s.State.AddVolumeSnapshot(volumeTag)
+	c.Assert(err, gc.ErrorMatches, `cannot add snapshot of volume 0: volume "0" not provisioned`)
+	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
+}
+
+func (s *VolumeSnapshotStateSuite) TestAddVolumeSnapshotVolumeNotFound(c *gc.C) {
+	_, err := s.State.AddVolumeSnapshot(names.NewVolumeTag("42"))
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *VolumeSnapshotStateSuite) TestSetVolumeSnapshotInfo(c *gc.C) {
+	_, volumeTag := s.setupProvisionedVolume(c, "modelscoped")
+	_, err := s.State.AddVolumeSnapshot(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.SetVolumeSnapshotFailed("0", "out of quota")
+	c.Assert(err, jc.ErrorIsNil)
+	snapshot, err := s.State.VolumeSnapshot("0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshot.FailureReason(), gc.Equals, "out of quota")
+
+	info := state.VolumeSnapshotInfo{SnapshotId: "snap-123", Size: 1024}
+	err = s.State.SetVolumeSnapshotInfo("0", info)
+	c.Assert(err, jc.ErrorIsNil)
+	snapshot, err = s.State.VolumeSnapshot("0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshot.FailureReason(), gc.Equals, "")
+	infoGet, err := snapshot.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(infoGet, jc.DeepEquals, info)
+
+	// Setting the same info again is a no-op, but it
+	// cannot be changed once set.
+	err = s.State.SetVolumeSnapshotInfo("0", info)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetVolumeSnapshotInfo("0", state.VolumeSnapshotInfo{SnapshotId: "snap-456"})
+	c.Assert(err, gc.ErrorMatches, `cannot set info for volume snapshot "0": snapshot info already set`)
+	err = s.State.SetVolumeSnapshotFailed("0", "oops")
+	c.Assert(err, gc.ErrorMatches, `cannot set failure for volume snapshot "0": snapshot already taken`)
+}
+
+func (s *VolumeSnapshotStateSuite) TestSetVolumeSnapshotInfoNoSnapshotId(c *gc.C) {
+	err := s.State.SetVolumeSnapshotInfo("0", state.VolumeSnapshotInfo{Size: 1024})
+	c.Assert(err, gc.ErrorMatches, `cannot set info for volume snapshot "0": snapshot ID not set`)
+}
+
+func (s *VolumeSnapshotStateSuite) TestWatchModelVolumeSnapshots(c *gc.C) {
+	_, volumeTag := s.setupProvisionedVolume(c, "modelscoped")
+
+	w := s.State.WatchModelVolumeSnapshots()
+	defer testing.AssertStop(c, w)
+	wc := testing.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChangeInSingleEvent() // initial
+	wc.AssertNoChange()
+
+	_, err := s.State.AddVolumeSnapshot(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent("0")
+	wc.AssertNoChange()
+}
+
+func (s *VolumeSnapshotStateSuite) TestWatchMachineVolumeSnapshots(c *gc.C) {
+	_, volumeTag := s.setupProvisionedVolume(c, "loop-pool")
+
+	w := s.State.WatchMachineVolumeSnapshots(names.NewMachineTag("0"))
+	defer testing.AssertStop(c, w)
+	wc := testing.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChangeInSingleEvent() // initial
+	wc.AssertNoChange()
+
+	_, err := s.State.AddVolumeSnapshot(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent("0/0")
+	wc.AssertNoChange()
+}
+
+func (s *VolumeSnapshotStateSuite) addTakenSnapshot(c *gc.C, pool string) *state.Unit {
+	u, volumeTag := s.setupProvisionedVolume(c, pool)
+	snapshot, err := s.State.AddVolumeSnapshot(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetVolumeSnapshotInfo(snapshot.Id(), state.VolumeSnapshotInfo{
+		SnapshotId: "snap-123",
+		Size:       2048,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return u
+}
+
+func (s *VolumeSnapshotStateSuite) TestAddStorageForUnitFromSnapshot(c *gc.C) {
+	u := s.addTakenSnapshot(c, "modelscoped")
+	err := s.State.AddStorageForUnitFromSnapshot(u.UnitTag(), "allecto", "0", state.StorageConstraints{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	volume := s.storageInstanceVolume(c, names.NewStorageTag("allecto/1"))
+	params, ok := volume.Params()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(params.Pool, gc.Equals, "modelscoped")
+	c.Assert(params.Size, gc.Equals, uint64(2048))
+	c.Assert(params.SnapshotId, gc.Equals, "0")
+}
+
+func (s *VolumeSnapshotStateSuite) TestAddStorageForUnitFromSnapshotLarger(c *gc.C) {
+	u := s.addTakenSnapshot(c, "modelscoped")
+	err := s.State.AddStorageForUnitFromSnapshot(u.UnitTag(), "allecto", "0", state.StorageConstraints{
+		Size: 4096,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	volume := s.storageInstanceVolume(c, names.NewStorageTag("allecto/1"))
+	params, ok := volume.Params()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(params.Size, gc.Equals, uint64(4096))
+}
+
+func (s *VolumeSnapshotStateSuite) TestAddStorageForUnitFromSnapshotErrors(c *gc.C) {
+	u := s.addTakenSnapshot(c, "modelscoped")
+	for i, test := range []struct {
+		snapshot string
+		cons     state.StorageConstraints
+		err      string
+	}{{
+		snapshot: "42",
+		err:      `volume snapshot "42" not found`,
+	}, {
+		snapshot: "0",
+		cons:     state.StorageConstraints{Size: 1024},
+		err:      `size 1024M is smaller than snapshot size 2048M`,
+	}, {
+		snapshot: "0",
+		cons:     state.StorageConstraints{Pool: "loop-pool"},
+		err:      `cannot create storage in pool "loop-pool" from snapshot of pool "modelscoped"`,
+	}, {
+		snapshot: "0",
+		cons:     state.StorageConstraints{Count: 2},
+		err:      `adding 2 storage instances from one snapshot not valid`,
+	}} {
+		c.Logf("test %d: %+v", i, test)
+		err := s.State.AddStorageForUnitFromSnapshot(u.UnitTag(), "allecto", test.snapshot, test.cons)
+		c.Check(err, gc.ErrorMatches, `adding "allecto" storage to storage-block/0 from snapshot `+test.snapshot+`: `+test.err)
+	}
+}
+
+func (s *VolumeSnapshotStateSuite) TestAddStorageForUnitFromSnapshotNotTaken(c *gc.C) {
+	u, volumeTag := s.setupProvisionedVolume(c, "modelscoped")
+	_, err := s.State.AddVolumeSnapshot(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AddStorageForUnitFromSnapshot(u.UnitTag(), "allecto", "0", state.StorageConstraints{})
+	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
+}
+
+func (s *VolumeSnapshotStateSuite) TestDestroyVolumeSnapshotTaken(c *gc.C) {
+	s.addTakenSnapshot(c, "modelscoped")
+	err := s.State.DestroyVolumeSnapshot("0")
+	c.Assert(err, jc.ErrorIsNil)
+	snapshot, err := s.State.VolumeSnapshot("0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshot.Life(), gc.Equals, state.Dead)
+
+	// Destroying again is a no-op.
+	err = s.State.DestroyVolumeSnapshot("0")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RemoveVolumeSnapshot("0")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.VolumeSnapshot("0")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	// Removing or destroying a removed snapshot is a no-op.
+	err = s.State.RemoveVolumeSnapshot("0")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.DestroyVolumeSnapshot("0")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *VolumeSnapshotStateSuite) TestDestroyVolumeSnapshotFailed(c *gc.C) {
+	_, volumeTag := s.setupProvisionedVolume(c, "modelscoped")
+	_, err := s.State.AddVolumeSnapshot(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetVolumeSnapshotFailed("0", "out of quota")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.DestroyVolumeSnapshot("0")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.VolumeSnapshot("0")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *VolumeSnapshotStateSuite) TestDestroyVolumeSnapshotPendingTaken(c *gc.C) {
+	_, volumeTag := s.setupProvisionedVolume(c, "modelscoped")
+	_, err := s.State.AddVolumeSnapshot(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.DestroyVolumeSnapshot("0")
+	c.Assert(err, jc.ErrorIsNil)
+	snapshot, err := s.State.VolumeSnapshot("0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshot.Life(), gc.Equals, state.Dying)
+
+	err = s.State.RemoveVolumeSnapshot("0")
+	c.Assert(err, gc.ErrorMatches, `cannot remove volume snapshot "0": volume snapshot is not dead`)
+
+	// Once taken, the snapshot is Dead and ready to be destroyed.
+	err = s.State.SetVolumeSnapshotInfo("0", state.VolumeSnapshotInfo{SnapshotId: "snap-123"})
+	c.Assert(err, jc.ErrorIsNil)
+	snapshot, err = s.State.VolumeSnapshot("0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshot.Life(), gc.Equals, state.Dead)
+}
+
+func (s *VolumeSnapshotStateSuite) TestDestroyVolumeSnapshotPendingFailed(c *gc.C) {
+	_, volumeTag := s.setupProvisionedVolume(c, "modelscoped")
+	_, err := s.State.AddVolumeSnapshot(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.DestroyVolumeSnapshot("0")
+	c.Assert(err, jc.ErrorIsNil)
+
+	// There is nothing to destroy if the snapshot
+	// could not be taken, so it is removed.
+	err = s.State.SetVolumeSnapshotFailed("0", "out of quota")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.VolumeSnapshot("0")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *VolumeSnapshotStateSuite) TestDestroyVolumeSnapshotBeingRestored(c *gc.C) {
+	u := s.addTakenSnapshot(c, "modelscoped")
+	err := s.State.AddStorageForUnitFromSnapshot(u.UnitTag(), "allecto", "0", state.StorageConstraints{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.DestroyVolumeSnapshot("0")
+	c.Assert(err, gc.ErrorMatches, `cannot destroy volume snapshot "0": snapshot is being restored to 1 volume\(s\)`)
+
+	// Once the volume is provisioned, the snapshot may be destroyed.
+	volume := s.storageInstanceVolume(c, names.NewStorageTag("allecto/1"))
+	err = s.State.SetVolumeInfo(volume.VolumeTag(), state.VolumeInfo{VolumeId: "vol-456", Size: 2048})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.DestroyVolumeSnapshot("0")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *VolumeSnapshotStateSuite) TestAddStorageForUnitFromSnapshotNotAlive(c *gc.C) {
+	u := s.addTakenSnapshot(c, "modelscoped")
+	err := s.State.DestroyVolumeSnapshot("0")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AddStorageForUnitFromSnapshot(u.UnitTag(), "allecto", "0", state.StorageConstraints{})
+	c.Assert(err, gc.ErrorMatches, `adding "allecto" storage to storage-block/0 from snapshot 0: snapshot is not alive`)
+}
+
+func (s *VolumeSnapshotStateSuite) TestWatchModelVolumeSnapshotsDestroyed(c *gc.C) {
+	s.addTakenSnapshot(c, "modelscoped")
+
+	w := s.State.WatchModelVolumeSnapshots()
+	defer testing.AssertStop(c, w)
+	wc := testing.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChangeInSingleEvent("0") // initial
+	wc.AssertNoChange()
+
+	err := s.State.DestroyVolumeSnapshot("0")
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent("0")
+	wc.AssertNoChange()
+}
+
+func (s *VolumeSnapshotStateSuite) TestCleanupVolumeSnapshotsForDyingModel(c *gc.C) {
+	s.addTakenSnapshot(c, "modelscoped")
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	err = model.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+	snapshot, err := s.State.VolumeSnapshot("0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshot.Life(), gc.Equals, state.Dead)
+}
@@ -229,6 +229,40 @@ func (st *State) WatchModelFilesystems() StringsWatcher {
 	return st.watchModelMachinestorage(filesystemsC)
 }
 
+// WatchModelVolumeSnapshots returns a StringsWatcher that notifies of
+// changes to the lifecycles of all snapshots of model-scoped volumes.
+func (st *State) WatchModelVolumeSnapshots() StringsWatcher {
+	return st.watchModelMachinestorage(volumeSnapshotsC)
+}
+
+// WatchModelVolumeResizes returns a StringsWatcher that notifies of
+// changes to model-scoped volumes, including requests to resize them.
+func (st *State) WatchModelVolumeResizes() StringsWatcher {
+	return st.watchModelMachinestorageResizes(volumesC)
+}
+
+// WatchModelFilesystemResizes returns a StringsWatcher that notifies of
+// changes to model-scoped filesystems, including requests to resize them.
+func (st *State) WatchModelFilesystemResizes() StringsWatcher {
+	return st.watchModelMachinestorageResizes(filesystemsC)
+}
+
+// watchModelMachinestorageResizes returns a StringsWatcher that notifies
+// of any change to the model-scoped storage entities in the collection.
+// Unlike the lifecycle watchers, this reports changes to pending sizes.
+func (st *State) watchModelMachinestorageResizes(collection string) StringsWatcher {
+	return newCollectionWatcher(st, colWCfg{
+		col: collection,
+		filter: func(id interface{}) bool {
+			k, err := st.strictLocalID(id.(string))
+			if err != nil {
+				return false
+			}
+			return !strings.Contains(k, "/")
+		},
+	})
+}
+
 func (st *State) watchModelMachinestorage(collection string) StringsWatcher {
 	pattern := fmt.Sprintf("^%s$", st.docID(names.NumberSnippet))
 	members := bson.D{{"_id", bson.D{{"$regex", pattern}}}}
@@ -254,6 +288,41 @@ func (st *State) WatchMachineFilesystems(m names.MachineTag) StringsWatcher {
 	return st.watchMachineStorage(m, filesystemsC)
 }
 
+// WatchMachineVolumeSnapshots returns a StringsWatcher that notifies of
+// changes to the lifecycles of all volume snapshots scoped to the
+// specified machine.
+func (st *State) WatchMachineVolumeSnapshots(m names.MachineTag) StringsWatcher {
+	return st.watchMachineStorage(m, volumeSnapshotsC)
+}
+
+// WatchMachineVolumeResizes returns a StringsWatcher that notifies of
+// changes to the volumes scoped to the specified machine, including
+// requests to resize them.
+func (st *State) WatchMachineVolumeResizes(m names.MachineTag) StringsWatcher {
+	return st.watchMachineStorageResizes(m, volumesC)
+}
+
+// WatchMachineFilesystemResizes returns a StringsWatcher that notifies of
+// changes to the filesystems scoped to the specified machine, including
+// requests to resize them.
+func (st *State) WatchMachineFilesystemResizes(m names.MachineTag) StringsWatcher {
+	return st.watchMachineStorageResizes(m, filesystemsC)
+}
+
+func (st *State) watchMachineStorageResizes(m names.MachineTag, collection string) StringsWatcher {
+	prefix := m.Id() + "/"
+	return newCollectionWatcher(st, colWCfg{
+		col: collection,
+		filter: func(id interface{}) bool {
+			k, err := st.strictLocalID(id.(string))
+			if err != nil {
+				return false
+			}
+			return strings.HasPrefix(k, prefix)
+		},
+	})
+}
+
 func (st *State) watchMachineStorage(m names.MachineTag, collection string) StringsWatcher {
 	pattern := fmt.Sprintf("^%s/%s$", st.docID(m.Id()), names.NumberSnippet)
 	members := bson.D{{"_id", bson.D{{"$regex", pattern}}}}
@@ -277,9 +346,27 @@ func (st *State) WatchEnvironVolumeAttachments() StringsWatcher {
 
 // WatchEnvironFilesystemAttachments returns a StringsWatcher that notifies
 // of changes to the lifecycles of all filesystem attachments related to
-// environ-scoped filesystems.
+// environ-scoped filesystems. Attachments of environ-scoped filesystems
+// that are backed by volumes are managed by the machines they are
+// attached to, and are not reported.
 func (st *State) WatchEnvironFilesystemAttachments() StringsWatcher {
-	return st.watchModelMachinestorageAttachments(filesystemAttachmentsC)
+	isVolumeBacked := st.volumeBackedFilesystemFunc()
+	filter := func(id interface{}) bool {
+		k, err := st.strictLocalID(id.(string))
+		if err != nil {
+			return false
+		}
+		colon := strings.IndexRune(k, ':')
+		if colon == -1 {
+			return false
+		}
+		filesystemId := k[colon+1:]
+		if strings.Contains(filesystemId, "/") {
+			return false
+		}
+		return !isVolumeBacked(filesystemId)
+	}
+	return newLifecycleWatcher(st, filesystemAttachmentsC, nil, filter, nil)
 }
 
 func (st *State) watchModelMachinestorageAttachments(collection string) StringsWatcher {
@@ -308,9 +395,54 @@ func (st *State) WatchMachineVolumeAttachments(m names.MachineTag) StringsWatche
 
 // WatchMachineFilesystemAttachments returns a StringsWatcher that notifies of
 // changes to the lifecycles of all filesystem attachments related to the specified
-// machine, for filesystems scoped to the machine.
+// machine, for filesystems scoped to the machine, and for volume-backed
+// filesystems scoped to the model (e.g. imported filesystems).
 func (st *State) WatchMachineFilesystemAttachments(m names.MachineTag) StringsWatcher {
-	return st.watchMachineStorageAttachments(m, filesystemAttachmentsC)
+	prefix := m.Id() + ":"
+	machinePrefix := prefix + m.Id() + "/"
+	isVolumeBacked := st.volumeBackedFilesystemFunc()
+	filter := func(id interface{}) bool {
+		k, err := st.strictLocalID(id.(string))
+		if err != nil {
+			return false
+		}
+		if strings.HasPrefix(k, machinePrefix) {
+			return true
+		}
+		if !strings.HasPrefix(k, prefix) {
+			return false
+		}
+		filesystemId := k[len(prefix):]
+		if strings.Contains(filesystemId, "/") {
+			return false
+		}
+		return isVolumeBacked(filesystemId)
+	}
+	return newLifecycleWatcher(st, filesystemAttachmentsC, nil, filter, nil)
+}
+
+// volumeBackedFilesystemFunc returns a function that reports whether
+// the filesystem with the specified ID is backed by a volume. Whether
+// or not a filesystem is volume-backed never changes, so the results
+// are cached; the returned function must not be called concurrently.
+func (st *State) volumeBackedFilesystemFunc() func(filesystemId string) bool {
+	cache := make(map[string]bool)
+	return func(filesystemId string) bool {
+		if volumeBacked, ok := cache[filesystemId]; ok {
+			return volumeBacked
+		}
+		if !names.IsValidFilesystem(filesystemId) {
+			return false
+		}
+		f, err := st.filesystemByTag(names.NewFilesystemTag(filesystemId))
+		if err != nil {
+			return false
+		}
+		_, err = f.Volume()
+		volumeBacked := err == nil
+		cache[filesystemId] = volumeBacked
+		return volumeBacked
+	}
 }
 
 func (st *State) watchMachineStorageAttachments(m names.MachineTag, collection string) StringsWatcher {
@@ -912,19 +1044,28 @@ func (w *RelationScopeWatcher) loop() error {
 
 // relationUnitsWatcher sends notifications of units entering and leaving the
 // scope of a RelationUnit, and changes to the settings of those units known
-// to have entered.
+// to have entered, and of the application-level settings of the
+// counterpart applications.
 type relationUnitsWatcher struct {
 	commonWatcher
 	sw       *RelationScopeWatcher
+	appKeys  map[string]string
 	watching set.Strings
 	updates  chan watcher.Change
 	out      chan params.RelationUnitsChange
 }
 
 // Watch returns a watcher that notifies of changes to conterpart units in
-// the relation.
+// the relation, and to the application settings of their applications.
 func (ru *RelationUnit) Watch() RelationUnitsWatcher {
-	return newRelationUnitsWatcher(ru.st, ru.WatchScope())
+	appKeys := make(map[string]string)
+	if related, err := ru.relation.RelatedEndpoints(ru.endpoint.ApplicationName); err == nil {
+		for _, ep := range related {
+			key := relationApplicationSettingsKey(ru.relation.Id(), ep.ApplicationName)
+			appKeys[key] = ep.ApplicationName
+		}
+	}
+	return newRelationUnitsWatcher(ru.st, ru.WatchScope(), appKeys)
 }
 
 // WatchUnits returns a watcher that notifies of changes to the units of the
@@ -947,13 +1088,17 @@ func (r *Relation) watchUnits(applicationName string, counterpart bool) (Relatio
 		role = counterpartRole(role)
 	}
 	rsw := watchRelationScope(r.st, r.globalScope(), role, "")
-	return newRelationUnitsWatcher(r.st, rsw), nil
+	return newRelationUnitsWatcher(r.st, rsw, nil), nil
 }
 
-func newRelationUnitsWatcher(backend modelBackend, sw *RelationScopeWatcher) RelationUnitsWatcher {
+// newRelationUnitsWatcher returns a watcher of the units in the scope
+// watched by sw. appKeys maps the keys of the application settings to
+// watch to the names of their applications.
+func newRelationUnitsWatcher(backend modelBackend, sw *RelationScopeWatcher, appKeys map[string]string) RelationUnitsWatcher {
 	w := &relationUnitsWatcher{
 		commonWatcher: newCommonWatcher(backend),
 		sw:            sw,
+		appKeys:       appKeys,
 		watching:      make(set.Strings),
 		updates:       make(chan watcher.Change),
 		out:           make(chan params.RelationUnitsChange),
@@ -974,7 +1119,7 @@ func (w *relationUnitsWatcher) Changes() <-chan params.RelationUnitsChange {
 }
 
 func emptyRelationUnitsChanges(changes *params.RelationUnitsChange) bool {
-	return len(changes.Changed)+len(changes.Departed) == 0
+	return len(changes.Changed)+len(changes.AppChanged)+len(changes.Departed) == 0
 }
 
 func setRelationUnitChangeVersion(changes *params.RelationUnitsChange, key string, version int64) {
@@ -1001,6 +1146,27 @@ func (w *relationUnitsWatcher) mergeSettings(changes *params.RelationUnitsChange
 	return doc.TxnRevno, nil
 }
 
+// mergeAppSettings reads the application settings node with the supplied
+// key, and sets a value in the AppChanged field keyed on the application's
+// name. It returns the mgo/txn revision number of the settings node, or -1
+// if the node does not yet exist.
+func (w *relationUnitsWatcher) mergeAppSettings(changes *params.RelationUnitsChange, key string) (int64, error) {
+	var doc struct {
+		TxnRevno int64 `bson:"txn-revno"`
+		Version  int64 `bson:"version"`
+	}
+	doc.TxnRevno = -1
+	err := readSettingsDocInto(w.backend, settingsC, key, &doc)
+	if err != nil && !errors.IsNotFound(err) {
+		return -1, err
+	}
+	if changes.AppChanged == nil {
+		changes.AppChanged = map[string]int64{}
+	}
+	changes.AppChanged[w.appKeys[key]] = doc.Version
+	return doc.TxnRevno, nil
+}
+
 // mergeScope starts and stops settings watches on the units entering and
 // leaving the scope in the supplied RelationScopeChange event, and applies
 // the expressed changes to the supplied RelationUnitsChange event.
@@ -1056,6 +1222,15 @@ func (w *relationUnitsWatcher) loop() (err error) {
 		changes     params.RelationUnitsChange
 		out         chan<- params.RelationUnitsChange
 	)
+	for key := range w.appKeys {
+		revno, err := w.mergeAppSettings(&changes, key)
+		if err != nil {
+			return err
+		}
+		docID := w.backend.docID(key)
+		w.watcher.Watch(settingsC, docID, revno, w.updates)
+		w.watching.Add(docID)
+	}
 	for {
 		select {
 		case <-w.watcher.Dead():
@@ -1079,7 +1254,11 @@ func (w *relationUnitsWatcher) loop() (err error) {
 			if !ok {
 				logger.Warningf("ignoring bad relation scope id: %#v", c.Id)
 			}
-			if _, err := w.mergeSettings(&changes, id); err != nil {
+			if key := w.backend.localID(id); w.appKeys[key] != "" {
+				if _, err := w.mergeAppSettings(&changes, key); err != nil {
+					return err
+				}
+			} else if _, err := w.mergeSettings(&changes, id); err != nil {
 				return err
 			}
 			out = w.out
@@ -1385,6 +1564,12 @@ func (st *State) WatchStorageAttachment(s names.StorageTag, u names.UnitTag) Not
 	return newEntityWatcher(st, storageAttachmentsC, st.docID(id))
 }
 
+// WatchFilesystem returns a watcher for observing changes to a
+// filesystem.
+func (st *State) WatchFilesystem(f names.FilesystemTag) NotifyWatcher {
+	return newEntityWatcher(st, filesystemsC, st.docID(f.Id()))
+}
+
 // WatchVolumeAttachment returns a watcher for observing changes
 // to a volume attachment.
 func (st *State) WatchVolumeAttachment(m names.MachineTag, v names.VolumeTag) NotifyWatcher {
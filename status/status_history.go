@@ -17,6 +17,9 @@ type StatusHistoryFilter struct {
 	Size int
 	// FromDate indicates the earliest date from which logs are expected.
 	FromDate *time.Time
+	// ToDate, if set, indicates the date before which logs are expected.
+	// It may be combined with any of the other filters.
+	ToDate *time.Time
 	// Delta indicates the age of the oldest log expected.
 	Delta *time.Duration
 	// Exclude indicates the status messages that should be excluded
@@ -39,6 +42,8 @@ func (f *StatusHistoryFilter) Validate() error {
 		return errors.NotValidf("Size and Delta together")
 	case t && d:
 		return errors.NotValidf("Date and Delta together")
+	case t && f.ToDate != nil && !f.ToDate.After(*f.FromDate):
+		return errors.NotValidf("ToDate not after FromDate")
 	}
 	return nil
 }
@@ -106,3 +106,19 @@ func (h *statusHistorySuite) TestStatusSquashing(c *gc.C) {
 
 	c.Assert(newStatuses, gc.DeepEquals, expectedStatuses)
 }
+
+func (h *statusHistorySuite) TestFilterValidateToDate(c *gc.C) {
+	from := time.Date(2017, time.June, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+	filter := status.StatusHistoryFilter{FromDate: &from, ToDate: &to}
+	c.Assert(filter.Validate(), gc.IsNil)
+
+	filter = status.StatusHistoryFilter{Size: 10, ToDate: &to}
+	c.Assert(filter.Validate(), gc.IsNil)
+
+	filter = status.StatusHistoryFilter{ToDate: &to}
+	c.Assert(filter.Validate(), gc.ErrorMatches, "missing filter parameters not valid")
+
+	filter = status.StatusHistoryFilter{FromDate: &to, ToDate: &from}
+	c.Assert(filter.Validate(), gc.ErrorMatches, "ToDate not after FromDate not valid")
+}
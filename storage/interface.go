@@ -127,6 +127,29 @@ type VolumeSource interface {
 	DetachVolumes(params []VolumeAttachmentParams) ([]error, error)
 }
 
+// VolumeSnapshotter is an optional interface that a VolumeSource may
+// implement if it supports taking point-in-time snapshots of volumes.
+// A VolumeSource that implements VolumeSnapshotter must also honour
+// VolumeParams.SnapshotId when creating volumes.
+type VolumeSnapshotter interface {
+	// CreateVolumeSnapshots creates snapshots of the volumes with the
+	// specified parameters.
+	CreateVolumeSnapshots(params []VolumeSnapshotParams) ([]CreateVolumeSnapshotsResult, error)
+
+	// DestroyVolumeSnapshots destroys the volume snapshots with the
+	// specified provider snapshot IDs. Destroying a snapshot that
+	// does not exist is not an error.
+	DestroyVolumeSnapshots(snapshotIds []string) ([]error, error)
+}
+
+// VolumeResizer is an optional interface that a VolumeSource may
+// implement if it supports growing volumes while they are in use.
+type VolumeResizer interface {
+	// ResizeVolumes grows the volumes with the specified parameters.
+	// Volumes may only be grown, never shrunk.
+	ResizeVolumes(params []VolumeResizeParams) ([]ResizeVolumesResult, error)
+}
+
 // FilesystemSource provides an interface for creating, destroying and
 // describing filesystems in the environment. A FilesystemSource is
 // configured in a particular way, and corresponds to a storage "pool".
@@ -160,6 +183,15 @@ type FilesystemSource interface {
 	DetachFilesystems(params []FilesystemAttachmentParams) ([]error, error)
 }
 
+// FilesystemResizer is an optional interface that a FilesystemSource
+// may implement if it supports growing filesystems while they are in
+// use.
+type FilesystemResizer interface {
+	// ResizeFilesystems grows the filesystems with the specified
+	// parameters. Filesystems may only be grown, never shrunk.
+	ResizeFilesystems(params []FilesystemResizeParams) ([]ResizeFilesystemsResult, error)
+}
+
 // VolumeParams is a fully specified set of parameters for volume creation,
 // derived from one or more of user-specified storage constraints, a
 // storage pool definition, and charm storage metadata.
@@ -183,6 +215,12 @@ type VolumeParams struct {
 	// storage provider supports tags.
 	ResourceTags map[string]string
 
+	// SnapshotId is the provider-supplied ID of a volume snapshot
+	// from which the volume should be created, or empty if the
+	// volume should be created empty. SnapshotId will only be set
+	// for volume sources that implement VolumeSnapshotter.
+	SnapshotId string
+
 	// Attachment identifies the machine that the volume should be attached
 	// to initially, or nil if the volume should not be attached to any
 	// machine. Some providers, such as MAAS, do not support dynamic
@@ -233,6 +271,52 @@ type AttachmentParams struct {
 	ReadOnly bool
 }
 
+// VolumeSnapshotParams is a set of parameters for creating a snapshot
+// of a volume.
+type VolumeSnapshotParams struct {
+	// Volume is the tag of the volume to snapshot.
+	Volume names.VolumeTag
+
+	// VolumeId is the unique provider-supplied ID for the volume to
+	// snapshot.
+	VolumeId string
+
+	// Provider is the name of the storage provider that created the
+	// volume.
+	Provider ProviderType
+
+	// Attributes is the set of provider-specific attributes of the
+	// storage pool that the volume was created from.
+	Attributes map[string]interface{}
+
+	// ResourceTags is a set of tags to set on the created snapshot,
+	// if the storage provider supports tags.
+	ResourceTags map[string]string
+}
+
+// VolumeResizeParams is a set of parameters for growing a volume.
+type VolumeResizeParams struct {
+	// Volume is the tag of the volume to resize.
+	Volume names.VolumeTag
+
+	// VolumeId is the unique provider-supplied ID for the volume to
+	// resize.
+	VolumeId string
+
+	// Size is the requested new size of the volume in MiB. The
+	// volume may be grown larger than this, e.g. to align with the
+	// provider's allocation unit.
+	Size uint64
+
+	// Provider is the name of the storage provider that created the
+	// volume.
+	Provider ProviderType
+
+	// Attributes is the set of provider-specific attributes of the
+	// storage pool that the volume was created from.
+	Attributes map[string]interface{}
+}
+
 // FilesystemParams is a fully specified set of parameters for filesystem creation,
 // derived from one or more of user-specified storage constraints, a
 // storage pool definition, and charm storage metadata.
@@ -258,6 +342,33 @@ type FilesystemParams struct {
 	ResourceTags map[string]string
 }
 
+// FilesystemResizeParams is a set of parameters for growing a
+// filesystem.
+type FilesystemResizeParams struct {
+	// Filesystem is the tag of the filesystem to resize.
+	Filesystem names.FilesystemTag
+
+	// FilesystemId is the unique provider-supplied ID for the
+	// filesystem to resize.
+	FilesystemId string
+
+	// Volume is the tag of the volume that backs the filesystem, if
+	// any. A volume-backed filesystem is resized only after its
+	// volume has been grown.
+	Volume names.VolumeTag
+
+	// Size is the requested new size of the filesystem in MiB.
+	Size uint64
+
+	// Provider is the name of the storage provider that created the
+	// filesystem.
+	Provider ProviderType
+
+	// Attributes is the set of provider-specific attributes of the
+	// storage pool that the filesystem was created from.
+	Attributes map[string]interface{}
+}
+
 // FilesystemAttachmentParams is a set of parameters for filesystem attachment
 // or detachment.
 type FilesystemAttachmentParams struct {
@@ -285,6 +396,22 @@ type CreateVolumesResult struct {
 	Error            error
 }
 
+// CreateVolumeSnapshotsResult contains the result of a
+// VolumeSnapshotter.CreateVolumeSnapshots call for one volume.
+// VolumeSnapshot should only be used if Error is nil.
+type CreateVolumeSnapshotsResult struct {
+	VolumeSnapshot *VolumeSnapshot
+	Error          error
+}
+
+// ResizeVolumesResult contains the result of a VolumeResizer.ResizeVolumes
+// call for one volume. Size, the new size of the volume in MiB, should
+// only be used if Error is nil.
+type ResizeVolumesResult struct {
+	Size  uint64
+	Error error
+}
+
 // DescribeVolumesResult contains the result of a VolumeSource.DescribeVolumes call
 // for one volume. Volume should only be used if Error is nil.
 type DescribeVolumesResult struct {
@@ -306,6 +433,15 @@ type CreateFilesystemsResult struct {
 	Error      error
 }
 
+// ResizeFilesystemsResult contains the result of a
+// FilesystemResizer.ResizeFilesystems call for one filesystem. Size,
+// the new size of the filesystem in MiB, should only be used if Error
+// is nil.
+type ResizeFilesystemsResult struct {
+	Size  uint64
+	Error error
+}
+
 // DescribeFilesystemsResult contains the result of a FilesystemSource.DescribeFilesystems call
 // for one filesystem. Filesystem should only be used if Error is nil.
 type DescribeFilesystemsResult struct {
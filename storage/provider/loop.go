@@ -98,6 +98,8 @@ type loopVolumeSource struct {
 }
 
 var _ storage.VolumeSource = (*loopVolumeSource)(nil)
+var _ storage.VolumeSnapshotter = (*loopVolumeSource)(nil)
+var _ storage.VolumeResizer = (*loopVolumeSource)(nil)
 
 // CreateVolumes is defined on the VolumeSource interface.
 func (lvs *loopVolumeSource) CreateVolumes(args []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
@@ -118,6 +120,17 @@ func (lvs *loopVolumeSource) createVolume(params storage.VolumeParams) (storage.
 	if err := ensureDir(lvs.dirFuncs, filepath.Dir(loopFilePath)); err != nil {
 		return storage.Volume{}, errors.Trace(err)
 	}
+	if params.SnapshotId != "" {
+		// Copy the snapshot's backing file; createBlockFile
+		// will then extend it if a larger size was requested.
+		snapshotFilePath, err := lvs.snapshotFilePath(params.SnapshotId)
+		if err != nil {
+			return storage.Volume{}, errors.Trace(err)
+		}
+		if err := copyBlockFile(lvs.run, snapshotFilePath, loopFilePath); err != nil {
+			return storage.Volume{}, errors.Annotate(err, "could not restore snapshot")
+		}
+	}
 	if err := createBlockFile(lvs.run, loopFilePath, params.Size); err != nil {
 		return storage.Volume{}, errors.Annotate(err, "could not create block file")
 	}
@@ -134,6 +147,114 @@ func (lvs *loopVolumeSource) volumeFilePath(tag names.VolumeTag) string {
 	return filepath.Join(lvs.storageDir, tag.String())
 }
 
+func (lvs *loopVolumeSource) snapshotFilePath(snapshotId string) (string, error) {
+	if filepath.Base(snapshotId) != snapshotId || strings.HasPrefix(snapshotId, ".") {
+		return "", errors.NotValidf("loop snapshot ID %q", snapshotId)
+	}
+	return filepath.Join(lvs.storageDir, "snapshots", snapshotId), nil
+}
+
+// CreateVolumeSnapshots is defined on the VolumeSnapshotter interface.
+func (lvs *loopVolumeSource) CreateVolumeSnapshots(args []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error) {
+	results := make([]storage.CreateVolumeSnapshotsResult, len(args))
+	for i, arg := range args {
+		snapshot, err := lvs.createVolumeSnapshot(arg)
+		if err != nil {
+			results[i].Error = errors.Annotate(err, "creating volume snapshot")
+			continue
+		}
+		results[i].VolumeSnapshot = snapshot
+	}
+	return results, nil
+}
+
+func (lvs *loopVolumeSource) createVolumeSnapshot(arg storage.VolumeSnapshotParams) (*storage.VolumeSnapshot, error) {
+	loopFilePath := lvs.volumeFilePath(arg.Volume)
+	info, err := os.Stat(loopFilePath)
+	if err != nil {
+		return nil, errors.Annotate(err, "reading loop backing file")
+	}
+	snapshotsDir := filepath.Join(lvs.storageDir, "snapshots")
+	if err := ensureDir(lvs.dirFuncs, snapshotsDir); err != nil {
+		return nil, errors.Trace(err)
+	}
+	// Snapshots are named after the volume, with the
+	// lowest index not already taken by another snapshot.
+	var snapshotId, snapshotFilePath string
+	for i := 0; ; i++ {
+		snapshotId = fmt.Sprintf("%s-%d", arg.Volume.String(), i)
+		snapshotFilePath = filepath.Join(snapshotsDir, snapshotId)
+		if _, err := os.Stat(snapshotFilePath); os.IsNotExist(err) {
+			break
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	if err := copyBlockFile(lvs.run, loopFilePath, snapshotFilePath); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &storage.VolumeSnapshot{
+		Volume:     arg.Volume,
+		SnapshotId: snapshotId,
+		Size:       uint64(info.Size()) / (1024 * 1024),
+	}, nil
+}
+
+// ResizeVolumes is defined on the VolumeResizer interface.
+func (lvs *loopVolumeSource) ResizeVolumes(args []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	results := make([]storage.ResizeVolumesResult, len(args))
+	for i, arg := range args {
+		if err := lvs.resizeVolume(arg); err != nil {
+			results[i].Error = errors.Annotate(err, "resizing volume")
+			continue
+		}
+		results[i].Size = arg.Size
+	}
+	return results, nil
+}
+
+func (lvs *loopVolumeSource) resizeVolume(arg storage.VolumeResizeParams) error {
+	loopFilePath := lvs.volumeFilePath(arg.Volume)
+	if err := createBlockFile(lvs.run, loopFilePath, arg.Size); err != nil {
+		return errors.Annotate(err, "could not grow block file")
+	}
+	// Any attached loop device must be told that its
+	// backing file has grown.
+	deviceNames, err := associatedLoopDevices(lvs.run, loopFilePath)
+	if err != nil {
+		return errors.Annotate(err, "locating loop device")
+	}
+	for _, deviceName := range deviceNames {
+		if err := refreshLoopDeviceSize(lvs.run, deviceName); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// DestroyVolumeSnapshots is defined on the VolumeSnapshotter interface.
+func (lvs *loopVolumeSource) DestroyVolumeSnapshots(snapshotIds []string) ([]error, error) {
+	results := make([]error, len(snapshotIds))
+	for i, snapshotId := range snapshotIds {
+		if err := lvs.destroyVolumeSnapshot(snapshotId); err != nil {
+			results[i] = errors.Annotatef(err, "destroying %q", snapshotId)
+		}
+	}
+	return results, nil
+}
+
+func (lvs *loopVolumeSource) destroyVolumeSnapshot(snapshotId string) error {
+	snapshotFilePath, err := lvs.snapshotFilePath(snapshotId)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = os.Remove(snapshotFilePath)
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Annotate(err, "removing snapshot backing file")
+	}
+	return nil
+}
+
 // ListVolumes is defined on the VolumeSource interface.
 func (lvs *loopVolumeSource) ListVolumes() ([]string, error) {
 	// TODO(axw) implement this when we need it.
@@ -248,6 +369,16 @@ func createBlockFile(run runCommandFunc, filePath string, sizeInMiB uint64) erro
 	return nil
 }
 
+// copyBlockFile copies the block file at the source path to the
+// destination path, preserving any holes in the file.
+func copyBlockFile(run runCommandFunc, srcPath, dstPath string) error {
+	_, err := run("cp", "--sparse=always", srcPath, dstPath)
+	if err != nil {
+		return errors.Annotatef(err, "copying loop backing file %q", srcPath)
+	}
+	return nil
+}
+
 // attachLoopDevice attaches a loop device to the file with the
 // specified path, and returns the loop device's name (e.g. "loop0").
 // losetup will create additional loop devices as necessary.
@@ -287,6 +418,16 @@ func detachLoopDevice(run runCommandFunc, deviceName string) error {
 	return err
 }
 
+// refreshLoopDeviceSize updates the size of the specified loop device
+// to match that of its backing file.
+func refreshLoopDeviceSize(run runCommandFunc, deviceName string) error {
+	_, err := run("losetup", "-c", path.Join("/dev", deviceName))
+	if err != nil {
+		return errors.Annotatef(err, "updating size of loop device %q", deviceName)
+	}
+	return nil
+}
+
 // associatedLoopDevices returns the device names of the loop devices
 // associated with the specified file path.
 func associatedLoopDevices(run runCommandFunc, filePath string) ([]string, error) {
@@ -125,6 +125,114 @@ func (s *loopSuite) TestCreateVolumesNoAttachment(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *loopSuite) TestCreateVolumesFromSnapshot(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	s.commands.expect("cp", "--sparse=always",
+		filepath.Join(s.storageDir, "snapshots", "volume-0-0"),
+		filepath.Join(s.storageDir, "volume-1"),
+	)
+	s.commands.expect("fallocate", "-l", "4MiB", filepath.Join(s.storageDir, "volume-1"))
+
+	results, err := source.CreateVolumes([]storage.VolumeParams{{
+		Tag:        names.NewVolumeTag("1"),
+		Size:       4,
+		SnapshotId: "volume-0-0",
+	}, {
+		Tag:        names.NewVolumeTag("2"),
+		Size:       4,
+		SnapshotId: "../volume-0",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[1].Error, gc.ErrorMatches, `creating volume: loop snapshot ID "../volume-0" not valid`)
+}
+
+func (s *loopSuite) TestCreateVolumeSnapshots(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	fileName := filepath.Join(s.storageDir, "volume-0")
+	err := ioutil.WriteFile(fileName, nil, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	err = os.Truncate(fileName, 2*1024*1024)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The first snapshot index is taken, so the next one is used.
+	snapshotsDir := filepath.Join(s.storageDir, "snapshots")
+	err = os.Mkdir(snapshotsDir, 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(filepath.Join(snapshotsDir, "volume-0-0"), nil, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	s.commands.expect("cp", "--sparse=always", fileName, filepath.Join(snapshotsDir, "volume-0-1"))
+
+	snapshotter, ok := source.(storage.VolumeSnapshotter)
+	c.Assert(ok, jc.IsTrue)
+	results, err := snapshotter.CreateVolumeSnapshots([]storage.VolumeSnapshotParams{{
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: "volume-0",
+	}, {
+		Volume:   names.NewVolumeTag("1"),
+		VolumeId: "volume-1",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].VolumeSnapshot, jc.DeepEquals, &storage.VolumeSnapshot{
+		Volume:     names.NewVolumeTag("0"),
+		SnapshotId: "volume-0-1",
+		Size:       2,
+	})
+	c.Assert(results[1].Error, gc.ErrorMatches, "creating volume snapshot: reading loop backing file: .*")
+}
+
+func (s *loopSuite) TestDestroyVolumeSnapshots(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	snapshotsDir := filepath.Join(s.storageDir, "snapshots")
+	err := os.Mkdir(snapshotsDir, 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	fileName := filepath.Join(snapshotsDir, "volume-0-0")
+	err = ioutil.WriteFile(fileName, nil, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	errs, err := source.(storage.VolumeSnapshotter).DestroyVolumeSnapshots([]string{
+		"volume-0-0", "volume-0-1", "../volume-0",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, gc.HasLen, 3)
+	c.Assert(errs[0], jc.ErrorIsNil)
+	c.Assert(errs[1], jc.ErrorIsNil) // already gone
+	c.Assert(errs[2], gc.ErrorMatches, `destroying "../volume-0": loop snapshot ID "../volume-0" not valid`)
+	_, err = os.Stat(fileName)
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+}
+
+func (s *loopSuite) TestResizeVolumes(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	fileName := filepath.Join(s.storageDir, "volume-0")
+	s.commands.expect("fallocate", "-l", "4MiB", fileName)
+	cmd := s.commands.expect("losetup", "-j", fileName)
+	cmd.respond("/dev/loop0: foo\n", nil)
+	s.commands.expect("losetup", "-c", "/dev/loop0")
+	s.commands.expect("fallocate", "-l", "4MiB", filepath.Join(s.storageDir, "volume-1")).respond(
+		"", errors.New("out of space"),
+	)
+
+	resizer, ok := source.(storage.VolumeResizer)
+	c.Assert(ok, jc.IsTrue)
+	results, err := resizer.ResizeVolumes([]storage.VolumeResizeParams{{
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: "volume-0",
+		Size:     4,
+	}, {
+		Volume:   names.NewVolumeTag("1"),
+		VolumeId: "volume-1",
+		Size:     4,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0], jc.DeepEquals, storage.ResizeVolumesResult{Size: 4})
+	c.Assert(results[1].Error, gc.ErrorMatches, "resizing volume: could not grow block file: .*out of space")
+}
+
 func (s *loopSuite) TestDestroyVolumes(c *gc.C) {
 	source, _ := s.loopVolumeSource(c)
 	fileName := filepath.Join(s.storageDir, "volume-0")
@@ -6,6 +6,7 @@ package provider
 import (
 	"path"
 	"path/filepath"
+	"strings"
 	"unicode"
 
 	"github.com/juju/errors"
@@ -109,6 +110,49 @@ func (s *managedFilesystemSource) createFilesystem(arg storage.FilesystemParams)
 	}, nil
 }
 
+// ResizeFilesystems is defined on storage.FilesystemResizer.
+func (s *managedFilesystemSource) ResizeFilesystems(args []storage.FilesystemResizeParams) ([]storage.ResizeFilesystemsResult, error) {
+	results := make([]storage.ResizeFilesystemsResult, len(args))
+	for i, arg := range args {
+		size, err := s.resizeFilesystem(arg)
+		if err != nil {
+			results[i].Error = err
+			continue
+		}
+		results[i].Size = size
+	}
+	return results, nil
+}
+
+func (s *managedFilesystemSource) resizeFilesystem(arg storage.FilesystemResizeParams) (uint64, error) {
+	blockDevice, err := s.backingVolumeBlockDevice(arg.Volume)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	if blockDevice.Size < arg.Size {
+		// The block device information is refreshed periodically,
+		// so it may not yet reflect the resized volume.
+		return 0, errors.Errorf(
+			"backing-volume %s has not yet grown to %dM", arg.Volume.Id(), arg.Size,
+		)
+	}
+	devicePath := devicePath(blockDevice)
+	partitioned, err := isPartitionedDisk(s.run, devicePath)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	if partitioned {
+		if err := growPartition(s.run, devicePath); err != nil {
+			return 0, errors.Trace(err)
+		}
+		devicePath = partitionDevicePath(devicePath)
+	}
+	if err := growFilesystem(s.run, devicePath); err != nil {
+		return 0, errors.Trace(err)
+	}
+	return blockDevice.Size, nil
+}
+
 // DestroyFilesystems is defined on storage.FilesystemSource.
 func (s *managedFilesystemSource) DestroyFilesystems(filesystemIds []string) ([]error, error) {
 	// DestroyFilesystems is a no-op; there is nothing to destroy,
@@ -141,7 +185,11 @@ func (s *managedFilesystemSource) attachFilesystem(arg storage.FilesystemAttachm
 		return nil, errors.Trace(err)
 	}
 	devicePath := devicePath(blockDevice)
-	if isDiskDevice(devicePath) {
+	partitioned, err := isPartitionedDisk(s.run, devicePath)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if partitioned {
 		devicePath = partitionDevicePath(devicePath)
 	}
 	if err := mountFilesystem(s.run, s.dirFuncs, devicePath, arg.Path, arg.ReadOnly); err != nil {
@@ -186,6 +234,32 @@ func createPartition(run runCommandFunc, devicePath string) error {
 	return nil
 }
 
+// growPartition grows the single partition (1) on the disk with the
+// specified device path to fill the disk.
+func growPartition(run runCommandFunc, devicePath string) error {
+	logger.Debugf("growing partition on %q", devicePath)
+	output, err := run("growpart", devicePath, "1")
+	if err != nil {
+		if strings.HasPrefix(output, "NOCHANGE") {
+			// The partition already fills the disk.
+			return nil
+		}
+		return errors.Annotate(err, "growpart failed")
+	}
+	return nil
+}
+
+// growFilesystem grows the filesystem on the specified device to fill
+// the device. The filesystem may be mounted.
+func growFilesystem(run runCommandFunc, devicePath string) error {
+	logger.Debugf("attempting to grow filesystem on %q", devicePath)
+	if _, err := run("resize2fs", devicePath); err != nil {
+		return errors.Annotate(err, "resize2fs failed")
+	}
+	logger.Infof("grew filesystem on %q", devicePath)
+	return nil
+}
+
 func createFilesystem(run runCommandFunc, devicePath string) error {
 	logger.Debugf("attempting to create filesystem on %q", devicePath)
 	mkfscmd := "mkfs." + defaultFilesystemType
@@ -266,6 +340,26 @@ func partitionDevicePath(devicePath string) string {
 	return devicePath + "1"
 }
 
+// isPartitionedDisk reports whether the device with the specified path
+// is a full disk holding a partition. The disks on which filesystems
+// are created have a single partition, but a volume imported with its
+// filesystem may have the filesystem directly on the disk.
+func isPartitionedDisk(run runCommandFunc, devicePath string) (bool, error) {
+	if !isDiskDevice(devicePath) {
+		return false, nil
+	}
+	output, err := run("lsblk", "--noheadings", "--output", "TYPE", devicePath)
+	if err != nil {
+		return false, errors.Annotate(err, "lsblk failed")
+	}
+	for _, deviceType := range strings.Fields(output) {
+		if deviceType == "part" {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // isDiskDevice reports whether or not the device is a full disk, as opposed
 // to a partition or a loop device. We create a partition on disks to contain
 // filesystems.
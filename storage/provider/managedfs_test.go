@@ -6,6 +6,7 @@ package provider_test
 import (
 	"path/filepath"
 
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
@@ -112,23 +113,97 @@ func (s *managedfsSuite) TestCreateFilesystemsNoBlockDevice(c *gc.C) {
 	c.Assert(results[0].Error, gc.ErrorMatches, "backing-volume 0 is not yet attached")
 }
 
+func (s *managedfsSuite) TestResizeFilesystems(c *gc.C) {
+	source := s.initSource(c)
+	// The partition on sda is grown before the filesystem
+	// on it; xvdf1 has no partition.
+	cmd := s.commands.expect("lsblk", "--noheadings", "--output", "TYPE", "/dev/sda")
+	cmd.respond("disk\npart\n", nil)
+	s.commands.expect("growpart", "/dev/sda", "1")
+	s.commands.expect("resize2fs", "/dev/sda1")
+	s.commands.expect("resize2fs", "/dev/xvdf1")
+
+	s.blockDevices[names.NewVolumeTag("0")] = storage.BlockDevice{
+		DeviceName: "sda",
+		Size:       4,
+	}
+	s.blockDevices[names.NewVolumeTag("1")] = storage.BlockDevice{
+		DeviceName: "xvdf1",
+		Size:       3,
+	}
+	c.Assert(source, gc.Implements, new(storage.FilesystemResizer))
+	results, err := source.(storage.FilesystemResizer).ResizeFilesystems([]storage.FilesystemResizeParams{{
+		Filesystem: names.NewFilesystemTag("0/0"),
+		Volume:     names.NewVolumeTag("0"),
+		Size:       4,
+	}, {
+		Filesystem: names.NewFilesystemTag("0/1"),
+		Volume:     names.NewVolumeTag("1"),
+		Size:       3,
+	}, {
+		Filesystem: names.NewFilesystemTag("0/2"),
+		Volume:     names.NewVolumeTag("1"),
+		Size:       5,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 3)
+	c.Assert(results[0], jc.DeepEquals, storage.ResizeFilesystemsResult{Size: 4})
+	c.Assert(results[1], jc.DeepEquals, storage.ResizeFilesystemsResult{Size: 3})
+	c.Assert(results[2].Error, gc.ErrorMatches, "backing-volume 1 has not yet grown to 5M")
+}
+
+func (s *managedfsSuite) TestResizeFilesystemsPartitionUnchanged(c *gc.C) {
+	source := s.initSource(c)
+	cmd := s.commands.expect("lsblk", "--noheadings", "--output", "TYPE", "/dev/sda")
+	cmd.respond("disk\npart\n", nil)
+	cmd = s.commands.expect("growpart", "/dev/sda", "1")
+	cmd.respond("NOCHANGE: partition 1 could only be grown by 0", errors.New("exit status 1"))
+	s.commands.expect("resize2fs", "/dev/sda1")
+
+	s.blockDevices[names.NewVolumeTag("0")] = storage.BlockDevice{
+		DeviceName: "sda",
+		Size:       4,
+	}
+	results, err := source.(storage.FilesystemResizer).ResizeFilesystems([]storage.FilesystemResizeParams{{
+		Filesystem: names.NewFilesystemTag("0/0"),
+		Volume:     names.NewVolumeTag("0"),
+		Size:       4,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.ResizeFilesystemsResult{{Size: 4}})
+}
+
 func (s *managedfsSuite) TestAttachFilesystems(c *gc.C) {
-	s.testAttachFilesystems(c, false, false)
+	s.testAttachFilesystems(c, false, false, true)
 }
 
 func (s *managedfsSuite) TestAttachFilesystemsReadOnly(c *gc.C) {
-	s.testAttachFilesystems(c, true, false)
+	s.testAttachFilesystems(c, true, false, true)
 }
 
 func (s *managedfsSuite) TestAttachFilesystemsReattach(c *gc.C) {
-	s.testAttachFilesystems(c, true, true)
+	s.testAttachFilesystems(c, true, true, true)
 }
 
-func (s *managedfsSuite) testAttachFilesystems(c *gc.C, readOnly, reattach bool) {
+func (s *managedfsSuite) TestAttachFilesystemsUnpartitioned(c *gc.C) {
+	// An imported volume may have its filesystem directly on the
+	// disk, in which case the disk itself is mounted.
+	s.testAttachFilesystems(c, false, false, false)
+}
+
+func (s *managedfsSuite) testAttachFilesystems(c *gc.C, readOnly, reattach, partitioned bool) {
 	const testMountPoint = "/in/the/place"
 
 	source := s.initSource(c)
-	cmd := s.commands.expect("df", "--output=source", filepath.Dir(testMountPoint))
+	cmd := s.commands.expect("lsblk", "--noheadings", "--output", "TYPE", "/dev/sda")
+	mountDevice := "/dev/sda"
+	if partitioned {
+		cmd.respond("disk\npart\n", nil)
+		mountDevice = "/dev/sda1"
+	} else {
+		cmd.respond("disk\n", nil)
+	}
+	cmd = s.commands.expect("df", "--output=source", filepath.Dir(testMountPoint))
 	cmd.respond("headers\n/same/as/rootfs", nil)
 	cmd = s.commands.expect("df", "--output=source", testMountPoint)
 	if reattach {
@@ -139,7 +214,7 @@ func (s *managedfsSuite) testAttachFilesystems(c *gc.C, readOnly, reattach bool)
 		if readOnly {
 			args = append(args, "-o", "ro")
 		}
-		args = append(args, "/dev/sda1", testMountPoint)
+		args = append(args, mountDevice, testMountPoint)
 		s.commands.expect("mount", args...)
 	}
 
@@ -32,6 +32,21 @@ type VolumeInfo struct {
 	Persistent bool
 }
 
+// VolumeSnapshot identifies and describes a point-in-time snapshot
+// of a volume.
+type VolumeSnapshot struct {
+	// Volume is the unique tag assigned by Juju for the volume
+	// that the snapshot was taken of.
+	Volume names.VolumeTag
+
+	// SnapshotId is a unique provider-supplied ID for the snapshot.
+	SnapshotId string
+
+	// Size is the size of the snapshotted volume, in MiB. Volumes
+	// created from the snapshot must be at least this large.
+	Size uint64
+}
+
 // VolumeAttachment identifies and describes machine-specific volume
 // attachment information, including how the volume is exposed on the
 // machine.
@@ -26,3 +26,7 @@ var LongAttempt = &utils.AttemptStrategy{
 	Total: LongWait,
 	Delay: ShortWait,
 }
+
+// SecretsKey is the key used to encrypt the values of secrets in
+// tests. It must be the same for every State opened on a test database.
+var SecretsKey = []byte("0123456789abcdef0123456789abcdef")
@@ -16,6 +16,10 @@ type RelationUnitsChange struct {
 	// latest known settings version for each.
 	Changed map[string]UnitSettings
 
+	// AppChanged holds the latest known version of the application
+	// settings of each counterpart application.
+	AppChanged map[string]int64
+
 	// Departed holds a set of units that have previously been reported to
 	// be in scope, but which no longer are.
 	Departed []string
@@ -0,0 +1,51 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	worker "gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/worker/dependency"
+)
+
+// ManifoldConfig holds dependencies and configuration for an
+// actionscheduler worker.
+type ManifoldConfig struct {
+	APICallerName string
+	ClockName     string
+	NewFacade     func(base.APICaller) (Facade, error)
+	NewWorker     func(Config) (worker.Worker, error)
+}
+
+// Manifold returns a dependency.Manifold that runs an actionscheduler
+// worker.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{
+			config.APICallerName,
+			config.ClockName,
+		},
+		Start: func(context dependency.Context) (worker.Worker, error) {
+			var clock clock.Clock
+			if err := context.Get(config.ClockName, &clock); err != nil {
+				return nil, errors.Trace(err)
+			}
+			var apiCaller base.APICaller
+			if err := context.Get(config.APICallerName, &apiCaller); err != nil {
+				return nil, errors.Trace(err)
+			}
+			facade, err := config.NewFacade(apiCaller)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			return config.NewWorker(Config{
+				Facade: facade,
+				Clock:  clock,
+			})
+		},
+	}
+}
@@ -0,0 +1,32 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler
+
+import (
+	"github.com/juju/errors"
+	worker "gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/api/actionscheduler"
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/watcher"
+)
+
+// NewFacade creates a Facade from a base.APICaller.
+// It's a sensible value for ManifoldConfig.NewFacade.
+func NewFacade(apiCaller base.APICaller) (Facade, error) {
+	return actionscheduler.NewAPI(
+		apiCaller,
+		watcher.NewNotifyWatcher,
+	), nil
+}
+
+// NewWorker creates a worker from the supplied config.
+// It's a sensible value for ManifoldConfig.NewWorker.
+func NewWorker(config Config) (worker.Worker, error) {
+	w, err := New(config)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
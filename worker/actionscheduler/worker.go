@@ -0,0 +1,203 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.actionscheduler")
+
+// RetryDelay is the time the worker waits before asking for a schedule
+// whose last run request failed to be run again.
+const RetryDelay = time.Minute
+
+// Facade defines the capabilities required by the worker.
+type Facade interface {
+
+	// Watch returns a NotifyWatcher that notifies when action
+	// schedules are added, changed or removed.
+	Watch() (watcher.NotifyWatcher, error)
+
+	// Schedules returns all action schedules in the model.
+	Schedules() ([]params.ActionSchedule, error)
+
+	// Run requests that the named schedules be run, returning
+	// an error for each.
+	Run(names []string) ([]error, error)
+}
+
+// Config defines a worker's dependencies.
+type Config struct {
+	Facade Facade
+	Clock  clock.Clock
+}
+
+// Validate returns an error if the config can't be expected
+// to run a functional worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	return nil
+}
+
+// New returns a worker that runs each enabled action schedule in the
+// model when it falls due.
+func New(config Config) (*Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{
+		config:    config,
+		requested: make(map[string]runRequest),
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Worker runs action schedules.
+type Worker struct {
+	catacomb  catacomb.Catacomb
+	config    Config
+	schedules []params.ActionSchedule
+
+	// requested records the last request to run each schedule,
+	// so that a schedule is not requested again until the run is
+	// reflected in its next run time, or a failed run is retried.
+	requested map[string]runRequest
+}
+
+// runRequest records a request to run a schedule.
+type runRequest struct {
+	nextRun time.Time
+	at      time.Time
+	failed  bool
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	watch, err := w.config.Facade.Watch()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(watch); err != nil {
+		return errors.Trace(err)
+	}
+
+	var timer <-chan time.Time
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-watch.Changes():
+			if !ok {
+				return errors.New("action schedule watcher closed")
+			}
+		case <-timer:
+			if err := w.runDue(); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		if w.schedules, err = w.config.Facade.Schedules(); err != nil {
+			return errors.Trace(err)
+		}
+		timer = w.nextTimer()
+	}
+}
+
+// runDue requests that all schedules that are due be run.
+func (w *Worker) runDue() error {
+	now := w.config.Clock.Now()
+	var due []params.ActionSchedule
+	var names []string
+	for _, schedule := range w.schedules {
+		if at, ok := w.dueTime(schedule); ok && !at.After(now) {
+			due = append(due, schedule)
+			names = append(names, schedule.Name)
+		}
+	}
+	if len(due) == 0 {
+		return nil
+	}
+	logger.Debugf("running action schedules %v", names)
+	errs, err := w.config.Facade.Run(names)
+	if err != nil {
+		return errors.Annotate(err, "cannot run action schedules")
+	}
+	for i, schedule := range due {
+		if errs[i] != nil {
+			logger.Errorf("cannot run action schedule %q: %v", schedule.Name, errs[i])
+		}
+		w.requested[schedule.Name] = runRequest{
+			nextRun: schedule.NextRun,
+			at:      now,
+			failed:  errs[i] != nil,
+		}
+	}
+	return nil
+}
+
+// dueTime returns the time at which the schedule should next be run,
+// and whether it should be run at all.
+func (w *Worker) dueTime(schedule params.ActionSchedule) (time.Time, bool) {
+	if !schedule.Enabled || schedule.NextRun.IsZero() {
+		return time.Time{}, false
+	}
+	request, ok := w.requested[schedule.Name]
+	if !ok || !request.nextRun.Equal(schedule.NextRun) {
+		return schedule.NextRun, true
+	}
+	if request.failed {
+		return request.at.Add(RetryDelay), true
+	}
+	// The run has been requested, but we have yet to see it recorded.
+	return time.Time{}, false
+}
+
+// nextTimer returns a channel that delivers when the next schedule
+// falls due, or nil if there are no enabled schedules.
+func (w *Worker) nextTimer() <-chan time.Time {
+	var next time.Time
+	for _, schedule := range w.schedules {
+		at, ok := w.dueTime(schedule)
+		if ok && (next.IsZero() || at.Before(next)) {
+			next = at
+		}
+	}
+	if next.IsZero() {
+		return nil
+	}
+	delay := next.Sub(w.config.Clock.Now())
+	if delay < 0 {
+		delay = 0
+	}
+	return w.config.Clock.After(delay)
+}
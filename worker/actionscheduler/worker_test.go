@@ -0,0 +1,208 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker/actionscheduler"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+	clock  *testing.Clock
+	facade *mockFacade
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Date(2017, 3, 15, 10, 30, 0, 0, time.UTC))
+	s.facade = &mockFacade{
+		watcher: notAWatcher{workertest.NewFakeWatcher(1, 1)},
+		runs:    make(chan []string, 10),
+	}
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) *actionscheduler.Worker {
+	w, err := actionscheduler.New(actionscheduler.Config{
+		Facade: s.facade,
+		Clock:  s.clock,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, w) })
+	return w
+}
+
+func (s *WorkerSuite) schedule(name string, next time.Duration) params.ActionSchedule {
+	return params.ActionSchedule{
+		Name:       name,
+		Receiver:   "application-mysql",
+		ActionName: "backup",
+		Schedule:   "@hourly",
+		Enabled:    true,
+		NextRun:    s.clock.Now().Add(next),
+	}
+}
+
+func (s *WorkerSuite) assertRun(c *gc.C, expect ...string) {
+	select {
+	case names := <-s.facade.runs:
+		c.Assert(names, jc.DeepEquals, expect)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for schedules to run")
+	}
+}
+
+func (s *WorkerSuite) assertNoRun(c *gc.C) {
+	select {
+	case names := <-s.facade.runs:
+		c.Fatalf("unexpected run of %v", names)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	_, err := actionscheduler.New(actionscheduler.Config{Clock: s.clock})
+	c.Check(err, gc.ErrorMatches, "nil Facade not valid")
+	_, err = actionscheduler.New(actionscheduler.Config{Facade: s.facade})
+	c.Check(err, gc.ErrorMatches, "nil Clock not valid")
+}
+
+func (s *WorkerSuite) TestRunsDueSchedules(c *gc.C) {
+	disabled := s.schedule("disabled", 0)
+	disabled.Enabled = false
+	disabled.NextRun = time.Time{}
+	s.facade.setSchedules(
+		s.schedule("hourly", time.Hour),
+		s.schedule("daily", 24*time.Hour),
+		disabled,
+	)
+	s.startWorker(c)
+
+	err := s.clock.WaitAdvance(time.Hour, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertRun(c, "hourly")
+
+	// The hourly schedule's next run time has not been updated,
+	// but it is not requested again.
+	err = s.clock.WaitAdvance(actionscheduler.RetryDelay, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertNoRun(c)
+}
+
+func (s *WorkerSuite) TestRefreshesOnChange(c *gc.C) {
+	s.facade.setSchedules(s.schedule("daily", 24*time.Hour))
+	s.startWorker(c)
+
+	err := s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertNoRun(c)
+
+	s.facade.setSchedules(s.schedule("daily", 24*time.Hour), s.schedule("soon", time.Minute))
+	s.facade.watcher.Ping()
+	err = s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertRun(c, "soon")
+}
+
+func (s *WorkerSuite) TestRetriesFailedRun(c *gc.C) {
+	s.facade.setSchedules(s.schedule("hourly", time.Hour))
+	s.facade.runErrs = []error{errors.New("blammo")}
+	s.startWorker(c)
+
+	err := s.clock.WaitAdvance(time.Hour, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertRun(c, "hourly")
+
+	err = s.clock.WaitAdvance(actionscheduler.RetryDelay, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertRun(c, "hourly")
+}
+
+func (s *WorkerSuite) TestWatchError(c *gc.C) {
+	s.facade.SetErrors(errors.New("blammo"))
+	w, err := actionscheduler.New(actionscheduler.Config{
+		Facade: s.facade,
+		Clock:  s.clock,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = workertest.CheckKilled(c, w)
+	c.Check(err, gc.ErrorMatches, "blammo")
+}
+
+func (s *WorkerSuite) TestRunError(c *gc.C) {
+	s.facade.setSchedules(s.schedule("hourly", time.Hour))
+	s.facade.runCallErr = errors.New("blammo")
+	w := s.startWorker(c)
+
+	err := s.clock.WaitAdvance(time.Hour, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	err = workertest.CheckKilled(c, w)
+	c.Check(err, gc.ErrorMatches, "cannot run action schedules: blammo")
+}
+
+type notAWatcher struct {
+	workertest.NotAWatcher
+}
+
+func (w notAWatcher) Changes() watcher.NotifyChannel {
+	return w.NotAWatcher.Changes()
+}
+
+// mockFacade implements actionscheduler.Facade for the tests'
+// convenience.
+type mockFacade struct {
+	testing.Stub
+	watcher notAWatcher
+	runs    chan []string
+
+	mu         sync.Mutex
+	schedules  []params.ActionSchedule
+	runErrs    []error
+	runCallErr error
+}
+
+func (m *mockFacade) setSchedules(schedules ...params.ActionSchedule) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.schedules = schedules
+}
+
+func (m *mockFacade) Watch() (watcher.NotifyWatcher, error) {
+	m.MethodCall(m, "Watch")
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return &m.watcher, nil
+}
+
+func (m *mockFacade) Schedules() ([]params.ActionSchedule, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.schedules, nil
+}
+
+func (m *mockFacade) Run(names []string) ([]error, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.runs <- names
+	if m.runCallErr != nil {
+		return nil, m.runCallErr
+	}
+	errs := make([]error, len(names))
+	copy(errs, m.runErrs)
+	return errs, nil
+}
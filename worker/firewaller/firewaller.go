@@ -98,7 +98,11 @@ func (config Config) Validate() error {
 	return nil
 }
 
-type portRanges map[network.PortRange]bool
+type portRanges map[firewaller.OpenedPortRange]bool
+
+// egressRetryDelay is how long to wait before retrying to apply egress
+// rules to machines that have not yet been provisioned.
+const egressRetryDelay = 10 * time.Second
 
 // Firewaller watches the state for port ranges opened or closed on
 // machines and reflects those changes onto the backing environment.
@@ -117,8 +121,11 @@ type Firewaller struct {
 	unitds               map[names.UnitTag]*unitData
 	applicationids       map[names.ApplicationTag]*applicationData
 	exposedChange        chan *exposedChange
+	egressChange         chan *egressChange
 	globalMode           bool
 	globalIngressRuleRef map[string]int // map of rule names to count of occurrences
+	globalEgressRules    []network.EgressRule
+	egressRetry          <-chan time.Time
 
 	modelUUID                  string
 	newRemoteFirewallerAPIFunc func(modelUUID string) (RemoteFirewallerAPICloser, error)
@@ -149,6 +156,7 @@ func NewFirewaller(cfg Config) (worker.Worker, error) {
 		unitds:                     make(map[names.UnitTag]*unitData),
 		applicationids:             make(map[names.ApplicationTag]*applicationData),
 		exposedChange:              make(chan *exposedChange),
+		egressChange:               make(chan *egressChange),
 		relationIngress:            make(map[names.RelationTag]*remoteRelationData),
 		remoteRelationsChange:      make(chan *remoteRelationChange),
 		pollClock:                  clk,
@@ -284,6 +292,7 @@ func (fw *Firewaller) loop() error {
 			}
 		case change := <-fw.exposedChange:
 			change.applicationd.exposed = change.exposed
+			change.applicationd.exposedEndpoints = change.exposedEndpoints
 			unitds := []*unitData{}
 			for _, unitd := range change.applicationd.unitds {
 				unitds = append(unitds, unitd)
@@ -291,6 +300,22 @@ func (fw *Firewaller) loop() error {
 			if err := fw.flushUnits(unitds); err != nil {
 				return errors.Annotate(err, "cannot change firewall ports")
 			}
+		case change := <-fw.egressChange:
+			change.applicationd.egressRules = change.egressRules
+			unitds := []*unitData{}
+			for _, unitd := range change.applicationd.unitds {
+				unitds = append(unitds, unitd)
+			}
+			if err := fw.flushUnits(unitds); err != nil {
+				return errors.Annotate(err, "cannot change firewall egress rules")
+			}
+		case <-fw.egressRetry:
+			fw.egressRetry = nil
+			for _, machined := range fw.machineds {
+				if err := fw.flushMachineEgress(machined); err != nil {
+					return errors.Annotate(err, "cannot change firewall egress rules")
+				}
+			}
 		}
 	}
 }
@@ -434,22 +459,28 @@ func (fw *Firewaller) startUnit(unit *firewaller.Unit, machineTag names.MachineT
 // startApplication creates a new data value for tracking details of the
 // application and starts watching the application for exposure changes.
 func (fw *Firewaller) startApplication(app *firewaller.Application) error {
-	exposed, err := app.IsExposed()
+	exposed, exposedEndpoints, err := applicationExposeCIDRs(app)
 	if err != nil {
 		return err
 	}
+	egressRules, err := app.EgressRules()
+	if err != nil {
+		return errors.Trace(err)
+	}
 	applicationd := &applicationData{
-		fw:          fw,
-		application: app,
-		exposed:     exposed,
-		unitds:      make(map[names.UnitTag]*unitData),
+		fw:               fw,
+		application:      app,
+		exposed:          exposed,
+		exposedEndpoints: exposedEndpoints,
+		egressRules:      egressRules,
+		unitds:           make(map[names.UnitTag]*unitData),
 	}
 	fw.applicationids[app.Tag()] = applicationd
 
 	err = catacomb.Invoke(catacomb.Plan{
 		Site: &applicationd.catacomb,
 		Work: func() error {
-			return applicationd.watchLoop(exposed)
+			return applicationd.watchLoop(exposed, exposedEndpoints, egressRules)
 		},
 	})
 	if err != nil {
@@ -489,7 +520,20 @@ func (fw *Firewaller) reconcileGlobal() error {
 			return err
 		}
 	}
-	return nil
+
+	egressFirewaller, ok := fw.environFirewaller.(environs.EgressFirewaller)
+	if !ok {
+		return nil
+	}
+	initialEgressRules, err := egressFirewaller.EgressRules()
+	if errors.IsNotSupported(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	toOpenEgress, toCloseEgress := diffEgressRules(initialEgressRules, fw.globalEgressRules)
+	return fw.flushGlobalEgressRules(toOpenEgress, toCloseEgress)
 }
 
 // reconcileInstances compares the initially started watcher for machines,
@@ -546,6 +590,22 @@ func (fw *Firewaller) reconcileInstances() error {
 				return err
 			}
 		}
+
+		egressFirewaller, ok := instances[0].(instance.EgressFirewaller)
+		if !ok {
+			continue
+		}
+		initialEgressRules, err := egressFirewaller.EgressRules(machineId)
+		if errors.IsNotSupported(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		toOpenEgress, toCloseEgress := diffEgressRules(initialEgressRules, machined.egressRules)
+		if err := flushInstanceEgressRules(egressFirewaller, machined, toOpenEgress, toCloseEgress); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -693,9 +753,14 @@ func (fw *Firewaller) flushMachine(machined *machineData) error {
 	toOpen, toClose := diffRanges(machined.ingressRules, want)
 	machined.ingressRules = want
 	if fw.globalMode {
-		return fw.flushGlobalPorts(toOpen, toClose)
+		err = fw.flushGlobalPorts(toOpen, toClose)
+	} else {
+		err = fw.flushInstancePorts(machined, toOpen, toClose)
+	}
+	if err != nil {
+		return err
 	}
-	return fw.flushInstancePorts(machined, toOpen, toClose)
+	return fw.flushMachineEgress(machined)
 }
 
 // gatherIngressRules returns the ingress rules to open and close
@@ -710,26 +775,29 @@ func (fw *Firewaller) gatherIngressRules(machines ...*machineData) ([]network.In
 				continue
 			}
 
-			cidrs := set.NewStrings()
-			// If the unit is exposed, allow access from everywhere.
-			if unitd.applicationd.exposed {
-				cidrs.Add("0.0.0.0/0")
-			} else {
-				// Not exposed, so add any ingress rules required by remote relations.
-				if err := fw.updateForRemoteRelationIngress(unitd.applicationd.application.Tag(), cidrs); err != nil {
-					return nil, errors.Trace(err)
-				}
-				logger.Debugf("CIDRS for %v: %v", unitTag, cidrs.Values())
+			// Add any ingress rules required by remote relations,
+			// which apply to all of the unit's ports.
+			relationCidrs := set.NewStrings()
+			if err := fw.updateForRemoteRelationIngress(unitd.applicationd.application.Tag(), relationCidrs); err != nil {
+				return nil, errors.Trace(err)
 			}
-			if cidrs.Size() > 0 {
-				for portRange := range portRanges {
-					sourceCidrs := cidrs.SortedValues()
-					rule, err := network.NewIngressRule(portRange.Protocol, portRange.FromPort, portRange.ToPort, sourceCidrs...)
-					if err != nil {
-						return nil, errors.Trace(err)
-					}
-					want = append(want, rule)
+			for portRange := range portRanges {
+				// If the unit is exposed, allow access from the CIDRs
+				// the port range's endpoint is exposed to.
+				cidrs := unitd.applicationd.exposeCIDRs(portRange.Endpoint)
+				if !cidrs.Contains("0.0.0.0/0") {
+					cidrs = cidrs.Union(relationCidrs)
+				}
+				logger.Debugf("CIDRS for %v %v: %v", unitTag, portRange, cidrs.Values())
+				if cidrs.Size() == 0 {
+					continue
+				}
+				ports := portRange.PortRange
+				rule, err := network.NewIngressRule(ports.Protocol, ports.FromPort, ports.ToPort, cidrs.SortedValues()...)
+				if err != nil {
+					return nil, errors.Trace(err)
 				}
+				want = append(want, rule)
 			}
 		}
 	}
@@ -850,6 +918,143 @@ func (fw *Firewaller) flushInstancePorts(machined *machineData, toOpen, toClose
 	return nil
 }
 
+// gatherEgressRules returns the egress rules of the applications with
+// units on the specified machines.
+func (fw *Firewaller) gatherEgressRules(machines ...*machineData) []network.EgressRule {
+	var want []network.EgressRule
+	for _, machined := range machines {
+		for _, unitd := range machined.unitds {
+			want = append(want, unitd.applicationd.egressRules...)
+		}
+	}
+	return network.MergeEgressRules(want)
+}
+
+// flushMachineEgress opens and closes egress rules for the passed
+// machine, or for the whole environment in global mode.
+func (fw *Firewaller) flushMachineEgress(machined *machineData) error {
+	if fw.globalMode {
+		var machines []*machineData
+		for _, md := range fw.machineds {
+			machines = append(machines, md)
+		}
+		want := fw.gatherEgressRules(machines...)
+		toOpen, toClose := diffEgressRules(fw.globalEgressRules, want)
+		fw.globalEgressRules = want
+		return fw.flushGlobalEgressRules(toOpen, toClose)
+	}
+	want := fw.gatherEgressRules(machined)
+	toOpen, toClose := diffEgressRules(machined.egressRules, want)
+	if len(toOpen) == 0 && len(toClose) == 0 {
+		return nil
+	}
+	m, err := machined.machine()
+	if params.IsCodeNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	instanceId, err := m.InstanceId()
+	if errors.IsNotProvisioned(err) {
+		// Unlike ports, egress rules apply before any unit is
+		// running, so try again once the machine has an instance.
+		logger.Debugf("cannot set egress rules on %q yet: %v", machined.tag, err)
+		if fw.egressRetry == nil {
+			fw.egressRetry = fw.pollClock.After(egressRetryDelay)
+		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	instances, err := fw.environInstances.Instances([]instance.Id{instanceId})
+	if err == environs.ErrNoInstances {
+		// The instance has gone, and its egress rules with it.
+		machined.egressRules = nil
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	machined.egressRules = want
+	egressFirewaller, ok := instances[0].(instance.EgressFirewaller)
+	if !ok {
+		logger.Warningf("cannot set egress rules on %q: not supported by the provider", machined.tag)
+		return nil
+	}
+	return flushInstanceEgressRules(egressFirewaller, machined, toOpen, toClose)
+}
+
+// flushGlobalEgressRules opens and closes egress rules for the whole
+// environment.
+func (fw *Firewaller) flushGlobalEgressRules(toOpen, toClose []network.EgressRule) error {
+	if len(toOpen) == 0 && len(toClose) == 0 {
+		return nil
+	}
+	egressFirewaller, ok := fw.environFirewaller.(environs.EgressFirewaller)
+	if !ok {
+		logger.Warningf("cannot set egress rules: not supported by the provider")
+		return nil
+	}
+	if len(toOpen) > 0 {
+		err := egressFirewaller.OpenEgressRules(toOpen)
+		if errors.IsNotSupported(err) {
+			logger.Warningf("cannot set egress rules: %v", err)
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		logger.Infof("opened egress rules %v in environment", toOpen)
+	}
+	if len(toClose) > 0 {
+		err := egressFirewaller.CloseEgressRules(toClose)
+		if errors.IsNotSupported(err) {
+			logger.Warningf("cannot set egress rules: %v", err)
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		logger.Infof("closed egress rules %v in environment", toClose)
+	}
+	return nil
+}
+
+// flushInstanceEgressRules opens and closes egress rules on the
+// machine's instance.
+func flushInstanceEgressRules(
+	egressFirewaller instance.EgressFirewaller,
+	machined *machineData,
+	toOpen, toClose []network.EgressRule,
+) error {
+	machineId := machined.tag.Id()
+	if len(toOpen) > 0 {
+		err := egressFirewaller.OpenEgressRules(machineId, toOpen)
+		if errors.IsNotSupported(err) {
+			logger.Warningf("cannot set egress rules on %q: %v", machined.tag, err)
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		logger.Infof("opened egress rules %v on %q", toOpen, machined.tag)
+	}
+	if len(toClose) > 0 {
+		err := egressFirewaller.CloseEgressRules(machineId, toClose)
+		if errors.IsNotSupported(err) {
+			logger.Warningf("cannot set egress rules on %q: %v", machined.tag, err)
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		logger.Infof("closed egress rules %v on %q", toClose, machined.tag)
+	}
+	return nil
+}
+
 // machineLifeChanged starts watching new machines when the firewaller
 // is starting, or when new machines come to life, and stops watching
 // machines that are dying.
@@ -945,6 +1150,7 @@ type machineData struct {
 	tag          names.MachineTag
 	unitds       map[names.UnitTag]*unitData
 	ingressRules []network.IngressRule
+	egressRules  []network.EgressRule
 	// ports defined by units on this machine
 	definedPorts map[names.UnitTag]portRanges
 }
@@ -994,23 +1200,102 @@ type unitData struct {
 	machined     *machineData
 }
 
-// exposedChange contains the changed exposed flag for one specific application.
+// exposedChange contains the changed exposed flag and exposed CIDRs for
+// one specific application.
 type exposedChange struct {
+	applicationd     *applicationData
+	exposed          bool
+	exposedEndpoints map[string]set.Strings
+}
+
+// egressChange contains the changed egress rules for one specific
+// application.
+type egressChange struct {
 	applicationd *applicationData
-	exposed      bool
+	egressRules  []network.EgressRule
 }
 
-// applicationData holds application details and watches exposure changes.
+// applicationData holds application details and watches exposure and
+// egress rule changes.
 type applicationData struct {
 	catacomb    catacomb.Catacomb
 	fw          *Firewaller
 	application *firewaller.Application
 	exposed     bool
+	egressRules []network.EgressRule
 	unitds      map[names.UnitTag]*unitData
+
+	// exposedEndpoints holds the CIDRs each endpoint of the exposed
+	// application is exposed to, keyed on endpoint name; the empty
+	// name holds the CIDRs for all endpoints.
+	exposedEndpoints map[string]set.Strings
 }
 
-// watchLoop watches the application's exposed flag for changes.
-func (ad *applicationData) watchLoop(exposed bool) error {
+// applicationExposeCIDRs returns whether the application is exposed,
+// and if so the CIDRs its endpoints are exposed to, keyed on endpoint
+// name. An exposed application with no expose settings may be accessed
+// from anywhere.
+func applicationExposeCIDRs(app *firewaller.Application) (bool, map[string]set.Strings, error) {
+	exposed, exposedEndpoints, err := app.ExposeInfo()
+	if err != nil {
+		return false, nil, errors.Trace(err)
+	}
+	if !exposed {
+		return false, nil, nil
+	}
+	if len(exposedEndpoints) == 0 {
+		return true, map[string]set.Strings{"": set.NewStrings("0.0.0.0/0")}, nil
+	}
+	result := make(map[string]set.Strings)
+	for name, exposedEndpoint := range exposedEndpoints {
+		result[name] = set.NewStrings(exposedEndpoint.ExposeToCIDRs...)
+	}
+	return true, result, nil
+}
+
+// exposeCIDRs returns the CIDRs from which the ports opened for the
+// named endpoint may be accessed. Ports opened for a particular
+// endpoint use that endpoint's expose settings, or those for all
+// endpoints if it has none; ports opened for all endpoints (the empty
+// name) use the CIDRs of all the expose settings.
+func (ad *applicationData) exposeCIDRs(endpoint string) set.Strings {
+	cidrs := set.NewStrings()
+	if !ad.exposed {
+		return cidrs
+	}
+	if endpoint == "" {
+		for _, endpointCIDRs := range ad.exposedEndpoints {
+			cidrs = cidrs.Union(endpointCIDRs)
+		}
+		return cidrs
+	}
+	if endpointCIDRs, ok := ad.exposedEndpoints[endpoint]; ok {
+		return cidrs.Union(endpointCIDRs)
+	}
+	return cidrs.Union(ad.exposedEndpoints[""])
+}
+
+// exposedEndpointsEqual returns whether the given per-endpoint CIDRs
+// are the same.
+func exposedEndpointsEqual(a, b map[string]set.Strings) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for name, cidrsA := range a {
+		cidrsB, ok := b[name]
+		if !ok {
+			return false
+		}
+		if !cidrsA.Difference(cidrsB).IsEmpty() || !cidrsB.Difference(cidrsA).IsEmpty() {
+			return false
+		}
+	}
+	return true
+}
+
+// watchLoop watches the application's exposed flag, expose settings and
+// egress rules for changes.
+func (ad *applicationData) watchLoop(exposed bool, exposedEndpoints map[string]set.Strings, egressRules []network.EgressRule) error {
 	appWatcher, err := ad.application.Watch()
 	if err != nil {
 		if params.IsCodeNotFound(err) {
@@ -1035,17 +1320,31 @@ func (ad *applicationData) watchLoop(exposed bool) error {
 				}
 				return nil
 			}
-			change, err := ad.application.IsExposed()
+			change, changeEndpoints, err := applicationExposeCIDRs(ad.application)
 			if err != nil {
 				return errors.Trace(err)
 			}
-			if change == exposed {
-				continue
+			if change != exposed || !exposedEndpointsEqual(changeEndpoints, exposedEndpoints) {
+				exposed = change
+				exposedEndpoints = changeEndpoints
+				select {
+				case ad.fw.exposedChange <- &exposedChange{ad, change, changeEndpoints}:
+				case <-ad.catacomb.Dying():
+					return ad.catacomb.ErrDying()
+				}
 			}
 
-			exposed = change
+			changeEgress, err := ad.application.EgressRules()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			toOpen, toClose := diffEgressRules(egressRules, changeEgress)
+			if len(toOpen) == 0 && len(toClose) == 0 {
+				continue
+			}
+			egressRules = changeEgress
 			select {
-			case ad.fw.exposedChange <- &exposedChange{ad, change}:
+			case ad.fw.egressChange <- &egressChange{ad, changeEgress}:
 			case <-ad.catacomb.Dying():
 				return ad.catacomb.ErrDying()
 			}
@@ -1143,6 +1442,40 @@ func diffRanges(currentRules, wantedRules []network.IngressRule) (toOpen, toClos
 	return toOpen, toClose
 }
 
+// diffEgressRules returns the egress rules to open and close to go from
+// the current to the wanted rules. Rules are compared per destination,
+// so a rule to open or close may cover only some of the destinations of
+// the current or wanted rule with the same port range.
+func diffEgressRules(currentRules, wantedRules []network.EgressRule) (toOpen, toClose []network.EgressRule) {
+	portCidrs := func(rules []network.EgressRule) map[network.PortRange]set.Strings {
+		result := make(map[network.PortRange]set.Strings)
+		for _, rule := range network.MergeEgressRules(rules) {
+			result[rule.PortRange] = set.NewStrings(rule.DestinationCIDRs...)
+		}
+		return result
+	}
+
+	currentPortCidrs := portCidrs(currentRules)
+	wantedPortCidrs := portCidrs(wantedRules)
+	for portRange, wantedCidrs := range wantedPortCidrs {
+		toOpenCidrs := wantedCidrs.Difference(currentPortCidrs[portRange])
+		if toOpenCidrs.Size() > 0 {
+			rule := network.EgressRule{PortRange: portRange, DestinationCIDRs: toOpenCidrs.SortedValues()}
+			toOpen = append(toOpen, rule)
+		}
+	}
+	for portRange, currentCidrs := range currentPortCidrs {
+		toCloseCidrs := currentCidrs.Difference(wantedPortCidrs[portRange])
+		if toCloseCidrs.Size() > 0 {
+			rule := network.EgressRule{PortRange: portRange, DestinationCIDRs: toCloseCidrs.SortedValues()}
+			toClose = append(toClose, rule)
+		}
+	}
+	network.SortEgressRules(toOpen)
+	network.SortEgressRules(toClose)
+	return toOpen, toClose
+}
+
 // relationLifeChanged manages the workers to process ingress changes for
 // the specified relation.
 func (fw *Firewaller) relationLifeChanged(tag names.RelationTag) error {
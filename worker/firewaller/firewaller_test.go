@@ -19,6 +19,7 @@ import (
 	apifirewaller "github.com/juju/juju/api/firewaller"
 	"github.com/juju/juju/api/remotefirewaller"
 	"github.com/juju/juju/api/remoterelations"
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/feature"
 	"github.com/juju/juju/instance"
@@ -150,6 +151,44 @@ func (s *firewallerBaseSuite) assertEnvironPorts(c *gc.C, expected []network.Ing
 	}
 }
 
+// assertEgressRules retrieves the egress rules of the instance, or of the
+// environment if inst is nil, and compares them to the expected.
+func (s *firewallerBaseSuite) assertEgressRules(c *gc.C, inst instance.Instance, machineId string, expected []network.EgressRule) {
+	s.BackingState.StartSync()
+	start := time.Now()
+	for {
+		var got []network.EgressRule
+		var err error
+		if inst != nil {
+			got, err = inst.(instance.EgressFirewaller).EgressRules(machineId)
+		} else {
+			got, err = s.Environ.(environs.EgressFirewaller).EgressRules()
+		}
+		if err != nil {
+			c.Fatal(err)
+			return
+		}
+		if reflect.DeepEqual(got, expected) {
+			c.Succeed()
+			return
+		}
+		if time.Since(start) > coretesting.LongWait {
+			c.Fatalf("timed out: expected %q; got %q", expected, got)
+			return
+		}
+		time.Sleep(coretesting.ShortWait)
+	}
+}
+
+// setControllerAddress sets the API address of the controller, to
+// which egress is always allowed by applications with egress rules.
+func (s *firewallerBaseSuite) setControllerAddress(c *gc.C) {
+	err := s.State.SetAPIHostPorts([][]network.HostPort{
+		network.NewHostPorts(17070, "10.0.0.1"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *firewallerBaseSuite) addUnit(c *gc.C, app *state.Application) (*state.Unit, *state.Machine) {
 	units, err := juju.AddUnits(s.State, app, app.Name(), 1, nil)
 	c.Assert(err, jc.ErrorIsNil)
@@ -490,6 +529,140 @@ func (s *InstanceModeSuite) TestSetClearExposedApplication(c *gc.C) {
 	s.assertPorts(c, inst, m.Id(), nil)
 }
 
+func (s *InstanceModeSuite) TestExposeSettingsApplication(c *gc.C) {
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	_, err := s.State.AddSpace("dmz", "", nil, true)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSubnet(state.SubnetInfo{CIDR: "10.0.1.0/24", SpaceName: "dmz"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	app := s.AddTestingService(c, "wordpress", s.charm)
+
+	u, m := s.addUnit(c, app)
+	inst := s.startInstance(c, m)
+	err = u.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Exposing an endpoint to a CIDR and a space opens the
+	// ports to the CIDR and the space's subnets.
+	err = app.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"url": {
+			ExposeToSpaces: []string{"dmz"},
+			ExposeToCIDRs:  []string{"192.168.0.0/16"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "10.0.1.0/24", "192.168.0.0/16"),
+	})
+
+	// Changing the expose settings updates the source CIDRs.
+	err = app.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"url": {ExposeToCIDRs: []string{"192.168.0.0/16"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "192.168.0.0/16"),
+	})
+
+	// Unexposing the endpoint closes the ports again.
+	err = app.UnsetExposeSettings([]string{"url"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertPorts(c, inst, m.Id(), nil)
+}
+
+func (s *InstanceModeSuite) TestExposeSettingsEndpointPorts(c *gc.C) {
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app := s.AddTestingService(c, "wordpress", s.charm)
+
+	u, m := s.addUnit(c, app)
+	inst := s.startInstance(c, m)
+	err := u.OpenPortsOnEndpoint("url", "tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	err = u.OpenPortsOnEndpoint("db", "tcp", 3306, 3306)
+	c.Assert(err, jc.ErrorIsNil)
+	err = u.OpenPort("tcp", 8080)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Ports opened for an endpoint use its expose settings, or those
+	// for all endpoints; ports opened for all endpoints use the
+	// settings of every endpoint.
+	err = app.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"url": {ExposeToCIDRs: []string{"192.168.0.0/16"}},
+		"":    {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "192.168.0.0/16"),
+		network.MustNewIngressRule("tcp", 3306, 3306, "10.0.0.0/8"),
+		network.MustNewIngressRule("tcp", 8080, 8080, "10.0.0.0/8", "192.168.0.0/16"),
+	})
+
+	// Without settings for all endpoints, ports opened for other
+	// endpoints are not accessible.
+	err = app.UnsetExposeSettings([]string{""})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "192.168.0.0/16"),
+		network.MustNewIngressRule("tcp", 8080, 8080, "192.168.0.0/16"),
+	})
+}
+
+func (s *InstanceModeSuite) TestEgressRules(c *gc.C) {
+	s.setControllerAddress(c)
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app1 := s.AddTestingService(c, "wordpress", s.charm)
+	err := app1.SetEgressRules([]network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "192.168.0.0/16"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, m1 := s.addUnit(c, app1)
+	inst1 := s.startInstance(c, m1)
+
+	app2 := s.AddTestingService(c, "mysql", s.charm)
+	_, m2 := s.addUnit(c, app2)
+	inst2 := s.startInstance(c, m2)
+
+	// Only the machine hosting the application with egress rules is
+	// restricted, and it may still reach the controller.
+	controllerRule := network.MustNewEgressRule("tcp", 17070, 17070, "10.0.0.1/32")
+	s.assertEgressRules(c, inst1, m1.Id(), []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "192.168.0.0/16"),
+		controllerRule,
+	})
+	s.assertEgressRules(c, inst2, m2.Id(), nil)
+
+	// Charm egress rules are combined with those set by operators.
+	err = app1.SetCharmEgressRules([]network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.1.0.0/16"),
+		network.MustNewEgressRule("udp", 53, 53),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertEgressRules(c, inst1, m1.Id(), []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.1.0.0/16", "192.168.0.0/16"),
+		controllerRule,
+		network.MustNewEgressRule("udp", 53, 53, "0.0.0.0/0"),
+	})
+
+	// Removing all the rules lifts the restriction.
+	err = app1.SetEgressRules(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = app1.SetCharmEgressRules(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertEgressRules(c, inst1, m1.Id(), nil)
+}
+
 func (s *InstanceModeSuite) TestRemoveUnit(c *gc.C) {
 	fw := s.newFirewaller(c)
 	defer statetesting.AssertKillAndWait(c, fw)
@@ -891,6 +1064,46 @@ func (s *GlobalModeSuite) TestGlobalMode(c *gc.C) {
 	s.assertEnvironPorts(c, nil)
 }
 
+func (s *GlobalModeSuite) TestEgressRules(c *gc.C) {
+	s.setControllerAddress(c)
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app1 := s.AddTestingService(c, "wordpress", s.charm)
+	err := app1.SetEgressRules([]network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "192.168.0.0/16"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, m1 := s.addUnit(c, app1)
+	s.startInstance(c, m1)
+
+	app2 := s.AddTestingService(c, "mysql", s.charm)
+	err = app2.SetEgressRules([]network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.1.0.0/16"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, m2 := s.addUnit(c, app2)
+	s.startInstance(c, m2)
+
+	// The environment allows the egress of all applications.
+	controllerRule := network.MustNewEgressRule("tcp", 17070, 17070, "10.0.0.1/32")
+	s.assertEgressRules(c, nil, "", []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.1.0.0/16", "192.168.0.0/16"),
+		controllerRule,
+	})
+
+	err = app1.SetEgressRules(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertEgressRules(c, nil, "", []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.1.0.0/16"),
+		controllerRule,
+	})
+
+	err = app2.SetEgressRules(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertEgressRules(c, nil, "", nil)
+}
+
 func (s *GlobalModeSuite) TestStartWithUnexposedApplication(c *gc.C) {
 	m, err := s.State.AddMachine("quantal", state.JobHostUnits)
 	c.Assert(err, jc.ErrorIsNil)
@@ -0,0 +1,70 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd/elasticsearch"
+	"github.com/juju/juju/logfwd/gelf"
+	"github.com/juju/juju/logfwd/ndjson"
+	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/juju/worker/logforwarder"
+)
+
+// Open returns a sink used to receive log messages to be forwarded,
+// using the protocol selected in the config.
+func Open(cfg *syslog.RawConfig) (*logforwarder.LogSink, error) {
+	switch protocol := cfg.ProtocolOrDefault(); protocol {
+	case syslog.ProtocolSyslog:
+		return OpenSyslog(cfg)
+	case syslog.ProtocolJSON:
+		return OpenJSON(cfg)
+	case syslog.ProtocolGELFUDP, syslog.ProtocolGELFTCP:
+		return OpenGELF(cfg)
+	case syslog.ProtocolElasticsearch:
+		return OpenElasticsearch(cfg)
+	default:
+		return nil, errors.NotValidf("log forwarding protocol %q", protocol)
+	}
+}
+
+// OpenJSON returns a sink that forwards log messages as
+// newline-delimited JSON documents.
+func OpenJSON(cfg *syslog.RawConfig) (*logforwarder.LogSink, error) {
+	if !cfg.Enabled {
+		return nil, errors.New("log forwarding not enabled")
+	}
+	client, err := ndjson.Open(*cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &logforwarder.LogSink{SendCloser: client}, nil
+}
+
+// OpenGELF returns a sink that forwards log messages as GELF
+// messages over UDP or TCP.
+func OpenGELF(cfg *syslog.RawConfig) (*logforwarder.LogSink, error) {
+	if !cfg.Enabled {
+		return nil, errors.New("log forwarding not enabled")
+	}
+	client, err := gelf.Open(*cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &logforwarder.LogSink{SendCloser: client}, nil
+}
+
+// OpenElasticsearch returns a sink that forwards log messages to
+// an Elasticsearch server.
+func OpenElasticsearch(cfg *syslog.RawConfig) (*logforwarder.LogSink, error) {
+	if !cfg.Enabled {
+		return nil, errors.New("log forwarding not enabled")
+	}
+	client, err := elasticsearch.Open(*cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &logforwarder.LogSink{SendCloser: client}, nil
+}
@@ -0,0 +1,70 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks_test
+
+import (
+	"net"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd/elasticsearch"
+	"github.com/juju/juju/logfwd/gelf"
+	"github.com/juju/juju/logfwd/ndjson"
+	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/juju/worker/logforwarder/sinks"
+)
+
+type OpenSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&OpenSuite{})
+
+func (s *OpenSuite) TestOpenNotEnabled(c *gc.C) {
+	for _, protocol := range syslog.Protocols {
+		_, err := sinks.Open(&syslog.RawConfig{Protocol: protocol})
+		c.Check(err, gc.ErrorMatches, "log forwarding not enabled", gc.Commentf("protocol %q", protocol))
+	}
+}
+
+func (s *OpenSuite) TestOpenUnknownProtocol(c *gc.C) {
+	_, err := sinks.Open(&syslog.RawConfig{
+		Enabled:  true,
+		Protocol: "carrier-pigeon",
+	})
+	c.Assert(err, gc.ErrorMatches, `log forwarding protocol "carrier-pigeon" not valid`)
+}
+
+func (s *OpenSuite) TestOpenProtocols(c *gc.C) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, jc.ErrorIsNil)
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+
+	for protocol, expect := range map[string]interface{}{
+		syslog.ProtocolJSON:          &ndjson.Client{},
+		syslog.ProtocolGELFUDP:       &gelf.Client{},
+		syslog.ProtocolGELFTCP:       &gelf.Client{},
+		syslog.ProtocolElasticsearch: &elasticsearch.Client{},
+	} {
+		sink, err := sinks.Open(&syslog.RawConfig{
+			Enabled:  true,
+			Protocol: protocol,
+			Host:     listener.Addr().String(),
+		})
+		c.Assert(err, jc.ErrorIsNil, gc.Commentf("protocol %q", protocol))
+		c.Check(sink.SendCloser, gc.FitsTypeOf, expect)
+		sink.Close()
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/juju/errors"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/worker/uniter/runner/context"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
 )
@@ -80,6 +81,9 @@ func (ctx *limitedContext) HasExecutionSetUnitStatus() bool { return false }
 // ResetExecutionSetUnitStatus implements runner.Context.
 func (ctx *limitedContext) ResetExecutionSetUnitStatus() {}
 
+// RecordExecution implements runner.Context.
+func (ctx *limitedContext) RecordExecution(params.HookExecution) {}
+
 // Id implements runner.Context.
 func (ctx *limitedContext) Id() string { return ctx.id }
 
@@ -10,6 +10,7 @@ import (
 
 	"github.com/juju/errors"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/worker/metrics/spool"
 	"github.com/juju/juju/worker/uniter/runner/context"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
@@ -82,6 +83,9 @@ func (ctx *hookContext) HasExecutionSetUnitStatus() bool { return false }
 // ResetExecutionSetUnitStatus implements runner.Context.
 func (ctx *hookContext) ResetExecutionSetUnitStatus() {}
 
+// RecordExecution implements runner.Context.
+func (ctx *hookContext) RecordExecution(params.HookExecution) {}
+
 // Id implements runner.Context.
 func (ctx *hookContext) Id() string { return ctx.id }
 
@@ -582,6 +582,7 @@ func constructStartInstanceParams(
 			storage.ProviderType(v.Provider),
 			v.Attributes,
 			v.Tags,
+			v.SnapshotId,
 			&storage.VolumeAttachmentParams{
 				AttachmentParams: storage.AttachmentParams{
 					Machine:  machineTag,
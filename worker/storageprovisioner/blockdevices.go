@@ -15,7 +15,7 @@ import (
 // machine have been seen to have changed. This triggers a refresh of all
 // block devices for attached volumes backing pending filesystems.
 func machineBlockDevicesChanged(ctx *context) error {
-	if len(ctx.incompleteFilesystemParams) == 0 {
+	if len(ctx.incompleteFilesystemParams) == 0 && len(ctx.incompleteFilesystemResizeParams) == 0 {
 		return nil
 	}
 	volumeTags := make([]names.VolumeTag, 0, len(ctx.incompleteFilesystemParams))
@@ -37,10 +37,18 @@ func machineBlockDevicesChanged(ctx *context) error {
 		}
 		volumeTags = append(volumeTags, params.Volume)
 	}
+	// Backing-volumes of filesystems waiting to be grown are always
+	// refreshed, as we are waiting for their block devices to grow.
+	for _, params := range ctx.incompleteFilesystemResizeParams {
+		volumeTags = append(volumeTags, params.Volume)
+	}
 	if len(volumeTags) == 0 {
 		return nil
 	}
-	return refreshVolumeBlockDevices(ctx, volumeTags)
+	if err := refreshVolumeBlockDevices(ctx, volumeTags); err != nil {
+		return errors.Trace(err)
+	}
+	return processIncompleteFilesystemResizes(ctx)
 }
 
 // processPendingVolumeBlockDevices is called before waiting for any events,
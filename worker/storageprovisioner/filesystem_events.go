@@ -292,10 +292,13 @@ func processAliveFilesystems(ctx *context, tags []names.FilesystemTag, filesyste
 				return errors.Annotate(err, "getting filesystem info")
 			}
 			updateFilesystem(ctx, filesystem)
-			if filesystem.Volume != (names.VolumeTag{}) {
+			_, isMachineScoped := ctx.config.Scope.(names.MachineTag)
+			if filesystem.Volume != (names.VolumeTag{}) && isMachineScoped {
 				// Ensure that volume-backed filesystems' block
 				// devices are present even after creating the
 				// filesystem, so that attachments can be made.
+				// Model-scoped, volume-backed filesystems are
+				// attached by the machine-scoped provisioners.
 				maybeAddPendingVolumeBlockDevice(ctx, filesystem.Volume)
 			}
 			continue
@@ -372,8 +375,26 @@ func processAliveFilesystemAttachments(
 	if err != nil {
 		return errors.Trace(err)
 	}
+	_, isMachineScoped := ctx.config.Scope.(names.MachineTag)
+	var unknownFilesystems []string
 	for i, params := range params {
 		updatePendingFilesystemAttachment(ctx, pending[i], params)
+		if _, ok := ctx.filesystems[params.Filesystem]; ok || !isMachineScoped {
+			continue
+		}
+		if _, ok := names.FilesystemMachine(params.Filesystem); !ok {
+			// The filesystem is model-scoped and backed by a
+			// volume (e.g. it was imported), so this worker is
+			// responsible for attaching it, but is not watching
+			// it. Fetch its details so the attachment can be
+			// completed.
+			unknownFilesystems = append(unknownFilesystems, params.Filesystem.Id())
+		}
+	}
+	if len(unknownFilesystems) > 0 {
+		if err := filesystemsChanged(ctx, unknownFilesystems); err != nil {
+			return errors.Annotate(err, "getting attached filesystems")
+		}
 	}
 	return nil
 }
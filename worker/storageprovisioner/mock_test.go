@@ -99,6 +99,16 @@ type mockVolumeAccessor struct {
 
 	setVolumeInfo           func([]params.Volume) ([]params.ErrorResult, error)
 	setVolumeAttachmentInfo func([]params.VolumeAttachment) ([]params.ErrorResult, error)
+
+	snapshotsWatcher         *mockStringsWatcher
+	snapshotParams           map[string]params.VolumeSnapshotParams
+	setVolumeSnapshotResults func([]params.VolumeSnapshotResult) ([]params.ErrorResult, error)
+	snapshotDestroyParams    map[string]params.VolumeSnapshotDestroyParams
+	removeVolumeSnapshots    func([]string) ([]params.ErrorResult, error)
+
+	resizesWatcher         *mockStringsWatcher
+	resizeParams           map[string]params.VolumeResizeParams
+	setVolumeResizeResults func([]params.VolumeResizeResult) ([]params.ErrorResult, error)
 }
 
 func (m *mockVolumeAccessor) provisionVolume(tag names.VolumeTag) params.Volume {
@@ -225,6 +235,70 @@ func (v *mockVolumeAccessor) SetVolumeAttachmentInfo(volumeAttachments []params.
 	return make([]params.ErrorResult, len(volumeAttachments)), nil
 }
 
+func (v *mockVolumeAccessor) WatchVolumeSnapshots() (watcher.StringsWatcher, error) {
+	return v.snapshotsWatcher, nil
+}
+
+func (v *mockVolumeAccessor) VolumeSnapshotParams(ids []string) ([]params.VolumeSnapshotParamsResult, error) {
+	result := make([]params.VolumeSnapshotParamsResult, len(ids))
+	for i, id := range ids {
+		if snapshotParams, ok := v.snapshotParams[id]; ok {
+			result[i].Result = &snapshotParams
+		} else {
+			result[i].Error = common.ServerError(errors.NotFoundf("volume snapshot %q", id))
+		}
+	}
+	return result, nil
+}
+
+func (v *mockVolumeAccessor) SetVolumeSnapshotResults(results []params.VolumeSnapshotResult) ([]params.ErrorResult, error) {
+	if v.setVolumeSnapshotResults != nil {
+		return v.setVolumeSnapshotResults(results)
+	}
+	return make([]params.ErrorResult, len(results)), nil
+}
+
+func (v *mockVolumeAccessor) VolumeSnapshotDestroyParams(ids []string) ([]params.VolumeSnapshotDestroyParamsResult, error) {
+	result := make([]params.VolumeSnapshotDestroyParamsResult, len(ids))
+	for i, id := range ids {
+		// Snapshots without destroy params are not Dead.
+		if destroyParams, ok := v.snapshotDestroyParams[id]; ok {
+			result[i].Result = &destroyParams
+		}
+	}
+	return result, nil
+}
+
+func (v *mockVolumeAccessor) RemoveVolumeSnapshots(ids []string) ([]params.ErrorResult, error) {
+	if v.removeVolumeSnapshots != nil {
+		return v.removeVolumeSnapshots(ids)
+	}
+	return make([]params.ErrorResult, len(ids)), nil
+}
+
+func (v *mockVolumeAccessor) WatchVolumeResizes() (watcher.StringsWatcher, error) {
+	return v.resizesWatcher, nil
+}
+
+func (v *mockVolumeAccessor) VolumeResizeParams(tags []names.VolumeTag) ([]params.VolumeResizeParamsResult, error) {
+	result := make([]params.VolumeResizeParamsResult, len(tags))
+	for i, tag := range tags {
+		if resizeParams, ok := v.resizeParams[tag.String()]; ok {
+			result[i].Result = &resizeParams
+		} else {
+			result[i].Error = common.ServerError(errors.NotFoundf("volume %q", tag.Id()))
+		}
+	}
+	return result, nil
+}
+
+func (v *mockVolumeAccessor) SetVolumeResizeResults(results []params.VolumeResizeResult) ([]params.ErrorResult, error) {
+	if v.setVolumeResizeResults != nil {
+		return v.setVolumeResizeResults(results)
+	}
+	return make([]params.ErrorResult, len(results)), nil
+}
+
 func newMockVolumeAccessor() *mockVolumeAccessor {
 	return &mockVolumeAccessor{
 		volumesWatcher:         newMockStringsWatcher(),
@@ -234,6 +308,11 @@ func newMockVolumeAccessor() *mockVolumeAccessor {
 		provisionedVolumes:     make(map[string]params.Volume),
 		provisionedAttachments: make(map[params.MachineStorageId]params.VolumeAttachment),
 		blockDevices:           make(map[params.MachineStorageId]storage.BlockDevice),
+		snapshotsWatcher:       newMockStringsWatcher(),
+		snapshotParams:         make(map[string]params.VolumeSnapshotParams),
+		snapshotDestroyParams:  make(map[string]params.VolumeSnapshotDestroyParams),
+		resizesWatcher:         newMockStringsWatcher(),
+		resizeParams:           make(map[string]params.VolumeResizeParams),
 	}
 }
 
@@ -246,6 +325,10 @@ type mockFilesystemAccessor struct {
 
 	setFilesystemInfo           func([]params.Filesystem) ([]params.ErrorResult, error)
 	setFilesystemAttachmentInfo func([]params.FilesystemAttachment) ([]params.ErrorResult, error)
+
+	resizesWatcher             *mockStringsWatcher
+	resizeParams               map[string]params.FilesystemResizeParams
+	setFilesystemResizeResults func([]params.FilesystemResizeResult) ([]params.ErrorResult, error)
 }
 
 func (m *mockFilesystemAccessor) provisionFilesystem(tag names.FilesystemTag) params.Filesystem {
@@ -349,6 +432,29 @@ func (f *mockFilesystemAccessor) SetFilesystemAttachmentInfo(filesystemAttachmen
 	return make([]params.ErrorResult, len(filesystemAttachments)), nil
 }
 
+func (f *mockFilesystemAccessor) WatchFilesystemResizes() (watcher.StringsWatcher, error) {
+	return f.resizesWatcher, nil
+}
+
+func (f *mockFilesystemAccessor) FilesystemResizeParams(tags []names.FilesystemTag) ([]params.FilesystemResizeParamsResult, error) {
+	result := make([]params.FilesystemResizeParamsResult, len(tags))
+	for i, tag := range tags {
+		if resizeParams, ok := f.resizeParams[tag.String()]; ok {
+			result[i].Result = &resizeParams
+		} else {
+			result[i].Error = common.ServerError(errors.NotFoundf("filesystem %q", tag.Id()))
+		}
+	}
+	return result, nil
+}
+
+func (f *mockFilesystemAccessor) SetFilesystemResizeResults(results []params.FilesystemResizeResult) ([]params.ErrorResult, error) {
+	if f.setFilesystemResizeResults != nil {
+		return f.setFilesystemResizeResults(results)
+	}
+	return make([]params.ErrorResult, len(results)), nil
+}
+
 func newMockFilesystemAccessor() *mockFilesystemAccessor {
 	return &mockFilesystemAccessor{
 		filesystemsWatcher:     newMockStringsWatcher(),
@@ -356,6 +462,8 @@ func newMockFilesystemAccessor() *mockFilesystemAccessor {
 		provisionedMachines:    make(map[string]instance.Id),
 		provisionedFilesystems: make(map[string]params.Filesystem),
 		provisionedAttachments: make(map[params.MachineStorageId]params.FilesystemAttachment),
+		resizesWatcher:         newMockStringsWatcher(),
+		resizeParams:           make(map[string]params.FilesystemResizeParams),
 	}
 }
 
@@ -424,6 +532,9 @@ type dummyProvider struct {
 	volumeSourceFunc             func(*storage.Config) (storage.VolumeSource, error)
 	filesystemSourceFunc         func(*storage.Config) (storage.FilesystemSource, error)
 	createVolumesFunc            func([]storage.VolumeParams) ([]storage.CreateVolumesResult, error)
+	createVolumeSnapshotsFunc    func([]storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error)
+	destroyVolumeSnapshotsFunc   func([]string) ([]error, error)
+	resizeVolumesFunc            func([]storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error)
 	createFilesystemsFunc        func([]storage.FilesystemParams) ([]storage.CreateFilesystemsResult, error)
 	attachVolumesFunc            func([]storage.VolumeAttachmentParams) ([]storage.AttachVolumesResult, error)
 	attachFilesystemsFunc        func([]storage.FilesystemAttachmentParams) ([]storage.AttachFilesystemsResult, error)
@@ -498,6 +609,42 @@ func (s *dummyVolumeSource) CreateVolumes(params []storage.VolumeParams) ([]stor
 	return results, nil
 }
 
+// CreateVolumeSnapshots takes snapshots of volumes.
+func (s *dummyVolumeSource) CreateVolumeSnapshots(params []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error) {
+	if s.provider != nil && s.provider.createVolumeSnapshotsFunc != nil {
+		return s.provider.createVolumeSnapshotsFunc(params)
+	}
+	results := make([]storage.CreateVolumeSnapshotsResult, len(params))
+	for i, p := range params {
+		results[i].VolumeSnapshot = &storage.VolumeSnapshot{
+			Volume:     p.Volume,
+			SnapshotId: "snap-" + p.VolumeId,
+			Size:       1024,
+		}
+	}
+	return results, nil
+}
+
+// DestroyVolumeSnapshots destroys volume snapshots.
+func (s *dummyVolumeSource) DestroyVolumeSnapshots(snapshotIds []string) ([]error, error) {
+	if s.provider != nil && s.provider.destroyVolumeSnapshotsFunc != nil {
+		return s.provider.destroyVolumeSnapshotsFunc(snapshotIds)
+	}
+	return make([]error, len(snapshotIds)), nil
+}
+
+// ResizeVolumes grows volumes.
+func (s *dummyVolumeSource) ResizeVolumes(params []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	if s.provider != nil && s.provider.resizeVolumesFunc != nil {
+		return s.provider.resizeVolumesFunc(params)
+	}
+	results := make([]storage.ResizeVolumesResult, len(params))
+	for i, p := range params {
+		results[i].Size = p.Size
+	}
+	return results, nil
+}
+
 // DestroyVolumes destroys volumes.
 func (s *dummyVolumeSource) DestroyVolumes(volumeIds []string) ([]error, error) {
 	if s.provider.destroyVolumesFunc != nil {
@@ -0,0 +1,421 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storageprovisioner
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/status"
+	"github.com/juju/juju/storage"
+)
+
+// volumeResizesChanged is called when the pending resizes of the volumes
+// with the provided IDs have been seen to have changed. Any volumes with
+// a resize pending are grown immediately.
+//
+// As with snapshots, resizes are not retried: the outcome of each
+// attempt is recorded in state, and failures are reported in the
+// volume's status.
+func volumeResizesChanged(ctx *context, changes []string) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	tags := make([]names.VolumeTag, len(changes))
+	for i, change := range changes {
+		tags[i] = names.NewVolumeTag(change)
+	}
+	paramsResults, err := ctx.config.Volumes.VolumeResizeParams(tags)
+	if err != nil {
+		return errors.Annotate(err, "getting volume resize params")
+	}
+	var resizeParams []storage.VolumeResizeParams
+	for i, result := range paramsResults {
+		if result.Error != nil {
+			if params.IsCodeNotFound(result.Error) || params.IsCodeUnauthorized(result.Error) {
+				// The volume has been removed, or is no
+				// longer our responsibility.
+				continue
+			}
+			return errors.Annotatef(result.Error, "getting resize params for %s", names.ReadableString(tags[i]))
+		}
+		if result.Result == nil {
+			// There is no resize pending.
+			continue
+		}
+		args, err := volumeResizeParamsFromParams(*result.Result)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		resizeParams = append(resizeParams, args)
+	}
+	if len(resizeParams) == 0 {
+		return nil
+	}
+	return resizeVolumes(ctx, resizeParams)
+}
+
+// resizeVolumes grows volumes with the specified parameters, and
+// records the outcomes in state.
+func resizeVolumes(ctx *context, resizeParams []storage.VolumeResizeParams) error {
+	bySource := make(map[storage.ProviderType][]int)
+	for i, args := range resizeParams {
+		bySource[args.Provider] = append(bySource[args.Provider], i)
+	}
+	results := make([]params.VolumeResizeResult, len(resizeParams))
+	for i, args := range resizeParams {
+		results[i].VolumeTag = args.Volume.String()
+	}
+	setError := func(i int, err error) {
+		logger.Debugf("failed to resize %s: %v", names.ReadableString(resizeParams[i].Volume), err)
+		results[i].Error = &params.Error{Message: err.Error()}
+	}
+	for providerType, indices := range bySource {
+		resizer, err := volumeResizer(ctx, providerType)
+		if err != nil {
+			for _, i := range indices {
+				setError(i, err)
+			}
+			continue
+		}
+		args := make([]storage.VolumeResizeParams, len(indices))
+		for j, i := range indices {
+			args[j] = resizeParams[i]
+		}
+		logger.Debugf("resizing volumes: %v", args)
+		resizeResults, err := resizer.ResizeVolumes(args)
+		if err != nil {
+			for _, i := range indices {
+				setError(i, err)
+			}
+			continue
+		}
+		for j, result := range resizeResults {
+			i := indices[j]
+			if result.Error != nil {
+				setError(i, result.Error)
+				continue
+			}
+			results[i].Size = result.Size
+			if volume, ok := ctx.volumes[resizeParams[i].Volume]; ok {
+				volume.Size = result.Size
+				ctx.volumes[resizeParams[i].Volume] = volume
+			}
+		}
+	}
+	var statuses []params.EntityStatusArgs
+	for i, result := range results {
+		if result.Error != nil {
+			statuses = append(statuses, params.EntityStatusArgs{
+				Tag:    result.VolumeTag,
+				Status: status.Error.String(),
+				Info:   "resizing volume: " + result.Error.Message,
+			})
+		} else if volumeAttached(ctx, resizeParams[i].Volume) {
+			statuses = append(statuses, params.EntityStatusArgs{
+				Tag:    result.VolumeTag,
+				Status: status.Attached.String(),
+			})
+		}
+	}
+	setStatus(ctx, statuses)
+	errorResults, err := ctx.config.Volumes.SetVolumeResizeResults(results)
+	if err != nil {
+		return errors.Annotate(err, "publishing volume resizes to state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			logger.Errorf(
+				"publishing resize of %s to state: %v",
+				names.ReadableString(resizeParams[i].Volume),
+				result.Error,
+			)
+		}
+	}
+	return nil
+}
+
+// volumeResizer returns the VolumeResizer for the specified storage
+// provider, or an error if its volume source does not support resizing.
+func volumeResizer(ctx *context, providerType storage.ProviderType) (storage.VolumeResizer, error) {
+	source, err := volumeSource(
+		ctx.config.StorageDir, string(providerType), providerType, ctx.config.Registry,
+	)
+	if errors.Cause(err) == errNonDynamic {
+		return nil, errors.NotSupportedf("resizing non-dynamic %q volumes", providerType)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	resizer, ok := source.(storage.VolumeResizer)
+	if !ok {
+		return nil, errors.NotSupportedf("resizing %q volumes", providerType)
+	}
+	return resizer, nil
+}
+
+// filesystemResizesChanged is called when the pending resizes of the
+// filesystems with the provided IDs have been seen to have changed.
+// Filesystems with a resize pending are grown immediately, except for
+// volume-backed filesystems whose backing volume's block device has not
+// yet been seen to grow; these are grown once it has.
+func filesystemResizesChanged(ctx *context, changes []string) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	tags := make([]names.FilesystemTag, len(changes))
+	for i, change := range changes {
+		tags[i] = names.NewFilesystemTag(change)
+	}
+	paramsResults, err := ctx.config.Filesystems.FilesystemResizeParams(tags)
+	if err != nil {
+		return errors.Annotate(err, "getting filesystem resize params")
+	}
+	var resizeParams []storage.FilesystemResizeParams
+	var volumeTags []names.VolumeTag
+	for i, result := range paramsResults {
+		if result.Error != nil {
+			if params.IsCodeNotFound(result.Error) || params.IsCodeUnauthorized(result.Error) {
+				// The filesystem has been removed, or is no
+				// longer our responsibility.
+				delete(ctx.incompleteFilesystemResizeParams, tags[i])
+				continue
+			}
+			return errors.Annotatef(result.Error, "getting resize params for %s", names.ReadableString(tags[i]))
+		}
+		if result.Result == nil {
+			// There is no resize pending.
+			delete(ctx.incompleteFilesystemResizeParams, tags[i])
+			continue
+		}
+		args, err := filesystemResizeParamsFromParams(*result.Result)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if args.Volume != (names.VolumeTag{}) {
+			ctx.incompleteFilesystemResizeParams[args.Filesystem] = args
+			volumeTags = append(volumeTags, args.Volume)
+			continue
+		}
+		resizeParams = append(resizeParams, args)
+	}
+	if len(volumeTags) > 0 {
+		if err := refreshVolumeBlockDevices(ctx, volumeTags); err != nil {
+			return errors.Trace(err)
+		}
+		if err := processIncompleteFilesystemResizes(ctx); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if len(resizeParams) == 0 {
+		return nil
+	}
+	return resizeFilesystems(ctx, resizeParams)
+}
+
+// processIncompleteFilesystemResizes grows the volume-backed filesystems
+// whose backing volumes' block devices have grown to the requested size.
+func processIncompleteFilesystemResizes(ctx *context) error {
+	var ready []storage.FilesystemResizeParams
+	for tag, args := range ctx.incompleteFilesystemResizeParams {
+		blockDevice, ok := ctx.volumeBlockDevices[args.Volume]
+		if !ok || blockDevice.Size < args.Size {
+			continue
+		}
+		ready = append(ready, args)
+		delete(ctx.incompleteFilesystemResizeParams, tag)
+	}
+	if len(ready) == 0 {
+		return nil
+	}
+	return resizeFilesystems(ctx, ready)
+}
+
+// resizeFilesystems grows filesystems with the specified parameters,
+// and records the outcomes in state.
+func resizeFilesystems(ctx *context, resizeParams []storage.FilesystemResizeParams) error {
+	bySource := make(map[storage.ProviderType][]int)
+	for i, args := range resizeParams {
+		bySource[args.Provider] = append(bySource[args.Provider], i)
+	}
+	results := make([]params.FilesystemResizeResult, len(resizeParams))
+	for i, args := range resizeParams {
+		results[i].FilesystemTag = args.Filesystem.String()
+	}
+	setError := func(i int, err error) {
+		logger.Debugf("failed to resize %s: %v", names.ReadableString(resizeParams[i].Filesystem), err)
+		results[i].Error = &params.Error{Message: err.Error()}
+	}
+	for providerType, indices := range bySource {
+		// Volume-backed and other filesystems from the same provider
+		// are handled by different sources, so group them again.
+		var managed, unmanaged []int
+		for _, i := range indices {
+			if resizeParams[i].Volume != (names.VolumeTag{}) {
+				managed = append(managed, i)
+			} else {
+				unmanaged = append(unmanaged, i)
+			}
+		}
+		for _, indices := range [][]int{managed, unmanaged} {
+			if len(indices) == 0 {
+				continue
+			}
+			var resizer storage.FilesystemResizer
+			var err error
+			if resizeParams[indices[0]].Volume != (names.VolumeTag{}) {
+				resizer, err = managedFilesystemResizer(ctx)
+			} else {
+				resizer, err = filesystemResizer(ctx, providerType)
+			}
+			if err != nil {
+				for _, i := range indices {
+					setError(i, err)
+				}
+				continue
+			}
+			args := make([]storage.FilesystemResizeParams, len(indices))
+			for j, i := range indices {
+				args[j] = resizeParams[i]
+			}
+			logger.Debugf("resizing filesystems: %v", args)
+			resizeResults, err := resizer.ResizeFilesystems(args)
+			if err != nil {
+				for _, i := range indices {
+					setError(i, err)
+				}
+				continue
+			}
+			for j, result := range resizeResults {
+				i := indices[j]
+				if result.Error != nil {
+					setError(i, result.Error)
+					continue
+				}
+				results[i].Size = result.Size
+				if filesystem, ok := ctx.filesystems[resizeParams[i].Filesystem]; ok {
+					filesystem.Size = result.Size
+					ctx.filesystems[resizeParams[i].Filesystem] = filesystem
+				}
+			}
+		}
+	}
+	var statuses []params.EntityStatusArgs
+	for i, result := range results {
+		if result.Error != nil {
+			statuses = append(statuses, params.EntityStatusArgs{
+				Tag:    result.FilesystemTag,
+				Status: status.Error.String(),
+				Info:   "resizing filesystem: " + result.Error.Message,
+			})
+		} else if filesystemAttached(ctx, resizeParams[i].Filesystem) {
+			statuses = append(statuses, params.EntityStatusArgs{
+				Tag:    result.FilesystemTag,
+				Status: status.Attached.String(),
+			})
+		}
+	}
+	setStatus(ctx, statuses)
+	errorResults, err := ctx.config.Filesystems.SetFilesystemResizeResults(results)
+	if err != nil {
+		return errors.Annotate(err, "publishing filesystem resizes to state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			logger.Errorf(
+				"publishing resize of %s to state: %v",
+				names.ReadableString(resizeParams[i].Filesystem),
+				result.Error,
+			)
+		}
+	}
+	return nil
+}
+
+// filesystemResizer returns the FilesystemResizer for the specified
+// storage provider, or an error if its filesystem source does not
+// support resizing.
+func filesystemResizer(ctx *context, providerType storage.ProviderType) (storage.FilesystemResizer, error) {
+	source, err := filesystemSource(
+		ctx.config.StorageDir, string(providerType), providerType, ctx.config.Registry,
+	)
+	if errors.Cause(err) == errNonDynamic {
+		return nil, errors.NotSupportedf("resizing non-dynamic %q filesystems", providerType)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	resizer, ok := source.(storage.FilesystemResizer)
+	if !ok {
+		return nil, errors.NotSupportedf("resizing %q filesystems", providerType)
+	}
+	return resizer, nil
+}
+
+// managedFilesystemResizer returns the FilesystemResizer for
+// volume-backed filesystems.
+func managedFilesystemResizer(ctx *context) (storage.FilesystemResizer, error) {
+	resizer, ok := ctx.managedFilesystemSource.(storage.FilesystemResizer)
+	if !ok {
+		return nil, errors.NotSupportedf("resizing volume-backed filesystems")
+	}
+	return resizer, nil
+}
+
+// volumeAttached reports whether the specified volume is known to be
+// attached to a machine.
+func volumeAttached(ctx *context, tag names.VolumeTag) bool {
+	for id := range ctx.volumeAttachments {
+		if id.AttachmentTag == tag.String() {
+			return true
+		}
+	}
+	return false
+}
+
+// filesystemAttached reports whether the specified filesystem is known
+// to be attached to a machine.
+func filesystemAttached(ctx *context, tag names.FilesystemTag) bool {
+	for id := range ctx.filesystemAttachments {
+		if id.AttachmentTag == tag.String() {
+			return true
+		}
+	}
+	return false
+}
+
+func volumeResizeParamsFromParams(in params.VolumeResizeParams) (storage.VolumeResizeParams, error) {
+	volumeTag, err := names.ParseVolumeTag(in.VolumeTag)
+	if err != nil {
+		return storage.VolumeResizeParams{}, errors.Trace(err)
+	}
+	return storage.VolumeResizeParams{
+		Volume:     volumeTag,
+		VolumeId:   in.VolumeId,
+		Size:       in.Size,
+		Provider:   storage.ProviderType(in.Provider),
+		Attributes: in.Attributes,
+	}, nil
+}
+
+func filesystemResizeParamsFromParams(in params.FilesystemResizeParams) (storage.FilesystemResizeParams, error) {
+	filesystemTag, err := names.ParseFilesystemTag(in.FilesystemTag)
+	if err != nil {
+		return storage.FilesystemResizeParams{}, errors.Trace(err)
+	}
+	var volumeTag names.VolumeTag
+	if in.VolumeTag != "" {
+		volumeTag, err = names.ParseVolumeTag(in.VolumeTag)
+		if err != nil {
+			return storage.FilesystemResizeParams{}, errors.Trace(err)
+		}
+	}
+	return storage.FilesystemResizeParams{
+		Filesystem:   filesystemTag,
+		FilesystemId: in.FilesystemId,
+		Volume:       volumeTag,
+		Size:         in.Size,
+		Provider:     storage.ProviderType(in.Provider),
+		Attributes:   in.Attributes,
+	}, nil
+}
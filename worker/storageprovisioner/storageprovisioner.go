@@ -85,6 +85,37 @@ type VolumeAccessor interface {
 	// SetVolumeAttachmentInfo records the details of newly provisioned
 	// volume attachments.
 	SetVolumeAttachmentInfo([]params.VolumeAttachment) ([]params.ErrorResult, error)
+
+	// WatchVolumeSnapshots watches for changes to volume snapshots
+	// that this storage provisioner is responsible for.
+	WatchVolumeSnapshots() (watcher.StringsWatcher, error)
+
+	// VolumeSnapshotParams returns the parameters for taking the
+	// volume snapshots with the specified IDs.
+	VolumeSnapshotParams([]string) ([]params.VolumeSnapshotParamsResult, error)
+
+	// SetVolumeSnapshotResults records the outcome of taking volume
+	// snapshots.
+	SetVolumeSnapshotResults([]params.VolumeSnapshotResult) ([]params.ErrorResult, error)
+
+	// VolumeSnapshotDestroyParams returns the parameters for
+	// destroying the Dead volume snapshots with the specified IDs.
+	VolumeSnapshotDestroyParams([]string) ([]params.VolumeSnapshotDestroyParamsResult, error)
+
+	// RemoveVolumeSnapshots removes the destroyed volume snapshots
+	// with the specified IDs from state.
+	RemoveVolumeSnapshots([]string) ([]params.ErrorResult, error)
+
+	// WatchVolumeResizes watches for changes to the pending resizes
+	// of volumes that this storage provisioner is responsible for.
+	WatchVolumeResizes() (watcher.StringsWatcher, error)
+
+	// VolumeResizeParams returns the parameters for growing the
+	// volumes with the specified tags.
+	VolumeResizeParams([]names.VolumeTag) ([]params.VolumeResizeParamsResult, error)
+
+	// SetVolumeResizeResults records the outcome of growing volumes.
+	SetVolumeResizeResults([]params.VolumeResizeResult) ([]params.ErrorResult, error)
 }
 
 // FilesystemAccessor defines an interface used to allow a storage provisioner
@@ -119,6 +150,19 @@ type FilesystemAccessor interface {
 	// SetFilesystemAttachmentInfo records the details of newly provisioned
 	// filesystem attachments.
 	SetFilesystemAttachmentInfo([]params.FilesystemAttachment) ([]params.ErrorResult, error)
+
+	// WatchFilesystemResizes watches for changes to the pending
+	// resizes of filesystems that this storage provisioner is
+	// responsible for.
+	WatchFilesystemResizes() (watcher.StringsWatcher, error)
+
+	// FilesystemResizeParams returns the parameters for growing the
+	// filesystems with the specified tags.
+	FilesystemResizeParams([]names.FilesystemTag) ([]params.FilesystemResizeParamsResult, error)
+
+	// SetFilesystemResizeResults records the outcome of growing
+	// filesystems.
+	SetFilesystemResizeResults([]params.FilesystemResizeResult) ([]params.ErrorResult, error)
 }
 
 // MachineAccessor defines an interface used to allow a storage provisioner
@@ -200,7 +244,10 @@ func (w *storageProvisioner) loop() error {
 		volumesChanges               watcher.StringsChannel
 		filesystemsChanges           watcher.StringsChannel
 		volumeAttachmentsChanges     watcher.MachineStorageIdsChannel
+		volumeSnapshotsChanges       watcher.StringsChannel
+		volumeResizesChanges         watcher.StringsChannel
 		filesystemAttachmentsChanges watcher.MachineStorageIdsChannel
+		filesystemResizesChanges     watcher.StringsChannel
 		machineBlockDevicesChanges   <-chan struct{}
 	)
 	machineChanges := make(chan names.MachineTag)
@@ -254,6 +301,33 @@ func (w *storageProvisioner) loop() error {
 	}
 	filesystemAttachmentsChanges = filesystemAttachmentsWatcher.Changes()
 
+	volumeSnapshotsWatcher, err := w.config.Volumes.WatchVolumeSnapshots()
+	if err != nil {
+		return errors.Annotate(err, "watching volume snapshots")
+	}
+	if err := w.catacomb.Add(volumeSnapshotsWatcher); err != nil {
+		return errors.Trace(err)
+	}
+	volumeSnapshotsChanges = volumeSnapshotsWatcher.Changes()
+
+	volumeResizesWatcher, err := w.config.Volumes.WatchVolumeResizes()
+	if err != nil {
+		return errors.Annotate(err, "watching volume resizes")
+	}
+	if err := w.catacomb.Add(volumeResizesWatcher); err != nil {
+		return errors.Trace(err)
+	}
+	volumeResizesChanges = volumeResizesWatcher.Changes()
+
+	filesystemResizesWatcher, err := w.config.Filesystems.WatchFilesystemResizes()
+	if err != nil {
+		return errors.Annotate(err, "watching filesystem resizes")
+	}
+	if err := w.catacomb.Add(filesystemResizesWatcher); err != nil {
+		return errors.Trace(err)
+	}
+	filesystemResizesChanges = filesystemResizesWatcher.Changes()
+
 	ctx := context{
 		kill:                                 w.catacomb.Kill,
 		addWorker:                            w.catacomb.Add,
@@ -270,6 +344,7 @@ func (w *storageProvisioner) loop() error {
 		incompleteVolumeAttachmentParams:     make(map[params.MachineStorageId]storage.VolumeAttachmentParams),
 		incompleteFilesystemParams:           make(map[names.FilesystemTag]storage.FilesystemParams),
 		incompleteFilesystemAttachmentParams: make(map[params.MachineStorageId]storage.FilesystemAttachmentParams),
+		incompleteFilesystemResizeParams:     make(map[names.FilesystemTag]storage.FilesystemResizeParams),
 		pendingVolumeBlockDevices:            make(set.Tags),
 	}
 	ctx.managedFilesystemSource = newManagedFilesystemSource(
@@ -313,6 +388,27 @@ func (w *storageProvisioner) loop() error {
 			if err := filesystemAttachmentsChanged(&ctx, changes); err != nil {
 				return errors.Trace(err)
 			}
+		case changes, ok := <-volumeSnapshotsChanges:
+			if !ok {
+				return errors.New("volume snapshots watcher closed")
+			}
+			if err := volumeSnapshotsChanged(&ctx, changes); err != nil {
+				return errors.Trace(err)
+			}
+		case changes, ok := <-volumeResizesChanges:
+			if !ok {
+				return errors.New("volume resizes watcher closed")
+			}
+			if err := volumeResizesChanged(&ctx, changes); err != nil {
+				return errors.Trace(err)
+			}
+		case changes, ok := <-filesystemResizesChanges:
+			if !ok {
+				return errors.New("filesystem resizes watcher closed")
+			}
+			if err := filesystemResizesChanged(&ctx, changes); err != nil {
+				return errors.Trace(err)
+			}
 		case _, ok := <-machineBlockDevicesChanges:
 			if !ok {
 				return errors.New("machine block devices watcher closed")
@@ -476,6 +572,13 @@ type context struct {
 	// map and a filesystem attachment operation is scheduled.
 	incompleteFilesystemAttachmentParams map[params.MachineStorageId]storage.FilesystemAttachmentParams
 
+	// incompleteFilesystemResizeParams contains parameters for growing
+	// volume-backed filesystems whose backing volumes' block devices
+	// have not yet been seen to grow. Once the block device has grown,
+	// the parameters are removed from this map and the filesystem is
+	// grown.
+	incompleteFilesystemResizeParams map[names.FilesystemTag]storage.FilesystemResizeParams
+
 	// pendingVolumeBlockDevices contains the tags of volumes about whose
 	// block devices we wish to enquire.
 	pendingVolumeBlockDevices set.Tags
@@ -143,6 +143,208 @@ func (s *storageProvisionerSuite) TestVolumeAdded(c *gc.C) {
 	waitChannel(c, volumeAttachmentInfoSet, "waiting for volume attachments to be set")
 }
 
+func (s *storageProvisionerSuite) TestVolumeSnapshotAdded(c *gc.C) {
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.snapshotParams["0"] = params.VolumeSnapshotParams{
+		Id:        "0",
+		VolumeTag: "volume-1",
+		VolumeId:  "id-1",
+		Provider:  "dummy",
+	}
+	// Snapshot "1" does not exist, and should be ignored.
+
+	snapshotResultsSet := make(chan interface{})
+	volumeAccessor.setVolumeSnapshotResults = func(results []params.VolumeSnapshotResult) ([]params.ErrorResult, error) {
+		defer close(snapshotResultsSet)
+		c.Assert(results, jc.DeepEquals, []params.VolumeSnapshotResult{{
+			Id:         "0",
+			SnapshotId: "snap-id-1",
+			Size:       1024,
+		}})
+		return make([]params.ErrorResult, len(results)), nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.snapshotsWatcher.changes <- []string{"0", "1"}
+	waitChannel(c, snapshotResultsSet, "waiting for volume snapshot results to be set")
+}
+
+func (s *storageProvisionerSuite) TestVolumeSnapshotFailed(c *gc.C) {
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.snapshotParams["0"] = params.VolumeSnapshotParams{
+		Id:        "0",
+		VolumeTag: "volume-1",
+		VolumeId:  "id-1",
+		Provider:  "dummy",
+	}
+	s.provider.createVolumeSnapshotsFunc = func(args []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error) {
+		return []storage.CreateVolumeSnapshotsResult{{
+			Error: errors.New("snapshot quota exceeded"),
+		}}, nil
+	}
+
+	snapshotResultsSet := make(chan interface{})
+	volumeAccessor.setVolumeSnapshotResults = func(results []params.VolumeSnapshotResult) ([]params.ErrorResult, error) {
+		defer close(snapshotResultsSet)
+		c.Assert(results, jc.DeepEquals, []params.VolumeSnapshotResult{{
+			Id:    "0",
+			Error: &params.Error{Message: "snapshot quota exceeded"},
+		}})
+		return make([]params.ErrorResult, len(results)), nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.snapshotsWatcher.changes <- []string{"0"}
+	waitChannel(c, snapshotResultsSet, "waiting for volume snapshot results to be set")
+}
+
+func (s *storageProvisionerSuite) TestVolumeSnapshotDestroyed(c *gc.C) {
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.snapshotDestroyParams["0"] = params.VolumeSnapshotDestroyParams{
+		Id:         "0",
+		SnapshotId: "snap-0",
+		Provider:   "dummy",
+	}
+	volumeAccessor.snapshotDestroyParams["1"] = params.VolumeSnapshotDestroyParams{
+		Id:         "1",
+		SnapshotId: "snap-1",
+		Provider:   "dummy",
+	}
+	s.provider.destroyVolumeSnapshotsFunc = func(snapshotIds []string) ([]error, error) {
+		// The order of snapshots sharing a provider is preserved.
+		c.Assert(snapshotIds, jc.DeepEquals, []string{"snap-0", "snap-1"})
+		return []error{nil, errors.New("snapshot in use")}, nil
+	}
+
+	// Only the snapshot that was destroyed is removed.
+	snapshotsRemoved := make(chan interface{})
+	volumeAccessor.removeVolumeSnapshots = func(ids []string) ([]params.ErrorResult, error) {
+		defer close(snapshotsRemoved)
+		c.Assert(ids, jc.DeepEquals, []string{"0"})
+		return make([]params.ErrorResult, len(ids)), nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.snapshotsWatcher.changes <- []string{"0", "1"}
+	waitChannel(c, snapshotsRemoved, "waiting for volume snapshots to be removed")
+}
+
+func (s *storageProvisionerSuite) TestVolumeResized(c *gc.C) {
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.resizeParams["volume-1"] = params.VolumeResizeParams{
+		VolumeTag: "volume-1",
+		VolumeId:  "id-1",
+		Size:      2048,
+		Provider:  "dummy",
+	}
+	// Volume "2" does not exist, and should be ignored.
+
+	resizeResultsSet := make(chan interface{})
+	volumeAccessor.setVolumeResizeResults = func(results []params.VolumeResizeResult) ([]params.ErrorResult, error) {
+		defer close(resizeResultsSet)
+		c.Assert(results, jc.DeepEquals, []params.VolumeResizeResult{{
+			VolumeTag: "volume-1",
+			Size:      2048,
+		}})
+		return make([]params.ErrorResult, len(results)), nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.resizesWatcher.changes <- []string{"1", "2"}
+	waitChannel(c, resizeResultsSet, "waiting for volume resize results to be set")
+}
+
+func (s *storageProvisionerSuite) TestVolumeResizeFailed(c *gc.C) {
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.resizeParams["volume-1"] = params.VolumeResizeParams{
+		VolumeTag: "volume-1",
+		VolumeId:  "id-1",
+		Size:      2048,
+		Provider:  "dummy",
+	}
+	s.provider.resizeVolumesFunc = func(args []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+		return []storage.ResizeVolumesResult{{
+			Error: errors.New("volume is busy"),
+		}}, nil
+	}
+
+	resizeResultsSet := make(chan interface{})
+	volumeAccessor.setVolumeResizeResults = func(results []params.VolumeResizeResult) ([]params.ErrorResult, error) {
+		defer close(resizeResultsSet)
+		c.Assert(results, jc.DeepEquals, []params.VolumeResizeResult{{
+			VolumeTag: "volume-1",
+			Error:     &params.Error{Message: "volume is busy"},
+		}})
+		return make([]params.ErrorResult, len(results)), nil
+	}
+
+	statusSet := make(chan interface{})
+	statusSetter := &mockStatusSetter{
+		setStatus: func(args []params.EntityStatusArgs) error {
+			defer close(statusSet)
+			c.Assert(args, jc.DeepEquals, []params.EntityStatusArgs{{
+				Tag:    "volume-1",
+				Status: "error",
+				Info:   "resizing volume: volume is busy",
+			}})
+			return nil
+		},
+	}
+
+	args := &workerArgs{volumes: volumeAccessor, statusSetter: statusSetter, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.resizesWatcher.changes <- []string{"1"}
+	waitChannel(c, statusSet, "waiting for volume status to be set")
+	waitChannel(c, resizeResultsSet, "waiting for volume resize results to be set")
+}
+
+func (s *storageProvisionerSuite) TestFilesystemResizeNotSupported(c *gc.C) {
+	filesystemAccessor := newMockFilesystemAccessor()
+	filesystemAccessor.resizeParams["filesystem-1"] = params.FilesystemResizeParams{
+		FilesystemTag: "filesystem-1",
+		FilesystemId:  "id-1",
+		Size:          2048,
+		Provider:      "dummy",
+	}
+
+	resizeResultsSet := make(chan interface{})
+	filesystemAccessor.setFilesystemResizeResults = func(results []params.FilesystemResizeResult) ([]params.ErrorResult, error) {
+		defer close(resizeResultsSet)
+		c.Assert(results, jc.DeepEquals, []params.FilesystemResizeResult{{
+			FilesystemTag: "filesystem-1",
+			Error:         &params.Error{Message: `resizing "dummy" filesystems not supported`},
+		}})
+		return make([]params.ErrorResult, len(results)), nil
+	}
+
+	args := &workerArgs{filesystems: filesystemAccessor, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	filesystemAccessor.resizesWatcher.changes <- []string{"1"}
+	waitChannel(c, resizeResultsSet, "waiting for filesystem resize results to be set")
+}
+
 func (s *storageProvisionerSuite) TestCreateVolumeCreatesAttachment(c *gc.C) {
 	volumeAccessor := newMockVolumeAccessor()
 	volumeAccessor.provisionedMachines["machine-1"] = instance.Id("already-provisioned-1")
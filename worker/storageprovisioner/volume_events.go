@@ -475,6 +475,7 @@ func volumeParamsFromParams(in params.VolumeParams) (storage.VolumeParams, error
 		providerType,
 		in.Attributes,
 		in.Tags,
+		in.SnapshotId,
 		attachment,
 	}, nil
 }
@@ -0,0 +1,222 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storageprovisioner
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/storage"
+)
+
+// volumeSnapshotsChanged is called when the lifecycle states of the volume
+// snapshots with the provided IDs have been seen to have changed. Any
+// snapshots that have not yet been taken are taken immediately, and any
+// Dead snapshots are destroyed and removed.
+//
+// Unlike volume creation, snapshots are not retried: the outcome of each
+// attempt, successful or not, is recorded in state for the user to see.
+func volumeSnapshotsChanged(ctx *context, changes []string) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	if err := processPendingVolumeSnapshots(ctx, changes); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(processDeadVolumeSnapshots(ctx, changes))
+}
+
+// processPendingVolumeSnapshots takes the snapshots with the provided
+// IDs that have not yet been taken.
+func processPendingVolumeSnapshots(ctx *context, changes []string) error {
+	paramsResults, err := ctx.config.Volumes.VolumeSnapshotParams(changes)
+	if err != nil {
+		return errors.Annotate(err, "getting volume snapshot params")
+	}
+	var snapshotParams []storage.VolumeSnapshotParams
+	var snapshotIds []string
+	for i, result := range paramsResults {
+		if result.Error != nil {
+			if params.IsCodeNotFound(result.Error) || params.IsCodeUnauthorized(result.Error) {
+				// The snapshot has been removed, or is no
+				// longer our responsibility.
+				continue
+			}
+			return errors.Annotatef(result.Error, "getting params for volume snapshot %s", changes[i])
+		}
+		if result.Result == nil {
+			// The snapshot has already been taken, or has failed.
+			continue
+		}
+		args, err := volumeSnapshotParamsFromParams(*result.Result)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		snapshotParams = append(snapshotParams, args)
+		snapshotIds = append(snapshotIds, result.Result.Id)
+	}
+	if len(snapshotParams) == 0 {
+		return nil
+	}
+	return createVolumeSnapshots(ctx, snapshotIds, snapshotParams)
+}
+
+// createVolumeSnapshots takes snapshots with the specified parameters,
+// and records the outcomes in state. The snapshot IDs correspond to the
+// parameters with the same index.
+func createVolumeSnapshots(ctx *context, ids []string, snapshotParams []storage.VolumeSnapshotParams) error {
+	// Group the snapshots by storage provider, as for volumes.
+	bySource := make(map[storage.ProviderType][]int)
+	for i, args := range snapshotParams {
+		bySource[args.Provider] = append(bySource[args.Provider], i)
+	}
+	results := make([]params.VolumeSnapshotResult, len(ids))
+	for i, id := range ids {
+		results[i].Id = id
+	}
+	setError := func(i int, err error) {
+		logger.Debugf("failed to take volume snapshot %s: %v", ids[i], err)
+		results[i].Error = &params.Error{Message: err.Error()}
+	}
+	for providerType, indices := range bySource {
+		snapshotter, err := volumeSnapshotter(ctx, providerType)
+		if err != nil {
+			for _, i := range indices {
+				setError(i, err)
+			}
+			continue
+		}
+		args := make([]storage.VolumeSnapshotParams, len(indices))
+		for j, i := range indices {
+			args[j] = snapshotParams[i]
+		}
+		logger.Debugf("creating volume snapshots: %v", args)
+		createResults, err := snapshotter.CreateVolumeSnapshots(args)
+		if err != nil {
+			for _, i := range indices {
+				setError(i, err)
+			}
+			continue
+		}
+		for j, result := range createResults {
+			i := indices[j]
+			if result.Error != nil {
+				setError(i, result.Error)
+				continue
+			}
+			results[i].SnapshotId = result.VolumeSnapshot.SnapshotId
+			results[i].Size = result.VolumeSnapshot.Size
+		}
+	}
+	errorResults, err := ctx.config.Volumes.SetVolumeSnapshotResults(results)
+	if err != nil {
+		return errors.Annotate(err, "publishing volume snapshots to state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			logger.Errorf("publishing volume snapshot %s to state: %v", ids[i], result.Error)
+		}
+	}
+	return nil
+}
+
+// processDeadVolumeSnapshots destroys the Dead snapshots with the
+// provided IDs, and removes them from state. A snapshot that cannot be
+// destroyed remains Dead, and is retried when the storage provisioner
+// next starts.
+func processDeadVolumeSnapshots(ctx *context, changes []string) error {
+	paramsResults, err := ctx.config.Volumes.VolumeSnapshotDestroyParams(changes)
+	if err != nil {
+		return errors.Annotate(err, "getting volume snapshot destroy params")
+	}
+	bySource := make(map[storage.ProviderType][]params.VolumeSnapshotDestroyParams)
+	for i, result := range paramsResults {
+		if result.Error != nil {
+			if params.IsCodeNotFound(result.Error) || params.IsCodeUnauthorized(result.Error) {
+				continue
+			}
+			return errors.Annotatef(result.Error, "getting destroy params for volume snapshot %s", changes[i])
+		}
+		if result.Result == nil {
+			// The snapshot is not Dead.
+			continue
+		}
+		providerType := storage.ProviderType(result.Result.Provider)
+		bySource[providerType] = append(bySource[providerType], *result.Result)
+	}
+	var destroyed []string
+	for providerType, args := range bySource {
+		ids := make([]string, len(args))
+		snapshotIds := make([]string, len(args))
+		for i, arg := range args {
+			ids[i] = arg.Id
+			snapshotIds[i] = arg.SnapshotId
+		}
+		snapshotter, err := volumeSnapshotter(ctx, providerType)
+		if err != nil {
+			logger.Errorf("destroying volume snapshots %v: %v", ids, err)
+			continue
+		}
+		logger.Debugf("destroying volume snapshots: %v", snapshotIds)
+		errs, err := snapshotter.DestroyVolumeSnapshots(snapshotIds)
+		if err != nil {
+			logger.Errorf("destroying volume snapshots %v: %v", ids, err)
+			continue
+		}
+		for i, err := range errs {
+			if err != nil {
+				logger.Errorf("destroying volume snapshot %s: %v", ids[i], err)
+				continue
+			}
+			destroyed = append(destroyed, ids[i])
+		}
+	}
+	if len(destroyed) == 0 {
+		return nil
+	}
+	errorResults, err := ctx.config.Volumes.RemoveVolumeSnapshots(destroyed)
+	if err != nil {
+		return errors.Annotate(err, "removing volume snapshots from state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			return errors.Annotatef(result.Error, "removing volume snapshot %s from state", destroyed[i])
+		}
+	}
+	return nil
+}
+
+// volumeSnapshotter returns the VolumeSnapshotter for the specified
+// storage provider, or an error if its volume source does not support
+// snapshots.
+func volumeSnapshotter(ctx *context, providerType storage.ProviderType) (storage.VolumeSnapshotter, error) {
+	source, err := volumeSource(
+		ctx.config.StorageDir, string(providerType), providerType, ctx.config.Registry,
+	)
+	if errors.Cause(err) == errNonDynamic {
+		return nil, errors.NotSupportedf("snapshots of non-dynamic %q volumes", providerType)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	snapshotter, ok := source.(storage.VolumeSnapshotter)
+	if !ok {
+		return nil, errors.NotSupportedf("snapshots of %q volumes", providerType)
+	}
+	return snapshotter, nil
+}
+
+func volumeSnapshotParamsFromParams(in params.VolumeSnapshotParams) (storage.VolumeSnapshotParams, error) {
+	volumeTag, err := names.ParseVolumeTag(in.VolumeTag)
+	if err != nil {
+		return storage.VolumeSnapshotParams{}, errors.Trace(err)
+	}
+	return storage.VolumeSnapshotParams{
+		Volume:       volumeTag,
+		VolumeId:     in.VolumeId,
+		Provider:     storage.ProviderType(in.Provider),
+		Attributes:   in.Attributes,
+		ResourceTags: in.Tags,
+	}, nil
+}
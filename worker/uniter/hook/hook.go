@@ -16,8 +16,16 @@ const (
 	LeaderElected         hooks.Kind = "leader-elected"
 	LeaderDeposed         hooks.Kind = "leader-deposed"
 	LeaderSettingsChanged hooks.Kind = "leader-settings-changed"
+	SecretChanged         hooks.Kind = "secret-changed"
+	StorageResized        hooks.Kind = "storage-resized"
 )
 
+// IsStorage returns whether the specified hook kind relates to a
+// storage instance, including the storage hooks defined in this package.
+func IsStorage(kind hooks.Kind) bool {
+	return kind.IsStorage() || kind == StorageResized
+}
+
 // Info holds details required to execute a hook. Not all fields are
 // relevant to all Kind values.
 type Info struct {
@@ -31,18 +39,45 @@ type Info struct {
 	// set when Kind indicates a relation hook other than relation-broken.
 	RemoteUnit string `yaml:"remote-unit,omitempty"`
 
-	// ChangeVersion identifies the most recent unit settings change
-	// associated with RemoteUnit. It is only set when RemoteUnit is set.
+	// RemoteApplication is the name of the application whose
+	// application-level settings change triggered the hook. It is only
+	// set for a relation-changed hook that has no RemoteUnit.
+	RemoteApplication string `yaml:"remote-application,omitempty"`
+
+	// ChangeVersion identifies the most recent settings change
+	// associated with RemoteUnit or RemoteApplication. It is only set
+	// when one of them is set.
 	ChangeVersion int64 `yaml:"change-version,omitempty"`
 
 	// StorageId is the ID of the storage instance relevant to the hook.
 	StorageId string `yaml:"storage-id,omitempty"`
+
+	// StorageSize is the size, in MiB, of the storage instance identified
+	// by StorageId that the hook was queued for. It is only set when Kind
+	// is StorageAttached or StorageResized.
+	StorageSize uint64 `yaml:"storage-size,omitempty"`
+
+	// SecretName is the name of the secret whose rotation triggered
+	// the hook. It is only set when Kind is SecretChanged.
+	SecretName string `yaml:"secret-name,omitempty"`
+
+	// SecretRevision is the revision of the secret identified by
+	// SecretName that the hook was queued for.
+	SecretRevision int `yaml:"secret-revision,omitempty"`
 }
 
 // Validate returns an error if the info is not valid.
 func (hi Info) Validate() error {
 	switch hi.Kind {
-	case hooks.RelationJoined, hooks.RelationChanged, hooks.RelationDeparted:
+	case hooks.RelationChanged:
+		if hi.RemoteUnit == "" && hi.RemoteApplication == "" {
+			return fmt.Errorf("%q hook requires a remote unit or application", hi.Kind)
+		}
+		if hi.RemoteUnit != "" && hi.RemoteApplication != "" {
+			return fmt.Errorf("%q hook cannot have both a remote unit and application", hi.Kind)
+		}
+		return nil
+	case hooks.RelationJoined, hooks.RelationDeparted:
 		if hi.RemoteUnit == "" {
 			return fmt.Errorf("%q hook requires a remote unit", hi.Kind)
 		}
@@ -52,7 +87,7 @@ func (hi Info) Validate() error {
 		return nil
 	case hooks.Action:
 		return fmt.Errorf("hooks.Kind Action is deprecated")
-	case hooks.StorageAttached, hooks.StorageDetaching:
+	case hooks.StorageAttached, hooks.StorageDetaching, StorageResized:
 		if !names.IsValidStorage(hi.StorageId) {
 			return fmt.Errorf("invalid storage ID %q", hi.StorageId)
 		}
@@ -60,6 +95,11 @@ func (hi Info) Validate() error {
 	// TODO(fwereade): define these in charm/hooks...
 	case LeaderElected, LeaderDeposed, LeaderSettingsChanged:
 		return nil
+	case SecretChanged:
+		if hi.SecretName == "" {
+			return fmt.Errorf("%q hook requires a secret name", hi.Kind)
+		}
+		return nil
 	}
 	return fmt.Errorf("unknown hook kind %q", hi.Kind)
 }
@@ -27,7 +27,13 @@ var validateTests = []struct {
 		`"relation-joined" hook requires a remote unit`,
 	}, {
 		hook.Info{Kind: hooks.RelationChanged},
-		`"relation-changed" hook requires a remote unit`,
+		`"relation-changed" hook requires a remote unit or application`,
+	}, {
+		hook.Info{Kind: hooks.RelationChanged, RemoteUnit: "x", RemoteApplication: "y"},
+		`"relation-changed" hook cannot have both a remote unit and application`,
+	}, {
+		hook.Info{Kind: hooks.RelationJoined, RemoteApplication: "y"},
+		`"relation-joined" hook requires a remote unit`,
 	}, {
 		hook.Info{Kind: hooks.RelationDeparted},
 		`"relation-departed" hook requires a remote unit`,
@@ -45,11 +51,16 @@ var validateTests = []struct {
 	{hook.Info{Kind: hooks.Stop}, ""},
 	{hook.Info{Kind: hooks.RelationJoined, RemoteUnit: "x"}, ""},
 	{hook.Info{Kind: hooks.RelationChanged, RemoteUnit: "x"}, ""},
+	{hook.Info{Kind: hooks.RelationChanged, RemoteApplication: "y"}, ""},
 	{hook.Info{Kind: hooks.RelationDeparted, RemoteUnit: "x"}, ""},
 	{hook.Info{Kind: hooks.RelationBroken}, ""},
 	{hook.Info{Kind: hooks.StorageAttached}, `invalid storage ID ""`},
 	{hook.Info{Kind: hooks.StorageAttached, StorageId: "data/0"}, ""},
 	{hook.Info{Kind: hooks.StorageDetaching, StorageId: "data/0"}, ""},
+	{hook.Info{Kind: hook.StorageResized}, `invalid storage ID ""`},
+	{hook.Info{Kind: hook.StorageResized, StorageId: "data/0", StorageSize: 2048}, ""},
+	{hook.Info{Kind: hook.SecretChanged}, `"secret-changed" hook requires a secret name`},
+	{hook.Info{Kind: hook.SecretChanged, SecretName: "db-password", SecretRevision: 2}, ""},
 }
 
 func (s *InfoSuite) TestValidate(c *gc.C) {
@@ -10,6 +10,7 @@ import (
 	corecharm "gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/charm.v6-unstable/hooks"
 	"gopkg.in/juju/names.v2"
+	"gopkg.in/juju/worker.v1"
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/status"
@@ -34,7 +35,7 @@ func (opc *operationCallbacks) PrepareHook(hi hook.Info) (string, error) {
 		if err != nil {
 			return "", err
 		}
-	case hi.Kind.IsStorage():
+	case hook.IsStorage(hi.Kind):
 		if err := opc.u.storage.ValidateHook(hi); err != nil {
 			return "", err
 		}
@@ -60,7 +61,7 @@ func (opc *operationCallbacks) CommitHook(hi hook.Info) error {
 	switch {
 	case hi.Kind.IsRelation():
 		return opc.u.relations.CommitHook(hi)
-	case hi.Kind.IsStorage():
+	case hook.IsStorage(hi.Kind):
 		return opc.u.storage.CommitHook(hi)
 	}
 	return nil
@@ -104,6 +105,61 @@ func (opc *operationCallbacks) FailAction(actionId, message string) error {
 	return err
 }
 
+// WatchActionCancellation is part of the operation.Callbacks interface.
+func (opc *operationCallbacks) WatchActionCancellation(actionId string) (<-chan struct{}, func(), error) {
+	if !names.IsValidAction(actionId) {
+		return nil, nil, errors.Errorf("invalid action id %q", actionId)
+	}
+	tag := names.NewActionTag(actionId)
+	w, err := opc.u.unit.WatchActionNotifications()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	cancelled := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case ids, ok := <-w.Changes():
+				if !ok {
+					return
+				}
+				if !containsString(ids, actionId) {
+					continue
+				}
+				actionStatus, err := opc.u.st.ActionStatus(tag)
+				if err != nil {
+					logger.Warningf("cannot get status of action %q: %v", actionId, err)
+					continue
+				}
+				if actionStatus == params.ActionAborting {
+					logger.Infof("action %q cancelled", actionId)
+					close(cancelled)
+					return
+				}
+			}
+		}
+	}()
+	stop := func() {
+		close(done)
+		if err := worker.Stop(w); err != nil {
+			logger.Warningf("stopping action watcher: %v", err)
+		}
+	}
+	return cancelled, stop, nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
 // GetArchiveInfo is part of the operation.Callbacks interface.
 func (opc *operationCallbacks) GetArchiveInfo(charmURL *corecharm.URL) (charm.BundleInfo, error) {
 	ch, err := opc.u.st.Charm(charmURL)
@@ -148,6 +148,12 @@ type Callbacks interface {
 	// RunActions operations.
 	FailAction(actionId, message string) error
 
+	// WatchActionCancellation returns a channel that is closed if the
+	// supplied action is cancelled while it is running, and a function
+	// that must be called to stop watching. It's only used by RunActions
+	// operations.
+	WatchActionCancellation(actionId string) (<-chan struct{}, func(), error)
+
 	// GetArchiveInfo is used to find out how to download a charm archive. It's
 	// only used by Deploy operations.
 	GetArchiveInfo(charmURL *corecharm.URL) (charm.BundleInfo, error)
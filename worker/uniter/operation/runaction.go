@@ -72,7 +72,18 @@ func (ra *runAction) Execute(state State) (*State, error) {
 		return nil, err
 	}
 
-	err := ra.runner.RunAction(ra.name)
+	actionData, err := ra.runner.Context().ActionData()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cancel, stop, err := ra.callbacks.WatchActionCancellation(ra.actionId)
+	if err != nil {
+		return nil, errors.Annotatef(err, "watching action %q", ra.name)
+	}
+	defer stop()
+	actionData.Cancel = cancel
+
+	err = ra.runner.RunAction(ra.name)
 	if err != nil {
 		// This indicates an actual error -- an action merely failing should
 		// be handled inside the Runner, and returned as nil.
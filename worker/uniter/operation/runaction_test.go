@@ -224,9 +224,47 @@ func (s *RunActionSuite) TestExecuteSuccess(c *gc.C) {
 		c.Assert(newState, jc.DeepEquals, &test.after)
 		c.Assert(callbacks.executingMessage, gc.Equals, "running action some-action-name")
 		c.Assert(*runnerFactory.MockNewActionRunner.runner.MockRunAction.gotName, gc.Equals, "some-action-name")
+		c.Assert(callbacks.watchedActionId, gc.Equals, someActionId)
+		c.Assert(callbacks.stopped, jc.IsTrue)
 	}
 }
 
+func (s *RunActionSuite) TestExecuteSetsCancel(c *gc.C) {
+	runnerFactory := NewRunActionRunnerFactory(nil)
+	callbacks := &RunActionCallbacks{cancel: make(chan struct{})}
+	factory := operation.NewFactory(operation.FactoryParams{
+		RunnerFactory: runnerFactory,
+		Callbacks:     callbacks,
+	})
+	op, err := factory.NewAction(someActionId)
+	c.Assert(err, jc.ErrorIsNil)
+	midState, err := op.Prepare(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = op.Execute(*midState)
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := runnerFactory.MockNewActionRunner.runner.context.(*MockContext)
+	c.Assert(ctx.actionData.Cancel, gc.Equals, (<-chan struct{})(callbacks.cancel))
+}
+
+func (s *RunActionSuite) TestExecuteWatchCancellationError(c *gc.C) {
+	runnerFactory := NewRunActionRunnerFactory(nil)
+	callbacks := &RunActionCallbacks{watchErr: errors.New("pow")}
+	factory := operation.NewFactory(operation.FactoryParams{
+		RunnerFactory: runnerFactory,
+		Callbacks:     callbacks,
+	})
+	op, err := factory.NewAction(someActionId)
+	c.Assert(err, jc.ErrorIsNil)
+	midState, err := op.Prepare(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	newState, err := op.Execute(*midState)
+	c.Assert(err, gc.ErrorMatches, `watching action "some-action-name": pow`)
+	c.Assert(newState, gc.IsNil)
+	c.Assert(runnerFactory.MockNewActionRunner.runner.MockRunAction.gotName, gc.IsNil)
+}
+
 func (s *RunActionSuite) TestCommit(c *gc.C) {
 	var stateChangeTests = []struct {
 		description string
@@ -33,12 +33,15 @@ func (rh *runHook) String() string {
 	suffix := ""
 	switch {
 	case rh.info.Kind.IsRelation():
-		if rh.info.RemoteUnit == "" {
-			suffix = fmt.Sprintf(" (%d)", rh.info.RelationId)
-		} else {
+		switch {
+		case rh.info.RemoteUnit != "":
 			suffix = fmt.Sprintf(" (%d; %s)", rh.info.RelationId, rh.info.RemoteUnit)
+		case rh.info.RemoteApplication != "":
+			suffix = fmt.Sprintf(" (%d; %s)", rh.info.RelationId, rh.info.RemoteApplication)
+		default:
+			suffix = fmt.Sprintf(" (%d)", rh.info.RelationId)
 		}
-	case rh.info.Kind.IsStorage():
+	case hook.IsStorage(rh.info.Kind):
 		suffix = fmt.Sprintf(" (%s)", rh.info.StorageId)
 	}
 	return fmt.Sprintf("run %s%s hook", rh.info.Kind, suffix)
@@ -220,6 +223,13 @@ func (rh *runHook) Commit(state State) (*State, error) {
 		newState.Started = true
 	case hooks.Stop:
 		newState.Stopped = true
+	case hook.SecretChanged:
+		revisions := make(map[string]int)
+		for name, revision := range newState.SecretRevisions {
+			revisions[name] = revision
+		}
+		revisions[rh.info.SecretName] = rh.info.SecretRevision
+		newState.SecretRevisions = revisions
 	}
 
 	return newState, nil
@@ -528,6 +528,32 @@ func (s *RunHookSuite) TestCommitSuccess_Start_Preserve(c *gc.C) {
 	}
 }
 
+func (s *RunHookSuite) TestCommitSuccess_SecretChanged_RecordRevision(c *gc.C) {
+	for i, newHook := range []newHook{
+		(operation.Factory).NewRunHook,
+		(operation.Factory).NewSkipHook,
+	} {
+		c.Logf("variant %d", i)
+		before := operation.State{
+			Started:         true,
+			SecretRevisions: map[string]int{"api-token": 3, "db-password": 1},
+		}
+		s.testCommitSuccess(c,
+			newHook,
+			hook.Info{Kind: hook.SecretChanged, SecretName: "db-password", SecretRevision: 2},
+			before,
+			operation.State{
+				Started:         true,
+				Kind:            operation.Continue,
+				Step:            operation.Pending,
+				SecretRevisions: map[string]int{"api-token": 3, "db-password": 2},
+			},
+		)
+		// The original state is not modified.
+		c.Assert(before.SecretRevisions["db-password"], gc.Equals, 1)
+	}
+}
+
 func (s *RunHookSuite) testQueueHook_BlankSlate(c *gc.C, cause hooks.Kind) {
 	for i, newHook := range []newHook{
 		(operation.Factory).NewRunHook,
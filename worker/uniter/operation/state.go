@@ -93,6 +93,11 @@ type State struct {
 	// Charm describes the charm being deployed by an Install or Upgrade
 	// operation, and is otherwise blank.
 	CharmURL *charm.URL `yaml:"charm,omitempty"`
+
+	// SecretRevisions holds the revisions of the secrets granted to
+	// the unit's application for which a secret-changed hook has
+	// been committed, keyed by secret name.
+	SecretRevisions map[string]int `yaml:"secret-revisions,omitempty"`
 }
 
 // validate returns an error if the state violates expectations.
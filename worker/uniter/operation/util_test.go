@@ -116,6 +116,10 @@ type RunActionCallbacks struct {
 	operation.Callbacks
 	*MockFailAction
 	executingMessage string
+	watchedActionId  string
+	cancel           chan struct{}
+	stopped          bool
+	watchErr         error
 }
 
 func (cb *RunActionCallbacks) FailAction(actionId, message string) error {
@@ -127,6 +131,14 @@ func (cb *RunActionCallbacks) SetExecutingStatus(message string) error {
 	return nil
 }
 
+func (cb *RunActionCallbacks) WatchActionCancellation(actionId string) (<-chan struct{}, func(), error) {
+	cb.watchedActionId = actionId
+	if cb.watchErr != nil {
+		return nil, nil, cb.watchErr
+	}
+	return cb.cancel, func() { cb.stopped = true }, nil
+}
+
 type RunCommandsCallbacks struct {
 	operation.Callbacks
 	executingMessage string
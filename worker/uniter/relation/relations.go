@@ -305,6 +305,25 @@ func nextRelationHook(
 		}
 	}
 
+	// Then scan for remote applications whose settings version is not
+	// reflected in local state. Settings that have never been written
+	// have version 0, and do not warrant a hook.
+	allAppNames := set.NewStrings()
+	for appName := range remote.ApplicationMembers {
+		allAppNames.Add(appName)
+	}
+	for _, appName := range allAppNames.SortedValues() {
+		remoteChangeVersion := remote.ApplicationMembers[appName]
+		if remoteChangeVersion != local.ApplicationMembers[appName] {
+			return hook.Info{
+				Kind:              hooks.RelationChanged,
+				RelationId:        relationId,
+				RemoteApplication: appName,
+				ChangeVersion:     remoteChangeVersion,
+			}, nil
+		}
+	}
+
 	// Nothing left to do for this relation.
 	return hook.Info{}, resolver.ErrNoOperation
 }
@@ -326,7 +326,7 @@ func getPrincipalAPICalls(numCalls int32) []apiCall {
 func (s *relationsSuite) TestHookRelationChanged(c *gc.C) {
 	var numCalls int32
 	apiCalls := relationJoinedAPICalls()
-	apiCalls = append(apiCalls, getPrincipalAPICalls(3)...)
+	apiCalls = append(apiCalls, getPrincipalAPICalls(4)...)
 	r := s.assertHookRelationJoined(c, &numCalls, apiCalls...)
 
 	// There will be an initial relation-changed regardless of
@@ -357,6 +357,18 @@ func (s *relationsSuite) TestHookRelationChanged(c *gc.C) {
 			"wordpress": 1,
 		},
 	}, &numCalls)
+
+	// A change to the remote application's settings should trigger
+	// a relation-changed hook too.
+	s.assertHookRelationChanged(c, r, remotestate.RelationSnapshot{
+		Life: params.Alive,
+		Members: map[string]int64{
+			"wordpress": 1,
+		},
+		ApplicationMembers: map[string]int64{
+			"wordpress": 1,
+		},
+	}, &numCalls)
 }
 
 func (s *relationsSuite) assertHookRelationDeparted(c *gc.C, numCalls *int32, apiCalls ...apiCall) relation.Relations {
@@ -32,6 +32,11 @@ type State struct {
 	// ChangedPending indicates that a "relation-changed" hook for the given
 	// unit name must be the first hook.Info to be sent to the output channel.
 	ChangedPending string
+
+	// ApplicationMembers is a map from application name to the last
+	// application settings version for which a hook.Info was delivered
+	// on the output channel.
+	ApplicationMembers map[string]int64
 }
 
 // copy returns an independent copy of the state.
@@ -46,6 +51,12 @@ func (s *State) copy() *State {
 			copy.Members[m] = v
 		}
 	}
+	if s.ApplicationMembers != nil {
+		copy.ApplicationMembers = map[string]int64{}
+		for app, v := range s.ApplicationMembers {
+			copy.ApplicationMembers[app] = v
+		}
+	}
 	return copy
 }
 
@@ -54,13 +65,26 @@ func (s *State) copy() *State {
 // against the current state before they are run, to ensure that the system
 // meets its guarantees about hook execution order.
 func (s *State) Validate(hi hook.Info) (err error) {
-	defer errors.DeferredAnnotatef(&err, "inappropriate %q for %q", hi.Kind, hi.RemoteUnit)
+	if hi.RemoteApplication != "" {
+		defer errors.DeferredAnnotatef(&err, "inappropriate %q for %q", hi.Kind, hi.RemoteApplication)
+	} else {
+		defer errors.DeferredAnnotatef(&err, "inappropriate %q for %q", hi.Kind, hi.RemoteUnit)
+	}
 	if hi.RelationId != s.RelationId {
 		return fmt.Errorf("expected relation %d, got relation %d", s.RelationId, hi.RelationId)
 	}
 	if s.Members == nil {
 		return fmt.Errorf(`relation is broken and cannot be changed further`)
 	}
+	if hi.RemoteApplication != "" {
+		if hi.Kind != hooks.RelationChanged {
+			return fmt.Errorf(`only "relation-changed" may be run for an application`)
+		}
+		if s.ChangedPending != "" {
+			return fmt.Errorf(`expected "relation-changed" for %q`, s.ChangedPending)
+		}
+		return nil
+	}
 	unit, kind := hi.RemoteUnit, hi.Kind
 	if kind == hooks.RelationBroken {
 		if len(s.Members) == 0 {
@@ -104,7 +128,11 @@ func (d *StateDir) State() *State {
 func ReadStateDir(dirPath string, relationId int) (d *StateDir, err error) {
 	d = &StateDir{
 		filepath.Join(dirPath, strconv.Itoa(relationId)),
-		State{relationId, map[string]int64{}, ""},
+		State{
+			RelationId:         relationId,
+			Members:            map[string]int64{},
+			ApplicationMembers: map[string]int64{},
+		},
 	}
 	defer errors.DeferredAnnotatef(&err, "cannot load relation state from %q", d.path)
 	if _, err := os.Stat(d.path); os.IsNotExist(err) {
@@ -118,7 +146,9 @@ func ReadStateDir(dirPath string, relationId int) (d *StateDir, err error) {
 	}
 	for _, fi := range fis {
 		// Entries with names ending in "-" followed by an integer must be
-		// files containing valid unit data; all other names are ignored.
+		// files containing valid unit data; entries with names starting
+		// with the application prefix must be files containing valid
+		// application data; all other names are ignored.
 		name := fi.Name()
 		i := strings.LastIndex(name, "-")
 		if i == -1 {
@@ -127,6 +157,18 @@ func ReadStateDir(dirPath string, relationId int) (d *StateDir, err error) {
 		svcName := name[:i]
 		unitId := name[i+1:]
 		if _, err := strconv.Atoi(unitId); err != nil {
+			if !strings.HasPrefix(name, applicationFilePrefix) {
+				continue
+			}
+			appName := strings.TrimPrefix(name, applicationFilePrefix)
+			var info diskInfo
+			if err = utils.ReadYaml(filepath.Join(d.path, name), &info); err != nil {
+				return nil, fmt.Errorf("invalid application file %q: %v", name, err)
+			}
+			if info.ChangeVersion == nil {
+				return nil, fmt.Errorf(`invalid application file %q: "changed-version" not set`, name)
+			}
+			d.state.ApplicationMembers[appName] = *info.ChangeVersion
 			continue
 		}
 		unitName := svcName + "/" + unitId
@@ -189,8 +231,15 @@ func (d *StateDir) Ensure() error {
 // Write doesn't validate hi but guarantees that successive writes of
 // the same hi are idempotent.
 func (d *StateDir) Write(hi hook.Info) (err error) {
+	if hi.RemoteApplication != "" {
+		defer errors.DeferredAnnotatef(&err, "failed to write %q hook info for %q on state directory", hi.Kind, hi.RemoteApplication)
+		return d.writeApplication(hi)
+	}
 	defer errors.DeferredAnnotatef(&err, "failed to write %q hook info for %q on state directory", hi.Kind, hi.RemoteUnit)
 	if hi.Kind == hooks.RelationBroken {
+		if err := d.removeApplications(); err != nil {
+			return err
+		}
 		return d.Remove()
 	}
 	name := strings.Replace(hi.RemoteUnit, "/", "-", 1)
@@ -217,6 +266,34 @@ func (d *StateDir) Write(hi hook.Info) (err error) {
 	return nil
 }
 
+// writeApplication records the application settings version delivered
+// by the relation-changed hook in hi.
+func (d *StateDir) writeApplication(hi hook.Info) error {
+	path := filepath.Join(d.path, applicationFilePrefix+hi.RemoteApplication)
+	di := diskInfo{ChangeVersion: &hi.ChangeVersion}
+	if err := utils.WriteYaml(path, &di); err != nil {
+		return err
+	}
+	// If write was successful, update own state.
+	d.state.ApplicationMembers[hi.RemoteApplication] = hi.ChangeVersion
+	return nil
+}
+
+// removeApplications removes the application settings files, which
+// outlive the remote units and must be gone before the directory can be
+// removed.
+func (d *StateDir) removeApplications() error {
+	for appName := range d.state.ApplicationMembers {
+		path := filepath.Join(d.path, applicationFilePrefix+appName)
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		// If atomic delete succeeded, update own state.
+		delete(d.state.ApplicationMembers, appName)
+	}
+	return nil
+}
+
 // Remove removes the directory if it exists and is empty.
 func (d *StateDir) Remove() error {
 	if err := os.Remove(d.path); err != nil && !os.IsNotExist(err) {
@@ -224,9 +301,16 @@ func (d *StateDir) Remove() error {
 	}
 	// If atomic delete succeeded, update own state.
 	d.state.Members = nil
+	d.state.ApplicationMembers = nil
 	return nil
 }
 
+// applicationFilePrefix prefixes the names of the files holding
+// application settings data. Application names never end in "-"
+// followed by an integer, so these files cannot be mistaken for unit
+// files.
+const applicationFilePrefix = "application-"
+
 // diskInfo defines the relation unit data serialization.
 type diskInfo struct {
 	ChangeVersion  *int64 `yaml:"change-version"`
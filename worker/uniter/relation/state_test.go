@@ -51,6 +51,8 @@ func (s *StateDirSuite) TestReadStateDirValid(c *gc.C) {
 		"foo-bar-1":           "change-version: 99\n",
 		"foo-bar-1.preparing": "change-version: 100\n",
 		"baz-qux-7":           "change-version: 101\nchanged-pending: true\n",
+		"application-baz-qux": "change-version: 3\n",
+		"application-1":       "change-version: 4\n",
 		"nonsensical":         "blah",
 		"27":                  "blah",
 	})
@@ -60,7 +62,8 @@ func (s *StateDirSuite) TestReadStateDirValid(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	state := dir.State()
 	c.Assert(state.RelationId, gc.Equals, 123)
-	c.Assert(msi(state.Members), gc.DeepEquals, msi{"foo-bar/1": 99, "baz-qux/7": 101})
+	c.Assert(msi(state.Members), gc.DeepEquals, msi{"foo-bar/1": 99, "baz-qux/7": 101, "application/1": 4})
+	c.Assert(msi(state.ApplicationMembers), gc.DeepEquals, msi{"baz-qux": 3})
 	c.Assert(state.ChangedPending, gc.Equals, "baz-qux/7")
 }
 
@@ -84,6 +87,9 @@ var badRelationsTests = []struct {
 			"foo-2": "change-version: 456\nchanged-pending: true\n",
 		}, nil,
 		`"foo/1" and "foo/2" both have pending changed hooks`,
+	}, {
+		map[string]string{"application-foo": "blah: blah\n"}, nil,
+		`invalid application file "application-foo": "changed-version" not set`,
 	},
 }
 
@@ -108,6 +114,7 @@ var defaultMembers = msi{"foo/1": 0, "foo/2": 0}
 var writeTests = []struct {
 	hooks   []hook.Info
 	members msi
+	apps    msi
 	pending string
 	err     string
 	deleted bool
@@ -156,6 +163,26 @@ var writeTests = []struct {
 			{Kind: hooks.RelationBroken, RelationId: 123},
 		},
 		deleted: true,
+	}, {
+		hooks: []hook.Info{
+			{Kind: hooks.RelationChanged, RelationId: 123, RemoteApplication: "foo", ChangeVersion: 2},
+		},
+		apps: msi{"foo": 2},
+	}, {
+		hooks: []hook.Info{
+			{Kind: hooks.RelationChanged, RelationId: 123, RemoteApplication: "foo", ChangeVersion: 2},
+			{Kind: hooks.RelationDeparted, RelationId: 123, RemoteUnit: "foo/1"},
+		},
+		members: msi{"foo/2": 0},
+		apps:    msi{"foo": 2},
+	}, {
+		hooks: []hook.Info{
+			{Kind: hooks.RelationChanged, RelationId: 123, RemoteApplication: "foo", ChangeVersion: 2},
+			{Kind: hooks.RelationDeparted, RelationId: 123, RemoteUnit: "foo/1"},
+			{Kind: hooks.RelationDeparted, RelationId: 123, RemoteUnit: "foo/2"},
+			{Kind: hooks.RelationBroken, RelationId: 123},
+		},
+		deleted: true,
 	},
 	// Verify detection of various error conditions.
 	{
@@ -199,6 +226,19 @@ var writeTests = []struct {
 			{Kind: hooks.RelationBroken, RelationId: 123},
 		},
 		err: `cannot run "relation-broken" while units still present`,
+	}, {
+		hooks: []hook.Info{
+			{Kind: hooks.RelationJoined, RelationId: 123, RemoteApplication: "foo"},
+		},
+		err: `only "relation-changed" may be run for an application`,
+	}, {
+		hooks: []hook.Info{
+			{Kind: hooks.RelationJoined, RelationId: 123, RemoteUnit: "foo/3"},
+			{Kind: hooks.RelationChanged, RelationId: 123, RemoteApplication: "foo", ChangeVersion: 1},
+		},
+		members: msi{"foo/1": 0, "foo/2": 0, "foo/3": 0},
+		pending: "foo/3",
+		err:     `expected "relation-changed" for "foo/3"`,
 	}, {
 		hooks: []hook.Info{
 			{Kind: hooks.RelationDeparted, RelationId: 123, RemoteUnit: "foo/1"},
@@ -225,7 +265,11 @@ func (s *StateDirSuite) TestWrite(c *gc.C) {
 			c.Logf("  hook %d", i)
 			if i == len(t.hooks)-1 && t.err != "" {
 				err = dir.State().Validate(hi)
-				expect := fmt.Sprintf(`inappropriate %q for %q: %s`, hi.Kind, hi.RemoteUnit, t.err)
+				remote := hi.RemoteUnit
+				if hi.RemoteApplication != "" {
+					remote = hi.RemoteApplication
+				}
+				expect := fmt.Sprintf(`inappropriate %q for %q: %s`, hi.Kind, remote, t.err)
 				c.Assert(err, gc.ErrorMatches, expect)
 			} else {
 				err = dir.State().Validate(hi)
@@ -241,7 +285,11 @@ func (s *StateDirSuite) TestWrite(c *gc.C) {
 		if members == nil && !t.deleted {
 			members = defaultMembers
 		}
-		assertState(c, dir, basedir, 123, members, t.pending, t.deleted)
+		apps := t.apps
+		if apps == nil && !t.deleted {
+			apps = msi{}
+		}
+		assertState(c, dir, basedir, 123, members, apps, t.pending, t.deleted)
 	}
 }
 
@@ -319,9 +367,9 @@ func (s *ReadAllStateDirsSuite) TestReadAllStateDirs(c *gc.C) {
 	for id, dir := range dirs {
 		c.Logf("%d: %#v", id, dir)
 	}
-	assertState(c, dirs[123], relsdir, 123, msi{"foo/0": 1, "foo/1": 2}, "foo/1", false)
-	assertState(c, dirs[456], relsdir, 456, msi{"bar/0": 3, "bar/1": 4}, "", false)
-	assertState(c, dirs[789], relsdir, 789, msi{}, "", false)
+	assertState(c, dirs[123], relsdir, 123, msi{"foo/0": 1, "foo/1": 2}, msi{}, "foo/1", false)
+	assertState(c, dirs[456], relsdir, 456, msi{"bar/0": 3, "bar/1": 4}, msi{}, "", false)
+	assertState(c, dirs[789], relsdir, 789, msi{}, msi{}, "", false)
 	c.Assert(dirs, gc.HasLen, 3)
 }
 
@@ -337,11 +385,12 @@ func setUpDir(c *gc.C, basedir, name string, contents map[string]string) string
 	return reldir
 }
 
-func assertState(c *gc.C, dir *relation.StateDir, relsdir string, relationId int, members msi, pending string, deleted bool) {
+func assertState(c *gc.C, dir *relation.StateDir, relsdir string, relationId int, members, apps msi, pending string, deleted bool) {
 	expect := &relation.State{
-		RelationId:     relationId,
-		Members:        map[string]int64(members),
-		ChangedPending: pending,
+		RelationId:         relationId,
+		Members:            map[string]int64(members),
+		ChangedPending:     pending,
+		ApplicationMembers: map[string]int64(apps),
 	}
 	c.Assert(dir.State(), gc.DeepEquals, expect)
 	if deleted {
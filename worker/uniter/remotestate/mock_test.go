@@ -102,6 +102,16 @@ type mockState struct {
 	storageAttachment         map[params.StorageAttachmentId]params.StorageAttachment
 	relationUnitsWatchers     map[names.RelationTag]*mockRelationUnitsWatcher
 	storageAttachmentWatchers map[names.StorageTag]*mockNotifyWatcher
+	secretsWatcher            *mockNotifyWatcher
+	secretRevisions           map[string]int
+}
+
+func (st *mockState) SecretRevisions() (map[string]int, error) {
+	return st.secretRevisions, nil
+}
+
+func (st *mockState) WatchSecrets() (watcher.NotifyWatcher, error) {
+	return st.secretsWatcher, nil
 }
 
 func (st *mockState) Relation(tag names.RelationTag) (remotestate.Relation, error) {
@@ -68,11 +68,20 @@ type Snapshot struct {
 	// Commands is the list of IDs of commands to be
 	// executed by this unit.
 	Commands []string
+
+	// SecretRevisions holds the current revisions of the
+	// secrets granted to the unit's application, keyed by
+	// secret name.
+	SecretRevisions map[string]int
 }
 
 type RelationSnapshot struct {
 	Life    params.Life
 	Members map[string]int64
+
+	// ApplicationMembers maps the names of the counterpart
+	// applications to the versions of their application settings.
+	ApplicationMembers map[string]int64
 }
 
 // StorageSnapshot has information relating to a storage
@@ -82,4 +91,5 @@ type StorageSnapshot struct {
 	Life     params.Life
 	Attached bool
 	Location string
+	Size     uint64
 }
@@ -111,12 +111,16 @@ func (w *RemoteStateWatcher) Snapshot() Snapshot {
 	snapshot.Relations = make(map[int]RelationSnapshot)
 	for id, relationSnapshot := range w.current.Relations {
 		relationSnapshotCopy := RelationSnapshot{
-			Life:    relationSnapshot.Life,
-			Members: make(map[string]int64),
+			Life:               relationSnapshot.Life,
+			Members:            make(map[string]int64),
+			ApplicationMembers: make(map[string]int64),
 		}
 		for name, version := range relationSnapshot.Members {
 			relationSnapshotCopy.Members[name] = version
 		}
+		for name, version := range relationSnapshot.ApplicationMembers {
+			relationSnapshotCopy.ApplicationMembers[name] = version
+		}
 		snapshot.Relations[id] = relationSnapshotCopy
 	}
 	snapshot.Storage = make(map[names.StorageTag]StorageSnapshot)
@@ -127,6 +131,12 @@ func (w *RemoteStateWatcher) Snapshot() Snapshot {
 	copy(snapshot.Actions, w.current.Actions)
 	snapshot.Commands = make([]string, len(w.current.Commands))
 	copy(snapshot.Commands, w.current.Commands)
+	if w.current.SecretRevisions != nil {
+		snapshot.SecretRevisions = make(map[string]int)
+		for name, revision := range w.current.SecretRevisions {
+			snapshot.SecretRevisions[name] = revision
+		}
+	}
 	return snapshot
 }
 
@@ -257,6 +267,16 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 	}
 	requiredEvents++
 
+	var seenSecretsChange bool
+	secretsw, err := w.st.WatchSecrets()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(secretsw); err != nil {
+		return errors.Trace(err)
+	}
+	requiredEvents++
+
 	var seenLeadershipChange bool
 	// There's no watcher for this per se; we wait on a channel
 	// returned by the leadership tracker.
@@ -355,6 +375,16 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 			}
 			observedEvent(&seenLeaderSettingsChange)
 
+		case _, ok := <-secretsw.Changes():
+			logger.Debugf("got secrets change: ok=%t", ok)
+			if !ok {
+				return errors.New("secrets watcher closed")
+			}
+			if err := w.secretsChanged(); err != nil {
+				return errors.Trace(err)
+			}
+			observedEvent(&seenSecretsChange)
+
 		case actions, ok := <-actionsw.Changes():
 			logger.Debugf("got action change: %v ok=%t", actions, ok)
 			if !ok {
@@ -525,6 +555,19 @@ func (w *RemoteStateWatcher) leaderSettingsChanged() error {
 	return nil
 }
 
+// secretsChanged responds to changes in the model's secrets by
+// recording the revisions of those granted to the unit's application.
+func (w *RemoteStateWatcher) secretsChanged() error {
+	revisions, err := w.st.SecretRevisions()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	w.mu.Lock()
+	w.current.SecretRevisions = revisions
+	w.mu.Unlock()
+	return nil
+}
+
 func (w *RemoteStateWatcher) leadershipChanged(isLeader bool) error {
 	w.mu.Lock()
 	w.current.Leader = isLeader
@@ -581,8 +624,9 @@ func (w *RemoteStateWatcher) watchRelationUnits(
 	rel Relation, relationTag names.RelationTag, ruw watcher.RelationUnitsWatcher,
 ) error {
 	relationSnapshot := RelationSnapshot{
-		Life:    rel.Life(),
-		Members: make(map[string]int64),
+		Life:               rel.Life(),
+		Members:            make(map[string]int64),
+		ApplicationMembers: make(map[string]int64),
 	}
 	select {
 	case <-w.catacomb.Dying():
@@ -594,6 +638,9 @@ func (w *RemoteStateWatcher) watchRelationUnits(
 		for unit, settings := range change.Changed {
 			relationSnapshot.Members[unit] = settings.Version
 		}
+		for app, version := range change.AppChanged {
+			relationSnapshot.ApplicationMembers[app] = version
+		}
 	}
 	innerRUW, err := newRelationUnitsWatcher(rel.Id(), ruw, w.relationUnitsChanges)
 	if err != nil {
@@ -618,6 +665,9 @@ func (w *RemoteStateWatcher) relationUnitsChanged(change relationUnitsChange) er
 	for unit, settings := range change.Changed {
 		snapshot.Members[unit] = settings.Version
 	}
+	for app, version := range change.AppChanged {
+		snapshot.ApplicationMembers[app] = version
+	}
 	for _, unit := range change.Departed {
 		delete(snapshot.Members, unit)
 	}
@@ -58,6 +58,7 @@ func (s *WatcherSuite) SetUpTest(c *gc.C) {
 		storageAttachment:         make(map[params.StorageAttachmentId]params.StorageAttachment),
 		relationUnitsWatchers:     make(map[names.RelationTag]*mockRelationUnitsWatcher),
 		storageAttachmentWatchers: make(map[names.StorageTag]*mockNotifyWatcher),
+		secretsWatcher:            newMockNotifyWatcher(),
 	}
 
 	s.leadership = &mockLeadershipTracker{
@@ -110,6 +111,7 @@ func (s *WatcherSuite) TestInitialSignal(c *gc.C) {
 	s.st.unit.service.serviceWatcher.changes <- struct{}{}
 	s.st.unit.service.leaderSettingsWatcher.changes <- struct{}{}
 	s.st.unit.service.relationsWatcher.changes <- []string{}
+	s.st.secretsWatcher.changes <- struct{}{}
 	s.leadership.claimTicket.ch <- struct{}{}
 	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
 }
@@ -123,6 +125,7 @@ func signalAll(st *mockState, l *mockLeadershipTracker) {
 	st.unit.service.serviceWatcher.changes <- struct{}{}
 	st.unit.service.leaderSettingsWatcher.changes <- struct{}{}
 	st.unit.service.relationsWatcher.changes <- []string{}
+	st.secretsWatcher.changes <- struct{}{}
 	l.claimTicket.ch <- struct{}{}
 }
 
@@ -187,6 +190,22 @@ func (s *WatcherSuite) TestRemoteStateChanged(c *gc.C) {
 	assertOneChange()
 }
 
+func (s *WatcherSuite) TestSecretsChanged(c *gc.C) {
+	signalAll(s.st, s.leadership)
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().SecretRevisions, gc.IsNil)
+
+	s.st.secretRevisions = map[string]int{"db-password": 2}
+	s.st.secretsWatcher.changes <- struct{}{}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	snapshot := s.watcher.Snapshot()
+	c.Assert(snapshot.SecretRevisions, jc.DeepEquals, map[string]int{"db-password": 2})
+
+	// The snapshot holds a copy of the revisions.
+	snapshot.SecretRevisions["db-password"] = 3
+	c.Assert(s.watcher.Snapshot().SecretRevisions, jc.DeepEquals, map[string]int{"db-password": 2})
+}
+
 func (s *WatcherSuite) TestActionsReceived(c *gc.C) {
 	signalAll(s.st, s.leadership)
 	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
@@ -433,7 +452,8 @@ func (s *WatcherSuite) TestRelationsChanged(c *gc.C) {
 	// returned its initial event also.
 	assertNoNotifyEvent(c, s.watcher.RemoteStateChanged(), "remote state change")
 	s.st.relationUnitsWatchers[relationTag].changes <- watcher.RelationUnitsChange{
-		Changed: map[string]watcher.UnitSettings{"mysql/1": {1}, "mysql/2": {2}},
+		Changed:    map[string]watcher.UnitSettings{"mysql/1": {1}, "mysql/2": {2}},
+		AppChanged: map[string]int64{"mysql": 0},
 	}
 	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
 	c.Assert(
@@ -441,8 +461,9 @@ func (s *WatcherSuite) TestRelationsChanged(c *gc.C) {
 		jc.DeepEquals,
 		map[int]remotestate.RelationSnapshot{
 			123: remotestate.RelationSnapshot{
-				Life:    params.Alive,
-				Members: map[string]int64{"mysql/1": 1, "mysql/2": 2},
+				Life:               params.Alive,
+				Members:            map[string]int64{"mysql/1": 1, "mysql/2": 2},
+				ApplicationMembers: map[string]int64{"mysql": 0},
 			},
 		},
 	)
@@ -498,6 +519,16 @@ func (s *WatcherSuite) TestRelationUnitsChanged(c *gc.C) {
 		jc.DeepEquals,
 		map[string]int64{"mysql/2": 1},
 	)
+
+	s.st.relationUnitsWatchers[relationTag].changes <- watcher.RelationUnitsChange{
+		AppChanged: map[string]int64{"mysql": 3},
+	}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(
+		s.watcher.Snapshot().Relations[123].ApplicationMembers,
+		jc.DeepEquals,
+		map[string]int64{"mysql": 3},
+	)
 }
 
 func (s *WatcherSuite) TestRelationUnitsDontLeakReferences(c *gc.C) {
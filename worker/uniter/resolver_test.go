@@ -70,6 +70,7 @@ func (s *resolverSuite) SetUpTest(c *gc.C) {
 		Relations:           relation.NewRelationsResolver(&dummyRelations{}),
 		Storage:             storage.NewResolver(attachments),
 		Commands:            nopResolver{},
+		Secrets:             nopResolver{},
 	}
 
 	s.resolver = uniter.NewUniterResolver(s.resolverConfig)
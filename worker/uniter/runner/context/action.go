@@ -4,6 +4,8 @@
 package context
 
 import (
+	"time"
+
 	"gopkg.in/juju/names.v2"
 )
 
@@ -15,6 +17,18 @@ type ActionData struct {
 	Failed         bool
 	ResultsMessage string
 	ResultsMap     map[string]interface{}
+
+	// Cancel, if not nil, is closed when the Action has been cancelled
+	// and should be stopped.
+	Cancel <-chan struct{}
+
+	// Timeout is the maximum time the Action may run for; zero means
+	// no limit.
+	Timeout time.Duration
+
+	// TimedOut is set when the Action was stopped because it ran for
+	// longer than Timeout.
+	TimedOut bool
 }
 
 // NewActionData builds a suitable ActionData struct with no nil members.
@@ -28,6 +42,16 @@ func NewActionData(name string, tag *names.ActionTag, params map[string]interfac
 	}
 }
 
+// Cancelled reports whether the Action has been cancelled.
+func (ad *ActionData) Cancelled() bool {
+	select {
+	case <-ad.Cancel:
+		return true
+	default:
+		return false
+	}
+}
+
 // addValueToMap adds the given value to the map on which the method is run.
 // This allows us to merge maps such as {foo: {bar: baz}} and {foo: {baz: faz}}
 // into {foo: {bar: baz, baz: faz}}.
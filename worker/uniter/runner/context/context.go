@@ -149,6 +149,11 @@ type HookContext struct {
 	// or if it is running a relation-broken hook.
 	remoteUnitName string
 
+	// remoteApplicationName identifies the application of the executing
+	// relation hook's remote unit, or the changing application if the
+	// hook was triggered by the application's settings.
+	remoteApplicationName string
+
 	// relations contains the context for every relation the unit is a member
 	// of, keyed on relation id.
 	relations map[int]*ContextRelation
@@ -202,6 +207,25 @@ type HookContext struct {
 	// hook run, so the actual add will happen in a flush.
 	storageAddConstraints map[string][]params.StorageConstraints
 
+	// cloudSpec holds the cloud spec of the model, read on first use.
+	cloudSpec *params.CloudSpec
+
+	// charmState holds the unit's charm state, read on first use.
+	// Changes made to it are written back to the controller on
+	// successful hook run, so the actual write will happen in a flush.
+	charmState map[string]string
+
+	// charmStateDirty records whether charmState has been changed.
+	charmStateDirty bool
+
+	// secretName is the name of the secret whose rotation triggered
+	// the running secret-changed hook. It is empty for other hooks.
+	secretName string
+
+	// secrets caches the values of the secrets read during the hook,
+	// keyed by secret name, so that a hook sees consistent values.
+	secrets map[string]map[string]string
+
 	// clock is used for any time operations.
 	clock clock.Clock
 
@@ -384,6 +408,15 @@ func (ctx *HookContext) ResetExecutionSetUnitStatus() {
 	ctx.hasRunStatusSet = false
 }
 
+// RecordExecution adds the hook or action execution to the unit's hook
+// history. Failing to record it does not fail the hook, so errors are
+// only logged.
+func (ctx *HookContext) RecordExecution(execution params.HookExecution) {
+	if err := ctx.unit.RecordHookExecution(execution); err != nil {
+		logger.Warningf("cannot record %s %q execution: %v", execution.Kind, execution.Name, err)
+	}
+}
+
 func (ctx *HookContext) PublicAddress() (string, error) {
 	if ctx.publicAddress == "" {
 		return "", errors.NotFoundf("public address")
@@ -434,16 +467,24 @@ func (ctx *HookContext) AddUnitStorage(cons map[string]params.StorageConstraints
 }
 
 func (ctx *HookContext) OpenPorts(protocol string, fromPort, toPort int) error {
+	return ctx.OpenPortsOnEndpoint("", protocol, fromPort, toPort)
+}
+
+func (ctx *HookContext) OpenPortsOnEndpoint(endpoint, protocol string, fromPort, toPort int) error {
 	return tryOpenPorts(
-		protocol, fromPort, toPort,
+		endpoint, protocol, fromPort, toPort,
 		ctx.unit.Tag(),
 		ctx.machinePorts, ctx.pendingPorts,
 	)
 }
 
 func (ctx *HookContext) ClosePorts(protocol string, fromPort, toPort int) error {
+	return ctx.ClosePortsOnEndpoint("", protocol, fromPort, toPort)
+}
+
+func (ctx *HookContext) ClosePortsOnEndpoint(endpoint, protocol string, fromPort, toPort int) error {
 	return tryClosePorts(
-		protocol, fromPort, toPort,
+		endpoint, protocol, fromPort, toPort,
 		ctx.unit.Tag(),
 		ctx.machinePorts, ctx.pendingPorts,
 	)
@@ -460,6 +501,23 @@ func (ctx *HookContext) OpenedPorts() []network.PortRange {
 	return unitRanges
 }
 
+// SetEgressRules replaces the egress rules set by the charm for the
+// unit's application. Only the leader may set them.
+func (ctx *HookContext) SetEgressRules(rules []network.EgressRule) error {
+	isLeader, err := ctx.IsLeader()
+	if err != nil {
+		return errors.Annotatef(err, "cannot determine leadership")
+	}
+	if !isLeader {
+		return ErrIsNotLeader
+	}
+	application, err := ctx.unit.Application()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return application.SetCharmEgressRules(ctx.unit.Name(), rules)
+}
+
 func (ctx *HookContext) ConfigSettings() (charm.Settings, error) {
 	if ctx.configSettings == nil {
 		var err error
@@ -475,6 +533,52 @@ func (ctx *HookContext) ConfigSettings() (charm.Settings, error) {
 	return result, nil
 }
 
+// GoalState returns the units expected for the executing unit's
+// application and for every application related to it.
+func (ctx *HookContext) GoalState() (*params.GoalState, error) {
+	goalState, err := ctx.unit.GoalState()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &goalState, nil
+}
+
+// CloudSpec returns the cloud spec, including the cloud credential, of
+// the model. It is only available to units of trusted applications.
+func (ctx *HookContext) CloudSpec() (*params.CloudSpec, error) {
+	if ctx.cloudSpec != nil {
+		return ctx.cloudSpec, nil
+	}
+	cloudSpec, err := ctx.state.CloudSpec()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ctx.cloudSpec = cloudSpec
+	return cloudSpec, nil
+}
+
+// GetSecret returns the values of the named secret. The secret must
+// have been granted to the unit's application.
+func (ctx *HookContext) GetSecret(name string) (map[string]string, error) {
+	values, ok := ctx.secrets[name]
+	if !ok {
+		var err error
+		values, err = ctx.state.SecretValues(name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if ctx.secrets == nil {
+			ctx.secrets = make(map[string]map[string]string)
+		}
+		ctx.secrets[name] = values
+	}
+	result := make(map[string]string, len(values))
+	for key, value := range values {
+		result[key] = value
+	}
+	return result, nil
+}
+
 // ActionName returns the name of the action.
 func (ctx *HookContext) ActionName() (string, error) {
 	if ctx.actionData == nil {
@@ -500,6 +604,16 @@ func (ctx *HookContext) SetActionMessage(message string) error {
 	return nil
 }
 
+// LogActionMessage records a progress message for the Action. Unlike
+// the results, which are delivered when the Action completes, the
+// message is sent to the controller immediately.
+func (ctx *HookContext) LogActionMessage(message string) error {
+	if ctx.actionData == nil {
+		return errors.New("not running an action")
+	}
+	return errors.Trace(ctx.state.LogActionMessage(ctx.actionData.Tag, message))
+}
+
 // SetActionFailed sets the fail state of the action.
 func (ctx *HookContext) SetActionFailed() error {
 	if ctx.actionData == nil {
@@ -532,6 +646,13 @@ func (ctx *HookContext) RemoteUnitName() (string, error) {
 	return ctx.remoteUnitName, nil
 }
 
+func (ctx *HookContext) RemoteApplicationName() (string, error) {
+	if ctx.remoteApplicationName == "" {
+		return "", errors.NotFoundf("remote application")
+	}
+	return ctx.remoteApplicationName, nil
+}
+
 func (ctx *HookContext) Relation(id int) (jujuc.ContextRelation, error) {
 	r, found := ctx.relations[id]
 	if !found {
@@ -587,10 +708,14 @@ func (context *HookContext) HookVars(paths Paths) ([]string, error) {
 			"JUJU_RELATION="+r.Name(),
 			"JUJU_RELATION_ID="+r.FakeId(),
 			"JUJU_REMOTE_UNIT="+context.remoteUnitName,
+			"JUJU_REMOTE_APP="+context.remoteApplicationName,
 		)
 	} else if !errors.IsNotFound(err) {
 		return nil, errors.Trace(err)
 	}
+	if context.secretName != "" {
+		vars = append(vars, "JUJU_SECRET_NAME="+context.secretName)
+	}
 	if context.actionData != nil {
 		vars = append(vars,
 			"JUJU_ACTION_NAME="+context.actionData.Name,
@@ -677,14 +802,16 @@ func (ctx *HookContext) Flush(process string, ctxErr error) (err error) {
 			var e error
 			var op string
 			if rangeInfo.ShouldOpen {
-				e = ctx.unit.OpenPorts(
+				e = ctx.unit.OpenPortsOnEndpoint(
+					rangeKey.Endpoint,
 					rangeKey.Ports.Protocol,
 					rangeKey.Ports.FromPort,
 					rangeKey.Ports.ToPort,
 				)
 				op = "open"
 			} else {
-				e = ctx.unit.ClosePorts(
+				e = ctx.unit.ClosePortsOnEndpoint(
+					rangeKey.Endpoint,
 					rangeKey.Ports.Protocol,
 					rangeKey.Ports.FromPort,
 					rangeKey.Ports.ToPort,
@@ -713,6 +840,16 @@ func (ctx *HookContext) Flush(process string, ctxErr error) (err error) {
 		}
 	}
 
+	if ctx.charmStateDirty && writeChanges {
+		if err := ctx.unit.SetCharmState(ctx.charmState); err != nil {
+			err = errors.Annotatef(err, "cannot write charm state")
+			logger.Errorf("%v", err)
+			if ctxErr == nil {
+				ctxErr = err
+			}
+		}
+	}
+
 	// TODO (tasdomas) 2014 09 03: context finalization needs to modified to apply all
 	//                             changes in one api call to minimize the risk
 	//                             of partial failures.
@@ -747,6 +884,16 @@ func (ctx *HookContext) finalizeAction(err, unhandledErr error) error {
 		status = params.ActionFailed
 	}
 
+	// A cancelled or timed out Action is expected to have been
+	// interrupted; whatever else happened, record why it stopped.
+	if ctx.actionData.Cancelled() {
+		status = params.ActionCancelled
+		message = "action cancelled"
+	} else if ctx.actionData.TimedOut {
+		status = params.ActionFailed
+		message = fmt.Sprintf("action timed out after %v", ctx.actionData.Timeout)
+	}
+
 	callErr := ctx.state.ActionFinish(tag, status, results, message)
 	if callErr != nil {
 		unhandledErr = errors.Wrap(unhandledErr, callErr)
@@ -828,3 +975,70 @@ func (ctx *HookContext) SetUnitWorkloadVersion(version string) error {
 	}
 	return result.OneError()
 }
+
+// ensureCharmState reads the unit's charm state if it has not already
+// been read.
+func (ctx *HookContext) ensureCharmState() error {
+	if ctx.charmState != nil {
+		return nil
+	}
+	charmState, err := ctx.unit.CharmState()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if charmState == nil {
+		charmState = make(map[string]string)
+	}
+	ctx.charmState = charmState
+	return nil
+}
+
+// GetCharmState is part of the jujuc.ContextCharmState interface.
+func (ctx *HookContext) GetCharmState() (map[string]string, error) {
+	if err := ctx.ensureCharmState(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make(map[string]string, len(ctx.charmState))
+	for key, value := range ctx.charmState {
+		result[key] = value
+	}
+	return result, nil
+}
+
+// GetCharmStateValue is part of the jujuc.ContextCharmState interface.
+func (ctx *HookContext) GetCharmStateValue(key string) (string, error) {
+	if err := ctx.ensureCharmState(); err != nil {
+		return "", errors.Trace(err)
+	}
+	value, ok := ctx.charmState[key]
+	if !ok {
+		return "", errors.NotFoundf("%q", key)
+	}
+	return value, nil
+}
+
+// SetCharmStateValue is part of the jujuc.ContextCharmState interface.
+func (ctx *HookContext) SetCharmStateValue(key, value string) error {
+	if err := ctx.ensureCharmState(); err != nil {
+		return errors.Trace(err)
+	}
+	if old, ok := ctx.charmState[key]; ok && old == value {
+		return nil
+	}
+	ctx.charmState[key] = value
+	ctx.charmStateDirty = true
+	return nil
+}
+
+// DeleteCharmStateValue is part of the jujuc.ContextCharmState interface.
+func (ctx *HookContext) DeleteCharmStateValue(key string) error {
+	if err := ctx.ensureCharmState(); err != nil {
+		return errors.Trace(err)
+	}
+	if _, ok := ctx.charmState[key]; !ok {
+		return nil
+	}
+	delete(ctx.charmState, key)
+	ctx.charmStateDirty = true
+	return nil
+}
@@ -14,6 +14,7 @@ import (
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
 	"github.com/juju/juju/worker/uniter/runner"
 	"github.com/juju/juju/worker/uniter/runner/context"
@@ -150,6 +151,53 @@ func (s *InterfaceSuite) TestGetSetWorkloadVersion(c *gc.C) {
 	c.Assert(result, gc.Equals, "Pipey")
 }
 
+func (s *InterfaceSuite) TestGetSetCharmState(c *gc.C) {
+	ctx := s.GetContext(c, -1, "")
+	// No charm state set yet.
+	charmState, err := ctx.GetCharmState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, gc.HasLen, 0)
+	_, err = ctx.GetCharmStateValue("foo")
+	c.Assert(err, gc.ErrorMatches, `"foo" not found`)
+
+	err = ctx.SetCharmStateValue("foo", "bar")
+	c.Assert(err, jc.ErrorIsNil)
+	value, err := ctx.GetCharmStateValue("foo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(value, gc.Equals, "bar")
+
+	err = ctx.DeleteCharmStateValue("foo")
+	c.Assert(err, jc.ErrorIsNil)
+	charmState, err = ctx.GetCharmState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, gc.HasLen, 0)
+}
+
+func (s *InterfaceSuite) TestGetSecret(c *gc.C) {
+	secret, err := s.State.AddSecret(state.SecretArgs{
+		Name:   "db-password",
+		Values: map[string]string{"password": "sekrit"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx := s.GetContext(c, -1, "")
+	_, err = ctx.GetSecret("db-password")
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+
+	err = secret.Grant("u")
+	c.Assert(err, jc.ErrorIsNil)
+	values, err := ctx.GetSecret("db-password")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(values, jc.DeepEquals, map[string]string{"password": "sekrit"})
+
+	// The values are cached for the duration of the hook.
+	err = secret.Rotate(map[string]string{"password": "new"})
+	c.Assert(err, jc.ErrorIsNil)
+	values, err = ctx.GetSecret("db-password")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(values, jc.DeepEquals, map[string]string{"password": "sekrit"})
+}
+
 func (s *InterfaceSuite) TestUnitStatusCaching(c *gc.C) {
 	ctx := s.GetContext(c, -1, "")
 	unitStatus, err := ctx.UnitStatus()
@@ -231,6 +279,8 @@ func (s *InterfaceSuite) TestNonActionCallsToActionMethodsFail(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, "not running an action")
 	err = ctx.SetActionMessage("foo")
 	c.Check(err, gc.ErrorMatches, "not running an action")
+	err = ctx.LogActionMessage("foo")
+	c.Check(err, gc.ErrorMatches, "not running an action")
 	err = ctx.UpdateActionResults([]string{"1", "2", "3"}, "value")
 	c.Check(err, gc.ErrorMatches, "not running an action")
 }
@@ -295,6 +345,18 @@ func (s *InterfaceSuite) TestSetActionFailed(c *gc.C) {
 	c.Check(actionData.Failed, jc.IsTrue)
 }
 
+// TestActionDataCancelled ensures Cancelled reports the state of the
+// Cancel channel.
+func (s *InterfaceSuite) TestActionDataCancelled(c *gc.C) {
+	actionData := &context.ActionData{}
+	c.Check(actionData.Cancelled(), jc.IsFalse)
+	cancel := make(chan struct{})
+	actionData.Cancel = cancel
+	c.Check(actionData.Cancelled(), jc.IsFalse)
+	close(cancel)
+	c.Check(actionData.Cancelled(), jc.IsTrue)
+}
+
 // TestSetActionMessage ensures SetActionMessage works properly.
 func (s *InterfaceSuite) TestSetActionMessage(c *gc.C) {
 	hctx := context.GetStubActionContext(nil)
@@ -190,6 +190,13 @@ func (f *contextFactory) HookContext(hookInfo hook.Info) (*HookContext, error) {
 	if hookInfo.Kind.IsRelation() {
 		ctx.relationId = hookInfo.RelationId
 		ctx.remoteUnitName = hookInfo.RemoteUnit
+		ctx.remoteApplicationName = hookInfo.RemoteApplication
+		if hookInfo.RemoteUnit != "" {
+			ctx.remoteApplicationName, err = names.UnitApplication(hookInfo.RemoteUnit)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
 		relation, found := ctx.relations[hookInfo.RelationId]
 		if !found {
 			return nil, errors.Errorf("unknown relation id: %v", hookInfo.RelationId)
@@ -202,7 +209,7 @@ func (f *contextFactory) HookContext(hookInfo hook.Info) (*HookContext, error) {
 		}
 		hookName = fmt.Sprintf("%s-%s", relation.Name(), hookInfo.Kind)
 	}
-	if hookInfo.Kind.IsStorage() {
+	if hook.IsStorage(hookInfo.Kind) {
 		ctx.storageTag = names.NewStorageTag(hookInfo.StorageId)
 		if _, err := ctx.storage.Storage(ctx.storageTag); err != nil {
 			return nil, errors.Annotatef(err, "could not retrieve storage for id: %v", hookInfo.StorageId)
@@ -213,6 +220,9 @@ func (f *contextFactory) HookContext(hookInfo hook.Info) (*HookContext, error) {
 		}
 		hookName = fmt.Sprintf("%s-%s", storageName, hookName)
 	}
+	if hookInfo.Kind == hook.SecretChanged {
+		ctx.secretName = hookInfo.SecretName
+	}
 	ctx.id = f.newId(hookName)
 	return ctx, nil
 }
@@ -229,6 +239,12 @@ func (f *contextFactory) CommandContext(commandInfo CommandInfo) (*HookContext,
 	}
 	ctx.relationId = relationId
 	ctx.remoteUnitName = remoteUnitName
+	if remoteUnitName != "" {
+		ctx.remoteApplicationName, err = names.UnitApplication(remoteUnitName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
 	ctx.id = f.newId("run-commands")
 	return ctx, nil
 }
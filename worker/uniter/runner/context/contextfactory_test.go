@@ -298,6 +298,20 @@ func (s *ContextFactorySuite) TestNewCommandContextInferRemoteUnit(c *gc.C) {
 	s.AssertNotStorageContext(c, ctx)
 }
 
+func (s *ContextFactorySuite) TestNewHookContextSecretChanged(c *gc.C) {
+	ctx, err := s.factory.HookContext(hook.Info{
+		Kind:           hook.SecretChanged,
+		SecretName:     "db-password",
+		SecretRevision: 2,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(context.ContextSecretName(ctx), gc.Equals, "db-password")
+	s.AssertCoreContext(c, ctx)
+	s.AssertNotActionContext(c, ctx)
+	s.AssertNotRelationContext(c, ctx)
+	s.AssertNotStorageContext(c, ctx)
+}
+
 func (s *ContextFactorySuite) TestNewHookContextPrunesNonMemberCaches(c *gc.C) {
 
 	// Write cached member settings for a member and a non-member.
@@ -381,6 +395,29 @@ func (s *ContextFactorySuite) TestNewHookContextRelationChangedUpdatesRelationCo
 	cached4, member := s.getCache(1, "r/4")
 	c.Assert(cached4, gc.IsNil)
 	c.Assert(member, jc.IsTrue)
+	appName, err := ctx.RemoteApplicationName()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(appName, gc.Equals, "r")
+}
+
+func (s *ContextFactorySuite) TestNewHookContextApplicationRelationChangedRetainsCaches(c *gc.C) {
+	s.setUpCacheMethods(c)
+	s.membership[1] = []string{"r/0"}
+	s.updateCache(1, "r/0", params.Settings{"foo": "bar"})
+
+	ctx, err := s.factory.HookContext(hook.Info{
+		Kind:              hooks.RelationChanged,
+		RelationId:        1,
+		RemoteApplication: "r",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AssertRelationContext(c, ctx, 1, "")
+	appName, err := ctx.RemoteApplicationName()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(appName, gc.Equals, "r")
+	cached0, member := s.getCache(1, "r/0")
+	c.Assert(cached0, jc.DeepEquals, params.Settings{"foo": "bar"})
+	c.Assert(member, jc.IsTrue)
 }
 
 func (s *ContextFactorySuite) TestNewHookContextRelationDepartedUpdatesRelationContextAndCaches(c *gc.C) {
@@ -91,6 +91,7 @@ func (s *EnvSuite) setRelation(ctx *context.HookContext) (expectVars []string) {
 		"JUJU_RELATION=an-endpoint",
 		"JUJU_RELATION_ID=an-endpoint:22",
 		"JUJU_REMOTE_UNIT=that-unit/456",
+		"JUJU_REMOTE_APP=that-unit",
 	}
 }
 
@@ -147,3 +148,22 @@ func (s *EnvSuite) TestEnvUbuntu(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	s.assertVars(c, actualVars, contextVars, pathsVars, ubuntuVars, relationVars)
 }
+
+func (s *EnvSuite) TestEnvSecretChanged(c *gc.C) {
+	s.PatchValue(&jujuos.HostOS, func() jujuos.OSType { return jujuos.Ubuntu })
+	os.Setenv("PATH", "foo:bar")
+	ubuntuVars := []string{
+		"PATH=path-to-tools:foo:bar",
+		"APT_LISTCHANGES_FRONTEND=none",
+		"DEBIAN_FRONTEND=noninteractive",
+	}
+
+	ctx, contextVars := s.getContext()
+	paths, pathsVars := s.getPaths()
+	context.SetEnvironmentHookContextSecret(ctx, "db-password")
+	actualVars, err := ctx.HookVars(paths)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertVars(c, actualVars, contextVars, pathsVars, ubuntuVars, []string{
+		"JUJU_SECRET_NAME=db-password",
+	})
+}
@@ -95,6 +95,7 @@ func SetEnvironmentHookContextRelation(
 ) {
 	context.relationId = relationId
 	context.remoteUnitName = remoteUnitName
+	context.remoteApplicationName, _ = names.UnitApplication(remoteUnitName)
 	context.relations = map[int]*ContextRelation{
 		relationId: {
 			endpointName: endpointName,
@@ -103,6 +104,17 @@ func SetEnvironmentHookContextRelation(
 	}
 }
 
+// SetEnvironmentHookContextSecret exists purely to set the fields used in hookVars.
+func SetEnvironmentHookContextSecret(context *HookContext, secretName string) {
+	context.secretName = secretName
+}
+
+// ContextSecretName returns the name of the secret whose rotation
+// triggered the context's hook.
+func ContextSecretName(hctx *HookContext) string {
+	return hctx.secretName
+}
+
 func PatchCachedStatus(ctx jujuc.Context, status, info string, data map[string]interface{}) func() {
 	hctx := ctx.(*HookContext)
 	oldStatus := hctx.status
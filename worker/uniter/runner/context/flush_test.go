@@ -207,6 +207,39 @@ func (s *FlushContextSuite) TestRunHookAddUnitStorageOnSuccess(c *gc.C) {
 	c.Assert(all, gc.HasLen, 0)
 }
 
+func (s *FlushContextSuite) TestRunHookCharmStateOnFailure(c *gc.C) {
+	ctx := s.context(c)
+	err := ctx.SetCharmStateValue("foo", "bar")
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Flush the context with an error.
+	err = ctx.Flush("some badge", errors.New("blam pow"))
+	c.Assert(err, gc.ErrorMatches, "blam pow")
+
+	charmState, err := s.unit.CharmState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, gc.HasLen, 0)
+}
+
+func (s *FlushContextSuite) TestRunHookCharmStateOnSuccess(c *gc.C) {
+	err := s.unit.SetCharmState(map[string]string{"foo": "bar", "baz": "qux"}, 0)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx := s.context(c)
+	err = ctx.SetCharmStateValue("foo", "quux")
+	c.Assert(err, jc.ErrorIsNil)
+	err = ctx.DeleteCharmStateValue("baz")
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Flush the context with a success.
+	err = ctx.Flush("success", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	charmState, err := s.unit.CharmState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, jc.DeepEquals, map[string]string{"foo": "quux"})
+}
+
 func (s *HookContextSuite) context(c *gc.C) *context.HookContext {
 	uuid, err := utils.NewUUID()
 	c.Assert(err, jc.ErrorIsNil)
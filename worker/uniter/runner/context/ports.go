@@ -21,11 +21,13 @@ type PortRangeInfo struct {
 	RelationTag names.RelationTag
 }
 
-// PortRange contains a port range and a relation id. Used as key to
-// pendingRelations and is only exported for testing.
+// PortRange contains a port range, a relation id and the endpoint the
+// range is opened for. Used as key to pendingRelations and is only
+// exported for testing.
 type PortRange struct {
 	Ports      network.PortRange
 	RelationId int
+	Endpoint   string
 }
 
 func validatePortRange(protocol string, fromPort, toPort int) (network.PortRange, error) {
@@ -42,7 +44,7 @@ func validatePortRange(protocol string, fromPort, toPort int) (network.PortRange
 }
 
 func tryOpenPorts(
-	protocol string,
+	endpoint, protocol string,
 	fromPort, toPort int,
 	unitTag names.UnitTag,
 	machinePorts map[network.PortRange]params.RelationUnit,
@@ -61,6 +63,7 @@ func tryOpenPorts(
 	rangeKey := PortRange{
 		Ports:      newRange,
 		RelationId: relationId,
+		Endpoint:   endpoint,
 	}
 
 	rangeInfo, isKnown := pendingPorts[rangeKey]
@@ -87,9 +90,9 @@ func tryOpenPorts(
 		}
 		if newRange.ConflictsWith(portRange) {
 			if portRange == newRange && relUnitTag == unitTag {
-				// The same unit trying to open the same range is just
-				// ignored.
-				return nil
+				// The same unit may open the same range again, perhaps
+				// for another endpoint; state ignores exact duplicates.
+				continue
 			}
 			return errors.Errorf(
 				"cannot open %v (unit %q): conflicts with existing %v (unit %q)",
@@ -99,6 +102,10 @@ func tryOpenPorts(
 	}
 	// Ensure other pending port ranges do not conflict with this one.
 	for rangeKey, rangeInfo := range pendingPorts {
+		if rangeKey.Ports == newRange {
+			// The same range pending for another endpoint.
+			continue
+		}
 		if newRange.ConflictsWith(rangeKey.Ports) && rangeInfo.ShouldOpen {
 			return errors.Errorf(
 				"cannot open %v (unit %q): conflicts with %v requested earlier",
@@ -114,7 +121,7 @@ func tryOpenPorts(
 }
 
 func tryClosePorts(
-	protocol string,
+	endpoint, protocol string,
 	fromPort, toPort int,
 	unitTag names.UnitTag,
 	machinePorts map[network.PortRange]params.RelationUnit,
@@ -133,6 +140,7 @@ func tryClosePorts(
 	rangeKey := PortRange{
 		Ports:      newRange,
 		RelationId: relationId,
+		Endpoint:   endpoint,
 	}
 
 	rangeInfo, isKnown := pendingPorts[rangeKey]
@@ -135,6 +135,7 @@ func makePendingPorts(
 
 type portsTest struct {
 	about         string
+	endpoint      string
 	proto         string
 	ports         []int
 	machinePorts  map[network.PortRange]params.RelationUnit
@@ -169,9 +170,9 @@ func (s *PortsSuite) TestTryOpenPorts(c *gc.C) {
 		about:         "open a new range (no machine ports yet)",
 		expectPending: makePendingPorts("tcp", 10, 20, true),
 	}, {
-		about:         "open an existing range (ignored)",
+		about:         "open an existing range (opened again, maybe for another endpoint)",
 		machinePorts:  makeMachinePorts("u/0", "tcp", 10, 20),
-		expectPending: map[context.PortRange]context.PortRangeInfo{},
+		expectPending: makePendingPorts("tcp", 10, 20, true),
 	}, {
 		about:         "open a range pending to be closed already",
 		pendingPorts:  makePendingPorts("tcp", 10, 20, false),
@@ -189,19 +190,29 @@ func (s *PortsSuite) TestTryOpenPorts(c *gc.C) {
 		machinePorts: makeMachinePorts("u/1", "tcp", 10, 20),
 		expectErr:    `cannot open 10-20/tcp \(unit "u/0"\): conflicts with existing 10-20/tcp \(unit "u/1"\)`,
 	}, {
-		about:         "open a range conflicting with the same unit (ignored)",
-		machinePorts:  makeMachinePorts("u/0", "tcp", 10, 20),
-		expectPending: map[context.PortRange]context.PortRangeInfo{},
+		about:        "try opening a range conflicting with the same unit",
+		ports:        []int{15, 25},
+		machinePorts: makeMachinePorts("u/0", "tcp", 10, 20),
+		expectErr:    `cannot open 15-25/tcp \(unit "u/0"\): conflicts with existing 10-20/tcp \(unit "u/0"\)`,
 	}, {
 		about:        "try opening a range conflicting with another pending range",
 		pendingPorts: makePendingPorts("tcp", 5, 25, true),
 		expectErr:    `cannot open 10-20/tcp \(unit "u/0"\): conflicts with 5-25/tcp requested earlier`,
+	}, {
+		about:        "open a range for an endpoint",
+		endpoint:     "db",
+		pendingPorts: makePendingPorts("tcp", 10, 20, true),
+		expectPending: map[context.PortRange]context.PortRangeInfo{
+			{Ports: network.PortRange{FromPort: 10, ToPort: 20, Protocol: "tcp"}, RelationId: -1}:                 {ShouldOpen: true},
+			{Ports: network.PortRange{FromPort: 10, ToPort: 20, Protocol: "tcp"}, RelationId: -1, Endpoint: "db"}: {ShouldOpen: true},
+		},
 	}}
 	for i, test := range tests {
 		c.Logf("test %d: %s", i, test.about)
 
 		test = test.withDefaults("tcp", 10, 20)
 		err := context.TryOpenPorts(
+			test.endpoint,
 			test.proto,
 			test.ports[0],
 			test.ports[1],
@@ -250,12 +261,20 @@ func (s *PortsSuite) TestTryClosePorts(c *gc.C) {
 		about:        "try closing a range of another unit",
 		machinePorts: makeMachinePorts("u/1", "tcp", 10, 20),
 		expectErr:    `cannot close 10-20/tcp \(opened by "u/1"\) from "u/0"`,
+	}, {
+		about:        "close an existing range for an endpoint",
+		endpoint:     "db",
+		machinePorts: makeMachinePorts("u/0", "tcp", 10, 20),
+		expectPending: map[context.PortRange]context.PortRangeInfo{
+			{Ports: network.PortRange{FromPort: 10, ToPort: 20, Protocol: "tcp"}, RelationId: -1, Endpoint: "db"}: {ShouldOpen: false},
+		},
 	}}
 	for i, test := range tests {
 		c.Logf("test %d: %s", i, test.about)
 
 		test = test.withDefaults("tcp", 10, 20)
 		err := context.TryClosePorts(
+			test.endpoint,
 			test.proto,
 			test.ports[0],
 			test.ports[1],
@@ -4,6 +4,10 @@
 package runner
 
 import (
+	"github.com/juju/cmd"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/worker/uniter/runner/context"
 )
 
@@ -12,8 +16,31 @@ var (
 	SearchHook              = searchHook
 	HookCommand             = hookCommand
 	LookPath                = lookPath
+	ExitCode                = exitCode
 )
 
 func RunnerPaths(rnr Runner) context.Paths {
 	return rnr.(*runner).paths
 }
+
+// RunTimedCommand runs the command as a hook tool with the given name,
+// returning its exit code and the tool calls recorded for it.
+func RunTimedCommand(clock clock.Clock, name string, command cmd.Command, ctx *cmd.Context, args []string) (int, []params.HookToolCall) {
+	recorder := newToolCallRecorder(clock)
+	code := cmd.Main(recorder.wrap(name, command), ctx, args)
+	calls, _ := recorder.recorded()
+	return code, calls
+}
+
+// RunTimedCommandRepeatedly runs the command the given number of times
+// as a hook tool with the given name, returning the tool calls
+// recorded and the number of calls dropped.
+func RunTimedCommandRepeatedly(clock clock.Clock, name string, command cmd.Command, ctx *cmd.Context, times int) ([]params.HookToolCall, int) {
+	recorder := newToolCallRecorder(clock)
+	for i := 0; i < times; i++ {
+		cmd.Main(recorder.wrap(name, command), ctx, nil)
+	}
+	return recorder.recorded()
+}
+
+const MaxRecordedToolCalls = maxRecordedToolCalls
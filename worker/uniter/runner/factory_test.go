@@ -252,6 +252,18 @@ func (s *FactorySuite) TestNewActionRunnerGood(c *gc.C) {
 	}
 }
 
+func (s *FactorySuite) TestNewActionRunnerWithTimeout(c *gc.C) {
+	s.SetCharm(c, "dummy")
+	payload := map[string]interface{}{"outfile": "/some/file.bz2"}
+	action, err := s.unit.AddActionWithTimeout("snapshot", payload, time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	rnr, err := s.factory.NewActionRunner(action.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := rnr.Context().ActionData()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(data.Timeout, gc.Equals, time.Minute)
+}
+
 func (s *FactorySuite) TestNewActionRunnerBadCharm(c *gc.C) {
 	rnr, err := s.factory.NewActionRunner("irrelevant")
 	c.Assert(rnr, gc.IsNil)
@@ -0,0 +1,61 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+)
+
+// ActionLogCommand implements the action-log command.
+type ActionLogCommand struct {
+	cmd.CommandBase
+	ctx     Context
+	message string
+}
+
+// NewActionLogCommand returns a new ActionLogCommand with the given context.
+func NewActionLogCommand(ctx Context) (cmd.Command, error) {
+	return &ActionLogCommand{ctx: ctx}, nil
+}
+
+// Info returns the content for --help.
+func (c *ActionLogCommand) Info() *cmd.Info {
+	doc := `
+action-log records a progress message for the running action. Unlike the
+results set with action-set, the message is sent to the controller
+immediately, so that it can be seen with "juju show-action-output --watch"
+while the action is still running.
+
+Messages longer than 4KiB are truncated. Only the most recent messages are
+kept, up to 1000 messages or 256KiB in total.
+`
+	return &cmd.Info{
+		Name:    "action-log",
+		Args:    "<message>",
+		Purpose: "record a progress message for the current action",
+		Doc:     doc,
+	}
+}
+
+// SetFlags handles any option flags, but there are none.
+func (c *ActionLogCommand) SetFlags(f *gnuflag.FlagSet) {
+}
+
+// Init sets the message to be logged.
+func (c *ActionLogCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no message specified")
+	}
+	c.message = strings.Join(args, " ")
+	return nil
+}
+
+// Run records the message for the current action.
+func (c *ActionLogCommand) Run(ctx *cmd.Context) error {
+	return c.ctx.LogActionMessage(c.message)
+}
@@ -53,6 +53,8 @@ type HookContext interface {
 	ContextComponents
 	ContextRelations
 	ContextVersion
+	ContextCharmState
+	ContextSecrets
 }
 
 // UnitHookContext is the context for a unit hook.
@@ -75,6 +77,11 @@ type relationHookContext interface {
 	// is associated with if it was found, and an error if it was not found or is not
 	// available.
 	RemoteUnitName() (string, error)
+
+	// RemoteApplicationName returns the name of the remote application
+	// the hook execution is associated with if it was found, and an
+	// error if it was not found or is not available.
+	RemoteApplicationName() (string, error)
 }
 
 // ActionHookContext is the context for an action hook.
@@ -95,6 +102,9 @@ type actionHookContext interface {
 	// SetActionMessage sets a message for the Action.
 	SetActionMessage(string) error
 
+	// LogActionMessage records a progress message for the Action.
+	LogActionMessage(string) error
+
 	// SetActionFailed sets a failure state for the Action.
 	SetActionFailed() error
 }
@@ -106,6 +116,14 @@ type ContextUnit interface {
 
 	// Config returns the current service configuration of the executing unit.
 	ConfigSettings() (charm.Settings, error)
+
+	// GoalState returns the units expected for the executing unit's
+	// application and for every application related to it.
+	GoalState() (*params.GoalState, error)
+
+	// CloudSpec returns the cloud spec, including the cloud credential,
+	// of the model. It is only available to units of trusted applications.
+	CloudSpec() (*params.CloudSpec, error)
 }
 
 // ContextStatus is the part of a hook context related to the unit's status.
@@ -154,11 +172,24 @@ type ContextNetworking interface {
 	// separately by a co- located unit).
 	ClosePorts(protocol string, fromPort, toPort int) error
 
+	// OpenPortsOnEndpoint marks the supplied port range for opening
+	// for the named endpoint of the executing unit, when the endpoint
+	// is exposed.
+	OpenPortsOnEndpoint(endpoint, protocol string, fromPort, toPort int) error
+
+	// ClosePortsOnEndpoint ensures the supplied port range is closed
+	// for the named endpoint of the executing unit.
+	ClosePortsOnEndpoint(endpoint, protocol string, fromPort, toPort int) error
+
 	// OpenedPorts returns all port ranges currently opened by this
 	// unit on its assigned machine. The result is sorted first by
 	// protocol, then by number.
 	OpenedPorts() []network.PortRange
 
+	// SetEgressRules replaces the egress rules set by the charm for the
+	// executing unit's application. Only the leader may set them.
+	SetEgressRules(rules []network.EgressRule) error
+
 	// NetworkConfig returns the network configuration for the unit and the
 	// given bindingName.
 	//
@@ -270,6 +301,15 @@ type ContextRelation interface {
 
 	// ReadSettings returns the settings of any remote unit in the relation.
 	ReadSettings(unit string) (params.Settings, error)
+
+	// ApplicationSettings allows read/write access to the local
+	// application's settings in this relation. Only the leader may
+	// access them.
+	ApplicationSettings() (Settings, error)
+
+	// ReadApplicationSettings returns the settings of any remote
+	// application in the relation.
+	ReadApplicationSettings(app string) (params.Settings, error)
 }
 
 // ContextStorageAttachment expresses the capabilities of a hook with
@@ -300,6 +340,35 @@ type ContextVersion interface {
 	SetUnitWorkloadVersion(string) error
 }
 
+// ContextCharmState expresses the parts of a hook context related to
+// the key/value state that a charm persists in the controller for its
+// unit. Changes are written back when the hook completes successfully.
+type ContextCharmState interface {
+
+	// GetCharmState returns a copy of the charm state of the unit.
+	GetCharmState() (map[string]string, error)
+
+	// GetCharmStateValue returns the value of the given key, or a
+	// not found error if it is not set.
+	GetCharmStateValue(string) (string, error)
+
+	// SetCharmStateValue sets the value of the given key.
+	SetCharmStateValue(string, string) error
+
+	// DeleteCharmStateValue removes the given key, if it is set.
+	DeleteCharmStateValue(string) error
+}
+
+// ContextSecrets expresses the parts of a hook context related to the
+// secrets granted to the unit's application.
+type ContextSecrets interface {
+
+	// GetSecret returns the values of the named secret. It returns an
+	// unauthorized error if the secret has not been granted to the
+	// unit's application.
+	GetSecret(string) (map[string]string, error)
+}
+
 // Settings is implemented by types that manipulate unit settings.
 type Settings interface {
 	Map() params.Settings
@@ -0,0 +1,99 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// CredentialGetCommand implements the credential-get command.
+type CredentialGetCommand struct {
+	cmd.CommandBase
+	ctx Context
+	out cmd.Output
+}
+
+// NewCredentialGetCommand returns a command that prints the cloud
+// spec, including the cloud credential, of the model.
+func NewCredentialGetCommand(ctx Context) (cmd.Command, error) {
+	return &CredentialGetCommand{ctx: ctx}, nil
+}
+
+// Info implements cmd.Command.
+func (c *CredentialGetCommand) Info() *cmd.Info {
+	doc := `
+credential-get prints the details of the cloud hosting the model,
+including the cloud credential used by the model.
+
+It is only available to units of applications that have been trusted by
+a model administrator with "juju trust". Each use is recorded in the
+controller's audit log.
+`
+	return &cmd.Info{
+		Name:    "credential-get",
+		Purpose: "print the cloud credential of the model",
+		Doc:     doc,
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *CredentialGetCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+}
+
+// Init implements cmd.Command.
+func (c *CredentialGetCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements cmd.Command.
+func (c *CredentialGetCommand) Run(ctx *cmd.Context) error {
+	cloudSpec, err := c.ctx.CloudSpec()
+	if params.IsCodeUnauthorized(err) {
+		return errors.New(`cannot access cloud credential: application has not been trusted with "juju trust"`)
+	} else if err != nil {
+		return errors.Annotate(err, "cannot access cloud credential")
+	}
+	return c.out.Write(ctx, formatCloudSpec(cloudSpec))
+}
+
+type formattedCloudSpec struct {
+	Type             string               `json:"type" yaml:"type"`
+	Name             string               `json:"name" yaml:"name"`
+	Region           string               `json:"region,omitempty" yaml:"region,omitempty"`
+	Endpoint         string               `json:"endpoint,omitempty" yaml:"endpoint,omitempty"`
+	IdentityEndpoint string               `json:"identity-endpoint,omitempty" yaml:"identity-endpoint,omitempty"`
+	StorageEndpoint  string               `json:"storage-endpoint,omitempty" yaml:"storage-endpoint,omitempty"`
+	Credential       *formattedCredential `json:"credential,omitempty" yaml:"credential,omitempty"`
+}
+
+type formattedCredential struct {
+	AuthType   string            `json:"auth-type" yaml:"auth-type"`
+	Attributes map[string]string `json:"attrs,omitempty" yaml:"attrs,omitempty"`
+}
+
+func formatCloudSpec(spec *params.CloudSpec) formattedCloudSpec {
+	result := formattedCloudSpec{
+		Type:             spec.Type,
+		Name:             spec.Name,
+		Region:           spec.Region,
+		Endpoint:         spec.Endpoint,
+		IdentityEndpoint: spec.IdentityEndpoint,
+		StorageEndpoint:  spec.StorageEndpoint,
+	}
+	if spec.Credential != nil {
+		result.Credential = &formattedCredential{
+			AuthType:   spec.Credential.AuthType,
+			Attributes: spec.Credential.Attributes,
+		}
+	}
+	return result
+}
@@ -0,0 +1,114 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"encoding/json"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type CredentialGetSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&CredentialGetSuite{})
+
+func (s *CredentialGetSuite) newCredentialGetContext(c *gc.C) *Context {
+	hctx := s.GetHookContext(c, -1, "")
+	hctx.info.CloudSpec = params.CloudSpec{
+		Type:     "ec2",
+		Name:     "aws",
+		Region:   "us-east-1",
+		Endpoint: "https://ec2.us-east-1.amazonaws.com",
+		Credential: &params.CloudCredential{
+			AuthType: "access-key",
+			Attributes: map[string]string{
+				"access-key": "key",
+				"secret-key": "secret",
+			},
+		},
+	}
+	return hctx
+}
+
+func (s *CredentialGetSuite) run(c *gc.C, args ...string) (*cmd.Context, int) {
+	hctx := s.newCredentialGetContext(c)
+	com, err := jujuc.NewCommand(hctx, cmdString("credential-get"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, args)
+	return ctx, code
+}
+
+func (s *CredentialGetSuite) TestOutputYAML(c *gc.C) {
+	ctx, code := s.run(c)
+	c.Assert(code, gc.Equals, 0)
+	c.Assert(bufferString(ctx.Stderr), gc.Equals, "")
+	c.Assert(bufferString(ctx.Stdout), jc.YAMLEquals, map[interface{}]interface{}{
+		"type":     "ec2",
+		"name":     "aws",
+		"region":   "us-east-1",
+		"endpoint": "https://ec2.us-east-1.amazonaws.com",
+		"credential": map[interface{}]interface{}{
+			"auth-type": "access-key",
+			"attrs": map[interface{}]interface{}{
+				"access-key": "key",
+				"secret-key": "secret",
+			},
+		},
+	})
+}
+
+func (s *CredentialGetSuite) TestOutputJSON(c *gc.C) {
+	ctx, code := s.run(c, "--format", "json")
+	c.Assert(code, gc.Equals, 0)
+	var out map[string]interface{}
+	err := json.Unmarshal(bufferBytes(ctx.Stdout), &out)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, jc.DeepEquals, map[string]interface{}{
+		"type":     "ec2",
+		"name":     "aws",
+		"region":   "us-east-1",
+		"endpoint": "https://ec2.us-east-1.amazonaws.com",
+		"credential": map[string]interface{}{
+			"auth-type": "access-key",
+			"attrs": map[string]interface{}{
+				"access-key": "key",
+				"secret-key": "secret",
+			},
+		},
+	})
+}
+
+func (s *CredentialGetSuite) TestNotTrusted(c *gc.C) {
+	s.Stub.SetErrors(&params.Error{
+		Code:    params.CodeUnauthorized,
+		Message: "permission denied",
+	})
+	ctx, code := s.run(c)
+	c.Assert(code, gc.Equals, 1)
+	c.Assert(bufferString(ctx.Stderr), gc.Equals,
+		"error: cannot access cloud credential: application has not been trusted with \"juju trust\"\n")
+}
+
+func (s *CredentialGetSuite) TestError(c *gc.C) {
+	s.Stub.SetErrors(errors.New("boom"))
+	ctx, code := s.run(c)
+	c.Assert(code, gc.Equals, 1)
+	c.Assert(bufferString(ctx.Stderr), gc.Equals, "error: cannot access cloud credential: boom\n")
+}
+
+func (s *CredentialGetSuite) TestUnexpectedArgs(c *gc.C) {
+	ctx, code := s.run(c, "foo")
+	c.Assert(code, gc.Equals, 2)
+	c.Assert(bufferString(ctx.Stderr), gc.Equals, "error: unrecognized args: [\"foo\"]\n")
+}
@@ -0,0 +1,63 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/network"
+)
+
+// egressRulesSetCommand implements the egress-rules-set command.
+type egressRulesSetCommand struct {
+	cmd.CommandBase
+	ctx   Context
+	rules []network.EgressRule
+}
+
+// NewEgressRulesSetCommand returns a new egressRulesSetCommand with the
+// given context.
+func NewEgressRulesSetCommand(ctx Context) (cmd.Command, error) {
+	return &egressRulesSetCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *egressRulesSetCommand) Info() *cmd.Info {
+	doc := `
+egress-rules-set immediately replaces the egress rules of the application,
+which limit the destinations to which the machines hosting its units may
+send traffic. Each rule takes the form <port-range>[@<cidr>[,<cidr>...]],
+for example "443/tcp" or "53/udp@10.0.0.2/32"; a rule without CIDRs allows
+traffic to any destination. The rules are combined with those set by the
+operator. Calling egress-rules-set without arguments removes the charm's
+rules. It will fail if called by a unit that is not currently application
+leader.
+`
+	return &cmd.Info{
+		Name:    "egress-rules-set",
+		Args:    "[<rule> ...]",
+		Purpose: "set the application's egress firewall rules",
+		Doc:     doc,
+	}
+}
+
+// Init is part of the cmd.Command interface.
+func (c *egressRulesSetCommand) Init(args []string) error {
+	c.rules = nil
+	for _, arg := range args {
+		rule, err := network.ParseEgressRule(arg)
+		if err != nil {
+			return errors.Annotatef(err, "invalid egress rule %q", arg)
+		}
+		c.rules = append(c.rules, rule)
+	}
+	return nil
+}
+
+// Run is part of the cmd.Command interface.
+func (c *egressRulesSetCommand) Run(_ *cmd.Context) error {
+	err := c.ctx.SetEgressRules(c.rules)
+	return errors.Annotatef(err, "cannot set egress rules")
+}
@@ -0,0 +1,85 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type EgressRulesSetSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&EgressRulesSetSuite{})
+
+func (s *EgressRulesSetSuite) createCommand(c *gc.C, err error) (*Context, cmd.Command) {
+	hctx := s.GetHookContext(c, -1, "")
+	s.Stub.SetErrors(err)
+
+	com, err := jujuc.NewCommand(hctx, cmdString("egress-rules-set"))
+	c.Assert(err, jc.ErrorIsNil)
+	return hctx, com
+}
+
+func (s *EgressRulesSetSuite) TestEgressRulesSet(c *gc.C) {
+	hctx, com := s.createCommand(c, nil)
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, []string{"443/tcp", "53/udp@10.0.0.2/32,10.0.0.3/32"})
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "")
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	c.Check(hctx.info.EgressRules, jc.DeepEquals, []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443),
+		network.MustNewEgressRule("udp", 53, 53, "10.0.0.2/32", "10.0.0.3/32"),
+	})
+}
+
+func (s *EgressRulesSetSuite) TestEgressRulesSetNoArguments(c *gc.C) {
+	hctx, com := s.createCommand(c, nil)
+	hctx.info.EgressRules = []network.EgressRule{network.MustNewEgressRule("tcp", 443, 443)}
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, nil)
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	c.Check(hctx.info.EgressRules, gc.IsNil)
+}
+
+func (s *EgressRulesSetSuite) TestEgressRulesSetInvalid(c *gc.C) {
+	for _, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"foo/tcp"},
+		err:  `error: invalid egress rule "foo/tcp": invalid port "foo": .*` + "\n",
+	}, {
+		args: []string{"443/icmp"},
+		err:  `error: invalid egress rule "443/icmp": invalid protocol "icmp", expected "tcp" or "udp"` + "\n",
+	}, {
+		args: []string{"443/tcp@10.0.0.0"},
+		err:  `error: invalid egress rule "443/tcp@10.0.0.0": invalid CIDR address: 10.0.0.0` + "\n",
+	}} {
+		hctx, com := s.createCommand(c, nil)
+		ctx := testing.Context(c)
+		code := cmd.Main(com, ctx, t.args)
+		c.Check(code, gc.Equals, 2)
+		c.Check(bufferString(ctx.Stderr), gc.Matches, t.err)
+		c.Check(hctx.info.EgressRules, gc.IsNil)
+	}
+}
+
+func (s *EgressRulesSetSuite) TestEgressRulesSetError(c *gc.C) {
+	hctx, com := s.createCommand(c, errors.New("permission denied"))
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, []string{"443/tcp"})
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "error: cannot set egress rules: permission denied\n")
+	c.Check(hctx.info.EgressRules, gc.IsNil)
+}
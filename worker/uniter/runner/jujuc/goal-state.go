@@ -0,0 +1,105 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// GoalStateCommand implements the goal-state command.
+type GoalStateCommand struct {
+	cmd.CommandBase
+	ctx Context
+	out cmd.Output
+}
+
+// NewGoalStateCommand returns a command that prints the goal state of
+// the executing unit.
+func NewGoalStateCommand(ctx Context) (cmd.Command, error) {
+	return &GoalStateCommand{ctx: ctx}, nil
+}
+
+// Info implements cmd.Command.
+func (c *GoalStateCommand) Info() *cmd.Info {
+	doc := `
+goal-state prints the units expected for this unit's application and,
+grouped by relation name, the applications related to it together with
+their expected units.
+
+Each unit is reported with its workload status, or with its life if it
+is dying or dead. Each related application is reported as "joined"
+unless its relation is being removed.
+`
+	return &cmd.Info{
+		Name:    "goal-state",
+		Purpose: "print the status of the charm's peers and related units",
+		Doc:     doc,
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *GoalStateCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+}
+
+// Init implements cmd.Command.
+func (c *GoalStateCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements cmd.Command.
+func (c *GoalStateCommand) Run(ctx *cmd.Context) error {
+	goalState, err := c.ctx.GoalState()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.out.Write(ctx, formatGoalState(goalState))
+}
+
+type formattedGoalState struct {
+	Units     goalStateUnits            `json:"units" yaml:"units"`
+	Relations map[string]goalStateUnits `json:"relations" yaml:"relations"`
+}
+
+type goalStateUnits map[string]goalStateStatus
+
+type goalStateStatus struct {
+	Status string `json:"status" yaml:"status"`
+	Since  string `json:"since,omitempty" yaml:"since,omitempty"`
+}
+
+func formatGoalState(goalState *params.GoalState) formattedGoalState {
+	result := formattedGoalState{
+		Units:     formatGoalStateUnits(goalState.Units),
+		Relations: make(map[string]goalStateUnits),
+	}
+	for name, units := range goalState.Relations {
+		result.Relations[name] = formatGoalStateUnits(units)
+	}
+	return result
+}
+
+func formatGoalStateUnits(units params.UnitsGoalState) goalStateUnits {
+	result := make(goalStateUnits)
+	for name, unit := range units {
+		var since string
+		if unit.Since != nil {
+			since = unit.Since.UTC().Format(time.RFC3339)
+		}
+		result[name] = goalStateStatus{
+			Status: unit.Status,
+			Since:  since,
+		}
+	}
+	return result
+}
@@ -0,0 +1,97 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type GoalStateSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&GoalStateSuite{})
+
+func (s *GoalStateSuite) newGoalStateContext(c *gc.C) *Context {
+	since := time.Date(2017, time.March, 14, 10, 0, 0, 0, time.UTC)
+	hctx := s.GetHookContext(c, -1, "")
+	hctx.info.GoalState = params.GoalState{
+		Units: params.UnitsGoalState{
+			"mysql/0": {Status: "active", Since: &since},
+			"mysql/1": {Status: "dying"},
+		},
+		Relations: map[string]params.UnitsGoalState{
+			"server": {
+				"wordpress":   {Status: "joined"},
+				"wordpress/0": {Status: "waiting", Since: &since},
+			},
+		},
+	}
+	return hctx
+}
+
+func (s *GoalStateSuite) run(c *gc.C, args ...string) *cmd.Context {
+	hctx := s.newGoalStateContext(c)
+	com, err := jujuc.NewCommand(hctx, cmdString("goal-state"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, args)
+	c.Assert(code, gc.Equals, 0)
+	c.Assert(bufferString(ctx.Stderr), gc.Equals, "")
+	return ctx
+}
+
+func (s *GoalStateSuite) TestOutputYAML(c *gc.C) {
+	ctx := s.run(c)
+	c.Assert(bufferString(ctx.Stdout), jc.YAMLEquals, map[interface{}]interface{}{
+		"units": map[interface{}]interface{}{
+			"mysql/0": map[interface{}]interface{}{"status": "active", "since": "2017-03-14T10:00:00Z"},
+			"mysql/1": map[interface{}]interface{}{"status": "dying"},
+		},
+		"relations": map[interface{}]interface{}{
+			"server": map[interface{}]interface{}{
+				"wordpress":   map[interface{}]interface{}{"status": "joined"},
+				"wordpress/0": map[interface{}]interface{}{"status": "waiting", "since": "2017-03-14T10:00:00Z"},
+			},
+		},
+	})
+}
+
+func (s *GoalStateSuite) TestOutputJSON(c *gc.C) {
+	ctx := s.run(c, "--format", "json")
+	var out map[string]interface{}
+	err := json.Unmarshal(bufferBytes(ctx.Stdout), &out)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, jc.DeepEquals, map[string]interface{}{
+		"units": map[string]interface{}{
+			"mysql/0": map[string]interface{}{"status": "active", "since": "2017-03-14T10:00:00Z"},
+			"mysql/1": map[string]interface{}{"status": "dying"},
+		},
+		"relations": map[string]interface{}{
+			"server": map[string]interface{}{
+				"wordpress":   map[string]interface{}{"status": "joined"},
+				"wordpress/0": map[string]interface{}{"status": "waiting", "since": "2017-03-14T10:00:00Z"},
+			},
+		},
+	})
+}
+
+func (s *GoalStateSuite) TestUnexpectedArgs(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	com, err := jujuc.NewCommand(hctx, cmdString("goal-state"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, []string{"foo"})
+	c.Assert(code, gc.Equals, 2)
+	c.Assert(bufferString(ctx.Stderr), gc.Equals, "error: unrecognized args: [\"foo\"]\n")
+}
@@ -110,7 +110,10 @@ type portCommand struct {
 	Protocol   string
 	FromPort   int
 	ToPort     int
+	Endpoints  []string
 	formatFlag string // deprecated
+
+	endpointsFlag string
 }
 
 func (c *portCommand) Info() *cmd.Info {
@@ -119,6 +122,7 @@ func (c *portCommand) Info() *cmd.Info {
 
 func (c *portCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.formatFlag, "format", "", "deprecated format flag")
+	f.StringVar(&c.endpointsFlag, "endpoints", "", "a comma-delimited list of application endpoints to target with this operation")
 }
 
 func (c *portCommand) Init(args []string) error {
@@ -134,6 +138,16 @@ func (c *portCommand) Init(args []string) error {
 	c.FromPort = portRange.fromPort
 	c.ToPort = portRange.toPort
 	c.Protocol = portRange.protocol
+	c.Endpoints = nil
+	if c.endpointsFlag != "" {
+		for _, endpoint := range strings.Split(c.endpointsFlag, ",") {
+			endpoint = strings.TrimSpace(endpoint)
+			if endpoint == "" {
+				return errors.Errorf("invalid endpoints %q", c.endpointsFlag)
+			}
+			c.Endpoints = append(c.Endpoints, endpoint)
+		}
+	}
 	return cmd.CheckEmpty(args[1:])
 }
 
@@ -148,14 +162,29 @@ var openPortInfo = &cmd.Info{
 	Name:    "open-port",
 	Args:    portFormat,
 	Purpose: "register a port or range to open",
-	Doc:     "The port range will only be open while the application is exposed.",
+	Doc: `
+The port range will only be open while the application is exposed.
+
+By default the range is opened for all of the unit's endpoints. If
+--endpoints is given, the range is opened for the listed endpoints only,
+and is accessible from the sources the application was exposed to for
+those endpoints.
+`[1:],
 }
 
 func NewOpenPortCommand(ctx Context) (cmd.Command, error) {
 	return &portCommand{
 		info: openPortInfo,
 		action: func(c *portCommand) error {
-			return ctx.OpenPorts(c.Protocol, c.FromPort, c.ToPort)
+			if len(c.Endpoints) == 0 {
+				return ctx.OpenPorts(c.Protocol, c.FromPort, c.ToPort)
+			}
+			for _, endpoint := range c.Endpoints {
+				if err := ctx.OpenPortsOnEndpoint(endpoint, c.Protocol, c.FromPort, c.ToPort); err != nil {
+					return errors.Trace(err)
+				}
+			}
+			return nil
 		},
 	}, nil
 }
@@ -164,13 +193,27 @@ var closePortInfo = &cmd.Info{
 	Name:    "close-port",
 	Args:    portFormat,
 	Purpose: "ensure a port or range is always closed",
+	Doc: `
+By default the range is closed for all of the unit's endpoints. If
+--endpoints is given, the range is closed for the listed endpoints only.
+A range opened for all endpoints is not closed for particular endpoints,
+nor the other way round.
+`[1:],
 }
 
 func NewClosePortCommand(ctx Context) (cmd.Command, error) {
 	return &portCommand{
 		info: closePortInfo,
 		action: func(c *portCommand) error {
-			return ctx.ClosePorts(c.Protocol, c.FromPort, c.ToPort)
+			if len(c.Endpoints) == 0 {
+				return ctx.ClosePorts(c.Protocol, c.FromPort, c.ToPort)
+			}
+			for _, endpoint := range c.Endpoints {
+				if err := ctx.ClosePortsOnEndpoint(endpoint, c.Protocol, c.FromPort, c.ToPort); err != nil {
+					return errors.Trace(err)
+				}
+			}
+			return nil
 		},
 	}, nil
 }
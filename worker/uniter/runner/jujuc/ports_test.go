@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/juju/cmd"
+	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -99,6 +100,7 @@ var badPortsTests = []struct {
 	{[]string{"9999/foo"}, `protocol must be "tcp" or "udp"; got "foo"`},
 	{[]string{"80-90/http"}, `protocol must be "tcp" or "udp"; got "http"`},
 	{[]string{"20-10/tcp"}, `invalid port range 20-10/tcp; expected fromPort <= toPort`},
+	{[]string{"--endpoints", "db,", "80"}, `invalid endpoints "db,"`},
 }
 
 func (s *PortsSuite) TestBadArgs(c *gc.C) {
@@ -126,6 +128,11 @@ register a port or range to open
 
 Details:
 The port range will only be open while the application is exposed.
+
+By default the range is opened for all of the unit's endpoints. If
+--endpoints is given, the range is opened for the listed endpoints only,
+and is accessible from the sources the application was exposed to for
+those endpoints.
 `[1:])
 
 	close, err := jujuc.NewCommand(hctx, cmdString("close-port"))
@@ -135,9 +142,35 @@ Usage: close-port <port>[/<protocol>] or <from>-<to>[/<protocol>]
 
 Summary:
 ensure a port or range is always closed
+
+Details:
+By default the range is closed for all of the unit's endpoints. If
+--endpoints is given, the range is closed for the listed endpoints only.
+A range opened for all endpoints is not closed for particular endpoints,
+nor the other way round.
 `[1:])
 }
 
+func (s *PortsSuite) TestOpenCloseEndpoints(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	for _, args := range [][]string{
+		{"open-port", "--endpoints", "db, admin", "80"},
+		{"close-port", "--endpoints", "admin", "80"},
+	} {
+		com, err := jujuc.NewCommand(hctx, cmdString(args[0]))
+		c.Assert(err, jc.ErrorIsNil)
+		ctx := testing.Context(c)
+		code := cmd.Main(com, ctx, args[1:])
+		c.Assert(code, gc.Equals, 0)
+		c.Assert(bufferString(ctx.Stderr), gc.Equals, "")
+	}
+	s.Stub.CheckCalls(c, []jujutesting.StubCall{
+		{"OpenPortsOnEndpoint", []interface{}{"db", "tcp", 80, 80}},
+		{"OpenPortsOnEndpoint", []interface{}{"admin", "tcp", 80, 80}},
+		{"ClosePortsOnEndpoint", []interface{}{"admin", "tcp", 80, 80}},
+	})
+}
+
 // Since the deprecation warning gets output during Run, we really need
 // some valid commands to run
 var portsFormatDeprectaionTests = []struct {
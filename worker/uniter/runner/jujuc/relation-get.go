@@ -9,6 +9,7 @@ import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
 )
@@ -21,9 +22,10 @@ type RelationGetCommand struct {
 	RelationId      int
 	relationIdProxy gnuflag.Value
 
-	Key      string
-	UnitName string
-	out      cmd.Output
+	Key         string
+	UnitName    string
+	Application bool
+	out         cmd.Output
 }
 
 func NewRelationGetCommand(ctx Context) (cmd.Command, error) {
@@ -43,6 +45,10 @@ func (c *RelationGetCommand) Info() *cmd.Info {
 	doc := `
 relation-get prints the value of a unit's relation setting, specified by key.
 If no key is given, or if the key is "-", all keys and values will be printed.
+
+With --app, the settings of an application are printed instead. The
+application may be named directly or through one of its units; only the
+leader may read its own application's settings.
 `
 	// There's nothing we can really do about the error here.
 	if name, err := c.ctx.RemoteUnitName(); err == nil {
@@ -64,6 +70,7 @@ func (c *RelationGetCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
 	f.Var(c.relationIdProxy, "r", "specify a relation by id")
 	f.Var(c.relationIdProxy, "relation", "")
+	f.BoolVar(&c.Application, "app", false, "get the settings of an application instead of a unit")
 }
 
 // Init is part of the cmd.Command interface.
@@ -88,19 +95,53 @@ func (c *RelationGetCommand) Init(args []string) error {
 		c.UnitName = args[0]
 		args = args[1:]
 	}
+	if c.Application {
+		return c.initApplication(args)
+	}
 	if c.UnitName == "" {
 		return fmt.Errorf("no unit id specified")
 	}
 	return cmd.CheckEmpty(args)
 }
 
+// initApplication resolves the application whose settings should be
+// printed, which may have been given directly or through a unit name.
+func (c *RelationGetCommand) initApplication(args []string) error {
+	if c.UnitName == "" {
+		name, err := c.ctx.RemoteApplicationName()
+		if err == nil {
+			c.UnitName = name
+		} else if cause := errors.Cause(err); !errors.IsNotFound(cause) {
+			return errors.Trace(err)
+		}
+	} else if names.IsValidUnit(c.UnitName) {
+		name, err := names.UnitApplication(c.UnitName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		c.UnitName = name
+	}
+	if c.UnitName == "" {
+		return fmt.Errorf("no application specified")
+	}
+	if !names.IsValidApplication(c.UnitName) {
+		return fmt.Errorf("invalid application name %q", c.UnitName)
+	}
+	return cmd.CheckEmpty(args)
+}
+
 func (c *RelationGetCommand) Run(ctx *cmd.Context) error {
 	r, err := c.ctx.Relation(c.RelationId)
 	if err != nil {
 		return errors.Trace(err)
 	}
 	var settings params.Settings
-	if c.UnitName == c.ctx.UnitName() {
+	if c.Application {
+		settings, err = c.readApplicationSettings(r)
+		if err != nil {
+			return err
+		}
+	} else if c.UnitName == c.ctx.UnitName() {
 		node, err := r.Settings()
 		if err != nil {
 			return err
@@ -121,3 +162,18 @@ func (c *RelationGetCommand) Run(ctx *cmd.Context) error {
 	}
 	return c.out.Write(ctx, nil)
 }
+
+func (c *RelationGetCommand) readApplicationSettings(r ContextRelation) (params.Settings, error) {
+	localApp, err := names.UnitApplication(c.ctx.UnitName())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if c.UnitName != localApp {
+		return r.ReadApplicationSettings(c.UnitName)
+	}
+	node, err := r.ApplicationSettings()
+	if err != nil {
+		return nil, err
+	}
+	return node.Map(), nil
+}
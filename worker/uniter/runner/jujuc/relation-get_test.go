@@ -29,6 +29,9 @@ func (s *RelationGetSuite) newHookContext(relid int, remote string) (jujuc.Conte
 	info.rels[0].Units["u/0"]["private-address"] = "foo: bar\n"
 	info.rels[1].SetRelated("m/0", jujuctesting.Settings{"pew": "pew\npew\n"})
 	info.rels[1].SetRelated("u/1", jujuctesting.Settings{"value": "12345"})
+	info.rels[0].ApplicationName = "u"
+	info.rels[0].SetRelatedApplication("u", jujuctesting.Settings{"owner": "u"})
+	info.rels[1].SetRelatedApplication("m", jujuctesting.Settings{"owner": "m"})
 	return hctx, info
 }
 
@@ -137,6 +140,45 @@ var relationGetTests = []struct {
 		relid:   1,
 		args:    []string{"missing", "u/1", "--format", "smart"},
 		out:     "",
+	}, {
+		summary: "application, none chosen",
+		relid:   1,
+		args:    []string{"--app"},
+		code:    2,
+		out:     `no application specified`,
+	}, {
+		summary: "application, invalid name",
+		relid:   1,
+		args:    []string{"--app", "-", "bad!"},
+		code:    2,
+		out:     `invalid application name "bad!"`,
+	}, {
+		summary: "application of implicit member",
+		relid:   1,
+		unit:    "m/0",
+		args:    []string{"--app"},
+		out:     "owner: m",
+	}, {
+		summary: "application, specific key with explicit application",
+		relid:   1,
+		args:    []string{"--app", "owner", "m"},
+		out:     "m",
+	}, {
+		summary: "application, specific key with explicit unit",
+		relid:   1,
+		args:    []string{"--app", "owner", "m/0"},
+		out:     "m",
+	}, {
+		summary: "application, all keys with explicit local",
+		relid:   0,
+		args:    []string{"--app", "-", "u"},
+		out:     "owner: u",
+	}, {
+		summary: "application, missing",
+		relid:   1,
+		args:    []string{"--app", "-", "bad"},
+		code:    1,
+		out:     `unknown application bad`,
 	},
 }
 
@@ -222,6 +264,8 @@ Summary:
 get relation settings
 
 Options:
+--app  (= false)
+    get the settings of an application instead of a unit
 --format  (= smart)
     Specify output format (json|smart|yaml)
 -o, --output (= "")
@@ -232,6 +276,10 @@ Options:
 Details:
 relation-get prints the value of a unit's relation setting, specified by key.
 If no key is given, or if the key is "-", all keys and values will be printed.
+
+With --app, the settings of an application are printed instead. The
+application may be named directly or through one of its units; only the
+leader may read its own application's settings.
 %s`[1:]
 
 var relationGetHelpTests = []struct {
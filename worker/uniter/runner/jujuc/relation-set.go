@@ -27,6 +27,9 @@ too long to fit within the command length limit of the shell or
 operating system. The file will contain a YAML map containing the
 settings.  Settings in the file will be overridden by any duplicate
 key-value arguments. A value of "-" for the filename means <stdin>.
+
+The --app option writes the settings of the local application instead
+of those of the local unit. Only the leader may do so.
 `
 
 // RelationSetCommand implements the relation-set command.
@@ -37,6 +40,7 @@ type RelationSetCommand struct {
 	relationIdProxy gnuflag.Value
 	Settings        map[string]string
 	settingsFile    cmd.FileVar
+	Application     bool
 	formatFlag      string // deprecated
 }
 
@@ -68,6 +72,8 @@ func (c *RelationSetCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.settingsFile.SetStdin()
 	f.Var(&c.settingsFile, "file", "file containing key-value pairs")
 
+	f.BoolVar(&c.Application, "app", false, "set the settings of the local application instead of the unit")
+
 	f.StringVar(&c.formatFlag, "format", "", "deprecated format flag")
 }
 
@@ -135,7 +141,12 @@ func (c *RelationSetCommand) Run(ctx *cmd.Context) (err error) {
 	if err != nil {
 		return errors.Trace(err)
 	}
-	settings, err := r.Settings()
+	var settings Settings
+	if c.Application {
+		settings, err = r.ApplicationSettings()
+	} else {
+		settings, err = r.Settings()
+	}
 	if err != nil {
 		return errors.Annotate(err, "cannot read relation settings")
 	}
@@ -46,6 +46,8 @@ Summary:
 set relation settings
 
 Options:
+--app  (= false)
+    set the settings of the local application instead of the unit
 --file  (= )
     file containing key-value pairs
 --format (= "")
@@ -65,6 +67,9 @@ too long to fit within the command length limit of the shell or
 operating system. The file will contain a YAML map containing the
 settings.  Settings in the file will be overridden by any duplicate
 key-value arguments. A value of "-" for the filename means <stdin>.
+
+The --app option writes the settings of the local application instead
+of those of the local unit. Only the leader may do so.
 `[1:], t.expect))
 		c.Assert(bufferString(ctx.Stderr), gc.Equals, "")
 	}
@@ -373,6 +378,23 @@ func (s *RelationSetSuite) TestRun(c *gc.C) {
 	}
 }
 
+func (s *RelationSetSuite) TestRunApplication(c *gc.C) {
+	hctx, info := s.newHookContext(0, "")
+	unit := jujuctesting.Settings{"base": "value"}
+	info.rels[1].Units["u/0"] = unit
+	app := jujuctesting.Settings{"base": "value"}
+	info.rels[1].ApplicationName = "u"
+	info.rels[1].SetRelatedApplication("u", app)
+
+	com, err := jujuc.NewCommand(hctx, cmdString("relation-set"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = testing.RunCommand(c, com, "-r", "1", "--app", "foo=bar")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(info.rels[1].Applications["u"], gc.DeepEquals, jujuctesting.Settings{"base": "value", "foo": "bar"})
+	c.Assert(info.rels[1].Units["u/0"], gc.DeepEquals, jujuctesting.Settings{"base": "value"})
+}
+
 func (s *RelationSetSuite) TestRunDeprecationWarning(c *gc.C) {
 	hctx, _ := s.newHookContext(0, "")
 	com, _ := jujuc.NewCommand(hctx, cmdString("relation-set"))
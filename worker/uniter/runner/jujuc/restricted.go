@@ -25,6 +25,12 @@ type RestrictedContext struct{}
 // ConfigSettings implements jujuc.Context.
 func (*RestrictedContext) ConfigSettings() (charm.Settings, error) { return nil, ErrRestrictedContext }
 
+// GoalState implements jujuc.Context.
+func (*RestrictedContext) GoalState() (*params.GoalState, error) { return nil, ErrRestrictedContext }
+
+// CloudSpec implements jujuc.Context.
+func (*RestrictedContext) CloudSpec() (*params.CloudSpec, error) { return nil, ErrRestrictedContext }
+
 // UnitStatus implements jujuc.Context.
 func (*RestrictedContext) UnitStatus() (*StatusInfo, error) { return nil, ErrRestrictedContext }
 
@@ -61,9 +67,24 @@ func (*RestrictedContext) ClosePorts(protocol string, fromPort, toPort int) erro
 	return ErrRestrictedContext
 }
 
+// OpenPortsOnEndpoint implements jujuc.Context.
+func (*RestrictedContext) OpenPortsOnEndpoint(endpoint, protocol string, fromPort, toPort int) error {
+	return ErrRestrictedContext
+}
+
+// ClosePortsOnEndpoint implements jujuc.Context.
+func (*RestrictedContext) ClosePortsOnEndpoint(endpoint, protocol string, fromPort, toPort int) error {
+	return ErrRestrictedContext
+}
+
 // OpenedPorts implements jujuc.Context.
 func (*RestrictedContext) OpenedPorts() []network.PortRange { return nil }
 
+// SetEgressRules implements jujuc.Context.
+func (*RestrictedContext) SetEgressRules(rules []network.EgressRule) error {
+	return ErrRestrictedContext
+}
+
 // NetworkConfig implements jujuc.Context.
 func (*RestrictedContext) NetworkConfig(bindingName string) ([]params.NetworkConfig, error) {
 	return nil, ErrRestrictedContext
@@ -117,6 +138,11 @@ func (*RestrictedContext) HookRelation() (ContextRelation, error) {
 // RemoteUnitName implements jujuc.Context.
 func (*RestrictedContext) RemoteUnitName() (string, error) { return "", ErrRestrictedContext }
 
+// RemoteApplicationName implements jujuc.Context.
+func (*RestrictedContext) RemoteApplicationName() (string, error) {
+	return "", ErrRestrictedContext
+}
+
 // ActionParams implements jujuc.Context.
 func (*RestrictedContext) ActionParams() (map[string]interface{}, error) {
 	return nil, ErrRestrictedContext
@@ -130,6 +156,9 @@ func (*RestrictedContext) UpdateActionResults(keys []string, value string) error
 // SetActionMessage implements jujuc.Context.
 func (*RestrictedContext) SetActionMessage(string) error { return ErrRestrictedContext }
 
+// LogActionMessage implements jujuc.Context.
+func (*RestrictedContext) LogActionMessage(string) error { return ErrRestrictedContext }
+
 // SetActionFailed implements jujuc.Context.
 func (*RestrictedContext) SetActionFailed() error { return ErrRestrictedContext }
 
@@ -147,3 +176,28 @@ func (*RestrictedContext) UnitWorkloadVersion() (string, error) {
 func (*RestrictedContext) SetUnitWorkloadVersion(string) error {
 	return ErrRestrictedContext
 }
+
+// GetCharmState implements jujuc.Context.
+func (*RestrictedContext) GetCharmState() (map[string]string, error) {
+	return nil, ErrRestrictedContext
+}
+
+// GetCharmStateValue implements jujuc.Context.
+func (*RestrictedContext) GetCharmStateValue(string) (string, error) {
+	return "", ErrRestrictedContext
+}
+
+// SetCharmStateValue implements jujuc.Context.
+func (*RestrictedContext) SetCharmStateValue(string, string) error {
+	return ErrRestrictedContext
+}
+
+// DeleteCharmStateValue implements jujuc.Context.
+func (*RestrictedContext) DeleteCharmStateValue(string) error {
+	return ErrRestrictedContext
+}
+
+// GetSecret implements jujuc.Context.
+func (*RestrictedContext) GetSecret(string) (map[string]string, error) {
+	return nil, ErrRestrictedContext
+}
@@ -0,0 +1,81 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// SecretGetCommand implements the secret-get command.
+type SecretGetCommand struct {
+	cmd.CommandBase
+	ctx Context
+	out cmd.Output
+
+	name string
+	key  string
+}
+
+// NewSecretGetCommand returns a secret-get command.
+func NewSecretGetCommand(ctx Context) (cmd.Command, error) {
+	return &SecretGetCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *SecretGetCommand) Info() *cmd.Info {
+	doc := `
+secret-get prints the value of the named secret, specified by key. If no
+key is given, all keys and values will be printed.
+
+The secret must have been granted to the unit's application with
+"juju grant-secret". When a secret is rotated, the secret-changed hook
+runs with the secret's name in $JUJU_SECRET_NAME.
+`
+	return &cmd.Info{
+		Name:    "secret-get",
+		Args:    "<name> [<key>]",
+		Purpose: "print secret values",
+		Doc:     doc,
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *SecretGetCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
+}
+
+// Init is part of the cmd.Command interface.
+func (c *SecretGetCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no secret name specified")
+	}
+	c.name, c.key = args[0], ""
+	if len(args) > 1 {
+		c.key = args[1]
+		args = args[1:]
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run is part of the cmd.Command interface.
+func (c *SecretGetCommand) Run(ctx *cmd.Context) error {
+	values, err := c.ctx.GetSecret(c.name)
+	if params.IsCodeUnauthorized(err) {
+		return errors.Errorf("cannot read secret %q: secret has not been granted to this application", c.name)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot read secret %q", c.name)
+	}
+	if c.key == "" {
+		return c.out.Write(ctx, values)
+	}
+	value, ok := values[c.key]
+	if !ok {
+		return errors.Errorf("key %q not found in secret %q", c.key, c.name)
+	}
+	return c.out.Write(ctx, value)
+}
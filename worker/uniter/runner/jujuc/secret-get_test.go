@@ -0,0 +1,89 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type SecretGetSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&SecretGetSuite{})
+
+var secretGetTests = []struct {
+	summary string
+	args    []string
+	code    int
+	out     string
+	err     string
+}{{
+	summary: "all keys",
+	args:    []string{"db-password"},
+	out:     "password: sekrit\nusername: admin\n",
+}, {
+	summary: "all keys as json",
+	args:    []string{"--format", "json", "db-password"},
+	out:     `{"password":"sekrit","username":"admin"}` + "\n",
+}, {
+	summary: "single key",
+	args:    []string{"db-password", "password"},
+	out:     "sekrit\n",
+}, {
+	summary: "missing key",
+	args:    []string{"db-password", "token"},
+	code:    1,
+	err:     "error: key \"token\" not found in secret \"db-password\"\n",
+}, {
+	summary: "missing secret",
+	args:    []string{"api-token"},
+	code:    1,
+	err:     "error: cannot read secret \"api-token\": secret \"api-token\" not found\n",
+}, {
+	summary: "no name",
+	code:    2,
+	err:     "error: no secret name specified\n",
+}, {
+	summary: "too many arguments",
+	args:    []string{"db-password", "password", "username"},
+	code:    2,
+	err:     "error: unrecognized args: [\"username\"]\n",
+}}
+
+func (s *SecretGetSuite) TestSecretGet(c *gc.C) {
+	for i, t := range secretGetTests {
+		c.Logf("test %d: %s", i, t.summary)
+		hctx := s.GetHookContext(c, -1, "")
+		hctx.info.Secrets.Secrets = map[string]map[string]string{
+			"db-password": {"username": "admin", "password": "sekrit"},
+		}
+		com, err := jujuc.NewCommand(hctx, cmdString("secret-get"))
+		c.Assert(err, jc.ErrorIsNil)
+		ctx := testing.Context(c)
+		code := cmd.Main(com, ctx, t.args)
+		c.Check(code, gc.Equals, t.code)
+		c.Check(bufferString(ctx.Stdout), gc.Equals, t.out)
+		c.Check(bufferString(ctx.Stderr), gc.Equals, t.err)
+	}
+}
+
+func (s *SecretGetSuite) TestSecretGetNotGranted(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	s.Stub.SetErrors(errors.Trace(&params.Error{Code: params.CodeUnauthorized}))
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-get"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, []string{"db-password"})
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals,
+		"error: cannot read secret \"db-password\": secret has not been granted to this application\n")
+}
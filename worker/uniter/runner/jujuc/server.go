@@ -52,6 +52,7 @@ var baseCommands = map[string]creator{
 	"action-get" + cmdSuffix:              NewActionGetCommand,
 	"action-set" + cmdSuffix:              NewActionSetCommand,
 	"action-fail" + cmdSuffix:             NewActionFailCommand,
+	"action-log" + cmdSuffix:              NewActionLogCommand,
 	"relation-ids" + cmdSuffix:            NewRelationIdsCommand,
 	"relation-list" + cmdSuffix:           NewRelationListCommand,
 	"relation-set" + cmdSuffix:            NewRelationSetCommand,
@@ -61,7 +62,14 @@ var baseCommands = map[string]creator{
 	"status-get" + cmdSuffix:              NewStatusGetCommand,
 	"status-set" + cmdSuffix:              NewStatusSetCommand,
 	"network-get" + cmdSuffix:             NewNetworkGetCommand,
+	"goal-state" + cmdSuffix:              NewGoalStateCommand,
+	"credential-get" + cmdSuffix:          NewCredentialGetCommand,
 	"application-version-set" + cmdSuffix: NewApplicationVersionSetCommand,
+	"state-get" + cmdSuffix:               NewStateGetCommand,
+	"state-set" + cmdSuffix:               NewStateSetCommand,
+	"state-delete" + cmdSuffix:            NewStateDeleteCommand,
+	"secret-get" + cmdSuffix:              NewSecretGetCommand,
+	"egress-rules-set" + cmdSuffix:        NewEgressRulesSetCommand,
 }
 
 var storageCommands = map[string]creator{
@@ -253,6 +253,8 @@ var newCommandTests = []struct {
 }{
 	{"close-port", ""},
 	{"config-get", ""},
+	{"goal-state", ""},
+	{"credential-get", ""},
 	{"juju-log", ""},
 	{"open-port", ""},
 	{"opened-ports", ""},
@@ -265,6 +267,11 @@ var newCommandTests = []struct {
 	{"storage-get", ""},
 	{"status-get", ""},
 	{"status-set", ""},
+	{"state-get", ""},
+	{"state-set", ""},
+	{"state-delete", ""},
+	{"secret-get", ""},
+	{"egress-rules-set", ""},
 	// The error message contains .exe on Windows
 	{"random", "unknown command: random(.exe)?"},
 }
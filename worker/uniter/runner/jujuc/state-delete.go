@@ -0,0 +1,56 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+)
+
+// StateDeleteCommand implements the state-delete command.
+type StateDeleteCommand struct {
+	cmd.CommandBase
+	ctx Context
+
+	keys []string
+}
+
+// NewStateDeleteCommand returns a state-delete command.
+func NewStateDeleteCommand(ctx Context) (cmd.Command, error) {
+	return &StateDeleteCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *StateDeleteCommand) Info() *cmd.Info {
+	doc := `
+state-delete removes the given keys from the charm state for this unit.
+Keys that are not set are ignored. The changes are written to the
+controller when the hook completes successfully.
+`
+	return &cmd.Info{
+		Name:    "state-delete",
+		Args:    "<key> [...]",
+		Purpose: "delete charm state",
+		Doc:     doc,
+	}
+}
+
+// Init is part of the cmd.Command interface.
+func (c *StateDeleteCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no keys specified")
+	}
+	c.keys = args
+	return nil
+}
+
+// Run is part of the cmd.Command interface.
+func (c *StateDeleteCommand) Run(_ *cmd.Context) error {
+	for _, key := range c.keys {
+		if err := c.ctx.DeleteCharmStateValue(key); err != nil {
+			return errors.Annotate(err, "cannot delete charm state")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type StateDeleteSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&StateDeleteSuite{})
+
+func (s *StateDeleteSuite) TestDelete(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	hctx.info.CharmState.CharmState = map[string]string{"one": "1", "two": "2"}
+	com, err := jujuc.NewCommand(hctx, cmdString("state-delete"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, []string{"one", "three"})
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	c.Check(hctx.info.CharmState.CharmState, jc.DeepEquals, map[string]string{"two": "2"})
+}
+
+func (s *StateDeleteSuite) TestNoArguments(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	com, err := jujuc.NewCommand(hctx, cmdString("state-delete"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, nil)
+	c.Check(code, gc.Equals, 2)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "error: no keys specified\n")
+}
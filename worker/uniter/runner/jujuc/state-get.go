@@ -0,0 +1,82 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+)
+
+// StateGetCommand implements the state-get command.
+type StateGetCommand struct {
+	cmd.CommandBase
+	ctx Context
+	out cmd.Output
+
+	key    string
+	strict bool
+}
+
+// NewStateGetCommand returns a state-get command.
+func NewStateGetCommand(ctx Context) (cmd.Command, error) {
+	return &StateGetCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *StateGetCommand) Info() *cmd.Info {
+	doc := `
+state-get prints the value of the charm state for this unit, specified
+by key. If no key is given, or if the key is "-", all keys and values
+will be printed.
+
+The charm state is stored in the controller, so it survives the unit
+being redeployed or its model being migrated.
+`
+	return &cmd.Info{
+		Name:    "state-get",
+		Args:    "[<key>]",
+		Purpose: "print charm state",
+		Doc:     doc,
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *StateGetCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
+	f.BoolVar(&c.strict, "strict", false, "return an error if the requested key does not exist")
+}
+
+// Init is part of the cmd.Command interface.
+func (c *StateGetCommand) Init(args []string) error {
+	c.key = ""
+	if len(args) == 0 {
+		return nil
+	}
+	if c.key = args[0]; c.key == "-" {
+		c.key = ""
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run is part of the cmd.Command interface.
+func (c *StateGetCommand) Run(ctx *cmd.Context) error {
+	if c.key == "" {
+		charmState, err := c.ctx.GetCharmState()
+		if err != nil {
+			return errors.Annotate(err, "cannot read charm state")
+		}
+		return c.out.Write(ctx, charmState)
+	}
+	value, err := c.ctx.GetCharmStateValue(c.key)
+	if errors.IsNotFound(err) {
+		if c.strict {
+			return errors.Errorf("key %q not found", c.key)
+		}
+		return c.out.Write(ctx, nil)
+	} else if err != nil {
+		return errors.Annotate(err, "cannot read charm state")
+	}
+	return c.out.Write(ctx, value)
+}
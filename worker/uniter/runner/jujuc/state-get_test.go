@@ -0,0 +1,79 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type StateGetSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&StateGetSuite{})
+
+var stateGetTests = []struct {
+	summary string
+	args    []string
+	code    int
+	out     string
+	err     string
+}{{
+	summary: "all keys",
+	out:     "one: \"1\"\ntwo: \"2\"\n",
+}, {
+	summary: "all keys with dash",
+	args:    []string{"-"},
+	out:     "one: \"1\"\ntwo: \"2\"\n",
+}, {
+	summary: "single key",
+	args:    []string{"one"},
+	out:     "1\n",
+}, {
+	summary: "missing key",
+	args:    []string{"three"},
+	out:     "",
+}, {
+	summary: "missing key, strict",
+	args:    []string{"--strict", "three"},
+	code:    1,
+	err:     "error: key \"three\" not found\n",
+}, {
+	summary: "too many arguments",
+	args:    []string{"one", "two"},
+	code:    2,
+	err:     "error: unrecognized args: [\"two\"]\n",
+}}
+
+func (s *StateGetSuite) TestStateGet(c *gc.C) {
+	for i, t := range stateGetTests {
+		c.Logf("test %d: %s", i, t.summary)
+		hctx := s.GetHookContext(c, -1, "")
+		hctx.info.CharmState.CharmState = map[string]string{"one": "1", "two": "2"}
+		com, err := jujuc.NewCommand(hctx, cmdString("state-get"))
+		c.Assert(err, jc.ErrorIsNil)
+		ctx := testing.Context(c)
+		code := cmd.Main(com, ctx, t.args)
+		c.Check(code, gc.Equals, t.code)
+		c.Check(bufferString(ctx.Stdout), gc.Equals, t.out)
+		c.Check(bufferString(ctx.Stderr), gc.Equals, t.err)
+	}
+}
+
+func (s *StateGetSuite) TestStateGetError(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	s.Stub.SetErrors(errors.New("boom"))
+	com, err := jujuc.NewCommand(hctx, cmdString("state-get"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, nil)
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "error: cannot read charm state: boom\n")
+}
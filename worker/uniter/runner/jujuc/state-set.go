@@ -0,0 +1,64 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/utils/keyvalues"
+)
+
+// StateSetCommand implements the state-set command.
+type StateSetCommand struct {
+	cmd.CommandBase
+	ctx Context
+
+	settings map[string]string
+}
+
+// NewStateSetCommand returns a state-set command.
+func NewStateSetCommand(ctx Context) (cmd.Command, error) {
+	return &StateSetCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *StateSetCommand) Info() *cmd.Info {
+	doc := `
+state-set sets the supplied key/value pairs in the charm state for this
+unit. Setting an empty value removes the key. The changes are written to
+the controller when the hook completes successfully, and will fail the
+hook if the state would exceed the controller's max-charm-state-size.
+`
+	return &cmd.Info{
+		Name:    "state-set",
+		Args:    "<key>=<value> [...]",
+		Purpose: "set charm state",
+		Doc:     doc,
+	}
+}
+
+// Init is part of the cmd.Command interface.
+func (c *StateSetCommand) Init(args []string) (err error) {
+	if len(args) == 0 {
+		return errors.New("no key/value pairs specified")
+	}
+	c.settings, err = keyvalues.Parse(args, true)
+	return
+}
+
+// Run is part of the cmd.Command interface.
+func (c *StateSetCommand) Run(_ *cmd.Context) error {
+	for key, value := range c.settings {
+		var err error
+		if value == "" {
+			err = c.ctx.DeleteCharmStateValue(key)
+		} else {
+			err = c.ctx.SetCharmStateValue(key, value)
+		}
+		if err != nil {
+			return errors.Annotate(err, "cannot set charm state")
+		}
+	}
+	return nil
+}
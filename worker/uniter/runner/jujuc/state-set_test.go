@@ -0,0 +1,52 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type StateSetSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&StateSetSuite{})
+
+func (s *StateSetSuite) run(c *gc.C, args ...string) (*Context, *cmd.Context, int) {
+	hctx := s.GetHookContext(c, -1, "")
+	hctx.info.CharmState.CharmState = map[string]string{"one": "1", "two": "2"}
+	com, err := jujuc.NewCommand(hctx, cmdString("state-set"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, args)
+	return hctx, ctx, code
+}
+
+func (s *StateSetSuite) TestSetValues(c *gc.C) {
+	hctx, ctx, code := s.run(c, "one=uno", "three=3", "two=")
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	c.Check(hctx.info.CharmState.CharmState, jc.DeepEquals, map[string]string{
+		"one":   "uno",
+		"three": "3",
+	})
+}
+
+func (s *StateSetSuite) TestNoArguments(c *gc.C) {
+	hctx, ctx, code := s.run(c)
+	c.Check(code, gc.Equals, 2)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "error: no key/value pairs specified\n")
+	c.Check(hctx.info.CharmState.CharmState, gc.HasLen, 2)
+}
+
+func (s *StateSetSuite) TestBadArgument(c *gc.C) {
+	_, ctx, code := s.run(c, "nonsense")
+	c.Check(code, gc.Equals, 2)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "error: expected \"key=value\", got \"nonsense\"\n")
+}
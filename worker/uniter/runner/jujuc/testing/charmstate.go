@@ -0,0 +1,68 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package testing
+
+import (
+	"github.com/juju/errors"
+)
+
+// CharmState holds values for the hook context.
+type CharmState struct {
+	CharmState map[string]string
+}
+
+// ContextCharmState is a test double for jujuc.ContextCharmState.
+type ContextCharmState struct {
+	contextBase
+	info *CharmState
+}
+
+// GetCharmState implements jujuc.ContextCharmState.
+func (c *ContextCharmState) GetCharmState() (map[string]string, error) {
+	c.stub.AddCall("GetCharmState")
+	if err := c.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make(map[string]string, len(c.info.CharmState))
+	for key, value := range c.info.CharmState {
+		result[key] = value
+	}
+	return result, nil
+}
+
+// GetCharmStateValue implements jujuc.ContextCharmState.
+func (c *ContextCharmState) GetCharmStateValue(key string) (string, error) {
+	c.stub.AddCall("GetCharmStateValue", key)
+	if err := c.stub.NextErr(); err != nil {
+		return "", errors.Trace(err)
+	}
+	value, ok := c.info.CharmState[key]
+	if !ok {
+		return "", errors.NotFoundf("%q", key)
+	}
+	return value, nil
+}
+
+// SetCharmStateValue implements jujuc.ContextCharmState.
+func (c *ContextCharmState) SetCharmStateValue(key, value string) error {
+	c.stub.AddCall("SetCharmStateValue", key, value)
+	if err := c.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+	if c.info.CharmState == nil {
+		c.info.CharmState = make(map[string]string)
+	}
+	c.info.CharmState[key] = value
+	return nil
+}
+
+// DeleteCharmStateValue implements jujuc.ContextCharmState.
+func (c *ContextCharmState) DeleteCharmStateValue(key string) error {
+	c.stub.AddCall("DeleteCharmStateValue", key)
+	if err := c.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+	delete(c.info.CharmState, key)
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 
 	"github.com/juju/testing"
+	"gopkg.in/juju/names.v2"
 )
 
 // ContextInfo holds the values for the hook context.
@@ -23,6 +24,8 @@ type ContextInfo struct {
 	RelationHook
 	ActionHook
 	Version
+	CharmState
+	Secrets
 }
 
 // Context returns a Context that wraps the info.
@@ -38,6 +41,10 @@ func (info *ContextInfo) SetAsRelationHook(id int, remote string) {
 	}
 	info.HookRelation = relation
 	info.RemoteUnitName = remote
+	info.RemoteApplicationName = ""
+	if names.IsValidUnit(remote) {
+		info.RemoteApplicationName, _ = names.UnitApplication(remote)
+	}
 }
 
 // SetAsActionHook updates the context to work as an action hook context.
@@ -63,6 +70,8 @@ type Context struct {
 	ContextRelationHook
 	ContextActionHook
 	ContextVersion
+	ContextCharmState
+	ContextSecrets
 }
 
 // NewContext builds a jujuc.Context test double.
@@ -92,5 +101,9 @@ func NewContext(stub *testing.Stub, info *ContextInfo) *Context {
 	ctx.ContextActionHook.info = &info.ActionHook
 	ctx.ContextVersion.stub = stub
 	ctx.ContextVersion.info = &info.Version
+	ctx.ContextCharmState.stub = stub
+	ctx.ContextCharmState.info = &info.CharmState
+	ctx.ContextSecrets.stub = stub
+	ctx.ContextSecrets.info = &info.Secrets
 	return &ctx
 }
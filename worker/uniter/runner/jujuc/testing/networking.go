@@ -17,6 +17,7 @@ type NetworkInterface struct {
 	PublicAddress            string
 	PrivateAddress           string
 	Ports                    []network.PortRange
+	EgressRules              []network.EgressRule
 	BindingsToNetworkConfigs map[string][]params.NetworkConfig
 }
 
@@ -95,6 +96,28 @@ func (c *ContextNetworking) ClosePorts(protocol string, from, to int) error {
 	return nil
 }
 
+// OpenPortsOnEndpoint implements jujuc.ContextNetworking.
+func (c *ContextNetworking) OpenPortsOnEndpoint(endpoint, protocol string, from, to int) error {
+	c.stub.AddCall("OpenPortsOnEndpoint", endpoint, protocol, from, to)
+	if err := c.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	c.info.AddPorts(protocol, from, to)
+	return nil
+}
+
+// ClosePortsOnEndpoint implements jujuc.ContextNetworking.
+func (c *ContextNetworking) ClosePortsOnEndpoint(endpoint, protocol string, from, to int) error {
+	c.stub.AddCall("ClosePortsOnEndpoint", endpoint, protocol, from, to)
+	if err := c.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	c.info.RemovePorts(protocol, from, to)
+	return nil
+}
+
 // OpenedPorts implements jujuc.ContextNetworking.
 func (c *ContextNetworking) OpenedPorts() []network.PortRange {
 	c.stub.AddCall("OpenedPorts")
@@ -103,6 +126,17 @@ func (c *ContextNetworking) OpenedPorts() []network.PortRange {
 	return c.info.Ports
 }
 
+// SetEgressRules implements jujuc.ContextNetworking.
+func (c *ContextNetworking) SetEgressRules(rules []network.EgressRule) error {
+	c.stub.AddCall("SetEgressRules", rules)
+	if err := c.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	c.info.EgressRules = rules
+	return nil
+}
+
 // NetworkConfig implements jujuc.ContextNetworking.
 func (c *ContextNetworking) NetworkConfig(bindingName string) ([]params.NetworkConfig, error) {
 	c.stub.AddCall("NetworkConfig", bindingName)
@@ -23,11 +23,24 @@ type Relation struct {
 	Units map[string]Settings
 	// UnitName is data for jujuc.ContextRelation.
 	UnitName string
+	// Applications is data for jujuc.ContextRelation.
+	Applications map[string]Settings
+	// ApplicationName is data for jujuc.ContextRelation.
+	ApplicationName string
 }
 
 // Reset clears the Relation's settings.
 func (r *Relation) Reset() {
 	r.Units = nil
+	r.Applications = nil
+}
+
+// SetRelatedApplication adds the relation settings for the application.
+func (r *Relation) SetRelatedApplication(name string, settings Settings) {
+	if r.Applications == nil {
+		r.Applications = make(map[string]Settings)
+	}
+	r.Applications[name] = settings
 }
 
 // SetRelated adds the relation settings for the unit.
@@ -108,3 +121,31 @@ func (r *ContextRelation) ReadSettings(name string) (params.Settings, error) {
 	}
 	return s.Map(), nil
 }
+
+// ApplicationSettings implements jujuc.ContextRelation.
+func (r *ContextRelation) ApplicationSettings() (jujuc.Settings, error) {
+	r.stub.AddCall("ApplicationSettings")
+	if err := r.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	settings, ok := r.info.Applications[r.info.ApplicationName]
+	if !ok {
+		return nil, errors.Errorf("no settings for %q", r.info.ApplicationName)
+	}
+	return settings, nil
+}
+
+// ReadApplicationSettings implements jujuc.ContextRelation.
+func (r *ContextRelation) ReadApplicationSettings(name string) (params.Settings, error) {
+	r.stub.AddCall("ReadApplicationSettings", name)
+	if err := r.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	s, found := r.info.Applications[name]
+	if !found {
+		return nil, fmt.Errorf("unknown application %s", name)
+	}
+	return s.Map(), nil
+}
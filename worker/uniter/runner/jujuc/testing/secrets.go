@@ -0,0 +1,36 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package testing
+
+import (
+	"github.com/juju/errors"
+)
+
+// Secrets holds the values for the hook context.
+type Secrets struct {
+	Secrets map[string]map[string]string
+}
+
+// ContextSecrets is a test double for jujuc.ContextSecrets.
+type ContextSecrets struct {
+	contextBase
+	info *Secrets
+}
+
+// GetSecret implements jujuc.ContextSecrets.
+func (c *ContextSecrets) GetSecret(name string) (map[string]string, error) {
+	c.stub.AddCall("GetSecret", name)
+	if err := c.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	values, ok := c.info.Secrets[name]
+	if !ok {
+		return nil, errors.NotFoundf("secret %q", name)
+	}
+	result := make(map[string]string, len(values))
+	for key, value := range values {
+		result[key] = value
+	}
+	return result, nil
+}
@@ -19,6 +19,7 @@ import (
 	utilexec "github.com/juju/utils/exec"
 	jujuos "github.com/juju/utils/os"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/actions"
 	"github.com/juju/juju/worker/uniter/runner/context"
 	"github.com/juju/juju/worker/uniter/runner/debug"
@@ -52,6 +53,7 @@ type Context interface {
 	SetProcess(process context.HookProcess)
 	HasExecutionSetUnitStatus() bool
 	ResetExecutionSetUnitStatus()
+	RecordExecution(execution params.HookExecution)
 
 	Prepare() error
 	Flush(badge string, failure error) error
@@ -74,14 +76,15 @@ func (runner *runner) Context() Context {
 
 // RunCommands exists to satisfy the Runner interface.
 func (runner *runner) RunCommands(commands string) (*utilexec.ExecResponse, error) {
-	result, err := runner.runCommandsWithTimeout(commands, 0, clock.WallClock)
+	result, err := runner.runCommandsWithTimeout(commands, 0, nil, clock.WallClock, nil)
 	return result, runner.context.Flush("run commands", err)
 }
 
 // runCommandsWithTimeout is a helper to abstract common code between run commands and
-// juju-run as an action
-func (runner *runner) runCommandsWithTimeout(commands string, timeout time.Duration, clock clock.Clock) (*utilexec.ExecResponse, error) {
-	srv, err := runner.startJujucServer()
+// juju-run as an action. The commands are killed if the timeout expires, or
+// if abort is closed. Hook tool calls are recorded in toolCalls if it is not nil.
+func (runner *runner) runCommandsWithTimeout(commands string, timeout time.Duration, abort <-chan struct{}, clock clock.Clock, toolCalls *toolCallRecorder) (*utilexec.ExecResponse, error) {
+	srv, err := runner.startJujucServer(toolCalls)
 	if err != nil {
 		return nil, err
 	}
@@ -105,10 +108,21 @@ func (runner *runner) runCommandsWithTimeout(commands string, timeout time.Durat
 	runner.context.SetProcess(hookProcess{command.Process()})
 
 	var cancel chan struct{}
-	if timeout != 0 {
+	if timeout != 0 || abort != nil {
 		cancel = make(chan struct{})
+		done := make(chan struct{})
+		defer close(done)
 		go func() {
-			<-clock.After(timeout)
+			var timedOut <-chan time.Time
+			if timeout != 0 {
+				timedOut = clock.After(timeout)
+			}
+			select {
+			case <-timedOut:
+			case <-abort:
+			case <-done:
+				return
+			}
 			close(cancel)
 		}()
 	}
@@ -119,23 +133,45 @@ func (runner *runner) runCommandsWithTimeout(commands string, timeout time.Durat
 
 // runJujuRunAction is the function that executes when a juju-run action is ran.
 func (runner *runner) runJujuRunAction() (err error) {
-	params, err := runner.context.ActionParams()
+	actionParams, err := runner.context.ActionParams()
 	if err != nil {
 		return errors.Trace(err)
 	}
-	command, ok := params["command"].(string)
+	command, ok := actionParams["command"].(string)
 	if !ok {
 		return errors.New("no command parameter to juju-run action")
 	}
 
 	// The timeout is passed in in nanoseconds(which are represented in go as int64)
 	// But due to serialization it comes out as float64
-	timeout, ok := params["timeout"].(float64)
+	timeout, ok := actionParams["timeout"].(float64)
 	if !ok {
 		logger.Debugf("unable to read juju-run action timeout, will continue running action without one")
 	}
 
-	results, err := runner.runCommandsWithTimeout(command, time.Duration(timeout), clock.WallClock)
+	abort := make(chan struct{})
+	stop := runner.watchAction(func() { close(abort) })
+	toolCalls := newToolCallRecorder(clock.WallClock)
+	started := clock.WallClock.Now()
+	results, err := runner.runCommandsWithTimeout(command, time.Duration(timeout), abort, clock.WallClock, toolCalls)
+	stop()
+
+	calls, dropped := toolCalls.recorded()
+	execution := params.HookExecution{
+		Kind:             "action",
+		Name:             actions.JujuRunActionName,
+		Started:          started,
+		Finished:         clock.WallClock.Now(),
+		ExitCode:         -1,
+		ToolCalls:        calls,
+		DroppedToolCalls: dropped,
+	}
+	if err != nil {
+		execution.Error = err.Error()
+	} else {
+		execution.ExitCode = results.Code
+	}
+	runner.context.RecordExecution(execution)
 
 	if err != nil {
 		return runner.context.Flush("juju-run", err)
@@ -204,7 +240,8 @@ func (runner *runner) RunHook(hookName string) error {
 }
 
 func (runner *runner) runCharmHookWithLocation(hookName, charmLocation string) error {
-	srv, err := runner.startJujucServer()
+	toolCalls := newToolCallRecorder(clock.WallClock)
+	srv, err := runner.startJujucServer(toolCalls)
 	if err != nil {
 		return err
 	}
@@ -221,6 +258,7 @@ func (runner *runner) runCharmHookWithLocation(hookName, charmLocation string) e
 		env = mergeWindowsEnvironment(env, os.Environ())
 	}
 
+	started := clock.WallClock.Now()
 	debugctx := debug.NewHooksContext(runner.context.UnitName())
 	if session, _ := debugctx.FindSession(); session != nil && session.MatchHook(hookName) {
 		logger.Infof("executing %s via debug-hooks", hookName)
@@ -228,6 +266,25 @@ func (runner *runner) runCharmHookWithLocation(hookName, charmLocation string) e
 	} else {
 		err = runner.runCharmHook(hookName, env, charmLocation)
 	}
+	if !context.IsMissingHookError(err) {
+		calls, dropped := toolCalls.recorded()
+		execution := params.HookExecution{
+			Kind:             "hook",
+			Name:             hookName,
+			Started:          started,
+			Finished:         clock.WallClock.Now(),
+			ExitCode:         exitCode(err),
+			ToolCalls:        calls,
+			DroppedToolCalls: dropped,
+		}
+		if charmLocation == "actions" {
+			execution.Kind = "action"
+		}
+		if err != nil {
+			execution.Error = err.Error()
+		}
+		runner.context.RecordExecution(execution)
+	}
 	return runner.context.Flush(hookName, err)
 }
 
@@ -258,20 +315,68 @@ func (runner *runner) runCharmHook(hookName string, env []string, charmLocation
 	if err == nil {
 		// Record the *os.Process of the hook
 		runner.context.SetProcess(hookProcess{ps.Process})
+		// Kill the process if the action it runs is cancelled or
+		// times out.
+		stop := runner.watchAction(func() {
+			if err := ps.Process.Kill(); err != nil {
+				logger.Warningf("cannot kill process %v: %v", ps.Process.Pid, err)
+			}
+		})
 		// Block until execution finishes
 		err = ps.Wait()
+		stop()
 	}
 	hookLogger.stop()
 	return errors.Trace(err)
 }
 
-func (runner *runner) startJujucServer() (*jujuc.Server, error) {
+// watchAction calls kill if the action being run is cancelled, or runs
+// for longer than its timeout. The returned function must be called once
+// the action's process has exited. If the runner is not running an
+// action, watchAction does nothing.
+func (runner *runner) watchAction(kill func()) (stop func()) {
+	actionData, err := runner.context.ActionData()
+	if err != nil || (actionData.Cancel == nil && actionData.Timeout == 0) {
+		return func() {}
+	}
+	var timedOut <-chan time.Time
+	if actionData.Timeout > 0 {
+		timedOut = clock.WallClock.After(actionData.Timeout)
+	}
+	done := make(chan struct{})
+	exited := make(chan struct{})
+	go func() {
+		defer close(exited)
+		select {
+		case <-actionData.Cancel:
+			logger.Infof("action %q cancelled", actionData.Name)
+		case <-timedOut:
+			logger.Infof("action %q timed out after %v", actionData.Name, actionData.Timeout)
+			actionData.TimedOut = true
+		case <-done:
+			return
+		}
+		kill()
+	}()
+	return func() {
+		close(done)
+		<-exited
+	}
+}
+
+// startJujucServer starts serving hook tools for the runner's context.
+// If toolCalls is not nil, each hook tool invocation is recorded in it.
+func (runner *runner) startJujucServer(toolCalls *toolCallRecorder) (*jujuc.Server, error) {
 	// Prepare server.
 	getCmd := func(ctxId, cmdName string) (cmd.Command, error) {
 		if ctxId != runner.context.Id() {
 			return nil, errors.Errorf("expected context id %q, got %q", runner.context.Id(), ctxId)
 		}
-		return jujuc.NewCommand(runner.context, cmdName)
+		command, err := jujuc.NewCommand(runner.context, cmdName)
+		if err != nil || toolCalls == nil {
+			return command, err
+		}
+		return toolCalls.wrap(cmdName, command), nil
 	}
 	srv, err := jujuc.NewServer(getCmd, runner.paths.GetJujucSocket())
 	if err != nil {
@@ -19,6 +19,7 @@ import (
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable/hooks"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/runner"
 	"github.com/juju/juju/worker/uniter/runner/context"
@@ -155,6 +156,7 @@ type MockContext struct {
 	flushBadge      string
 	flushFailure    error
 	flushResult     error
+	executions      []params.HookExecution
 }
 
 func (ctx *MockContext) UnitName() string {
@@ -180,6 +182,10 @@ func (ctx *MockContext) Prepare() error {
 	return nil
 }
 
+func (ctx *MockContext) RecordExecution(execution params.HookExecution) {
+	ctx.executions = append(ctx.executions, execution)
+}
+
 func (ctx *MockContext) Flush(badge string, failure error) error {
 	ctx.flushBadge = badge
 	ctx.flushFailure = failure
@@ -217,6 +223,15 @@ func (s *RunMockContextSuite) assertRecordedPid(c *gc.C, expectPid int) {
 	c.Assert(strings.TrimRight(string(content), "\r\n"), gc.Equals, expectContent)
 }
 
+func (s *RunMockContextSuite) assertRecordedExecution(c *gc.C, ctx *MockContext, kind, name string, code int) {
+	c.Assert(ctx.executions, gc.HasLen, 1)
+	execution := ctx.executions[0]
+	c.Check(execution.Kind, gc.Equals, kind)
+	c.Check(execution.Name, gc.Equals, name)
+	c.Check(execution.ExitCode, gc.Equals, code)
+	c.Check(execution.Finished.Before(execution.Started), jc.IsFalse)
+}
+
 func (s *RunMockContextSuite) TestRunHookFlushSuccess(c *gc.C) {
 	expectErr := errors.New("pew pew pew")
 	ctx := &MockContext{
@@ -250,6 +265,7 @@ func (s *RunMockContextSuite) TestRunHookFlushFailure(c *gc.C) {
 	c.Assert(ctx.flushBadge, gc.Equals, "something-happened")
 	c.Assert(ctx.flushFailure, gc.ErrorMatches, "exit status 123")
 	s.assertRecordedPid(c, ctx.expectPid)
+	s.assertRecordedExecution(c, ctx, "hook", "something-happened", 123)
 }
 
 func (s *RunMockContextSuite) TestRunActionFlushSuccess(c *gc.C) {
@@ -268,6 +284,7 @@ func (s *RunMockContextSuite) TestRunActionFlushSuccess(c *gc.C) {
 	c.Assert(ctx.flushBadge, gc.Equals, "something-happened")
 	c.Assert(ctx.flushFailure, gc.IsNil)
 	s.assertRecordedPid(c, ctx.expectPid)
+	s.assertRecordedExecution(c, ctx, "action", "something-happened", 0)
 }
 
 func (s *RunMockContextSuite) TestRunActionFlushFailure(c *gc.C) {
@@ -315,6 +332,7 @@ func (s *RunMockContextSuite) TestRunActionSuccessful(c *gc.C) {
 	c.Assert(ctx.actionResults["Code"], gc.Equals, "0")
 	c.Assert(strings.TrimRight(ctx.actionResults["Stdout"].(string), "\r\n"), gc.Equals, "1")
 	c.Assert(ctx.actionResults["Stderr"], gc.Equals, "")
+	s.assertRecordedExecution(c, ctx, "action", "juju-run", 0)
 }
 
 func (s *RunMockContextSuite) TestRunActionCancelled(c *gc.C) {
@@ -336,6 +354,44 @@ func (s *RunMockContextSuite) TestRunActionCancelled(c *gc.C) {
 	c.Assert(ctx.actionResults["Stderr"], gc.Equals, nil)
 }
 
+func (s *RunMockContextSuite) TestRunActionAborted(c *gc.C) {
+	abort := make(chan struct{})
+	close(abort)
+	ctx := &MockContext{
+		actionData: &context.ActionData{Cancel: abort},
+		actionParams: map[string]interface{}{
+			"command": "sleep 10",
+			"timeout": float64(0),
+		},
+		actionResults: map[string]interface{}{},
+	}
+	t0 := time.Now()
+	err := runner.NewRunner(ctx, s.paths).RunAction("juju-run")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(time.Since(t0) < 5*time.Second, jc.IsTrue)
+	c.Assert(ctx.flushBadge, gc.Equals, "juju-run")
+	c.Assert(ctx.flushFailure, gc.Equals, exec.ErrCancelled)
+	c.Assert(ctx.actionResults["Code"], gc.Equals, nil)
+}
+
+func (s *RunMockContextSuite) TestRunActionTimedOut(c *gc.C) {
+	actionData := &context.ActionData{Timeout: 100 * time.Millisecond}
+	ctx := &MockContext{
+		actionData: actionData,
+		actionParams: map[string]interface{}{
+			"command": "sleep 10",
+			"timeout": float64(0),
+		},
+		actionResults: map[string]interface{}{},
+	}
+	t0 := time.Now()
+	err := runner.NewRunner(ctx, s.paths).RunAction("juju-run")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(time.Since(t0) < 5*time.Second, jc.IsTrue)
+	c.Assert(ctx.flushFailure, gc.Equals, exec.ErrCancelled)
+	c.Assert(actionData.TimedOut, jc.IsTrue)
+}
+
 func (s *RunMockContextSuite) TestRunCommandsFlushSuccess(c *gc.C) {
 	expectErr := errors.New("pew pew pew")
 	ctx := &MockContext{
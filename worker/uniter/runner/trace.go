@@ -0,0 +1,127 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package runner
+
+import (
+	"os/exec"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+// maxRecordedToolCalls is the number of hook tool calls recorded for
+// each hook or action execution. Later calls are only counted, so that
+// a hook calling tools in a loop does not grow the record without
+// bound.
+const maxRecordedToolCalls = 100
+
+// toolCallRecorder collects the hook tools invoked while a hook or
+// action runs, so they can be reported alongside the execution.
+type toolCallRecorder struct {
+	clock clock.Clock
+
+	mu      sync.Mutex
+	calls   []params.HookToolCall
+	dropped int
+}
+
+func newToolCallRecorder(clock clock.Clock) *toolCallRecorder {
+	return &toolCallRecorder{clock: clock}
+}
+
+// wrap returns a command which runs the supplied hook tool, recording
+// its name, duration and exit code.
+func (r *toolCallRecorder) wrap(name string, command cmd.Command) cmd.Command {
+	return &timedCommand{
+		Command:  command,
+		name:     strings.TrimSuffix(name, jujuc.CmdSuffix),
+		recorder: r,
+	}
+}
+
+func (r *toolCallRecorder) add(call params.HookToolCall) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.calls) >= maxRecordedToolCalls {
+		r.dropped++
+		return
+	}
+	r.calls = append(r.calls, call)
+}
+
+// recorded returns the hook tool calls recorded so far, in the order
+// they finished, and the number of later calls which were not
+// recorded.
+func (r *toolCallRecorder) recorded() ([]params.HookToolCall, int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]params.HookToolCall(nil), r.calls...), r.dropped
+}
+
+// timedCommand wraps a hook tool, timing it from Init until Run
+// returns. The exit codes recorded match those cmd.Main returns.
+type timedCommand struct {
+	cmd.Command
+	name     string
+	recorder *toolCallRecorder
+	started  time.Time
+}
+
+// Init is part of the cmd.Command interface.
+func (c *timedCommand) Init(args []string) error {
+	c.started = c.recorder.clock.Now()
+	if err := c.Command.Init(args); err != nil {
+		c.finish(2)
+		return err
+	}
+	return nil
+}
+
+// Run is part of the cmd.Command interface.
+func (c *timedCommand) Run(ctx *cmd.Context) error {
+	err := c.Command.Run(ctx)
+	code := 0
+	if cmd.IsRcPassthroughError(err) {
+		code = err.(*cmd.RcPassthroughError).Code
+	} else if err != nil {
+		code = 1
+	}
+	c.finish(code)
+	return err
+}
+
+func (c *timedCommand) finish(code int) {
+	c.recorder.add(params.HookToolCall{
+		Name:     c.name,
+		Started:  c.started,
+		Duration: c.recorder.clock.Now().Sub(c.started),
+		ExitCode: code,
+	})
+}
+
+// exitCode returns the exit code of a hook process given the error
+// returned when waiting for it, or -1 if the process did not exit
+// normally.
+func exitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	exitErr, ok := errors.Cause(err).(*exec.ExitError)
+	if !ok {
+		return -1
+	}
+	status, ok := exitErr.Sys().(syscall.WaitStatus)
+	if !ok || !status.Exited() {
+		return -1
+	}
+	return status.ExitStatus()
+}
@@ -0,0 +1,118 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package runner_test
+
+import (
+	"os/exec"
+	"runtime"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/runner"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type TraceSuite struct {
+	testing.IsolationSuite
+	clock *testing.Clock
+}
+
+var _ = gc.Suite(&TraceSuite{})
+
+var traceStart = time.Date(2017, time.June, 1, 10, 0, 0, 0, time.UTC)
+
+func (s *TraceSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(traceStart)
+}
+
+type slowCommand struct {
+	cmd.CommandBase
+	clock   *testing.Clock
+	initErr error
+	runErr  error
+}
+
+func (c *slowCommand) Info() *cmd.Info {
+	return &cmd.Info{Name: "slow"}
+}
+
+func (c *slowCommand) Init(args []string) error {
+	return c.initErr
+}
+
+func (c *slowCommand) Run(*cmd.Context) error {
+	c.clock.Advance(250 * time.Millisecond)
+	return c.runErr
+}
+
+func (s *TraceSuite) TestRecordsToolCall(c *gc.C) {
+	for i, test := range []struct {
+		initErr error
+		runErr  error
+		code    int
+		elapsed time.Duration
+	}{{
+		elapsed: 250 * time.Millisecond,
+	}, {
+		runErr:  errors.New("boom"),
+		code:    1,
+		elapsed: 250 * time.Millisecond,
+	}, {
+		runErr:  cmd.NewRcPassthroughError(42),
+		code:    42,
+		elapsed: 250 * time.Millisecond,
+	}, {
+		initErr: errors.New("bad args"),
+		code:    2,
+	}} {
+		c.Logf("test %d", i)
+		s.clock = testing.NewClock(traceStart)
+		command := &slowCommand{
+			clock:   s.clock,
+			initErr: test.initErr,
+			runErr:  test.runErr,
+		}
+		code, calls := runner.RunTimedCommand(
+			s.clock, "status-set"+jujuc.CmdSuffix, command, coretesting.Context(c), nil,
+		)
+		c.Check(code, gc.Equals, test.code)
+		c.Check(calls, jc.DeepEquals, []params.HookToolCall{{
+			Name:     "status-set",
+			Started:  traceStart,
+			Duration: test.elapsed,
+			ExitCode: test.code,
+		}})
+	}
+}
+
+func (s *TraceSuite) TestRecordsFirstToolCalls(c *gc.C) {
+	command := &slowCommand{clock: s.clock}
+	calls, dropped := runner.RunTimedCommandRepeatedly(
+		s.clock, "status-set"+jujuc.CmdSuffix, command, coretesting.Context(c), runner.MaxRecordedToolCalls+5,
+	)
+	c.Assert(calls, gc.HasLen, runner.MaxRecordedToolCalls)
+	c.Check(calls[0].Started, gc.Equals, traceStart)
+	c.Check(calls[runner.MaxRecordedToolCalls-1].Started, gc.Equals,
+		traceStart.Add(time.Duration(runner.MaxRecordedToolCalls-1)*250*time.Millisecond))
+	c.Check(dropped, gc.Equals, 5)
+}
+
+func (s *TraceSuite) TestExitCode(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("exit codes are tested using /bin/sh")
+	}
+	c.Check(runner.ExitCode(nil), gc.Equals, 0)
+	c.Check(runner.ExitCode(errors.New("cannot start hook")), gc.Equals, -1)
+
+	err := exec.Command("/bin/sh", "-c", "exit 7").Run()
+	c.Check(runner.ExitCode(errors.Trace(err)), gc.Equals, 7)
+}
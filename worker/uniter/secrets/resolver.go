@@ -0,0 +1,64 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package secrets provides the resolver that runs the secret-changed
+// hook when a secret granted to the unit's application is rotated.
+package secrets
+
+import (
+	"sort"
+
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/worker/uniter/hook"
+	"github.com/juju/juju/worker/uniter/operation"
+	"github.com/juju/juju/worker/uniter/remotestate"
+	"github.com/juju/juju/worker/uniter/resolver"
+)
+
+var logger = loggo.GetLogger("juju.worker.uniter.secrets")
+
+type secretsResolver struct{}
+
+// NewResolver returns a new resolver that runs a secret-changed hook
+// for each granted secret whose revision differs from the revision last
+// seen by the unit. A newly granted secret is treated as changed.
+func NewResolver() resolver.Resolver {
+	return &secretsResolver{}
+}
+
+// NextOp is defined on the Resolver interface.
+func (r *secretsResolver) NextOp(
+	localState resolver.LocalState,
+	remoteState remotestate.Snapshot,
+	opFactory operation.Factory,
+) (operation.Operation, error) {
+	if !localState.Installed || localState.Kind != operation.Continue {
+		return nil, resolver.ErrNoOperation
+	}
+	if remoteState.Life != params.Alive {
+		return nil, resolver.ErrNoOperation
+	}
+
+	// Visit the secrets in name order so that hooks run in a
+	// predictable order when several secrets change at once.
+	names := make([]string, 0, len(remoteState.SecretRevisions))
+	for name := range remoteState.SecretRevisions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		revision := remoteState.SecretRevisions[name]
+		if localState.SecretRevisions[name] == revision {
+			continue
+		}
+		logger.Debugf("secret %q changed to revision %d", name, revision)
+		return opFactory.NewRunHook(hook.Info{
+			Kind:           hook.SecretChanged,
+			SecretName:     name,
+			SecretRevision: revision,
+		})
+	}
+	return nil, resolver.ErrNoOperation
+}
@@ -0,0 +1,112 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/worker/uniter/hook"
+	"github.com/juju/juju/worker/uniter/operation"
+	"github.com/juju/juju/worker/uniter/remotestate"
+	"github.com/juju/juju/worker/uniter/resolver"
+	"github.com/juju/juju/worker/uniter/secrets"
+)
+
+type secretsSuite struct{}
+
+var _ = gc.Suite(&secretsSuite{})
+
+func (s *secretsSuite) localState(revisions map[string]int) resolver.LocalState {
+	return resolver.LocalState{
+		State: operation.State{
+			Installed:       true,
+			Kind:            operation.Continue,
+			SecretRevisions: revisions,
+		},
+	}
+}
+
+func (s *secretsSuite) remoteState(revisions map[string]int) remotestate.Snapshot {
+	return remotestate.Snapshot{
+		Life:            params.Alive,
+		SecretRevisions: revisions,
+	}
+}
+
+func (s *secretsSuite) TestNoSecrets(c *gc.C) {
+	_, err := secrets.NewResolver().NextOp(s.localState(nil), s.remoteState(nil), &mockOperations{})
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+}
+
+func (s *secretsSuite) TestUpToDate(c *gc.C) {
+	revisions := map[string]int{"db-password": 2}
+	_, err := secrets.NewResolver().NextOp(s.localState(revisions), s.remoteState(revisions), &mockOperations{})
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+}
+
+func (s *secretsSuite) TestSecretChanged(c *gc.C) {
+	localState := s.localState(map[string]int{"api-token": 1, "db-password": 1})
+	remoteState := s.remoteState(map[string]int{"api-token": 1, "db-password": 2})
+	op, err := secrets.NewResolver().NextOp(localState, remoteState, &mockOperations{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op, jc.DeepEquals, mockOp(hook.Info{
+		Kind:           hook.SecretChanged,
+		SecretName:     "db-password",
+		SecretRevision: 2,
+	}))
+}
+
+func (s *secretsSuite) TestSecretGranted(c *gc.C) {
+	remoteState := s.remoteState(map[string]int{"db-password": 3, "api-token": 1})
+	op, err := secrets.NewResolver().NextOp(s.localState(nil), remoteState, &mockOperations{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op, jc.DeepEquals, mockOp(hook.Info{
+		Kind:           hook.SecretChanged,
+		SecretName:     "api-token",
+		SecretRevision: 1,
+	}))
+}
+
+func (s *secretsSuite) TestNotInstalled(c *gc.C) {
+	localState := s.localState(nil)
+	localState.Installed = false
+	remoteState := s.remoteState(map[string]int{"db-password": 1})
+	_, err := secrets.NewResolver().NextOp(localState, remoteState, &mockOperations{})
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+}
+
+func (s *secretsSuite) TestHookPending(c *gc.C) {
+	localState := s.localState(nil)
+	localState.Kind = operation.RunHook
+	localState.Step = operation.Pending
+	remoteState := s.remoteState(map[string]int{"db-password": 1})
+	_, err := secrets.NewResolver().NextOp(localState, remoteState, &mockOperations{})
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+}
+
+func (s *secretsSuite) TestDying(c *gc.C) {
+	remoteState := s.remoteState(map[string]int{"db-password": 1})
+	remoteState.Life = params.Dying
+	_, err := secrets.NewResolver().NextOp(s.localState(nil), remoteState, &mockOperations{})
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+}
+
+type mockOperations struct {
+	operation.Factory
+}
+
+func (m *mockOperations) NewRunHook(info hook.Info) (operation.Operation, error) {
+	return mockOp(info), nil
+}
+
+func mockOp(info hook.Info) operation.Operation {
+	return &mockOperation{info: info}
+}
+
+type mockOperation struct {
+	operation.Operation
+	info hook.Info
+}
@@ -249,6 +249,122 @@ func (s *attachmentsSuite) TestAttachmentsStorage(c *gc.C) {
 	c.Assert(ctx.Location(), gc.Equals, "/dev/sdb")
 }
 
+func (s *attachmentsSuite) TestAttachmentsStorageResized(c *gc.C) {
+	stateDir := c.MkDir()
+	unitTag := names.NewUnitTag("mysql/0")
+	abort := make(chan struct{})
+
+	st := &mockStorageAccessor{
+		unitStorageAttachments: func(u names.UnitTag) ([]params.StorageAttachmentId, error) {
+			return nil, nil
+		},
+	}
+
+	att, err := storage.NewAttachments(st, unitTag, stateDir, abort)
+	c.Assert(err, jc.ErrorIsNil)
+	r := storage.NewResolver(att)
+
+	storageTag := names.NewStorageTag("data/0")
+	localState := resolver.LocalState{State: operation.State{
+		Kind: operation.Continue,
+	}}
+	nextOp := func(size uint64) (operation.Operation, error) {
+		return r.NextOp(localState, remotestate.Snapshot{
+			Life: params.Alive,
+			Storage: map[names.StorageTag]remotestate.StorageSnapshot{
+				storageTag: {
+					Kind:     params.StorageKindFilesystem,
+					Life:     params.Alive,
+					Location: "/srv/data",
+					Attached: true,
+					Size:     size,
+				},
+			},
+		}, &mockOperations{})
+	}
+
+	op, err := nextOp(1024)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run hook storage-attached")
+	err = att.CommitHook(hook.Info{
+		Kind:        hooks.StorageAttached,
+		StorageId:   storageTag.Id(),
+		StorageSize: 1024,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// No hook is run while the size is unchanged.
+	_, err = nextOp(1024)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+
+	op, err = nextOp(2048)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run hook storage-resized")
+	err = att.CommitHook(hook.Info{
+		Kind:        hook.StorageResized,
+		StorageId:   storageTag.Id(),
+		StorageSize: 2048,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = nextOp(2048)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+}
+
+func (s *attachmentsSuite) TestAttachmentsStorageSizeNotRecorded(c *gc.C) {
+	stateDir := c.MkDir()
+	unitTag := names.NewUnitTag("mysql/0")
+	abort := make(chan struct{})
+
+	// Storage attached by an older agent has no size recorded.
+	storageTag := names.NewStorageTag("data/0")
+	stateFile := filepath.Join(stateDir, "data-0")
+	err := ioutil.WriteFile(stateFile, []byte("attached: true\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	st := &mockStorageAccessor{
+		unitStorageAttachments: func(u names.UnitTag) ([]params.StorageAttachmentId, error) {
+			return []params.StorageAttachmentId{{
+				StorageTag: storageTag.String(),
+				UnitTag:    unitTag.String(),
+			}}, nil
+		},
+		storageAttachment: func(s names.StorageTag, u names.UnitTag) (params.StorageAttachment, error) {
+			return params.StorageAttachment{
+				Kind:     params.StorageKindFilesystem,
+				Location: "/srv/data",
+				Size:     1024,
+			}, nil
+		},
+	}
+
+	att, err := storage.NewAttachments(st, unitTag, stateDir, abort)
+	c.Assert(err, jc.ErrorIsNil)
+	r := storage.NewResolver(att)
+
+	localState := resolver.LocalState{State: operation.State{
+		Kind: operation.Continue,
+	}}
+	_, err = r.NextOp(localState, remotestate.Snapshot{
+		Life: params.Alive,
+		Storage: map[names.StorageTag]remotestate.StorageSnapshot{
+			storageTag: {
+				Kind:     params.StorageKindFilesystem,
+				Life:     params.Alive,
+				Location: "/srv/data",
+				Attached: true,
+				Size:     1024,
+			},
+		},
+	}, &mockOperations{})
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+
+	// The current size is recorded as the baseline.
+	data, err := ioutil.ReadFile(stateFile)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "attached: true\nsize: 1024\n")
+}
+
 func (s *attachmentsSuite) TestAttachmentsCommitHook(c *gc.C) {
 	stateDir := c.MkDir()
 	unitTag := names.NewUnitTag("mysql/0")
@@ -39,6 +39,7 @@ import (
 	"github.com/juju/juju/worker/uniter/runner"
 	"github.com/juju/juju/worker/uniter/runner/context"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
+	"github.com/juju/juju/worker/uniter/secrets"
 	"github.com/juju/juju/worker/uniter/storage"
 )
 
@@ -295,6 +296,7 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 			Commands: runcommands.NewCommandsResolver(
 				u.commands, watcher.CommandCompleted,
 			),
+			Secrets: secrets.NewResolver(),
 		})
 
 		// We should not do anything until there has been a change
@@ -569,6 +571,9 @@ func (u *Uniter) reportHookError(hookInfo hook.Info) error {
 		if hookInfo.RemoteUnit != "" {
 			statusData["remote-unit"] = hookInfo.RemoteUnit
 		}
+		if hookInfo.RemoteApplication != "" {
+			statusData["remote-application"] = hookInfo.RemoteApplication
+		}
 		relationName, err := u.relations.Name(hookInfo.RelationId)
 		if err != nil {
 			return errors.Trace(err)